@@ -0,0 +1,284 @@
+/*
+** 2005 February 15
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains C code routines that used to generate VDBE code
+** that implements the ALTER TABLE command.
+ */
+package internal
+
+/*
+** The Go port does not generate code for an ALTER TABLE statement.
+** Instead the parse tree is recorded in one of these objects and attached
+** to the Stmt.
+ */
+type Alter struct {
+	op   uint8    /* TK_RENAME, TK_ADD or TK_DROP */
+	pSrc *SrcList /* The table being altered.  pSrc->nSrc==1 */
+	zOld []byte   /* Column renamed or dropped.  NULL for the others */
+	zNew []byte   /* New name of the table or column, or NULL */
+	pNew *Table   /* For ADD COLUMN, a table whose only column is new */
+}
+
+/*
+** Parameter zName is the name of a table that is about to be altered
+** (either with ALTER TABLE ... RENAME TO or ALTER TABLE ... ADD COLUMN).
+** If the table is a system table, this function leaves an error message
+** in pParse->zErr (system tables may not be altered) and returns non-zero.
+**
+** Or, if zName is not a system table, zero is returned.
+ */
+func isAlterableTable(pParse *Parse, zName []byte) int {
+	if sqlite3_strnicmp(zName, []byte("sqlite_"), 7) == 0 {
+		sqlite3ErrorMsg(pParse, "table %s may not be altered", zName)
+		return 1
+	}
+	return 0
+}
+
+/*
+** Record the ALTER TABLE statement p in pParse, unless an error has
+** been seen.
+ */
+func alterRecord(pParse *Parse, p *Alter) {
+	if pParse.nErr != 0 {
+		return
+	}
+	assert(p.pSrc.nSrc == 1, "p.pSrc.nSrc == 1")
+	pParse.pAlter = p
+}
+
+/*
+** Generate code to implement the "ALTER TABLE xxx RENAME TO yyy"
+** command.
+**
+** The port has no schema, so only the names are checked.
+ */
+func sqlite3AlterRenameTable(
+	pParse *Parse, /* Parser context. */
+	pSrc *SrcList, /* The table to rename. */
+	pName *Token, /* The new table name. */
+) {
+	var zName []byte /* NULL-terminated version of pName */
+	db := pParse.db
+
+	if sqlite3IsOmitted(pParse, OmitAlterTable, "ALTER TABLE") {
+		goto exit_rename_table
+	}
+	if isAlterableTable(pParse, pSrc.a[0].zName) != 0 {
+		goto exit_rename_table
+	}
+
+	/* Get a NULL terminated version of the new table name. */
+	zName = sqlite3NameFromToken(db, pName)
+	if zName == nil {
+		goto exit_rename_table
+	}
+
+	/* Make sure it is not a system table being altered, or a reserved name
+	 ** that the table is being renamed to.
+	 */
+	if sqlite3CheckObjectName(pParse, zName, "table", zName) != SQLITE_OK {
+		goto exit_rename_table
+	}
+	alterRecord(pParse, &Alter{op: TK_RENAME, pSrc: pSrc, zNew: zName})
+	return
+
+exit_rename_table:
+	sqlite3SrcListDelete(db, pSrc)
+}
+
+/*
+** This function is called after an "ALTER TABLE ... ADD" statement
+** has been parsed. Argument pColDef contains the text of the new
+** column definition.
+**
+** The table pParse->pNewTable was created by sqlite3AlterBeginAddColumn().
+** Its only column is the new one, with the constraints that came with it.
+ */
+func sqlite3AlterFinishAddColumn(pParse *Parse, pColDef *Token) {
+	var pNew *Table /* Copy of pParse->pNewTable */
+	var pCol *Column
+
+	UNUSED_PARAMETER(pColDef)
+	pNew = pParse.pNewTable
+	if pParse.nErr != 0 || pNew == nil || pParse.pAlter == nil {
+		return
+	}
+	pParse.pNewTable = nil
+	if pNew.nCol != 1 {
+		return
+	}
+	pCol = &pNew.aCol[pNew.nCol-1]
+
+	/* The port does not record DEFAULT clauses, so the checks that
+	 ** depend on the default value of the new column are not made.
+	 */
+	if (pCol.colFlags & COLFLAG_PRIMKEY) != 0 {
+		sqlite3ErrorMsg(pParse, "Cannot add a PRIMARY KEY column")
+		return
+	}
+	if pNew.pIndex != nil {
+		sqlite3ErrorMsg(pParse, "Cannot add a UNIQUE column")
+		return
+	}
+	pParse.pAlter.pNew = pNew
+}
+
+/*
+** This function is called by the parser after the table-name in
+** an "ALTER TABLE <table-name> ADD" statement is parsed. Argument
+** pSrc is the full-name of the table being altered.
+**
+** This routine makes a (partial) copy of the Table structure
+** for the table being altered and sets Parse.pNewTable to point
+** to it. Routines called by the parser as the column definition
+** is parsed (i.e. sqlite3AddColumn()) add the new Column data to
+** the copy. The copy of the Table structure is deleted by tokenize.c
+** after parsing is finished.
+**
+** The port has no schema, so the copy starts without any columns.
+ */
+func sqlite3AlterBeginAddColumn(pParse *Parse, pSrc *SrcList) {
+	var pNew *Table
+	db := pParse.db
+
+	if sqlite3IsOmitted(pParse, OmitAlterTable, "ALTER TABLE") {
+		goto exit_begin_add_column
+	}
+	assert(pParse.pNewTable == nil, "pParse.pNewTable == nil")
+	if isAlterableTable(pParse, pSrc.a[0].zName) != 0 {
+		goto exit_begin_add_column
+	}
+
+	pNew = &Table{}
+	pNew.zName = sqlite3DbStrDup(db, pSrc.a[0].zName)
+	pNew.iPKey = -1
+	pNew.nTabRef = 1
+	pParse.pNewTable = pNew
+	alterRecord(pParse, &Alter{op: TK_ADD, pSrc: pSrc})
+	return
+
+exit_begin_add_column:
+	sqlite3SrcListDelete(db, pSrc)
+}
+
+/*
+** Handles the following parser reduction:
+**
+**  cmd ::= ALTER TABLE pSrc RENAME COLUMN pOld TO pNew
+ */
+func sqlite3AlterRenameColumn(
+	pParse *Parse, /* Parsing context */
+	pSrc *SrcList, /* Table being altered.  pSrc->nSrc==1 */
+	pOld *Token, /* Name of column being changed */
+	pNew *Token, /* New column name */
+) {
+	var zOld []byte /* Old column name */
+	var zNew []byte /* New column name */
+	db := pParse.db
+
+	if sqlite3IsOmitted(pParse, OmitAlterTable, "ALTER TABLE") {
+		goto exit_rename_column
+	}
+	if isAlterableTable(pParse, pSrc.a[0].zName) != 0 {
+		goto exit_rename_column
+	}
+	zOld = sqlite3NameFromToken(db, pOld)
+	zNew = sqlite3NameFromToken(db, pNew)
+	if zOld == nil || zNew == nil {
+		goto exit_rename_column
+	}
+	alterRecord(pParse, &Alter{op: TK_RENAME, pSrc: pSrc, zOld: zOld, zNew: zNew})
+	return
+
+exit_rename_column:
+	sqlite3SrcListDelete(db, pSrc)
+}
+
+/*
+** This function is called by the parser upon parsing an
+**
+**     ALTER TABLE pSrc DROP COLUMN pName
+**
+** statement. Argument pSrc contains the possibly qualified name of the
+** table being edited, and token pName the name of the column to drop.
+ */
+func sqlite3AlterDropColumn(pParse *Parse, pSrc *SrcList, pName *Token) {
+	var zCol []byte /* Name of column to drop */
+	db := pParse.db
+
+	if sqlite3IsOmitted(pParse, OmitAlterTable, "ALTER TABLE") {
+		goto exit_drop_column
+	}
+	if isAlterableTable(pParse, pSrc.a[0].zName) != 0 {
+		goto exit_drop_column
+	}
+
+	/* Get the name of the column being dropped. */
+	zCol = sqlite3NameFromToken(db, pName)
+	if zCol == nil {
+		goto exit_drop_column
+	}
+	alterRecord(pParse, &Alter{op: TK_DROP, pSrc: pSrc, zOld: zCol})
+	return
+
+exit_drop_column:
+	sqlite3SrcListDelete(db, pSrc)
+}
+
+/*
+** The following structure is used to record the locations of identifiers
+** that may need to be rewritten by an ALTER TABLE ... RENAME statement.
+** Each RenameToken maps a pointer to a parse tree object (an Expr,
+** a column name, a table name, ...) onto the token that produced it.
+ */
+type RenameToken struct {
+	p     interface{}  /* Parse tree element created by token t */
+	t     Token        /* The token that created parse tree element p */
+	pNext *RenameToken /* Next is a list of all RenameToken objects */
+}
+
+/*
+** Remember that the parser tree element pPtr was created using
+** the token pToken.
+**
+** In other words, construct a new RenameToken object and add it
+** to the list of RenameToken objects currently being built up
+** in pParse->pRename.
+**
+** The pPtr argument is returned so that this routine can be used
+** with tail recursion in tokenExpr() routine, for a small performance
+** improvement.
+ */
+func sqlite3RenameTokenMap(pParse *Parse, pPtr interface{}, pToken *Token) interface{} {
+	if ALWAYS(pParse.eParseMode != PARSE_MODE_UNMAP) {
+		pNew := &RenameToken{}
+		pNew.p = pPtr
+		pNew.t = *pToken
+		pNew.pNext = pParse.pRename
+		pParse.pRename = pNew
+	}
+	return pPtr
+}
+
+/*
+** It is assumed that there is already a RenameToken object associated
+** with parse tree element pFrom. This function remaps the associated token
+** to parse tree element pTo.
+ */
+func sqlite3RenameTokenRemap(pParse *Parse, pTo interface{}, pFrom interface{}) {
+	for p := pParse.pRename; p != nil; p = p.pNext {
+		if p.p == pFrom {
+			p.p = pTo
+			break
+		}
+	}
+}
@@ -0,0 +1,108 @@
+/*
+** 2003 April 6
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains code used to implement the ATTACH and DETACH commands.
+ */
+package internal
+
+/*
+** The Go port does not attach databases.  Instead the parse tree of an
+** ATTACH or DETACH statement is recorded in one of these objects and
+** attached to the Stmt.
+ */
+type Attach struct {
+	op        uint8 /* TK_ATTACH or TK_DETACH */
+	pFilename *Expr /* Name of the database file.  NULL for DETACH */
+	pDbname   *Expr /* Name of the database */
+	pKey      *Expr /* Encryption key, or NULL */
+}
+
+/*
+** This procedure generates VDBE code for a single invocation of either the
+** sqlite_detach() or sqlite_attach() SQL user functions.
+**
+** The arguments are resolved as in C.  An identifier is taken to be a
+** string, so that "ATTACH x AS y" names the file "x".  The statement is
+** then recorded in pParse->pAttach.
+ */
+func codeAttach(
+	pParse *Parse, /* The parser context */
+	op uint8, /* Either TK_ATTACH or TK_DETACH */
+	pFilename *Expr, /* The database filename.  NULL for DETACH */
+	pDbname *Expr, /* The name of the database */
+	pKey *Expr, /* Database key for encryption extension */
+) {
+	var p *Attach
+	var sName NameContext
+
+	if pParse.nErr != 0 {
+		goto attach_end
+	}
+	sName.pParse = pParse
+	if resolveAttachExpr(&sName, pFilename) != SQLITE_OK ||
+		resolveAttachExpr(&sName, pDbname) != SQLITE_OK ||
+		resolveAttachExpr(&sName, pKey) != SQLITE_OK {
+		goto attach_end
+	}
+	p = &Attach{}
+	p.op = op
+	p.pFilename = pFilename
+	p.pDbname = pDbname
+	p.pKey = pKey
+	pParse.pAttach = p
+	return
+
+attach_end:
+	sqlite3ExprDelete(pParse.db, pFilename)
+	sqlite3ExprDelete(pParse.db, pDbname)
+	sqlite3ExprDelete(pParse.db, pKey)
+}
+
+/*
+** This routine is used to resolve the expression arguments of ATTACH
+** and DETACH.  An identifier is converted into a string.  Any other
+** expression is resolved with no tables in scope.
+ */
+func resolveAttachExpr(pName *NameContext, pExpr *Expr) int {
+	rc := SQLITE_OK
+	if pExpr != nil {
+		if pExpr.op != TK_ID {
+			rc = sqlite3ResolveExprNames(pName, pExpr)
+		} else {
+			pExpr.op = TK_STRING
+		}
+	}
+	return rc
+}
+
+/*
+** Called by the parser to compile an ATTACH statement.
+**
+**     ATTACH p AS pDbname KEY pKey
+ */
+func sqlite3Attach(pParse *Parse, p *Expr, pDbname *Expr, pKey *Expr) {
+	if sqlite3IsOmitted(pParse, OmitAttach, "ATTACH") {
+		return
+	}
+	codeAttach(pParse, TK_ATTACH, p, pDbname, pKey)
+}
+
+/*
+** Called by the parser to compile a DETACH statement.
+**
+**     DETACH pDbname
+ */
+func sqlite3Detach(pParse *Parse, pDbname *Expr) {
+	if sqlite3IsOmitted(pParse, OmitAttach, "DETACH") {
+		return
+	}
+	codeAttach(pParse, TK_DETACH, nil, pDbname, nil)
+}
@@ -0,0 +1,1849 @@
+/*
+** 2001 September 15
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains C code routines that are called by the SQLite parser
+** when syntax rules are reduced.  The routines in this file handle the
+** following kinds of SQL syntax:
+**
+**     CREATE TABLE
+**     DROP TABLE
+**     CREATE INDEX
+**     DROP INDEX
+**     creating ID lists
+**     BEGIN TRANSACTION
+**     COMMIT
+**     ROLLBACK
+ */
+package internal
+
+/*
+** This routine is called after a single SQL statement has been
+** parsed and a VDBE program to execute that statement has been
+** prepared.  This routine puts the finishing touches on the
+** VDBE program and resets the pParse structure for the next
+** parse.
+**
+** Note that if an error occurred, it might be the case that
+** no VDBE code was generated.
+**
+** The Go port generates no code.  Instead the finished statement is
+** recorded in pParse->pStmt and pParse->rc is set to SQLITE_DONE so
+** that sqlite3RunParser() stops after this statement.
+ */
+func sqlite3FinishCoding(pParse *Parse) {
+	var p *Stmt
+
+	if pParse.nested != 0 {
+		return
+	}
+	if pParse.nErr != 0 {
+		if pParse.rc == SQLITE_OK {
+			pParse.rc = SQLITE_ERROR
+		}
+		return
+	}
+	if pParse.explain != 0 && sqlite3IsOmitted(pParse, OmitExplain, "EXPLAIN") {
+		return
+	}
+
+	/* The statement runs from its first token up to, but not including,
+	 ** the token that caused this rule to reduce (the ";" or end of input).
+	 */
+	z := pParse.zStmt
+	n := len(z) - len(pParse.sLastToken.z)
+	for n > 0 && sqlite3Isspace(z[n-1]) {
+		n--
+	}
+	p = &Stmt{}
+	p.zSql = z[:n]
+	p.iOfst = len(pParse.zTail) - len(z)
+	p.explain = pParse.explain
+	p.pSelect = pParse.pSelect
+	p.pDelete = pParse.pDelete
+	p.pUpdate = pParse.pUpdate
+	p.pInsert = pParse.pInsert
+	p.pTable = pParse.pTable
+	p.pIndex = pParse.pIndex
+	p.pTrigger = pParse.pTrigger
+	p.pDrop = pParse.pDrop
+	p.pAlter = pParse.pAlter
+	p.pPragma = pParse.pPragma
+	p.pAttach = pParse.pAttach
+	p.aVersion = pParse.aVersion
+	pParse.pStmt = p
+	pParse.rc = SQLITE_DONE
+}
+
+/*
+** Given a token, return a string that consists of the text of that
+** token.  Space to hold the returned string
+** is obtained from sqliteMalloc() and must be freed by the calling
+** function.
+**
+** Any quotation marks (ex:  "name", 'name', [name], or `name`) that
+** surround the body of the token are removed.
+**
+** Tokens are often just pointers into the original SQL text and so
+** are not \000 terminated and are not persistent.  The returned string
+** is \000 terminated and is persistent.
+ */
+func sqlite3NameFromToken(db *sqlite3, pName *Token) []byte {
+	var zName []byte
+	if pName != nil {
+		zName = sqlite3DbStrNDup(db, pName.z, pName.n)
+		zName = sqlite3Dequote(zName)
+	}
+	return zName
+}
+
+/*
+** Scan the column type name zType (length nType) and return the
+** associated affinity type.
+**
+** This routine does a case-independent search of zType for the
+** substrings in the following table. If one of the substrings is
+** found, the corresponding affinity is returned. If zType contains
+** more than one of the substrings, entries toward the top of
+** the table take priority. For example, if zType is 'BLOBINT',
+** SQLITE_AFF_INTEGER is returned.
+**
+** Substring     | Affinity
+** --------------------------------
+** 'INT'         | SQLITE_AFF_INTEGER
+** 'CHAR'        | SQLITE_AFF_TEXT
+** 'CLOB'        | SQLITE_AFF_TEXT
+** 'TEXT'        | SQLITE_AFF_TEXT
+** 'BLOB'        | SQLITE_AFF_BLOB
+** 'REAL'        | SQLITE_AFF_REAL
+** 'FLOA'        | SQLITE_AFF_REAL
+** 'DOUB'        | SQLITE_AFF_REAL
+**
+** If none of the substrings in the above table are found,
+** SQLITE_AFF_NUMERIC is returned.
+ */
+func sqlite3AffinityType(zIn []byte, pCol *Column) rune {
+	var h uint32
+	var aff rune = SQLITE_AFF_NUMERIC
+	var zChar []byte
+
+	for len(zIn) > 0 {
+		h = (h << 8) + uint32(sqlite3UpperToLower[zIn[0]])
+		zIn = zIn[1:]
+		if h == (('c' << 24) + ('h' << 16) + ('a' << 8) + 'r') { /* CHAR */
+			aff = SQLITE_AFF_TEXT
+			zChar = zIn
+		} else if h == (('c' << 24) + ('l' << 16) + ('o' << 8) + 'b') { /* CLOB */
+			aff = SQLITE_AFF_TEXT
+		} else if h == (('t' << 24) + ('e' << 16) + ('x' << 8) + 't') { /* TEXT */
+			aff = SQLITE_AFF_TEXT
+		} else if h == (('b'<<24)+('l'<<16)+('o'<<8)+'b') && /* BLOB */
+			(aff == SQLITE_AFF_NUMERIC || aff == SQLITE_AFF_REAL) {
+			aff = SQLITE_AFF_BLOB
+			if len(zIn) > 0 && zIn[0] == '(' {
+				zChar = zIn
+			}
+		} else if h == (('r'<<24)+('e'<<16)+('a'<<8)+'l') && /* REAL */
+			aff == SQLITE_AFF_NUMERIC {
+			aff = SQLITE_AFF_REAL
+		} else if h == (('f'<<24)+('l'<<16)+('o'<<8)+'a') && /* FLOA */
+			aff == SQLITE_AFF_NUMERIC {
+			aff = SQLITE_AFF_REAL
+		} else if h == (('d'<<24)+('o'<<16)+('u'<<8)+'b') && /* DOUB */
+			aff == SQLITE_AFF_NUMERIC {
+			aff = SQLITE_AFF_REAL
+		} else if (h & 0x00FFFFFF) == (('i' << 16) + ('n' << 8) + 't') { /* INT */
+			aff = SQLITE_AFF_INTEGER
+			break
+		}
+	}
+
+	/* If pCol is not NULL, store an estimate of the field size.  The
+	 ** estimate is scaled so that the size of an integer is 1.  */
+	if pCol != nil {
+		v := 0 /* default size is approx 4 bytes */
+		if aff < SQLITE_AFF_NUMERIC {
+			if zChar != nil {
+				for len(zChar) > 0 {
+					if sqlite3Isdigit(zChar[0]) {
+						/* BLOB(k), VARCHAR(k), CHAR(k) -> r=(k/4+1) */
+						sqlite3GetInt32(zChar, &v)
+						break
+					}
+					zChar = zChar[1:]
+				}
+			} else {
+				v = 16 /* BLOB, TEXT, CLOB -> r=5  (approx 20 bytes)*/
+			}
+		}
+		v = v/4 + 1
+		if v > 255 {
+			v = 255
+		}
+		pCol.szEst = uint8(v)
+	}
+	return aff
+}
+
+/*
+** Make a copy of the first n bytes of z.  The copy does not share
+** storage with z, so it may be dequoted or kept after the SQL text is
+** released.
+ */
+func sqlite3DbStrNDup(db *sqlite3, z []byte, n uint) []byte {
+	if z == nil {
+		return nil
+	}
+	zNew := make([]byte, n)
+	copy(zNew, z[:n])
+	return zNew
+}
+
+/*
+** Make a copy of z.  A nil z yields nil.
+ */
+func sqlite3DbStrDup(db *sqlite3, z []byte) []byte {
+	if z == nil {
+		return nil
+	}
+	return sqlite3DbStrNDup(db, z, uint(len(z)))
+}
+
+/*
+** Make a copy of the text that runs from zStart up to, but not
+** including, zEnd.  zEnd must be a suffix of zStart.  Leading and
+** trailing whitespace is omitted from the copy.
+ */
+func sqlite3DbSpanDup(db *sqlite3, zStart []byte, zEnd []byte) []byte {
+	var n int
+	for len(zStart) > 0 && sqlite3Isspace(zStart[0]) {
+		zStart = zStart[1:]
+	}
+	n = len(zStart) - len(zEnd)
+	for ALWAYS(n > 0) && sqlite3Isspace(zStart[n-1]) {
+		n--
+	}
+	if n < 0 {
+		n = 0
+	}
+	return sqlite3DbStrNDup(db, zStart, uint(n))
+}
+
+/*
+** Add the RETURNING clause to the parse currently underway.
+**
+** The C version also creates a transient AFTER trigger on the table
+** being modified and uses it to code the clause.  Here the clause is
+** only recorded in pParse->u1.pReturning, where the INSERT, UPDATE or
+** DELETE that owns it picks it up.
+ */
+func sqlite3AddReturning(pParse *Parse, pList *ExprList) {
+	var pRet *Returning
+
+	if pParse.pNewTrigger != nil {
+		sqlite3ErrorMsg(pParse, "cannot use RETURNING in a trigger")
+	} else {
+		assert(pParse.bReturning == 0, "pParse.bReturning == 0")
+	}
+	pParse.bReturning = 1
+	pRet = &Returning{}
+	pParse.u1.pReturning = pRet
+	pRet.pParse = pParse
+	pRet.pReturnEL = pList
+}
+
+/*
+** Change the most recently parsed column to be a GENERATED ALWAYS AS
+** column.
+ */
+func sqlite3AddGenerated(pParse *Parse, pExpr *Expr, pType *Token) {
+	if sqlite3IsOmitted(pParse, OmitGeneratedColumns, "generated columns") {
+		return
+	}
+}
+
+/*
+** This routine is used to check if the UTF-8 string zName is a legal
+** unqualified name for a new schema object (table, index, view or
+** trigger).  All names are legal except those that begin with the string
+** "sqlite_" (in upper, lower or mixed case).  This portion of the namespace
+** is reserved for internal use.
+ */
+func sqlite3CheckObjectName(
+	pParse *Parse, /* Parsing context */
+	zName []byte, /* Name of the object to check */
+	zType string, /* Type of this object */
+	zTblName []byte, /* Parent table name for triggers and indexes */
+) int {
+	UNUSED_PARAMETER(zType)
+	UNUSED_PARAMETER(zTblName)
+	if pParse.nested == 0 && sqlite3_strnicmp(zName, []byte("sqlite_"), 7) == 0 {
+		sqlite3ErrorMsg(pParse, "object name reserved for internal use: %s", zName)
+		return SQLITE_ERROR
+	}
+	return SQLITE_OK
+}
+
+/*
+** Begin constructing a new table representation in memory.  This is
+** the first of several action routines that get called in response
+** to a CREATE TABLE statement.  In particular, this routine is called
+** after seeing tokens "CREATE" and "TABLE" and the table name. The isTemp
+** flag is true if the table should be stored in the auxiliary database
+** file instead of in the main database file.  This is normally the case
+** when the "TEMP" or "TEMPORARY" keyword occurs in between
+** CREATE and TABLE.
+**
+** The new table record is initialized and put in pParse->pNewTable.
+** As more of the CREATE TABLE statement is parsed, additional action
+** routines will be called to add more information to this record.
+** At the end of the CREATE TABLE statement, the sqlite3EndTable() routine
+** is called to complete the construction of the new table record.
+**
+** The port has no schema, so it cannot check whether a table or view
+** of the same name already exists, and noErr has no effect.
+ */
+func sqlite3StartTable(
+	pParse *Parse, /* Parser context */
+	pName1 *Token, /* First part of the name of the table or view */
+	pName2 *Token, /* Second part of the name of the table or view */
+	isTemp int, /* True if this is a TEMP table */
+	isView int, /* True if this is a VIEW */
+	isVirtual int, /* True if this is a VIRTUAL table */
+	noErr int, /* Do nothing if table already exists */
+) {
+	var pTable *Table
+	var zName []byte /* The name of the new table */
+	var pName *Token /* Unqualified name of the table to create */
+	db := pParse.db
+
+	UNUSED_PARAMETER(isVirtual)
+	UNUSED_PARAMETER(noErr)
+
+	/* The table or view name to create is passed to this routine via tokens
+	 ** pName1 and pName2. If the table name was fully qualified, for example:
+	 **
+	 ** CREATE TABLE xxx.yyy (...);
+	 **
+	 ** Then pName1 is set to "xxx" and pName2 "yyy". On the other hand if
+	 ** the table name is not fully qualified, i.e.:
+	 **
+	 ** CREATE TABLE yyy(...);
+	 **
+	 ** Then pName1 is set to "yyy" and pName2 is "".
+	 */
+	if pName2.n > 0 {
+		if isTemp != 0 && sqlite3StrICmp(sqlite3NameFromToken(db, pName1), []byte("temp")) != 0 {
+			/* If creating a temp table, the name may not be qualified. Unless
+			 ** the database name is "temp" anyway.  */
+			sqlite3ErrorMsg(pParse, "temporary table name must be unqualified")
+			return
+		}
+		pName = pName2
+	} else {
+		pName = pName1
+	}
+	zName = sqlite3NameFromToken(db, pName)
+	if zName == nil {
+		return
+	}
+	zType := "table"
+	if isView != 0 {
+		zType = "view"
+	}
+	if sqlite3CheckObjectName(pParse, zName, zType, zName) != 0 {
+		return
+	}
+
+	pTable = &Table{}
+	pTable.zName = zName
+	pTable.iPKey = -1
+	pTable.nTabRef = 1
+	assert(pParse.pNewTable == nil, "pParse.pNewTable == nil")
+	pParse.pNewTable = pTable
+}
+
+/*
+** Set the collating sequence name for a column.
+ */
+func sqlite3ColumnSetColl(db *sqlite3, pCol *Column, zColl []byte) {
+	n := sqlite3Strlen30(pCol.zCnName)
+	if (pCol.colFlags & COLFLAG_HASTYPE) != 0 {
+		n += 1 + sqlite3Strlen30(pCol.zCnName[n+1:])
+	}
+	zNew := append(pCol.zCnName[:n:n], 0)
+	zNew = append(zNew, zColl[:sqlite3Strlen30(zColl)]...)
+	pCol.zCnName = append(zNew, 0)
+	pCol.colFlags |= COLFLAG_HASCOLL
+}
+
+/*
+** Return the collating sequence name for a column
+ */
+func sqlite3ColumnColl(pCol *Column) []byte {
+	if (pCol.colFlags & COLFLAG_HASCOLL) == 0 {
+		return nil
+	}
+	z := pCol.zCnName[sqlite3Strlen30(pCol.zCnName)+1:]
+	if (pCol.colFlags & COLFLAG_HASTYPE) != 0 {
+		z = z[sqlite3Strlen30(z)+1:]
+	}
+	return z[:sqlite3Strlen30(z)]
+}
+
+/*
+** Return the index of a column in a table.  Return -1 if the column
+** is not contained in the table.
+ */
+func sqlite3ColumnIndex(pTab *Table, zCol []byte) int {
+	for i := 0; i < int(pTab.nCol); i++ {
+		if sqlite3StrICmp(pTab.aCol[i].zCnName, zCol) == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+/*
+** Add a new column to the table currently being constructed.
+**
+** The parser calls this routine once for each column declaration
+** in a CREATE TABLE statement.  sqlite3StartTable() gets called
+** first to get things going.  Then this routine is called for each
+** column.
+**
+** The port records the name of the column, its declared type and its
+** affinity.  As in C, a type that is not one of the standard types is
+** stored after the column name in zCnName.
+ */
+func sqlite3AddColumn(pParse *Parse, sName Token, sType Token) {
+	var p *Table
+	var z []byte
+	var pCol *Column
+	db := pParse.db
+	var eType uint8 = COLTYPE_CUSTOM
+	var szEst uint8 = 1
+	var affinity rune = SQLITE_AFF_BLOB
+
+	if p = pParse.pNewTable; p == nil {
+		return
+	}
+	if int(p.nCol)+1 > db.aLimit[SQLITE_LIMIT_COLUMN] {
+		sqlite3ErrorMsg(pParse, "too many columns on %s", p.zName)
+		return
+	}
+
+	/* Because keywords GENERATE ALWAYS can be converted into identifiers
+	 ** by the parser, we can sometimes end up with a typename that ends
+	 ** with "generated always".  Check for this case and omit the surplus
+	 ** text. */
+	if sType.n >= 16 &&
+		sqlite3_strnicmp(sType.z[sType.n-6:], []byte("always"), 6) == 0 {
+		sType.n -= 6
+		for sType.n > 0 && sqlite3Isspace(sType.z[sType.n-1]) {
+			sType.n--
+		}
+		if sType.n >= 9 &&
+			sqlite3_strnicmp(sType.z[sType.n-9:], []byte("generated"), 9) == 0 {
+			sType.n -= 9
+			for sType.n > 0 && sqlite3Isspace(sType.z[sType.n-1]) {
+				sType.n--
+			}
+		}
+	}
+
+	/* Check for standard typenames.  For standard typenames we will
+	 ** set the Column.eType field rather than storing the typename after
+	 ** the column name, in order to save space. */
+	if sType.n >= 3 {
+		zType := sqlite3NameFromToken(db, &sType)
+		for i := 0; i < SQLITE_N_STDTYPE; i++ {
+			if len(zType) == int(sqlite3StdTypeLen[i]) &&
+				sqlite3_strnicmp(zType, []byte(sqlite3StdType[i]), len(zType)) == 0 {
+				sType.n = 0
+				eType = uint8(i + 1)
+				affinity = sqlite3StdTypeAffinity[i]
+				if affinity <= SQLITE_AFF_TEXT {
+					szEst = 5
+				}
+				break
+			}
+		}
+	}
+
+	z = sqlite3NameFromToken(db, &sName)
+	if z == nil {
+		return
+	}
+	for i := 0; i < int(p.nCol); i++ {
+		if sqlite3StrICmp(z, p.aCol[i].zCnName) == 0 {
+			sqlite3ErrorMsg(pParse, "duplicate column name: %s", z)
+			return
+		}
+	}
+	p.aCol = append(p.aCol[:p.nCol], Column{})
+	pCol = &p.aCol[p.nCol]
+	pCol.zCnName = z
+
+	if sType.n == 0 {
+		/* If there is no type specified, columns have the default affinity
+		 ** 'BLOB' with a default size of 4 bytes. */
+		pCol.affinity = affinity
+		pCol.eCType = eType
+		pCol.szEst = szEst
+	} else {
+		zType := sqlite3NameFromToken(db, &sType)
+		pCol.zCnName = append(append(append(z, 0), zType...), 0)
+		pCol.affinity = sqlite3AffinityType(zType, pCol)
+		pCol.colFlags |= COLFLAG_HASTYPE
+	}
+	p.nCol++
+	p.nNVCol++
+	pParse.constraintName.n = 0
+}
+
+/*
+** This routine is called by the parser while in the middle of
+** parsing a CREATE TABLE statement.  A "NOT NULL" constraint has
+** been seen on a column.  This routine sets the notNull flag on
+** the column currently under construction.
+ */
+func sqlite3AddNotNull(pParse *Parse, onError int) {
+	var pCol *Column
+	p := pParse.pNewTable
+	if p == nil || p.nCol < 1 {
+		return
+	}
+	pCol = &p.aCol[p.nCol-1]
+	pCol.notNull = uint8(onError)
+	p.tabFlags |= TF_HasNotNull
+
+	/* Set the uniqNotNull flag on any UNIQUE or PK indexes already created
+	 ** on this column.  */
+	if (pCol.colFlags & COLFLAG_UNIQUE) != 0 {
+		for pIdx := p.pIndex; pIdx != nil; pIdx = pIdx.pNext {
+			assert(pIdx.nKeyCol == 1 && pIdx.onError != OE_None, "pIdx.nKeyCol == 1 && pIdx.onError != OE_None")
+			if int(pIdx.aiColumn[0]) == int(p.nCol)-1 {
+				pIdx.uniqNotNull = 1
+			}
+		}
+	}
+}
+
+/*
+** Tag the given column as being part of the PRIMARY KEY
+ */
+func makeColumnPartOfPrimaryKey(pParse *Parse, pCol *Column) {
+	pCol.colFlags |= COLFLAG_PRIMKEY
+	if (pCol.colFlags & COLFLAG_GENERATED) != 0 {
+		sqlite3ErrorMsg(pParse, "generated columns cannot be part of the PRIMARY KEY")
+	}
+}
+
+/*
+** Designate the PRIMARY KEY for the table.  pList is a list of names
+** of columns that form the primary key.  If pList is NULL, then the
+** most recently added column of the table is the primary key.
+**
+** A table can have at most one primary key.  If the table already has
+** a primary key (and this is the second primary key) then create an
+** error.
+**
+** If the PRIMARY KEY is on a single column whose datatype is INTEGER,
+** then we will try to use that column as the rowid.  Set the Table.iPKey
+** field of the table under construction to be the index of the
+** INTEGER PRIMARY KEY column.  Table.iPKey is set to -1 if there is
+** no INTEGER PRIMARY KEY.
+**
+** If the key is not an INTEGER PRIMARY KEY, then create a unique
+** index for the key.  No index is created for INTEGER PRIMARY KEYs.
+ */
+func sqlite3AddPrimaryKey(
+	pParse *Parse, /* Parsing context */
+	pList *ExprList, /* List of field names to be indexed */
+	onError int, /* What to do with a uniqueness conflict */
+	autoInc int, /* True if the AUTOINCREMENT keyword is present */
+	sortOrder int, /* SQLITE_SO_ASC or SQLITE_SO_DESC */
+) {
+	pTab := pParse.pNewTable
+	var pCol *Column
+	iCol := -1
+	var nTerm int
+
+	if pTab == nil {
+		goto primary_key_exit
+	}
+	if (pTab.tabFlags & TF_HasPrimaryKey) != 0 {
+		sqlite3ErrorMsg(pParse, "table \"%s\" has more than one primary key", pTab.zName)
+		goto primary_key_exit
+	}
+	pTab.tabFlags |= TF_HasPrimaryKey
+	if pList == nil {
+		iCol = int(pTab.nCol) - 1
+		pCol = &pTab.aCol[iCol]
+		makeColumnPartOfPrimaryKey(pParse, pCol)
+		nTerm = 1
+	} else {
+		nTerm = pList.nExpr
+		for i := 0; i < nTerm; i++ {
+			pCExpr := sqlite3ExprSkipCollate(pList.a[i].pExpr)
+			assert(pCExpr != nil, "pCExpr != nil")
+			sqlite3StringToId(pCExpr)
+			if pCExpr.op == TK_ID {
+				zCName := pCExpr.u.zToken
+				for iCol = 0; iCol < int(pTab.nCol); iCol++ {
+					if sqlite3StrICmp(zCName, pTab.aCol[iCol].zCnName) == 0 {
+						pCol = &pTab.aCol[iCol]
+						makeColumnPartOfPrimaryKey(pParse, pCol)
+						break
+					}
+				}
+			}
+		}
+	}
+	if nTerm == 1 &&
+		pCol != nil &&
+		pCol.eCType == COLTYPE_INTEGER &&
+		sortOrder != SQLITE_SO_DESC {
+		pTab.iPKey = int16(iCol)
+		pTab.keyConf = uint8(onError)
+		assert(autoInc == 0 || autoInc == 1, "autoInc == 0 || autoInc == 1")
+		pTab.tabFlags |= uint32(autoInc) * TF_Autoincrement
+		if pList != nil {
+			pParse.iPkSortOrder = pList.a[0].sortFlags
+		}
+		sqlite3HasExplicitNulls(pParse, pList)
+	} else if autoInc != 0 {
+		sqlite3ErrorMsg(pParse, "AUTOINCREMENT is only allowed on an "+
+			"INTEGER PRIMARY KEY")
+	} else {
+		sqlite3CreateIndex(pParse, nil, nil, nil, pList, onError, nil,
+			nil, sortOrder, 0, SQLITE_IDXTYPE_PRIMARYKEY)
+		pList = nil
+	}
+
+primary_key_exit:
+	sqlite3ExprListDelete(pParse.db, pList)
+}
+
+/*
+** Set the collation function of the most recently parsed table column
+** to the CollSeq given.
+**
+** The port has no collating functions, so any name is accepted, as it
+** is by C while the schema is being loaded.
+ */
+func sqlite3AddCollateType(pParse *Parse, pToken *Token) {
+	var p *Table
+	var i int
+	var zColl []byte
+	var db *sqlite3
+
+	if p = pParse.pNewTable; p == nil || pParse.eParseMode >= PARSE_MODE_RENAME {
+		return
+	}
+	i = int(p.nCol) - 1
+	db = pParse.db
+	zColl = sqlite3NameFromToken(db, pToken)
+	if zColl == nil {
+		return
+	}
+	sqlite3ColumnSetColl(db, &p.aCol[i], zColl)
+
+	/* If the column is declared as "<name> PRIMARY KEY COLLATE <type>",
+	 ** then an index may have been created on this column before the
+	 ** collation type was added. Correct this if it is the case.
+	 */
+	for pIdx := p.pIndex; pIdx != nil; pIdx = pIdx.pNext {
+		assert(pIdx.nKeyCol == 1, "pIdx.nKeyCol == 1")
+		if int(pIdx.aiColumn[0]) == i {
+			pIdx.azColl[0] = sqlite3ColumnColl(&p.aCol[i])
+		}
+	}
+}
+
+/*
+** This routine is called to report the final ")" that terminates
+** a CREATE TABLE statement.
+**
+** The table structure that other action routines have been building
+** is added to the internal hash tables, assuming no errors have
+** occurred.
+**
+** The port has no hash tables.  The finished table is recorded in
+** pParse->pTable so that it is attached to the Stmt.  For a CREATE TABLE
+** AS SELECT, the columns are named as for a view.  Their affinities are
+** those of the result set, or BLOB.
+ */
+func sqlite3EndTable(
+	pParse *Parse, /* Parse context */
+	pCons *Token, /* The ',' token after the last column defn. */
+	pEnd *Token, /* The ')' before options in the CREATE TABLE */
+	tabOpts uint32, /* Extra table options. Usually 0. */
+	pSelect *Select, /* Select from a "CREATE ... AS SELECT" */
+) {
+	var p *Table /* The new table */
+
+	UNUSED_PARAMETER(pCons)
+	if pEnd == nil && pSelect == nil {
+		return
+	}
+	p = pParse.pNewTable
+	if p == nil {
+		return
+	}
+
+	if (tabOpts & TF_Strict) != 0 {
+		p.tabFlags |= TF_Strict
+	}
+
+	/* Special processing for WITHOUT ROWID Tables */
+	if (tabOpts & TF_WithoutRowid) != 0 {
+		if (p.tabFlags & TF_Autoincrement) != 0 {
+			sqlite3ErrorMsg(pParse,
+				"AUTOINCREMENT not allowed on WITHOUT ROWID tables")
+			return
+		}
+		if (p.tabFlags & TF_HasPrimaryKey) == 0 {
+			sqlite3ErrorMsg(pParse, "PRIMARY KEY missing on table %s", p.zName)
+			return
+		}
+		p.tabFlags |= TF_WithoutRowid | TF_NoVisibleRowid
+	}
+
+	/* If this is a CREATE TABLE xx AS SELECT ..., compute the columns of
+	 ** the new table from the result set of the SELECT.
+	 */
+	if pSelect != nil {
+		var pLeft *Select
+		sqlite3SelectPrep(pParse, pSelect, nil)
+		if pParse.nErr != 0 {
+			return
+		}
+		for pLeft = pSelect; pLeft.pPrior != nil; pLeft = pLeft.pPrior {
+		}
+		if !selectHasStar(pLeft.pEList) {
+			sqlite3ColumnsFromExprList(pParse, pLeft.pEList, &p.nCol, &p.aCol)
+			if pParse.nErr != 0 {
+				return
+			}
+			p.nNVCol = p.nCol
+			sqlite3SubqueryColumnTypes(pParse, p, pSelect, SQLITE_AFF_BLOB)
+		}
+	}
+
+	pParse.pTable = p
+	pParse.pNewTable = nil
+}
+
+/*
+** This routine is called to create a new foreign key on the table
+** currently under construction.  pFromCol determines which columns
+** in the current table point to the foreign key.  If pFromCol==0 then
+** connect the key to the last column inserted.  pTo is the name of
+** the table referred to (a.k.a the "parent" table).  pToCol is a list
+** of tables in the parent pTo table.  flags contains all
+** information about the conflict resolution algorithms specified
+** in the ON DELETE, ON UPDATE and ON INSERT clauses.
+**
+** An FKey structure is created and added to the table currently
+** under construction in the pParse->pNewTable field.
+**
+** The foreign key is set for IMMEDIATE processing.  A subsequent call
+** to sqlite3DeferForeignKey() might change this to DEFERRED.
+**
+** The C version also adds the key to Schema.fkeyHash, so that the keys
+** that refer to a parent table can be found.  The port has no schema.
+** A Catalog builds that index when it is asked for its foreign keys.
+ */
+func sqlite3CreateForeignKey(
+	pParse *Parse, /* Parsing context */
+	pFromCol *ExprList, /* Columns in this table that point to other table */
+	pTo *Token, /* Name of the other table */
+	pToCol *ExprList, /* Columns in the other table */
+	flags int, /* Conflict resolution algorithms. */
+) {
+	db := pParse.db
+	var pFKey *FKey
+	p := pParse.pNewTable
+	var nCol int
+
+	assert(pTo != nil, "pTo != nil")
+	if p == nil {
+		goto fk_end
+	}
+	if pFromCol == nil {
+		iCol := int(p.nCol) - 1
+		if iCol < 0 {
+			goto fk_end
+		}
+		if pToCol != nil && pToCol.nExpr != 1 {
+			sqlite3ErrorMsg(pParse, "foreign key on %s"+
+				" should reference only one column of table %T",
+				p.aCol[iCol].zCnName, pTo)
+			goto fk_end
+		}
+		nCol = 1
+	} else if pToCol != nil && pToCol.nExpr != pFromCol.nExpr {
+		sqlite3ErrorMsg(pParse,
+			"number of columns in foreign key does not match the number of "+
+				"columns in the referenced table")
+		goto fk_end
+	} else {
+		nCol = pFromCol.nExpr
+	}
+	pFKey = &FKey{}
+	pFKey.pFrom = p
+	assert(IsOrdinaryTable(p), "IsOrdinaryTable(p)")
+	pFKey.pNextFrom = p.u.tab.pFKey
+	pFKey.zTo = sqlite3NameFromToken(db, pTo)
+	pFKey.nCol = nCol
+	pFKey.aCol = make([]struct {
+		iFrom int
+		zCol  []byte
+	}, nCol)
+	if pFromCol == nil {
+		pFKey.aCol[0].iFrom = int(p.nCol) - 1
+	} else {
+		for i := 0; i < nCol; i++ {
+			var j int
+			for j = 0; j < int(p.nCol); j++ {
+				if sqlite3StrICmp(p.aCol[j].zCnName, pFromCol.a[i].zEName) == 0 {
+					pFKey.aCol[i].iFrom = j
+					break
+				}
+			}
+			if j >= int(p.nCol) {
+				sqlite3ErrorMsg(pParse,
+					"unknown column \"%s\" in foreign key definition",
+					pFromCol.a[i].zEName)
+				goto fk_end
+			}
+		}
+	}
+	if pToCol != nil {
+		for i := 0; i < nCol; i++ {
+			pFKey.aCol[i].zCol = pToCol.a[i].zEName
+		}
+	}
+	pFKey.isDeferred = 0
+	pFKey.aAction[0] = uint8(flags & 0xff)        /* ON DELETE action */
+	pFKey.aAction[1] = uint8((flags >> 8) & 0xff) /* ON UPDATE action */
+
+	/* Link the foreign key to the table as the last step.
+	 */
+	assert(IsOrdinaryTable(p), "IsOrdinaryTable(p)")
+	p.u.tab.pFKey = pFKey
+
+fk_end:
+	sqlite3ExprListDelete(db, pFromCol)
+	sqlite3ExprListDelete(db, pToCol)
+}
+
+/*
+** This routine is called when an INITIALLY IMMEDIATE or INITIALLY DEFERRED
+** clause is seen as part of a foreign key definition.  The isDeferred
+** parameter is 1 for INITIALLY DEFERRED and 0 for INITIALLY IMMEDIATE.
+** The behavior of the most recently created foreign key is adjusted
+** accordingly.
+ */
+func sqlite3DeferForeignKey(pParse *Parse, isDeferred int) {
+	var pTab *Table
+	var pFKey *FKey
+	if pTab = pParse.pNewTable; pTab == nil {
+		return
+	}
+	if !IsOrdinaryTable(pTab) {
+		return
+	}
+	if pFKey = pTab.u.tab.pFKey; pFKey == nil {
+		return
+	}
+	assert(isDeferred == 0 || isDeferred == 1, "isDeferred == 0 || isDeferred == 1") /* EV: R-30323-21917 */
+	pFKey.isDeferred = uint8(isDeferred)
+}
+
+/*
+** The parser calls this routine in order to create a new VIEW
+**
+** The C version writes the view into the schema.  The port computes the
+** names and affinities of its columns, then records the view in
+** pParse->pTable so that it is attached to the Stmt.
+ */
+func sqlite3CreateView(
+	pParse *Parse, /* The parsing context */
+	pBegin *Token, /* The CREATE token that begins the statement */
+	pName1 *Token, /* The token that holds the name of the view */
+	pName2 *Token, /* The token that holds the name of the view */
+	pCNames *ExprList, /* Optional list of view column names */
+	pSelect *Select, /* A SELECT statement that will become the new view */
+	isTemp int, /* TRUE for a TEMPORARY view */
+	noErr int, /* Suppress error messages if VIEW already exists */
+) {
+	var p *Table
+	db := pParse.db
+
+	UNUSED_PARAMETER(pBegin)
+	if sqlite3IsOmitted(pParse, OmitView, "CREATE VIEW") {
+		goto create_view_fail
+	}
+	if pParse.nVar > 0 {
+		sqlite3ErrorMsg(pParse, "parameters are not allowed in views")
+		goto create_view_fail
+	}
+	sqlite3StartTable(pParse, pName1, pName2, isTemp, 1, 0, noErr)
+	p = pParse.pNewTable
+	if p == nil || pParse.nErr != 0 {
+		goto create_view_fail
+	}
+	p.tabFlags |= TF_NoVisibleRowid
+
+	/* The view takes ownership of the SELECT and of the column list */
+	pSelect.selFlags |= SF_View
+	p.u.view.pSelect = pSelect
+	pSelect = nil
+	p.pCheck = pCNames
+	pCNames = nil
+	p.eTabType = TABTYP_VIEW
+
+	if sqlite3ViewGetColumnNames(pParse, p) != 0 {
+		goto create_view_fail
+	}
+	pParse.pTable = p
+
+create_view_fail:
+	pParse.pNewTable = nil
+	sqlite3SelectDelete(db, pSelect)
+	sqlite3ExprListDelete(db, pCNames)
+}
+
+/*
+** The Table structure pTable is really a VIEW.  Fill in the names of
+** the columns of the view in the pTable structure.  Return non-zero if
+** there are errors.  If an error is seen an error message is left
+** in pParse->zErrMsg.
+**
+** The names come from the column list of the CREATE VIEW statement, if
+** it has one, and otherwise from the result set of the leftmost SELECT
+** of the view.  The affinity of each column is that of its expression.
+** Without a schema, the declared types of the table columns that a view
+** selects are unknown, so such a column has no affinity.  A result set
+** that uses "*" or "TABLE.*" cannot be expanded either.  If the view has
+** no column list, its columns are then left unnamed.  NewCatalog()
+** computes the columns again once the tables are known.
+ */
+func sqlite3ViewGetColumnNames(pParse *Parse, pTable *Table) int {
+	var pSel *Select     /* The SELECT that defines the view */
+	var pLeft *Select    /* Left-most SELECT of the view */
+	var pEList *ExprList /* Result set of pLeft */
+
+	assert(IsView(pTable), "IsView(pTable)")
+	if pTable.nCol > 0 {
+		return 0
+	}
+	pSel = pTable.u.view.pSelect
+	sqlite3SelectPrep(pParse, pSel, nil)
+	if pParse.nErr != 0 {
+		return 1
+	}
+	for pLeft = pSel; pLeft.pPrior != nil; pLeft = pLeft.pPrior {
+	}
+	pEList = pLeft.pEList
+	if pTable.pCheck != nil {
+		if !selectHasStar(pEList) && pEList.nExpr != pTable.pCheck.nExpr {
+			sqlite3ErrorMsg(pParse, "expected %d columns for '%s' but got %d",
+				pTable.pCheck.nExpr, pTable.zName, pEList.nExpr)
+			return 1
+		}
+		sqlite3ColumnsFromExprList(pParse, pTable.pCheck, &pTable.nCol, &pTable.aCol)
+		if pParse.nErr == 0 && int(pTable.nCol) == pEList.nExpr {
+			sqlite3SubqueryColumnTypes(pParse, pTable, pSel, SQLITE_AFF_NONE)
+		}
+	} else if !selectHasStar(pEList) {
+		sqlite3ColumnsFromExprList(pParse, pEList, &pTable.nCol, &pTable.aCol)
+		if pParse.nErr == 0 {
+			sqlite3SubqueryColumnTypes(pParse, pTable, pSel, SQLITE_AFF_NONE)
+		}
+	}
+	if pParse.nErr != 0 {
+		return 1
+	}
+	return 0
+}
+
+/*
+** The Go port does not generate code for a DROP statement.  Instead the
+** name of the object to be dropped is recorded in one of these objects
+** and attached to the Stmt.
+ */
+type Drop struct {
+	op    uint8    /* TK_TABLE, TK_VIEW, TK_INDEX or TK_TRIGGER */
+	pName *SrcList /* Name of the object to drop.  pName->nSrc==1 */
+	noErr uint8    /* True if IF EXISTS was given */
+}
+
+/*
+** Record a DROP statement for the object of type op named by pName.
+ */
+func sqlite3DropObject(pParse *Parse, op uint8, pName *SrcList, noErr int) {
+	var p *Drop
+
+	if pParse.nErr != 0 {
+		sqlite3SrcListDelete(pParse.db, pName)
+		return
+	}
+	assert(pName.nSrc == 1, "pName.nSrc == 1")
+	p = &Drop{}
+	p.op = op
+	p.pName = pName
+	p.noErr = uint8(noErr)
+	pParse.pDrop = p
+}
+
+/*
+** This routine is called to do the work of a DROP TABLE statement.
+** pName is the name of the table to be dropped.
+**
+** The port has no schema, so whether the table exists and whether it is
+** a view is not checked.  The statement is only recorded.
+ */
+func sqlite3DropTable(pParse *Parse, pName *SrcList, isView int, noErr int) {
+	op := uint8(TK_TABLE)
+	if isView != 0 {
+		if sqlite3IsOmitted(pParse, OmitView, "DROP VIEW") {
+			sqlite3SrcListDelete(pParse.db, pName)
+			return
+		}
+		op = TK_VIEW
+	}
+	sqlite3DropObject(pParse, op, pName, noErr)
+}
+
+/*
+** This routine will drop an existing named index.  This routine
+** implements the DROP INDEX statement.
+ */
+func sqlite3DropIndex(pParse *Parse, pName *SrcList, ifExists int) {
+	sqlite3DropObject(pParse, TK_INDEX, pName, ifExists)
+}
+
+/*
+** If expression list pList contains an expression that was parsed with
+** an explicit "NULLS FIRST" or "NULLS LAST" clause, leave an error in
+** pParse and return non-zero. Otherwise, return zero.
+ */
+func sqlite3HasExplicitNulls(pParse *Parse, pList *ExprList) int {
+	if pList != nil {
+		for i := 0; i < pList.nExpr; i++ {
+			if pList.a[i].bNulls != 0 {
+				sf := pList.a[i].sortFlags
+				zNulls := "LAST"
+				if sf == 0 || sf == 3 {
+					zNulls = "FIRST"
+				}
+				sqlite3ErrorMsg(pParse, "unsupported use of NULLS %s", zNulls)
+				return 1
+			}
+		}
+	}
+	return 0
+}
+
+/*
+** The index term pExpr, with any COLLATE removed, is an identifier or
+** a TABLE.COLUMN reference.  Return the column name, or NULL if pExpr
+** is some other kind of expression.
+ */
+func indexColumnName(pExpr *Expr) []byte {
+	switch pExpr.op {
+	case TK_ID:
+		return pExpr.u.zToken
+	case TK_DOT:
+		if pExpr.pRight.op == TK_ID {
+			return pExpr.pRight.u.zToken
+		}
+	}
+	return nil
+}
+
+/*
+** Allocate heap space to hold an Index object with nCol columns.
+**
+** The C version allocates the arrays of the object and the space for
+** its strings in one block.  Here aiColumn[] is left empty, since it
+** is filled in by sqlite3ResolveIndex().
+ */
+func sqlite3AllocateIndexObject(db *sqlite3, nCol int) *Index {
+	UNUSED_PARAMETER(db)
+	p := &Index{}
+	p.azColl = make([][]byte, nCol)
+	p.aSortOrder = make([]uint8, nCol)
+	p.nColumn = uint16(nCol)
+	p.nKeyCol = uint16(nCol)
+	return p
+}
+
+/*
+** Create a new index for an SQL table.  pName1.pName2 is the name of the
+** index and pTblList is the name of the table that is to be indexed.
+** Both will be NULL for a primary key or an index that is created to
+** satisfy a UNIQUE constraint.  If pTblName and pName are NULL, use
+** pParse->pNewTable as the table to be indexed.  pParse->pNewTable is a
+** table that is being created by CREATE TABLE.
+**
+** pList is a list of columns to be indexed.  pList will be NULL if this
+** is a primary key or unique-constraint on the most recent column added
+** to the table currently under construction.
+**
+** The port has no schema.  The index made by a PRIMARY KEY or UNIQUE
+** constraint is resolved against the table under construction and added
+** to its list of indexes.  The table of a CREATE INDEX is known only by
+** name, so the index is recorded in pParse->pIndex with the column list
+** kept in aColExpr and aiColumn[] left empty.  sqlite3ResolveIndex()
+** fills it in once the table is known.
+ */
+func sqlite3CreateIndex(
+	pParse *Parse, /* All information about this parse */
+	pName1 *Token, /* First part of index name. May be NULL */
+	pName2 *Token, /* Second part of index name. May be NULL */
+	pTblName *SrcList, /* Table to index. Use pParse->pNewTable if 0 */
+	pList *ExprList, /* A list of columns to be indexed */
+	onError int, /* OE_Abort, OE_Ignore, OE_Replace, or OE_None */
+	pStart *Token, /* The CREATE token that begins this statement */
+	pPIWhere *Expr, /* WHERE clause for partial indices */
+	sortOrder int, /* Sort order of primary key when pList==NULL */
+	ifNotExist int, /* Omit error if index already exists */
+	idxType uint8, /* The index type */
+) {
+	var pTab *Table   /* Table to be indexed */
+	var pIndex *Index /* The index to be created */
+	var zName []byte  /* Name of the index */
+	var pName *Token  /* Unqualified name of the index to create */
+	var pIdx *Index   /* An index already on pTab */
+	var nKeyCol int   /* Number of terms in pList */
+	db := pParse.db
+
+	UNUSED_PARAMETER(pStart)
+	UNUSED_PARAMETER(ifNotExist)
+	if pParse.nErr > 0 {
+		goto exit_create_index
+	}
+	if sqlite3HasExplicitNulls(pParse, pList) != 0 {
+		goto exit_create_index
+	}
+
+	/*
+	 ** Find the table that is to be indexed.  Return early if not found.
+	 */
+	if pTblName != nil {
+		/* Use the two-part index name to determine the database
+		 ** to search for the table. 'Fix' the table name to this db
+		 ** before looking up the table.
+		 */
+		assert(pName1 != nil && pName2 != nil, "pName1 != nil && pName2 != nil")
+		if pName2.n > 0 {
+			pName = pName2
+		} else {
+			pName = pName1
+		}
+		assert(pTblName.nSrc == 1, "pTblName.nSrc == 1")
+
+		/* Without a schema, only the name of the table is known */
+		pTab = &Table{}
+		pTab.zName = pTblName.a[0].zName
+		pTab.iPKey = -1
+		if sqlite3_strnicmp(pTab.zName, []byte("sqlite_"), 7) == 0 &&
+			db.init.busy == 0 {
+			sqlite3ErrorMsg(pParse, "table %s may not be indexed", pTab.zName)
+			goto exit_create_index
+		}
+	} else {
+		assert(pName1 == nil, "pName1 == nil")
+		assert(pStart == nil, "pStart == nil")
+		pTab = pParse.pNewTable
+		if pTab == nil {
+			goto exit_create_index
+		}
+	}
+
+	/*
+	 ** Find the name of the index.  Make sure there is not already another
+	 ** index or table with the same name.
+	 **
+	 ** If pName==0 it means that we are
+	 ** dealing with a primary key or UNIQUE constraint.  We have to invent our
+	 ** own name.
+	 */
+	if pName != nil {
+		zName = sqlite3NameFromToken(db, pName)
+		if zName == nil {
+			goto exit_create_index
+		}
+		if sqlite3CheckObjectName(pParse, zName, "index", pTab.zName) != SQLITE_OK {
+			goto exit_create_index
+		}
+	} else {
+		n := 1
+		for pLoop := pTab.pIndex; pLoop != nil; pLoop = pLoop.pNext {
+			n++
+		}
+		zName = sqlite3MPrintf(db, "sqlite_autoindex_%s_%d", pTab.zName, n)
+	}
+
+	/* If pList==0, it means this routine was called to make a primary
+	 ** key out of the last column added to the table under construction.
+	 ** So create a fake list to simulate this.
+	 */
+	if pList == nil {
+		var prevCol Token
+		pCol := &pTab.aCol[pTab.nCol-1]
+		pCol.colFlags |= COLFLAG_UNIQUE
+		sqlite3TokenInit(&prevCol, pCol.zCnName)
+		pList = sqlite3ExprListAppend(pParse, nil, sqlite3ExprAlloc(db, TK_ID, &prevCol, 0))
+		assert(pList.nExpr == 1, "pList.nExpr == 1")
+		sqlite3ExprListSetSortOrder(pList, sortOrder, SQLITE_SO_UNDEFINED)
+	} else {
+		sqlite3ExprListCheckLength(pParse, pList, "index")
+		if pParse.nErr != 0 {
+			goto exit_create_index
+		}
+	}
+
+	/* A PRIMARY KEY or UNIQUE constraint may only name columns of the
+	 ** table.
+	 */
+	nKeyCol = pList.nExpr
+	for i := 0; i < nKeyCol; i++ {
+		sqlite3StringToId(pList.a[i].pExpr)
+		if pTab == pParse.pNewTable &&
+			indexColumnName(sqlite3ExprSkipCollate(pList.a[i].pExpr)) == nil {
+			sqlite3ErrorMsg(pParse, "expressions prohibited in PRIMARY KEY and "+
+				"UNIQUE constraints")
+			goto exit_create_index
+		}
+	}
+
+	/*
+	 ** Allocate the index structure.
+	 */
+	pIndex = sqlite3AllocateIndexObject(db, nKeyCol)
+	pIndex.zName = zName
+	pIndex.onError = uint8(onError)
+	pIndex.idxType = idxType
+	pIndex.aColExpr = pList
+	pList = nil
+	pIndex.pPartIdxWhere = pPIWhere
+	pPIWhere = nil
+
+	/* Record the explicit collating sequences and the sort orders.  The
+	 ** collating sequence of a column without one comes from the table.
+	 */
+	for i := 0; i < nKeyCol; i++ {
+		pListItem := &pIndex.aColExpr.a[i]
+		if pListItem.pExpr.op == TK_COLLATE {
+			pIndex.azColl[i] = pListItem.pExpr.u.zToken
+		}
+		pIndex.aSortOrder[i] = pListItem.sortFlags
+	}
+
+	if sqlite3ResolveIndex(pParse, pIndex, pTab) != 0 {
+		goto exit_create_index
+	}
+
+	if pTab == pParse.pNewTable {
+		/* This routine has been called to create an automatic index as a
+		 ** result of a PRIMARY KEY or UNIQUE clause on a column definition, or
+		 ** a PRIMARY KEY or UNIQUE clause following the column definitions.
+		 ** i.e. one of:
+		 **
+		 ** CREATE TABLE t(x PRIMARY KEY, y);
+		 ** CREATE TABLE t(x, y, UNIQUE(x, y));
+		 **
+		 ** Either way, check to see if the table already has such an index. If
+		 ** so, don't bother creating this one. This only applies to
+		 ** automatically created indices. Users can do as they wish with
+		 ** explicit indices.
+		 **
+		 ** Two UNIQUE or PRIMARY KEY constraints are considered equivalent
+		 ** (and thus suppressing the second one) even if they have different
+		 ** sort orders.
+		 **
+		 ** If there are different collating sequences or if the columns of
+		 ** the constraint occur in different orders, then the constraints are
+		 ** considered distinct and both result in separate indices.
+		 */
+		for pIdx = pTab.pIndex; pIdx != nil; pIdx = pIdx.pNext {
+			var k int
+			assert(IsUniqueIndex(pIdx), "IsUniqueIndex(pIdx)")
+			assert(pIdx.idxType != SQLITE_IDXTYPE_APPDEF, "pIdx.idxType != SQLITE_IDXTYPE_APPDEF")
+			assert(IsUniqueIndex(pIndex), "IsUniqueIndex(pIndex)")
+
+			if pIdx.nKeyCol != pIndex.nKeyCol {
+				continue
+			}
+			for k = 0; k < int(pIdx.nKeyCol); k++ {
+				if pIdx.aiColumn[k] != pIndex.aiColumn[k] {
+					break
+				}
+				if sqlite3StrICmp(pIdx.azColl[k], pIndex.azColl[k]) != 0 {
+					break
+				}
+			}
+			if k == int(pIdx.nKeyCol) {
+				if pIdx.onError != pIndex.onError {
+					/* This constraint creates the same index as a previous
+					 ** constraint specified somewhere in the CREATE TABLE statement.
+					 ** However the ON CONFLICT clauses are different. If both this
+					 ** constraint and the previous equivalent constraint have explicit
+					 ** ON CONFLICT clauses this is an error. Otherwise, use the
+					 ** explicitly specified behavior for the index.
+					 */
+					if !(pIdx.onError == OE_Default || pIndex.onError == OE_Default) {
+						sqlite3ErrorMsg(pParse,
+							"conflicting ON CONFLICT clauses specified")
+					}
+					if pIdx.onError == OE_Default {
+						pIdx.onError = pIndex.onError
+					}
+				}
+				if idxType == SQLITE_IDXTYPE_PRIMARYKEY {
+					pIdx.idxType = idxType
+				}
+				goto exit_create_index
+			}
+		}
+		pIndex.pNext = pTab.pIndex
+		pTab.pIndex = pIndex
+	} else {
+		pParse.pIndex = pIndex
+	}
+
+exit_create_index:
+	sqlite3ExprDelete(db, pPIWhere)
+	sqlite3ExprListDelete(db, pList)
+	sqlite3SrcListDelete(db, pTblName)
+}
+
+/*
+** Resolve index pIndex against pTab, the table it indexes.  The terms
+** of the index are in pIndex->aColExpr.  Check that its expressions and
+** WHERE clause are allowed in an index and, if the columns of pTab are
+** known, that they refer only to those columns.  Then fill in
+** aiColumn[] and the collating sequences taken from the table.
+**
+** The C version does this as part of sqlite3CreateIndex().  The port
+** also calls it when the table of a CREATE INDEX becomes known.
+**
+** Return the number of errors seen.
+ */
+func sqlite3ResolveIndex(pParse *Parse, pIndex *Index, pTab *Table) int {
+	if IsView(pTab) {
+		sqlite3ErrorMsg(pParse, "views may not be indexed")
+		return 1
+	}
+	if IsVirtual(pTab) {
+		sqlite3ErrorMsg(pParse, "virtual tables may not be indexed")
+		return 1
+	}
+	pIndex.pTable = pTab
+	if pIndex.pPartIdxWhere != nil &&
+		sqlite3ResolveSelfReference(pParse, pTab, NC_PartIdx, pIndex.pPartIdxWhere, nil) != 0 {
+		return 1
+	}
+	for i := 0; i < int(pIndex.nKeyCol); i++ {
+		if sqlite3ResolveSelfReference(pParse, pTab, NC_IdxExpr, pIndex.aColExpr.a[i].pExpr, nil) != 0 {
+			return 1
+		}
+	}
+
+	/* Without the columns of the table, the terms that are column names
+	 ** cannot be told apart from the rest.
+	 */
+	if pTab.nCol == 0 {
+		pIndex.aiColumn = nil
+		return 0
+	}
+
+	/* In the common case where the expression is exactly a table column,
+	 ** store that column in aiColumn[].  For general expressions, store
+	 ** XN_EXPR (-2) in aiColumn[].
+	 */
+	pIndex.aiColumn = make([]int16, pIndex.nKeyCol)
+	pIndex.uniqNotNull = 0
+	if pIndex.onError != OE_None {
+		pIndex.uniqNotNull = 1
+	}
+	for i := 0; i < int(pIndex.nKeyCol); i++ {
+		j := XN_EXPR
+		pListItem := &pIndex.aColExpr.a[i]
+		if zCol := indexColumnName(sqlite3ExprSkipCollate(pListItem.pExpr)); zCol != nil {
+			for j = 0; j < int(pTab.nCol); j++ {
+				if sqlite3StrICmp(zCol, pTab.aCol[j].zCnName) == 0 {
+					break
+				}
+			}
+			if j == int(pTab.nCol) {
+				j = int(pTab.iPKey)
+			}
+		}
+		pIndex.aiColumn[i] = int16(j)
+		if j == XN_EXPR || (j >= 0 && pTab.aCol[j].notNull == OE_None) {
+			pIndex.uniqNotNull = 0
+		}
+		if pListItem.pExpr.op != TK_COLLATE {
+			var zColl []byte
+			if j >= 0 {
+				zColl = sqlite3ColumnColl(&pTab.aCol[j])
+			}
+			if zColl == nil {
+				zColl = sqlite3StrBINARY
+			}
+			pIndex.azColl[i] = zColl
+		}
+	}
+	return 0
+}
+
+/*
+** Convert a TK_STRING token in an index term, or one under a COLLATE,
+** into a TK_ID.  This allows a column to be named with a string, as in
+** "CREATE INDEX i1 ON t1('a')".
+ */
+func sqlite3StringToId(p *Expr) {
+	if p.op == TK_STRING {
+		p.op = TK_ID
+	} else if p.op == TK_COLLATE && p.pLeft.op == TK_STRING {
+		p.pLeft.op = TK_ID
+	}
+}
+
+/*
+** Append a new element to the given IdList.  Create a new IdList if
+** need be.
+**
+** A new IdList is returned, or NULL if malloc() fails.
+ */
+func sqlite3IdListAppend(pParse *Parse, pList *IdList, pToken *Token) *IdList {
+	db := pParse.db
+	if pList == nil {
+		pList = &IdList{}
+	}
+	pList.a = append(pList.a, struct {
+		zName []byte
+		idx   int
+		pExpr *Expr
+	}{zName: sqlite3NameFromToken(db, pToken)})
+	pList.nId++
+	return pList
+}
+
+/*
+** Return the index in pList of the identifier named zId.  Return -1
+** if not found.
+ */
+func sqlite3IdListIndex(pList *IdList, zName []byte) int {
+	for i := 0; i < pList.nId; i++ {
+		if sqlite3StrICmp(pList.a[i].zName, zName) == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+/*
+** Expand the space allocated for the given SrcList object by
+** creating nExtra new slots beginning at iStart.  iStart is zero based.
+** New slots are zeroed.
+**
+** For example, suppose a SrcList initially contains two entries: A,B.
+** To append 3 new entries onto the end, do this:
+**
+**    sqlite3SrcListEnlarge(db, pSrclist, 3, 2);
+**
+** After the call above it would contain:  A, B, nil, nil, nil.
+** If the iStart argument had been 1 instead of 2, then the result
+** would have been:  A, nil, nil, nil, B.  To prepend the new slots,
+** the iStart value would be 0.  The result then would
+** be: nil, nil, nil, A, B.
+**
+** If a memory allocation fails or the SrcList becomes too large, leave
+** the original SrcList unchanged, return NULL, and leave an error message
+** in pParse.
+ */
+func sqlite3SrcListEnlarge(
+	pParse *Parse, /* Parsing context into which errors are reported */
+	pSrc *SrcList, /* The SrcList to be enlarged */
+	nExtra int, /* Number of new slots to add to pSrc->a[] */
+	iStart int, /* Index in pSrc->a[] of first new slot */
+) *SrcList {
+	assert(iStart >= 0, "iStart >= 0")
+	assert(nExtra >= 1, "nExtra >= 1")
+	assert(pSrc != nil, "pSrc != nil")
+	assert(iStart <= pSrc.nSrc, "iStart <= pSrc.nSrc")
+
+	if pSrc.nSrc+nExtra >= SQLITE_MAX_SRCLIST {
+		sqlite3ErrorMsg(pParse, "too many FROM clause terms, max: %d",
+			SQLITE_MAX_SRCLIST)
+		return nil
+	}
+
+	/* Allocate the new array and move existing slots that come after
+	 ** the newly inserted slots out of the way */
+	aNew := make([]SrcItem, pSrc.nSrc+nExtra)
+	copy(aNew, pSrc.a[:iStart])
+	copy(aNew[iStart+nExtra:], pSrc.a[iStart:pSrc.nSrc])
+	pSrc.a = aNew
+	pSrc.nSrc += nExtra
+	pSrc.nAlloc = uint32(len(aNew))
+
+	/* The new slots are already zeroed, apart from the cursor number */
+	for i := iStart; i < iStart+nExtra; i++ {
+		pSrc.a[i].iCursor = -1
+	}
+
+	/* Return a pointer to the enlarged SrcList */
+	return pSrc
+}
+
+/*
+** Append a new table name to the given SrcList.  Create a new SrcList if
+** need be.  A new entry is created in the SrcList even if pTable is NULL.
+**
+** A SrcList is returned, or NULL if there is an OOM error or if the
+** SrcList grows to large.  The returned
+** SrcList might be the same as the SrcList that was input or it might be
+** a new one.  If an OOM error does occurs, then the prior value of pList
+** that is input to this routine is automatically freed.
+**
+** If pDatabase is not null, it means that the table has an optional
+** database name prefix.  Like this:  "database.table".  The pDatabase
+** points to the table name and the pTable points to the database name.
+** The SrcList.a[].zName field is filled with the table name which might
+** come from pTable (if pDatabase is NULL) or from pDatabase.
+** SrcList.a[].zDatabase is filled with the database name from pTable,
+** or with NULL if no database is specified.
+**
+** In other words, if call like this:
+**
+**         sqlite3SrcListAppend(D,A,B,0);
+**
+** Then B is a table name and the database name is unspecified.  If called
+** like this:
+**
+**         sqlite3SrcListAppend(D,A,B,C);
+**
+** Then C is the table name and B is the database name.  If C is defined
+** then so is B.  In other words, we never have a case where:
+**
+**         sqlite3SrcListAppend(D,A,0,C);
+**
+** Both pTable and pDatabase are assumed to be quoted.  They are dequoted
+** before being added to the SrcList.
+ */
+func sqlite3SrcListAppend(
+	pParse *Parse, /* Parsing context, in which errors are reported */
+	pList *SrcList, /* Append to this SrcList. NULL creates a new SrcList */
+	pTable *Token, /* Table to append */
+	pDatabase *Token, /* Database of the table */
+) *SrcList {
+	var pItem *SrcItem
+	assert(pDatabase == nil || pTable != nil, "pDatabase == nil || pTable != nil") /* Cannot have C without B */
+	assert(pParse != nil, "pParse != nil")
+	assert(pParse.db != nil, "pParse.db != nil")
+	db := pParse.db
+	if pList == nil {
+		pList = &SrcList{}
+		pList.nAlloc = 1
+		pList.nSrc = 1
+		pList.a = make([]SrcItem, 1)
+		pList.a[0].iCursor = -1
+	} else {
+		pNew := sqlite3SrcListEnlarge(pParse, pList, 1, pList.nSrc)
+		if pNew == nil {
+			sqlite3SrcListDelete(db, pList)
+			return nil
+		} else {
+			pList = pNew
+		}
+	}
+	pItem = &pList.a[pList.nSrc-1]
+	if pDatabase != nil && pDatabase.z == nil {
+		pDatabase = nil
+	}
+	if pDatabase != nil {
+		pItem.zName = sqlite3NameFromToken(db, pDatabase)
+		pItem.zDatabase = sqlite3NameFromToken(db, pTable)
+	} else {
+		pItem.zName = sqlite3NameFromToken(db, pTable)
+		pItem.zDatabase = nil
+	}
+	return pList
+}
+
+/*
+** This routine is called by the parser to add a new term to the
+** end of a growing FROM clause.  The "p" parameter is the part of
+** the FROM clause that has already been constructed.  "p" is NULL
+** if this is the first term of the FROM clause.  pTable and pDatabase
+** are the name of the table and database named in the FROM clause term.
+** pDatabase is NULL if the database name qualifier is missing - the
+** usual case.  If the term has an alias, then pAlias points to the
+** alias token.  If the term is a subquery, then pSubquery is the
+** SELECT statement that the subquery encodes.  The pTable and
+** pDatabase parameters are NULL for subqueries.  The pOnUsing
+** parameter holds the ON or USING clause, if any.
+**
+** Return a new SrcList which encodes is the FROM with the new
+** term added.
+ */
+func sqlite3SrcListAppendFromTerm(
+	pParse *Parse, /* Parsing context */
+	p *SrcList, /* The left part of the FROM clause already seen */
+	pTable *Token, /* Name of the table to add to the FROM clause */
+	pDatabase *Token, /* Name of the database containing pTable */
+	pAlias *Token, /* The right-hand side of the AS subexpression */
+	pSubquery *Select, /* A subquery used in place of a table name */
+	pOnUsing *OnOrUsing, /* Either the ON clause or the USING clause */
+) *SrcList {
+	var pItem *SrcItem
+	db := pParse.db
+	if p == nil && pOnUsing != nil && (pOnUsing.pOn != nil || pOnUsing.pUsing != nil) {
+		zClause := "USING"
+		if pOnUsing.pOn != nil {
+			zClause = "ON"
+		}
+		sqlite3ErrorMsg(pParse, "a JOIN clause is required before %s", zClause)
+		goto append_from_error
+	}
+	p = sqlite3SrcListAppend(pParse, p, pTable, pDatabase)
+	if p == nil {
+		goto append_from_error
+	}
+	assert(p.nSrc > 0, "p.nSrc > 0")
+	pItem = &p.a[p.nSrc-1]
+	assert(pAlias != nil, "pAlias != nil")
+	if pAlias.n != 0 {
+		pItem.zAlias = sqlite3NameFromToken(db, pAlias)
+	}
+	if pSubquery != nil {
+		pItem.pSelect = pSubquery
+	}
+	assert(pOnUsing == nil || pOnUsing.pOn == nil || pOnUsing.pUsing == nil,
+		"pOnUsing == nil || pOnUsing.pOn == nil || pOnUsing.pUsing == nil")
+	if pOnUsing == nil {
+		pItem.u3.pOn = nil
+	} else if pOnUsing.pUsing != nil {
+		pItem.u3.pUsing = pOnUsing.pUsing
+	} else {
+		pItem.u3.pOn = pOnUsing.pOn
+	}
+	return p
+
+append_from_error:
+	assert(p == nil, "p == nil")
+	if pOnUsing != nil {
+		sqlite3ExprDelete(db, pOnUsing.pOn)
+		sqlite3IdListDelete(db, pOnUsing.pUsing)
+	}
+	sqlite3SelectDelete(db, pSubquery)
+	return nil
+}
+
+/*
+** Add an INDEXED BY or NOT INDEXED clause to the most recently added
+** element of the source-list passed as the second argument.
+ */
+func sqlite3SrcListIndexedBy(pParse *Parse, p *SrcList, pIndexedBy *Token) {
+	assert(pIndexedBy != nil, "pIndexedBy != nil")
+	if p != nil && pIndexedBy.n > 0 {
+		var pItem *SrcItem
+		assert(p.nSrc > 0, "p.nSrc > 0")
+		pItem = &p.a[p.nSrc-1]
+		assert(pItem.fg.notIndexed == 0, "pItem.fg.notIndexed == 0")
+		if pIndexedBy.n == 1 && pIndexedBy.z == nil {
+			/* A "NOT INDEXED" clause was supplied. See parse.y
+			 ** construct "indexed_opt" for details. */
+			pItem.fg.notIndexed = 1
+		} else {
+			pItem.u1.zIndexedBy = sqlite3NameFromToken(pParse.db, pIndexedBy)
+		}
+	}
+}
+
+/*
+** Append the contents of SrcList p2 to SrcList p1 and return the resulting
+** SrcList. Or, if an error occurs, return NULL. In all cases, p1 and p2
+** are deleted by this function.
+ */
+func sqlite3SrcListAppendList(pParse *Parse, p1 *SrcList, p2 *SrcList) *SrcList {
+	assert(p1 != nil && p1.nSrc == 1, "p1 != nil && p1.nSrc == 1")
+	if p2 != nil {
+		pNew := sqlite3SrcListEnlarge(pParse, p1, p2.nSrc, 1)
+		if pNew == nil {
+			sqlite3SrcListDelete(pParse.db, p2)
+		} else {
+			p1 = pNew
+			copy(p1.a[1:], p2.a[:p2.nSrc])
+			p1.a[0].fg.jointype |= JT_LTORJ & p1.a[1].fg.jointype
+		}
+	}
+	return p1
+}
+
+/*
+** Add the list of function arguments to the SrcList entry for a
+** table-valued-function.
+ */
+func sqlite3SrcListFuncArgs(pParse *Parse, p *SrcList, pList *ExprList) {
+	if p != nil {
+		pItem := &p.a[p.nSrc-1]
+		assert(pItem.fg.notIndexed == 0, "pItem.fg.notIndexed == 0")
+		assert(pItem.u1.zIndexedBy == nil, "pItem.u1.zIndexedBy == nil")
+		assert(pItem.u1.pFuncArg == nil, "pItem.u1.pFuncArg == nil")
+		pItem.u1.pFuncArg = pList
+	} else {
+		sqlite3ExprListDelete(pParse.db, pList)
+	}
+}
+
+/*
+** When building up a FROM clause in the parser, the join operator
+** is initially attached to the left operand.  But the code generator
+** expects the join operator to be on the right operand.  This routine
+** Shifts all join operators from left to right for an entire FROM
+** clause.
+**
+** Example: Suppose the join is like this:
+**
+**           A natural cross join B
+**
+** The operator is "natural cross join".  The A and B operands are stored
+** in p->a[0] and p->a[1], respectively.  The parser initially stores the
+** operator with A.  This routine shifts that operator over to B.
+**
+** Additional changes:
+**
+**   *   All tables to the left of the right-most RIGHT JOIN are tagged with
+**       JT_LTORJ (mnemonic: Left Table Of Right Join) so that the
+**       code generator can easily tell that the table is part of
+**       the left operand of at least one RIGHT JOIN.
+ */
+func sqlite3SrcListShiftJoinType(pParse *Parse, p *SrcList) {
+	if p != nil && p.nSrc > 1 {
+		i := p.nSrc - 1
+		var allFlags uint8
+		for {
+			p.a[i].fg.jointype = p.a[i-1].fg.jointype
+			allFlags |= p.a[i].fg.jointype
+			i--
+			if i <= 0 {
+				break
+			}
+		}
+		p.a[0].fg.jointype = 0
+
+		/* All terms to the left of a RIGHT JOIN should be tagged with the
+		 ** JT_LTORJ flags */
+		if (allFlags & JT_RIGHT) != 0 {
+			for i = p.nSrc - 1; ALWAYS(i > 0) && (p.a[i].fg.jointype&JT_RIGHT) == 0; i-- {
+			}
+			i--
+			assert(i >= 0, "i >= 0")
+			for {
+				p.a[i].fg.jointype |= JT_LTORJ
+				i--
+				if i < 0 {
+					break
+				}
+			}
+		}
+	}
+}
+
+/*
+** Generate code for the REINDEX command.
+**
+**        REINDEX                            -- 1
+**        REINDEX  <collation>               -- 2
+**        REINDEX  ?<database>.?<tablename>  -- 3
+**        REINDEX  ?<database>.?<indexname>  -- 4
+**
+** Form 1 causes all indices in all attached databases to be rebuilt.
+** Form 2 rebuilds all indices in all databases that use the named
+** collating function.  Forms 3 and 4 rebuild the named index or all
+** indices associated with the named table.
+ */
+func sqlite3Reindex(pParse *Parse, pName1 *Token, pName2 *Token) {
+	if sqlite3IsOmitted(pParse, OmitReindex, "REINDEX") {
+		return
+	}
+}
+
+/*
+** Create a new CTE object
+ */
+func sqlite3CteNew(
+	pParse *Parse, /* Parsing context */
+	pName *Token, /* Name of the common-table */
+	pArglist *ExprList, /* Optional column name list for the table */
+	pQuery *Select, /* Query used to initialize the table */
+	eM10d uint8, /* The MATERIALIZED flag */
+) *Cte {
+	var pNew *Cte
+
+	if sqlite3IsOmitted(pParse, OmitCTE, "common table expressions") {
+		return nil
+	}
+	pNew = &Cte{}
+	pNew.pSelect = pQuery
+	pNew.pCols = pArglist
+	pNew.zName = sqlite3NameFromToken(pParse.db, pName)
+	pNew.eM10d = eM10d
+	return pNew
+}
+
+/*
+** This routine is invoked once per CTE by the parser while parsing a
+** WITH clause.  The CTE described by teh third argument is added to
+** the WITH clause of the second argument.  If the second argument is
+** NULL, then a new WITH argument is created.
+ */
+func sqlite3WithAdd(
+	pParse *Parse, /* Parsing context */
+	pWith *With, /* Existing WITH clause, or NULL */
+	pCte *Cte, /* CTE to add to the WITH clause */
+) *With {
+	var pNew *With
+	var zName []byte
+
+	if pCte == nil {
+		return pWith
+	}
+
+	/* Check that the CTE name is unique within this WITH clause. If
+	 ** not, store an error in the Parse structure. */
+	zName = pCte.zName
+	if zName != nil && pWith != nil {
+		for i := 0; i < pWith.nCte; i++ {
+			if sqlite3StrICmp(zName, pWith.a[i].zName) == 0 {
+				sqlite3ErrorMsg(pParse, "duplicate WITH table name: %s", zName)
+			}
+		}
+	}
+
+	if pWith != nil {
+		pNew = pWith
+	} else {
+		pNew = &With{}
+	}
+	pNew.a = append(pNew.a, *pCte)
+	pNew.nCte++
+	return pNew
+}
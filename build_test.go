@@ -0,0 +1,80 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Tests for building CREATE VIEW objects.
+ */
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+/*
+** A view must get the column names sqlite3ColumnsFromExprList() would
+** give it, or the names of its column list.
+ */
+func TestView(t *testing.T) {
+	aTest := []struct {
+		zSql   string
+		azWant []string
+	}{
+		{"CREATE VIEW v AS SELECT a, a, b AS a, 1+2, TRUE, x.y FROM t",
+			[]string{"a", "a:1", "a:2", "1+2", "column5", "y"}},
+		{"CREATE VIEW v(p, q) AS SELECT a, b FROM t",
+			[]string{"p", "q"}},
+	}
+	for _, tc := range aTest {
+		aStmt, err := ParseSQL(tc.zSql, nil)
+		if err != nil {
+			t.Errorf("%s: %v", tc.zSql, err)
+			continue
+		}
+		pTab := aStmt[0].pTable
+		if pTab == nil || pTab.u.view.pSelect == nil {
+			t.Errorf("%s: no view recorded", tc.zSql)
+			continue
+		}
+		var azCol []string
+		for i := 0; i < int(pTab.nCol); i++ {
+			azCol = append(azCol, string(pTab.aCol[i].zCnName))
+		}
+		if !reflect.DeepEqual(azCol, tc.azWant) {
+			t.Errorf("%s: got columns %q, want %q", tc.zSql, azCol, tc.azWant)
+		}
+	}
+}
+
+/*
+** The checks that need no schema must be reported the way SQLite
+** reports them.
+ */
+func TestViewError(t *testing.T) {
+	aTest := []struct {
+		zSql string
+		zErr string
+	}{
+		{"CREATE VIEW v(x, y) AS SELECT 1",
+			"expected 2 columns for 'v' but got 1"},
+		{"CREATE TEMP VIEW main.v AS SELECT 1",
+			"temporary table name must be unqualified"},
+		{"CREATE VIEW sqlite_v AS SELECT 1",
+			"object name reserved for internal use: sqlite_v"},
+		{"CREATE VIEW v AS SELECT ?",
+			"parameters are not allowed in views"},
+	}
+	for _, tc := range aTest {
+		_, err := ParseSQL(tc.zSql, nil)
+		if pErr, ok := err.(*Error); !ok || pErr.Msg != tc.zErr {
+			t.Errorf("%s: got %v, want %q", tc.zSql, err, tc.zErr)
+		}
+	}
+}
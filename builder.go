@@ -0,0 +1,625 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains routines that build parse trees from Go code
+** instead of from SQL text.
+**
+** The trees are made by the same constructors the parser uses, so a
+** built tree has the same shape as the tree the parser would produce
+** for its SQL text.  For example:
+**
+**     p := NewSelect(Col("a")).From("t").
+**         Where(Eq(Col("b"), Param("x"))).
+**         OrderBy(Col("a"))
+**     p.SQL()  ==  "SELECT a FROM t WHERE b = :x ORDER BY a"
+**
+** Names and literal values are passed as they should appear in the
+** tree, without quotes.  The text is quoted as required by deparse.go
+** when the tree is rendered.
+**
+** The parser reports errors such as "too many FROM clause terms" to
+** its caller.  The builder panics instead, since such an error means
+** that the Go program that called it is wrong.
+ */
+package internal
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+/*
+** Return a new parsing context for one builder operation.  Only the
+** database connection, which holds the limits, and the error fields
+** are used.
+ */
+func builderParse() *Parse {
+	return &Parse{db: openDatabase()}
+}
+
+/*
+** Panic if an error was reported to pParse.
+ */
+func builderCheck(pParse *Parse) {
+	if pParse.nErr > 0 {
+		panic("golite: " + string(pParse.zErrMsg))
+	}
+}
+
+/*
+** Return a token that refers to the text of z.
+ */
+func builderToken(z string) *Token {
+	return &Token{z: []byte(z), n: uint(len(z))}
+}
+
+/*
+** Return a node with operator op and subtrees pLeft and pRight.
+ */
+func builderPExpr(op int, pLeft, pRight *Expr) *Expr {
+	pParse := builderParse()
+	p := sqlite3PExpr(pParse, op, pLeft, pRight)
+	builderCheck(pParse)
+	return p
+}
+
+/*
+** Return a new ExprList holding the expressions in a.
+ */
+func builderExprList(pParse *Parse, a []*Expr) *ExprList {
+	var pList *ExprList
+	for _, pExpr := range a {
+		pList = sqlite3ExprListAppend(pParse, pList, pExpr)
+	}
+	return pList
+}
+
+/*
+** Col returns a reference to a column.  With more than one argument
+** the names are qualified by each other, so Col("t", "a") is "t.a" and
+** Col("main", "t", "a") is "main.t.a".
+ */
+func Col(azName ...string) *Expr {
+	assert(len(azName) > 0, "len(azName) > 0")
+	db := openDatabase()
+	p := sqlite3Expr(db, TK_ID, []byte(azName[len(azName)-1]))
+	for i := len(azName) - 2; i >= 0; i-- {
+		pLeft := sqlite3Expr(db, TK_ID, []byte(azName[i]))
+		p = builderPExpr(TK_DOT, pLeft, p)
+	}
+	return p
+}
+
+/*
+** Star returns the "*" that stands for every column of the result.
+ */
+func Star() *Expr {
+	return sqlite3Expr(openDatabase(), TK_ASTERISK, nil)
+}
+
+/*
+** Param returns a bound parameter.  An empty name gives an anonymous
+** "?".  A name that does not begin with one of "?:@$" is given a ":"
+** prefix.  Variable numbers are not assigned to built parameters.
+ */
+func Param(zName string) *Expr {
+	if zName == "" {
+		zName = "?"
+	} else if !strings.ContainsRune("?:@$", rune(zName[0])) {
+		zName = ":" + zName
+	}
+	return sqlite3Expr(openDatabase(), TK_VARIABLE, []byte(zName))
+}
+
+/*
+** Int returns an integer literal.  As in SQL text, a negative value is
+** the unary minus operator applied to a positive literal.
+ */
+func Int(iVal int64) *Expr {
+	db := openDatabase()
+	if iVal < 0 {
+		zNum := strconv.FormatUint(uint64(-(iVal+1))+1, 10)
+		return builderPExpr(TK_UMINUS, sqlite3Expr(db, TK_INTEGER, []byte(zNum)), nil)
+	}
+	return sqlite3Expr(db, TK_INTEGER, []byte(strconv.FormatInt(iVal, 10)))
+}
+
+/*
+** Float returns a floating point literal.  SQL has no literal for NaN,
+** which becomes NULL, as it does when bound as a parameter.  Infinity
+** is written as 9e999, which overflows to infinity when it is read.
+ */
+func Float(rVal float64) *Expr {
+	var zNum string
+	if math.IsNaN(rVal) {
+		return Null()
+	}
+	bNeg := math.Signbit(rVal)
+	if bNeg {
+		rVal = -rVal
+	}
+	if math.IsInf(rVal, 1) {
+		zNum = "9e999"
+	} else {
+		zNum = strconv.FormatFloat(rVal, 'g', -1, 64)
+		if !strings.ContainsAny(zNum, ".e") {
+			zNum += ".0"
+		}
+	}
+	p := sqlite3Expr(openDatabase(), TK_FLOAT, []byte(zNum))
+	if bNeg {
+		p = builderPExpr(TK_UMINUS, p, nil)
+	}
+	return p
+}
+
+/*
+** String returns a string literal.
+ */
+func String(z string) *Expr {
+	return sqlite3Expr(openDatabase(), TK_STRING, []byte(z))
+}
+
+/*
+** Blob returns a BLOB literal.
+ */
+func Blob(a []byte) *Expr {
+	z := make([]byte, 0, 3+2*len(a))
+	z = append(z, "x'"...)
+	for _, c := range a {
+		z = append(z, "0123456789abcdef"[c>>4], "0123456789abcdef"[c&0xf])
+	}
+	z = append(z, '\'')
+	return sqlite3Expr(openDatabase(), TK_BLOB, z)
+}
+
+/*
+** Null returns the NULL literal.
+ */
+func Null() *Expr {
+	return sqlite3Expr(openDatabase(), TK_NULL, []byte("NULL"))
+}
+
+/* Comparison and arithmetic operators */
+func Eq(pLeft, pRight *Expr) *Expr     { return builderPExpr(TK_EQ, pLeft, pRight) }
+func Ne(pLeft, pRight *Expr) *Expr     { return builderPExpr(TK_NE, pLeft, pRight) }
+func Lt(pLeft, pRight *Expr) *Expr     { return builderPExpr(TK_LT, pLeft, pRight) }
+func Le(pLeft, pRight *Expr) *Expr     { return builderPExpr(TK_LE, pLeft, pRight) }
+func Gt(pLeft, pRight *Expr) *Expr     { return builderPExpr(TK_GT, pLeft, pRight) }
+func Ge(pLeft, pRight *Expr) *Expr     { return builderPExpr(TK_GE, pLeft, pRight) }
+func Add(pLeft, pRight *Expr) *Expr    { return builderPExpr(TK_PLUS, pLeft, pRight) }
+func Sub(pLeft, pRight *Expr) *Expr    { return builderPExpr(TK_MINUS, pLeft, pRight) }
+func Mul(pLeft, pRight *Expr) *Expr    { return builderPExpr(TK_STAR, pLeft, pRight) }
+func Div(pLeft, pRight *Expr) *Expr    { return builderPExpr(TK_SLASH, pLeft, pRight) }
+func Concat(pLeft, pRight *Expr) *Expr { return builderPExpr(TK_CONCAT, pLeft, pRight) }
+
+/*
+** Is returns "pLeft IS pRight" and IsNot returns "pLeft IS NOT pRight".
+** If pRight is NULL the result is "pLeft ISNULL" or "pLeft NOTNULL",
+** as it is from the parser.
+ */
+func Is(pLeft, pRight *Expr) *Expr    { return builderIs(TK_IS, TK_ISNULL, pLeft, pRight) }
+func IsNot(pLeft, pRight *Expr) *Expr { return builderIs(TK_ISNOT, TK_NOTNULL, pLeft, pRight) }
+
+/*
+** Return a node with the binary operator op, or with the unary operator
+** opNull if pRight is the NULL literal.
+ */
+func builderIs(op int, opNull int, pLeft, pRight *Expr) *Expr {
+	pParse := builderParse()
+	p := sqlite3PExpr(pParse, op, pLeft, pRight)
+	binaryToUnaryIfNull(pParse, pRight, p, opNull)
+	builderCheck(pParse)
+	return p
+}
+
+/*
+** And returns the conjunction of its arguments.  Nil arguments are
+** ignored, so a filter can be grown one optional term at a time.  And
+** returns nil if every argument is nil.
+ */
+func And(a ...*Expr) *Expr {
+	var p *Expr
+	pParse := builderParse()
+	for _, pExpr := range a {
+		p = sqlite3ExprAnd(pParse, p, pExpr)
+	}
+	builderCheck(pParse)
+	return p
+}
+
+/*
+** Or returns the disjunction of its arguments.  Nil arguments are
+** ignored.  Or returns nil if every argument is nil.
+ */
+func Or(a ...*Expr) *Expr {
+	var p *Expr
+	for _, pExpr := range a {
+		if pExpr == nil {
+			continue
+		}
+		if p == nil {
+			p = pExpr
+		} else {
+			p = builderPExpr(TK_OR, p, pExpr)
+		}
+	}
+	return p
+}
+
+/*
+** Not returns the negation of pExpr.  Applied to the result of Like,
+** Glob, Between or In it gives "x NOT LIKE y" and so on, which the
+** parser represents in the same way.
+ */
+func Not(pExpr *Expr) *Expr {
+	p := builderPExpr(TK_NOT, pExpr, nil)
+	if pExpr.op == TK_FUNCTION && ExprHasProperty(pExpr, EP_InfixFunc) {
+		/* The parser sets EP_InfixFunc on the NOT, not on the function */
+		pExpr.flags &^= EP_InfixFunc
+		p.flags |= EP_InfixFunc
+	}
+	return p
+}
+
+/* IsNull returns "x ISNULL" and NotNull returns "x NOTNULL" */
+func IsNull(pExpr *Expr) *Expr  { return builderPExpr(TK_ISNULL, pExpr, nil) }
+func NotNull(pExpr *Expr) *Expr { return builderPExpr(TK_NOTNULL, pExpr, nil) }
+
+/*
+** Between returns "pExpr BETWEEN pLo AND pHi".
+ */
+func Between(pExpr, pLo, pHi *Expr) *Expr {
+	pParse := builderParse()
+	pList := builderExprList(pParse, []*Expr{pLo, pHi})
+	p := sqlite3PExpr(pParse, TK_BETWEEN, pExpr, nil)
+	p.x.pList = pList
+	sqlite3ExprSetHeightAndFlags(pParse, p)
+	builderCheck(pParse)
+	return p
+}
+
+/*
+** In returns "pExpr IN (a...)".  The parser turns "x IN ()" into
+** the constant false, and "x IN (c)" for a constant c into "x == +c",
+** and so does In.
+ */
+func In(pExpr *Expr, a ...*Expr) *Expr {
+	if len(a) == 0 {
+		return Int(0)
+	}
+	if len(a) == 1 && sqlite3ExprIsConstant(a[0]) != 0 && pExpr.op != TK_VECTOR {
+		return builderPExpr(TK_EQ, pExpr, builderPExpr(TK_UPLUS, a[0], nil))
+	}
+	pParse := builderParse()
+	p := sqlite3PExpr(pParse, TK_IN, pExpr, nil)
+	p.x.pList = builderExprList(pParse, a)
+	sqlite3ExprSetHeightAndFlags(pParse, p)
+	builderCheck(pParse)
+	return p
+}
+
+/*
+** InSelect returns "pExpr IN (pSelect)".
+ */
+func InSelect(pExpr *Expr, pSelect *Select) *Expr {
+	pParse := builderParse()
+	p := sqlite3PExpr(pParse, TK_IN, pExpr, nil)
+	sqlite3PExprAddSelect(pParse, p, pSelect)
+	builderCheck(pParse)
+	return p
+}
+
+/*
+** Exists returns "EXISTS (pSelect)".
+ */
+func Exists(pSelect *Select) *Expr {
+	pParse := builderParse()
+	p := sqlite3PExpr(pParse, TK_EXISTS, nil, nil)
+	sqlite3PExprAddSelect(pParse, p, pSelect)
+	builderCheck(pParse)
+	return p
+}
+
+/*
+** Subquery returns "(pSelect)", a scalar subquery.
+ */
+func Subquery(pSelect *Select) *Expr {
+	pParse := builderParse()
+	p := sqlite3PExpr(pParse, TK_SELECT, nil, nil)
+	sqlite3PExprAddSelect(pParse, p, pSelect)
+	builderCheck(pParse)
+	return p
+}
+
+/*
+** Return "pExpr zOp pPattern [ESCAPE pEscape]".  As in the parser, the
+** operator is a function whose arguments are in reverse order.
+ */
+func builderLike(zOp string, pExpr, pPattern, pEscape *Expr) *Expr {
+	a := []*Expr{pPattern, pExpr}
+	if pEscape != nil {
+		a = append(a, pEscape)
+	}
+	pParse := builderParse()
+	p := sqlite3ExprFunction(pParse, builderExprList(pParse, a), builderToken(zOp), 0)
+	p.w.iOfst = 0
+	p.flags |= EP_InfixFunc
+	builderCheck(pParse)
+	return p
+}
+
+/*
+** Like returns "pExpr LIKE pPattern".  Glob returns "pExpr GLOB
+** pPattern".  If pEscape is not nil, it is the ESCAPE character.
+ */
+func Like(pExpr, pPattern, pEscape *Expr) *Expr {
+	return builderLike("like", pExpr, pPattern, pEscape)
+}
+func Glob(pExpr, pPattern *Expr) *Expr {
+	return builderLike("glob", pExpr, pPattern, nil)
+}
+
+/*
+** Func returns a call to function zName.  Func("count") with no
+** arguments is "count(*)".
+ */
+func Func(zName string, a ...*Expr) *Expr {
+	pParse := builderParse()
+	p := sqlite3ExprFunction(pParse, builderExprList(pParse, a), builderToken(zName), 0)
+	p.w.iOfst = 0
+	builderCheck(pParse)
+	return p
+}
+
+/*
+** Cast returns "CAST(pExpr AS zType)".
+ */
+func Cast(pExpr *Expr, zType string) *Expr {
+	db := openDatabase()
+	p := sqlite3ExprAlloc(db, TK_CAST, builderToken(zType), 0)
+	sqlite3ExprAttachSubtrees(db, p, pExpr, nil)
+	return p
+}
+
+/*
+** Collate returns "pExpr COLLATE zName".
+ */
+func Collate(pExpr *Expr, zName string) *Expr {
+	pParse := builderParse()
+	p := sqlite3ExprAddCollateToken(pParse, pExpr, builderToken(zName), 0)
+	builderCheck(pParse)
+	return p
+}
+
+/*
+** NewSelect returns "SELECT a... " with no FROM clause.  With no
+** arguments the result is "SELECT *".  The methods of Select add the
+** other clauses.  Each method modifies p and returns it.
+ */
+func NewSelect(a ...*Expr) *Select {
+	pParse := builderParse()
+	p := sqlite3SelectNew(pParse, builderExprList(pParse, a), nil, nil, nil, nil, nil, 0, nil)
+	builderCheck(pParse)
+	return p
+}
+
+/*
+** Column adds a result column named zAlias.  If zAlias is "" the
+** column has no AS clause.
+ */
+func (p *Select) Column(pExpr *Expr, zAlias string) *Select {
+	pParse := builderParse()
+	p.pEList = sqlite3ExprListAppend(pParse, p.pEList, pExpr)
+	if zAlias != "" {
+		sqlite3ExprListSetName(pParse, p.pEList, builderToken(zAlias), 0)
+	}
+	builderCheck(pParse)
+	return p
+}
+
+/*
+** Distinct makes p a SELECT DISTINCT.
+ */
+func (p *Select) Distinct() *Select {
+	p.selFlags |= SF_Distinct
+	return p
+}
+
+/*
+** Add a term to the FROM clause of p.  jointype is the join with the
+** previous term.
+ */
+func (p *Select) builderFrom(zName string, pSub *Select, jointype uint8, pOn *Expr) *Select {
+	var pTable *Token
+	var pDatabase *Token
+	var sAlias Token
+	var sOnUsing OnOrUsing
+	pParse := builderParse()
+	if pSub == nil {
+		if i := strings.LastIndexByte(zName, '.'); i >= 0 {
+			pTable = builderToken(zName[:i])
+			pDatabase = builderToken(zName[i+1:])
+		} else {
+			pTable = builderToken(zName)
+		}
+	}
+	pSrc := p.pSrc
+	if pSrc.nSrc == 0 {
+		pSrc = nil
+	}
+	sOnUsing.pOn = pOn
+	pSrc = sqlite3SrcListAppendFromTerm(pParse, pSrc, pTable, pDatabase, &sAlias, pSub, &sOnUsing)
+	builderCheck(pParse)
+	pItem := &pSrc.a[pSrc.nSrc-1]
+	if pSrc.nSrc > 1 {
+		/* The parser records the join type on the term to the left and
+		 ** shifts it once the FROM clause is complete.  Store it where
+		 ** sqlite3SrcListShiftJoinType() would have put it. */
+		pItem.fg.jointype = jointype
+		if (jointype & JT_RIGHT) != 0 {
+			for i := 0; i < pSrc.nSrc-1; i++ {
+				pSrc.a[i].fg.jointype |= JT_LTORJ
+			}
+		}
+	}
+	p.pSrc = pSrc
+	return p
+}
+
+/*
+** From adds table zName to the FROM clause.  The name may be qualified
+** by a schema name, as in "main.t".  Terms after the first are joined
+** with a comma.
+ */
+func (p *Select) From(zName string) *Select {
+	return p.builderFrom(zName, nil, JT_INNER, nil)
+}
+
+/*
+** Join adds "JOIN zName ON pOn" to the FROM clause.  LeftJoin adds
+** "LEFT JOIN zName ON pOn".  pOn may be nil.
+ */
+func (p *Select) Join(zName string, pOn *Expr) *Select {
+	return p.builderFrom(zName, nil, JT_INNER, pOn)
+}
+func (p *Select) LeftJoin(zName string, pOn *Expr) *Select {
+	return p.builderFrom(zName, nil, JT_LEFT|JT_OUTER, pOn)
+}
+
+/*
+** FromSelect adds the subquery "(pSub) AS zAlias" to the FROM clause.
+ */
+func (p *Select) FromSelect(pSub *Select, zAlias string) *Select {
+	p.builderFrom("", pSub, JT_INNER, nil)
+	return p.As(zAlias)
+}
+
+/*
+** As sets the alias of the last term of the FROM clause.
+ */
+func (p *Select) As(zAlias string) *Select {
+	assert(p.pSrc.nSrc > 0, "p.pSrc.nSrc > 0")
+	p.pSrc.a[p.pSrc.nSrc-1].zAlias = []byte(zAlias)
+	return p
+}
+
+/*
+** Where adds pExpr to the WHERE clause.  If there is already a WHERE
+** clause the two are joined with AND.
+ */
+func (p *Select) Where(pExpr *Expr) *Select {
+	p.pWhere = And(p.pWhere, pExpr)
+	return p
+}
+
+/*
+** GroupBy appends a... to the GROUP BY clause.
+ */
+func (p *Select) GroupBy(a ...*Expr) *Select {
+	pParse := builderParse()
+	for _, pExpr := range a {
+		p.pGroupBy = sqlite3ExprListAppend(pParse, p.pGroupBy, pExpr)
+	}
+	builderCheck(pParse)
+	return p
+}
+
+/*
+** Having adds pExpr to the HAVING clause.  If there is already a HAVING
+** clause the two are joined with AND.
+ */
+func (p *Select) Having(pExpr *Expr) *Select {
+	p.pHaving = And(p.pHaving, pExpr)
+	return p
+}
+
+/*
+** Append pExpr to the ORDER BY clause with sort order iSortOrder.
+ */
+func (p *Select) builderOrderBy(pExpr *Expr, iSortOrder int) *Select {
+	pParse := builderParse()
+	p.pOrderBy = sqlite3ExprListAppend(pParse, p.pOrderBy, pExpr)
+	sqlite3ExprListSetSortOrder(p.pOrderBy, iSortOrder, SQLITE_SO_UNDEFINED)
+	builderCheck(pParse)
+	return p
+}
+
+/*
+** OrderBy appends pExpr to the ORDER BY clause.  OrderByDesc appends
+** "pExpr DESC".  For a compound SELECT, call these on the SELECT
+** returned by Union, UnionAll, Intersect or Except.
+ */
+func (p *Select) OrderBy(pExpr *Expr) *Select {
+	return p.builderOrderBy(pExpr, SQLITE_SO_ASC)
+}
+func (p *Select) OrderByDesc(pExpr *Expr) *Select {
+	return p.builderOrderBy(pExpr, SQLITE_SO_DESC)
+}
+
+/*
+** Limit sets the LIMIT of p.  Offset sets the OFFSET.  An OFFSET with
+** no LIMIT is given "LIMIT -1", which SQL requires and which means no
+** limit.
+ */
+func (p *Select) Limit(pExpr *Expr) *Select {
+	if p.pLimit == nil {
+		p.pLimit = builderPExpr(TK_LIMIT, pExpr, nil)
+	} else {
+		p.pLimit.pLeft = pExpr
+	}
+	return p
+}
+func (p *Select) Offset(pExpr *Expr) *Select {
+	if p.pLimit == nil {
+		p.pLimit = builderPExpr(TK_LIMIT, Int(-1), pExpr)
+	} else {
+		p.pLimit.pRight = pExpr
+	}
+	return p
+}
+
+/*
+** Return the compound "p op pRhs".  This is the work of the
+** selectnowith rule of the grammar.
+ */
+func (p *Select) builderCompound(op int, pRhs *Select) *Select {
+	pParse := builderParse()
+	if pRhs.pPrior != nil {
+		/* A compound on the right must be a subquery, as in
+		 ** "SELECT ... UNION SELECT * FROM (SELECT ... EXCEPT ...)" */
+		var x Token
+		parserDoubleLinkSelect(pParse, pRhs)
+		pFrom := sqlite3SrcListAppendFromTerm(pParse, nil, nil, nil, &x, pRhs, nil)
+		pRhs = sqlite3SelectNew(pParse, nil, pFrom, nil, nil, nil, nil, 0, nil)
+	}
+	pRhs.op = uint8(op)
+	pRhs.pPrior = p
+	p.selFlags &^= SF_MultiValue
+	pRhs.selFlags &^= SF_MultiValue
+	if op != TK_ALL {
+		pParse.hasCompound = 1
+	}
+	parserDoubleLinkSelect(pParse, pRhs)
+	builderCheck(pParse)
+	return pRhs
+}
+
+/*
+** Union, UnionAll, Intersect and Except return the compound of p and
+** pRhs.  The result is the SELECT to which ORDER BY and LIMIT of the
+** compound as a whole should be added.
+ */
+func (p *Select) Union(pRhs *Select) *Select     { return p.builderCompound(TK_UNION, pRhs) }
+func (p *Select) UnionAll(pRhs *Select) *Select  { return p.builderCompound(TK_ALL, pRhs) }
+func (p *Select) Intersect(pRhs *Select) *Select { return p.builderCompound(TK_INTERSECT, pRhs) }
+func (p *Select) Except(pRhs *Select) *Select    { return p.builderCompound(TK_EXCEPT, pRhs) }
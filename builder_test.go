@@ -0,0 +1,94 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Tests for building statements in Go.
+ */
+package internal
+
+import "testing"
+
+/*
+** Built statements must render as the SQL that would parse into them.
+ */
+func TestBuilder(t *testing.T) {
+	aTest := []struct {
+		pSelect *Select
+		zWant   string
+	}{
+		{NewSelect(), "SELECT *"},
+		{
+			NewSelect(Col("t", "a")).Distinct().
+				Column(Func("count", Star()), "n").
+				From("t").
+				LeftJoin("u", Eq(Col("t", "id"), Col("u", "id"))).
+				Where(And(Between(Col("a"), Int(1), Int(10)), Like(Col("b"), String("x%"), nil))).
+				GroupBy(Col("t", "a")).
+				Having(Gt(Func("count", Star()), Int(2))).
+				OrderByDesc(Col("n")).
+				Limit(Int(5)),
+			"SELECT DISTINCT t.a, count(*) AS n FROM t LEFT JOIN u ON t.id = u.id " +
+				"WHERE a BETWEEN 1 AND 10 AND b LIKE 'x%' GROUP BY t.a " +
+				"HAVING count(*) > 2 ORDER BY n DESC LIMIT 5",
+		},
+		{
+			NewSelect(Mul(Add(Col("a"), Int(1)), Param(":p"))).From("t").
+				Where(Or(IsNull(Col("b")), In(Col("c"), Int(1), Int(2)))),
+			"SELECT (a + 1) * :p FROM t WHERE b ISNULL OR c IN (1, 2)",
+		},
+		{
+			NewSelect(Col("a")).From("t").Union(NewSelect(Col("b")).From("u")).
+				OrderBy(Int(1)),
+			"SELECT a FROM t UNION SELECT b FROM u ORDER BY 1",
+		},
+	}
+	for _, tc := range aTest {
+		zGot := tc.pSelect.SQL()
+		if zGot != tc.zWant {
+			t.Errorf("got  %s\nwant %s", zGot, tc.zWant)
+			continue
+		}
+		if zAgain := testSelect(t, zGot).SQL(); zAgain != zGot {
+			t.Errorf("%s parses and renders as %s", zGot, zAgain)
+		}
+	}
+}
+
+/*
+** A built expression must be Equal to the tree the parser builds from
+** the same SQL.  Parameters are left out, since the parser numbers them
+** and the builder does not.
+ */
+func TestBuilderEqual(t *testing.T) {
+	aTest := []struct {
+		pExpr *Expr
+		zSql  string
+	}{
+		{In(Col("a"), Int(1)), "a IN (1)"},
+		{In(Col("a"), Col("b")), "a IN (b)"},
+		{In(Col("a"), Int(1), Int(2)), "a IN (1, 2)"},
+		{In(Col("a")), "a IN ()"},
+		{Is(Col("a"), Null()), "a IS NULL"},
+		{IsNot(Col("a"), Null()), "a IS NOT NULL"},
+		{Is(Col("a"), Col("b")), "a IS b"},
+		{Not(Like(Col("a"), String("x%"), nil)), "a NOT LIKE 'x%'"},
+		{Between(Col("t", "a"), Int(-1), Float(2.5)), "t.a BETWEEN -1 AND 2.5"},
+		{And(Eq(Col("a"), Col("x")), nil, Or(IsNull(Col("b")), Gt(Col("c"), Int(0)))),
+			"a = x AND (b ISNULL OR c > 0)"},
+		{Cast(Collate(Col("a"), "nocase"), "TEXT"), "CAST(a COLLATE nocase AS TEXT)"},
+		{Func("coalesce", Col("a"), Blob([]byte{1, 0xab}), String("it's")),
+			"coalesce(a, x'01ab', 'it''s')"},
+	}
+	for _, tc := range aTest {
+		if pParsed := testExpr(t, tc.zSql); !tc.pExpr.Equal(pParsed) {
+			t.Errorf("%s: built %s, parsed %s", tc.zSql, tc.pExpr.SQL(), pParsed.SQL())
+		}
+	}
+}
@@ -0,0 +1,1327 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains the Catalog, which collects the tables, views,
+** indexes and triggers created by a sequence of parsed statements.
+**
+** The parser checks each statement on its own, so a CREATE INDEX cannot
+** see the columns of its table and a CREATE TRIGGER cannot tell a view
+** from a table.  A Catalog brings the statements together and repeats
+** those checks with the table in hand:
+**
+**     aStmt, err := ParseSQL(zSchema, nil)
+**     pCat, err := NewCatalog(aStmt)
+**
+** ForeignKeys returns the REFERENCES clauses of the tables, each checked
+** against its parent table.
+**
+** The ON CONFLICT clauses of an INSERT are matched against the PRIMARY
+** KEY and UNIQUE constraints of its table, as SQLite does when it
+** prepares the statement, and the columns named by each DO UPDATE
+** clause must exist.  So must the columns named by a RETURNING clause.
+** NewCatalog does not change these statements.
+**
+** ResultColumns describes the rows that a SELECT or a RETURNING clause
+** returns: the name, declared type and nullability of each column.
+** Table looks up a table or view with its columns.  The columns of a view
+** are computed from the tables it selects from.  Indexes lists the
+** indexes, with the collating sequence of each term.
+**
+** DROP and ALTER statements have no effect on a Catalog, although the
+** parser records them.  A later CREATE of a name replaces the earlier
+** object of that name.
+ */
+package internal
+
+/*
+** A Catalog is the schema described by a sequence of statements.
+ */
+type Catalog struct {
+	db     *sqlite3   /* Connection used to report errors */
+	aTable []*Table   /* Tables and views, in the order they were created */
+	aIndex []*Index   /* Indexes made by CREATE INDEX, in order */
+	aTrig  []*Trigger /* Triggers, in the order they were created */
+
+	aCteBusy []*Cte /* CTEs whose columns are being computed */
+}
+
+/*
+** NewCatalog builds the Catalog described by the CREATE statements of
+** aStmt, in order.  The first error found is returned as an *Error whose
+** Offset is within the complete SQL text that aStmt was parsed from.
+**
+** Adding a CREATE INDEX statement to the Catalog fills in the column
+** numbers of its index.
+ */
+func NewCatalog(aStmt []*Stmt) (*Catalog, error) {
+	p := &Catalog{db: openDatabase()}
+	for _, pStmt := range aStmt {
+		if pStmt.pTable != nil {
+			if err := p.addTable(pStmt, pStmt.pTable); err != nil {
+				return nil, err
+			}
+		}
+		if pStmt.pIndex != nil {
+			if err := p.addIndex(pStmt, pStmt.pIndex); err != nil {
+				return nil, err
+			}
+		}
+		if pStmt.pTrigger != nil {
+			if err := p.addTrigger(pStmt, pStmt.pTrigger); err != nil {
+				return nil, err
+			}
+		}
+		if pStmt.pInsert != nil && pStmt.pInsert.pUpsert != nil {
+			if err := p.checkUpsert(pStmt, pStmt.pInsert); err != nil {
+				return nil, err
+			}
+		}
+		if pStmt.pInsert != nil && pStmt.pInsert.pReturning != nil {
+			if err := p.checkReturning(pStmt, pStmt.pInsert.pTabList, pStmt.pInsert.pReturning); err != nil {
+				return nil, err
+			}
+		}
+		if pStmt.pUpdate != nil && pStmt.pUpdate.pReturning != nil {
+			if err := p.checkReturning(pStmt, pStmt.pUpdate.pTabList, pStmt.pUpdate.pReturning); err != nil {
+				return nil, err
+			}
+		}
+		if pStmt.pDelete != nil && pStmt.pDelete.pReturning != nil {
+			if err := p.checkReturning(pStmt, pStmt.pDelete.pTabList, pStmt.pDelete.pReturning); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return p, nil
+}
+
+/*
+** Locate the table or view called zName.  Return NULL if there is none.
+ */
+func (p *Catalog) findTable(zName []byte) *Table {
+	for _, pTab := range p.aTable {
+		if sqlite3StrICmp(pTab.zName, zName) == 0 {
+			return pTab
+		}
+	}
+	return nil
+}
+
+/*
+** Add the table or view built by the CREATE statement pStmt to the
+** Catalog, in place of any table or view of the same name.  The indexes
+** and triggers on the old table go with it.  The columns of a view are
+** computed from the tables already in the Catalog.
+ */
+func (p *Catalog) addTable(pStmt *Stmt, pTab *Table) error {
+	if IsView(pTab) {
+		if err := p.viewGetColumnNames(pStmt, pTab); err != nil {
+			return err
+		}
+	}
+	for i, pOld := range p.aTable {
+		if sqlite3StrICmp(pOld.zName, pTab.zName) == 0 {
+			p.aTable = append(p.aTable[:i], p.aTable[i+1:]...)
+			p.dropIndexes(pOld)
+			p.dropTriggers(pOld)
+			break
+		}
+	}
+	p.aTable = append(p.aTable, pTab)
+	return nil
+}
+
+/*
+** Fill in the columns of the view pView from the tables of the Catalog,
+** as sqlite3ViewGetColumnNames() does in C.  Each "*" of the result set
+** is expanded, and a column that selects a column of a table takes its
+** declared type, affinity and NOT NULL constraint.  If a table of the
+** view is not in the Catalog, the columns are those the parser found.
+ */
+func (p *Catalog) viewGetColumnNames(pStmt *Stmt, pView *Table) error {
+	var sParse Parse
+	sParse.db = p.db
+
+	p.db.errByteOffset = -1
+	pSel := pView.u.view.pSelect
+	pLeft := pSel
+	for pLeft.pPrior != nil {
+		pLeft = pLeft.pPrior
+	}
+	pScope, pEList := p.resultSetScope(pLeft, pSel.pWith, nil)
+	for i := 0; pScope.pSrc != nil && i < pScope.pSrc.nSrc; i++ {
+		if pScope.aTab[i] == nil {
+			return nil
+		}
+	}
+	if pView.pCheck != nil && pView.pCheck.nExpr != pEList.nExpr {
+		sqlite3ErrorMsg(&sParse, "expected %d columns for '%s' but got %d",
+			pView.pCheck.nExpr, pView.zName, pEList.nExpr)
+		return p.stmtError(pStmt, &sParse)
+	}
+	pTab := p.resultSetOfSelect(pSel, pView.pCheck, nil)
+	pView.nCol = pTab.nCol
+	pView.aCol = pTab.aCol
+	return nil
+}
+
+/*
+** Remove the triggers on pTab.
+ */
+func (p *Catalog) dropTriggers(pTab *Table) {
+	aTrig := p.aTrig[:0]
+	for _, pTrig := range p.aTrig {
+		if sqlite3StrICmp(pTrig.table, pTab.zName) != 0 {
+			aTrig = append(aTrig, pTrig)
+		}
+	}
+	p.aTrig = aTrig
+}
+
+/*
+** Remove the indexes made by CREATE INDEX on pTab.
+ */
+func (p *Catalog) dropIndexes(pTab *Table) {
+	aIndex := p.aIndex[:0]
+	for _, pIdx := range p.aIndex {
+		if pIdx.pTable != pTab {
+			aIndex = append(aIndex, pIdx)
+		}
+	}
+	p.aIndex = aIndex
+}
+
+/*
+** Resolve the index built by the CREATE INDEX statement pStmt against
+** its table and add it to the Catalog, in place of any index of the same
+** name.
+ */
+func (p *Catalog) addIndex(pStmt *Stmt, pIndex *Index) error {
+	var sParse Parse
+	sParse.db = p.db
+
+	p.db.errByteOffset = -1
+	pTab := p.findTable(pIndex.pTable.zName)
+	if pTab == nil {
+		sqlite3ErrorMsg(&sParse, "no such table: %s", pIndex.pTable.zName)
+	} else {
+		sqlite3ResolveIndex(&sParse, pIndex, pTab)
+	}
+	if sParse.nErr != 0 {
+		return p.stmtError(pStmt, &sParse)
+	}
+	for i, pOld := range p.aIndex {
+		if sqlite3StrICmp(pOld.zName, pIndex.zName) == 0 {
+			p.aIndex = append(p.aIndex[:i], p.aIndex[i+1:]...)
+			break
+		}
+	}
+	p.aIndex = append(p.aIndex, pIndex)
+	return nil
+}
+
+/*
+** Check the trigger built by the CREATE TRIGGER statement pStmt against
+** its table or view, as sqlite3BeginTrigger() does in C, and add it to
+** the Catalog in place of any trigger of the same name.
+ */
+func (p *Catalog) addTrigger(pStmt *Stmt, pTrig *Trigger) error {
+	var sParse Parse
+	sParse.db = p.db
+
+	p.db.errByteOffset = -1
+	pTab := p.findTable(pTrig.table)
+	if pTab == nil {
+		sqlite3ErrorMsg(&sParse, "no such table: main.%s", pTrig.table)
+	} else if IsVirtual(pTab) {
+		sqlite3ErrorMsg(&sParse, "cannot create triggers on virtual tables")
+	} else if IsView(pTab) && pTrig.bInstead == 0 {
+		/* INSTEAD of triggers are only for views and views only support
+		 ** INSTEAD of triggers.
+		 */
+		zTime := "AFTER"
+		if pTrig.tr_tm == TRIGGER_BEFORE {
+			zTime = "BEFORE"
+		}
+		sqlite3ErrorMsg(&sParse, "cannot create %s trigger on view: %s",
+			zTime, pTrig.table)
+	} else if !IsView(pTab) && pTrig.bInstead != 0 {
+		sqlite3ErrorMsg(&sParse, "cannot create INSTEAD OF"+
+			" trigger on table: %s", pTrig.table)
+	}
+	if sParse.nErr != 0 {
+		return p.stmtError(pStmt, &sParse)
+	}
+	for i, pOld := range p.aTrig {
+		if sqlite3StrICmp(pOld.zName, pTrig.zName) == 0 {
+			p.aTrig = append(p.aTrig[:i], p.aTrig[i+1:]...)
+			break
+		}
+	}
+	p.aTrig = append(p.aTrig, pTrig)
+	return nil
+}
+
+/*
+** Match the ON CONFLICT clauses of the INSERT statement pStmt against
+** the PRIMARY KEY and UNIQUE constraints of its table, which sets their
+** pUpsertIdx.  Then check that each DO UPDATE clause sets columns of
+** the table and that its SET and WHERE terms name columns of the table
+** or of EXCLUDED.  An INSERT into a table that is not in the Catalog is
+** not checked.
+ */
+func (p *Catalog) checkUpsert(pStmt *Stmt, pInsert *Insert) error {
+	var sParse Parse
+	var aIdx []*Index
+	sParse.db = p.db
+
+	p.db.errByteOffset = -1
+	pTab := p.findTable(pInsert.pTabList.a[0].zName)
+	if pTab == nil || !IsOrdinaryTable(pTab) || pTab.nCol == 0 {
+		return nil
+	}
+	for _, pIndex := range p.aIndex {
+		if pIndex.pTable == pTab {
+			aIdx = append(aIdx, pIndex)
+		}
+	}
+	if sqlite3UpsertLocateIndex(&sParse, pTab, aIdx, pInsert.pUpsert) != SQLITE_OK {
+		return p.stmtError(pStmt, &sParse)
+	}
+
+	/* The DO UPDATE terms see the table, by its alias if it has one, and
+	** the EXCLUDED pseudo-table.  EXCLUDED has the columns of the table,
+	** so making it the outer scope gives unqualified names to the table.
+	 */
+	pExcluded := &catalogScope{
+		pSrc: &SrcList{nSrc: 1, nAlloc: 1, a: []SrcItem{{zName: []byte("excluded")}}},
+		aTab: []*Table{pTab},
+	}
+	pScope := &catalogScope{
+		pSrc:   &SrcList{nSrc: 1, nAlloc: 1, a: pInsert.pTabList.a[:1]},
+		aTab:   []*Table{pTab},
+		pOuter: pExcluded,
+	}
+	for pUpsert := pInsert.pUpsert; pUpsert != nil; pUpsert = pUpsert.pNextUpsert {
+		if pUpsert.isDoUpdate == 0 {
+			continue
+		}
+		pSet := pUpsert.pUpsertSet
+		for i := 0; pSet != nil && i < pSet.nExpr; i++ {
+			zName := pSet.a[i].zEName
+			if sqlite3ColumnIndex(pTab, zName) < 0 &&
+				(!HasRowid(pTab) || !sqlite3IsRowid(zName)) {
+				sqlite3ErrorMsg(&sParse, "no such column: %s", zName)
+				return p.stmtError(pStmt, &sParse)
+			}
+		}
+		if p.checkColumns(&sParse, pScope, pSet, pUpsert.pUpsertWhere) != 0 {
+			return p.stmtError(pStmt, &sParse)
+		}
+	}
+	return nil
+}
+
+/*
+** A catalogCheck is the state of the walker used by checkColumns().
+** pScope is the scope of the innermost SELECT being walked.
+ */
+type catalogCheck struct {
+	pCat   *Catalog
+	pScope *catalogScope
+}
+
+/*
+** Check that every column named by the expressions of pList, and by
+** pExpr, is a column of a table in pScope or a scope that encloses it.
+** A subquery sees its own FROM clause first.  Return non-zero and leave
+** an error in pParse if a column does not exist.
+**
+** A name is only reported if every table it might come from is in the
+** Catalog, so a subquery or a table that the Catalog does not know may
+** hide an error.  This is the part of sqlite3ResolveExprNames() that a
+** Catalog can do without generating code.
+ */
+func (p *Catalog) checkColumns(pParse *Parse, pScope *catalogScope, pList *ExprList, pExpr *Expr) int {
+	var w Walker
+	w.pParse = pParse
+	w.xExprCallback = catalogCheckExprStep
+	w.xSelectCallback = catalogCheckSelectStep
+	w.xSelectCallback2 = catalogCheckSelectStep2
+	w.u.pCheck = &catalogCheck{pCat: p, pScope: pScope}
+	if sqlite3WalkExprList(&w, pList) != 0 || sqlite3WalkExpr(&w, pExpr) != 0 {
+		return 1
+	}
+	return 0
+}
+
+/*
+** Enter the scope of the SELECT pSelect.
+ */
+func catalogCheckSelectStep(pWalker *Walker, pSelect *Select) int {
+	pCheck := pWalker.u.pCheck
+	pCheck.pScope = pCheck.pCat.selectScope(pSelect, pSelect.pWith, pCheck.pScope)
+	return WRC_Continue
+}
+
+/*
+** Leave the scope of the SELECT pSelect.
+ */
+func catalogCheckSelectStep2(pWalker *Walker, pSelect *Select) {
+	pCheck := pWalker.u.pCheck
+	pCheck.pScope = pCheck.pScope.pOuter
+}
+
+/*
+** This is the xExprCallback of the walker used by checkColumns().
+ */
+func catalogCheckExprStep(pWalker *Walker, pExpr *Expr) int {
+	var zTab []byte
+	var zCol []byte
+	pCheck := pWalker.u.pCheck
+	switch pExpr.op {
+	case TK_ID:
+		zCol = pExpr.u.zToken
+	case TK_DOT:
+		pRight := pExpr.pRight
+		if pRight.op == TK_DOT {
+			pRight = pRight.pRight
+		}
+		if pRight.op != TK_ID {
+			return WRC_Prune
+		}
+		zTab = pExpr.pLeft.u.zToken
+		zCol = pRight.u.zToken
+	default:
+		return WRC_Continue
+	}
+	pTab, _, _, _ := pCheck.pCat.lookupColumn(pCheck.pScope, pExpr)
+	if pTab != nil || !pCheck.pCat.scopeIsKnown(pCheck.pScope) {
+		return WRC_Prune
+	}
+	if zTab == nil {
+		if ExprHasProperty(pExpr, EP_DblQuoted) || sqlite3IsTrueOrFalse(zCol) != 0 {
+			/* A string literal in double quotes, or TRUE or FALSE */
+			return WRC_Prune
+		}
+		if pSel := pCheck.pScope.pSel; pSel != nil {
+			for i := 0; i < pSel.pEList.nExpr; i++ {
+				pItem := &pSel.pEList.a[i]
+				if pItem.eEName == ENAME_NAME && sqlite3StrICmp(pItem.zEName, zCol) == 0 {
+					/* An alias of the result set, as in an ORDER BY */
+					return WRC_Prune
+				}
+			}
+		}
+		sqlite3ErrorMsg(pWalker.pParse, "no such column: %s", zCol)
+	} else {
+		sqlite3ErrorMsg(pWalker.pParse, "no such column: %s.%s", zTab, zCol)
+	}
+	sqlite3RecordErrorOffsetOfExpr(pWalker.pParse.db, pExpr)
+	return WRC_Abort
+}
+
+/*
+** Return true if every term of the FROM clause of pScope, and of each
+** scope that encloses it, is a table or view of the Catalog.
+ */
+func (p *Catalog) scopeIsKnown(pScope *catalogScope) bool {
+	for pS := pScope; pS != nil; pS = pS.pOuter {
+		for i := 0; pS.pSrc != nil && i < pS.pSrc.nSrc; i++ {
+			pItem := &pS.pSrc.a[i]
+			if pS.aTab[i] == nil || pItem.pSelect != nil || pItem.u1.pFuncArg != nil {
+				return false
+			}
+			if pCte, _ := findCte(pItem, pS); pCte != nil {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+/*
+** Check the RETURNING clause pList of the INSERT, UPDATE or DELETE
+** statement pStmt against pTabList->a[0], the table it modifies.  Every
+** column that the clause names must exist, with each "*" standing for
+** the columns of the table.  A subquery of the clause is checked against
+** its own FROM clause first.  The clause is not checked if the table is
+** not in the Catalog.
+**
+** pList itself is not changed.  ResultColumns() expands the "*" terms
+** each time it is called.
+ */
+func (p *Catalog) checkReturning(pStmt *Stmt, pTabList *SrcList, pList *ExprList) error {
+	var sParse Parse
+	sParse.db = p.db
+
+	p.db.errByteOffset = -1
+	pScope, pEList := p.returningScope(pTabList, pList)
+	if pScope.aTab[0] == nil || pScope.aTab[0].nCol == 0 {
+		return nil
+	}
+	if p.checkColumns(&sParse, pScope, pEList, nil) != 0 {
+		return p.stmtError(pStmt, &sParse)
+	}
+	return nil
+}
+
+/*
+** Return the error left in pParse while checking statement pStmt.  Its
+** Offset is within the complete SQL text, or -1 if the error is not tied
+** to a token.
+ */
+func (p *Catalog) stmtError(pStmt *Stmt, pParse *Parse) error {
+	iErr := -1
+	if p.db.errByteOffset >= 0 {
+		iErr = pStmt.iBase + p.db.errByteOffset
+	}
+	return &Error{Code: SQLITE_ERROR, Msg: string(pParse.zErrMsg), Offset: iErr}
+}
+
+/*
+** A ForeignKey is one REFERENCES clause of a table in a Catalog.
+** ParentColumns is nil if the clause names no parent columns, in which
+** case the key refers to the PRIMARY KEY of the parent table.  OnDelete
+** and OnUpdate are "NO ACTION", "RESTRICT", "SET NULL", "SET DEFAULT" or
+** "CASCADE".
+**
+** Err is nil if the parent key is sound.  Otherwise it is the *Error
+** that SQLite reports when the child table is written, "foreign key
+** mismatch", with an Offset of -1.  Reason then says why: the parent
+** table does not exist, the number of columns differ, or the parent key
+** is not UNIQUE.
+ */
+type ForeignKey struct {
+	Child         string
+	ChildColumns  []string
+	Parent        string
+	ParentColumns []string
+	OnDelete      string
+	OnUpdate      string
+	Deferred      bool
+	Err           error
+	Reason        string
+}
+
+/*
+** Return the name of foreign key action eAction.
+ */
+func fkActionName(eAction uint8) string {
+	switch eAction {
+	case OE_Restrict:
+		return "RESTRICT"
+	case OE_SetNull:
+		return "SET NULL"
+	case OE_SetDflt:
+		return "SET DEFAULT"
+	case OE_Cascade:
+		return "CASCADE"
+	}
+	return "NO ACTION"
+}
+
+/*
+** ForeignKeys returns the foreign key graph of the Catalog: each edge
+** goes from a child table to the parent table it references.  Tables
+** are visited in the order they were created and the keys of each table
+** in the order they were declared.  Each key is checked against its
+** parent table.
+ */
+func (p *Catalog) ForeignKeys() []ForeignKey {
+	var aFk []ForeignKey
+	for _, pTab := range p.aTable {
+		if !IsOrdinaryTable(pTab) {
+			continue
+		}
+		var apFKey []*FKey
+		for pFKey := pTab.u.tab.pFKey; pFKey != nil; pFKey = pFKey.pNextFrom {
+			apFKey = append([]*FKey{pFKey}, apFKey...)
+		}
+		for _, pFKey := range apFKey {
+			fk := ForeignKey{
+				Child:    string(pTab.zName),
+				Parent:   string(pFKey.zTo),
+				OnDelete: fkActionName(pFKey.aAction[0]),
+				OnUpdate: fkActionName(pFKey.aAction[1]),
+				Deferred: pFKey.isDeferred != 0,
+			}
+			for i := 0; i < pFKey.nCol; i++ {
+				zCol := pTab.aCol[pFKey.aCol[i].iFrom].zCnName
+				fk.ChildColumns = append(fk.ChildColumns, string(zCol[:sqlite3Strlen30(zCol)]))
+				if pFKey.aCol[i].zCol != nil {
+					fk.ParentColumns = append(fk.ParentColumns, string(pFKey.aCol[i].zCol))
+				}
+			}
+			fk.Reason, fk.Err = p.checkForeignKey(pFKey)
+			aFk = append(aFk, fk)
+		}
+	}
+	return aFk
+}
+
+/*
+** Check that the parent table of pFKey exists and has a UNIQUE or
+** PRIMARY KEY constraint on the parent key.  Return nil if it does.
+** Otherwise return the reason for the error, and the error.
+ */
+func (p *Catalog) checkForeignKey(pFKey *FKey) (string, error) {
+	var sParse Parse
+	var pIdx *Index
+	var aIdx []*Index
+	var zWhy string
+	sParse.db = p.db
+
+	pParent := p.findTable(pFKey.zTo)
+	if pParent == nil || !IsOrdinaryTable(pParent) {
+		sqlite3ErrorMsg(&sParse,
+			"foreign key mismatch - \"%w\" referencing \"%w\"",
+			pFKey.pFrom.zName, pFKey.zTo)
+		zWhy = "no such table: " + string(pFKey.zTo)
+	} else {
+		for _, pIndex := range p.aIndex {
+			if pIndex.pTable == pParent {
+				aIdx = append(aIdx, pIndex)
+			}
+		}
+		if sqlite3FkLocateIndex(&sParse, pParent, aIdx, pFKey, &pIdx, nil) != 0 {
+			zWhy = fkMismatchReason(p.db, pParent, pFKey)
+		}
+	}
+	if sParse.nErr != 0 {
+		return zWhy, &Error{Code: SQLITE_ERROR, Msg: string(sParse.zErrMsg), Offset: -1}
+	}
+	return "", nil
+}
+
+/*
+** Return the reason that sqlite3FkLocateIndex() found no parent key for
+** pFKey on table pParent.
+ */
+func fkMismatchReason(db *sqlite3, pParent *Table, pFKey *FKey) string {
+	nCol := pFKey.nCol
+	if pFKey.aCol[0].zCol == nil {
+		var pPk *Index
+		for p := pParent.pIndex; p != nil; p = p.pNext {
+			if IsPrimaryKeyIndex(p) {
+				pPk = p
+			}
+		}
+		if pPk == nil && pParent.iPKey < 0 {
+			return "parent table has no PRIMARY KEY"
+		}
+		nPk := 1
+		if pPk != nil {
+			nPk = int(pPk.nKeyCol)
+		}
+		if nPk != nCol {
+			return string(sqlite3MPrintf(db,
+				"foreign key has %d columns but the PRIMARY KEY has %d", nCol, nPk))
+		}
+	} else {
+		for i := 0; i < nCol; i++ {
+			if !fkParentHasColumn(pParent, pFKey.aCol[i].zCol) {
+				return string(sqlite3MPrintf(db, "no such column: %s.%s",
+					pParent.zName, pFKey.aCol[i].zCol))
+			}
+		}
+	}
+	return "parent key is not UNIQUE"
+}
+
+/*
+** Return true if zCol names a column of pParent.
+ */
+func fkParentHasColumn(pParent *Table, zCol []byte) bool {
+	for i := 0; i < int(pParent.nCol); i++ {
+		if sqlite3StrICmp(pParent.aCol[i].zCnName, zCol) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+** A ColumnInfo describes a column of a table or view, or a column of the
+** rows that a statement returns.
+**
+** Type is the declared type of the column, or "" if it has none.  The
+** declared type of a result column is that of the table column that it
+** is taken from, as sqlite3_column_decltype() reports it.  Collation is
+** the collating sequence named by a COLLATE clause of the column, or of
+** the expression of a result column.  NotNull is
+** true if the value can never be NULL: the column has a NOT NULL
+** constraint or is the rowid, and is not on the outer side of a join.
+ */
+type ColumnInfo struct {
+	Name      string /* Name of the column */
+	Type      string /* Declared type, or "" */
+	Collation string /* Declared collating sequence, or "" */
+	NotNull   bool   /* True if the value is never NULL */
+}
+
+/*
+** ResultColumns describes the rows returned by pStmt: the result set of
+** a SELECT, or the RETURNING clause of an INSERT, UPDATE or DELETE.  A
+** "*" is expanded using the tables of the Catalog.  A table that is not
+** in the Catalog contributes no columns to a "*" and no types.  It
+** returns nil if pStmt returns no rows.
+**
+** The names are those that sqlite3_column_name() reports, so two
+** columns may have the same name.
+ */
+func (p *Catalog) ResultColumns(pStmt *Stmt) []ColumnInfo {
+	var pScope *catalogScope /* Scope of the result set */
+	var pEList *ExprList     /* The result set, with "*" expanded */
+	var pTab *Table          /* Declared types and nullability */
+	switch {
+	case pStmt.pSelect != nil:
+		pLeft := pStmt.pSelect
+		for pLeft.pPrior != nil {
+			pLeft = pLeft.pPrior
+		}
+		pTab = p.resultSetOfSelect(pStmt.pSelect, nil, nil)
+		pScope, pEList = p.resultSetScope(pLeft, pStmt.pSelect.pWith, nil)
+	case pStmt.pInsert != nil && pStmt.pInsert.pReturning != nil:
+		pScope, pEList = p.returningScope(pStmt.pInsert.pTabList, pStmt.pInsert.pReturning)
+	case pStmt.pUpdate != nil && pStmt.pUpdate.pReturning != nil:
+		pScope, pEList = p.returningScope(pStmt.pUpdate.pTabList, pStmt.pUpdate.pReturning)
+	case pStmt.pDelete != nil && pStmt.pDelete.pReturning != nil:
+		pScope, pEList = p.returningScope(pStmt.pDelete.pTabList, pStmt.pDelete.pReturning)
+	default:
+		return nil
+	}
+	if pTab == nil {
+		pTab = p.resultSetOfList(pScope, pEList, nil)
+	}
+	aInfo := make([]ColumnInfo, 0, pTab.nCol)
+	for i := 0; i < int(pTab.nCol); i++ {
+		pInfo := columnInfo(pTab, i)
+		pInfo.Name = p.columnName(pScope, pEList, i)
+		aInfo = append(aInfo, pInfo)
+	}
+	return aInfo
+}
+
+/*
+** Return the name of column i of the result set pEList, evaluated in
+** pScope.  This is the name generateColumnNames() gives the column in
+** C when neither full_column_names nor short_column_names is changed.
+ */
+func (p *Catalog) columnName(pScope *catalogScope, pEList *ExprList, i int) string {
+	pX := &pEList.a[i]
+	if pX.zEName != nil && pX.eEName == ENAME_NAME {
+		return string(pX.zEName)
+	}
+	if pTab, iCol, _, _ := p.lookupColumn(pScope, pX.pExpr); pTab != nil {
+		if iCol < 0 {
+			iCol = int(pTab.iPKey)
+		}
+		if iCol < 0 {
+			return "rowid"
+		}
+		zName := pTab.aCol[iCol].zCnName
+		return string(zName[:sqlite3Strlen30(zName)])
+	}
+	if pX.zEName != nil {
+		return string(pX.zEName)
+	}
+	return string(sqlite3MPrintf(p.db, "column%d", i+1))
+}
+
+/*
+** Return the ColumnInfo that describes column iCol of pTab.
+ */
+func columnInfo(pTab *Table, iCol int) ColumnInfo {
+	pCol := &pTab.aCol[iCol]
+	zName := pCol.zCnName[:sqlite3Strlen30(pCol.zCnName)]
+	return ColumnInfo{
+		Name:      string(zName),
+		Type:      string(sqlite3ColumnType(pCol, nil)),
+		Collation: string(sqlite3ColumnColl(pCol)),
+		NotNull:   columnNotNull(pTab, iCol),
+	}
+}
+
+/*
+** Return true if column iCol of pTab can never hold a NULL: it has a
+** NOT NULL constraint, it is the INTEGER PRIMARY KEY, or it is part of
+** the PRIMARY KEY of a WITHOUT ROWID table.
+ */
+func columnNotNull(pTab *Table, iCol int) bool {
+	pCol := &pTab.aCol[iCol]
+	return pCol.notNull != OE_None || iCol == int(pTab.iPKey) ||
+		(!HasRowid(pTab) && (pCol.colFlags&COLFLAG_PRIMKEY) != 0)
+}
+
+/*
+** A TableInfo describes a table or view of a Catalog.  The columns of a
+** view are those of its result set, with the declared types of the table
+** columns they select.  Hidden columns are not listed.
+ */
+type TableInfo struct {
+	Name         string       /* Name of the table or view */
+	View         bool         /* True for a view */
+	WithoutRowid bool         /* True for a WITHOUT ROWID table */
+	Columns      []ColumnInfo /* The columns, in order */
+}
+
+/*
+** Table looks up the table or view called zName.  Tables and views share
+** one namespace and the name is not case sensitive.  It returns nil if
+** the Catalog has no such table or view.
+ */
+func (p *Catalog) Table(zName string) *TableInfo {
+	pTab := p.findTable([]byte(zName))
+	if pTab == nil {
+		return nil
+	}
+	pInfo := tableInfo(pTab)
+	return &pInfo
+}
+
+/*
+** Tables returns the tables and views of the Catalog, in the order they
+** were created.
+ */
+func (p *Catalog) Tables() []TableInfo {
+	aInfo := make([]TableInfo, 0, len(p.aTable))
+	for _, pTab := range p.aTable {
+		aInfo = append(aInfo, tableInfo(pTab))
+	}
+	return aInfo
+}
+
+/*
+** Info describes the table or view p.  The columns of a view are those
+** found by the parser until the view is added to a Catalog.
+ */
+func (p *Table) Info() TableInfo { return tableInfo(p) }
+
+/*
+** Select returns the SELECT that defines the view p, or nil if p is not
+** a view.
+ */
+func (p *Table) Select() *Select {
+	if !IsView(p) {
+		return nil
+	}
+	return p.u.view.pSelect
+}
+
+/*
+** Return the TableInfo that describes pTab.
+ */
+func tableInfo(pTab *Table) TableInfo {
+	info := TableInfo{
+		Name:         string(pTab.zName),
+		View:         IsView(pTab),
+		WithoutRowid: !HasRowid(pTab),
+	}
+	for i := 0; i < int(pTab.nCol); i++ {
+		if !IsHiddenColumn(&pTab.aCol[i]) {
+			info.Columns = append(info.Columns, columnInfo(pTab, i))
+		}
+	}
+	return info
+}
+
+/*
+** A catalogScope is a FROM clause in which the Catalog looks up column
+** references.  aTab[i] is the table, view or subquery of pSrc->a[i], or
+** NULL if it is not known.  pWith is the WITH clause attached to the
+** SELECT, if any, pSel is the SELECT itself and pOuter is the scope of
+** the enclosing query.  The C version keeps this information in a NameContext, once each term of
+** the FROM clause has been given its Table by sqlite3SelectExpand().
+ */
+type catalogScope struct {
+	pSrc   *SrcList
+	aTab   []*Table
+	pWith  *With
+	pSel   *Select
+	pOuter *catalogScope
+}
+
+/*
+** Build the scope of the SELECT pSel, which is not a compound.  pWith is
+** the WITH clause of the statement that pSel belongs to.
+ */
+func (p *Catalog) selectScope(pSel *Select, pWith *With, pOuter *catalogScope) *catalogScope {
+	pScope := &catalogScope{pSrc: pSel.pSrc, pWith: pWith, pSel: pSel, pOuter: pOuter}
+	if pSel.pSrc == nil {
+		return pScope
+	}
+	pScope.aTab = make([]*Table, pSel.pSrc.nSrc)
+	for i := 0; i < pSel.pSrc.nSrc; i++ {
+		pScope.aTab[i] = p.srcItemTable(&pSel.pSrc.a[i], pScope)
+	}
+	return pScope
+}
+
+/*
+** Return the Table that the FROM clause term pItem reads from: the
+** result set of a subquery or a CTE, or a table or view of the Catalog.
+** Return NULL if it is not known.
+ */
+func (p *Catalog) srcItemTable(pItem *SrcItem, pScope *catalogScope) *Table {
+	if pItem.pSelect != nil {
+		return p.resultSetOfSelect(pItem.pSelect, nil, pScope)
+	}
+	if pCte, pS := findCte(pItem, pScope); pCte != nil {
+		if pItem.fg.isRecursive != 0 || p.cteIsBusy(pCte) {
+			/* A reference to a CTE from within its own definition */
+			return nil
+		}
+		p.aCteBusy = append(p.aCteBusy, pCte)
+		pTab := p.resultSetOfSelect(pCte.pSelect, pCte.pCols, pS)
+		p.aCteBusy = p.aCteBusy[:len(p.aCteBusy)-1]
+		return pTab
+	}
+	return p.findTable(pItem.zName)
+}
+
+/*
+** Return the CTE that the FROM clause term pItem refers to, and the
+** scope whose WITH clause defines it.  Return NULL if pItem does not
+** refer to a CTE.
+ */
+func findCte(pItem *SrcItem, pScope *catalogScope) (*Cte, *catalogScope) {
+	if pItem.zDatabase != nil {
+		return nil, nil
+	}
+	for pS := pScope; pS != nil; pS = pS.pOuter {
+		for pWith := pS.pWith; pWith != nil; pWith = pWith.pOuter {
+			for i := 0; i < pWith.nCte; i++ {
+				if sqlite3StrICmp(pItem.zName, pWith.a[i].zName) == 0 {
+					return &pWith.a[i], pS
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+/*
+** Return true if the columns of CTE pCte are being computed.
+ */
+func (p *Catalog) cteIsBusy(pCte *Cte) bool {
+	for _, pBusy := range p.aCteBusy {
+		if pBusy == pCte {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+** Compute the columns of the result set of pSelect, as a transient
+** Table.  If pCNames is not NULL and has one name for each column, the
+** columns take those names.  The declared type of each column is found
+** by columnTypeImpl() from the leftmost SELECT.  A column is NOT NULL
+** only if it is never NULL in every SELECT of a compound.
+**
+** This is sqlite3ResultSetOfSelect() in C, where the tables of the FROM
+** clauses come from the schema.
+ */
+func (p *Catalog) resultSetOfSelect(pSelect *Select, pCNames *ExprList, pOuter *catalogScope) *Table {
+	var pTab *Table
+	var aSel []*Select
+	for pS := pSelect; pS != nil; pS = pS.pPrior {
+		aSel = append([]*Select{pS}, aSel...)
+	}
+	for _, pS := range aSel {
+		pScope, pEList := p.resultSetScope(pS, pSelect.pWith, pOuter)
+		if pTab == nil {
+			pTab = p.resultSetOfList(pScope, pEList, pCNames)
+		} else if pEList.nExpr == int(pTab.nCol) {
+			for j := 0; j < int(pTab.nCol); j++ {
+				if !p.exprNotNull(pScope, pEList.a[j].pExpr) {
+					pTab.aCol[j].notNull = OE_None
+				}
+			}
+		}
+	}
+	return pTab
+}
+
+/*
+** Return the scope of pSel, a SELECT that is not a compound, and its
+** result set with each "*" expanded.  pWith is the WITH clause of the
+** statement that pSel belongs to.
+ */
+func (p *Catalog) resultSetScope(pSel *Select, pWith *With, pOuter *catalogScope) (*catalogScope, *ExprList) {
+	var sParse Parse
+	sParse.db = p.db
+	pScope := p.selectScope(pSel, pWith, pOuter)
+	return pScope, p.expandResultSet(&sParse, pScope, pSel.pEList)
+}
+
+/*
+** Return the scope of a RETURNING clause pList, which sees only the
+** table pTabList->a[0], and the clause with each "*" expanded.
+ */
+func (p *Catalog) returningScope(pTabList *SrcList, pList *ExprList) (*catalogScope, *ExprList) {
+	var sParse Parse
+	sParse.db = p.db
+
+	pSrc := &SrcList{nSrc: 1, nAlloc: 1, a: pTabList.a[:1]}
+	pScope := &catalogScope{pSrc: pSrc, aTab: []*Table{p.findTable(pSrc.a[0].zName)}}
+	if pScope.aTab[0] != nil && pScope.aTab[0].nCol > 0 {
+		pList = sqlite3ExpandReturning(&sParse, pList, pScope.aTab[0])
+	}
+	return pScope, pList
+}
+
+/*
+** Compute the columns of the result set pEList, evaluated in pScope, as
+** a transient Table.  The names are made unique as for a subquery.  If
+** pCNames is not NULL and has one name for each column, the columns take
+** those names instead.
+ */
+func (p *Catalog) resultSetOfList(pScope *catalogScope, pEList *ExprList, pCNames *ExprList) *Table {
+	var sParse Parse
+	sParse.db = p.db
+
+	pTab := &Table{}
+	pTab.nTabRef = 1
+	pTab.iPKey = -1
+	pTab.tabFlags = TF_Ephemeral | TF_NoVisibleRowid
+	if pCNames != nil && pCNames.nExpr == pEList.nExpr {
+		sqlite3ColumnsFromExprList(&sParse, pCNames, &pTab.nCol, &pTab.aCol)
+	} else {
+		sqlite3ColumnsFromExprList(&sParse, pEList, &pTab.nCol, &pTab.aCol)
+	}
+	p.subqueryColumnTypes(pTab, pScope, pEList)
+	return pTab
+}
+
+/*
+** Return the result set pEList with each "*" and "TABLE.*" replaced by
+** the columns of the FROM clause terms of pScope, as selectExpander()
+** does in C.  A term of a NATURAL join or a join with a USING clause
+** omits the columns that it has in common with the terms to its left.
+** If pEList has no wildcard it is returned unchanged.
+ */
+func (p *Catalog) expandResultSet(pParse *Parse, pScope *catalogScope, pEList *ExprList) *ExprList {
+	var pNew *ExprList
+	db := pParse.db
+
+	if !selectHasStar(pEList) {
+		return pEList
+	}
+	for k := 0; k < pEList.nExpr; k++ {
+		pE := pEList.a[k].pExpr
+		var zTName []byte /* Table name in "TABLE.*", or NULL */
+		if pE.op == TK_DOT && pE.pRight.op == TK_ASTERISK {
+			zTName = pE.pLeft.u.zToken
+		} else if pE.op != TK_ASTERISK {
+			pNew = sqlite3ExprListAppend(pParse, pNew, pE)
+			pNew.a[pNew.nExpr-1].zEName = pEList.a[k].zEName
+			pNew.a[pNew.nExpr-1].eEName = pEList.a[k].eEName
+			continue
+		}
+		for i := 0; pScope.pSrc != nil && i < pScope.pSrc.nSrc; i++ {
+			pFrom := &pScope.pSrc.a[i]
+			pTab := pScope.aTab[i]
+			zTabName := pFrom.zAlias
+			if zTabName == nil {
+				zTabName = pFrom.zName
+			}
+			if pTab == nil || (zTName != nil && sqlite3StrICmp(zTName, zTabName) != 0) {
+				continue
+			}
+			for j := 0; j < int(pTab.nCol); j++ {
+				zName := pTab.aCol[j].zCnName
+				if IsHiddenColumn(&pTab.aCol[j]) {
+					continue
+				}
+				if zTName == nil && i > 0 && catalogJoinHides(pScope, i, zName) {
+					continue
+				}
+				pRight := sqlite3Expr(db, TK_ID, zName)
+				var pExpr *Expr
+				if zTabName != nil {
+					pLeft := sqlite3Expr(db, TK_ID, zTabName)
+					pExpr = sqlite3PExpr(pParse, TK_DOT, pLeft, pRight)
+				} else {
+					pExpr = pRight
+				}
+				pNew = sqlite3ExprListAppend(pParse, pNew, pExpr)
+				pNew.a[pNew.nExpr-1].zEName = pRight.u.zToken
+				pNew.a[pNew.nExpr-1].eEName = ENAME_NAME
+			}
+		}
+	}
+	if pNew == nil {
+		pNew = &ExprList{}
+	}
+	return pNew
+}
+
+/*
+** Return true if column zName of FROM clause term iTerm is left out of
+** the expansion of "*" because the term is joined to the terms on its
+** left with NATURAL or USING, and one of them has the column too.
+ */
+func catalogJoinHides(pScope *catalogScope, iTerm int, zName []byte) bool {
+	pFrom := &pScope.pSrc.a[iTerm]
+	if (pFrom.fg.jointype & JT_NATURAL) == 0 {
+		if pFrom.u3.pUsing == nil || sqlite3IdListIndex(pFrom.u3.pUsing, zName) < 0 {
+			return false
+		}
+	}
+	for i := 0; i < iTerm; i++ {
+		if pScope.aTab[i] != nil && sqlite3ColumnIndex(pScope.aTab[i], zName) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+** Fill in the declared type, affinity, collating sequence and nullability
+** of each column of the transient Table pTab from the expressions of
+** pEList, evaluated in pScope.  The declared type and collating sequence
+** are those of the table column an expression refers to, as in the C
+** version, unless the expression has a COLLATE clause of its own.
+ */
+func (p *Catalog) subqueryColumnTypes(pTab *Table, pScope *catalogScope, pEList *ExprList) {
+	for i := 0; i < int(pTab.nCol); i++ {
+		pCol := &pTab.aCol[i]
+		pExpr := pEList.a[i].pExpr
+		zType, pSrcCol := p.columnTypeImpl(pScope, pExpr)
+		if pSrcCol != nil {
+			pCol.affinity = pSrcCol.affinity
+		} else {
+			pCol.affinity = sqlite3ExprAffinity(pExpr)
+		}
+		if zType != nil {
+			n := sqlite3Strlen30(pCol.zCnName)
+			pCol.zCnName = append(append(append(pCol.zCnName[:n:n], 0), zType...), 0)
+			pCol.colFlags |= COLFLAG_HASTYPE
+		}
+		if pExpr.op == TK_COLLATE {
+			sqlite3ColumnSetColl(p.db, pCol, pExpr.u.zToken)
+		} else if pSrcCol != nil && (pSrcCol.colFlags&COLFLAG_HASCOLL) != 0 {
+			sqlite3ColumnSetColl(p.db, pCol, sqlite3ColumnColl(pSrcCol))
+		}
+		if p.exprNotNull(pScope, pExpr) {
+			pCol.notNull = OE_Abort
+		}
+	}
+}
+
+/*
+** Look up the column that expression pExpr refers to, if it is a column
+** reference, in pScope and the scopes that enclose it.  Return the table
+** and the index of the column within it, or -1 for the rowid, and the
+** scope the column was found in.  Return a NULL table if pExpr is not a
+** column reference or the column is not known.
+ */
+func (p *Catalog) lookupColumn(pScope *catalogScope, pExpr *Expr) (*Table, int, *catalogScope, int) {
+	var zTab []byte /* Table name, or NULL */
+	var zCol []byte /* Column name */
+	switch pExpr.op {
+	case TK_ID:
+		zCol = pExpr.u.zToken
+	case TK_DOT:
+		pRight := pExpr.pRight
+		if pRight.op == TK_DOT {
+			/* DATABASE.TABLE.COLUMN */
+			zTab = pRight.pLeft.u.zToken
+			pRight = pRight.pRight
+		} else {
+			zTab = pExpr.pLeft.u.zToken
+		}
+		if pRight.op != TK_ID {
+			return nil, 0, nil, 0
+		}
+		zCol = pRight.u.zToken
+	default:
+		return nil, 0, nil, 0
+	}
+	for pS := pScope; pS != nil; pS = pS.pOuter {
+		for i := 0; pS.pSrc != nil && i < pS.pSrc.nSrc; i++ {
+			pItem := &pS.pSrc.a[i]
+			pTab := pS.aTab[i]
+			if zTab != nil {
+				zName := pItem.zAlias
+				if zName == nil {
+					zName = pItem.zName
+				}
+				if zName == nil || sqlite3StrICmp(zName, zTab) != 0 {
+					continue
+				}
+			}
+			if pTab == nil {
+				if zTab != nil {
+					return nil, 0, nil, 0
+				}
+				continue
+			}
+			if iCol := sqlite3ColumnIndex(pTab, zCol); iCol >= 0 {
+				return pTab, iCol, pS, i
+			}
+			if zTab != nil && (pTab.tabFlags&(TF_WithoutRowid|TF_NoVisibleRowid)) == 0 &&
+				sqlite3IsRowid(zCol) {
+				return pTab, -1, pS, i
+			}
+		}
+		if zTab == nil && pS.pSrc != nil && pS.pSrc.nSrc == 1 && pS.aTab[0] != nil &&
+			(pS.aTab[0].tabFlags&(TF_WithoutRowid|TF_NoVisibleRowid)) == 0 && sqlite3IsRowid(zCol) {
+			/* An unqualified rowid of the only table of the FROM clause */
+			return pS.aTab[0], -1, pS, 0
+		}
+	}
+	return nil, 0, nil, 0
+}
+
+/*
+** Return the declared type of the value of expression pExpr, and the
+** table column it is taken from.  Only a column reference, or a scalar
+** subquery whose first result is one, has a declared type.
+ */
+func (p *Catalog) columnTypeImpl(pScope *catalogScope, pExpr *Expr) ([]byte, *Column) {
+	switch pExpr.op {
+	case TK_ID, TK_DOT:
+		pTab, iCol, _, _ := p.lookupColumn(pScope, pExpr)
+		if pTab == nil {
+			break
+		}
+		if iCol < 0 {
+			iCol = int(pTab.iPKey)
+		}
+		if iCol < 0 {
+			return []byte("INTEGER"), nil
+		}
+		return sqlite3ColumnType(&pTab.aCol[iCol], nil), &pTab.aCol[iCol]
+	case TK_SELECT:
+		pS := pExpr.x.pSelect
+		pInner, pEList := p.resultSetScope(pS, pS.pWith, pScope)
+		if pEList.nExpr > 0 {
+			return p.columnTypeImpl(pInner, pEList.a[0].pExpr)
+		}
+	}
+	return nil, nil
+}
+
+/*
+** Return true if expression pExpr, evaluated in pScope, is never NULL.
+** That is so for a non-NULL literal, count() and EXISTS, and for a
+** reference to the rowid or to a column with a NOT NULL constraint,
+** provided that its table is not on the outer side of a join.
+ */
+func (p *Catalog) exprNotNull(pScope *catalogScope, pExpr *Expr) bool {
+	for pExpr.op == TK_COLLATE {
+		pExpr = pExpr.pLeft
+	}
+	switch pExpr.op {
+	case TK_INTEGER, TK_FLOAT, TK_STRING, TK_BLOB, TK_EXISTS:
+		return true
+	case TK_CAST:
+		return p.exprNotNull(pScope, pExpr.pLeft)
+	case TK_FUNCTION:
+		return sqlite3StrICmp(pExpr.u.zToken, []byte("count")) == 0
+	case TK_ID, TK_DOT:
+		pTab, iCol, pS, iTerm := p.lookupColumn(pScope, pExpr)
+		if pTab == nil || (pS.pSrc.a[iTerm].fg.jointype&(JT_LEFT|JT_LTORJ)) != 0 {
+			return false
+		}
+		return iCol < 0 || columnNotNull(pTab, iCol)
+	}
+	return false
+}
+
+/*
+** An IndexInfo describes an index: one made by CREATE INDEX, or one that
+** SQLite makes for a PRIMARY KEY or UNIQUE constraint of a table.  Where
+** is the WHERE clause of a partial index, or nil.
+ */
+type IndexInfo struct {
+	Name       string        /* Name of the index */
+	Table      string        /* Name of the table indexed */
+	Unique     bool          /* True for a UNIQUE or PRIMARY KEY index */
+	PrimaryKey bool          /* True for the index of a PRIMARY KEY */
+	Columns    []IndexColumn /* The terms of the index, in order */
+	Where      *Expr         /* WHERE clause of a partial index */
+}
+
+/*
+** An IndexColumn is one term of an index.  Name is the column indexed,
+** or "" if the term is an expression, which is then in Expr.  Collation
+** is the collating sequence, or "" if it is not known because the index
+** has not been added to a Catalog.
+ */
+type IndexColumn struct {
+	Name      string /* Column name, or "" */
+	Expr      *Expr  /* Indexed expression, or nil */
+	Collation string /* Collating sequence */
+	Desc      bool   /* True for a DESC term */
+}
+
+/*
+** Indexes returns the indexes of the Catalog.  The indexes made for the
+** constraints of each table come first, with the tables in the order they
+** were created, followed by those made by CREATE INDEX, in order.
+ */
+func (p *Catalog) Indexes() []IndexInfo {
+	var aInfo []IndexInfo
+	for _, pTab := range p.aTable {
+		var apIdx []*Index
+		for pIdx := pTab.pIndex; pIdx != nil; pIdx = pIdx.pNext {
+			apIdx = append([]*Index{pIdx}, apIdx...)
+		}
+		for _, pIdx := range apIdx {
+			aInfo = append(aInfo, indexInfo(pIdx))
+		}
+	}
+	for _, pIdx := range p.aIndex {
+		aInfo = append(aInfo, indexInfo(pIdx))
+	}
+	return aInfo
+}
+
+/*
+** Info describes the index p.
+ */
+func (p *Index) Info() IndexInfo { return indexInfo(p) }
+
+/*
+** Return the IndexInfo that describes pIdx.  Until the index has been
+** resolved against its table, a term is taken to be a column if it is an
+** identifier.
+ */
+func indexInfo(pIdx *Index) IndexInfo {
+	info := IndexInfo{
+		Name:       string(pIdx.zName),
+		Table:      string(pIdx.pTable.zName),
+		Unique:     IsUniqueIndex(pIdx),
+		PrimaryKey: IsPrimaryKeyIndex(pIdx),
+		Where:      pIdx.pPartIdxWhere,
+	}
+	for i := 0; i < int(pIdx.nKeyCol); i++ {
+		var c IndexColumn
+		pExpr := sqlite3ExprSkipCollate(pIdx.aColExpr.a[i].pExpr)
+		if pIdx.aiColumn != nil && pIdx.aiColumn[i] >= 0 {
+			zName := pIdx.pTable.aCol[pIdx.aiColumn[i]].zCnName
+			c.Name = string(zName[:sqlite3Strlen30(zName)])
+		} else if zCol := indexColumnName(pExpr); zCol != nil &&
+			(pIdx.aiColumn == nil || pIdx.aiColumn[i] != XN_EXPR) {
+			c.Name = string(zCol)
+		} else {
+			c.Expr = pExpr
+		}
+		c.Collation = string(pIdx.azColl[i])
+		c.Desc = pIdx.aSortOrder[i] == SQLITE_SO_DESC
+		info.Columns = append(info.Columns, c)
+	}
+	return info
+}
@@ -0,0 +1,320 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Tests for CREATE INDEX, the Catalog and its foreign key checks.
+ */
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+/*
+** Parse zSql and build a Catalog from it.
+ */
+func testCatalog(t *testing.T, zSql string) (*Catalog, error) {
+	t.Helper()
+	aStmt, err := ParseSQL(zSql, nil)
+	if err != nil {
+		t.Fatalf("%s: %v", zSql, err)
+	}
+	return NewCatalog(aStmt)
+}
+
+/*
+** NewCatalog must report the errors that SQLite reports when the
+** statements are run against the schema built so far.
+ */
+func TestCatalogError(t *testing.T) {
+	aTest := []struct {
+		zSql string
+		zErr string
+	}{
+		{"CREATE INDEX i ON nosuch(a)", "no such table: nosuch"},
+		{"CREATE TABLE t(a); CREATE INDEX i ON t(zz)", "no such column: zz"},
+		{"CREATE TABLE t(a); CREATE INDEX i ON t(a) WHERE zz", "no such column: zz"},
+		{"CREATE TABLE t(a); CREATE TRIGGER tr AFTER INSERT ON nosuch BEGIN SELECT 1; END",
+			"no such table: main.nosuch"},
+		{"CREATE TABLE t(a); CREATE VIEW v AS SELECT a FROM t; CREATE TRIGGER tr AFTER INSERT ON v BEGIN SELECT 1; END",
+			"cannot create AFTER trigger on view: v"},
+		{"CREATE TABLE t(a); CREATE VIEW v AS SELECT a FROM t; CREATE TRIGGER tr BEFORE DELETE ON v BEGIN SELECT 1; END",
+			"cannot create BEFORE trigger on view: v"},
+		{"CREATE TABLE t(a); CREATE TRIGGER tr INSTEAD OF INSERT ON t BEGIN SELECT 1; END",
+			"cannot create INSTEAD OF trigger on table: t"},
+		{"CREATE TABLE t(a); CREATE VIEW v AS SELECT a FROM t; CREATE TRIGGER tr INSTEAD OF INSERT ON v BEGIN SELECT 1; END", ""},
+		{"CREATE TABLE t(a, b); CREATE VIEW v(x) AS SELECT * FROM t",
+			"expected 1 columns for 'v' but got 2"},
+		{"CREATE TABLE t(a); INSERT INTO t(a) VALUES(1) RETURNING zz",
+			"no such column: zz"},
+		{"CREATE TABLE t(a); DELETE FROM t RETURNING t.zz",
+			"no such column: t.zz"},
+		{"CREATE TABLE t(a); CREATE TABLE p(b); DELETE FROM t RETURNING (SELECT zz FROM p)",
+			"no such column: zz"},
+		{"CREATE TABLE t(a); CREATE TABLE p(b); DELETE FROM t RETURNING (SELECT b FROM p WHERE p.a)",
+			"no such column: p.a"},
+		{"CREATE TABLE t(a); CREATE TABLE p(b); DELETE FROM t RETURNING (SELECT b FROM p WHERE b = a), " +
+			"EXISTS (SELECT 1 FROM p AS x WHERE x.b = t.a), (SELECT b AS y FROM p ORDER BY y)", ""},
+		{"CREATE TABLE t(a); DELETE FROM t RETURNING (SELECT zz FROM nosuch)", ""},
+		{"CREATE TABLE t(a); INSERT INTO t(a) VALUES(1) ON CONFLICT(a) DO NOTHING",
+			"ON CONFLICT clause does not match any PRIMARY KEY or UNIQUE constraint"},
+		{"CREATE TABLE t(a, b); CREATE UNIQUE INDEX i ON t(a) WHERE b > 0; INSERT INTO t VALUES(1, 2) ON CONFLICT(a) DO NOTHING",
+			"ON CONFLICT clause does not match any PRIMARY KEY or UNIQUE constraint"},
+		{"CREATE TABLE t(a UNIQUE, b); INSERT INTO t VALUES(1, 2) ON CONFLICT(a COLLATE nocase) DO NOTHING",
+			"ON CONFLICT clause does not match any PRIMARY KEY or UNIQUE constraint"},
+		{"CREATE TABLE t(a UNIQUE, b); INSERT INTO t VALUES(1, 2) ON CONFLICT(a) DO NOTHING ON CONFLICT(b) DO NOTHING",
+			"2nd ON CONFLICT clause does not match any PRIMARY KEY or UNIQUE constraint"},
+		{"CREATE TABLE t(a INTEGER PRIMARY KEY); INSERT INTO t(a) VALUES(1) ON CONFLICT(rowid) DO NOTHING", ""},
+		{"CREATE TABLE t(a, b); CREATE UNIQUE INDEX i ON t(b, a); INSERT INTO t VALUES(1, 2) ON CONFLICT(a, b) DO NOTHING", ""},
+		{"CREATE TABLE t(a, b); CREATE UNIQUE INDEX i ON t(a) WHERE b > 0; INSERT INTO t VALUES(1, 2) ON CONFLICT(a) WHERE b > 0 DO NOTHING", ""},
+		{"INSERT INTO nosuch VALUES(1) ON CONFLICT(a) DO NOTHING", ""},
+		{"CREATE TABLE t(a UNIQUE, b); INSERT INTO t VALUES(1, 2) ON CONFLICT(a) DO UPDATE SET zz = 1",
+			"no such column: zz"},
+		{"CREATE TABLE t(a UNIQUE, b); INSERT INTO t VALUES(1, 2) ON CONFLICT(a) DO UPDATE SET b = excluded.zz",
+			"no such column: excluded.zz"},
+		{"CREATE TABLE t(a UNIQUE, b); INSERT INTO t VALUES(1, 2) ON CONFLICT(a) DO UPDATE SET b = 1 WHERE zz > 0",
+			"no such column: zz"},
+		{"CREATE TABLE t(a UNIQUE, b); CREATE TABLE u(c); INSERT INTO t VALUES(1, 2) ON CONFLICT(a) DO UPDATE SET b = (SELECT max(c) FROM u WHERE c > zz)",
+			"no such column: zz"},
+		{"CREATE TABLE t(a UNIQUE, b); CREATE TABLE u(c); INSERT INTO t AS x VALUES(1, 2) ON CONFLICT(a) " +
+			"DO UPDATE SET b = (SELECT max(c) FROM u WHERE c > x.b AND c < excluded.b), rowid = a WHERE \"s\" <> b AND NOT FALSE", ""},
+		{"CREATE TABLE t(a UNIQUE, b); INSERT INTO t VALUES(1, 2) ON CONFLICT(a) DO UPDATE SET b = (SELECT c FROM nosuch WHERE zz)", ""},
+		{"CREATE TABLE t(a); CREATE INDEX i ON t(a) WHERE a > 1", ""},
+		{"CREATE TABLE t(a, CHECK(a = TRUE)); CREATE INDEX i ON t(a) WHERE a IS FALSE", ""},
+		{"CREATE TABLE t(a); CREATE TABLE t(b); CREATE INDEX i ON t(b)", ""},
+	}
+	for _, tc := range aTest {
+		_, err := testCatalog(t, tc.zSql)
+		if tc.zErr == "" {
+			if err != nil {
+				t.Errorf("%s: %v", tc.zSql, err)
+			}
+			continue
+		}
+		pErr, ok := err.(*Error)
+		if !ok {
+			t.Errorf("%s: got %v, want %q", tc.zSql, err, tc.zErr)
+		} else if pErr.Msg != tc.zErr {
+			t.Errorf("%s: got %q, want %q", tc.zSql, pErr.Msg, tc.zErr)
+		}
+	}
+}
+
+/*
+** Index terms must take their collation from the column unless they
+** have a COLLATE of their own.
+ */
+func TestCatalogIndexes(t *testing.T) {
+	pCat, err := testCatalog(t, "CREATE TABLE t(a TEXT COLLATE nocase, b);"+
+		"CREATE UNIQUE INDEX i ON t(a, b COLLATE rtrim DESC) WHERE b > 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	aInfo := pCat.Indexes()
+	if len(aInfo) != 1 {
+		t.Fatalf("%d indexes, want 1", len(aInfo))
+	}
+	info := aInfo[0]
+	if info.Name != "i" || info.Table != "t" || !info.Unique || info.PrimaryKey ||
+		info.Where == nil || len(info.Columns) != 2 {
+		t.Fatalf("got %+v", info)
+	}
+	aWant := []IndexColumn{
+		{Name: "a", Collation: "nocase"},
+		{Name: "b", Collation: "rtrim", Desc: true},
+	}
+	for i, want := range aWant {
+		if info.Columns[i] != want {
+			t.Errorf("term %d: got %+v, want %+v", i, info.Columns[i], want)
+		}
+	}
+}
+
+/*
+** The columns of a view must come from the tables it selects, with "*"
+** expanded, and Table must look names up without regard to case.
+ */
+func TestCatalogTables(t *testing.T) {
+	pCat, err := testCatalog(t, "CREATE TABLE t(a INTEGER NOT NULL, b TEXT);"+
+		"CREATE VIEW v AS SELECT * FROM t; CREATE VIEW w(x, y) AS SELECT v.b, a FROM v")
+	if err != nil {
+		t.Fatal(err)
+	}
+	aWant := []TableInfo{
+		{Name: "t", Columns: []ColumnInfo{
+			{Name: "a", Type: "INTEGER", NotNull: true}, {Name: "b", Type: "TEXT"}}},
+		{Name: "v", View: true, Columns: []ColumnInfo{
+			{Name: "a", Type: "INTEGER", NotNull: true}, {Name: "b", Type: "TEXT"}}},
+		{Name: "w", View: true, Columns: []ColumnInfo{
+			{Name: "x", Type: "TEXT"}, {Name: "y", Type: "INTEGER", NotNull: true}}},
+	}
+	if aInfo := pCat.Tables(); !reflect.DeepEqual(aInfo, aWant) {
+		t.Errorf("got  %+v\nwant %+v", aInfo, aWant)
+	}
+	if pInfo := pCat.Table("W"); pInfo == nil || !reflect.DeepEqual(*pInfo, aWant[2]) {
+		t.Errorf("Table(\"W\") = %+v", pInfo)
+	}
+	if pCat.Table("nosuch") != nil {
+		t.Error("Table(\"nosuch\") is not nil")
+	}
+}
+
+/*
+** NewCatalog must not expand the "*" of a RETURNING clause in place.
+ */
+func TestCatalogReturning(t *testing.T) {
+	aStmt, err := ParseSQL("CREATE TABLE t(a, b); DELETE FROM t RETURNING *", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pCat, err := NewCatalog(aStmt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := aStmt[1].pDelete.pReturning; p.nExpr != 1 || p.a[0].pExpr.op != TK_ASTERISK {
+		t.Errorf("RETURNING clause changed to %d terms", p.nExpr)
+	}
+	if aCol := pCat.ResultColumns(aStmt[1]); len(aCol) != 2 {
+		t.Errorf("got %d result columns, want 2", len(aCol))
+	}
+}
+
+/*
+** ResultColumns must describe each column with its declared type, its
+** collation and whether it can be NULL, with "*" expanded.
+ */
+func TestCatalogResultColumns(t *testing.T) {
+	aTest := []struct {
+		zSql  string
+		aWant []ColumnInfo
+	}{
+		{"CREATE TABLE t(a INTEGER NOT NULL, b VARCHAR(20) COLLATE nocase);" +
+			"UPDATE t SET a = 1 RETURNING *, rowid",
+			[]ColumnInfo{
+				{Name: "a", Type: "INTEGER", NotNull: true},
+				{Name: "b", Type: "VARCHAR(20)", Collation: "nocase"},
+				{Name: "rowid", Type: "INTEGER", NotNull: true},
+			}},
+		{"CREATE TABLE t(a INTEGER NOT NULL, b VARCHAR(20)); CREATE TABLE u(c TEXT NOT NULL);" +
+			"SELECT t.*, u.c, a + 1 AS x FROM t LEFT JOIN u",
+			[]ColumnInfo{
+				{Name: "a", Type: "INTEGER", NotNull: true},
+				{Name: "b", Type: "VARCHAR(20)"},
+				{Name: "c", Type: "TEXT"},
+				{Name: "x"},
+			}},
+	}
+	for _, tc := range aTest {
+		aStmt, err := ParseSQL(tc.zSql, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.zSql, err)
+		}
+		pCat, err := NewCatalog(aStmt)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.zSql, err)
+		}
+		aCol := pCat.ResultColumns(aStmt[len(aStmt)-1])
+		if !reflect.DeepEqual(aCol, tc.aWant) {
+			t.Errorf("%s:\ngot  %+v\nwant %+v", tc.zSql, aCol, tc.aWant)
+		}
+	}
+}
+
+/*
+** The checks that need no Catalog must be made by the parser.
+ */
+func TestIndexError(t *testing.T) {
+	aTest := []struct {
+		zSql string
+		zErr string
+	}{
+		{"CREATE TABLE t(a); CREATE INDEX i ON t(random())",
+			"non-deterministic functions prohibited in index expressions"},
+		{"CREATE TABLE t(a); CREATE INDEX i ON t(a) WHERE a > ?",
+			"parameters prohibited in partial index WHERE clauses"},
+		{"CREATE TABLE t(a); CREATE INDEX i ON t((SELECT 1))",
+			"subqueries prohibited in index expressions"},
+		{"CREATE TABLE t(a PRIMARY KEY, b, UNIQUE(zz))",
+			"no such column: zz"},
+		{"CREATE TABLE t(a PRIMARY KEY, b PRIMARY KEY)",
+			"table \"t\" has more than one primary key"},
+	}
+	for _, tc := range aTest {
+		_, err := ParseSQL(tc.zSql, nil)
+		if pErr, ok := err.(*Error); !ok || pErr.Msg != tc.zErr {
+			t.Errorf("%s: got %v, want %q", tc.zSql, err, tc.zErr)
+		}
+	}
+}
+
+/*
+** Each foreign key must carry the error SQLite reports when the child
+** table is written, and the reason for it, or neither if the key is
+** sound.
+ */
+func TestForeignKeyError(t *testing.T) {
+	zSql := "CREATE TABLE p(a, b, c INTEGER PRIMARY KEY, UNIQUE(a, b));" +
+		"CREATE TABLE q(x, y);" +
+		"CREATE UNIQUE INDEX qi ON q(x, y);" +
+		"CREATE TABLE c(" +
+		"  c1 REFERENCES nosuch," +
+		"  c2 REFERENCES p(a)," +
+		"  c3 REFERENCES p(zz)," +
+		"  c4 REFERENCES q," +
+		"  c5 REFERENCES p," +
+		"  c6, c7," +
+		"  FOREIGN KEY(c6, c7) REFERENCES p," +
+		"  FOREIGN KEY(c6, c7) REFERENCES p(b, a)," +
+		"  FOREIGN KEY(c6, c7) REFERENCES q(x, y)" +
+		")"
+	zMismatch := `foreign key mismatch - "c" referencing `
+	aWant := []struct {
+		zParent string
+		zReason string
+	}{
+		{"nosuch", "no such table: nosuch"},
+		{"p", "parent key is not UNIQUE"},
+		{"p", "no such column: p.zz"},
+		{"q", "parent table has no PRIMARY KEY"},
+		{"p", ""},
+		{"p", "foreign key has 2 columns but the PRIMARY KEY has 1"},
+		{"p", ""},
+		{"q", ""},
+	}
+
+	pCat, err := testCatalog(t, zSql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aFk := pCat.ForeignKeys()
+	if len(aFk) != len(aWant) {
+		t.Fatalf("got %d foreign keys, want %d", len(aFk), len(aWant))
+	}
+	for i, fk := range aFk {
+		want := aWant[i]
+		if fk.Child != "c" || fk.Parent != want.zParent {
+			t.Errorf("key %d: %s -> %s, want c -> %s", i, fk.Child, fk.Parent, want.zParent)
+		}
+		if fk.Reason != want.zReason {
+			t.Errorf("key %d: reason %q, want %q", i, fk.Reason, want.zReason)
+		}
+		if want.zReason == "" {
+			if fk.Err != nil {
+				t.Errorf("key %d: unexpected error %v", i, fk.Err)
+			}
+			continue
+		}
+		zErr := zMismatch + `"` + want.zParent + `"`
+		if pErr, ok := fk.Err.(*Error); !ok || pErr.Msg != zErr || pErr.Offset != -1 {
+			t.Errorf("key %d: error %v, want %q", i, fk.Err, zErr)
+		}
+	}
+}
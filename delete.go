@@ -0,0 +1,94 @@
+/*
+** 2001 September 15
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains C code routines that are called by the parser
+** in order to generate code for DELETE FROM statements.
+ */
+package internal
+
+/*
+** The Go port does not generate code for a DELETE statement.  Instead
+** the parse tree is recorded in one of these objects and attached to the
+** Stmt.
+ */
+type Delete struct {
+	pTabList   *SrcList  /* The table from which we should delete things */
+	pWhere     *Expr     /* The WHERE clause.  May be null */
+	pOrderBy   *ExprList /* ORDER BY clause. May be null */
+	pLimit     *Expr     /* LIMIT clause. May be null */
+	pReturning *ExprList /* RETURNING clause. May be null */
+}
+
+/*
+** Generate code for a DELETE FROM statement.
+**
+**     DELETE FROM table_wxyz WHERE a<5 AND b NOT NULL;
+**                 \________/       \________________/
+**                  pTabList              pWhere
+**
+** The pOrderBy and pLimit arguments are only non-NULL when the
+** UPDATE/DELETE LIMIT option is enabled.
+ */
+func sqlite3DeleteFrom(
+	pParse *Parse, /* The parser context */
+	pTabList *SrcList, /* The table from which we should delete things */
+	pWhere *Expr, /* The WHERE clause.  May be null */
+	pOrderBy *ExprList, /* ORDER BY clause. May be null */
+	pLimit *Expr, /* LIMIT clause. May be null */
+) {
+	var p *Delete
+	var pReturning *ExprList /* The RETURNING clause, if any */
+	var sNC NameContext      /* Name context to resolve expressions in */
+
+	if pParse.nErr != 0 {
+		goto delete_from_cleanup
+	}
+
+	/* Resolve the column names in the WHERE clause.
+	 */
+	sNC.pParse = pParse
+	sNC.pSrcList = pTabList
+	if sqlite3ResolveExprNames(&sNC, pWhere) != 0 {
+		goto delete_from_cleanup
+	}
+	pReturning = sqlite3ReturningResolve(pParse, pTabList)
+	if pParse.nErr != 0 {
+		goto delete_from_cleanup
+	}
+	assert(pOrderBy == nil || pParse.db.bUpdateDeleteLimit != 0, "pOrderBy == nil || pParse.db.bUpdateDeleteLimit != 0")
+	assert(pLimit == nil || pParse.db.bUpdateDeleteLimit != 0, "pLimit == nil || pParse.db.bUpdateDeleteLimit != 0")
+	p = &Delete{}
+	p.pTabList = pTabList
+	p.pWhere = pWhere
+	p.pOrderBy = pOrderBy
+	p.pLimit = pLimit
+	p.pReturning = pReturning
+	pParse.pDelete = p
+	return
+
+delete_from_cleanup:
+	sqlite3SrcListDelete(pParse.db, pTabList)
+	sqlite3ExprDelete(pParse.db, pWhere)
+	sqlite3ExprListDelete(pParse.db, pOrderBy)
+	sqlite3ExprDelete(pParse.db, pLimit)
+}
+
+/* Table returns the name of the table that p deletes from */
+func (p *Delete) Table() string { return string(p.pTabList.a[0].zName) }
+
+/* Where returns the WHERE clause, or nil if there is none */
+func (p *Delete) Where() *Expr { return p.pWhere }
+
+/*
+** Returning returns the terms of the RETURNING clause, or nil if there is
+** none.  A Catalog replaces "*" by the columns of the table.
+ */
+func (p *Delete) Returning() []*Expr { return exprListToSlice(p.pReturning) }
@@ -0,0 +1,784 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains routines that turn parse trees back into SQL text.
+**
+** The text produced is not the original text of the statement.  It is
+** a canonical spelling of the tree: keywords are in upper case, names
+** are quoted only where the tokenizer requires it, and parentheses are
+** added only where the precedence of the operators demands them.
+** Parsing the text again yields an equivalent tree, so trees built by
+** the parser and trees built with the routines in builder.go can be
+** used interchangeably.
+ */
+package internal
+
+import "strconv"
+
+/*
+** Operator precedence, from loosest to tightest binding.  The levels
+** follow the %left and %right declarations in parse.y.  An operand
+** whose precedence is lower than its context requires is enclosed in
+** parentheses.
+ */
+const (
+	PREC_NONE    = iota
+	PREC_OR      /* OR */
+	PREC_AND     /* AND */
+	PREC_NOT     /* NOT */
+	PREC_EQ      /* IS MATCH LIKE BETWEEN IN ISNULL NOTNULL <> = */
+	PREC_CMP     /* > <= < >= */
+	PREC_ESCAPE  /* ESCAPE */
+	PREC_BIT     /* & | << >> */
+	PREC_ADD     /* + - */
+	PREC_MUL     /* * / % */
+	PREC_CONCAT  /* || -> ->> */
+	PREC_COLLATE /* COLLATE */
+	PREC_UNARY   /* ~ and unary + and - */
+	PREC_PRIMARY /* Literals, names, function calls, (...) */
+)
+
+/*
+** Spelling and precedence of each binary operator.
+ */
+var aDeparseBinary = map[uint8]struct {
+	zOp  string /* The operator */
+	prec int    /* Its PREC_* value */
+}{
+	TK_OR:     {"OR", PREC_OR},
+	TK_AND:    {"AND", PREC_AND},
+	TK_IS:     {"IS", PREC_EQ},
+	TK_ISNOT:  {"IS NOT", PREC_EQ},
+	TK_EQ:     {"=", PREC_EQ},
+	TK_NE:     {"<>", PREC_EQ},
+	TK_LT:     {"<", PREC_CMP},
+	TK_LE:     {"<=", PREC_CMP},
+	TK_GT:     {">", PREC_CMP},
+	TK_GE:     {">=", PREC_CMP},
+	TK_BITAND: {"&", PREC_BIT},
+	TK_BITOR:  {"|", PREC_BIT},
+	TK_LSHIFT: {"<<", PREC_BIT},
+	TK_RSHIFT: {">>", PREC_BIT},
+	TK_PLUS:   {"+", PREC_ADD},
+	TK_MINUS:  {"-", PREC_ADD},
+	TK_STAR:   {"*", PREC_MUL},
+	TK_SLASH:  {"/", PREC_MUL},
+	TK_REM:    {"%", PREC_MUL},
+	TK_CONCAT: {"||", PREC_CONCAT},
+}
+
+/*
+** Return true if p is a LIKE, GLOB, MATCH or REGEXP operator.  The
+** parser builds these as functions whose arguments are in reverse
+** order.  For "x NOT LIKE y" the EP_InfixFunc flag is on the TK_NOT
+** node above the function rather than on the function itself.
+ */
+func deparseIsInfix(p *Expr) bool {
+	return p.op == TK_FUNCTION && ExprHasProperty(p, EP_InfixFunc) &&
+		ExprUseXList(p) && p.x.pList != nil && p.x.pList.nExpr >= 2
+}
+
+/*
+** Return true if p is a NOT that the parser would have produced for
+** "x NOT LIKE y", "x NOT BETWEEN a AND b" or "x NOT IN (...)".  These
+** are written with the NOT in the middle.
+ */
+func deparseIsNotInfix(p *Expr) bool {
+	if p.op != TK_NOT || p.pLeft == nil {
+		return false
+	}
+	pLeft := p.pLeft
+	if pLeft.op == TK_BETWEEN || pLeft.op == TK_IN {
+		return true
+	}
+	return pLeft.op == TK_FUNCTION && ExprUseXList(pLeft) &&
+		pLeft.x.pList != nil && pLeft.x.pList.nExpr >= 2 &&
+		(ExprHasProperty(p, EP_InfixFunc) || ExprHasProperty(pLeft, EP_InfixFunc))
+}
+
+/*
+** Return the precedence of the operator at the root of expression p.
+ */
+func deparsePrecedence(p *Expr) int {
+	switch p.op {
+	case TK_NOT:
+		if deparseIsNotInfix(p) {
+			return PREC_EQ
+		}
+		return PREC_NOT
+	case TK_ISNULL, TK_NOTNULL, TK_BETWEEN, TK_IN:
+		return PREC_EQ
+	case TK_FUNCTION:
+		if deparseIsInfix(p) {
+			return PREC_EQ
+		}
+	case TK_TRUTH:
+		return PREC_EQ
+	case TK_PTR:
+		return PREC_CONCAT
+	case TK_COLLATE:
+		return PREC_COLLATE
+	case TK_UMINUS, TK_UPLUS, TK_BITNOT:
+		return PREC_UNARY
+	default:
+		if op, ok := aDeparseBinary[p.op]; ok {
+			return op.prec
+		}
+	}
+	return PREC_PRIMARY
+}
+
+/*
+** Append the name of a function to z.  Function names are matched
+** without regard to the quoting, so a keyword that the parser accepts
+** as an identifier, such as "replace" or "like", is written as-is.
+ */
+func deparseFuncName(z []byte, zName []byte) []byte {
+	var tokenType int
+	if len(zName) > 0 && sqlite3GetToken(zName, &tokenType) == len(zName) &&
+		(tokenType == TK_ID || int(sqlite3ParserFallback(tokenType)) == TK_ID) {
+		return append(z, zName...)
+	}
+	return append(z, QuoteIdent(string(zName))...)
+}
+
+/*
+** Append an identifier to z, quoting it if required.
+ */
+func deparseName(z []byte, zName []byte) []byte {
+	return append(z, QuoteIdent(string(zName))...)
+}
+
+/*
+** Append a string literal to z.
+ */
+func deparseString(z []byte, zText []byte) []byte {
+	return append(z, sqlite3MPrintf(nil, "'%q'", zText)...)
+}
+
+/*
+** Append the elements of pList to z, separated by commas.
+ */
+func deparseExprList(z []byte, pList *ExprList) []byte {
+	if pList == nil {
+		return z
+	}
+	for i := 0; i < pList.nExpr; i++ {
+		if i > 0 {
+			z = append(z, ", "...)
+		}
+		z = deparseExpr(z, pList.a[i].pExpr, PREC_NONE)
+	}
+	return z
+}
+
+/*
+** Append an ORDER BY list to z.  The sort order and any NULLS FIRST or
+** NULLS LAST are recovered from the sortFlags set by
+** sqlite3ExprListSetSortOrder().
+ */
+func deparseSortList(z []byte, pList *ExprList) []byte {
+	for i := 0; i < pList.nExpr; i++ {
+		pItem := &pList.a[i]
+		if i > 0 {
+			z = append(z, ", "...)
+		}
+		z = deparseExpr(z, pItem.pExpr, PREC_NONE)
+		bDesc := (pItem.sortFlags & KEYINFO_ORDER_DESC) != 0
+		if bDesc {
+			z = append(z, " DESC"...)
+		}
+		if pItem.bNulls != 0 {
+			if bDesc == ((pItem.sortFlags & KEYINFO_ORDER_BIGNULL) != 0) {
+				z = append(z, " NULLS FIRST"...)
+			} else {
+				z = append(z, " NULLS LAST"...)
+			}
+		}
+	}
+	return z
+}
+
+/*
+** Append one end of a window frame to z.
+ */
+func deparseFrameBound(z []byte, eType uint8, pExpr *Expr, bEnd bool) []byte {
+	switch eType {
+	case TK_UNBOUNDED:
+		if bEnd {
+			z = append(z, "UNBOUNDED FOLLOWING"...)
+		} else {
+			z = append(z, "UNBOUNDED PRECEDING"...)
+		}
+	case TK_CURRENT:
+		z = append(z, "CURRENT ROW"...)
+	case TK_PRECEDING:
+		z = deparseExpr(z, pExpr, PREC_NONE)
+		z = append(z, " PRECEDING"...)
+	default:
+		assert(eType == TK_FOLLOWING, "eType == TK_FOLLOWING")
+		z = deparseExpr(z, pExpr, PREC_NONE)
+		z = append(z, " FOLLOWING"...)
+	}
+	return z
+}
+
+/*
+** Append the part of a window definition that goes between the
+** parentheses to z: the base window, PARTITION BY, ORDER BY and frame.
+** A frame that the parser supplied by default is omitted.
+ */
+func deparseWindowBody(z []byte, p *Window) []byte {
+	zSep := ""
+	if p.zBase != nil {
+		z = deparseName(z, p.zBase)
+		zSep = " "
+	}
+	if p.pPartition != nil {
+		z = append(z, zSep...)
+		z = append(z, "PARTITION BY "...)
+		z = deparseExprList(z, p.pPartition)
+		zSep = " "
+	}
+	if p.pOrderBy != nil {
+		z = append(z, zSep...)
+		z = append(z, "ORDER BY "...)
+		z = deparseSortList(z, p.pOrderBy)
+		zSep = " "
+	}
+	if p.eFrmType != 0 && p.eFrmType != TK_FILTER && p.bImplicitFrame == 0 {
+		z = append(z, zSep...)
+		switch p.eFrmType {
+		case TK_ROWS:
+			z = append(z, "ROWS"...)
+		case TK_GROUPS:
+			z = append(z, "GROUPS"...)
+		default:
+			z = append(z, "RANGE"...)
+		}
+		z = append(z, " BETWEEN "...)
+		z = deparseFrameBound(z, p.eStart, p.pStart, false)
+		z = append(z, " AND "...)
+		z = deparseFrameBound(z, p.eEnd, p.pEnd, true)
+		switch p.eExclude {
+		case TK_NO:
+			z = append(z, " EXCLUDE NO OTHERS"...)
+		case TK_CURRENT:
+			z = append(z, " EXCLUDE CURRENT ROW"...)
+		case TK_GROUP:
+			z = append(z, " EXCLUDE GROUP"...)
+		case TK_TIES:
+			z = append(z, " EXCLUDE TIES"...)
+		}
+	}
+	return z
+}
+
+/*
+** Append the FILTER and OVER clauses of window function pWin to z.
+ */
+func deparseOver(z []byte, pWin *Window) []byte {
+	if pWin.pFilter != nil {
+		z = append(z, " FILTER (WHERE "...)
+		z = deparseExpr(z, pWin.pFilter, PREC_NONE)
+		z = append(z, ')')
+	}
+	if pWin.eFrmType == TK_FILTER {
+		return z
+	}
+	z = append(z, " OVER "...)
+	if pWin.zName != nil {
+		return deparseName(z, pWin.zName)
+	}
+	z = append(z, '(')
+	z = deparseWindowBody(z, pWin)
+	return append(z, ')')
+}
+
+/*
+** Append a function call to z.
+ */
+func deparseFunction(z []byte, p *Expr) []byte {
+	pList := p.x.pList
+	zName := p.u.zToken
+	if pList == nil && !ExprHasProperty(p, EP_WinFunc) &&
+		sqlite3KeywordCode(zName, len(zName)) == TK_CTIME_KW {
+		/* CURRENT_TIME, CURRENT_DATE and CURRENT_TIMESTAMP */
+		return append(z, zName...)
+	}
+	z = deparseFuncName(z, zName)
+	z = append(z, '(')
+	if ExprHasProperty(p, EP_Distinct) {
+		z = append(z, "DISTINCT "...)
+	}
+	if pList == nil {
+		/* The parser builds "f()" and "f(*)" alike.  The star form is
+		 ** only meaningful for count(). */
+		if sqlite3StrICmp(zName, []byte("count")) == 0 {
+			z = append(z, '*')
+		}
+	} else {
+		z = deparseExprList(z, pList)
+	}
+	z = append(z, ')')
+	if ExprHasProperty(p, EP_WinFunc) && p.y.pWin != nil {
+		z = deparseOver(z, p.y.pWin)
+	}
+	return z
+}
+
+/*
+** Append a LIKE, GLOB, MATCH or REGEXP operator to z.  pFunc is the
+** function node and zNot is "" or " NOT".
+ */
+func deparseInfix(z []byte, pFunc *Expr, zNot string) []byte {
+	pList := pFunc.x.pList
+	z = deparseExpr(z, pList.a[1].pExpr, PREC_EQ)
+	z = append(z, zNot...)
+	z = append(z, ' ')
+	for _, c := range pFunc.u.zToken {
+		z = append(z, sqlite3Toupper(c))
+	}
+	z = append(z, ' ')
+	if pList.nExpr > 2 {
+		z = deparseExpr(z, pList.a[0].pExpr, PREC_ESCAPE+1)
+		z = append(z, " ESCAPE "...)
+		z = deparseExpr(z, pList.a[2].pExpr, PREC_EQ+1)
+	} else {
+		z = deparseExpr(z, pList.a[0].pExpr, PREC_EQ+1)
+	}
+	return z
+}
+
+/*
+** Append "x BETWEEN a AND b" or "x IN (...)" to z.  zNot is "" or
+** " NOT".
+ */
+func deparseRange(z []byte, p *Expr, zNot string) []byte {
+	z = deparseExpr(z, p.pLeft, PREC_EQ)
+	z = append(z, zNot...)
+	if p.op == TK_BETWEEN {
+		z = append(z, " BETWEEN "...)
+		z = deparseExpr(z, p.x.pList.a[0].pExpr, PREC_EQ+1)
+		z = append(z, " AND "...)
+		z = deparseExpr(z, p.x.pList.a[1].pExpr, PREC_EQ+1)
+		return z
+	}
+	z = append(z, " IN ("...)
+	if ExprUseXSelect(p) {
+		z = deparseSelect(z, p.x.pSelect)
+	} else {
+		z = deparseExprList(z, p.x.pList)
+	}
+	return append(z, ')')
+}
+
+/*
+** Append expression p to z.  If the operator at the root of p binds
+** less tightly than prec, the expression is enclosed in parentheses.
+ */
+func deparseExpr(z []byte, p *Expr, prec int) []byte {
+	if p == nil {
+		return append(z, "NULL"...)
+	}
+	bParen := deparsePrecedence(p) < prec
+	if bParen {
+		z = append(z, '(')
+	}
+	switch p.op {
+	case TK_ID:
+		z = deparseName(z, p.u.zToken)
+	case TK_DOT:
+		z = deparseExpr(z, p.pLeft, PREC_PRIMARY)
+		z = append(z, '.')
+		z = deparseExpr(z, p.pRight, PREC_PRIMARY)
+	case TK_ASTERISK:
+		z = append(z, '*')
+	case TK_STRING:
+		z = deparseString(z, p.u.zToken)
+	case TK_INTEGER:
+		if ExprHasProperty(p, EP_IntValue) {
+			z = strconv.AppendInt(z, int64(p.u.iValue), 10)
+		} else {
+			z = append(z, p.u.zToken...)
+		}
+	case TK_FLOAT, TK_BLOB, TK_VARIABLE:
+		z = append(z, p.u.zToken...)
+	case TK_NULL:
+		z = append(z, "NULL"...)
+	case TK_TRUEFALSE:
+		for _, c := range p.u.zToken {
+			z = append(z, sqlite3Toupper(c))
+		}
+	case TK_UMINUS, TK_UPLUS, TK_BITNOT:
+		switch p.op {
+		case TK_UMINUS:
+			z = append(z, '-')
+		case TK_UPLUS:
+			z = append(z, '+')
+		default:
+			z = append(z, '~')
+		}
+		zOp := deparseExpr(nil, p.pLeft, PREC_UNARY)
+		if len(zOp) > 0 && (zOp[0] == '-' || zOp[0] == '+') {
+			/* "- -1", not "--1", which would begin a comment */
+			z = append(z, ' ')
+		}
+		z = append(z, zOp...)
+	case TK_NOT:
+		if deparseIsNotInfix(p) {
+			if p.pLeft.op == TK_FUNCTION {
+				z = deparseInfix(z, p.pLeft, " NOT")
+			} else {
+				z = deparseRange(z, p.pLeft, " NOT")
+			}
+		} else {
+			z = append(z, "NOT "...)
+			z = deparseExpr(z, p.pLeft, PREC_NOT)
+		}
+	case TK_ISNULL:
+		z = deparseExpr(z, p.pLeft, PREC_EQ)
+		z = append(z, " ISNULL"...)
+	case TK_NOTNULL:
+		z = deparseExpr(z, p.pLeft, PREC_EQ)
+		z = append(z, " NOTNULL"...)
+	case TK_BETWEEN, TK_IN:
+		z = deparseRange(z, p, "")
+	case TK_TRUTH:
+		z = deparseExpr(z, p.pLeft, PREC_EQ)
+		if p.op2 == TK_ISNOT {
+			z = append(z, " IS NOT "...)
+		} else {
+			z = append(z, " IS "...)
+		}
+		z = deparseExpr(z, p.pRight, PREC_EQ+1)
+	case TK_PTR:
+		z = deparseExpr(z, p.pLeft, PREC_CONCAT)
+		z = append(z, ' ')
+		z = append(z, p.u.zToken...)
+		z = append(z, ' ')
+		z = deparseExpr(z, p.pRight, PREC_CONCAT+1)
+	case TK_COLLATE:
+		z = deparseExpr(z, p.pLeft, PREC_COLLATE)
+		z = append(z, " COLLATE "...)
+		z = deparseName(z, p.u.zToken)
+	case TK_CAST:
+		z = append(z, "CAST("...)
+		z = deparseExpr(z, p.pLeft, PREC_NONE)
+		z = append(z, " AS "...)
+		z = append(z, p.u.zToken...)
+		z = append(z, ')')
+	case TK_FUNCTION:
+		if deparseIsInfix(p) {
+			z = deparseInfix(z, p, "")
+		} else {
+			z = deparseFunction(z, p)
+		}
+	case TK_SELECT:
+		z = append(z, '(')
+		z = deparseSelect(z, p.x.pSelect)
+		z = append(z, ')')
+	case TK_EXISTS:
+		z = append(z, "EXISTS ("...)
+		z = deparseSelect(z, p.x.pSelect)
+		z = append(z, ')')
+	case TK_VECTOR:
+		z = append(z, '(')
+		z = deparseExprList(z, p.x.pList)
+		z = append(z, ')')
+	case TK_CASE:
+		pList := p.x.pList
+		z = append(z, "CASE"...)
+		if p.pLeft != nil {
+			z = append(z, ' ')
+			z = deparseExpr(z, p.pLeft, PREC_NONE)
+		}
+		i := 0
+		for ; i+1 < pList.nExpr; i += 2 {
+			z = append(z, " WHEN "...)
+			z = deparseExpr(z, pList.a[i].pExpr, PREC_NONE)
+			z = append(z, " THEN "...)
+			z = deparseExpr(z, pList.a[i+1].pExpr, PREC_NONE)
+		}
+		if i < pList.nExpr {
+			z = append(z, " ELSE "...)
+			z = deparseExpr(z, pList.a[i].pExpr, PREC_NONE)
+		}
+		z = append(z, " END"...)
+	case TK_RAISE:
+		z = append(z, "RAISE("...)
+		switch p.affExpr {
+		case OE_Ignore:
+			z = append(z, "IGNORE"...)
+		case OE_Rollback:
+			z = append(z, "ROLLBACK, "...)
+		case OE_Abort:
+			z = append(z, "ABORT, "...)
+		default:
+			z = append(z, "FAIL, "...)
+		}
+		if p.affExpr != OE_Ignore {
+			z = deparseString(z, p.u.zToken)
+		}
+		z = append(z, ')')
+	default:
+		if op, ok := aDeparseBinary[p.op]; ok {
+			z = deparseExpr(z, p.pLeft, op.prec)
+			z = append(z, ' ')
+			z = append(z, op.zOp...)
+			z = append(z, ' ')
+			z = deparseExpr(z, p.pRight, op.prec+1)
+		} else {
+			/* Nodes such as TK_COLUMN are created after name resolution,
+			 ** which the Go port does not do. */
+			z = append(z, p.u.zToken...)
+		}
+	}
+	if bParen {
+		z = append(z, ')')
+	}
+	return z
+}
+
+/*
+** Append the FROM clause pSrc to z, without the FROM keyword.
+ */
+func deparseSrcList(z []byte, pSrc *SrcList) []byte {
+	for i := 0; i < pSrc.nSrc; i++ {
+		pItem := &pSrc.a[i]
+		if i > 0 {
+			jointype := pItem.fg.jointype &^ JT_LTORJ
+			if jointype == JT_INNER && pItem.u3.pOn == nil && pItem.u3.pUsing == nil {
+				z = append(z, ", "...)
+			} else {
+				z = append(z, ' ')
+				if (jointype & JT_NATURAL) != 0 {
+					z = append(z, "NATURAL "...)
+				}
+				switch {
+				case (jointype & (JT_LEFT | JT_RIGHT)) == (JT_LEFT | JT_RIGHT):
+					z = append(z, "FULL "...)
+				case (jointype & JT_LEFT) != 0:
+					z = append(z, "LEFT "...)
+				case (jointype & JT_RIGHT) != 0:
+					z = append(z, "RIGHT "...)
+				case (jointype & JT_CROSS) != 0:
+					z = append(z, "CROSS "...)
+				}
+				z = append(z, "JOIN "...)
+			}
+		}
+		if pItem.pSelect != nil {
+			z = append(z, '(')
+			if (pItem.pSelect.selFlags & SF_NestedFrom) != 0 {
+				z = deparseSrcList(z, pItem.pSelect.pSrc)
+			} else {
+				z = deparseSelect(z, pItem.pSelect)
+			}
+			z = append(z, ')')
+		} else {
+			if pItem.zDatabase != nil {
+				z = deparseName(z, pItem.zDatabase)
+				z = append(z, '.')
+			}
+			z = deparseName(z, pItem.zName)
+			if pItem.u1.pFuncArg != nil {
+				z = append(z, '(')
+				z = deparseExprList(z, pItem.u1.pFuncArg)
+				z = append(z, ')')
+			}
+		}
+		if pItem.zAlias != nil {
+			z = append(z, " AS "...)
+			z = deparseName(z, pItem.zAlias)
+		}
+		if pItem.u1.zIndexedBy != nil {
+			z = append(z, " INDEXED BY "...)
+			z = deparseName(z, pItem.u1.zIndexedBy)
+		} else if pItem.fg.notIndexed != 0 {
+			z = append(z, " NOT INDEXED"...)
+		}
+		if pItem.u3.pOn != nil {
+			z = append(z, " ON "...)
+			z = deparseExpr(z, pItem.u3.pOn, PREC_NONE)
+		} else if pItem.u3.pUsing != nil {
+			z = append(z, " USING ("...)
+			for j := 0; j < pItem.u3.pUsing.nId; j++ {
+				if j > 0 {
+					z = append(z, ", "...)
+				}
+				z = deparseName(z, pItem.u3.pUsing.a[j].zName)
+			}
+			z = append(z, ')')
+		}
+	}
+	return z
+}
+
+/*
+** Append the WITH clause pWith to z, followed by a space.
+ */
+func deparseWith(z []byte, pWith *With) []byte {
+	z = append(z, "WITH "...)
+	for i := 0; i < pWith.nCte; i++ {
+		pCte := &pWith.a[i]
+		if i > 0 {
+			z = append(z, ", "...)
+		}
+		z = deparseName(z, pCte.zName)
+		if pCte.pCols != nil {
+			z = append(z, '(')
+			for j := 0; j < pCte.pCols.nExpr; j++ {
+				if j > 0 {
+					z = append(z, ", "...)
+				}
+				z = deparseName(z, pCte.pCols.a[j].zEName)
+			}
+			z = append(z, ')')
+		}
+		z = append(z, " AS "...)
+		switch pCte.eM10d {
+		case M10d_Yes:
+			z = append(z, "MATERIALIZED "...)
+		case M10d_No:
+			z = append(z, "NOT MATERIALIZED "...)
+		}
+		z = append(z, '(')
+		z = deparseSelect(z, pCte.pSelect)
+		z = append(z, ')')
+	}
+	return append(z, ' ')
+}
+
+/*
+** Append a single SELECT or VALUES term of a compound to z.  The ORDER
+** BY and LIMIT clauses are not included.  They belong to the compound
+** as a whole and are written by deparseSelect().
+ */
+func deparseOneSelect(z []byte, p *Select) []byte {
+	if (p.selFlags & SF_Values) != 0 {
+		z = append(z, "VALUES("...)
+		z = deparseExprList(z, p.pEList)
+		return append(z, ')')
+	}
+	z = append(z, "SELECT "...)
+	if (p.selFlags & SF_Distinct) != 0 {
+		z = append(z, "DISTINCT "...)
+	} else if (p.selFlags & SF_All) != 0 {
+		z = append(z, "ALL "...)
+	}
+	for i := 0; i < p.pEList.nExpr; i++ {
+		pItem := &p.pEList.a[i]
+		if i > 0 {
+			z = append(z, ", "...)
+		}
+		z = deparseExpr(z, pItem.pExpr, PREC_NONE)
+		if pItem.eEName == ENAME_NAME && pItem.zEName != nil {
+			z = append(z, " AS "...)
+			z = deparseName(z, pItem.zEName)
+		}
+	}
+	if p.pSrc != nil && p.pSrc.nSrc > 0 {
+		z = append(z, " FROM "...)
+		z = deparseSrcList(z, p.pSrc)
+	}
+	if p.pWhere != nil {
+		z = append(z, " WHERE "...)
+		z = deparseExpr(z, p.pWhere, PREC_NONE)
+	}
+	if p.pGroupBy != nil {
+		z = append(z, " GROUP BY "...)
+		z = deparseExprList(z, p.pGroupBy)
+	}
+	if p.pHaving != nil {
+		z = append(z, " HAVING "...)
+		z = deparseExpr(z, p.pHaving, PREC_NONE)
+	}
+	for pWin := p.pWinDefn; pWin != nil; pWin = pWin.pNextWin {
+		if pWin == p.pWinDefn {
+			z = append(z, " WINDOW "...)
+		} else {
+			z = append(z, ", "...)
+		}
+		z = deparseName(z, pWin.zName)
+		z = append(z, " AS ("...)
+		z = deparseWindowBody(z, pWin)
+		z = append(z, ')')
+	}
+	return z
+}
+
+/*
+** Append the SELECT statement p to z.  If p is a compound, the terms
+** are written from left to right, which is the reverse of the order of
+** the pPrior list.
+**
+** A VALUES clause of several rows is held as a UNION ALL of its rows,
+** with SF_MultiValue set on the last.  The parser clears SF_MultiValue
+** when such a VALUES is the left operand of a compound, so in that case
+** the rows are written as separate VALUES terms joined by UNION ALL,
+** which gives the same tree.
+ */
+func deparseSelect(z []byte, p *Select) []byte {
+	var aTerm []*Select
+	if p.pWith != nil {
+		z = deparseWith(z, p.pWith)
+	}
+	for pLoop := p; pLoop != nil; pLoop = pLoop.pPrior {
+		aTerm = append(aTerm, pLoop)
+	}
+	for i := len(aTerm) - 1; i >= 0; i-- {
+		pLoop := aTerm[i]
+		if i < len(aTerm)-1 {
+			if (p.selFlags & SF_MultiValue) != 0 {
+				/* Another row of a multi-row VALUES clause */
+				z = append(z, ", ("...)
+				z = deparseExprList(z, pLoop.pEList)
+				z = append(z, ')')
+				continue
+			}
+			z = append(z, ' ')
+			z = append(z, sqlite3SelectOpName(int(pLoop.op))...)
+			z = append(z, ' ')
+		}
+		z = deparseOneSelect(z, pLoop)
+	}
+	if p.pOrderBy != nil {
+		z = append(z, " ORDER BY "...)
+		z = deparseSortList(z, p.pOrderBy)
+	}
+	if p.pLimit != nil {
+		z = append(z, " LIMIT "...)
+		z = deparseExpr(z, p.pLimit.pLeft, PREC_NONE)
+		if p.pLimit.pRight != nil {
+			z = append(z, " OFFSET "...)
+			z = deparseExpr(z, p.pLimit.pRight, PREC_NONE)
+		}
+	}
+	return z
+}
+
+/*
+** SQL returns the text of the expression.  The text is a canonical
+** spelling of the tree, not the text it was parsed from.
+ */
+func (p *Expr) SQL() string {
+	return string(deparseExpr(nil, p, PREC_NONE))
+}
+
+/*
+** SQL returns the text of the SELECT statement, without a trailing
+** semicolon.  The text is a canonical spelling of the tree, not the
+** text it was parsed from.
+ */
+func (p *Select) SQL() string {
+	return string(deparseSelect(nil, p))
+}
@@ -0,0 +1,73 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Tests for rendering parse trees back into SQL text.
+ */
+package internal
+
+import "testing"
+
+/*
+** Every SELECT must render to the expected text, and that text must
+** parse back into a tree with the same compound operators and flags
+** that renders to the same text again.  An empty zWant means the text
+** is expected to render unchanged.
+ */
+func TestDeparseRoundTrip(t *testing.T) {
+	aTest := []struct {
+		zSql  string
+		zWant string
+	}{
+		{"VALUES(1) UNION ALL VALUES(2)", ""},
+		{"VALUES(1),(2),(3)", "VALUES(1), (2), (3)"},
+		{"VALUES(1),(2) UNION VALUES(3)",
+			"VALUES(1) UNION ALL VALUES(2) UNION VALUES(3)"},
+		{"SELECT 1 UNION ALL VALUES(1),(2)",
+			"SELECT 1 UNION ALL SELECT * FROM (VALUES(1), (2))"},
+		{"SELECT DISTINCT a, b AS c FROM t WHERE a > 1 GROUP BY b HAVING count(*) > 2 ORDER BY 1 DESC LIMIT 10 OFFSET 5", ""},
+		{"SELECT * FROM t1 LEFT JOIN t2 ON t1.a = t2.b JOIN t3 USING (c)", ""},
+		{"SELECT a FROM (SELECT a FROM t) AS s WHERE a IN (SELECT b FROM u)", ""},
+		{"WITH c(x) AS (SELECT 1 UNION ALL SELECT x+1 FROM c WHERE x<10) SELECT x FROM c",
+			"WITH c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c WHERE x < 10) SELECT x FROM c"},
+		{"SELECT sum(a) OVER w, rank() OVER (PARTITION BY b ORDER BY c ROWS BETWEEN 1 PRECEDING AND CURRENT ROW) FROM t WINDOW w AS (ORDER BY a)", ""},
+		{"SELECT CASE a WHEN 1 THEN 'x' ELSE 'y' END, CAST(b AS TEXT), c COLLATE nocase, d BETWEEN 1 AND 2, e LIKE 'f%' ESCAPE '\\', EXISTS (SELECT 1) FROM t", ""},
+		{"SELECT a FROM t EXCEPT SELECT b FROM u INTERSECT SELECT c FROM v", ""},
+		{"SELECT count(*) FILTER (WHERE a > 1) FROM t", ""},
+		{"SELECT a IS NOT DISTINCT FROM b, x IS NOT NULL FROM t",
+			"SELECT a IS b, x NOTNULL FROM t"},
+	}
+	for _, tc := range aTest {
+		zWant := tc.zWant
+		if zWant == "" {
+			zWant = tc.zSql
+		}
+		p := testSelect(t, tc.zSql)
+		zGot := p.SQL()
+		if zGot != zWant {
+			t.Errorf("%s:\n got %s\nwant %s", tc.zSql, zGot, zWant)
+			continue
+		}
+		p2 := testSelect(t, zGot)
+		if zAgain := p2.SQL(); zAgain != zGot {
+			t.Errorf("%s:\n renders as %s\nthen as %s", tc.zSql, zGot, zAgain)
+		}
+		for pA, pB := p, p2; pA != nil || pB != nil; pA, pB = pA.pPrior, pB.pPrior {
+			if pA == nil || pB == nil {
+				t.Errorf("%s: compound has a different number of terms", tc.zSql)
+				break
+			}
+			if pA.op != pB.op || pA.selFlags != pB.selFlags {
+				t.Errorf("%s: term op/flags %d/%#x reparse as %d/%#x",
+					tc.zSql, pA.op, pA.selFlags, pB.op, pB.selFlags)
+			}
+		}
+	}
+}
@@ -0,0 +1,1929 @@
+/*
+** 2001 September 15
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains routines used for analyzing expressions and
+** for generating VDBE code that evaluates expressions in SQLite.
+ */
+package internal
+
+import (
+	"bytes"
+	"strconv"
+)
+
+/*
+** Set the error offset for an Expr node, if possible.
+ */
+func sqlite3RecordErrorOffsetOfExpr(db *sqlite3, pExpr *Expr) {
+	for pExpr != nil &&
+		(ExprHasProperty(pExpr, EP_FromJoin|EP_InnerJoin) || pExpr.w.iOfst <= 0) {
+		pExpr = pExpr.pLeft
+	}
+	if pExpr == nil {
+		return
+	}
+	db.errByteOffset = pExpr.w.iOfst
+}
+
+/*
+** Return the 'affinity' of the expression pExpr if any.
+**
+** If pExpr is a column, a reference to a column via an 'AS' alias,
+** or a sub-select with a column as the return value, then the
+** affinity of that column is returned. Otherwise, 0x00 is returned,
+** indicating no affinity for the expression.
+**
+** i.e. the WHERE clause expressions in the following statements all
+** have an affinity:
+**
+** CREATE TABLE t1(a);
+** SELECT * FROM t1 WHERE a;
+** SELECT a AS b FROM t1 WHERE b;
+** SELECT * FROM t1 WHERE (select a from t1);
+**
+** The Go port does not resolve names, so a column reference never has
+** an affinity here.
+ */
+func sqlite3ExprAffinity(pExpr *Expr) rune {
+	var op uint8
+	for ExprHasProperty(pExpr, EP_Skip|EP_IfNullRow) {
+		assert(pExpr.op == TK_COLLATE || pExpr.op == TK_IF_NULL_ROW ||
+			(pExpr.op == TK_REGISTER && pExpr.op2 == TK_IF_NULL_ROW),
+			"pExpr.op == TK_COLLATE || pExpr.op == TK_IF_NULL_ROW || ...")
+		pExpr = pExpr.pLeft
+		assert(pExpr != nil, "pExpr != nil")
+	}
+	op = pExpr.op
+	if op == TK_REGISTER {
+		op = pExpr.op2
+	}
+	if op == TK_SELECT {
+		assert(ExprUseXSelect(pExpr), "ExprUseXSelect(pExpr)")
+		assert(pExpr.x.pSelect != nil, "pExpr.x.pSelect != nil")
+		assert(pExpr.x.pSelect.pEList != nil, "pExpr.x.pSelect.pEList != nil")
+		assert(pExpr.x.pSelect.pEList.a[0].pExpr != nil, "pExpr.x.pSelect.pEList.a[0].pExpr != nil")
+		return sqlite3ExprAffinity(pExpr.x.pSelect.pEList.a[0].pExpr)
+	}
+	if op == TK_CAST {
+		assert(!ExprHasProperty(pExpr, EP_IntValue), "!ExprHasProperty(pExpr, EP_IntValue)")
+		return sqlite3AffinityType(pExpr.u.zToken, nil)
+	}
+	if op == TK_SELECT_COLUMN {
+		assert(pExpr.pLeft != nil && ExprUseXSelect(pExpr.pLeft), "pExpr.pLeft != nil && ExprUseXSelect(pExpr.pLeft)")
+		return sqlite3ExprAffinity(
+			pExpr.pLeft.x.pSelect.pEList.a[pExpr.iColumn].pExpr,
+		)
+	}
+	if op == TK_VECTOR {
+		assert(ExprUseXList(pExpr), "ExprUseXList(pExpr)")
+		return sqlite3ExprAffinity(pExpr.x.pList.a[0].pExpr)
+	}
+	return pExpr.affExpr
+}
+
+/*
+** Set the collating sequence for expression pExpr to be the collating
+** sequence named by pToken.   Return a pointer to a new Expr node that
+** implements the COLLATE operator.
+**
+** If a memory allocation error occurs, that fact is recorded in pParse->db
+** and the pExpr parameter is returned unchanged.
+ */
+func sqlite3ExprAddCollateToken(
+	pParse *Parse, /* Parsing context */
+	pExpr *Expr, /* Add the "COLLATE" clause to this expression */
+	pCollName *Token, /* Name of collating sequence */
+	dequote int, /* True to dequote pCollName */
+) *Expr {
+	if pCollName.n > 0 {
+		pNew := sqlite3ExprAlloc(pParse.db, TK_COLLATE, pCollName, dequote)
+		pNew.pLeft = pExpr
+		pNew.flags |= EP_Collate | EP_Skip
+		pExpr = pNew
+	}
+	return pExpr
+}
+
+/*
+** Skip over any TK_COLLATE operators.
+ */
+func sqlite3ExprSkipCollate(pExpr *Expr) *Expr {
+	for pExpr != nil && ExprHasProperty(pExpr, EP_Skip) {
+		assert(pExpr.op == TK_COLLATE, "pExpr.op == TK_COLLATE")
+		pExpr = pExpr.pLeft
+	}
+	return pExpr
+}
+
+/*
+** Skip over any TK_COLLATE operators and/or any unlikely()
+** or likelihood() or likely() functions at the root of an
+** expression.
+ */
+func sqlite3ExprSkipCollateAndLikely(pExpr *Expr) *Expr {
+	for pExpr != nil && ExprHasProperty(pExpr, EP_Skip|EP_Unlikely) {
+		if ExprHasProperty(pExpr, EP_Unlikely) {
+			assert(ExprUseXList(pExpr), "ExprUseXList(pExpr)")
+			assert(pExpr.x.pList.nExpr > 0, "pExpr.x.pList.nExpr > 0")
+			assert(pExpr.op == TK_FUNCTION, "pExpr.op == TK_FUNCTION")
+			pExpr = pExpr.x.pList.a[0].pExpr
+		} else {
+			assert(pExpr.op == TK_COLLATE, "pExpr.op == TK_COLLATE")
+			pExpr = pExpr.pLeft
+		}
+	}
+	return pExpr
+}
+
+/*
+** pExpr is an operand of a comparison operator.  aff2 is the
+** type affinity of the other operand.  This routine returns the
+** type affinity that should be used for the comparison operator.
+ */
+func sqlite3CompareAffinity(pExpr *Expr, aff2 rune) rune {
+	aff1 := sqlite3ExprAffinity(pExpr)
+	if aff1 > SQLITE_AFF_NONE && aff2 > SQLITE_AFF_NONE {
+		/* Both sides of the comparison are columns. If one has numeric
+		 ** affinity, use that. Otherwise use no affinity.
+		 */
+		if sqlite3IsNumericAffinity(aff1) || sqlite3IsNumericAffinity(aff2) {
+			return SQLITE_AFF_NUMERIC
+		} else {
+			return SQLITE_AFF_BLOB
+		}
+	} else {
+		/* One side is a column, the other is not. Use the columns affinity. */
+		assert(aff1 <= SQLITE_AFF_NONE || aff2 <= SQLITE_AFF_NONE, "aff1 <= SQLITE_AFF_NONE || aff2 <= SQLITE_AFF_NONE")
+		if aff1 <= SQLITE_AFF_NONE {
+			return aff2 | SQLITE_AFF_NONE
+		}
+		return aff1 | SQLITE_AFF_NONE
+	}
+}
+
+/*
+** Return the number of elements in the vector passed as the only argument.
+** If the argument is not a vector, return 1.
+ */
+func sqlite3ExprVectorSize(pExpr *Expr) int {
+	op := pExpr.op
+	if op == TK_REGISTER {
+		op = pExpr.op2
+	}
+	if op == TK_VECTOR {
+		return pExpr.x.pList.nExpr
+	} else if op == TK_SELECT {
+		return pExpr.x.pSelect.pEList.nExpr
+	} else {
+		return 1
+	}
+}
+
+/*
+** Compute and return a new Expr object which when passed to
+** sqlite3ExprCode() will generate all necessary code to compute
+** the iField-th column of the vector expression pVector.
+**
+** It is ok for pVector to be a scalar (as long as iField==0).
+** In that case, this routine works like sqlite3ExprDup().
+**
+** The caller owns the returned Expr object and is responsible for
+** ensuring that the returned value eventually gets freed.
+ */
+func sqlite3ExprForVectorField(
+	pParse *Parse, /* Parsing context */
+	pVector *Expr, /* The vector.  List of expressions or a sub-SELECT */
+	iField int, /* Which column of the vector to return */
+	nField int, /* Total number of columns in the vector */
+) *Expr {
+	var pRet *Expr
+	if pVector.op == TK_SELECT {
+		assert(ExprUseXSelect(pVector), "ExprUseXSelect(pVector)")
+		/* The TK_SELECT_COLUMN Expr node:
+		 **
+		 ** pLeft:           pVector containing TK_SELECT.  Not deleted.
+		 ** pRight:          not used.  But recursively deleted.
+		 ** iColumn:         Index of a column in pVector
+		 ** iTable:          0 or the number of columns on the LHS of an assignment
+		 ** pLeft->iTable:   First in an array of register holding result, or 0
+		 **                  if the result is not yet computed.
+		 */
+		pRet = sqlite3PExpr(pParse, TK_SELECT_COLUMN, nil, nil)
+		if pRet != nil {
+			pRet.iTable = nField
+			pRet.iColumn = ynVar(iField)
+			pRet.pLeft = pVector
+		}
+	} else {
+		if pVector.op == TK_VECTOR {
+			assert(ExprUseXList(pVector), "ExprUseXList(pVector)")
+			ppVector := &pVector.x.pList.a[iField].pExpr
+			pVector = *ppVector
+			if pParse.eParseMode >= PARSE_MODE_RENAME {
+				/* This must be a vector UPDATE inside a trigger */
+				*ppVector = nil
+				return pVector
+			}
+		}
+		pRet = sqlite3ExprDup(pParse.db, pVector, 0)
+	}
+	return pRet
+}
+
+// #if SQLITE_MAX_EXPR_DEPTH>0
+/*
+** Check that argument nHeight is less than or equal to the maximum
+** expression depth allowed. If it is not, leave an error message in
+** pParse.
+ */
+func sqlite3ExprCheckHeight(pParse *Parse, nHeight int) int {
+	rc := SQLITE_OK
+	mxHeight := pParse.db.aLimit[SQLITE_LIMIT_EXPR_DEPTH]
+	if nHeight > mxHeight {
+		sqlite3ErrorMsg(pParse,
+			"Expression tree is too large (maximum depth %d)", mxHeight,
+		)
+		rc = SQLITE_ERROR
+	}
+	return rc
+}
+
+/* The following three functions, heightOfExpr(), heightOfExprList()
+** and heightOfSelect(), are used to determine the maximum height
+** of any expression tree referenced by the structure passed as the
+** first argument.
+**
+** If this maximum height is greater than the current value pointed
+** to by pnHeight, the second parameter, then set *pnHeight to that
+** value.
+ */
+func heightOfExpr(p *Expr, pnHeight *int) {
+	if p != nil {
+		if p.nHeight > *pnHeight {
+			*pnHeight = p.nHeight
+		}
+	}
+}
+func heightOfExprList(p *ExprList, pnHeight *int) {
+	if p != nil {
+		for i := 0; i < p.nExpr; i++ {
+			heightOfExpr(p.a[i].pExpr, pnHeight)
+		}
+	}
+}
+func heightOfSelect(pSelect *Select, pnHeight *int) {
+	for p := pSelect; p != nil; p = p.pPrior {
+		heightOfExpr(p.pWhere, pnHeight)
+		heightOfExpr(p.pHaving, pnHeight)
+		heightOfExpr(p.pLimit, pnHeight)
+		heightOfExprList(p.pEList, pnHeight)
+		heightOfExprList(p.pGroupBy, pnHeight)
+		heightOfExprList(p.pOrderBy, pnHeight)
+	}
+}
+
+/*
+** Set the Expr.nHeight variable in the structure passed as an
+** argument. An expression with no children, Expr.pList or
+** Expr.pSelect member has a height of 1. Any other expression
+** has a height equal to the maximum height of any other
+** referenced Expr plus one.
+**
+** Also propagate EP_Propagate flags up from Expr.x.pList to Expr.flags,
+** if appropriate.
+ */
+func exprSetHeight(p *Expr) {
+	nHeight := 0
+	if p.pLeft != nil {
+		nHeight = p.pLeft.nHeight
+	}
+	if p.pRight != nil && p.pRight.nHeight > nHeight {
+		nHeight = p.pRight.nHeight
+	}
+	if ExprUseXSelect(p) {
+		heightOfSelect(p.x.pSelect, &nHeight)
+	} else if p.x.pList != nil {
+		heightOfExprList(p.x.pList, &nHeight)
+		p.flags |= EP_Propagate & sqlite3ExprListFlags(p.x.pList)
+	}
+	p.nHeight = nHeight + 1
+}
+
+/*
+** Set the Expr.nHeight variable using the exprSetHeight() function. If
+** the height is greater than the maximum allowed expression depth,
+** leave an error in pParse.
+**
+** Also propagate all EP_Propagate flags from the Expr.x.pList into
+** Expr.flags.
+ */
+func sqlite3ExprSetHeightAndFlags(pParse *Parse, p *Expr) {
+	if pParse.nErr != 0 {
+		return
+	}
+	exprSetHeight(p)
+	sqlite3ExprCheckHeight(pParse, p.nHeight)
+}
+
+/*
+** Return the maximum height of any expression tree referenced
+** by the select statement passed as an argument.
+ */
+func sqlite3SelectExprHeight(p *Select) int {
+	nHeight := 0
+	heightOfSelect(p, &nHeight)
+	return nHeight
+}
+
+// #endif /* SQLITE_MAX_EXPR_DEPTH>0 */
+
+/*
+** This routine is the core allocator for Expr nodes.
+**
+** Construct a new expression node and return a pointer to it.  Memory
+** for this node and for the pToken argument is a single allocation
+** obtained from sqlite3DbMalloc().  The calling function
+** is responsible for making sure the node eventually gets freed.
+**
+** If dequote is true, then the token (if it exists) is dequoted.
+** If dequote is false, no dequoting is performed.  The deQuote
+** parameter is ignored if pToken is NULL or if the token does not
+** appear to be quoted.  If the quotes were of the form "..." (double-quotes)
+** then the EP_DblQuoted flag is set on the expression node.
+**
+** Special case:  If op==TK_INTEGER and pToken points to a string that
+** can be translated into a 32-bit integer, then the token is not
+** stored in u.zToken.  Instead, the integer values is written
+** into u.iValue and the EP_IntValue flag is set.  No extra storage
+** is allocated to hold the integer text and the dequote flag is ignored.
+ */
+func sqlite3ExprAlloc(db *sqlite3, op int, pToken *Token, dequote int) *Expr {
+	var pNew *Expr
+	iValue := 0
+	isInt := false
+
+	if pToken != nil {
+		if op == TK_INTEGER && pToken.z != nil &&
+			sqlite3GetInt32(pToken.z[:pToken.n], &iValue) != 0 {
+			isInt = true
+		}
+	}
+	pNew = &Expr{}
+	pNew.op = uint8(op)
+	pNew.iAgg = -1
+	if pToken != nil {
+		if isInt {
+			pNew.flags |= EP_IntValue | EP_Leaf
+			if iValue != 0 {
+				pNew.flags |= EP_IsTrue
+			} else {
+				pNew.flags |= EP_IsFalse
+			}
+			pNew.u.iValue = iValue
+		} else {
+			/* Refer to the token text in place.  Make a private copy only
+			 ** if it is about to be dequoted, which modifies the text. */
+			pNew.u.zToken = pToken.z[:pToken.n:pToken.n]
+			if dequote != 0 && len(pNew.u.zToken) > 0 && sqlite3Isquote(pNew.u.zToken[0]) {
+				pNew.u.zToken = append([]byte(nil), pNew.u.zToken...)
+				sqlite3DequoteExpr(pNew)
+			}
+		}
+	}
+	// #if SQLITE_MAX_EXPR_DEPTH>0
+	pNew.nHeight = 1
+	// #endif
+	return pNew
+}
+
+/*
+** Allocate a new expression node from a zero-terminated token that has
+** already been dequoted.
+ */
+func sqlite3Expr(db *sqlite3, op int, zToken []byte) *Expr {
+	var x Token
+	x.z = zToken
+	x.n = uint(sqlite3Strlen30(zToken))
+	return sqlite3ExprAlloc(db, op, &x, 0)
+}
+
+/*
+** Attach subtrees pLeft and pRight to the Expr node pRoot.
+**
+** If pRoot==NULL that means that a memory allocation error has occurred.
+** In that case, delete the subtrees pLeft and pRight.
+ */
+func sqlite3ExprAttachSubtrees(db *sqlite3, pRoot, pLeft, pRight *Expr) {
+	if pRoot == nil {
+		sqlite3ExprDelete(db, pLeft)
+		sqlite3ExprDelete(db, pRight)
+	} else {
+		if pRight != nil {
+			pRoot.pRight = pRight
+			pRoot.flags |= EP_Propagate & pRight.flags
+		}
+		if pLeft != nil {
+			pRoot.pLeft = pLeft
+			pRoot.flags |= EP_Propagate & pLeft.flags
+		}
+		exprSetHeight(pRoot)
+	}
+}
+
+/*
+** Allocate an Expr node which joins as many as two subtrees.
+**
+** One or both of the subtrees can be NULL.  Return a pointer to the new
+** Expr node.  Or, if an OOM error occurs, set pParse->db->mallocFailed,
+** free the subtrees and return NULL.
+ */
+func sqlite3PExpr(pParse *Parse, op int, pLeft, pRight *Expr) *Expr {
+	p := &Expr{}
+	p.op = uint8(op & 0xff)
+	p.iAgg = -1
+	sqlite3ExprAttachSubtrees(pParse.db, p, pLeft, pRight)
+	sqlite3ExprCheckHeight(pParse, p.nHeight)
+	return p
+}
+
+/*
+** Add pSelect to the Expr.x.pSelect field.  Or, if pExpr is NULL (due
+** do a memory allocation failure) then delete the pSelect object.
+ */
+func sqlite3PExprAddSelect(pParse *Parse, pExpr *Expr, pSelect *Select) {
+	if pExpr != nil {
+		pExpr.x.pSelect = pSelect
+		ExprSetProperty(pExpr, EP_xIsSelect|EP_Subquery)
+		sqlite3ExprSetHeightAndFlags(pParse, pExpr)
+	} else {
+		sqlite3SelectDelete(pParse.db, pSelect)
+	}
+}
+
+/*
+** Invoke sqlite3RenameExprUnmap() and sqlite3ExprDelete() on the
+** expression.
+ */
+func sqlite3ExprUnmapAndDelete(pParse *Parse, p *Expr) {
+	if p != nil {
+		sqlite3ExprDelete(pParse.db, p)
+	}
+}
+
+/*
+** Expression list pEList is a list of vector values. This function
+** converts the contents of pEList to a VALUES(...) Select statement
+** returning 1 row for each element of the list. For example, the
+** expression list:
+**
+**   ( (1,2), (3,4) (5,6) )
+**
+** is translated to the equivalent of:
+**
+**   VALUES(1,2), (3,4), (5,6)
+**
+** Each of the vector values in pEList must contain exactly nElem terms.
+** If a list element that is not a vector or does not contain nElem terms,
+** an error message is left in pParse.
+**
+** This is used as part of processing IN(...) expressions with a list
+** of vectors on the RHS. e.g. "... IN ((1,2), (3,4), (5,6))".
+ */
+func sqlite3ExprListToValues(pParse *Parse, nElem int, pEList *ExprList) *Select {
+	var pRet *Select
+	assert(nElem > 1, "nElem > 1")
+	for ii := 0; ii < pEList.nExpr; ii++ {
+		pExpr := pEList.a[ii].pExpr
+		var nExprElem int
+		if pExpr.op == TK_VECTOR {
+			assert(ExprUseXList(pExpr), "ExprUseXList(pExpr)")
+			nExprElem = pExpr.x.pList.nExpr
+		} else {
+			nExprElem = 1
+		}
+		if nExprElem != nElem {
+			zPlural := ""
+			if nExprElem > 1 {
+				zPlural = "s"
+			}
+			sqlite3ErrorMsg(pParse, "IN(...) element has %d term%s - expected %d",
+				nExprElem, zPlural, nElem,
+			)
+			break
+		}
+		assert(ExprUseXList(pExpr), "ExprUseXList(pExpr)")
+		pSel := sqlite3SelectNew(pParse, pExpr.x.pList, nil, nil, nil, nil, nil, SF_Values, nil)
+		pExpr.x.pList = nil
+		if pSel != nil {
+			if pRet != nil {
+				pSel.op = TK_ALL
+				pSel.pPrior = pRet
+			}
+			pRet = pSel
+		}
+	}
+
+	if pRet != nil && pRet.pPrior != nil {
+		pRet.selFlags |= SF_MultiValue
+	}
+	sqlite3ExprListDelete(pParse.db, pEList)
+	return pRet
+}
+
+/*
+** Join two expressions using an AND operator.  If either expression is
+** NULL, then just return the other expression.
+**
+** If one side or the other of the AND is known to be false, then instead
+** of returning an AND expression, just return a constant expression with
+** a value of false.
+ */
+func sqlite3ExprAnd(pParse *Parse, pLeft *Expr, pRight *Expr) *Expr {
+	db := pParse.db
+	if pLeft == nil {
+		return pRight
+	} else if pRight == nil {
+		return pLeft
+	} else if ExprAlwaysFalse(pLeft) || ExprAlwaysFalse(pRight) {
+		sqlite3ExprDelete(db, pLeft)
+		sqlite3ExprDelete(db, pRight)
+		return sqlite3Expr(db, TK_INTEGER, []byte("0"))
+	} else {
+		return sqlite3PExpr(pParse, TK_AND, pLeft, pRight)
+	}
+}
+
+/*
+** Construct a new expression node for a function with multiple
+** arguments.
+ */
+func sqlite3ExprFunction(
+	pParse *Parse, /* Parsing context */
+	pList *ExprList, /* Argument list */
+	pToken *Token, /* Name of the function */
+	eDistinct int, /* SF_Distinct or SF_ALL or 0 */
+) *Expr {
+	var pNew *Expr
+	db := pParse.db
+	assert(pToken != nil, "pToken != nil")
+	pNew = sqlite3ExprAlloc(db, TK_FUNCTION, pToken, 1)
+	pNew.w.iOfst = len(pParse.zTail) - len(pToken.z)
+	if pList != nil &&
+		pList.nExpr > pParse.db.aLimit[SQLITE_LIMIT_FUNCTION_ARG] &&
+		pParse.nested == 0 {
+		sqlite3ErrorMsg(pParse, "too many arguments on function %T", pToken)
+	}
+	pNew.x.pList = pList
+	ExprSetProperty(pNew, EP_HasFunc)
+	assert(ExprUseXList(pNew), "ExprUseXList(pNew)")
+	sqlite3ExprSetHeightAndFlags(pParse, pNew)
+	if eDistinct == SF_Distinct {
+		ExprSetProperty(pNew, EP_Distinct)
+	}
+	return pNew
+}
+
+/*
+** Assign a variable number to an expression that encodes a wildcard
+** in the original SQL statement.
+**
+** Wildcards consisting of a single "?" are assigned the next sequential
+** variable number.
+**
+** Wildcards of the form "?nnn" are assigned the number "nnn".  We make
+** sure "nnn" is not too big to avoid a denial of service attack when
+** the SQL statement comes from an external source.
+**
+** Wildcards of the form ":aaa", "@aaa", or "$aaa" are assigned the same number
+** as the previous instance of the same wildcard.  Or if this is the first
+** instance of the wildcard, the next sequential variable number is
+** assigned.
+ */
+func sqlite3ExprAssignVarNumber(pParse *Parse, pExpr *Expr, n uint) {
+	db := pParse.db
+	var z []byte
+	var x ynVar
+
+	if pExpr == nil {
+		return
+	}
+	assert(!ExprHasProperty(pExpr, EP_IntValue|EP_Reduced|EP_TokenOnly),
+		"!ExprHasProperty(pExpr, EP_IntValue|EP_Reduced|EP_TokenOnly)")
+	z = pExpr.u.zToken
+	assert(len(z) > 0, "len(z) > 0")
+	assert(uint(len(z)) == n, "uint(len(z)) == n")
+	if len(z) == 1 {
+		/* Wildcard of the form "?".  Assign the next variable number */
+		assert(z[0] == '?', "z[0] == '?'")
+		pParse.nVar++
+		x = pParse.nVar
+	} else {
+		doAdd := false
+		if z[0] == '?' {
+			/* Wildcard of the form "?nnn".  Convert "nnn" to an integer and
+			 ** use it as the variable number */
+			var i int64
+			var bOk bool
+			if n == 2 { /*OPTIMIZATION-IF-TRUE*/
+				i = int64(z[1]) - '0' /* The common case of ?N for a single digit N */
+				bOk = true
+			} else {
+				v, err := strconv.ParseInt(string(z[1:]), 10, 64)
+				i, bOk = v, err == nil
+			}
+			if !bOk || i < 1 || i > int64(db.aLimit[SQLITE_LIMIT_VARIABLE_NUMBER]) {
+				sqlite3ErrorMsg(pParse, "variable number must be between ?1 and ?%d",
+					db.aLimit[SQLITE_LIMIT_VARIABLE_NUMBER])
+				sqlite3RecordErrorOffsetOfExpr(pParse.db, pExpr)
+				return
+			}
+			x = ynVar(i)
+			if x > pParse.nVar {
+				pParse.nVar = x
+				doAdd = true
+			} else if sqlite3VListNumToName(pParse.pVList, int(x)) == nil {
+				doAdd = true
+			}
+		} else {
+			/* Wildcards like ":aaa", "$aaa" or "@aaa".  Reuse the same variable
+			 ** number as the prior appearance of the same name, or if the name
+			 ** has never appeared before, reuse the same variable number
+			 */
+			x = ynVar(sqlite3VListNameToNum(pParse.pVList, z))
+			if x == 0 {
+				pParse.nVar++
+				x = pParse.nVar
+				doAdd = true
+			}
+		}
+		if doAdd {
+			pParse.pVList = sqlite3VListAdd(db, pParse.pVList, z, int(x))
+		}
+	}
+	pExpr.iColumn = x
+	if int(x) > db.aLimit[SQLITE_LIMIT_VARIABLE_NUMBER] {
+		sqlite3ErrorMsg(pParse, "too many SQL variables")
+		sqlite3RecordErrorOffsetOfExpr(pParse.db, pExpr)
+	}
+}
+
+/*
+** Add a new element to the end of an expression list.  If pList is
+** initially NULL, then create a new expression list.
+**
+** The pList argument must be either NULL or a pointer to an ExprList
+** obtained from a prior call to sqlite3ExprListAppend().
+**
+** If a memory allocation error occurs, the entire list is freed and
+** NULL is returned.  If non-NULL is returned, then it is guaranteed
+** that the new entry was successfully appended.
+ */
+func sqlite3ExprListAppend(
+	pParse *Parse, /* Parsing context */
+	pList *ExprList, /* List to which to append. Might be NULL */
+	pExpr *Expr, /* Expression to be appended. Might be NULL */
+) *ExprList {
+	if pList == nil {
+		pList = &ExprList{}
+	}
+	pList.a = append(pList.a, ExprList_item{pExpr: pExpr})
+	pList.nExpr++
+	pList.nAlloc = cap(pList.a)
+	return pList
+}
+
+/*
+** pColumns and pExpr form a vector assignment which is part of the SET
+** clause of an UPDATE statement.  Like this:
+**
+**        (a,b,c) = (expr1,expr2,expr3)
+** Or:    (a,b,c) = (SELECT x,y,z FROM ....)
+**
+** For each term of the vector assignment, append new entries to the
+** expression list pList.  In the case of a subquery on the RHS, append
+** TK_SELECT_COLUMN expressions.
+ */
+func sqlite3ExprListAppendVector(
+	pParse *Parse, /* Parsing context */
+	pList *ExprList, /* List to which to append. Might be NULL */
+	pColumns *IdList, /* List of names of LHS of the assignment */
+	pExpr *Expr, /* Vector expression to be appended. Might be NULL */
+) *ExprList {
+	db := pParse.db
+	iFirst := 0
+	if pList != nil {
+		iFirst = pList.nExpr
+	}
+
+	/* pColumns can only be NULL due to an OOM but an OOM will cause an
+	 ** exit prior to this routine being invoked */
+	if NEVER(pColumns == nil) || pExpr == nil {
+		goto vector_append_error
+	}
+
+	/* If the RHS is a vector, then we can immediately check to see that
+	 ** the size of the RHS and LHS match.  But if the RHS is a SELECT,
+	 ** wildcards ("*") in the result set of the SELECT must be expanded before
+	 ** we can do the size check, so defer the size check until code generation.
+	 */
+	if pExpr.op != TK_SELECT {
+		if n := sqlite3ExprVectorSize(pExpr); pColumns.nId != n {
+			sqlite3ErrorMsg(pParse, "%d columns assigned %d values",
+				pColumns.nId, n)
+			goto vector_append_error
+		}
+	}
+
+	for i := 0; i < pColumns.nId; i++ {
+		pSubExpr := sqlite3ExprForVectorField(pParse, pExpr, i, pColumns.nId)
+		if pSubExpr == nil {
+			continue
+		}
+		pList = sqlite3ExprListAppend(pParse, pList, pSubExpr)
+		if pList != nil {
+			assert(pList.nExpr == iFirst+i+1, "pList.nExpr == iFirst+i+1")
+			pList.a[pList.nExpr-1].zEName = pColumns.a[i].zName
+			pColumns.a[i].zName = nil
+		}
+	}
+
+	if pExpr.op == TK_SELECT && ALWAYS(pList != nil) {
+		pFirst := pList.a[iFirst].pExpr
+		assert(pFirst != nil, "pFirst != nil")
+		assert(pFirst.op == TK_SELECT_COLUMN, "pFirst.op == TK_SELECT_COLUMN")
+
+		/* Store the SELECT statement in pRight so it will be deleted when
+		 ** sqlite3ExprListDelete() is called */
+		pFirst.pRight = pExpr
+		pExpr = nil
+
+		/* Remember the size of the LHS in iTable so that we can check that
+		 ** the RHS and LHS sizes match during code generation. */
+		pFirst.iTable = pColumns.nId
+	}
+
+vector_append_error:
+	sqlite3ExprUnmapAndDelete(pParse, pExpr)
+	sqlite3IdListDelete(db, pColumns)
+	return pList
+}
+
+/*
+** Set the sort order for the last element on the given ExprList.
+ */
+func sqlite3ExprListSetSortOrder(p *ExprList, iSortOrder int, eNulls int) {
+	var pItem *ExprList_item
+	if p == nil {
+		return
+	}
+	assert(p.nExpr > 0, "p.nExpr > 0")
+	assert(iSortOrder == SQLITE_SO_UNDEFINED || iSortOrder == SQLITE_SO_ASC || iSortOrder == SQLITE_SO_DESC,
+		"iSortOrder == SQLITE_SO_UNDEFINED || iSortOrder == SQLITE_SO_ASC || iSortOrder == SQLITE_SO_DESC")
+	assert(eNulls == SQLITE_SO_UNDEFINED || eNulls == SQLITE_SO_ASC || eNulls == SQLITE_SO_DESC,
+		"eNulls == SQLITE_SO_UNDEFINED || eNulls == SQLITE_SO_ASC || eNulls == SQLITE_SO_DESC")
+
+	pItem = &p.a[p.nExpr-1]
+	assert(pItem.bNulls == 0, "pItem.bNulls == 0")
+	if iSortOrder == SQLITE_SO_UNDEFINED {
+		iSortOrder = SQLITE_SO_ASC
+	}
+	pItem.sortFlags = uint8(iSortOrder)
+
+	if eNulls != SQLITE_SO_UNDEFINED {
+		pItem.bNulls = 1
+		if iSortOrder != eNulls {
+			pItem.sortFlags |= KEYINFO_ORDER_BIGNULL
+		}
+	}
+}
+
+/*
+** Set the ExprList.a[].zEName element of the most recently added item
+** on the expression list.
+**
+** pList might be NULL following an OOM error.  But pName should never be
+** NULL.  If a memory allocation fails, the pParse->db->mallocFailed flag
+** is set.
+ */
+func sqlite3ExprListSetName(
+	pParse *Parse, /* Parsing context */
+	pList *ExprList, /* List to which to add the span. */
+	pName *Token, /* Name to be added */
+	dequote int, /* True to cause the name to be dequoted */
+) {
+	if pList != nil {
+		var pItem *ExprList_item
+		assert(pList.nExpr > 0, "pList.nExpr > 0")
+		pItem = &pList.a[pList.nExpr-1]
+		assert(pItem.zEName == nil, "pItem.zEName == nil")
+		assert(pItem.eEName == ENAME_NAME, "pItem.eEName == ENAME_NAME")
+		pItem.zEName = sqlite3DbStrNDup(pParse.db, pName.z, pName.n)
+		if dequote != 0 {
+			pItem.zEName = sqlite3Dequote(pItem.zEName)
+		}
+	}
+}
+
+/*
+** Set the ExprList.a[].zSpan element of the most recently added item
+** on the expression list.
+**
+** pList might be NULL following an OOM error.  But pSpan should never be
+** NULL.  If a memory allocation fails, the pParse->db->mallocFailed flag
+** is set.
+ */
+func sqlite3ExprListSetSpan(
+	pParse *Parse, /* Parsing context */
+	pList *ExprList, /* List to which to add the span. */
+	zStart []byte, /* Start of the span */
+	zEnd []byte, /* End of the span */
+) {
+	db := pParse.db
+	if pList != nil {
+		pItem := &pList.a[pList.nExpr-1]
+		assert(pList.nExpr > 0, "pList.nExpr > 0")
+		if pItem.zEName == nil {
+			pItem.zEName = sqlite3DbSpanDup(db, zStart, zEnd)
+			pItem.eEName = ENAME_SPAN
+		}
+	}
+}
+
+/*
+** If the expression list pEList contains more than iLimit elements,
+** leave an error message in pParse.
+ */
+func sqlite3ExprListCheckLength(
+	pParse *Parse,
+	pEList *ExprList,
+	zObject string,
+) {
+	mx := pParse.db.aLimit[SQLITE_LIMIT_COLUMN]
+	if pEList != nil && pEList.nExpr > mx {
+		sqlite3ErrorMsg(pParse, "too many columns in %s", zObject)
+	}
+}
+
+/*
+** Return the bitwise-OR of all Expr.flags fields in the given
+** ExprList.
+ */
+func sqlite3ExprListFlags(pList *ExprList) uint32 {
+	var m uint32
+	assert(pList != nil, "pList != nil")
+	for i := 0; i < pList.nExpr; i++ {
+		pExpr := pList.a[i].pExpr
+		assert(pExpr != nil, "pExpr != nil")
+		m |= pExpr.flags
+	}
+	return m
+}
+
+/*
+** This is a SELECT-node callback for the expression walker that
+** always "fails".  By "fail" in this case, we mean set
+** pWalker->eCode to zero and abort.
+**
+** This callback is used by multiple expression walkers.
+ */
+func sqlite3SelectWalkFail(pWalker *Walker, NotUsed *Select) int {
+	pWalker.eCode = 0
+	return WRC_Abort
+}
+
+/*
+** Check the input string to see if it is "true" or "false" (in any case).
+**
+**       If the string is....           Return
+**         "true"                         EP_IsTrue
+**         "false"                        EP_IsFalse
+**         anything else                  0
+ */
+func sqlite3IsTrueOrFalse(zIn []byte) uint32 {
+	if sqlite3StrICmp(zIn, []byte("true")) == 0 {
+		return EP_IsTrue
+	}
+	if sqlite3StrICmp(zIn, []byte("false")) == 0 {
+		return EP_IsFalse
+	}
+	return 0
+}
+
+/*
+** If the input expression is an ID with the name "true" or "false"
+** then convert it into an TK_TRUEFALSE term.  Return non-zero if
+** the conversion happened, and zero if the expression is unaltered.
+ */
+func sqlite3ExprIdToTrueFalse(pExpr *Expr) int {
+	var v uint32
+	assert(pExpr.op == TK_ID || pExpr.op == TK_STRING, "pExpr.op == TK_ID || pExpr.op == TK_STRING")
+	if !ExprHasProperty(pExpr, EP_Quoted|EP_IntValue) {
+		if v = sqlite3IsTrueOrFalse(pExpr.u.zToken); v != 0 {
+			pExpr.op = TK_TRUEFALSE
+			ExprSetProperty(pExpr, v)
+			return 1
+		}
+	}
+	return 0
+}
+
+/*
+** The argument must be a TK_TRUEFALSE Expr node.  Return 1 if it is TRUE
+** and 0 if it is FALSE.
+ */
+func sqlite3ExprTruthValue(pExpr *Expr) bool {
+	pExpr = sqlite3ExprSkipCollateAndLikely(pExpr)
+	assert(pExpr.op == TK_TRUEFALSE, "pExpr.op == TK_TRUEFALSE")
+	assert(sqlite3StrICmp(pExpr.u.zToken, []byte("true")) == 0 ||
+		sqlite3StrICmp(pExpr.u.zToken, []byte("false")) == 0,
+		"sqlite3StrICmp(pExpr.u.zToken, \"true\") == 0 || ...")
+	return len(pExpr.u.zToken) == 4
+}
+
+/*
+** If pExpr is an AND or OR expression, try to simplify it by eliminating
+** terms that are always true or false.  Return the simplified expression.
+** Or return the original expression if no simplification is possible.
+**
+** Examples:
+**
+**     (x<10) AND true                =>   (x<10)
+**     (x<10) AND false               =>   false
+**     (x<10) AND (y=22 OR false)     =>   (x<10) AND (y=22)
+**     (x<10) AND (y=22 OR true)      =>   (x<10)
+**     (y=22) OR true                 =>   true
+ */
+func sqlite3ExprSimplifiedAndOr(pExpr *Expr) *Expr {
+	assert(pExpr != nil, "pExpr != nil")
+	if pExpr.op == TK_AND || pExpr.op == TK_OR {
+		pRight := sqlite3ExprSimplifiedAndOr(pExpr.pRight)
+		pLeft := sqlite3ExprSimplifiedAndOr(pExpr.pLeft)
+		if ExprAlwaysTrue(pLeft) || ExprAlwaysFalse(pRight) {
+			if pExpr.op == TK_AND {
+				pExpr = pRight
+			} else {
+				pExpr = pLeft
+			}
+		} else if ExprAlwaysTrue(pRight) || ExprAlwaysFalse(pLeft) {
+			if pExpr.op == TK_AND {
+				pExpr = pLeft
+			} else {
+				pExpr = pRight
+			}
+		}
+	}
+	return pExpr
+}
+
+/*
+** These routines are Walker callbacks used to check expressions to
+** see if they are "constant" for some definition of constant.  The
+** Walker.eCode value determines the type of "constant" we are looking
+** for.
+**
+** These callback routines are used to implement the following:
+**
+**     sqlite3ExprIsConstant()                  pWalker->eCode==1
+**     sqlite3ExprIsConstantNotJoin()           pWalker->eCode==2
+**
+** In all cases, the callbacks set Walker.eCode=0 and abort if the expression
+** is found to not be a constant.
+**
+** The sqlite3ExprIsConstantOrFunction() is used for evaluating DEFAULT
+** expressions in a CREATE TABLE statement.  The Go port has no function
+** registry, so a function call is never constant.
+ */
+func exprNodeIsConstant(pWalker *Walker, pExpr *Expr) int {
+	/* If pWalker->eCode is 2 then any term of the expression that comes from
+	 ** the ON or USING clauses of a left join disqualifies the expression
+	 ** from being considered constant. */
+	if pWalker.eCode == 2 && ExprHasProperty(pExpr, EP_FromJoin) {
+		pWalker.eCode = 0
+		return WRC_Abort
+	}
+
+	switch pExpr.op {
+	/* Consider functions to be constant if all their arguments are constant
+	 ** and either pWalker->eCode==4 or 5 or the function has the
+	 ** SQLITE_FUNC_CONST flag. */
+	case TK_FUNCTION:
+		if (pWalker.eCode >= 4 || ExprHasProperty(pExpr, EP_ConstFunc)) &&
+			!ExprHasProperty(pExpr, EP_WinFunc) {
+			return WRC_Continue
+		}
+		pWalker.eCode = 0
+		return WRC_Abort
+	case TK_ID:
+		/* Convert "true" or "false" in a DEFAULT clause into the
+		 ** appropriate TK_TRUEFALSE operator */
+		if sqlite3ExprIdToTrueFalse(pExpr) != 0 {
+			return WRC_Prune
+		}
+		fallthrough
+	case TK_COLUMN, TK_AGG_FUNCTION, TK_AGG_COLUMN:
+		testcase(pExpr.op == TK_ID)
+		testcase(pExpr.op == TK_COLUMN)
+		testcase(pExpr.op == TK_AGG_FUNCTION)
+		testcase(pExpr.op == TK_AGG_COLUMN)
+		if ExprHasProperty(pExpr, EP_FixedCol) && pWalker.eCode != 2 {
+			return WRC_Continue
+		}
+		if pWalker.eCode == 3 && pExpr.iTable == pWalker.u.iCur {
+			return WRC_Continue
+		}
+		fallthrough
+	case TK_IF_NULL_ROW, TK_REGISTER, TK_DOT, TK_RAISE:
+		testcase(pExpr.op == TK_REGISTER)
+		testcase(pExpr.op == TK_IF_NULL_ROW)
+		testcase(pExpr.op == TK_DOT)
+		pWalker.eCode = 0
+		return WRC_Abort
+	case TK_VARIABLE:
+		if pWalker.eCode == 5 {
+			/* Silently convert bound parameters that appear inside of CREATE
+			 ** statements into a NULL when parsing the CREATE statement text out
+			 ** of the sqlite_schema table */
+			pExpr.op = TK_NULL
+		} else if pWalker.eCode == 4 {
+			/* A bound parameter in a CREATE statement that originates from
+			 ** sqlite3_prepare() causes an error */
+			pWalker.eCode = 0
+			return WRC_Abort
+		}
+		fallthrough
+	default:
+		testcase(pExpr.op == TK_SELECT) /* sqlite3SelectWalkFail() disallows */
+		testcase(pExpr.op == TK_EXISTS) /* sqlite3SelectWalkFail() disallows */
+		return WRC_Continue
+	}
+}
+func exprIsConst(p *Expr, initFlag int, iCur int) int {
+	var w Walker
+	w.eCode = uint16(initFlag)
+	w.xExprCallback = exprNodeIsConstant
+	w.xSelectCallback = sqlite3SelectWalkFail
+	w.u.iCur = iCur
+	sqlite3WalkExpr(&w, p)
+	return int(w.eCode)
+}
+
+/*
+** Return TRUE if the given string is a row-id column name.
+ */
+func sqlite3IsRowid(z []byte) bool {
+	if sqlite3StrICmp(z, []byte("_ROWID_")) == 0 {
+		return true
+	}
+	if sqlite3StrICmp(z, []byte("ROWID")) == 0 {
+		return true
+	}
+	if sqlite3StrICmp(z, []byte("OID")) == 0 {
+		return true
+	}
+	return false
+}
+
+/*
+** Walk an expression tree.  Return non-zero if the expression is constant
+** and 0 if it involves variables or function calls.
+**
+** For the purposes of this function, a double-quoted string (ex: "abc")
+** is considered a variable but a single-quoted string (ex: 'abc') is
+** a constant.
+ */
+func sqlite3ExprIsConstant(p *Expr) int {
+	return exprIsConst(p, 1, 0)
+}
+
+/*
+** Walk an expression tree.  Return non-zero if
+**
+**   (1) the expression is constant, and
+**   (2) the expression does originate in the ON or USING clause
+**       of a LEFT JOIN, and
+**   (3) the expression does not contain any EP_FixedCol TK_COLUMN
+**       operands created by the constant propagation optimization.
+**
+** When this routine returns true, it indicates that the expression
+** can be added to the pParse->pConstExpr list and evaluated once when
+** the prepared statement starts up.  See sqlite3ExprCodeRunJustOnce().
+ */
+func sqlite3ExprIsConstantNotJoin(p *Expr) int {
+	return exprIsConst(p, 2, 0)
+}
+
+/*
+** If the expression p codes a constant integer that is small enough
+** to fit in a 32-bit integer, return 1 and put the value of the integer
+** in *pValue.  If the expression is not an integer or if it is too big
+** to fit in a signed 32-bit integer, return 0 and leave *pValue unchanged.
+ */
+func sqlite3ExprIsInteger(p *Expr, pValue *int) int {
+	rc := 0
+	if NEVER(p == nil) {
+		return 0 /* Used to only happen following on OOM */
+	}
+	if (p.flags & EP_IntValue) != 0 {
+		*pValue = p.u.iValue
+		return 1
+	}
+	switch p.op {
+	case TK_UPLUS:
+		rc = sqlite3ExprIsInteger(p.pLeft, pValue)
+	case TK_UMINUS:
+		v := 0
+		if sqlite3ExprIsInteger(p.pLeft, &v) != 0 {
+			*pValue = -v
+			rc = 1
+		}
+	}
+	return rc
+}
+
+/*
+** Convert an expression node to TK_STRING quoted text by removing the
+** quotes, and set EP_Quoted (and EP_DblQuoted for "...") on the node.
+ */
+func sqlite3DequoteExpr(p *Expr) {
+	assert(!ExprHasProperty(p, EP_IntValue), "!ExprHasProperty(p, EP_IntValue)")
+	assert(sqlite3Isquote(p.u.zToken[0]), "sqlite3Isquote(p.u.zToken[0])")
+	if p.u.zToken[0] == '"' {
+		p.flags |= EP_Quoted | EP_DblQuoted
+	} else {
+		p.flags |= EP_Quoted
+	}
+	p.u.zToken = sqlite3Dequote(p.u.zToken)
+}
+
+/*
+** Return the value of the literal expression pExpr, negated if negFlag
+** is true.  This follows the rules that codeInteger() and codeReal()
+** use when generating code for a literal.  Return a non-nil error if
+** pExpr is not a literal or is malformed.
+ */
+func sqlite3ExprLiteralValue(pExpr *Expr, negFlag bool) (interface{}, error) {
+	var zErr []byte
+	z := pExpr.u.zToken
+	switch pExpr.op {
+	case TK_UPLUS, TK_UMINUS:
+		pLeft := pExpr.pLeft
+		if pLeft != nil && (pLeft.op == TK_INTEGER || pLeft.op == TK_FLOAT ||
+			pLeft.op == TK_UPLUS || pLeft.op == TK_UMINUS) {
+			return sqlite3ExprLiteralValue(pExpr.pLeft, negFlag != (pExpr.op == TK_UMINUS))
+		}
+	case TK_NULL:
+		if !negFlag {
+			return nil, nil
+		}
+	case TK_STRING:
+		if !negFlag {
+			return string(z), nil
+		}
+	case TK_BLOB:
+		if !negFlag {
+			/* The tokenizer and DecodeJSON accept only well-formed X'hex' */
+			return sqlite3HexToBlob(nil, z[2:len(z)-1]), nil
+		}
+	case TK_INTEGER:
+		if ExprHasProperty(pExpr, EP_IntValue) {
+			i := int64(pExpr.u.iValue)
+			if negFlag {
+				i = -i
+			}
+			return i, nil
+		}
+		var value int64
+		c := sqlite3DecOrHexToI64(z, &value)
+		if (c == 3 && !negFlag) || (c == 2) || (negFlag && value == SMALLEST_INT64) {
+			if sqlite3_strnicmp(z, []byte("0x"), 2) == 0 {
+				zNeg := ""
+				if negFlag {
+					zNeg = "-"
+				}
+				zErr = sqlite3MPrintf(nil, "hex literal too big: %s%s", zNeg, z)
+				return nil, &Error{Code: SQLITE_ERROR, Msg: string(zErr), Offset: -1}
+			}
+			return sqlite3ExprRealValue(z, negFlag), nil
+		}
+		if negFlag {
+			if c == 3 {
+				value = SMALLEST_INT64
+			} else {
+				value = -value
+			}
+		}
+		return value, nil
+	case TK_FLOAT:
+		return sqlite3ExprRealValue(z, negFlag), nil
+	}
+	return nil, &Error{Code: SQLITE_ERROR, Msg: "not a literal value", Offset: -1}
+}
+
+/*
+** Return the floating point value of the numeric literal z.  Literals
+** too large for a double become an infinity, as in sqlite3AtoF().
+ */
+func sqlite3ExprRealValue(z []byte, negFlag bool) float64 {
+	r, _ := strconv.ParseFloat(string(z), 64)
+	if negFlag {
+		r = -r
+	}
+	return r
+}
+
+/*
+** Value returns the value of a literal expression:
+**
+**    NULL                          nil
+**    'text'                        string, with the quotes removed
+**    X'hex'                        []byte
+**    123 or 0x7b                   int64
+**    1.5, 1e3, or an integer too
+**      large for a 64-bit integer  float64
+**
+** A unary minus or plus in front of a numeric literal is applied to the
+** value.  An error is returned if p is not a literal or if a hexadecimal
+** literal does not fit in 64 bits.
+ */
+func (p *Expr) Value() (interface{}, error) {
+	return sqlite3ExprLiteralValue(p, false)
+}
+
+/*
+** Construct a new expression node for the JSON operators -> and ->>.
+** pOp is the operator token.  The node has op TK_PTR and u.zToken set
+** to the text of the operator, so that ->, which returns JSON, can be
+** told apart from ->>, which returns an SQL value.
+**
+** If the right operand is a string literal that begins with "$", then
+** it is a JSON path and must be well-formed.  Any other right operand is
+** an object label or array index and is checked only at run-time.
+ */
+func sqlite3ExprPtr(pParse *Parse, pLeft *Expr, pOp *Token, pRight *Expr) *Expr {
+	var pNew *Expr
+	db := pParse.db
+	pNew = sqlite3PExpr(pParse, TK_PTR, pLeft, pRight)
+	pNew.u.zToken = pOp.z[:pOp.n:pOp.n]
+	pNew.w.iOfst = len(pParse.zTail) - len(pOp.z)
+	if pRight != nil && pRight.op == TK_STRING &&
+		charAt(pRight.u.zToken, 0) == '$' && !jsonPathIsValid(pRight.u.zToken) {
+		sqlite3ErrorMsg(pParse, "bad JSON path: %Q", pRight.u.zToken)
+		sqlite3RecordErrorOffsetOfExpr(db, pRight)
+	}
+	return pNew
+}
+
+/*
+** This function is the workhorse of sqlite3ExprDup().  It returns a
+** copy of the Expr p together with every subtree, window and
+** subquery that hangs off of it.
+**
+** In C, the EXPRDUP_REDUCE flag allows the copy to be stored in a
+** reduced-size allocation.  Every Go Expr has the same size, so the
+** flag is accepted for compatibility but otherwise ignored.
+ */
+func exprDup(db *sqlite3, p *Expr, dupFlags int) *Expr {
+	assert(p != nil, "p != nil")
+	assert(dupFlags == 0 || dupFlags == EXPRDUP_REDUCE, "dupFlags == 0 || dupFlags == EXPRDUP_REDUCE")
+
+	pNew := &Expr{}
+	*pNew = *p
+
+	/* Copy the token text.  The original usually refers to the SQL
+	 ** text of the statement and must not be shared. */
+	if !ExprHasProperty(p, EP_IntValue) {
+		pNew.u.zToken = sqlite3DbStrDup(db, p.u.zToken)
+	}
+	ExprClearProperty(pNew, EP_Static|EP_MemToken)
+
+	if !ExprHasProperty(p, EP_TokenOnly|EP_Leaf) {
+		/* Fill in the pNew.x.pSelect or pNew.x.pList member. */
+		if ExprUseXSelect(p) {
+			pNew.x.pSelect = sqlite3SelectDup(db, p.x.pSelect, dupFlags)
+			pNew.x.pList = nil
+		} else {
+			pNew.x.pList = sqlite3ExprListDup(db, p.x.pList, dupFlags)
+			pNew.x.pSelect = nil
+		}
+
+		/* Fill in pNew.pLeft and pNew.pRight.  The C version leaves the
+		 ** pLeft of a TK_SELECT_COLUMN pointing at the original vector,
+		 ** which is shared by its siblings.  Here the copy gets a vector
+		 ** of its own; sqlite3ExprListDup() restores the sharing between
+		 ** siblings within the new list. */
+		pNew.pRight = nil
+		if p.pRight != nil {
+			pNew.pRight = exprDup(db, p.pRight, 0)
+		}
+		pNew.pLeft = nil
+		if p.op == TK_SELECT_COLUMN && p.pRight != nil && p.pLeft == p.pRight {
+			pNew.pLeft = pNew.pRight
+		} else if p.pLeft != nil {
+			pNew.pLeft = exprDup(db, p.pLeft, 0)
+		}
+	} else {
+		pNew.pLeft = nil
+		pNew.pRight = nil
+		pNew.x.pList = nil
+		pNew.x.pSelect = nil
+	}
+
+	if ExprHasProperty(p, EP_WinFunc) {
+		pNew.y.pWin = sqlite3WindowDup(db, pNew, p.y.pWin)
+		assert(ExprHasProperty(pNew, EP_WinFunc), "ExprHasProperty(pNew, EP_WinFunc)")
+	}
+	return pNew
+}
+
+/*
+** The following group of routines make deep copies of expressions,
+** expression lists, ID lists, and select statements.  The copies can
+** be deleted (by being passed to their respective ...Delete() routines)
+** without effecting the originals.
+**
+** The expression list, ID, and source lists return by sqlite3ExprListDup(),
+** sqlite3IdListDup(), and sqlite3SrcListDup() can not be further expanded
+** by subsequent calls to sqlite*ListAppend() routines.
+**
+** Any tables that the SrcList might point to are reused.
+**
+** The flags parameter contains a combination of the EXPRDUP_XXX flags.
+** If the EXPRDUP_REDUCE flag is set, then the structure returned is a
+** truncated version of the usual Expr structure that will be stored as
+** part of the in-memory representation of the database schema.
+ */
+func sqlite3ExprDup(db *sqlite3, p *Expr, flags int) *Expr {
+	assert(flags == 0 || flags == EXPRDUP_REDUCE, "flags == 0 || flags == EXPRDUP_REDUCE")
+	if p == nil {
+		return nil
+	}
+	return exprDup(db, p, flags)
+}
+
+func sqlite3ExprListDup(db *sqlite3, p *ExprList, flags int) *ExprList {
+	var pPriorSelectColOld *Expr
+	var pPriorSelectColNew *Expr
+	if p == nil {
+		return nil
+	}
+	pNew := &ExprList{}
+	pNew.nExpr = p.nExpr
+	pNew.a = make([]ExprList_item, p.nExpr)
+	pNew.nAlloc = p.nExpr
+	for i := 0; i < p.nExpr; i++ {
+		pItem := &pNew.a[i]
+		pOldItem := &p.a[i]
+		pOldExpr := pOldItem.pExpr
+		*pItem = *pOldItem
+		pItem.pExpr = sqlite3ExprDup(db, pOldExpr, flags)
+		if pOldExpr != nil && pOldExpr.op == TK_SELECT_COLUMN && pItem.pExpr != nil {
+			pNewExpr := pItem.pExpr
+			if pNewExpr.pRight != nil {
+				pPriorSelectColOld = pOldExpr.pRight
+				pPriorSelectColNew = pNewExpr.pRight
+				pNewExpr.pLeft = pNewExpr.pRight
+			} else {
+				if pOldExpr.pLeft != pPriorSelectColOld {
+					pPriorSelectColOld = pOldExpr.pLeft
+					pPriorSelectColNew = pNewExpr.pLeft
+					pNewExpr.pRight = pPriorSelectColNew
+				}
+				pNewExpr.pLeft = pPriorSelectColNew
+			}
+		}
+		pItem.zEName = sqlite3DbStrDup(db, pOldItem.zEName)
+	}
+	return pNew
+}
+
+/*
+** If cursors, triggers, views and subqueries are all omitted from
+** the build, then none of the following routines, except for
+** sqlite3SelectDup(), can be called. sqlite3SelectDup() is sometimes
+** called with a NULL argument.
+**
+** The Table, Schema, Index and CteUse objects referenced from a SrcItem
+** belong to the schema or to the parser rather than to the parse tree,
+** so they are shared with the copy, as in C.  sqlite3WithDup() shares
+** the CteUse of each CTE in the same way, so a FROM clause term of the
+** copy and the CTE it refers to have the same CteUse.  Unlike C, the
+** reference counts Table.nTabRef and CteUse.nUse are not incremented.
+** A copy is made to be examined or modified on its own, and copying a
+** tree must leave the original, including the Uses reported by
+** Select.With(), unchanged.  Nothing that works on a copy changes a
+** CteUse.
+ */
+func sqlite3SrcListDup(db *sqlite3, p *SrcList, flags int) *SrcList {
+	if p == nil {
+		return nil
+	}
+	pNew := &SrcList{}
+	pNew.nSrc = p.nSrc
+	pNew.nAlloc = uint32(p.nSrc)
+	pNew.a = make([]SrcItem, p.nSrc)
+	pNew.sFrom = p.sFrom
+	for i := 0; i < p.nSrc; i++ {
+		pNewItem := &pNew.a[i]
+		pOldItem := &p.a[i]
+		pNewItem.pSchema = pOldItem.pSchema
+		pNewItem.zDatabase = sqlite3DbStrDup(db, pOldItem.zDatabase)
+		pNewItem.zName = sqlite3DbStrDup(db, pOldItem.zName)
+		pNewItem.zAlias = sqlite3DbStrDup(db, pOldItem.zAlias)
+		pNewItem.fg = pOldItem.fg
+		pNewItem.iCursor = pOldItem.iCursor
+		pNewItem.addrFillSub = pOldItem.addrFillSub
+		pNewItem.regReturn = pOldItem.regReturn
+		pNewItem.regResult = pOldItem.regResult
+		pNewItem.u1.zIndexedBy = sqlite3DbStrDup(db, pOldItem.u1.zIndexedBy)
+		pNewItem.u1.pFuncArg = sqlite3ExprListDup(db, pOldItem.u1.pFuncArg, flags)
+		pNewItem.u2 = pOldItem.u2
+		pNewItem.pTab = pOldItem.pTab
+		pNewItem.pSelect = sqlite3SelectDup(db, pOldItem.pSelect, flags)
+		pNewItem.u3.pUsing = sqlite3IdListDup(db, pOldItem.u3.pUsing)
+		pNewItem.u3.pOn = sqlite3ExprDup(db, pOldItem.u3.pOn, flags)
+		pNewItem.colUsed = pOldItem.colUsed
+	}
+	return pNew
+}
+func sqlite3IdListDup(db *sqlite3, p *IdList) *IdList {
+	if p == nil {
+		return nil
+	}
+	pNew := &IdList{}
+	pNew.nId = p.nId
+	pNew.eU4 = p.eU4
+	pNew.a = make([]struct {
+		zName []byte
+		idx   int
+		pExpr *Expr
+	}, p.nId)
+	for i := 0; i < p.nId; i++ {
+		pNewItem := &pNew.a[i]
+		pOldItem := &p.a[i]
+		pNewItem.zName = sqlite3DbStrDup(db, pOldItem.zName)
+		pNewItem.idx = pOldItem.idx
+		pNewItem.pExpr = sqlite3ExprDup(db, pOldItem.pExpr, 0)
+	}
+	return pNew
+}
+func sqlite3SelectDup(db *sqlite3, pDup *Select, flags int) *Select {
+	var pRet *Select
+	var pNext *Select
+	pp := &pRet
+	for p := pDup; p != nil; p = p.pPrior {
+		pNew := &Select{}
+		pNew.pEList = sqlite3ExprListDup(db, p.pEList, flags)
+		pNew.pSrc = sqlite3SrcListDup(db, p.pSrc, flags)
+		pNew.pWhere = sqlite3ExprDup(db, p.pWhere, flags)
+		pNew.pGroupBy = sqlite3ExprListDup(db, p.pGroupBy, flags)
+		pNew.pHaving = sqlite3ExprDup(db, p.pHaving, flags)
+		pNew.pOrderBy = sqlite3ExprListDup(db, p.pOrderBy, flags)
+		pNew.op = p.op
+		pNew.pNext = pNext
+		pNew.pPrior = nil
+		pNew.pLimit = sqlite3ExprDup(db, p.pLimit, flags)
+		pNew.iLimit = 0
+		pNew.iOffset = 0
+		pNew.selFlags = p.selFlags &^ SF_UsesEphemeral
+		pNew.addrOpenEphm[0] = -1
+		pNew.addrOpenEphm[1] = -1
+		pNew.nSelectRow = p.nSelectRow
+		pNew.pWith = sqlite3WithDup(db, p.pWith)
+		pNew.pWin = nil
+		pNew.pWinDefn = sqlite3WindowListDup(db, p.pWinDefn)
+		if p.pWin != nil {
+			gatherSelectWindows(pNew)
+		}
+		pNew.selId = p.selId
+		*pp = pNew
+		pp = &pNew.pPrior
+		pNext = pNew
+	}
+	return pRet
+}
+
+/*
+** Create and return a deep copy of the object passed as the second
+** argument. If an OOM condition is encountered, NULL is returned
+** and the db->mallocFailed flag set.
+**
+** The CteUse of each CTE is shared with the copy, as it is by
+** sqlite3SrcListDup().
+ */
+func sqlite3WithDup(db *sqlite3, p *With) *With {
+	var pRet *With
+	if p != nil {
+		pRet = &With{}
+		pRet.nCte = p.nCte
+		pRet.a = make([]Cte, p.nCte)
+		for i := 0; i < p.nCte; i++ {
+			pRet.a[i].pSelect = sqlite3SelectDup(db, p.a[i].pSelect, 0)
+			pRet.a[i].pCols = sqlite3ExprListDup(db, p.a[i].pCols, 0)
+			pRet.a[i].zName = sqlite3DbStrDup(db, p.a[i].zName)
+			pRet.a[i].eM10d = p.a[i].eM10d
+			pRet.a[i].pUse = p.a[i].pUse
+		}
+	}
+	return pRet
+}
+
+/*
+** The gatherSelectWindows() procedure and its helper routine
+** gatherSelectWindowsCallback() are used to scan all the expressions
+** an a newly duplicated SELECT statement and gather all of the Window
+** objects found there, assembling them onto the linked list at Select->pWin.
+ */
+func gatherSelectWindowsCallback(pWalker *Walker, pExpr *Expr) int {
+	if pExpr.op == TK_FUNCTION && ExprHasProperty(pExpr, EP_WinFunc) &&
+		pExpr.y.pWin.eFrmType != TK_FILTER {
+		pSelect := pWalker.u.pSelect
+		pWin := pExpr.y.pWin
+		assert(pWin != nil, "pWin != nil")
+		assert(pWin.ppThis == nil, "pWin.ppThis == nil")
+		sqlite3WindowLink(pSelect, pWin)
+	}
+	return WRC_Continue
+}
+func gatherSelectWindowsSelectCallback(pWalker *Walker, p *Select) int {
+	if p == pWalker.u.pSelect {
+		return WRC_Continue
+	}
+	return WRC_Prune
+}
+func gatherSelectWindows(p *Select) {
+	var w Walker
+	w.xExprCallback = gatherSelectWindowsCallback
+	w.xSelectCallback = gatherSelectWindowsSelectCallback
+	w.xSelectCallback2 = nil
+	w.pParse = nil
+	w.u.pSelect = p
+	sqlite3WalkSelect(&w, p)
+}
+
+/*
+** Return TRUE if the TK_VARIABLE node pVar may stand for the value of
+** the expression pExpr.
+**
+** In C, the value bound to pVar by the statement that is being
+** reprepared is compared against pExpr.  This port never has bound
+** values, so a variable matches any literal.  This is the wildcard mode
+** of sqlite3ExprCompare(), used to match a statement that has
+** parameters against one that has literal values in their place.
+ */
+func exprCompareVariable(pParse *Parse, pVar *Expr, pExpr *Expr) bool {
+	UNUSED_PARAMETER(pParse)
+	assert(pVar.op == TK_VARIABLE, "pVar.op == TK_VARIABLE")
+	_, err := sqlite3ExprLiteralValue(pExpr, false)
+	return err == nil
+}
+
+/*
+** Do a deep comparison of two expression trees.  Return 0 if the two
+** expressions are completely identical.  Return 1 if they differ only
+** by a COLLATE operator at the top level.  Return 2 if there are differences
+** other than the top-level COLLATE operator.
+**
+** If any subelement of pB has Expr.iTable==(-1) then it is allowed
+** to compare equal to an equivalent element in pA with Expr.iTable==iTab.
+**
+** The pA side might be using TK_REGISTER.  If that is the case and pB is
+** not using TK_REGISTER but is otherwise equivalent, then still return 0.
+**
+** Sometimes this routine will return 2 even if the two expressions
+** really are equivalent.  If we cannot prove that the expressions are
+** identical, we return 2 just to be safe.  So if this routine
+** returns 2, then you do not really know for certain if the two
+** expressions are the same.  But if you get a 0 or 1 return, then you
+** can be sure the expressions are the same.  In the places where
+** this routine is used, it does not hurt to get an extra 2 - that
+** just might result in some slightly slower code.  But returning
+** an incorrect 0 or 1 could lead to a malfunction.
+**
+** If pParse is not NULL then TK_VARIABLE terms in pA are allowed to
+** match literals in pB.  See exprCompareVariable().  If pParse is NULL,
+** a variable only matches an identical variable.
+ */
+func sqlite3ExprCompare(pParse *Parse, pA *Expr, pB *Expr, iTab int) int {
+	var combinedFlags uint32
+	if pA == nil || pB == nil {
+		if pB == pA {
+			return 0
+		}
+		return 2
+	}
+	if pParse != nil && pA.op == TK_VARIABLE && exprCompareVariable(pParse, pA, pB) {
+		return 0
+	}
+	combinedFlags = pA.flags | pB.flags
+	if combinedFlags&EP_IntValue != 0 {
+		if (pA.flags&pB.flags&EP_IntValue) != 0 && pA.u.iValue == pB.u.iValue {
+			return 0
+		}
+		return 2
+	}
+	if pA.op != pB.op || pA.op == TK_RAISE {
+		if pA.op == TK_COLLATE && sqlite3ExprCompare(pParse, pA.pLeft, pB, iTab) < 2 {
+			return 1
+		}
+		if pB.op == TK_COLLATE && sqlite3ExprCompare(pParse, pA, pB.pLeft, iTab) < 2 {
+			return 1
+		}
+		return 2
+	}
+	assert(!ExprHasProperty(pA, EP_IntValue), "!ExprHasProperty(pA, EP_IntValue)")
+	assert(!ExprHasProperty(pB, EP_IntValue), "!ExprHasProperty(pB, EP_IntValue)")
+	if pA.u.zToken != nil {
+		if pA.op == TK_FUNCTION || pA.op == TK_AGG_FUNCTION {
+			if sqlite3StrICmp(pA.u.zToken, pB.u.zToken) != 0 {
+				return 2
+			}
+			assert(pA.op == pB.op, "pA.op == pB.op")
+			if ExprHasProperty(pA, EP_WinFunc) != ExprHasProperty(pB, EP_WinFunc) {
+				return 2
+			}
+			if ExprHasProperty(pA, EP_WinFunc) {
+				if sqlite3WindowCompare(pParse, pA.y.pWin, pB.y.pWin, true) != 0 {
+					return 2
+				}
+			}
+		} else if pA.op == TK_NULL {
+			return 0
+		} else if pA.op == TK_COLLATE {
+			if sqlite3_stricmp(pA.u.zToken, pB.u.zToken) != 0 {
+				return 2
+			}
+		} else if pB.u.zToken != nil &&
+			pA.op != TK_COLUMN &&
+			pA.op != TK_AGG_COLUMN &&
+			!bytes.Equal(pA.u.zToken, pB.u.zToken) {
+			return 2
+		}
+	}
+	if (pA.flags & (EP_Distinct | EP_Commuted)) !=
+		(pB.flags & (EP_Distinct | EP_Commuted)) {
+		return 2
+	}
+	if (combinedFlags & EP_TokenOnly) == 0 {
+		if combinedFlags&EP_xIsSelect != 0 {
+			return 2
+		}
+		if (combinedFlags&EP_FixedCol) == 0 &&
+			sqlite3ExprCompare(pParse, pA.pLeft, pB.pLeft, iTab) != 0 {
+			return 2
+		}
+		if sqlite3ExprCompare(pParse, pA.pRight, pB.pRight, iTab) != 0 {
+			return 2
+		}
+		if sqlite3ExprListCompare(pA.x.pList, pB.x.pList, iTab) != 0 {
+			return 2
+		}
+		if pA.op != TK_STRING &&
+			pA.op != TK_TRUEFALSE &&
+			(combinedFlags&EP_Reduced) == 0 {
+			if pA.iColumn != pB.iColumn {
+				return 2
+			}
+			if pA.op2 != pB.op2 && pA.op == TK_TRUTH {
+				return 2
+			}
+			if pA.op != TK_IN && pA.iTable != pB.iTable && pA.iTable != iTab {
+				return 2
+			}
+		}
+	}
+	return 0
+}
+
+/*
+** Compare two ExprList objects.  Return 0 if they are identical, 1
+** if they are certainly different, or 2 if it is not possible to
+** determine if they are identical or not.
+**
+** If any subelement of pB has Expr.iTable==(-1) then it is allowed
+** to compare equal to an equivalent element in pA with Expr.iTable==iTab.
+**
+** This routine might return non-zero for equivalent ExprLists.  The
+** only consequence will be disabled optimizations.  But this routine
+** must never return 0 if the two ExprList objects are different, or
+** a malfunction will result.
+**
+** Two NULL pointers are considered to be the same.  But a NULL pointer
+** always differs from a non-NULL pointer.
+ */
+func sqlite3ExprListCompare(pA *ExprList, pB *ExprList, iTab int) int {
+	if pA == nil && pB == nil {
+		return 0
+	}
+	if pA == nil || pB == nil {
+		return 1
+	}
+	if pA.nExpr != pB.nExpr {
+		return 1
+	}
+	for i := 0; i < pA.nExpr; i++ {
+		pExprA := pA.a[i].pExpr
+		pExprB := pB.a[i].pExpr
+		if pA.a[i].sortFlags != pB.a[i].sortFlags {
+			return 1
+		}
+		if res := sqlite3ExprCompare(nil, pExprA, pExprB, iTab); res != 0 {
+			return res
+		}
+	}
+	return 0
+}
+
+/*
+** Return the number of expressions in the list p, or 0 if p is NULL.
+ */
+func sqlite3ExprListLength(p *ExprList) int {
+	if p == nil {
+		return 0
+	}
+	return p.nExpr
+}
+
+/*
+** Mix the value v into the hash h.  The multiplier is the one used by
+** strHash() in hash.c.
+ */
+func exprHashStep(h uint32, v uint32) uint32 {
+	return (h + v) * 0x9e3779b1
+}
+
+/*
+** Mix the text z into the hash h.  If noCase is true, upper and lower
+** case ASCII characters hash alike.
+ */
+func exprHashText(h uint32, z []byte, noCase bool) uint32 {
+	for _, c := range z {
+		if noCase {
+			c = sqlite3UpperToLower[c]
+		}
+		h = exprHashStep(h, uint32(c))
+	}
+	return exprHashStep(h, uint32(len(z)))
+}
+
+/*
+** Compute a hash of the expression tree p that is consistent with
+** sqlite3ExprCompare():  if sqlite3ExprCompare(0, pA, pB, iTab) returns
+** 0, for any value of iTab, then pA and pB have the same hash.  The
+** converse does not hold.
+**
+** To keep that promise, the hash leaves out everything the comparison
+** is allowed to overlook:  Expr.iTable, the names of TK_COLUMN nodes,
+** the case of function and collation names, and the token of a NULL.
+** Tokens are hashed only for node types that always carry one, since
+** the comparison ignores a token that is missing from either side.
+ */
+func sqlite3ExprHash(p *Expr) uint32 {
+	var h uint32
+	if p == nil {
+		return exprHashStep(h, 0)
+	}
+	if ExprHasProperty(p, EP_IntValue) {
+		h = exprHashStep(h, EP_IntValue)
+		return exprHashStep(h, uint32(p.u.iValue))
+	}
+	h = exprHashStep(h, uint32(p.op))
+	switch p.op {
+	case TK_NULL:
+		return h
+	case TK_FUNCTION, TK_AGG_FUNCTION:
+		h = exprHashText(h, p.u.zToken, true)
+		h = exprHashStep(h, p.flags&EP_WinFunc)
+	case TK_COLLATE:
+		h = exprHashText(h, p.u.zToken, true)
+	case TK_ID, TK_STRING, TK_INTEGER, TK_FLOAT, TK_BLOB, TK_VARIABLE:
+		h = exprHashText(h, p.u.zToken, false)
+	}
+	h = exprHashStep(h, p.flags&(EP_Distinct|EP_Commuted))
+	if ExprHasProperty(p, EP_xIsSelect) {
+		return h
+	}
+	if p.op != TK_COLUMN {
+		h = exprHashStep(h, sqlite3ExprHash(p.pLeft))
+	}
+	h = exprHashStep(h, sqlite3ExprHash(p.pRight))
+	h = exprHashStep(h, sqlite3ExprListHash(p.x.pList))
+	if p.op != TK_STRING && p.op != TK_TRUEFALSE {
+		h = exprHashStep(h, uint32(p.iColumn))
+		if p.op == TK_TRUTH {
+			h = exprHashStep(h, uint32(p.op2))
+		}
+	}
+	return h
+}
+
+/*
+** Compute a hash of the expression list p that is consistent with
+** sqlite3ExprListCompare().
+ */
+func sqlite3ExprListHash(p *ExprList) uint32 {
+	var h uint32
+	if p == nil {
+		return exprHashStep(h, 0)
+	}
+	h = exprHashStep(h, uint32(p.nExpr))
+	for i := 0; i < p.nExpr; i++ {
+		h = exprHashStep(h, uint32(p.a[i].sortFlags))
+		h = exprHashStep(h, sqlite3ExprHash(p.a[i].pExpr))
+	}
+	return h
+}
+
+/*
+** Equal reports whether p and q are structurally identical expressions,
+** as decided by sqlite3ExprCompare().  Expressions that differ only in
+** a top-level COLLATE are not equal, and an expression that contains a
+** subquery is never equal to anything, not even to itself.
+ */
+func (p *Expr) Equal(q *Expr) bool {
+	return sqlite3ExprCompare(nil, p, q, -1) == 0
+}
+
+/*
+** EqualPattern is Equal, except that a parameter in p matches any
+** literal value in q, so "a = ?" matches "a = 5" and "a = 'x'".  This
+** is the wildcard mode of sqlite3ExprCompare(); see exprCompareVariable().
+** As in C, the mode does not reach the terms of a list, such as the
+** arguments of a function or the right-hand side of IN, where a
+** parameter matches only the same parameter.
+ */
+func (p *Expr) EqualPattern(q *Expr) bool {
+	return sqlite3ExprCompare(&Parse{db: openDatabase()}, p, q, -1) == 0
+}
+
+/*
+** Hash returns a hash of p that is consistent with Equal:  expressions
+** that are Equal have the same Hash.  It is meant to be used as the key
+** of a Go map whose values are lists of expressions to be checked with
+** Equal.
+ */
+func (p *Expr) Hash() uint32 {
+	return sqlite3ExprHash(p)
+}
+
+/*
+** Clone returns a deep copy of p.  The copy can be modified without
+** changing p.
+ */
+func (p *Expr) Clone() *Expr {
+	return sqlite3ExprDup(openDatabase(), p, 0)
+}
+
+/*
+** Clone returns a deep copy of p, including the terms of a compound
+** SELECT, its WITH clause and its subqueries.  The copy can be modified
+** without changing p.
+ */
+func (p *Select) Clone() *Select {
+	return sqlite3SelectDup(openDatabase(), p, 0)
+}
+
+/*
+** Clone returns a deep copy of the expression list p, including the
+** names and sort orders of its terms.
+ */
+func (p *ExprList) Clone() *ExprList {
+	return sqlite3ExprListDup(openDatabase(), p, 0)
+}
+
+/*
+** Clone returns a deep copy of the FROM clause p, including its
+** subqueries and join constraints.
+ */
+func (p *SrcList) Clone() *SrcList {
+	return sqlite3SrcListDup(openDatabase(), p, 0)
+}
+
+/*
+** Clone returns a deep copy of the WITH clause p and the SELECT of each
+** of its common table expressions.
+ */
+func (p *With) Clone() *With {
+	return sqlite3WithDup(openDatabase(), p)
+}
+
+/*
+** Return the expressions of pList as a slice, or nil if pList is NULL.
+ */
+func exprListToSlice(pList *ExprList) []*Expr {
+	if pList == nil {
+		return nil
+	}
+	a := make([]*Expr, 0, pList.nExpr)
+	for i := 0; i < pList.nExpr; i++ {
+		a = append(a, pList.a[i].pExpr)
+	}
+	return a
+}
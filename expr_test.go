@@ -0,0 +1,289 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Tests for routines that examine, copy and compare expressions.
+ */
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+/*
+** Parse zExpr as the only result column of a SELECT and return it.
+ */
+func testExpr(t *testing.T, zExpr string) *Expr {
+	t.Helper()
+	aStmt, err := ParseSQL("SELECT "+zExpr, nil)
+	if err != nil {
+		t.Fatalf("%s: %v", zExpr, err)
+	}
+	return aStmt[0].pSelect.pEList.a[0].pExpr
+}
+
+/*
+** Parse zSql, which must be a single SELECT statement, and return it.
+ */
+func testSelect(t *testing.T, zSql string) *Select {
+	t.Helper()
+	aStmt, err := ParseSQL(zSql, nil)
+	if err != nil {
+		t.Fatalf("%s: %v", zSql, err)
+	}
+	if len(aStmt) != 1 || aStmt[0].pSelect == nil {
+		t.Fatalf("%s: not a single SELECT", zSql)
+	}
+	return aStmt[0].pSelect
+}
+
+/*
+** Value must return the value SQLite would give each literal, and an
+** error for expressions that are not literals.
+ */
+func TestExprValue(t *testing.T) {
+	aTest := []struct {
+		zExpr string
+		pWant interface{}
+		zErr  string
+	}{
+		{"NULL", nil, ""},
+		{"'it''s'", "it's", ""},
+		{"x'0aFF'", []byte{0x0a, 0xff}, ""},
+		{"X''", []byte{}, ""},
+		{"123", int64(123), ""},
+		{"0x7b", int64(123), ""},
+		{"-5", int64(-5), ""},
+		{"- -5", int64(5), ""},
+		{"+1.5", 1.5, ""},
+		{"1e3", 1000.0, ""},
+		{"9223372036854775808", 9223372036854775808.0, ""},
+		{"-9223372036854775808", int64(-9223372036854775808), ""},
+		{"0xffffffffffffffff", int64(-1), ""},
+		{"0x1ffffffffffffffff", nil, "hex literal too big: 0x1ffffffffffffffff"},
+		{"-'a'", nil, "not a literal value"},
+		{"-NULL", nil, "not a literal value"},
+		{"1+2", nil, "not a literal value"},
+		{"a", nil, "not a literal value"},
+	}
+	for _, tc := range aTest {
+		pVal, err := testExpr(t, tc.zExpr).Value()
+		if tc.zErr != "" {
+			if pErr, ok := err.(*Error); !ok || pErr.Msg != tc.zErr {
+				t.Errorf("%s: got %v, want %q", tc.zExpr, err, tc.zErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tc.zExpr, err)
+		} else if !reflect.DeepEqual(pVal, tc.pWant) {
+			t.Errorf("%s: got %#v, want %#v", tc.zExpr, pVal, tc.pWant)
+		}
+	}
+}
+
+/*
+** Changing a clone of an expression must leave the original alone,
+** including the token text and the window of a window function.
+ */
+func TestExprClone(t *testing.T) {
+	p := testExpr(t, "a + f(b, 'x') OVER (PARTITION BY c)")
+	pClone := p.Clone()
+	if pClone == p || pClone.pRight == p.pRight || pClone.pRight.y.pWin == p.pRight.y.pWin {
+		t.Fatal("Clone shares nodes with the original")
+	}
+	pClone.op = TK_MINUS
+	pClone.pLeft.u.zToken[0] = 'z'
+	pClone.pRight.x.pList.a[1].pExpr.u.zToken[0] = 'y'
+	pClone.pRight.y.pWin.pPartition.a[0].pExpr.u.zToken[0] = 'w'
+	if p.op != TK_PLUS || string(p.pLeft.u.zToken) != "a" ||
+		string(p.pRight.x.pList.a[1].pExpr.u.zToken) != "x" ||
+		string(p.pRight.y.pWin.pPartition.a[0].pExpr.u.zToken) != "c" {
+		t.Error("changing the clone changed the original")
+	}
+}
+
+/*
+** Changing a clone of a compound SELECT must leave every term of the
+** original alone.
+ */
+func TestSelectClone(t *testing.T) {
+	p := testSelect(t, "SELECT a FROM t WHERE b > 1 UNION SELECT c FROM (SELECT c FROM u)")
+	pClone := p.Clone()
+	if pClone.pPrior == nil || pClone.pPrior == p.pPrior {
+		t.Fatal("Clone shares the compound terms with the original")
+	}
+	pClone.pEList.a[0].pExpr.u.zToken[0] = 'z'
+	pClone.pSrc.a[0].pSelect.pSrc.a[0].zName[0] = 'v'
+	pClone.pPrior.pWhere.pRight.u.iValue = 2
+	pClone.pPrior.pSrc.a[0].zName[0] = 's'
+	if string(p.pEList.a[0].pExpr.u.zToken) != "c" ||
+		string(p.pSrc.a[0].pSelect.pSrc.a[0].zName) != "u" ||
+		p.pPrior.pWhere.pRight.u.iValue != 1 ||
+		string(p.pPrior.pSrc.a[0].zName) != "t" {
+		t.Error("changing the clone changed the original")
+	}
+}
+
+/*
+** Changing a clone of an expression list, FROM clause, WITH clause,
+** window or ON CONFLICT clause must leave the original alone.
+ */
+func TestClone(t *testing.T) {
+	p := testSelect(t, "WITH c(x) AS (SELECT a FROM t) "+
+		"SELECT x, sum(x) OVER w FROM c, u AS v ON v.y = c.x WINDOW w AS (ORDER BY x)")
+
+	pEList := p.pEList.Clone()
+	pEList.a[0].pExpr.u.zToken[0] = 'z'
+	pEList.a[1].zEName[0] = 'n'
+	if string(p.pEList.a[0].pExpr.u.zToken) != "x" || string(p.pEList.a[1].zEName) != "sum(x) OVER w" {
+		t.Error("changing the ExprList clone changed the original")
+	}
+
+	pSrc := p.pSrc.Clone()
+	pSrc.a[1].zAlias[0] = 'w'
+	pSrc.a[1].u3.pOn.pLeft.pLeft.u.zToken[0] = 'w'
+	if string(p.pSrc.a[1].zAlias) != "v" || string(p.pSrc.a[1].u3.pOn.pLeft.pLeft.u.zToken) != "v" {
+		t.Error("changing the SrcList clone changed the original")
+	}
+
+	pWith := p.pWith.Clone()
+	pWith.a[0].zName[0] = 'd'
+	pWith.a[0].pCols.a[0].zEName[0] = 'y'
+	pWith.a[0].pSelect.pSrc.a[0].zName[0] = 's'
+	if string(p.pWith.a[0].zName) != "c" || string(p.pWith.a[0].pCols.a[0].zEName) != "x" ||
+		string(p.pWith.a[0].pSelect.pSrc.a[0].zName) != "t" {
+		t.Error("changing the With clone changed the original")
+	}
+
+	pWin := p.pWinDefn.Clone()
+	pWin.pOrderBy.a[0].pExpr.u.zToken[0] = 'y'
+	if pWin.pOwner != nil || string(p.pWinDefn.pOrderBy.a[0].pExpr.u.zToken) != "x" {
+		t.Error("changing the Window clone changed the original")
+	}
+
+	aStmt, err := ParseSQL("INSERT INTO t(a) VALUES(1) ON CONFLICT(a) DO UPDATE SET b = 2 WHERE c "+
+		"ON CONFLICT DO NOTHING", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pOrig := aStmt[0].pInsert.pUpsert
+	pUpsert := pOrig.Clone()
+	pUpsert.pUpsertSet.a[0].pExpr.u.iValue = 3
+	pUpsert.pUpsertWhere.u.zToken[0] = 'd'
+	pUpsert.pNextUpsert.isDoUpdate = 1
+	if pOrig.pUpsertSet.a[0].pExpr.u.iValue != 2 || string(pOrig.pUpsertWhere.u.zToken) != "c" ||
+		pOrig.pNextUpsert.isDoUpdate != 0 {
+		t.Error("changing the Upsert clone changed the original")
+	}
+}
+
+/*
+** A FROM clause term of a cloned SELECT must share its CteUse with the
+** CTE of the cloned WITH clause, which is that of the original.
+ */
+func TestCloneCteUse(t *testing.T) {
+	p := testSelect(t, "WITH c AS (SELECT 1) SELECT * FROM c")
+	pClone := p.Clone()
+	pUse := pClone.pWith.a[0].pUse
+	if pUse == nil || pClone.pSrc.a[0].u2.pCteUse != pUse || p.pWith.a[0].pUse != pUse {
+		t.Fatal("the clone does not share the CteUse")
+	}
+	if aCte := pClone.With(); aCte[0].Uses != 1 || p.With()[0].Uses != 1 {
+		t.Errorf("clone has %d uses, want 1", aCte[0].Uses)
+	}
+}
+
+/*
+** A parameter of the pattern must match any literal, but only outside
+** of a list.
+ */
+func TestExprEqualPattern(t *testing.T) {
+	aTest := []struct {
+		zPattern string
+		zExpr    string
+		bMatch   bool
+	}{
+		{"a = ?", "a = 5", true},
+		{"a = ?", "a = 'x'", true},
+		{"a = ?1 AND b > :b", "a = -2.5 AND b > x'00'", true},
+		{"a = ?", "a = NULL", true},
+		{"a = ?", "a = ?", true},
+		{"a = ?", "a = b", false},
+		{"a = ?", "a = 1 + 1", false},
+		{"a = 5", "a = ?", false},
+		{"b = ?", "a = 5", false},
+		{"f(?)", "f(5)", false},
+		{"a IN (?, ?)", "a IN (1, 2)", false},
+	}
+	for _, tc := range aTest {
+		pPattern := testExpr(t, tc.zPattern)
+		pExpr := testExpr(t, tc.zExpr)
+		if bMatch := pPattern.EqualPattern(pExpr); bMatch != tc.bMatch {
+			t.Errorf("%s matches %s: got %v, want %v", tc.zPattern, tc.zExpr, bMatch, tc.bMatch)
+		}
+		if pPattern.Equal(pExpr) && !pPattern.EqualPattern(pExpr) {
+			t.Errorf("%s is Equal to %s but does not match it", tc.zPattern, tc.zExpr)
+		}
+	}
+}
+
+/*
+** Expressions that are Equal must have the same Hash, and differences
+** that sqlite3ExprCompare() sees must make them unequal.
+ */
+func TestExprCompareHash(t *testing.T) {
+	aTest := []struct {
+		zLeft  string
+		zRight string
+		bEqual bool
+	}{
+		{"a+1", "a+1", true},
+		{"a+1", "A+1", false},
+		{"t.a = ?1", "t.a = ?1", true},
+		{"upper(a)", "UPPER(a)", true},
+		{"count(DISTINCT a)", "count(DISTINCT a)", true},
+		{"a COLLATE nocase", "a COLLATE NOCASE", true},
+		{"CAST(a AS text)", "CAST(a AS text)", true},
+		{"CASE WHEN a THEN 1 ELSE 2 END", "CASE WHEN a THEN 1 ELSE 2 END", true},
+		{"a IN (1,2,3)", "a IN (1,2,3)", true},
+		{"f(a) OVER (PARTITION BY b)", "f(a) OVER (PARTITION BY b)", true},
+		{"a+1", "a+2", false},
+		{"a+1", "1+a", false},
+		{"a+1", "a-1", false},
+		{"'a'", "'A'", false},
+		{"count(a)", "count(DISTINCT a)", false},
+		{"a COLLATE nocase", "a", false},
+		{"a COLLATE nocase", "a COLLATE rtrim", false},
+		{"CAST(a AS text)", "CAST(a AS blob)", false},
+		{"a IN (1,2,3)", "a IN (1,2)", false},
+		{"f(a) OVER (PARTITION BY b)", "f(a) OVER (PARTITION BY c)", false},
+		{"f(a) OVER (ORDER BY b)", "f(a)", false},
+		{"EXISTS (SELECT 1)", "EXISTS (SELECT 1)", false},
+	}
+	for _, tc := range aTest {
+		pLeft := testExpr(t, tc.zLeft)
+		pRight := testExpr(t, tc.zRight)
+		if bEqual := pLeft.Equal(pRight); bEqual != tc.bEqual {
+			t.Errorf("%q.Equal(%q) = %v, want %v", tc.zLeft, tc.zRight, bEqual, tc.bEqual)
+		}
+		if pRight.Equal(pLeft) != pLeft.Equal(pRight) {
+			t.Errorf("%q.Equal(%q) is not symmetric", tc.zLeft, tc.zRight)
+		}
+		if tc.bEqual && pLeft.Hash() != pRight.Hash() {
+			t.Errorf("%q and %q are equal but hash to %#x and %#x",
+				tc.zLeft, tc.zRight, pLeft.Hash(), pRight.Hash())
+		}
+		if pLeft.Hash() != pLeft.Clone().Hash() {
+			t.Errorf("%q hashes differently from its clone", tc.zLeft)
+		}
+	}
+}
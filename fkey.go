@@ -0,0 +1,192 @@
+/*
+** 2009 September 15
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains code used by the compiler to add foreign key
+** support to compiled SQL statements.
+**
+** The Go port generates no code, so only the lookup of the parent key
+** of a foreign key remains.  It is used to check the foreign keys of a
+** Catalog.
+ */
+package internal
+
+/*
+** A foreign key constraint requires that the key columns in the parent
+** table are collectively subject to a UNIQUE or PRIMARY KEY constraint.
+** Given that pParent is the parent table for foreign key constraint pFKey,
+** search the schema for a unique index on the parent key columns.
+**
+** If successful, zero is returned. If the parent key is an INTEGER PRIMARY
+** KEY column, then output variable *ppIdx is set to NULL. Otherwise, *ppIdx
+** is set to point to the unique index.
+**
+** If the parent key consists of a single column (the foreign key constraint
+** is not a composite foreign key), output variable *paiCol is set to NULL.
+** Otherwise, it is set to point to an allocated array of size N, where
+** N is the number of columns in the parent key. The first element of the
+** array is the index of the child table column that is mapped by the FK
+** constraint to the parent table column stored in the left-most column
+** of index *ppIdx. The second element of the array is the index of the
+** child table column that corresponds to the second left-most column of
+** *ppIdx, and so on.
+**
+** If the required index cannot be found, either because:
+**
+**   1) The named parent key columns do not exist, or
+**
+**   2) The named parent key columns do exist, but are not subject to a
+**      UNIQUE or PRIMARY KEY constraint, or
+**
+**   3) No parent key columns were provided explicitly as part of the
+**      foreign key definition, and the parent table does not have a
+**      PRIMARY KEY, or
+**
+**   4) No parent key columns were provided explicitly as part of the
+**      foreign key definition, and the PRIMARY KEY of the parent table
+**      consists of a different number of columns to the child key in
+**      the child table.
+**
+** then non-zero is returned, and a "foreign key mismatch" error loaded
+** into pParse. If an OOM error occurs, non-zero is returned and the
+** pParse->db->mallocFailed flag is set.
+**
+** The port has no schema, so the indexes on pParent made by CREATE INDEX
+** statements are passed in aIdx[].
+ */
+func sqlite3FkLocateIndex(
+	pParse *Parse, /* Parse context to store any error in */
+	pParent *Table, /* Parent table of FK constraint pFKey */
+	aIdx []*Index, /* CREATE INDEX indexes on pParent */
+	pFKey *FKey, /* Foreign key to find index for */
+	ppIdx **Index, /* OUT: Unique index on parent table */
+	paiCol *[]int, /* OUT: Map of index columns in pFKey */
+) int {
+	var pIdx *Index            /* Value to return via *ppIdx */
+	var aiCol []int            /* Value to return via *paiCol */
+	nCol := pFKey.nCol         /* Number of columns in parent key */
+	zKey := pFKey.aCol[0].zCol /* Name of left-most parent key column */
+	var apIdx []*Index         /* All indexes on pParent */
+
+	/* The caller is responsible for zeroing output parameters. */
+	assert(ppIdx != nil && *ppIdx == nil, "ppIdx != nil && *ppIdx == nil")
+	assert(paiCol == nil || *paiCol == nil, "paiCol == nil || *paiCol == nil")
+	assert(pParse != nil, "pParse != nil")
+
+	/* If this is a non-composite (single column) foreign key, check if it
+	 ** maps to the INTEGER PRIMARY KEY of table pParent. If so, leave *ppIdx
+	 ** and *paiCol set to zero and return early.
+	 **
+	 ** Otherwise, for a composite foreign key (more than one column), allocate
+	 ** space for the aiCol array (returned via output parameter *paiCol).
+	 ** Non-composite foreign keys do not require the aiCol array.
+	 */
+	if nCol == 1 {
+		/* The FK maps to the IPK if any of the following are true:
+		 **
+		 **   1) There is an INTEGER PRIMARY KEY column and the FK is implicitly
+		 **      mapped to the primary key of table pParent, or
+		 **   2) The FK is explicitly mapped to a column declared as INTEGER
+		 **      PRIMARY KEY.
+		 */
+		if pParent.iPKey >= 0 {
+			if zKey == nil {
+				return 0
+			}
+			if sqlite3StrICmp(pParent.aCol[pParent.iPKey].zCnName, zKey) == 0 {
+				return 0
+			}
+		}
+	} else if paiCol != nil {
+		assert(nCol > 1, "nCol > 1")
+		aiCol = make([]int, nCol)
+		*paiCol = aiCol
+	}
+
+	for p := pParent.pIndex; p != nil; p = p.pNext {
+		apIdx = append(apIdx, p)
+	}
+	apIdx = append(apIdx, aIdx...)
+	for _, p := range apIdx {
+		if int(p.nKeyCol) == nCol && IsUniqueIndex(p) && p.pPartIdxWhere == nil &&
+			p.aiColumn != nil {
+			/* p is a UNIQUE index (or a PRIMARY KEY) and has the right number
+			 ** of columns. If each indexed column corresponds to a foreign key
+			 ** column of pFKey, then this index is a winner.  */
+
+			if zKey == nil {
+				/* If zKey is NULL, then this foreign key is implicitly mapped to
+				 ** the PRIMARY KEY of table pParent. The PRIMARY KEY index may be
+				 ** identified by the test.  */
+				if IsPrimaryKeyIndex(p) {
+					if aiCol != nil {
+						for i := 0; i < nCol; i++ {
+							aiCol[i] = pFKey.aCol[i].iFrom
+						}
+					}
+					pIdx = p
+					break
+				}
+			} else {
+				/* If zKey is non-NULL, then this foreign key was declared to
+				 ** map to an explicit list of columns in table pParent. Check if this
+				 ** index matches those columns. Also, check that the index uses
+				 ** the default collation sequences for each column. */
+				var i, j int
+				for i = 0; i < nCol; i++ {
+					iCol := p.aiColumn[i] /* Index of column in parent tbl */
+
+					if iCol < 0 {
+						break /* No foreign keys against expression indexes */
+					}
+
+					/* If the index uses a collation sequence that is different from
+					 ** the default collation sequence for the column, this index is
+					 ** unusable. Bail out early in this case.  */
+					if sqlite3StrICmp(p.azColl[i], sqlite3StrBINARY) != 0 {
+						break
+					}
+
+					zIdxCol := pParent.aCol[iCol].zCnName /* Name of indexed column */
+					for j = 0; j < nCol; j++ {
+						if sqlite3StrICmp(pFKey.aCol[j].zCol, zIdxCol) == 0 {
+							if aiCol != nil {
+								aiCol[i] = pFKey.aCol[j].iFrom
+							}
+							break
+						}
+					}
+					if j == nCol {
+						break
+					}
+				}
+				if i == nCol {
+					pIdx = p /* p is usable */
+					break
+				}
+			}
+		}
+	}
+
+	if pIdx == nil {
+		if pParse.disableTriggers == 0 {
+			sqlite3ErrorMsg(pParse,
+				"foreign key mismatch - \"%w\" referencing \"%w\"",
+				pFKey.pFrom.zName, pFKey.zTo)
+		}
+		return 1
+	}
+
+	*ppIdx = pIdx
+	if paiCol != nil {
+		*paiCol = aiCol
+	}
+	return 0
+}
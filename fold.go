@@ -0,0 +1,1156 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains a pass that folds constant subexpressions of a
+** parse tree into literals.
+**
+** SQLite itself does not fold constants.  It generates code that
+** evaluates them once, when the statement starts.  The rules used here
+** are those of that code: the arithmetic and comparison opcodes of
+** vdbe.c and the value conversions of vdbemem.c.  Where SQLite would
+** convert a floating point value to text, the result depends on
+** formatting details that are not reproduced, and the expression is
+** left alone.
+**
+** Values are represented as by Expr.Value: nil for NULL, int64,
+** float64, string for TEXT and []byte for BLOB.
+**
+** In some places only the truth of an expression matters: the WHERE
+** and HAVING clauses, ON clauses and the WHEN terms of a CASE without
+** an operand.  There NULL and false are the same, and "x AND true"
+** reduces to "x" whatever the type of x.  The bBool argument of the
+** routines below is true for expressions in such a context.
+ */
+package internal
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+)
+
+/*
+** Return true if r1 is an integer value that can be held exactly by
+** the double r1.  This is sqlite3RealSameAsInt() from vdbemem.c.
+ */
+func foldRealSameAsInt(r1 float64, i int64) bool {
+	r2 := float64(i)
+	return r1 == 0.0 ||
+		(math.Float64bits(r1) == math.Float64bits(r2) &&
+			i >= -2251799813685248 && i < 2251799813685248)
+}
+
+/*
+** Convert a 64-bit IEEE double into a 64-bit signed integer.
+** If the double is out of range of a 64-bit signed integer then
+** return the closest available 64-bit signed integer.
+ */
+func foldDoubleToInt64(r float64) int64 {
+	if r <= float64(SMALLEST_INT64) {
+		return SMALLEST_INT64
+	} else if r >= float64(LARGEST_INT64) {
+		return LARGEST_INT64
+	} else if math.IsNaN(r) {
+		return 0
+	}
+	return int64(r)
+}
+
+/*
+** Return the integer value of v, as sqlite3VdbeIntValue() does.  Text
+** and blobs yield the value of their longest integer prefix.
+ */
+func foldIntValue(v interface{}) int64 {
+	var i int64
+	switch x := v.(type) {
+	case int64:
+		return x
+	case float64:
+		return foldDoubleToInt64(x)
+	case string:
+		sqlite3Atoi64([]byte(x), &i, len(x))
+	case []byte:
+		sqlite3Atoi64(x, &i, len(x))
+	}
+	return i
+}
+
+/*
+** Return the floating point value of v, as sqlite3VdbeRealValue()
+** does.
+ */
+func foldRealValue(v interface{}) float64 {
+	var r float64
+	switch x := v.(type) {
+	case int64:
+		return float64(x)
+	case float64:
+		return x
+	case string:
+		sqlite3AtoF([]byte(x), &r)
+	case []byte:
+		sqlite3AtoF(x, &r)
+	}
+	return r
+}
+
+/*
+** Return the text of v.  Return false if v is a floating point value,
+** whose text SQLite computes with its own formatting routine.
+ */
+func foldTextValue(v interface{}) (string, bool) {
+	switch x := v.(type) {
+	case int64:
+		return strconv.FormatInt(x, 10), true
+	case string:
+		return x, true
+	case []byte:
+		return string(x), true
+	}
+	return "", false
+}
+
+/*
+** Return the numeric value that an arithmetic operator uses for v.  This
+** is computeNumericType() from vdbe.c.  Text that is not a number
+** becomes the value of its numeric prefix, or 0.
+ */
+func foldNumericValue(v interface{}) interface{} {
+	var z []byte
+	var r float64
+	var ix int64
+	switch x := v.(type) {
+	case string:
+		z = []byte(x)
+	case []byte:
+		z = x
+	default:
+		return v
+	}
+	rc := sqlite3AtoF(z, &r)
+	if rc <= 0 {
+		if rc == 0 && sqlite3Atoi64(z, &ix, len(z)) <= 1 {
+			return ix
+		}
+		return r
+	} else if rc == 1 && sqlite3Atoi64(z, &ix, len(z)) == 0 {
+		return ix
+	}
+	return r
+}
+
+/*
+** Apply numeric affinity to v, as applyNumericAffinity() in vdbe.c
+** does.  Only text that is a well-formed number is converted.
+ */
+func foldApplyNumericAffinity(v interface{}) interface{} {
+	var r float64
+	var ix int64
+	z, ok := v.(string)
+	if !ok {
+		return v
+	}
+	rc := sqlite3AtoF([]byte(z), &r)
+	if rc <= 0 {
+		return v
+	}
+	if rc == 1 && sqlite3Atoi64([]byte(z), &ix, len(z)) == 0 {
+		return ix
+	}
+	return r
+}
+
+/*
+** Return the value of CAST(v AS type), where aff is the affinity of
+** the type.  This is sqlite3VdbeMemCast().
+ */
+func foldCast(v interface{}, aff rune) (interface{}, bool) {
+	if v == nil {
+		return nil, true
+	}
+	switch aff {
+	case SQLITE_AFF_BLOB:
+		if x, ok := v.([]byte); ok {
+			return x, true
+		}
+		z, ok := foldTextValue(v)
+		return []byte(z), ok
+	case SQLITE_AFF_NUMERIC:
+		var z []byte
+		var r float64
+		var ix int64
+		switch x := v.(type) {
+		case string:
+			z = []byte(x)
+		case []byte:
+			z = x
+		default:
+			return v, true
+		}
+		/* sqlite3VdbeMemNumerify() */
+		rc := sqlite3AtoF(z, &r)
+		if (rc == 0 || rc == 1) && sqlite3Atoi64(z, &ix, len(z)) <= 1 {
+			return ix, true
+		}
+		if ix = foldDoubleToInt64(r); foldRealSameAsInt(r, ix) {
+			return ix, true
+		}
+		return r, true
+	case SQLITE_AFF_INTEGER:
+		return foldIntValue(v), true
+	case SQLITE_AFF_REAL:
+		return foldRealValue(v), true
+	default:
+		z, ok := foldTextValue(v)
+		return z, ok
+	}
+}
+
+/*
+** Return the truth of v, as sqlite3VdbeBooleanValue() does.  The second
+** result is true if v is NULL.
+ */
+func foldTruth(v interface{}) (bool, bool) {
+	switch x := v.(type) {
+	case nil:
+		return false, true
+	case int64:
+		return x != 0, false
+	}
+	return foldRealValue(v) != 0.0, false
+}
+
+/*
+** Return a value that is 1 if b is true and 0 if it is false.
+ */
+func foldBool(b bool) interface{} {
+	if b {
+		return int64(1)
+	}
+	return int64(0)
+}
+
+/*
+** Compare an integer and a floating point value, as
+** sqlite3IntFloatCompare() does.
+ */
+func foldIntFloatCompare(i int64, r float64) int {
+	if math.IsNaN(r) {
+		return 1
+	}
+	if r < -9223372036854775808.0 {
+		return +1
+	}
+	if r >= 9223372036854775808.0 {
+		return -1
+	}
+	y := int64(r)
+	if i < y {
+		return -1
+	}
+	if i > y {
+		return +1
+	}
+	s := float64(i)
+	if s < r {
+		return -1
+	}
+	if s > r {
+		return +1
+	}
+	return 0
+}
+
+/*
+** Compare text a and b using the built-in collating sequence zColl.
+** Return false if zColl is not BINARY, NOCASE or RTRIM.
+ */
+func foldCollCompare(zColl []byte, a, b []byte) (int, bool) {
+	switch {
+	case zColl == nil || sqlite3StrICmp(zColl, []byte("BINARY")) == 0:
+		return bytes.Compare(a, b), true
+	case sqlite3StrICmp(zColl, []byte("NOCASE")) == 0:
+		n := len(a)
+		if len(b) < n {
+			n = len(b)
+		}
+		for i := 0; i < n; i++ {
+			c := int(sqlite3UpperToLower[a[i]]) - int(sqlite3UpperToLower[b[i]])
+			if c != 0 {
+				return c, true
+			}
+		}
+		return len(a) - len(b), true
+	case sqlite3StrICmp(zColl, []byte("RTRIM")) == 0:
+		return bytes.Compare(bytes.TrimRight(a, " "), bytes.TrimRight(b, " ")), true
+	}
+	return 0, false
+}
+
+/*
+** Compare two values that are not NULL, as sqlite3MemCompare() does.
+** Numbers sort before text, which sorts before blobs.  Return false if
+** the collating sequence is unknown.
+ */
+func foldCompareValues(a, b interface{}, zColl []byte) (int, bool) {
+	switch x := a.(type) {
+	case int64:
+		switch y := b.(type) {
+		case int64:
+			if x < y {
+				return -1, true
+			} else if x > y {
+				return +1, true
+			}
+			return 0, true
+		case float64:
+			return foldIntFloatCompare(x, y), true
+		}
+		return -1, true
+	case float64:
+		switch y := b.(type) {
+		case int64:
+			return -foldIntFloatCompare(y, x), true
+		case float64:
+			if x < y {
+				return -1, true
+			} else if x > y {
+				return +1, true
+			}
+			return 0, true
+		}
+		return -1, true
+	case string:
+		switch y := b.(type) {
+		case int64, float64:
+			return +1, true
+		case string:
+			return foldCollCompare(zColl, []byte(x), []byte(y))
+		}
+		return -1, true
+	case []byte:
+		if y, ok := b.([]byte); ok {
+			return bytes.Compare(x, y), true
+		}
+		return +1, true
+	}
+	return 0, false
+}
+
+/*
+** Return the name of the collating sequence of pExpr, or nil for the
+** default.  This follows sqlite3ExprCollSeq().
+ */
+func foldCollName(pExpr *Expr) []byte {
+	p := pExpr
+	for p != nil {
+		op := p.op
+		if op == TK_REGISTER {
+			op = p.op2
+		}
+		if op == TK_CAST || op == TK_UPLUS {
+			p = p.pLeft
+			continue
+		}
+		if op == TK_VECTOR {
+			assert(ExprUseXList(p), "ExprUseXList(p)")
+			p = p.x.pList.a[0].pExpr
+			continue
+		}
+		if op == TK_COLLATE {
+			return p.u.zToken
+		}
+		if (p.flags & EP_Collate) == 0 {
+			break
+		}
+		if p.pLeft != nil && (p.pLeft.flags&EP_Collate) != 0 {
+			p = p.pLeft
+		} else {
+			pNext := p.pRight
+			if ExprUseXList(p) && p.x.pList != nil {
+				for i := 0; i < p.x.pList.nExpr; i++ {
+					if ExprHasProperty(p.x.pList.a[i].pExpr, EP_Collate) {
+						pNext = p.x.pList.a[i].pExpr
+						break
+					}
+				}
+			}
+			p = pNext
+		}
+	}
+	return nil
+}
+
+/*
+** Return the collating sequence for a comparison of pLeft and pRight.
+** This follows sqlite3BinaryCompareCollSeq().
+ */
+func foldBinaryCollName(pLeft, pRight *Expr) []byte {
+	if (pLeft.flags & EP_Collate) != 0 {
+		return foldCollName(pLeft)
+	} else if pRight != nil && (pRight.flags&EP_Collate) != 0 {
+		return foldCollName(pRight)
+	}
+	if zColl := foldCollName(pLeft); zColl != nil {
+		return zColl
+	}
+	return foldCollName(pRight)
+}
+
+/*
+** Compare a and b, the values of pLeft and pRight, with operator op.
+** The affinity and collating sequence of the comparison are those that
+** codeCompare() would use.
+ */
+func foldComparison(op uint8, pLeft, pRight *Expr, a, b interface{}) (interface{}, bool) {
+	if a == nil || b == nil {
+		switch op {
+		case TK_IS:
+			return foldBool(a == nil && b == nil), true
+		case TK_ISNOT:
+			return foldBool(a != nil || b != nil), true
+		}
+		return nil, true
+	}
+	aff := sqlite3CompareAffinity(pRight, sqlite3ExprAffinity(pLeft))
+	if aff >= SQLITE_AFF_NUMERIC {
+		a = foldApplyNumericAffinity(a)
+		b = foldApplyNumericAffinity(b)
+	} else if aff == SQLITE_AFF_TEXT {
+		_, aText := a.(string)
+		_, bText := b.(string)
+		if aText || bText {
+			var ok bool
+			if _, isBlob := a.([]byte); !isBlob {
+				if a, ok = foldTextValue(a); !ok {
+					return nil, false
+				}
+			}
+			if _, isBlob := b.([]byte); !isBlob {
+				if b, ok = foldTextValue(b); !ok {
+					return nil, false
+				}
+			}
+		}
+	}
+	c, ok := foldCompareValues(a, b, foldBinaryCollName(pLeft, pRight))
+	if !ok {
+		return nil, false
+	}
+	switch op {
+	case TK_EQ, TK_IS:
+		return foldBool(c == 0), true
+	case TK_NE, TK_ISNOT:
+		return foldBool(c != 0), true
+	case TK_LT:
+		return foldBool(c < 0), true
+	case TK_LE:
+		return foldBool(c <= 0), true
+	case TK_GT:
+		return foldBool(c > 0), true
+	default:
+		assert(op == TK_GE, "op == TK_GE")
+		return foldBool(c >= 0), true
+	}
+}
+
+/*
+** Apply the arithmetic operator op to a and b.  Integer arithmetic that
+** overflows is done in floating point instead, and division by zero
+** gives NULL, as in the OP_Add group of opcodes.
+ */
+func foldArithmetic(op uint8, a, b interface{}) interface{} {
+	if a == nil || b == nil {
+		return nil
+	}
+	a = foldNumericValue(a)
+	b = foldNumericValue(b)
+	iA, aInt := a.(int64)
+	iB, bInt := b.(int64)
+	if aInt && bInt {
+		switch op {
+		case TK_PLUS:
+			if r := iA + iB; (iA >= 0) != (iB >= 0) || (r >= 0) == (iA >= 0) {
+				return r
+			}
+		case TK_MINUS:
+			if iB == SMALLEST_INT64 {
+				if iA < 0 {
+					return iA - iB
+				}
+			} else if r := iA - iB; (iA >= 0) == (iB >= 0) || (r >= 0) == (iA >= 0) {
+				return r
+			}
+		case TK_STAR:
+			if iA == 0 || iB == 0 {
+				return int64(0)
+			}
+			if r := iA * iB; r/iB == iA && !(iA == -1 && iB == SMALLEST_INT64) &&
+				!(iB == -1 && iA == SMALLEST_INT64) {
+				return r
+			}
+		case TK_SLASH:
+			if iB == 0 {
+				return nil
+			}
+			if !(iB == -1 && iA == SMALLEST_INT64) {
+				return iA / iB
+			}
+		default:
+			assert(op == TK_REM, "op == TK_REM")
+			if iB == 0 {
+				return nil
+			}
+			if iB == -1 {
+				iB = 1
+			}
+			return iA % iB
+		}
+	}
+	rA := foldRealValue(a)
+	rB := foldRealValue(b)
+	var r float64
+	switch op {
+	case TK_PLUS:
+		r = rA + rB
+	case TK_MINUS:
+		r = rA - rB
+	case TK_STAR:
+		r = rA * rB
+	case TK_SLASH:
+		if rB == 0.0 {
+			return nil
+		}
+		r = rA / rB
+	default:
+		iA = foldDoubleToInt64(rA)
+		iB = foldDoubleToInt64(rB)
+		if iB == 0 {
+			return nil
+		}
+		if iB == -1 {
+			iB = 1
+		}
+		r = float64(iA % iB)
+	}
+	if math.IsNaN(r) {
+		return nil
+	}
+	return r
+}
+
+/*
+** Apply the bitwise operator op to a and b, as the OP_BitAnd group of
+** opcodes does.
+ */
+func foldBitwise(op uint8, a, b interface{}) interface{} {
+	if a == nil || b == nil {
+		return nil
+	}
+	iA := foldIntValue(a)
+	iB := foldIntValue(b)
+	switch op {
+	case TK_BITAND:
+		return iA & iB
+	case TK_BITOR:
+		return iA | iB
+	}
+	if iB == 0 {
+		return iA
+	}
+	/* If shifting by a negative amount, shift in the other direction */
+	if iB < 0 {
+		if op == TK_LSHIFT {
+			op = TK_RSHIFT
+		} else {
+			op = TK_LSHIFT
+		}
+		if iB > -64 {
+			iB = -iB
+		} else {
+			iB = 64
+		}
+	}
+	if iB >= 64 {
+		if iA >= 0 || op == TK_LSHIFT {
+			return int64(0)
+		}
+		return int64(-1)
+	}
+	if op == TK_LSHIFT {
+		return int64(uint64(iA) << uint(iB))
+	}
+	return iA >> uint(iB) /* Sign-extend on a right shift of a negative number */
+}
+
+/*
+** Return the value of "pLeft IS [NOT] TRUE" or "pLeft IS [NOT] FALSE",
+** where v is the value of pLeft.  The resolver turns these into TK_TRUTH
+** operators, which the OP_IsTrue opcode evaluates.
+ */
+func foldTruthTest(op uint8, pRight *Expr, v interface{}) interface{} {
+	isTrue := sqlite3ExprTruthValue(pRight)
+	bNormal := op == TK_IS
+	b, isNull := foldTruth(v)
+	if isNull {
+		b = !isTrue
+	}
+	return foldBool(b != (isTrue != bNormal))
+}
+
+/*
+** Return the value of the constant expression pExpr.  The second result
+** is false if pExpr is not constant or if its value cannot be computed.
+ */
+func foldValue(pExpr *Expr) (interface{}, bool) {
+	if pExpr == nil {
+		return nil, false
+	}
+	switch pExpr.op {
+	case TK_NULL, TK_STRING, TK_BLOB, TK_INTEGER, TK_FLOAT:
+		v, err := sqlite3ExprLiteralValue(pExpr, false)
+		return v, err == nil
+	case TK_TRUEFALSE:
+		return foldBool(sqlite3ExprTruthValue(pExpr)), true
+	case TK_UPLUS, TK_COLLATE:
+		return foldValue(pExpr.pLeft)
+	case TK_UMINUS:
+		if v, err := sqlite3ExprLiteralValue(pExpr, false); err == nil {
+			return v, true
+		}
+		/* Otherwise the operand is subtracted from zero */
+		v, ok := foldValue(pExpr.pLeft)
+		if !ok {
+			return nil, false
+		}
+		return foldArithmetic(TK_MINUS, int64(0), v), true
+	case TK_BITNOT:
+		v, ok := foldValue(pExpr.pLeft)
+		if !ok || v == nil {
+			return nil, ok
+		}
+		return ^foldIntValue(v), true
+	case TK_NOT:
+		v, ok := foldValue(pExpr.pLeft)
+		if !ok || v == nil {
+			return nil, ok
+		}
+		b, _ := foldTruth(v)
+		return foldBool(!b), true
+	case TK_ISNULL, TK_NOTNULL:
+		v, ok := foldValue(pExpr.pLeft)
+		if !ok {
+			return nil, false
+		}
+		return foldBool((v == nil) == (pExpr.op == TK_ISNULL)), true
+	case TK_CAST:
+		v, ok := foldValue(pExpr.pLeft)
+		if !ok {
+			return nil, false
+		}
+		return foldCast(v, sqlite3AffinityType(pExpr.u.zToken, nil))
+	case TK_AND, TK_OR:
+		/* A false operand of AND, or a true operand of OR, decides the
+		 ** result even if the other operand is not constant */
+		a, aOk := foldValue(pExpr.pLeft)
+		b, bOk := foldValue(pExpr.pRight)
+		aTrue, aNull := foldTruth(a)
+		bTrue, bNull := foldTruth(b)
+		bAnd := pExpr.op == TK_AND
+		if (aOk && !aNull && aTrue != bAnd) || (bOk && !bNull && bTrue != bAnd) {
+			return foldBool(!bAnd), true
+		}
+		if !aOk || !bOk {
+			return nil, false
+		}
+		if aNull || bNull {
+			return nil, true
+		}
+		return foldBool(bAnd), true
+	case TK_PLUS, TK_MINUS, TK_STAR, TK_SLASH, TK_REM,
+		TK_BITAND, TK_BITOR, TK_LSHIFT, TK_RSHIFT, TK_CONCAT,
+		TK_EQ, TK_NE, TK_LT, TK_LE, TK_GT, TK_GE, TK_IS, TK_ISNOT:
+		if pExpr.pLeft.op == TK_VECTOR || pExpr.pRight.op == TK_VECTOR {
+			return nil, false
+		}
+		a, ok := foldValue(pExpr.pLeft)
+		if !ok {
+			return nil, false
+		}
+		if pExpr.op == TK_IS || pExpr.op == TK_ISNOT {
+			pRight := sqlite3ExprSkipCollateAndLikely(pExpr.pRight)
+			if pRight.op == TK_TRUEFALSE {
+				return foldTruthTest(pExpr.op, pRight, a), true
+			}
+		}
+		b, ok := foldValue(pExpr.pRight)
+		if !ok {
+			return nil, false
+		}
+		switch pExpr.op {
+		case TK_PLUS, TK_MINUS, TK_STAR, TK_SLASH, TK_REM:
+			return foldArithmetic(pExpr.op, a, b), true
+		case TK_BITAND, TK_BITOR, TK_LSHIFT, TK_RSHIFT:
+			return foldBitwise(pExpr.op, a, b), true
+		case TK_CONCAT:
+			if a == nil || b == nil {
+				return nil, true
+			}
+			zA, okA := foldTextValue(a)
+			zB, okB := foldTextValue(b)
+			return zA + zB, okA && okB
+		}
+		return foldComparison(pExpr.op, pExpr.pLeft, pExpr.pRight, a, b)
+	case TK_BETWEEN:
+		/* Coded by exprCodeBetween() as "x>=lo AND x<=hi" */
+		pList := pExpr.x.pList
+		if pExpr.pLeft.op == TK_VECTOR {
+			return nil, false
+		}
+		x, ok1 := foldValue(pExpr.pLeft)
+		lo, ok2 := foldValue(pList.a[0].pExpr)
+		hi, ok3 := foldValue(pList.a[1].pExpr)
+		if !ok1 || !ok2 || !ok3 {
+			return nil, false
+		}
+		a, ok1 := foldComparison(TK_GE, pExpr.pLeft, pList.a[0].pExpr, x, lo)
+		b, ok2 := foldComparison(TK_LE, pExpr.pLeft, pList.a[1].pExpr, x, hi)
+		if !ok1 || !ok2 {
+			return nil, false
+		}
+		aTrue, aNull := foldTruth(a)
+		bTrue, bNull := foldTruth(b)
+		if (!aNull && !aTrue) || (!bNull && !bTrue) {
+			return int64(0), true
+		} else if aNull || bNull {
+			return nil, true
+		}
+		return int64(1), true
+	case TK_IN:
+		/* The LHS affinity and collating sequence apply to every term of
+		 ** the list, as in sqlite3ExprCodeIN() */
+		if ExprUseXSelect(pExpr) || pExpr.pLeft.op == TK_VECTOR {
+			return nil, false
+		}
+		x, ok := foldValue(pExpr.pLeft)
+		if !ok {
+			return nil, false
+		}
+		aff := sqlite3ExprAffinity(pExpr.pLeft)
+		zColl := foldCollName(pExpr.pLeft)
+		bNull := x == nil
+		bFound := false
+		pList := pExpr.x.pList
+		for i := 0; i < pList.nExpr; i++ {
+			y, ok := foldValue(pList.a[i].pExpr)
+			if !ok {
+				return nil, false
+			}
+			if y == nil {
+				bNull = true
+			} else if x != nil && !bFound {
+				a, b := x, y
+				if aff >= SQLITE_AFF_NUMERIC {
+					a = foldApplyNumericAffinity(a)
+					b = foldApplyNumericAffinity(b)
+				} else if aff == SQLITE_AFF_TEXT {
+					var okA, okB bool
+					a, okA = foldTextValue(a)
+					if _, isBlob := y.([]byte); isBlob {
+						b, okB = y, true
+					} else {
+						b, okB = foldTextValue(b)
+					}
+					if !okA || !okB {
+						return nil, false
+					}
+				}
+				c, ok := foldCompareValues(a, b, zColl)
+				if !ok {
+					return nil, false
+				}
+				bFound = c == 0
+			}
+		}
+		if bFound {
+			return int64(1), true
+		} else if bNull {
+			return nil, true
+		}
+		return int64(0), true
+	case TK_CASE:
+		pList := pExpr.x.pList
+		i := 0
+		for ; i+1 < pList.nExpr; i += 2 {
+			var c interface{}
+			var ok bool
+			pWhen := pList.a[i].pExpr
+			if pExpr.pLeft != nil {
+				var a, b interface{}
+				var okA, okB bool
+				if a, okA = foldValue(pExpr.pLeft); okA {
+					b, okB = foldValue(pWhen)
+				}
+				if !okA || !okB {
+					return nil, false
+				}
+				c, ok = foldComparison(TK_EQ, pExpr.pLeft, pWhen, a, b)
+			} else {
+				c, ok = foldValue(pWhen)
+			}
+			if !ok {
+				return nil, false
+			}
+			if b, _ := foldTruth(c); b {
+				return foldValue(pList.a[i+1].pExpr)
+			}
+		}
+		if i < pList.nExpr {
+			return foldValue(pList.a[i].pExpr)
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
+/*
+** Return true if pExpr is a literal that folding would not change.
+ */
+func foldIsLiteral(pExpr *Expr) bool {
+	switch pExpr.op {
+	case TK_NULL, TK_STRING, TK_BLOB, TK_INTEGER, TK_FLOAT, TK_TRUEFALSE:
+		return true
+	case TK_UMINUS:
+		return pExpr.pLeft.op == TK_INTEGER || pExpr.pLeft.op == TK_FLOAT
+	}
+	return false
+}
+
+/*
+** Return true if the value of pExpr is always 0, 1 or NULL.
+ */
+func foldIsBoolean(pExpr *Expr) bool {
+	switch pExpr.op {
+	case TK_EQ, TK_NE, TK_LT, TK_LE, TK_GT, TK_GE, TK_IS, TK_ISNOT,
+		TK_ISNULL, TK_NOTNULL, TK_NOT, TK_AND, TK_OR, TK_BETWEEN,
+		TK_IN, TK_EXISTS, TK_TRUEFALSE, TK_NULL:
+		return true
+	case TK_INTEGER:
+		return ExprHasProperty(pExpr, EP_IntValue) &&
+			(pExpr.u.iValue == 0 || pExpr.u.iValue == 1)
+	}
+	return false
+}
+
+/*
+** Return a literal with value v.  In a boolean context the literal is
+** 1 if v is true and 0 if v is false or NULL.
+ */
+func foldLiteral(v interface{}, bBool bool) *Expr {
+	if bBool {
+		b, _ := foldTruth(v)
+		return Int(foldIntValue(foldBool(b)))
+	}
+	switch x := v.(type) {
+	case int64:
+		return Int(x)
+	case float64:
+		return Float(x)
+	case string:
+		return String(x)
+	case []byte:
+		return Blob(x)
+	}
+	return Null()
+}
+
+/*
+** Return true if pNew may replace pExpr.  Outside a boolean context the
+** replacement must have the same affinity and no collating sequence,
+** since either may change the result of a comparison that pExpr is an
+** operand of.
+ */
+func foldCanReplace(pExpr, pNew *Expr, bBool bool) bool {
+	if bBool {
+		return true
+	}
+	return sqlite3ExprAffinity(pExpr) == sqlite3ExprAffinity(pNew) &&
+		!ExprHasProperty(pExpr, EP_Collate) && !ExprHasProperty(pNew, EP_Collate)
+}
+
+/*
+** Fold the expressions in pList.  Terms of an ORDER BY or GROUP BY clause
+** that are integer literals refer to result columns, so for those lists
+** bKeepRoot is true and only the operands of each term are folded.
+ */
+func foldExprList(pParse *Parse, pList *ExprList, bKeepRoot bool) {
+	if pList == nil {
+		return
+	}
+	for i := 0; i < pList.nExpr; i++ {
+		pItem := &pList.a[i]
+		if bKeepRoot {
+			foldOperands(pParse, pItem.pExpr, false)
+		} else {
+			pItem.pExpr = sqlite3ExprFold(pParse, pItem.pExpr, false)
+		}
+	}
+}
+
+/*
+** Fold the operands of pExpr, but not pExpr itself.  Subqueries are
+** not entered.  They are folded by sqlite3SelectFold().
+ */
+func foldOperands(pParse *Parse, pExpr *Expr, bBool bool) {
+	if pExpr == nil || ExprHasProperty(pExpr, EP_TokenOnly|EP_Leaf) {
+		return
+	}
+	switch pExpr.op {
+	case TK_DOT, TK_SELECT, TK_EXISTS:
+		return
+	case TK_AND, TK_OR:
+		pExpr.pLeft = sqlite3ExprFold(pParse, pExpr.pLeft, bBool)
+		pExpr.pRight = sqlite3ExprFold(pParse, pExpr.pRight, bBool)
+		return
+	case TK_CASE:
+		pList := pExpr.x.pList
+		pExpr.pLeft = sqlite3ExprFold(pParse, pExpr.pLeft, false)
+		for i := 0; i < pList.nExpr; i++ {
+			bWhen := pExpr.pLeft == nil && (i&1) == 0 && i+1 < pList.nExpr
+			if bWhen {
+				pList.a[i].pExpr = sqlite3ExprFold(pParse, pList.a[i].pExpr, true)
+			} else if (i&1) == 0 && i+1 < pList.nExpr {
+				pList.a[i].pExpr = sqlite3ExprFold(pParse, pList.a[i].pExpr, false)
+			} else {
+				pList.a[i].pExpr = sqlite3ExprFold(pParse, pList.a[i].pExpr, bBool)
+			}
+		}
+		return
+	}
+	pExpr.pLeft = sqlite3ExprFold(pParse, pExpr.pLeft, false)
+	pExpr.pRight = sqlite3ExprFold(pParse, pExpr.pRight, false)
+	if ExprUseXList(pExpr) {
+		foldExprList(pParse, pExpr.x.pList, false)
+	}
+}
+
+/*
+** Simplify a CASE expression whose value could not be computed.  WHEN
+** terms that are never true are removed, and a WHEN term that is always
+** true becomes the ELSE.  If no WHEN term is left, the CASE is replaced
+** by its ELSE term.
+ */
+func foldCase(pParse *Parse, pExpr *Expr, bBool bool) *Expr {
+	var pElse *Expr
+	var aKeep []*Expr
+	pList := pExpr.x.pList
+	i := 0
+	for ; i+1 < pList.nExpr; i += 2 {
+		var c interface{}
+		ok := false
+		pWhen := pList.a[i].pExpr
+		if pExpr.pLeft == nil {
+			c, ok = foldValue(pWhen)
+		} else if a, okA := foldValue(pExpr.pLeft); okA {
+			if b, okB := foldValue(pWhen); okB {
+				c, ok = foldComparison(TK_EQ, pExpr.pLeft, pWhen, a, b)
+			}
+		}
+		if !ok {
+			aKeep = append(aKeep, pWhen, pList.a[i+1].pExpr)
+		} else if b, _ := foldTruth(c); b {
+			pElse = pList.a[i+1].pExpr
+			break
+		}
+	}
+	if pElse == nil && i < pList.nExpr {
+		pElse = pList.a[pList.nExpr-1].pExpr
+	}
+	if len(aKeep) == 0 {
+		if pElse == nil {
+			pElse = Null()
+		}
+		if foldCanReplace(pExpr, pElse, bBool) {
+			return pElse
+		}
+		return pExpr
+	}
+	if len(aKeep) < pList.nExpr-(pList.nExpr&1) || (pElse != nil) != ((pList.nExpr&1) != 0) {
+		var pNew *ExprList
+		for _, p := range aKeep {
+			pNew = sqlite3ExprListAppend(pParse, pNew, p)
+		}
+		if pElse != nil {
+			pNew = sqlite3ExprListAppend(pParse, pNew, pElse)
+		}
+		pExpr.x.pList = pNew
+	}
+	return pExpr
+}
+
+/*
+** Fold the constant subexpressions of pExpr and return the result.
+** Operands are modified in place.  The root may be replaced, so the
+** caller must store the value returned.
+ */
+func sqlite3ExprFold(pParse *Parse, pExpr *Expr, bBool bool) *Expr {
+	if pExpr == nil {
+		return nil
+	}
+	switch pExpr.op {
+	case TK_ID:
+		/* A name that is not a column, "true" or "false", is a constant */
+		sqlite3ExprIdToTrueFalse(pExpr)
+	case TK_NOT:
+		if bBool && pExpr.pLeft.op == TK_NOT {
+			/* NOT NOT x has the truth of x */
+			return sqlite3ExprFold(pParse, pExpr.pLeft.pLeft, true)
+		}
+	}
+	foldOperands(pParse, pExpr, bBool)
+
+	if v, ok := foldValue(pExpr); ok {
+		if foldIsLiteral(pExpr) && (!bBool || pExpr.op == TK_INTEGER || pExpr.op == TK_TRUEFALSE) {
+			return pExpr
+		}
+		pNew := foldLiteral(v, bBool)
+		if foldCanReplace(pExpr, pNew, bBool) {
+			pNew.w.iOfst = pExpr.w.iOfst
+			pNew.iSpan = pExpr.iSpan
+			pNew.nSpan = pExpr.nSpan
+			return pNew
+		}
+		return pExpr
+	}
+
+	switch pExpr.op {
+	case TK_AND, TK_OR:
+		if bBool {
+			return sqlite3ExprSimplifiedAndOr(pExpr)
+		}
+		/* "x AND true" and "x OR false" are x if x is a boolean */
+		for i := 0; i < 2; i++ {
+			pConst, pOther := pExpr.pLeft, pExpr.pRight
+			if i == 1 {
+				pConst, pOther = pOther, pConst
+			}
+			v, ok := foldValue(pConst)
+			if !ok {
+				continue
+			}
+			b, isNull := foldTruth(v)
+			if !isNull && b == (pExpr.op == TK_AND) && foldIsBoolean(pOther) &&
+				foldCanReplace(pExpr, pOther, false) {
+				return pOther
+			}
+		}
+	case TK_NOT:
+		/* NOT NOT x is x if x is a boolean */
+		if pExpr.pLeft.op == TK_NOT && foldIsBoolean(pExpr.pLeft.pLeft) &&
+			foldCanReplace(pExpr, pExpr.pLeft.pLeft, false) {
+			return pExpr.pLeft.pLeft
+		}
+	case TK_CASE:
+		return foldCase(pParse, pExpr, bBool)
+	}
+	return pExpr
+}
+
+/*
+** Fold the expressions of one SELECT.  This is the xSelectCallback
+** of the walker used by sqlite3SelectFold().  The walker then visits
+** the subqueries that survive.
+ */
+func foldSelectCallback(pWalker *Walker, p *Select) int {
+	pParse := pWalker.pParse
+	foldExprList(pParse, p.pEList, false)
+	for i := 0; p.pSrc != nil && i < p.pSrc.nSrc; i++ {
+		pItem := &p.pSrc.a[i]
+		if pItem.u3.pOn != nil {
+			pItem.u3.pOn = sqlite3ExprFold(pParse, pItem.u3.pOn, true)
+		}
+		foldExprList(pParse, pItem.u1.pFuncArg, false)
+	}
+	if p.pWhere != nil {
+		p.pWhere = sqlite3ExprFold(pParse, p.pWhere, true)
+	}
+	foldExprList(pParse, p.pGroupBy, true)
+	if p.pHaving != nil {
+		p.pHaving = sqlite3ExprFold(pParse, p.pHaving, true)
+	}
+	foldExprList(pParse, p.pOrderBy, true)
+	if p.pLimit != nil {
+		p.pLimit.pLeft = sqlite3ExprFold(pParse, p.pLimit.pLeft, false)
+		p.pLimit.pRight = sqlite3ExprFold(pParse, p.pLimit.pRight, false)
+	}
+	return WRC_Continue
+}
+
+/*
+** Fold the constant subexpressions of every expression in SELECT
+** statement p, including those of subqueries.
+ */
+func sqlite3SelectFold(pParse *Parse, p *Select) {
+	var w Walker
+	w.pParse = pParse
+	w.xExprCallback = sqlite3ExprWalkNoop
+	w.xSelectCallback = foldSelectCallback
+	sqlite3WalkSelect(&w, p)
+}
+
+/*
+** IsConstant reports whether the value of p does not depend on any
+** table, parameter or function.  As in SQLite, the unquoted names TRUE
+** and FALSE are constants.
+ */
+func (p *Expr) IsConstant() bool {
+	db := openDatabase()
+	return sqlite3ExprIsConstant(sqlite3ExprDup(db, p, 0)) != 0
+}
+
+/*
+** Fold returns a copy of p in which constant subexpressions have been
+** replaced by their values.  For example, "a + (2 * 3)" becomes
+** "a + 6" and "x AND 0" becomes "0".  p itself is not modified.
+ */
+func (p *Expr) Fold() *Expr {
+	pParse := &Parse{db: openDatabase()}
+	return sqlite3ExprFold(pParse, sqlite3ExprDup(pParse.db, p, 0), false)
+}
+
+/*
+** AlwaysFalse reports whether p, used as a WHERE clause, can be shown
+** to reject every row.  That is the case if p folds to a value that is
+** false or NULL.  A false result means only that no proof was found.
+ */
+func (p *Expr) AlwaysFalse() bool {
+	pParse := &Parse{db: openDatabase()}
+	pFold := sqlite3ExprFold(pParse, sqlite3ExprDup(pParse.db, p, 0), true)
+	return ExprAlwaysFalse(pFold)
+}
+
+/*
+** Fold folds the constant subexpressions of every expression in p and
+** its subqueries, in place.  ORDER BY and GROUP BY terms keep their
+** root, since an integer there refers to a result column.
+ */
+func (p *Select) Fold() {
+	sqlite3SelectFold(&Parse{db: openDatabase()}, p)
+}
+
+/*
+** WhereAlwaysFalse reports whether the WHERE clause of p can be shown to
+** reject every row.  For a compound SELECT only the right-most term is
+** examined.
+ */
+func (p *Select) WhereAlwaysFalse() bool {
+	return p.pWhere != nil && p.pWhere.AlwaysFalse()
+}
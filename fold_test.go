@@ -0,0 +1,78 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Tests for constant folding.
+ */
+package internal
+
+import "testing"
+
+/*
+** Fold must replace constant subexpressions by their values and leave
+** everything that depends on a column or a function call alone.
+ */
+func TestFold(t *testing.T) {
+	aTest := []struct {
+		zExpr string
+		zWant string
+	}{
+		{"a + (2 * 3)", "a + 6"},
+		{"1 + 2 * 3", "7"},
+		{"x AND 0", "0"},
+		{"x OR 1", "1"},
+		{"x AND 1", "x AND 1"},
+		{"'a' || 'b'", "'ab'"},
+		{"-(-5)", "5"},
+		{"10 / 4", "2"},
+		{"10 / 0", "NULL"},
+		{"5 % 3", "2"},
+		{"1 < 2", "1"},
+		{"NOT 0", "1"},
+		{"1 IN (1,2)", "1"},
+		{"CASE WHEN 1 THEN a ELSE b END", "a"},
+		{"NULL + a", "NULL + a"},
+		{"abs(-3)", "abs(-3)"},
+		{"coalesce(NULL, 3)", "coalesce(NULL, 3)"},
+	}
+	for _, tc := range aTest {
+		p := testExpr(t, tc.zExpr)
+		zBefore := p.SQL()
+		if zGot := p.Fold().SQL(); zGot != tc.zWant {
+			t.Errorf("Fold(%q) = %q, want %q", tc.zExpr, zGot, tc.zWant)
+		}
+		if p.SQL() != zBefore {
+			t.Errorf("Fold(%q) modified its receiver", tc.zExpr)
+		}
+	}
+}
+
+/*
+** AlwaysFalse must recognize WHERE clauses that fold to false or NULL.
+ */
+func TestAlwaysFalse(t *testing.T) {
+	aTest := []struct {
+		zExpr string
+		bWant bool
+	}{
+		{"0", true},
+		{"NULL", true},
+		{"1 > 2", true},
+		{"a = 1 AND 2 < 1", true},
+		{"1", false},
+		{"a = 1", false},
+		{"a = 1 OR 1 > 2", false},
+	}
+	for _, tc := range aTest {
+		if bGot := testExpr(t, tc.zExpr).AlwaysFalse(); bGot != tc.bWant {
+			t.Errorf("AlwaysFalse(%q) = %v, want %v", tc.zExpr, bGot, tc.bWant)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains routines that render SQL text with syntax
+** highlighting, either as ANSI terminal escapes or as HTML.
+ */
+package internal
+
+import (
+	"html"
+	"strings"
+)
+
+/*
+** Return the offsets of keywords in zSql that the parser accepts as
+** identifiers by way of the %fallback ID directive.  For example, KEY
+** in "SELECT key FROM t" is an identifier.
+**
+** After an error the rest of the statement is only scanned, up to and
+** including the next ";", and its keywords are not included.  Parsing
+** starts again with the statement that follows.
+ */
+func highlightFallbacks(zSql []byte) map[int]bool {
+	var sParse Parse
+	db := openDatabase()
+	aOfst := map[int]bool{}
+	zTail := zSql
+	for len(zTail) > 0 {
+		sParse = Parse{}
+		sParse.db = db
+		db.errByteOffset = -1
+		sqlite3RunParser(&sParse, zTail)
+		iBase := len(zSql) - len(zTail)
+		for _, i := range sParse.aFallback {
+			aOfst[iBase+i] = true
+		}
+		if sParse.nErr > 0 {
+			zTail = highlightSkipStatement(zTail, &sParse)
+			continue
+		}
+		if len(sParse.zTail) == len(zTail) {
+			break
+		}
+		zTail = sParse.zTail
+	}
+	return aOfst
+}
+
+/*
+** The parser stopped with an error while parsing zTail.  Return the text
+** that follows the ";" at or after the token that caused the error, or
+** an empty slice if there is no such ";".
+ */
+func highlightSkipStatement(zTail []byte, pParse *Parse) []byte {
+	var tokenType int
+	z := pParse.zTail
+	if z0 := pParse.sLastToken.z; z0 != nil && len(z0) <= len(zTail) && len(z0) >= len(z) {
+		/* Rescan the token that caused the error, in case it is the ";" */
+		z = z0
+	}
+	for len(z) > 0 {
+		n := sqlite3GetToken(z, &tokenType)
+		z = z[n:]
+		if tokenType == TK_SEMI {
+			break
+		}
+	}
+	return z
+}
+
+/*
+** The CSS class and ANSI escape sequence used for each kind of token.
+ */
+type highlightStyle struct {
+	zClass string /* CSS class name */
+	zAnsi  string /* ANSI escape sequence that starts the color */
+}
+
+var (
+	hlKeyword    = &highlightStyle{"sql-keyword", "\x1b[1;34m"}
+	hlIdentifier = &highlightStyle{"sql-identifier", "\x1b[36m"}
+	hlString     = &highlightStyle{"sql-string", "\x1b[32m"}
+	hlNumber     = &highlightStyle{"sql-number", "\x1b[35m"}
+	hlBlob       = &highlightStyle{"sql-blob", "\x1b[33m"}
+	hlParameter  = &highlightStyle{"sql-parameter", "\x1b[1;33m"}
+	hlOperator   = &highlightStyle{"sql-operator", "\x1b[37m"}
+	hlComment    = &highlightStyle{"sql-comment", "\x1b[90m"}
+	hlError      = &highlightStyle{"sql-error", "\x1b[1;31m"}
+)
+
+/*
+** Return the style for token t, or nil if the token is whitespace and
+** is written as is.  aFallback holds the offsets of keywords that are
+** used as identifiers.
+ */
+func highlightStyleOf(t *ScanToken, aFallback map[int]bool) *highlightStyle {
+	switch t.Class {
+	case ClassSpace:
+		return nil
+	case ClassComment:
+		return hlComment
+	case ClassIllegal:
+		return hlError
+	case ClassIdentifier:
+		return hlIdentifier
+	case ClassVariable:
+		return hlParameter
+	case ClassOperator:
+		return hlOperator
+	case ClassKeyword:
+		if aFallback[t.Offset] {
+			return hlIdentifier
+		}
+		return hlKeyword
+	}
+	switch t.Kind {
+	case TK_STRING:
+		return hlString
+	case TK_BLOB:
+		return hlBlob
+	}
+	return hlNumber
+}
+
+/*
+** Split zSql into tokens and pass each one, with its style, to xRender.
+ */
+func highlight(zSql string, xRender func(t *ScanToken, pStyle *highlightStyle)) {
+	aFallback := highlightFallbacks([]byte(zSql))
+	s := NewScanner(zSql)
+	s.Space = true
+	for s.Next() {
+		t := s.Token()
+		xRender(&t, highlightStyleOf(&t, aFallback))
+	}
+}
+
+/*
+** HighlightANSI returns zSql with ANSI terminal color escapes around
+** each token.  Keywords that the parser accepts as identifiers, such as
+** KEY in "SELECT key FROM t", are colored as identifiers.
+ */
+func HighlightANSI(zSql string) string {
+	var b strings.Builder
+	highlight(zSql, func(t *ScanToken, pStyle *highlightStyle) {
+		if pStyle == nil {
+			b.WriteString(t.Text)
+			return
+		}
+		b.WriteString(pStyle.zAnsi)
+		b.WriteString(t.Text)
+		b.WriteString("\x1b[0m")
+	})
+	return b.String()
+}
+
+/*
+** HighlightHTML returns zSql as HTML with each token inside a <span>
+** element.  The CSS classes are sql-keyword, sql-identifier, sql-string,
+** sql-number, sql-blob, sql-parameter, sql-operator, sql-comment and
+** sql-error.  Whitespace is escaped but not wrapped.
+ */
+func HighlightHTML(zSql string) string {
+	var b strings.Builder
+	highlight(zSql, func(t *ScanToken, pStyle *highlightStyle) {
+		if pStyle == nil {
+			b.WriteString(html.EscapeString(t.Text))
+			return
+		}
+		b.WriteString(`<span class="`)
+		b.WriteString(pStyle.zClass)
+		b.WriteString(`">`)
+		b.WriteString(html.EscapeString(t.Text))
+		b.WriteString("</span>")
+	})
+	return b.String()
+}
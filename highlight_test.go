@@ -0,0 +1,79 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Tests for syntax highlighting.
+ */
+package internal
+
+import "testing"
+
+/*
+** Each token must be wrapped in a span of its class, with the text
+** escaped.  KEY is used as an identifier and must be colored as one.
+ */
+func TestHighlightHTML(t *testing.T) {
+	zSql := "SELECT key, 'a<b', x'0f' FROM t WHERE x = 1.5 AND y = :p -- c"
+	zWant := `<span class="sql-keyword">SELECT</span> ` +
+		`<span class="sql-identifier">key</span><span class="sql-operator">,</span> ` +
+		`<span class="sql-string">&#39;a&lt;b&#39;</span><span class="sql-operator">,</span> ` +
+		`<span class="sql-blob">x&#39;0f&#39;</span> ` +
+		`<span class="sql-keyword">FROM</span> <span class="sql-identifier">t</span> ` +
+		`<span class="sql-keyword">WHERE</span> <span class="sql-identifier">x</span> ` +
+		`<span class="sql-operator">=</span> <span class="sql-number">1.5</span> ` +
+		`<span class="sql-keyword">AND</span> <span class="sql-identifier">y</span> ` +
+		`<span class="sql-operator">=</span> <span class="sql-parameter">:p</span> ` +
+		`<span class="sql-comment">-- c</span>`
+	if zGot := HighlightHTML(zSql); zGot != zWant {
+		t.Errorf("got  %s\nwant %s", zGot, zWant)
+	}
+}
+
+/*
+** HighlightANSI must reset the color after each token and leave
+** whitespace alone.
+ */
+func TestHighlightANSI(t *testing.T) {
+	zSql := "SELECT key FROM t"
+	zWant := "\x1b[1;34mSELECT\x1b[0m \x1b[36mkey\x1b[0m \x1b[1;34mFROM\x1b[0m \x1b[36mt\x1b[0m"
+	if zGot := HighlightANSI(zSql); zGot != zWant {
+		t.Errorf("got  %q\nwant %q", zGot, zWant)
+	}
+}
+
+/*
+** A syntax error must not stop the keywords of later statements from
+** being colored as identifiers.  The rest of the statement with the
+** error is colored by the scanner alone.
+ */
+func TestHighlightAfterError(t *testing.T) {
+	aTest := []struct {
+		zSql  string
+		zWant string
+	}{
+		{"SELEC key; SELECT key",
+			`<span class="sql-identifier">SELEC</span> <span class="sql-keyword">key</span>` +
+				`<span class="sql-operator">;</span> <span class="sql-keyword">SELECT</span> ` +
+				`<span class="sql-identifier">key</span>`},
+		{"SELECT ; SELECT key",
+			`<span class="sql-keyword">SELECT</span> <span class="sql-operator">;</span> ` +
+				`<span class="sql-keyword">SELECT</span> <span class="sql-identifier">key</span>`},
+		{"SELECT 'a;b' FROM FROM; SELECT key",
+			`<span class="sql-keyword">SELECT</span> <span class="sql-string">&#39;a;b&#39;</span> ` +
+				`<span class="sql-keyword">FROM</span> <span class="sql-keyword">FROM</span>` +
+				`<span class="sql-operator">;</span> <span class="sql-keyword">SELECT</span> ` +
+				`<span class="sql-identifier">key</span>`},
+	}
+	for _, tc := range aTest {
+		if zGot := HighlightHTML(tc.zSql); zGot != tc.zWant {
+			t.Errorf("%s:\ngot  %s\nwant %s", tc.zSql, zGot, tc.zWant)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+/*
+** 2001 September 15
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains C code routines that are called by the parser
+** to handle INSERT statements in SQLite.
+ */
+package internal
+
+/*
+** The Go port does not generate code for an INSERT statement.  Instead
+** the parse tree is recorded in one of these objects and attached to the
+** Stmt.
+ */
+type Insert struct {
+	pTabList   *SrcList  /* Name of table into which we are inserting */
+	pSelect    *Select   /* A SELECT statement to use as the data source */
+	pColumn    *IdList   /* Column names corresponding to IDLIST, or NULL. */
+	onError    int       /* How to handle constraint errors */
+	pUpsert    *Upsert   /* ON CONFLICT clauses for upsert, or NULL */
+	pReturning *ExprList /* RETURNING clause, or NULL */
+}
+
+/*
+** This routine is called to handle SQL of the following forms:
+**
+**    insert into TABLE (IDLIST) values(EXPRLIST),(EXPRLIST),...
+**    insert into TABLE (IDLIST) select
+**    insert into TABLE (IDLIST) default values
+**
+** The IDLIST following the table name is always optional.  If omitted,
+** then a list of all (non-hidden) columns for the table is substituted.
+** The IDLIST appears in the pColumn parameter.  pColumn is NULL if IDLIST
+** is omitted.
+**
+** The pSelect parameter holds the values to be inserted for the
+** first two forms shown above.  A VALUES clause is really just short-hand
+** for a SELECT statement that omits the FROM clause and everything else
+** that follows.  If the pSelect parameter is NULL, that means that the
+** DEFAULT VALUES form of the INSERT statement is intended.
+**
+** The pUpsert parameter holds the chain of ON CONFLICT clauses, in the
+** order they were written.
+ */
+func sqlite3Insert(
+	pParse *Parse, /* Parser context */
+	pTabList *SrcList, /* Name of table into which we are inserting */
+	pSelect *Select, /* A SELECT statement to use as the data source */
+	pColumn *IdList, /* Column names corresponding to IDLIST, or NULL. */
+	onError int, /* How to handle constraint errors */
+	pUpsert *Upsert, /* ON CONFLICT clauses for upsert, or NULL */
+) {
+	var p *Insert
+	var nColumn int          /* Number of columns in the data */
+	var pReturning *ExprList /* The RETURNING clause, if any */
+
+	if pParse.nErr != 0 {
+		goto insert_cleanup
+	}
+	assert(pTabList.nSrc == 1, "pTabList.nSrc == 1")
+
+	/* Resolve the names in the data source.  The VALUES clause and the
+	 ** SELECT cannot see the table being inserted into.
+	 */
+	if pSelect != nil {
+		sqlite3SelectPrep(pParse, pSelect, nil)
+		if pParse.nErr != 0 {
+			goto insert_cleanup
+		}
+		nColumn = pSelect.pEList.nExpr
+	}
+
+	/* If the INSERT statement included an IDLIST term, then make sure
+	 ** the number of values supplied matches it.  Without a schema the
+	 ** number of columns is unknown when the IDLIST is omitted, and so is
+	 ** the width of a result set that uses "*".
+	 */
+	if pColumn != nil && (pSelect == nil || !selectHasStar(pSelect.pEList)) &&
+		nColumn != pColumn.nId {
+		sqlite3ErrorMsg(pParse, "%d values for %d columns", nColumn, pColumn.nId)
+		goto insert_cleanup
+	}
+
+	/* Resolve the names in the ON CONFLICT clauses
+	 */
+	if pUpsert != nil && sqlite3UpsertResolve(pParse, pTabList, pUpsert) != 0 {
+		goto insert_cleanup
+	}
+
+	/* Resolve the names in the RETURNING clause
+	 */
+	pReturning = sqlite3ReturningResolve(pParse, pTabList)
+	if pParse.nErr != 0 {
+		goto insert_cleanup
+	}
+
+	p = &Insert{}
+	p.pTabList = pTabList
+	p.pSelect = pSelect
+	p.pColumn = pColumn
+	p.onError = onError
+	p.pUpsert = pUpsert
+	p.pReturning = pReturning
+	pParse.pInsert = p
+	return
+
+insert_cleanup:
+	sqlite3SrcListDelete(pParse.db, pTabList)
+	sqlite3SelectDelete(pParse.db, pSelect)
+	sqlite3IdListDelete(pParse.db, pColumn)
+}
+
+/* Table returns the name of the table that p inserts into */
+func (p *Insert) Table() string { return string(p.pTabList.a[0].zName) }
+
+/*
+** Columns returns the column list that follows the table name, or nil
+** if the statement has none.
+ */
+func (p *Insert) Columns() []string {
+	var azCol []string
+	if p.pColumn == nil {
+		return nil
+	}
+	for i := 0; i < p.pColumn.nId; i++ {
+		azCol = append(azCol, string(p.pColumn.a[i].zName))
+	}
+	return azCol
+}
+
+/*
+** Select returns the VALUES clause or SELECT statement that supplies the
+** rows to insert, or nil for DEFAULT VALUES.
+ */
+func (p *Insert) Select() *Select { return p.pSelect }
+
+/*
+** Returning returns the terms of the RETURNING clause, or nil if there is
+** none.  A Catalog replaces "*" by the columns of the table.
+ */
+func (p *Insert) Returning() []*Expr { return exprListToSlice(p.pReturning) }
@@ -0,0 +1,84 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Tests for INSERT statements and their ON CONFLICT clauses.
+ */
+package internal
+
+import "testing"
+
+/*
+** An INSERT must record its table, columns, data source and the whole
+** chain of ON CONFLICT clauses in the order they were written.
+ */
+func TestInsert(t *testing.T) {
+	zSql := "INSERT OR REPLACE INTO t(a, b) VALUES(1, 2) " +
+		"ON CONFLICT(a) DO NOTHING ON CONFLICT(b) DO UPDATE SET a = excluded.a ON CONFLICT DO NOTHING"
+	aStmt, err := ParseSQL(zSql, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := aStmt[0].pInsert
+	if p == nil {
+		t.Fatal("no INSERT recorded")
+	}
+	if string(p.pTabList.a[0].zName) != "t" || p.pColumn == nil || p.pColumn.nId != 2 ||
+		p.pSelect == nil || p.onError != OE_Replace {
+		t.Errorf("got %+v", p)
+	}
+	n := 0
+	for pUpsert := p.pUpsert; pUpsert != nil; pUpsert = pUpsert.pNextUpsert {
+		n++
+	}
+	if n != 3 {
+		t.Errorf("%d ON CONFLICT clauses, want 3", n)
+	}
+}
+
+/*
+** The EXCLUDED pseudo-table must be visible only in the DO UPDATE terms
+** of an upsert, and the IDLIST must match the number of values.
+ */
+func TestInsertError(t *testing.T) {
+	aTest := []struct {
+		zSql  string
+		zErr  string
+		iOfst int
+	}{
+		{"SELECT excluded.a FROM t",
+			"no such column: excluded.a", 7},
+		{"INSERT INTO t(a) VALUES(1) ON CONFLICT(a) WHERE excluded.a DO NOTHING",
+			"no such column: excluded.a", 48},
+		{"INSERT INTO t(a, b) VALUES(1)",
+			"1 values for 2 columns", -1},
+		{"INSERT INTO t(a) VALUES(1, 2)",
+			"2 values for 1 columns", -1},
+	}
+	for _, tc := range aTest {
+		_, err := ParseSQL(tc.zSql, nil)
+		pErr, ok := err.(*Error)
+		if !ok || pErr.Msg != tc.zErr {
+			t.Errorf("%s: got %v, want %q", tc.zSql, err, tc.zErr)
+		} else if tc.iOfst >= 0 && pErr.Offset != tc.iOfst {
+			t.Errorf("%s: error at offset %d, want %d", tc.zSql, pErr.Offset, tc.iOfst)
+		}
+	}
+
+	for _, zSql := range []string{
+		"INSERT INTO t(a) VALUES(1) ON CONFLICT(a) DO UPDATE SET b = excluded.b WHERE excluded.c > 1",
+		"INSERT INTO t(a) VALUES(1) ON CONFLICT(a) DO UPDATE SET b = (SELECT excluded.a)",
+		"SELECT excluded.a FROM excluded",
+	} {
+		if _, err := ParseSQL(zSql, nil); err != nil {
+			t.Errorf("%s: %v", zSql, err)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+**
+** This file contains the parts of SQLite's JSON support that the parser
+** needs: checking the JSON path on the right-hand side of the -> and ->>
+** operators.
+ */
+package internal
+
+/*
+** Return true if zPath, which begins with "$", is a well-formed JSON
+** path.  The rules are those of jsonLookupStep():
+**
+**     $                  the whole document
+**     .label             object member "label"
+**     ."label"           object member, quoted
+**     [N]                array element N
+**     [#]  [#-N]         array element counted from the end
+**
+** Steps may be repeated.  Anything else is a "bad JSON path".
+ */
+func jsonPathIsValid(zPath []byte) bool {
+	var i, k int
+	if charAt(zPath, 0) != '$' {
+		return false
+	}
+	zPath = zPath[1:]
+	for len(zPath) > 0 {
+		switch zPath[0] {
+		case '.':
+			zPath = zPath[1:]
+			if charAt(zPath, 0) == '"' {
+				for i = 1; i < len(zPath) && zPath[i] != '"'; i++ {
+				}
+				if i >= len(zPath) {
+					return false
+				}
+				i++
+			} else {
+				for i = 0; i < len(zPath) && zPath[i] != '.' && zPath[i] != '['; i++ {
+				}
+				if i == 0 {
+					return false
+				}
+			}
+		case '[':
+			k = 1
+			for sqlite3Isdigit(charAt(zPath, k)) {
+				k++
+			}
+			if k == 1 && charAt(zPath, 1) == '#' {
+				k = 2
+				if charAt(zPath, 2) == '-' && sqlite3Isdigit(charAt(zPath, 3)) {
+					k = 3
+					for sqlite3Isdigit(charAt(zPath, k)) {
+						k++
+					}
+				}
+			} else if k == 1 {
+				return false
+			}
+			if charAt(zPath, k) != ']' {
+				return false
+			}
+			i = k + 1
+		default:
+			return false
+		}
+		zPath = zPath[i:]
+	}
+	return true
+}
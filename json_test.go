@@ -0,0 +1,75 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Tests for the -> and ->> operators.
+ */
+package internal
+
+import "testing"
+
+/*
+** The -> and ->> operators must become TK_PTR nodes that keep the
+** operator text, with the value on the left and the path on the right.
+ */
+func TestExprPtr(t *testing.T) {
+	aTest := []struct {
+		zExpr string
+		zOp   string
+	}{
+		{"a -> '$.x'", "->"},
+		{"a ->> '$[0].y'", "->>"},
+		{"a -> 'label'", "->"},
+		{"a ->> 2", "->>"},
+		{"a -> '$'", "->"},
+		{"a -> '$.\"q\"'", "->"},
+		{"a -> '$[#-1]'", "->"},
+		{"(a -> '$.b') ->> '$.c'", "->>"},
+	}
+	for _, tc := range aTest {
+		p := testExpr(t, tc.zExpr)
+		if p.op != TK_PTR || string(p.u.zToken) != tc.zOp {
+			t.Errorf("%s: op %d %q, want TK_PTR %q", tc.zExpr, p.op, p.u.zToken, tc.zOp)
+			continue
+		}
+		if p.pLeft == nil || p.pRight == nil {
+			t.Errorf("%s: missing operand", tc.zExpr)
+		}
+	}
+}
+
+/*
+** A malformed JSON path on the right of -> or ->> must be reported with
+** the offset of the path.
+ */
+func TestExprPtrBadPath(t *testing.T) {
+	aTest := []struct {
+		zSql  string
+		iOfst int
+	}{
+		{"SELECT a -> '$.'", 12},
+		{"SELECT a -> '$['", 12},
+		{"SELECT a ->> '$x'", 13},
+		{"SELECT a ->> '$[1'", 13},
+	}
+	for _, tc := range aTest {
+		_, err := ParseSQL(tc.zSql, nil)
+		pErr, ok := err.(*Error)
+		if !ok {
+			t.Errorf("%s: got %v, want an error", tc.zSql, err)
+			continue
+		}
+		zWant := "bad JSON path: " + tc.zSql[tc.iOfst:]
+		if pErr.Msg != zWant || pErr.Offset != tc.iOfst {
+			t.Errorf("%s: got %q at %d, want %q at %d",
+				tc.zSql, pErr.Msg, pErr.Offset, zWant, tc.iOfst)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+/*
+** The code in this file implements a function that determines whether
+** or not a given identifier is really an SQL keyword.  The same thing
+** might be implemented more directly using a hand-written hash table.
+** But by using this automatically generated code, the size of the code
+** is substantially reduced.  This is important for embedded applications
+** on platforms with limited memory.
+**
+** The C version of this file is generated by mkkeywordhash.c and packs
+** the keyword text into a single string with a perfect hash.  The Go
+** port keeps the keyword table as a sorted array and indexes it with
+** a map keyed on the upper-case keyword text.
+ */
+package internal
+
+/*
+** An instance of the following structure describes a single keyword.
+ */
+type Keyword struct {
+	zName     string /* The keyword name */
+	tokenType int    /* Token value for this keyword */
+}
+
+/*
+** These are the keywords
+ */
+var aKeywordTable = []Keyword{
+	{"ABORT", TK_ABORT},
+	{"ACTION", TK_ACTION},
+	{"ADD", TK_ADD},
+	{"AFTER", TK_AFTER},
+	{"ALL", TK_ALL},
+	{"ALTER", TK_ALTER},
+	{"ALWAYS", TK_ALWAYS},
+	{"ANALYZE", TK_ANALYZE},
+	{"AND", TK_AND},
+	{"AS", TK_AS},
+	{"ASC", TK_ASC},
+	{"ATTACH", TK_ATTACH},
+	{"AUTOINCREMENT", TK_AUTOINCR},
+	{"BEFORE", TK_BEFORE},
+	{"BEGIN", TK_BEGIN},
+	{"BETWEEN", TK_BETWEEN},
+	{"BY", TK_BY},
+	{"CASCADE", TK_CASCADE},
+	{"CASE", TK_CASE},
+	{"CAST", TK_CAST},
+	{"CHECK", TK_CHECK},
+	{"COLLATE", TK_COLLATE},
+	{"COLUMN", TK_COLUMNKW},
+	{"COMMIT", TK_COMMIT},
+	{"CONFLICT", TK_CONFLICT},
+	{"CONSTRAINT", TK_CONSTRAINT},
+	{"CREATE", TK_CREATE},
+	{"CROSS", TK_JOIN_KW},
+	{"CURRENT", TK_CURRENT},
+	{"CURRENT_DATE", TK_CTIME_KW},
+	{"CURRENT_TIME", TK_CTIME_KW},
+	{"CURRENT_TIMESTAMP", TK_CTIME_KW},
+	{"DATABASE", TK_DATABASE},
+	{"DEFAULT", TK_DEFAULT},
+	{"DEFERRED", TK_DEFERRED},
+	{"DEFERRABLE", TK_DEFERRABLE},
+	{"DELETE", TK_DELETE},
+	{"DESC", TK_DESC},
+	{"DETACH", TK_DETACH},
+	{"DISTINCT", TK_DISTINCT},
+	{"DO", TK_DO},
+	{"DROP", TK_DROP},
+	{"END", TK_END},
+	{"EACH", TK_EACH},
+	{"ELSE", TK_ELSE},
+	{"ESCAPE", TK_ESCAPE},
+	{"EXCEPT", TK_EXCEPT},
+	{"EXCLUSIVE", TK_EXCLUSIVE},
+	{"EXCLUDE", TK_EXCLUDE},
+	{"EXISTS", TK_EXISTS},
+	{"EXPLAIN", TK_EXPLAIN},
+	{"FAIL", TK_FAIL},
+	{"FILTER", TK_FILTER},
+	{"FIRST", TK_FIRST},
+	{"FOLLOWING", TK_FOLLOWING},
+	{"FOR", TK_FOR},
+	{"FOREIGN", TK_FOREIGN},
+	{"FROM", TK_FROM},
+	{"FULL", TK_JOIN_KW},
+	{"GENERATED", TK_GENERATED},
+	{"GLOB", TK_LIKE_KW},
+	{"GROUP", TK_GROUP},
+	{"GROUPS", TK_GROUPS},
+	{"HAVING", TK_HAVING},
+	{"IF", TK_IF},
+	{"IGNORE", TK_IGNORE},
+	{"IMMEDIATE", TK_IMMEDIATE},
+	{"IN", TK_IN},
+	{"INDEX", TK_INDEX},
+	{"INDEXED", TK_INDEXED},
+	{"INITIALLY", TK_INITIALLY},
+	{"INNER", TK_JOIN_KW},
+	{"INSERT", TK_INSERT},
+	{"INSTEAD", TK_INSTEAD},
+	{"INTERSECT", TK_INTERSECT},
+	{"INTO", TK_INTO},
+	{"IS", TK_IS},
+	{"ISNULL", TK_ISNULL},
+	{"JOIN", TK_JOIN},
+	{"KEY", TK_KEY},
+	{"LAST", TK_LAST},
+	{"LEFT", TK_JOIN_KW},
+	{"LIKE", TK_LIKE_KW},
+	{"LIMIT", TK_LIMIT},
+	{"MATCH", TK_MATCH},
+	{"MATERIALIZED", TK_MATERIALIZED},
+	{"NATURAL", TK_JOIN_KW},
+	{"NO", TK_NO},
+	{"NOT", TK_NOT},
+	{"NOTHING", TK_NOTHING},
+	{"NOTNULL", TK_NOTNULL},
+	{"NULL", TK_NULL},
+	{"NULLS", TK_NULLS},
+	{"OF", TK_OF},
+	{"OFFSET", TK_OFFSET},
+	{"ON", TK_ON},
+	{"OR", TK_OR},
+	{"ORDER", TK_ORDER},
+	{"OTHERS", TK_OTHERS},
+	{"OUTER", TK_JOIN_KW},
+	{"OVER", TK_OVER},
+	{"PARTITION", TK_PARTITION},
+	{"PLAN", TK_PLAN},
+	{"PRAGMA", TK_PRAGMA},
+	{"PRECEDING", TK_PRECEDING},
+	{"PRIMARY", TK_PRIMARY},
+	{"QUERY", TK_QUERY},
+	{"RAISE", TK_RAISE},
+	{"RANGE", TK_RANGE},
+	{"RECURSIVE", TK_RECURSIVE},
+	{"REFERENCES", TK_REFERENCES},
+	{"REGEXP", TK_LIKE_KW},
+	{"REINDEX", TK_REINDEX},
+	{"RELEASE", TK_RELEASE},
+	{"RENAME", TK_RENAME},
+	{"REPLACE", TK_REPLACE},
+	{"RESTRICT", TK_RESTRICT},
+	{"RETURNING", TK_RETURNING},
+	{"RIGHT", TK_JOIN_KW},
+	{"ROLLBACK", TK_ROLLBACK},
+	{"ROW", TK_ROW},
+	{"ROWS", TK_ROWS},
+	{"SAVEPOINT", TK_SAVEPOINT},
+	{"SELECT", TK_SELECT},
+	{"SET", TK_SET},
+	{"TABLE", TK_TABLE},
+	{"TEMP", TK_TEMP},
+	{"TEMPORARY", TK_TEMP},
+	{"THEN", TK_THEN},
+	{"TIES", TK_TIES},
+	{"TO", TK_TO},
+	{"TRANSACTION", TK_TRANSACTION},
+	{"TRIGGER", TK_TRIGGER},
+	{"UNBOUNDED", TK_UNBOUNDED},
+	{"UNION", TK_UNION},
+	{"UNIQUE", TK_UNIQUE},
+	{"UPDATE", TK_UPDATE},
+	{"USING", TK_USING},
+	{"VACUUM", TK_VACUUM},
+	{"VALUES", TK_VALUES},
+	{"VIEW", TK_VIEW},
+	{"VIRTUAL", TK_VIRTUAL},
+	{"WHEN", TK_WHEN},
+	{"WHERE", TK_WHERE},
+	{"WINDOW", TK_WINDOW},
+	{"WITH", TK_WITH},
+	{"WITHOUT", TK_WITHOUT},
+}
+
+/*
+** The number of keywords recognized by the tokenizer.
+ */
+const SQLITE_N_KEYWORD = 147
+
+/* Map from the upper-case text of a keyword to its index in aKeywordTable */
+var aKWHash = func() map[string]int {
+	m := make(map[string]int, len(aKeywordTable))
+	for i, k := range aKeywordTable {
+		m[k.zName] = i
+	}
+	return m
+}()
+
+/* The longest keyword is CURRENT_TIMESTAMP */
+const mxKeywordLen = 17
+
+/* Check to see if z[0..n-1] is a keyword. If it is, write the
+** parser symbol code for that keyword into *pType.  Always
+** return the integer n (the length of the token).
+ */
+func keywordCode(z []byte, n int, pType *int) int {
+	var zUpper [mxKeywordLen]byte
+	if n < 2 || n > mxKeywordLen {
+		return n
+	}
+	for j := 0; j < n; j++ {
+		zUpper[j] = sqlite3Toupper(z[j])
+	}
+	if i, ok := aKWHash[string(zUpper[:n])]; ok {
+		*pType = aKeywordTable[i].tokenType
+	}
+	return n
+}
+
+func sqlite3KeywordCode(z []byte, n int) int {
+	id := TK_ID
+	keywordCode(z, n, &id)
+	return id
+}
+
+/*
+** Return the number of distinct keywords recognized by the parser.
+ */
+func sqlite3_keyword_count() int { return SQLITE_N_KEYWORD }
+
+/*
+** Write the name of the i-th keyword into *pzName and its length into
+** *pnName.  Return SQLITE_OK, or SQLITE_ERROR if i is out of range.
+ */
+func sqlite3_keyword_name(i int, pzName *[]byte, pnName *int) int {
+	if i < 0 || i >= SQLITE_N_KEYWORD {
+		return SQLITE_ERROR
+	}
+	*pzName = []byte(aKeywordTable[i].zName)
+	*pnName = len(aKeywordTable[i].zName)
+	return SQLITE_OK
+}
+
+/*
+** Return non-zero if the first nName bytes of zName are a keyword.
+ */
+func sqlite3_keyword_check(zName []byte, nName int) int {
+	if sqlite3KeywordCode(zName, nName) != TK_ID {
+		return 1
+	}
+	return 0
+}
+
+/*
+** KeywordInfo describes one SQL keyword.  Kind is the token code the
+** tokenizer returns for it.  Fallback is true if the keyword can be used
+** as a bare identifier wherever the keyword itself would be a syntax
+** error.  That is so for the keywords named by the %fallback ID
+** directive in parse.y, and for WINDOW, OVER and FILTER, which the
+** tokenizer reads as identifiers unless the text that follows makes
+** them keywords.
+ */
+type KeywordInfo struct {
+	Name     string
+	Kind     TokenKind
+	Fallback bool
+}
+
+/*
+** Return true if a keyword with token code tokenType may be used as an
+** identifier.  See KeywordInfo.
+ */
+func sqlite3KeywordIsFallback(tokenType int) bool {
+	switch tokenType {
+	case TK_WINDOW, TK_OVER, TK_FILTER:
+		/* See analyzeWindowKeyword() and its neighbours in tokenize.go */
+		return true
+	}
+	return sqlite3ParserFallback(tokenType) == TK_ID
+}
+
+/*
+** Keywords returns every keyword known to the parser.  The keywords are
+** in the order of sqlite3_keyword_name(), which is close to, but not
+** quite, alphabetical order.
+ */
+func Keywords() []KeywordInfo {
+	a := make([]KeywordInfo, sqlite3_keyword_count())
+	for i := range a {
+		var zName []byte
+		var nName int
+		sqlite3_keyword_name(i, &zName, &nName)
+		a[i].Name = string(zName[:nName])
+		a[i].Kind = TokenKind(aKeywordTable[i].tokenType)
+		a[i].Fallback = sqlite3KeywordIsFallback(aKeywordTable[i].tokenType)
+	}
+	return a
+}
+
+/*
+** IsKeyword reports whether zName is an SQL keyword, as
+** sqlite3_keyword_check() does.  Case is ignored.  Many keywords, such as
+** KEY, REPLACE and WINDOW, can still be used as identifiers; see
+** KeywordInfo.Fallback.
+ */
+func IsKeyword(zName string) bool {
+	return sqlite3_keyword_check([]byte(zName), len(zName)) != 0
+}
@@ -0,0 +1,94 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Tests for keyword introspection.
+ */
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+/*
+** Keywords must list each of the 147 keywords of SQLite once, and
+** IsKeyword must accept each of them in any case.  The tokenizer must
+** return the listed token kind for each of them.
+ */
+func TestKeywords(t *testing.T) {
+	aKw := Keywords()
+	if len(aKw) != 147 {
+		t.Fatalf("%d keywords, want 147", len(aKw))
+	}
+	mSeen := map[string]bool{}
+	for _, kw := range aKw {
+		if mSeen[kw.Name] {
+			t.Errorf("%s is listed twice", kw.Name)
+		}
+		mSeen[kw.Name] = true
+		if !IsKeyword(kw.Name) || !IsKeyword(strings.ToLower(kw.Name)) {
+			t.Errorf("IsKeyword(%q) is false", kw.Name)
+		}
+		var tokenType int
+		sqlite3GetToken([]byte(kw.Name), &tokenType)
+		if TokenKind(tokenType) != kw.Kind {
+			t.Errorf("%s is tokenized as %s, want %s", kw.Name, TokenKind(tokenType), kw.Kind)
+		}
+	}
+
+	for _, zName := range []string{"", "rowid", "SELECTX", "x", "oid"} {
+		if IsKeyword(zName) {
+			t.Errorf("IsKeyword(%q) is true", zName)
+		}
+	}
+}
+
+/*
+** Fallback must be set for keywords that may be used as identifiers
+** and clear for reserved words.
+ */
+func TestKeywordFallback(t *testing.T) {
+	mFallback := map[string]bool{}
+	for _, kw := range Keywords() {
+		mFallback[kw.Name] = kw.Fallback
+	}
+	for _, zName := range []string{"ABORT", "KEY", "REPLACE", "TEMP", "WITHOUT", "WINDOW", "OVER", "FILTER"} {
+		if !mFallback[zName] {
+			t.Errorf("%s has no fallback", zName)
+		}
+	}
+	for _, zName := range []string{"SELECT", "FROM", "WHERE", "AND", "TABLE"} {
+		if mFallback[zName] {
+			t.Errorf("%s has a fallback", zName)
+		}
+	}
+}
+
+/*
+** Every keyword reported with a Fallback must parse as the name of a
+** column and of a table.
+ */
+func TestKeywordFallbackParses(t *testing.T) {
+	for _, kw := range Keywords() {
+		if !kw.Fallback {
+			continue
+		}
+		zSql := "CREATE TABLE t(" + kw.Name + " INT); SELECT a FROM " + kw.Name
+		if _, err := ParseSQL(zSql, nil); err != nil {
+			t.Errorf("%s: %v", zSql, err)
+		}
+	}
+	for _, zName := range []string{"WINDOW", "OVER", "FILTER"} {
+		if !IsKeyword(zName) {
+			t.Errorf("IsKeyword(%q) is false", zName)
+		}
+	}
+}
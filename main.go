@@ -0,0 +1,298 @@
+/*
+** 2001 September 15
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Main file for the SQLite library.  The routines in this file
+** implement the programmer interface to the library.  Routines in
+** other files are for internal use by SQLite and should not be
+** accessed by users of the library.
+ */
+package internal
+
+/*
+** Return a static string that describes the kind of error specified in the
+** argument.
+ */
+func sqlite3ErrStr(rc int) string {
+	zErr := "unknown error"
+	switch rc {
+	case SQLITE_OK:
+		zErr = "not an error"
+	case SQLITE_ERROR:
+		zErr = "SQL logic error"
+	case SQLITE_NOMEM:
+		zErr = "out of memory"
+	case SQLITE_TOOBIG:
+		zErr = "string or blob too big"
+	case SQLITE_MISUSE:
+		zErr = "bad parameter or other API misuse"
+	case SQLITE_RANGE:
+		zErr = "column index out of range"
+	case SQLITE_DONE:
+		zErr = "no more rows available"
+	}
+	return zErr
+}
+
+/*
+** This array defines hard upper bounds on limit values.  The
+** initializer must be kept in sync with the SQLITE_LIMIT_*
+** #defines in sqlite3.h.
+ */
+var aHardLimit = [SQLITE_N_LIMIT]int{
+	SQLITE_MAX_LENGTH,
+	SQLITE_MAX_SQL_LENGTH,
+	SQLITE_MAX_COLUMN,
+	SQLITE_MAX_EXPR_DEPTH,
+	SQLITE_MAX_COMPOUND_SELECT,
+	SQLITE_MAX_VDBE_OP,
+	SQLITE_MAX_FUNCTION_ARG,
+	SQLITE_MAX_ATTACHED,
+	SQLITE_MAX_LIKE_PATTERN_LENGTH,
+	SQLITE_MAX_VARIABLE_NUMBER, /* IMP: R-38091-32352 */
+	SQLITE_MAX_TRIGGER_DEPTH,
+	SQLITE_MAX_WORKER_THREADS,
+	SQLITE_MAX_PARSER_DEPTH,
+}
+
+/*
+** Change the value of a limit.  Report the old value.
+** If an invalid limit index is supplied, report -1.
+** Make no changes but still report the old value if the
+** new limit is negative.
+**
+** A new lower limit does not shrink existing constructs.
+** It merely prevents new constructs that exceed the limit
+** from forming.
+ */
+func sqlite3_limit(db *sqlite3, limitId int, newLimit int) int {
+	var oldLimit int
+
+	if limitId < 0 || limitId >= SQLITE_N_LIMIT {
+		return -1
+	}
+	oldLimit = db.aLimit[limitId]
+	if newLimit >= 0 { /* IMP: R-52476-28732 */
+		if newLimit > aHardLimit[limitId] {
+			newLimit = aHardLimit[limitId] /* IMP: R-51463-25634 */
+		} else if newLimit < 1 && limitId == SQLITE_LIMIT_LENGTH {
+			newLimit = 1
+		}
+		db.aLimit[limitId] = newLimit
+	}
+	return oldLimit /* IMP: R-53341-35419 */
+}
+
+/*
+** This routine does the work of opening a database on behalf of
+** sqlite3_open() and sqlite3_open16(). The database filename "zFilename"
+** is UTF-8 encoded.
+**
+** There is no database file behind a golite connection.  The object
+** only carries the settings that affect parsing.
+ */
+func openDatabase() *sqlite3 {
+	db := &sqlite3{}
+	db.aLimit = aHardLimit
+	db.aLimit[SQLITE_LIMIT_WORKER_THREADS] = 0
+	db.aLimit[SQLITE_LIMIT_PARSER_DEPTH] = SQLITE_DEFAULT_PARSER_DEPTH
+	return db
+}
+
+/*
+** Limits holds the run-time limits that apply while parsing.  Each field
+** corresponds to one of the SQLITE_LIMIT_* categories.  A zero field
+** leaves the default in place.  Values larger than the compiled-in
+** maximum are silently reduced to that maximum, as sqlite3_limit() does.
+ */
+type Limits struct {
+	SQLLength      int /* SQLITE_LIMIT_SQL_LENGTH: bytes of SQL text */
+	ExprDepth      int /* SQLITE_LIMIT_EXPR_DEPTH: depth of an expression tree */
+	CompoundSelect int /* SQLITE_LIMIT_COMPOUND_SELECT: terms in a compound SELECT */
+	FunctionArg    int /* SQLITE_LIMIT_FUNCTION_ARG: arguments to a function */
+	VariableNumber int /* SQLITE_LIMIT_VARIABLE_NUMBER: largest ?NNN parameter */
+	ParserDepth    int /* SQLITE_LIMIT_PARSER_DEPTH: entries on the parser stack */
+}
+
+/*
+** Feature is a set of optional parts of the SQL language.  Each bit
+** corresponds to one of the SQLITE_OMIT_* compile-time options that
+** remove a feature from the SQLite grammar.  The Go port always parses
+** these constructs but can be told to reject them with a "not supported"
+** error.
+ */
+type Feature uint32
+
+const (
+	OmitAlterTable       Feature = 0x0001 /* SQLITE_OMIT_ALTERTABLE */
+	OmitAnalyze          Feature = 0x0002 /* SQLITE_OMIT_ANALYZE */
+	OmitAttach           Feature = 0x0004 /* SQLITE_OMIT_ATTACH */
+	OmitCTE              Feature = 0x0008 /* SQLITE_OMIT_CTE */
+	OmitExplain          Feature = 0x0010 /* SQLITE_OMIT_EXPLAIN */
+	OmitGeneratedColumns Feature = 0x0020 /* SQLITE_OMIT_GENERATED_COLUMNS */
+	OmitPragma           Feature = 0x0040 /* SQLITE_OMIT_PRAGMA */
+	OmitReindex          Feature = 0x0080 /* SQLITE_OMIT_REINDEX */
+	OmitTrigger          Feature = 0x0100 /* SQLITE_OMIT_TRIGGER */
+	OmitVacuum           Feature = 0x0200 /* SQLITE_OMIT_VACUUM */
+	OmitView             Feature = 0x0400 /* SQLITE_OMIT_VIEW */
+	OmitVirtualTable     Feature = 0x0800 /* SQLITE_OMIT_VIRTUALTABLE */
+	OmitWindowFunc       Feature = 0x1000 /* SQLITE_OMIT_WINDOWFUNC */
+)
+
+/*
+** Options controls how SQL text is parsed.  A nil *Options is the same
+** as the zero value.
+ */
+type Options struct {
+	Limits Limits
+	Omit   Feature /* Language features to reject */
+
+	/* Accept ORDER BY and LIMIT on UPDATE and DELETE statements, as
+	** SQLite does when built with SQLITE_ENABLE_UPDATE_DELETE_LIMIT.
+	** Otherwise those clauses are a syntax error. */
+	UpdateDeleteLimit bool
+
+	/* If not empty, the oldest SQLite release, such as "3.31.0", that
+	** must be able to run the SQL.  Constructs introduced by later
+	** releases are reported by Stmt.VersionIssues. */
+	TargetVersion string
+}
+
+/*
+** Return true if the feature identified by mask has been omitted from
+** the language accepted by database connection db.  If it has, also
+** leave an error message in pParse.  zName is the name of the feature
+** used in the error message.
+ */
+func sqlite3IsOmitted(pParse *Parse, mask Feature, zName string) bool {
+	if (Feature(pParse.db.omitFlags) & mask) == 0 {
+		return false
+	}
+	sqlite3ErrorMsg(pParse, "%s not supported", zName)
+	return true
+}
+
+/*
+** Convert a version string of the form "X.Y.Z" into the integer
+** X*1000000 + Y*1000 + Z used by SQLITE_VERSION_NUMBER.  The ".Z" part
+** may be omitted.  Return -1 if zVersion is not well formed.
+ */
+func sqlite3VersionNumber(zVersion string) int {
+	var aPart [3]int
+	nPart := 0
+	z := []byte(zVersion)
+	for nPart < 3 {
+		i := 0
+		for sqlite3Isdigit(charAt(z, i)) {
+			aPart[nPart] = aPart[nPart]*10 + int(z[i]-'0')
+			if aPart[nPart] > 999 {
+				return -1
+			}
+			i++
+		}
+		if i == 0 {
+			return -1
+		}
+		nPart++
+		z = z[i:]
+		if len(z) == 0 {
+			break
+		}
+		if z[0] != '.' {
+			return -1
+		}
+		z = z[1:]
+	}
+	if len(z) > 0 || nPart < 2 {
+		return -1
+	}
+	return aPart[0]*1000000 + aPart[1]*1000 + aPart[2]
+}
+
+/*
+** VersionIssue describes a construct that requires a newer release of
+** SQLite than Options.TargetVersion.
+ */
+type VersionIssue struct {
+	Feature string /* Name of the construct, e.g. "RETURNING" */
+	Version string /* The SQLite release that introduced the construct */
+	Offset  int    /* Byte offset of the construct within the SQL text */
+	Length  int    /* Length of the construct in bytes */
+}
+
+/*
+** Record a VersionIssue against the statement being parsed if the
+** construct zName, which first appeared in SQLite release iVersion, is
+** newer than the target version of the database connection.  The
+** construct runs from the start of token pFirst to the end of token
+** pLast.  pLast may be NULL if the construct is the single token pFirst.
+ */
+func sqlite3CheckVersion(pParse *Parse, iVersion int, zName string, pFirst *Token, pLast *Token) {
+	var p VersionIssue
+	db := pParse.db
+	if db.iTargetVersion == 0 || iVersion <= db.iTargetVersion {
+		return
+	}
+	if pLast == nil {
+		pLast = pFirst
+	}
+	p.Feature = zName
+	p.Version = string(sqlite3MPrintf(db, "%d.%d.%d",
+		iVersion/1000000, iVersion/1000%1000, iVersion%1000))
+	p.Offset = len(pParse.zTail) - len(pFirst.z)
+	p.Length = len(pFirst.z) - len(pLast.z) + int(pLast.n)
+
+	/* Rules are not always reduced in the order that their text appears,
+	 ** as for a WINDOW clause or a FILTER around an operator.  Keep the list
+	 ** in order of offset. */
+	i := len(pParse.aVersion)
+	pParse.aVersion = append(pParse.aVersion, p)
+	for ; i > 0 && pParse.aVersion[i-1].Offset > p.Offset; i-- {
+		pParse.aVersion[i] = pParse.aVersion[i-1]
+	}
+	pParse.aVersion[i] = p
+}
+
+/*
+** Apply the settings in opts to the database connection db.  An error
+** is returned if the options are not valid.
+ */
+func (opts *Options) apply(db *sqlite3) error {
+	if opts == nil {
+		return nil
+	}
+	if opts.TargetVersion != "" {
+		db.iTargetVersion = sqlite3VersionNumber(opts.TargetVersion)
+		if db.iTargetVersion < 0 {
+			db.iTargetVersion = 0
+			return &Error{Code: SQLITE_MISUSE, Msg: "invalid target version: " + opts.TargetVersion, Offset: -1}
+		}
+	}
+	db.omitFlags = uint32(opts.Omit)
+	if opts.UpdateDeleteLimit {
+		db.bUpdateDeleteLimit = 1
+	}
+	for _, x := range []struct {
+		id int
+		v  int
+	}{
+		{SQLITE_LIMIT_SQL_LENGTH, opts.Limits.SQLLength},
+		{SQLITE_LIMIT_EXPR_DEPTH, opts.Limits.ExprDepth},
+		{SQLITE_LIMIT_COMPOUND_SELECT, opts.Limits.CompoundSelect},
+		{SQLITE_LIMIT_FUNCTION_ARG, opts.Limits.FunctionArg},
+		{SQLITE_LIMIT_VARIABLE_NUMBER, opts.Limits.VariableNumber},
+		{SQLITE_LIMIT_PARSER_DEPTH, opts.Limits.ParserDepth},
+	} {
+		if x.v > 0 {
+			sqlite3_limit(db, x.id, x.v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Tests for the options that control parsing.
+ */
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+/*
+** Each SQLITE_LIMIT_* category must reject SQL that goes one past the
+** limit with SQLite's error message, and accept SQL that is within it.
+ */
+func TestLimits(t *testing.T) {
+	aTest := []struct {
+		zSql   string
+		sLimit Limits
+		zErr   string
+	}{
+		{"SELECT 1234", Limits{SQLLength: 11}, ""},
+		{"SELECT 12345", Limits{SQLLength: 11}, "statement too long"},
+		{"SELECT 1+2+3", Limits{ExprDepth: 3}, ""},
+		{"SELECT 1+2+3", Limits{ExprDepth: 2}, "Expression tree is too large (maximum depth 2)"},
+		{"SELECT 1 UNION SELECT 2", Limits{CompoundSelect: 2}, ""},
+		{"SELECT 1 UNION SELECT 2 UNION SELECT 3", Limits{CompoundSelect: 2}, "too many terms in compound SELECT"},
+		{"SELECT f(1,2)", Limits{FunctionArg: 2}, ""},
+		{"SELECT f(1,2,3)", Limits{FunctionArg: 2}, "too many arguments on function f"},
+		{"SELECT ?4", Limits{VariableNumber: 4}, ""},
+		{"SELECT ?5", Limits{VariableNumber: 4}, "variable number must be between ?1 and ?4"},
+		{"SELECT (1)", Limits{ParserDepth: 10}, ""},
+		{"SELECT ((((((1))))))", Limits{ParserDepth: 10}, "parser stack overflow"},
+		{"SELECT ?40000", Limits{VariableNumber: 1 << 30}, "variable number must be between ?1 and ?32766"},
+	}
+	for _, tc := range aTest {
+		_, err := ParseSQL(tc.zSql, &Options{Limits: tc.sLimit})
+		if tc.zErr == "" {
+			if err != nil {
+				t.Errorf("%s with %+v: %v", tc.zSql, tc.sLimit, err)
+			}
+			continue
+		}
+		if pErr, ok := err.(*Error); !ok || pErr.Msg != tc.zErr {
+			t.Errorf("%s with %+v: got %v, want %q", tc.zSql, tc.sLimit, err, tc.zErr)
+		}
+	}
+}
+
+/*
+** Each Feature in Options.Omit must reject the statements that use it
+** with a "not supported" error.  Keywords of omitted features that fall
+** back to identifiers must still be usable as names.
+ */
+func TestOmit(t *testing.T) {
+	aTest := []struct {
+		zSql  string
+		mOmit Feature
+		zErr  string
+	}{
+		{"ALTER TABLE t RENAME TO u", OmitAlterTable, "ALTER TABLE not supported"},
+		{"ANALYZE", OmitAnalyze, "ANALYZE not supported"},
+		{"ATTACH 'x.db' AS y", OmitAttach, "ATTACH not supported"},
+		{"DETACH y", OmitAttach, "DETACH not supported"},
+		{"WITH c AS (SELECT 1) SELECT * FROM c", OmitCTE, "common table expressions not supported"},
+		{"EXPLAIN SELECT 1", OmitExplain, "EXPLAIN not supported"},
+		{"CREATE TABLE t(a, b AS (a+1))", OmitGeneratedColumns, "generated columns not supported"},
+		{"PRAGMA cache_size", OmitPragma, "PRAGMA not supported"},
+		{"REINDEX", OmitReindex, "REINDEX not supported"},
+		{"CREATE TRIGGER r AFTER INSERT ON t BEGIN SELECT 1; END", OmitTrigger, "CREATE TRIGGER not supported"},
+		{"DROP TRIGGER r", OmitTrigger, "DROP TRIGGER not supported"},
+		{"VACUUM", OmitVacuum, "VACUUM not supported"},
+		{"CREATE VIRTUAL TABLE v USING m", OmitVirtualTable, "CREATE VIRTUAL TABLE not supported"},
+		{"SELECT sum(a) OVER () FROM t", OmitWindowFunc, "window functions not supported"},
+		{"SELECT count(*) FILTER (WHERE a) FROM t", OmitWindowFunc, "window functions not supported"},
+	}
+	for _, tc := range aTest {
+		_, err := ParseSQL(tc.zSql, &Options{Omit: tc.mOmit})
+		if pErr, ok := err.(*Error); !ok || pErr.Msg != tc.zErr {
+			t.Errorf("%s: got %v, want %q", tc.zSql, err, tc.zErr)
+		}
+	}
+
+	for _, zSql := range []string{
+		"SELECT window, over, filter FROM t",
+		"SELECT analyze, vacuum, reindex FROM pragma",
+		"CREATE TABLE t(a)",
+	} {
+		if _, err := ParseSQL(zSql, &Options{Omit: ^Feature(0)}); err != nil {
+			t.Errorf("%s: %v", zSql, err)
+		}
+	}
+}
+
+/*
+** ORDER BY and LIMIT on UPDATE and DELETE are syntax errors unless
+** Options.UpdateDeleteLimit is set, in which case they are recorded in
+** the parse tree.
+ */
+func TestUpdateDeleteLimit(t *testing.T) {
+	aTest := []struct {
+		zSql string
+		zErr string
+	}{
+		{"DELETE FROM t WHERE a > 1 ORDER BY a LIMIT 10", `syntax error near "ORDER BY"`},
+		{"DELETE FROM t LIMIT 10 OFFSET 2", `syntax error near "LIMIT"`},
+		{"UPDATE t SET a = 1 ORDER BY b LIMIT 1", `syntax error near "ORDER BY"`},
+		{"UPDATE t SET a = 1 LIMIT 1", `syntax error near "LIMIT"`},
+	}
+	for _, tc := range aTest {
+		_, err := ParseSQL(tc.zSql, nil)
+		if pErr, ok := err.(*Error); !ok || pErr.Msg != tc.zErr {
+			t.Errorf("%s: got %v, want %q", tc.zSql, err, tc.zErr)
+		}
+		aStmt, err := ParseSQL(tc.zSql, &Options{UpdateDeleteLimit: true})
+		if err != nil {
+			t.Errorf("%s: %v", tc.zSql, err)
+			continue
+		}
+		var pLimit *Expr
+		if p := aStmt[0].pDelete; p != nil {
+			pLimit = p.pLimit
+		} else if p := aStmt[0].pUpdate; p != nil {
+			pLimit = p.pLimit
+		}
+		if pLimit == nil || pLimit.op != TK_LIMIT {
+			t.Errorf("%s: LIMIT is not recorded", tc.zSql)
+		}
+	}
+}
+
+/*
+** Constructs newer than Options.TargetVersion must be reported by
+** Stmt.VersionIssues in the order they appear, with their byte spans.
+** None are reported for a target version that has them all.
+ */
+func TestVersionIssue(t *testing.T) {
+	aTest := []struct {
+		zSql   string
+		aIssue []VersionIssue
+	}{
+		{"CREATE TABLE t(a INT) STRICT", []VersionIssue{{"STRICT", "3.37.0", 22, 6}}},
+		{"CREATE TABLE t(a, b AS (a))", []VersionIssue{{"generated columns", "3.31.0", 20, 6}}},
+		{"SELECT a FROM t ORDER BY a NULLS FIRST", []VersionIssue{{"NULLS FIRST", "3.30.0", 27, 11}}},
+		{"DELETE FROM t RETURNING *", []VersionIssue{{"RETURNING", "3.35.0", 14, 9}}},
+		{"UPDATE t SET a = 1 FROM u", []VersionIssue{{"UPDATE FROM", "3.33.0", 19, 4}}},
+		{"INSERT INTO t VALUES(1) ON CONFLICT DO NOTHING", []VersionIssue{{"UPSERT", "3.24.0", 24, 11}}},
+		{"SELECT a IS NOT DISTINCT FROM b, a -> '$.x'", []VersionIssue{
+			{"IS NOT DISTINCT FROM", "3.39.0", 9, 20},
+			{"->", "3.38.0", 35, 2},
+		}},
+		{"ALTER TABLE t RENAME COLUMN a TO b", []VersionIssue{{"RENAME COLUMN", "3.25.0", 14, 20}}},
+		{"WITH c AS MATERIALIZED (SELECT 1) SELECT * FROM c", []VersionIssue{{"MATERIALIZED", "3.35.0", 7, 15}}},
+		{"SELECT sum(a) FILTER (WHERE a > 0) OVER w FROM t WINDOW w AS ()", []VersionIssue{
+			{"FILTER", "3.30.0", 14, 20},
+			{"window functions", "3.25.0", 35, 6},
+			{"window functions", "3.25.0", 49, 6},
+		}},
+		{"SELECT * FROM a RIGHT JOIN b ON 1", []VersionIssue{{"RIGHT JOIN", "3.39.0", 16, 5}}},
+		{"SELECT a FROM t WHERE b IN (1, 2)", nil},
+	}
+	for _, tc := range aTest {
+		aStmt, err := ParseSQL(tc.zSql, &Options{TargetVersion: "3.20.0"})
+		if err != nil {
+			t.Errorf("%s: %v", tc.zSql, err)
+			continue
+		}
+		if aIssue := aStmt[0].VersionIssues(); !reflect.DeepEqual(aIssue, tc.aIssue) {
+			t.Errorf("%s:\n got %+v\nwant %+v", tc.zSql, aIssue, tc.aIssue)
+		}
+		aStmt, err = ParseSQL(tc.zSql, &Options{TargetVersion: "3.39.4"})
+		if err != nil || len(aStmt[0].VersionIssues()) != 0 {
+			t.Errorf("%s: issues reported for 3.39.4", tc.zSql)
+		}
+	}
+
+	for _, zVersion := range []string{"x", "3", "3.1000.0", "3.35.x"} {
+		if _, err := ParseSQL("SELECT 1", &Options{TargetVersion: zVersion}); err == nil {
+			t.Errorf("TargetVersion %q accepted", zVersion)
+		}
+	}
+}
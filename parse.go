@@ -1,5 +1,9 @@
 /* This file is automatically generated by Lemon from input grammar
-** source file "parse.y". */
+** source file "parse.y" with the following
+** preprocessor symbols defined:
+**
+**    -DSQLITE_UDL_CAPABLE_PARSER
+*/
 /*
 ** 2001-09-15
 **
@@ -94,28 +98,28 @@ func disableLookaside(pParse *Parse){
   // DisableLookaside;
 }
 
-// #if !defined(SQLITE_ENABLE_UPDATE_DELETE_LIMIT) \
-//  && defined(SQLITE_UDL_CAPABLE_PARSER)
-// /*
-// ** Issue an error message if an ORDER BY or LIMIT clause occurs on an
-// ** UPDATE or DELETE statement.
-// */
+/*
+** Issue an error message if an ORDER BY or LIMIT clause occurs on an
+** UPDATE or DELETE statement.  This is the behavior of SQLite builds
+** without SQLITE_ENABLE_UPDATE_DELETE_LIMIT.  The Go port decides at
+** run-time, based on Options.UpdateDeleteLimit, so the grammar is always
+** built with SQLITE_UDL_CAPABLE_PARSER.
+*/
 func updateDeleteLimitError(
   pParse *Parse,
   pOrderBy *ExprList,
   pLimit *Expr,
 ){
-  // if( pOrderBy ){
-  //   sqlite3ErrorMsg(pParse, "syntax error near \"ORDER BY\"");
-  // }else{
-  //   sqlite3ErrorMsg(pParse, "syntax error near \"LIMIT\"");
-  // }
-  // sqlite3ExprListDelete(pParse.db, pOrderBy);
-  // sqlite3ExprDelete(pParse.db, pLimit);
-}
-// #endif /* SQLITE_ENABLE_UPDATE_DELETE_LIMIT */
+  if( pOrderBy!=nil ){
+    sqlite3ErrorMsg(pParse, "syntax error near \"ORDER BY\"");
+  }else{
+    sqlite3ErrorMsg(pParse, "syntax error near \"LIMIT\"");
+  }
+  sqlite3ExprListDelete(pParse.db, pOrderBy);
+  sqlite3ExprDelete(pParse.db, pLimit);
+}
 
-//line 519 "parse.y"
+//line 526 "parse.y"
 
   /*
   ** For a compound SELECT statement, make sure p->pPrior->pNext==p for
@@ -123,31 +127,32 @@ func updateDeleteLimitError(
   ** SQLITE_LIMIT_COMPOUND_SELECT.
   */
   func parserDoubleLinkSelect(pParse *Parse, p *Select){
-    // assert( p!=0 );
-    // if( p->pPrior ){
-    //   Select *pNext = 0, *pLoop = p;
-    //   int mxSelect, cnt = 1;
-    //   while(1){
-    //     pLoop->pNext = pNext;
-    //     pLoop->selFlags |= SF_Compound;
-    //     pNext = pLoop;
-    //     pLoop = pLoop->pPrior;
-    //     if( pLoop==0 ) break;
-    //     cnt++;        
-    //     if( pLoop->pOrderBy || pLoop->pLimit ){
-    //       sqlite3ErrorMsg(pParse,"%s clause should come after %s not before",
-    //          pLoop->pOrderBy!=0 ? "ORDER BY" : "LIMIT",
-    //          sqlite3SelectOpName(pNext->op));
-    //       break;
-    //     }
-    //   }
-    //   if( (p->selFlags & SF_MultiValue)==0 && 
-    //     (mxSelect = pParse.db->aLimit[SQLITE_LIMIT_COMPOUND_SELECT])>0 &&
-    //     cnt>mxSelect
-    //   ){
-    //     sqlite3ErrorMsg(pParse, "too many terms in compound SELECT");
-    //   }
-    // }
+    assert( p!=nil, "p!=nil" );
+    if( p.pPrior!=nil ){
+      var pNext *Select
+      pLoop := p
+      var mxSelect int
+      cnt := 1
+      for {
+        pLoop.pNext = pNext;
+        pLoop.selFlags |= SF_Compound;
+        pNext = pLoop;
+        pLoop = pLoop.pPrior;
+        if( pLoop==nil ) { break; }
+        cnt++;
+        if( pLoop.pOrderBy!=nil || pLoop.pLimit!=nil ){
+          zClause := "LIMIT"
+          if( pLoop.pOrderBy!=nil ) { zClause = "ORDER BY"; }
+          sqlite3ErrorMsg(pParse,"%s clause should come after %s not before",
+             zClause, sqlite3SelectOpName(int(pNext.op)));
+          break;
+        }
+      }
+      mxSelect = pParse.db.aLimit[SQLITE_LIMIT_COMPOUND_SELECT]
+      if( (p.selFlags & SF_MultiValue)==0 && mxSelect>0 && cnt>mxSelect ){
+        sqlite3ErrorMsg(pParse, "too many terms in compound SELECT");
+      }
+    }
   }
 
   /* Attach a With object describing the WITH clause to a Select
@@ -156,63 +161,73 @@ func updateDeleteLimitError(
   func attachWithToSelect(pParse *Parse, pSelect *Select, pWith *With) *Select{
     if pSelect != nil {
       pSelect.pWith = pWith;
-      // parserDoubleLinkSelect(pParse, pSelect);
+      parserDoubleLinkSelect(pParse, pSelect);
     }else{
       // sqlite3WithDelete(pParse.db, pWith);
     }
     return pSelect;
   }
-//line 1061 "parse.y"
+//line 1083 "parse.y"
 
 
   /* Construct a new Expr object from a single token */
   func tokenExpr(pParse *Parse, op int, t Token) *Expr{
-    // Expr *p = sqlite3DbMallocRawNN(pParse.db, sizeof(Expr)+t.n+1);
-    // if( p ){
-    //   /* memset(p, 0, sizeof(Expr)); */
-    //   p->op = (u8)op;
-    //   p->affExpr = 0;
-    //   p->flags = EP_Leaf;
-    //   ExprClearVVAProperties(p);
-    //   /* p->iAgg = -1; // Not required */
-    //   p->pLeft = p->pRight = 0;
-    //   p->pAggInfo = 0;
-    //   memset(&p->x, 0, sizeof(p->x));
-    //   memset(&p->y, 0, sizeof(p->y));
-    //   p->op2 = 0;
-    //   p->iTable = 0;
-    //   p->iColumn = 0;
-    //   p->u.zToken = (char*)&p[1];
-    //   memcpy(p->u.zToken, t.z, t.n);
-    //   p->u.zToken[t.n] = 0;
-    //   p->w.iOfst = (int)(t.z - pParse->zTail);
-    //   if( sqlite3Isquote(p->u.zToken[0]) ){
-    //     sqlite3DequoteExpr(p);
-    //   }
+    p := &Expr{}
+    p.op = uint8(op);
+    p.affExpr = 0;
+    p.flags = EP_Leaf;
+    /* p.iAgg = -1; // Not required */
+    /* The token text refers directly to the SQL input.  It is copied
+    ** only when it must be dequoted, since that rewrites it in place */
+    p.u.zToken = t.z[:t.n:t.n];
+    p.w.iOfst = len(pParse.zTail) - len(t.z);
+    p.iSpan = p.w.iOfst;
+    p.nSpan = int(t.n);
+    if( t.n>0 && sqlite3Isquote(p.u.zToken[0]) ){
+      p.u.zToken = append([]byte(nil), p.u.zToken...);
+      sqlite3DequoteExpr(p);
+    }
     // #if SQLITE_MAX_EXPR_DEPTH>0
-    //   p->nHeight = 1;
-    // #endif  
-    //   if( IN_RENAME_OBJECT ){
-    //     return (Expr*)sqlite3RenameTokenMap(pParse, (void*)p, &t);
-    //   }
-    // }
-    // return p;
-    return nil
+    p.nHeight = 1;
+    // #endif
+    return p
   }
 
-//line 1244 "parse.y"
+  /* Record the span of expression p, which is the text of the SQL input
+  ** from zStart up to the end of the last token shifted into the parser.
+  ** This is called as each rule that builds an expression is reduced,
+  ** so the span takes in any keywords or parentheses around the
+  ** operands.  Nothing is recorded if zStart is nil, which is the case
+  ** if the span of the leftmost operand is not known. */
+  func exprSpan(pParse *Parse, p *Expr, zStart []byte){
+    if( p!=nil && zStart!=nil ){
+      p.iSpan = len(pParse.zTail) - len(zStart);
+      p.nSpan = len(zStart) - len(pParse.zPrevEnd);
+    }
+  }
+
+  /* Return the SQL input from the start of the span of p, or nil if the
+  ** span is not known.  Rules whose leftmost symbol is an expression call
+  ** this before the action replaces it. */
+  func exprSpanStart(pParse *Parse, p *Expr) []byte {
+    if( p==nil || p.nSpan==0 ){
+      return nil;
+    }
+    return pParse.zTail[p.iSpan:];
+  }
+//line 1331 "parse.y"
 
   /* A routine to convert a binary TK_IS or TK_ISNOT expression into a
   ** unary TK_ISNULL or TK_NOTNULL expression. */
   func binaryToUnaryIfNull(pParse *Parse, pY *Expr, pA *Expr, op int){
-    // sqlite3 *db = pParse.db;
-    // if( pA && pY && pY->op==TK_NULL && !IN_RENAME_OBJECT ){
-    //   pA->op = (u8)op;
-    //   sqlite3ExprDelete(db, pA->pRight);
-    //   pA->pRight = 0;
-    // }
+    db := pParse.db;
+    if( pA!=nil && pY!=nil && pY.op==TK_NULL && pParse.eParseMode<PARSE_MODE_RENAME ){
+      pA.op = uint8(op);
+      sqlite3ExprDelete(db, pA.pRight);
+      pA.pRight = nil;
+    }
   }
-//line 1479 "parse.y"
+//line 1606 "parse.y"
 
   /* Add a single new term to an ExprList that is used to store a
   ** list of identifiers.  Report an error if the ID list contains
@@ -226,22 +241,21 @@ func updateDeleteLimitError(
     hasCollate int,
     sortOrder int,
   ) *ExprList {
-    // ExprList *p = sqlite3ExprListAppend(pParse, pPrior, 0);
-    // if( (hasCollate || sortOrder!=SQLITE_SO_UNDEFINED)
-    //     && pParse.db->init.busy==0
-    // ){
-    //   sqlite3ErrorMsg(pParse, "syntax error after column name \"%.*s\"",
-    //                      pIdToken->n, pIdToken->z);
-    // }
-    // sqlite3ExprListSetName(pParse, p, pIdToken, 1);
-    return nil;
+    p := sqlite3ExprListAppend(pParse, pPrior, nil);
+    if( (hasCollate!=0 || sortOrder!=SQLITE_SO_UNDEFINED) &&
+        pParse.db.init.busy==0 ){
+      sqlite3ErrorMsg(pParse, "syntax error after column name \"%T\"",
+                         pIdToken);
+    }
+    sqlite3ExprListSetName(pParse, p, pIdToken, 1);
+    return p;
   }
-//line 1962 "parse.y"
+//line 2106 "parse.y"
 
 // #if TK_SPAN>255
 // # error too many tokens in the grammar
 // #endif
-//line 279 "parse.go"
+//line 259 "parse.go"
 
 /**************** End of %include directives **********************************/
 /* These constants specify the various numeric values for terminal symbols.
@@ -525,18 +539,18 @@ const YYTRACKMAXSTACKDEPTH = false
 const NDEBUG = false
 const YYERRORSYMBOL = 0
 const YYFALLBACK = true
-const YYNSTATE = 570
-const YYNRULE = 403
-const YYNRULE_WITH_ACTION = 340
+const YYNSTATE = 580
+const YYNRULE = 405
+const YYNRULE_WITH_ACTION = 343
 const YYNTOKEN = 185
-const YY_MAX_SHIFT = 569
-const YY_MIN_SHIFTREDUCE = 829
-const YY_MAX_SHIFTREDUCE = 1231
-const YY_ERROR_ACTION = 1232
-const YY_ACCEPT_ACTION = 1233
-const YY_NO_ACTION = 1234
-const YY_MIN_REDUCE = 1235
-const YY_MAX_REDUCE = 1637
+const YY_MAX_SHIFT = 579
+const YY_MIN_SHIFTREDUCE = 839
+const YY_MAX_SHIFTREDUCE = 1243
+const YY_ERROR_ACTION = 1244
+const YY_ACCEPT_ACTION = 1245
+const YY_NO_ACTION = 1246
+const YY_MIN_REDUCE = 1247
+const YY_MAX_REDUCE = 1651
 
 /************* End control #defines *******************************************/
 
@@ -596,216 +610,220 @@ const YY_MAX_REDUCE = 1637
 **  yy_default[]       Default action for each state.
 **
 *********** Begin parsing tables **********************************************/
-const YY_ACTTAB_COUNT = 2064
+const YY_ACTTAB_COUNT = 2101
 
 var yy_action = []YYACTIONTYPE{
-	/* 0 */ 562, 204, 562, 116, 112, 225, 562, 116, 112, 225,
-	/* 10 */ 562, 1306, 373, 1285, 404, 556, 556, 556, 562, 405,
-	/* 20 */ 374, 1306, 1268, 41, 41, 41, 41, 204, 1516, 71,
-	/* 30 */ 71, 965, 415, 41, 41, 487, 299, 275, 299, 966,
-	/* 40 */ 393, 71, 71, 123, 124, 114, 1209, 1209, 1042, 1045,
-	/* 50 */ 1034, 1034, 121, 121, 122, 122, 122, 122, 472, 405,
-	/* 60 */ 1233, 1, 1, 569, 2, 1237, 544, 116, 112, 225,
-	/* 70 */ 313, 476, 142, 476, 520, 116, 112, 225, 525, 1319,
-	/* 80 */ 413, 519, 138, 123, 124, 114, 1209, 1209, 1042, 1045,
-	/* 90 */ 1034, 1034, 121, 121, 122, 122, 122, 122, 116, 112,
-	/* 100 */ 225, 323, 120, 120, 120, 120, 119, 119, 118, 118,
-	/* 110 */ 118, 117, 113, 440, 280, 280, 280, 280, 438, 438,
-	/* 120 */ 438, 1557, 372, 1559, 1184, 371, 1155, 559, 1155, 559,
-	/* 130 */ 405, 1557, 533, 255, 222, 440, 99, 141, 445, 312,
-	/* 140 */ 553, 236, 120, 120, 120, 120, 119, 119, 118, 118,
-	/* 150 */ 118, 117, 113, 440, 123, 124, 114, 1209, 1209, 1042,
-	/* 160 */ 1045, 1034, 1034, 121, 121, 122, 122, 122, 122, 138,
-	/* 170 */ 290, 1184, 335, 444, 118, 118, 118, 117, 113, 440,
-	/* 180 */ 125, 1184, 1185, 1186, 144, 437, 436, 562, 117, 113,
-	/* 190 */ 440, 122, 122, 122, 122, 115, 120, 120, 120, 120,
-	/* 200 */ 119, 119, 118, 118, 118, 117, 113, 440, 450, 110,
-	/* 210 */ 13, 13, 542, 120, 120, 120, 120, 119, 119, 118,
-	/* 220 */ 118, 118, 117, 113, 440, 418, 312, 553, 1184, 1185,
-	/* 230 */ 1186, 145, 1216, 405, 1216, 122, 122, 122, 122, 120,
-	/* 240 */ 120, 120, 120, 119, 119, 118, 118, 118, 117, 113,
-	/* 250 */ 440, 461, 338, 1031, 1031, 1043, 1046, 123, 124, 114,
-	/* 260 */ 1209, 1209, 1042, 1045, 1034, 1034, 121, 121, 122, 122,
-	/* 270 */ 122, 122, 1271, 518, 218, 1184, 562, 405, 220, 510,
-	/* 280 */ 171, 80, 81, 120, 120, 120, 120, 119, 119, 118,
-	/* 290 */ 118, 118, 117, 113, 440, 1001, 16, 16, 1184, 55,
-	/* 300 */ 55, 123, 124, 114, 1209, 1209, 1042, 1045, 1034, 1034,
-	/* 310 */ 121, 121, 122, 122, 122, 122, 120, 120, 120, 120,
-	/* 320 */ 119, 119, 118, 118, 118, 117, 113, 440, 1035, 542,
-	/* 330 */ 1184, 369, 1184, 1185, 1186, 248, 1426, 395, 500, 497,
-	/* 340 */ 496, 108, 554, 560, 4, 920, 920, 429, 495, 336,
-	/* 350 */ 456, 324, 356, 390, 1229, 1184, 1185, 1186, 557, 562,
-	/* 360 */ 120, 120, 120, 120, 119, 119, 118, 118, 118, 117,
-	/* 370 */ 113, 440, 280, 280, 365, 1570, 1597, 437, 436, 150,
-	/* 380 */ 405, 441, 71, 71, 1278, 559, 1213, 1184, 1185, 1186,
-	/* 390 */ 83, 1215, 267, 551, 539, 511, 1551, 562, 96, 1214,
-	/* 400 */ 6, 1270, 468, 138, 123, 124, 114, 1209, 1209, 1042,
-	/* 410 */ 1045, 1034, 1034, 121, 121, 122, 122, 122, 122, 544,
-	/* 420 */ 13, 13, 1021, 503, 1216, 1184, 1216, 543, 106, 106,
-	/* 430 */ 218, 562, 1230, 171, 562, 423, 107, 193, 441, 564,
-	/* 440 */ 563, 426, 1542, 1011, 321, 545, 1184, 266, 283, 364,
-	/* 450 */ 506, 359, 505, 253, 71, 71, 539, 71, 71, 355,
-	/* 460 */ 312, 553, 1603, 120, 120, 120, 120, 119, 119, 118,
-	/* 470 */ 118, 118, 117, 113, 440, 1011, 1011, 1013, 1014, 27,
-	/* 480 */ 280, 280, 1184, 1185, 1186, 1150, 562, 1602, 405, 895,
-	/* 490 */ 186, 544, 352, 559, 544, 931, 529, 513, 1150, 512,
-	/* 500 */ 409, 1150, 546, 1184, 1185, 1186, 562, 540, 1544, 51,
-	/* 510 */ 51, 210, 123, 124, 114, 1209, 1209, 1042, 1045, 1034,
-	/* 520 */ 1034, 121, 121, 122, 122, 122, 122, 1184, 470, 56,
-	/* 530 */ 56, 405, 280, 280, 1480, 501, 119, 119, 118, 118,
-	/* 540 */ 118, 117, 113, 440, 1001, 559, 514, 213, 537, 1551,
-	/* 550 */ 312, 553, 138, 6, 528, 123, 124, 114, 1209, 1209,
-	/* 560 */ 1042, 1045, 1034, 1034, 121, 121, 122, 122, 122, 122,
-	/* 570 */ 1545, 120, 120, 120, 120, 119, 119, 118, 118, 118,
-	/* 580 */ 117, 113, 440, 481, 1184, 1185, 1186, 478, 277, 1259,
-	/* 590 */ 951, 248, 1184, 369, 500, 497, 496, 1184, 336, 565,
-	/* 600 */ 1184, 565, 405, 288, 495, 951, 870, 187, 476, 312,
-	/* 610 */ 553, 380, 286, 376, 120, 120, 120, 120, 119, 119,
-	/* 620 */ 118, 118, 118, 117, 113, 440, 123, 124, 114, 1209,
-	/* 630 */ 1209, 1042, 1045, 1034, 1034, 121, 121, 122, 122, 122,
-	/* 640 */ 122, 405, 390, 1128, 1184, 863, 98, 280, 280, 1184,
-	/* 650 */ 1185, 1186, 369, 1085, 1184, 1185, 1186, 1184, 1185, 1186,
-	/* 660 */ 559, 451, 32, 369, 229, 123, 124, 114, 1209, 1209,
-	/* 670 */ 1042, 1045, 1034, 1034, 121, 121, 122, 122, 122, 122,
-	/* 680 */ 1425, 953, 562, 224, 952, 120, 120, 120, 120, 119,
-	/* 690 */ 119, 118, 118, 118, 117, 113, 440, 1150, 224, 1184,
-	/* 700 */ 153, 1184, 1185, 1186, 1543, 13, 13, 297, 951, 1224,
-	/* 710 */ 1150, 149, 405, 1150, 369, 1573, 1168, 5, 365, 1570,
-	/* 720 */ 425, 1230, 3, 951, 120, 120, 120, 120, 119, 119,
-	/* 730 */ 118, 118, 118, 117, 113, 440, 123, 124, 114, 1209,
-	/* 740 */ 1209, 1042, 1045, 1034, 1034, 121, 121, 122, 122, 122,
-	/* 750 */ 122, 405, 204, 561, 1184, 1022, 1184, 1185, 1186, 1184,
-	/* 760 */ 384, 846, 151, 1542, 282, 398, 1090, 1090, 484, 562,
-	/* 770 */ 461, 338, 1311, 1311, 1542, 123, 124, 114, 1209, 1209,
-	/* 780 */ 1042, 1045, 1034, 1034, 121, 121, 122, 122, 122, 122,
-	/* 790 */ 127, 562, 13, 13, 370, 120, 120, 120, 120, 119,
-	/* 800 */ 119, 118, 118, 118, 117, 113, 440, 298, 562, 449,
-	/* 810 */ 524, 1184, 1185, 1186, 13, 13, 1184, 1185, 1186, 1289,
-	/* 820 */ 459, 1259, 405, 1309, 1309, 1542, 1006, 449, 448, 196,
-	/* 830 */ 295, 71, 71, 1257, 120, 120, 120, 120, 119, 119,
-	/* 840 */ 118, 118, 118, 117, 113, 440, 123, 124, 114, 1209,
-	/* 850 */ 1209, 1042, 1045, 1034, 1034, 121, 121, 122, 122, 122,
-	/* 860 */ 122, 405, 223, 1065, 1150, 280, 280, 415, 308, 274,
-	/* 870 */ 274, 281, 281, 1411, 402, 401, 378, 1150, 559, 562,
-	/* 880 */ 1150, 1188, 559, 1590, 559, 123, 124, 114, 1209, 1209,
-	/* 890 */ 1042, 1045, 1034, 1034, 121, 121, 122, 122, 122, 122,
-	/* 900 */ 449, 1472, 13, 13, 1526, 120, 120, 120, 120, 119,
-	/* 910 */ 119, 118, 118, 118, 117, 113, 440, 197, 562, 350,
-	/* 920 */ 1576, 569, 2, 1237, 834, 835, 836, 1552, 313, 1204,
-	/* 930 */ 142, 6, 405, 251, 250, 249, 202, 1319, 9, 1188,
-	/* 940 */ 258, 71, 71, 420, 120, 120, 120, 120, 119, 119,
-	/* 950 */ 118, 118, 118, 117, 113, 440, 123, 124, 114, 1209,
-	/* 960 */ 1209, 1042, 1045, 1034, 1034, 121, 121, 122, 122, 122,
-	/* 970 */ 122, 562, 280, 280, 562, 1205, 405, 568, 309, 1237,
-	/* 980 */ 345, 1288, 348, 415, 313, 559, 142, 487, 521, 1633,
-	/* 990 */ 391, 367, 487, 1319, 70, 70, 1287, 71, 71, 236,
-	/* 1000 */ 1317, 101, 114, 1209, 1209, 1042, 1045, 1034, 1034, 121,
-	/* 1010 */ 121, 122, 122, 122, 122, 120, 120, 120, 120, 119,
-	/* 1020 */ 119, 118, 118, 118, 117, 113, 440, 1106, 280, 280,
-	/* 1030 */ 424, 444, 1515, 1205, 435, 280, 280, 1479, 1344, 307,
-	/* 1040 */ 470, 559, 1107, 965, 487, 487, 213, 1255, 559, 1528,
-	/* 1050 */ 562, 966, 203, 562, 1021, 236, 379, 1108, 515, 120,
-	/* 1060 */ 120, 120, 120, 119, 119, 118, 118, 118, 117, 113,
-	/* 1070 */ 440, 1012, 104, 71, 71, 1011, 13, 13, 906, 562,
-	/* 1080 */ 1485, 562, 280, 280, 95, 522, 487, 444, 907, 1318,
-	/* 1090 */ 1314, 541, 405, 280, 280, 559, 147, 205, 1485, 1487,
-	/* 1100 */ 258, 446, 15, 15, 43, 43, 559, 1011, 1011, 1013,
-	/* 1110 */ 439, 328, 405, 523, 12, 291, 123, 124, 114, 1209,
-	/* 1120 */ 1209, 1042, 1045, 1034, 1034, 121, 121, 122, 122, 122,
-	/* 1130 */ 122, 343, 405, 858, 1524, 1205, 123, 124, 114, 1209,
-	/* 1140 */ 1209, 1042, 1045, 1034, 1034, 121, 121, 122, 122, 122,
-	/* 1150 */ 122, 1129, 1631, 470, 1631, 367, 123, 111, 114, 1209,
-	/* 1160 */ 1209, 1042, 1045, 1034, 1034, 121, 121, 122, 122, 122,
-	/* 1170 */ 122, 1485, 325, 470, 327, 120, 120, 120, 120, 119,
-	/* 1180 */ 119, 118, 118, 118, 117, 113, 440, 199, 1411, 562,
-	/* 1190 */ 1286, 858, 460, 1205, 432, 120, 120, 120, 120, 119,
-	/* 1200 */ 119, 118, 118, 118, 117, 113, 440, 547, 1129, 1632,
-	/* 1210 */ 535, 1632, 57, 57, 886, 120, 120, 120, 120, 119,
-	/* 1220 */ 119, 118, 118, 118, 117, 113, 440, 562, 294, 534,
-	/* 1230 */ 1127, 1411, 1549, 1550, 1323, 405, 6, 6, 1161, 1260,
-	/* 1240 */ 411, 316, 280, 280, 1411, 504, 559, 521, 296, 453,
-	/* 1250 */ 44, 44, 562, 887, 12, 559, 326, 474, 421, 403,
-	/* 1260 */ 124, 114, 1209, 1209, 1042, 1045, 1034, 1034, 121, 121,
-	/* 1270 */ 122, 122, 122, 122, 562, 58, 58, 284, 1184, 1411,
-	/* 1280 */ 492, 454, 388, 388, 387, 269, 385, 1127, 1548, 843,
-	/* 1290 */ 1161, 403, 6, 562, 317, 1150, 466, 59, 59, 1547,
-	/* 1300 */ 1106, 422, 230, 6, 319, 252, 536, 252, 1150, 427,
-	/* 1310 */ 562, 1150, 318, 17, 483, 1107, 60, 60, 120, 120,
-	/* 1320 */ 120, 120, 119, 119, 118, 118, 118, 117, 113, 440,
-	/* 1330 */ 1108, 212, 477, 61, 61, 1184, 1185, 1186, 108, 554,
-	/* 1340 */ 320, 4, 232, 452, 522, 562, 233, 452, 562, 433,
-	/* 1350 */ 164, 550, 416, 137, 475, 557, 562, 289, 562, 1087,
-	/* 1360 */ 562, 289, 562, 1087, 527, 562, 866, 8, 62, 62,
-	/* 1370 */ 231, 45, 45, 562, 410, 562, 410, 562, 441, 46,
-	/* 1380 */ 46, 47, 47, 49, 49, 50, 50, 195, 63, 63,
-	/* 1390 */ 551, 562, 355, 562, 98, 482, 64, 64, 65, 65,
-	/* 1400 */ 14, 14, 555, 411, 531, 406, 562, 1021, 562, 530,
-	/* 1410 */ 312, 553, 312, 553, 66, 66, 129, 129, 562, 1021,
-	/* 1420 */ 562, 508, 926, 866, 1012, 106, 106, 925, 1011, 67,
-	/* 1430 */ 67, 52, 52, 107, 447, 441, 564, 563, 412, 173,
-	/* 1440 */ 1011, 68, 68, 69, 69, 562, 463, 562, 926, 467,
-	/* 1450 */ 1356, 279, 222, 925, 311, 1355, 403, 562, 455, 403,
-	/* 1460 */ 1011, 1011, 1013, 235, 403, 84, 209, 1342, 53, 53,
-	/* 1470 */ 159, 159, 1011, 1011, 1013, 1014, 27, 1575, 1172, 443,
-	/* 1480 */ 160, 160, 284, 95, 105, 1531, 103, 388, 388, 387,
-	/* 1490 */ 269, 385, 562, 873, 843, 877, 562, 108, 554, 462,
-	/* 1500 */ 4, 562, 148, 30, 38, 562, 1124, 230, 392, 319,
-	/* 1510 */ 108, 554, 523, 4, 557, 76, 76, 318, 562, 54,
-	/* 1520 */ 54, 562, 333, 464, 72, 72, 329, 557, 130, 130,
-	/* 1530 */ 562, 285, 1504, 562, 31, 1503, 562, 441, 334, 479,
-	/* 1540 */ 98, 73, 73, 340, 157, 157, 292, 232, 1072, 551,
-	/* 1550 */ 441, 873, 1352, 131, 131, 164, 132, 132, 137, 128,
-	/* 1560 */ 128, 1564, 551, 531, 562, 315, 562, 344, 532, 1003,
-	/* 1570 */ 469, 257, 257, 885, 884, 231, 531, 562, 1021, 562,
-	/* 1580 */ 471, 530, 257, 363, 106, 106, 517, 158, 158, 152,
-	/* 1590 */ 152, 1021, 107, 362, 441, 564, 563, 106, 106, 1011,
-	/* 1600 */ 136, 136, 135, 135, 562, 107, 1072, 441, 564, 563,
-	/* 1610 */ 406, 347, 1011, 562, 349, 312, 553, 562, 339, 562,
-	/* 1620 */ 98, 493, 353, 254, 98, 892, 893, 133, 133, 351,
-	/* 1630 */ 1302, 1011, 1011, 1013, 1014, 27, 134, 134, 1015, 447,
-	/* 1640 */ 75, 75, 77, 77, 1011, 1011, 1013, 1014, 27, 1172,
-	/* 1650 */ 443, 562, 358, 284, 108, 554, 368, 4, 388, 388,
-	/* 1660 */ 387, 269, 385, 562, 1133, 843, 562, 1068, 956, 254,
-	/* 1670 */ 257, 557, 968, 969, 74, 74, 549, 923, 230, 110,
-	/* 1680 */ 319, 108, 554, 1084, 4, 1084, 42, 42, 318, 48,
-	/* 1690 */ 48, 1083, 1365, 1083, 441, 856, 1015, 146, 557, 924,
-	/* 1700 */ 1410, 110, 1338, 1350, 548, 1416, 551, 1267, 207, 1258,
-	/* 1710 */ 1246, 1245, 1247, 1583, 11, 488, 272, 215, 232, 1335,
-	/* 1720 */ 304, 441, 305, 306, 389, 228, 164, 1397, 1392, 137,
-	/* 1730 */ 287, 331, 332, 551, 293, 1021, 1385, 337, 473, 200,
-	/* 1740 */ 361, 106, 106, 930, 498, 1402, 231, 1401, 1285, 107,
-	/* 1750 */ 396, 441, 564, 563, 219, 1476, 1011, 1347, 1475, 1348,
-	/* 1760 */ 1346, 1345, 1021, 1224, 552, 1586, 261, 1221, 106, 106,
-	/* 1770 */ 1523, 201, 383, 1521, 214, 414, 107, 83, 441, 564,
-	/* 1780 */ 563, 406, 211, 1011, 175, 1398, 312, 553, 1011, 1011,
-	/* 1790 */ 1013, 1014, 27, 226, 184, 169, 100, 554, 79, 4,
-	/* 1800 */ 82, 457, 35, 179, 458, 177, 491, 238, 96, 1481,
-	/* 1810 */ 447, 180, 1404, 557, 181, 1011, 1011, 1013, 1014, 27,
-	/* 1820 */ 182, 1403, 394, 36, 465, 1406, 397, 188, 1470, 480,
-	/* 1830 */ 242, 89, 1492, 486, 342, 244, 441, 273, 192, 346,
-	/* 1840 */ 489, 245, 399, 1248, 428, 246, 507, 1296, 551, 91,
-	/* 1850 */ 877, 1305, 1304, 220, 1601, 1295, 1303, 430, 431, 516,
-	/* 1860 */ 1569, 259, 400, 302, 1600, 1275, 303, 260, 360, 1274,
-	/* 1870 */ 1273, 1599, 366, 1555, 434, 1554, 1370, 1021, 1369, 542,
-	/* 1880 */ 126, 10, 1456, 106, 106, 377, 102, 97, 310, 526,
-	/* 1890 */ 34, 107, 566, 441, 564, 563, 1178, 271, 1011, 268,
-	/* 1900 */ 270, 567, 1243, 1238, 206, 1328, 375, 381, 1327, 382,
-	/* 1910 */ 407, 161, 174, 408, 1508, 1509, 143, 300, 830, 162,
-	/* 1920 */ 1507, 1506, 163, 442, 208, 314, 227, 216, 217, 78,
-	/* 1930 */ 1011, 1011, 1013, 1014, 27, 140, 1082, 322, 1080, 165,
-	/* 1940 */ 176, 1204, 234, 178, 909, 330, 237, 1096, 183, 166,
-	/* 1950 */ 167, 417, 85, 86, 419, 185, 87, 88, 168, 1099,
-	/* 1960 */ 239, 1095, 240, 154, 18, 241, 341, 1218, 257, 1088,
-	/* 1970 */ 243, 485, 190, 189, 37, 845, 490, 362, 247, 494,
-	/* 1980 */ 357, 191, 875, 90, 19, 502, 354, 20, 499, 92,
-	/* 1990 */ 170, 155, 888, 93, 301, 509, 94, 1166, 156, 1048,
-	/* 2000 */ 1135, 39, 221, 1134, 276, 278, 256, 194, 110, 960,
-	/* 2010 */ 954, 1156, 21, 1152, 22, 1160, 1140, 1154, 23, 33,
-	/* 2020 */ 24, 1159, 25, 538, 26, 198, 98, 1063, 1049, 1047,
-	/* 2030 */ 1051, 7, 1105, 262, 1104, 263, 1052, 28, 40, 558,
-	/* 2040 */ 1016, 857, 109, 29, 919, 386, 139, 172, 264, 265,
-	/* 2050 */ 1174, 1592, 1173, 1234, 1234, 1234, 1234, 1234, 1234, 1234,
-	/* 2060 */ 1234, 1234, 1234, 1591,
+	/* 0 */ 572, 208, 572, 118, 115, 229, 572, 118, 115, 229,
+	/* 10 */ 572, 1320, 381, 1299, 412, 566, 566, 566, 572, 413,
+	/* 20 */ 382, 1320, 1280, 41, 41, 41, 41, 208, 1531, 71,
+	/* 30 */ 71, 977, 423, 41, 41, 495, 303, 279, 303, 978,
+	/* 40 */ 401, 71, 71, 125, 126, 80, 1220, 1220, 1056, 1059,
+	/* 50 */ 1046, 1046, 123, 123, 124, 124, 124, 124, 480, 413,
+	/* 60 */ 1245, 1, 1, 579, 2, 1249, 554, 118, 115, 229,
+	/* 70 */ 317, 484, 146, 484, 528, 118, 115, 229, 533, 1333,
+	/* 80 */ 421, 527, 142, 125, 126, 80, 1220, 1220, 1056, 1059,
+	/* 90 */ 1046, 1046, 123, 123, 124, 124, 124, 124, 118, 115,
+	/* 100 */ 229, 327, 122, 122, 122, 122, 121, 121, 120, 120,
+	/* 110 */ 120, 119, 116, 448, 284, 284, 284, 284, 446, 446,
+	/* 120 */ 446, 1572, 380, 1574, 1197, 379, 1168, 569, 1168, 569,
+	/* 130 */ 413, 1572, 541, 259, 226, 448, 101, 145, 453, 316,
+	/* 140 */ 563, 240, 122, 122, 122, 122, 121, 121, 120, 120,
+	/* 150 */ 120, 119, 116, 448, 125, 126, 80, 1220, 1220, 1056,
+	/* 160 */ 1059, 1046, 1046, 123, 123, 124, 124, 124, 124, 142,
+	/* 170 */ 294, 1197, 343, 452, 120, 120, 120, 119, 116, 448,
+	/* 180 */ 127, 1197, 1198, 1199, 148, 445, 444, 572, 119, 116,
+	/* 190 */ 448, 124, 124, 124, 124, 117, 122, 122, 122, 122,
+	/* 200 */ 121, 121, 120, 120, 120, 119, 116, 448, 458, 113,
+	/* 210 */ 13, 13, 550, 122, 122, 122, 122, 121, 121, 120,
+	/* 220 */ 120, 120, 119, 116, 448, 426, 316, 563, 1197, 1198,
+	/* 230 */ 1199, 149, 1228, 413, 1228, 124, 124, 124, 124, 122,
+	/* 240 */ 122, 122, 122, 121, 121, 120, 120, 120, 119, 116,
+	/* 250 */ 448, 469, 346, 1043, 1043, 1057, 1060, 125, 126, 80,
+	/* 260 */ 1220, 1220, 1056, 1059, 1046, 1046, 123, 123, 124, 124,
+	/* 270 */ 124, 124, 1283, 526, 222, 1197, 572, 413, 224, 518,
+	/* 280 */ 175, 82, 83, 122, 122, 122, 122, 121, 121, 120,
+	/* 290 */ 120, 120, 119, 116, 448, 1013, 16, 16, 1197, 133,
+	/* 300 */ 133, 125, 126, 80, 1220, 1220, 1056, 1059, 1046, 1046,
+	/* 310 */ 123, 123, 124, 124, 124, 124, 122, 122, 122, 122,
+	/* 320 */ 121, 121, 120, 120, 120, 119, 116, 448, 1047, 550,
+	/* 330 */ 1197, 377, 1197, 1198, 1199, 252, 1440, 403, 508, 505,
+	/* 340 */ 504, 111, 564, 570, 4, 932, 932, 437, 503, 344,
+	/* 350 */ 464, 330, 364, 398, 1241, 1197, 1198, 1199, 567, 572,
+	/* 360 */ 122, 122, 122, 122, 121, 121, 120, 120, 120, 119,
+	/* 370 */ 116, 448, 284, 284, 373, 1585, 1612, 445, 444, 154,
+	/* 380 */ 413, 449, 71, 71, 1290, 569, 1225, 1197, 1198, 1199,
+	/* 390 */ 85, 1227, 271, 561, 547, 519, 1566, 572, 98, 1226,
+	/* 400 */ 6, 1282, 476, 142, 125, 126, 80, 1220, 1220, 1056,
+	/* 410 */ 1059, 1046, 1046, 123, 123, 124, 124, 124, 124, 554,
+	/* 420 */ 13, 13, 1033, 511, 1228, 1197, 1228, 553, 109, 109,
+	/* 430 */ 222, 572, 1242, 175, 572, 431, 110, 197, 449, 574,
+	/* 440 */ 573, 434, 1557, 1023, 325, 555, 1197, 270, 287, 372,
+	/* 450 */ 514, 367, 513, 257, 71, 71, 547, 71, 71, 363,
+	/* 460 */ 316, 563, 1616, 122, 122, 122, 122, 121, 121, 120,
+	/* 470 */ 120, 120, 119, 116, 448, 1023, 1023, 1025, 1026, 27,
+	/* 480 */ 284, 284, 1197, 1198, 1199, 1163, 572, 1615, 413, 907,
+	/* 490 */ 190, 554, 360, 569, 554, 943, 537, 521, 1163, 520,
+	/* 500 */ 417, 1163, 556, 1197, 1198, 1199, 572, 548, 1559, 51,
+	/* 510 */ 51, 214, 125, 126, 80, 1220, 1220, 1056, 1059, 1046,
+	/* 520 */ 1046, 123, 123, 124, 124, 124, 124, 1197, 478, 135,
+	/* 530 */ 135, 413, 284, 284, 1495, 509, 121, 121, 120, 120,
+	/* 540 */ 120, 119, 116, 448, 1013, 569, 522, 217, 545, 1566,
+	/* 550 */ 316, 563, 142, 6, 536, 125, 126, 80, 1220, 1220,
+	/* 560 */ 1056, 1059, 1046, 1046, 123, 123, 124, 124, 124, 124,
+	/* 570 */ 1560, 122, 122, 122, 122, 121, 121, 120, 120, 120,
+	/* 580 */ 119, 116, 448, 489, 1197, 1198, 1199, 486, 281, 1271,
+	/* 590 */ 963, 252, 1197, 377, 508, 505, 504, 1197, 344, 575,
+	/* 600 */ 1197, 575, 413, 292, 503, 963, 880, 191, 484, 316,
+	/* 610 */ 563, 388, 290, 384, 122, 122, 122, 122, 121, 121,
+	/* 620 */ 120, 120, 120, 119, 116, 448, 125, 126, 80, 1220,
+	/* 630 */ 1220, 1056, 1059, 1046, 1046, 123, 123, 124, 124, 124,
+	/* 640 */ 124, 413, 398, 1141, 1197, 873, 100, 284, 284, 1197,
+	/* 650 */ 1198, 1199, 377, 1098, 1197, 1198, 1199, 1197, 1198, 1199,
+	/* 660 */ 569, 459, 32, 377, 233, 125, 126, 80, 1220, 1220,
+	/* 670 */ 1056, 1059, 1046, 1046, 123, 123, 124, 124, 124, 124,
+	/* 680 */ 1439, 965, 572, 228, 964, 122, 122, 122, 122, 121,
+	/* 690 */ 121, 120, 120, 120, 119, 116, 448, 1163, 228, 1197,
+	/* 700 */ 157, 1197, 1198, 1199, 1558, 13, 13, 301, 963, 1236,
+	/* 710 */ 1163, 153, 413, 1163, 377, 1588, 1181, 5, 373, 1585,
+	/* 720 */ 433, 1242, 3, 963, 122, 122, 122, 122, 121, 121,
+	/* 730 */ 120, 120, 120, 119, 116, 448, 125, 126, 80, 1220,
+	/* 740 */ 1220, 1056, 1059, 1046, 1046, 123, 123, 124, 124, 124,
+	/* 750 */ 124, 413, 208, 571, 1197, 1034, 1197, 1198, 1199, 1197,
+	/* 760 */ 392, 856, 155, 1557, 286, 406, 1103, 1103, 492, 572,
+	/* 770 */ 469, 346, 1325, 1325, 1557, 125, 126, 80, 1220, 1220,
+	/* 780 */ 1056, 1059, 1046, 1046, 123, 123, 124, 124, 124, 124,
+	/* 790 */ 129, 572, 13, 13, 378, 122, 122, 122, 122, 121,
+	/* 800 */ 121, 120, 120, 120, 119, 116, 448, 302, 572, 457,
+	/* 810 */ 532, 1197, 1198, 1199, 13, 13, 1197, 1198, 1199, 1303,
+	/* 820 */ 467, 1271, 413, 1323, 1323, 1557, 1018, 457, 456, 200,
+	/* 830 */ 299, 71, 71, 1269, 122, 122, 122, 122, 121, 121,
+	/* 840 */ 120, 120, 120, 119, 116, 448, 125, 126, 80, 1220,
+	/* 850 */ 1220, 1056, 1059, 1046, 1046, 123, 123, 124, 124, 124,
+	/* 860 */ 124, 413, 227, 1078, 1163, 284, 284, 423, 312, 278,
+	/* 870 */ 278, 285, 285, 1425, 410, 409, 386, 1163, 569, 572,
+	/* 880 */ 1163, 1201, 569, 1605, 569, 125, 126, 80, 1220, 1220,
+	/* 890 */ 1056, 1059, 1046, 1046, 123, 123, 124, 124, 124, 124,
+	/* 900 */ 457, 1487, 13, 13, 1541, 122, 122, 122, 122, 121,
+	/* 910 */ 121, 120, 120, 120, 119, 116, 448, 201, 572, 358,
+	/* 920 */ 1591, 579, 2, 1249, 844, 845, 846, 1567, 317, 1215,
+	/* 930 */ 146, 6, 413, 255, 254, 253, 206, 1333, 9, 1201,
+	/* 940 */ 262, 71, 71, 428, 122, 122, 122, 122, 121, 121,
+	/* 950 */ 120, 120, 120, 119, 116, 448, 125, 126, 80, 1220,
+	/* 960 */ 1220, 1056, 1059, 1046, 1046, 123, 123, 124, 124, 124,
+	/* 970 */ 124, 572, 284, 284, 572, 1216, 413, 578, 313, 1249,
+	/* 980 */ 353, 1302, 356, 423, 317, 569, 146, 495, 529, 1647,
+	/* 990 */ 399, 375, 495, 1333, 70, 70, 1301, 71, 71, 240,
+	/* 1000 */ 1331, 104, 80, 1220, 1220, 1056, 1059, 1046, 1046, 123,
+	/* 1010 */ 123, 124, 124, 124, 124, 122, 122, 122, 122, 121,
+	/* 1020 */ 121, 120, 120, 120, 119, 116, 448, 1119, 284, 284,
+	/* 1030 */ 432, 452, 1530, 1216, 443, 284, 284, 1494, 1358, 311,
+	/* 1040 */ 478, 569, 1120, 977, 495, 495, 217, 1267, 569, 1543,
+	/* 1050 */ 572, 978, 207, 572, 1033, 240, 387, 1121, 523, 122,
+	/* 1060 */ 122, 122, 122, 121, 121, 120, 120, 120, 119, 116,
+	/* 1070 */ 448, 1024, 107, 71, 71, 1023, 13, 13, 918, 572,
+	/* 1080 */ 1500, 572, 284, 284, 97, 530, 495, 452, 919, 1332,
+	/* 1090 */ 1328, 549, 413, 284, 284, 569, 151, 209, 1500, 1502,
+	/* 1100 */ 262, 454, 55, 55, 56, 56, 569, 1023, 1023, 1025,
+	/* 1110 */ 447, 336, 413, 531, 12, 295, 125, 126, 80, 1220,
+	/* 1120 */ 1220, 1056, 1059, 1046, 1046, 123, 123, 124, 124, 124,
+	/* 1130 */ 124, 351, 413, 868, 1539, 1216, 125, 126, 80, 1220,
+	/* 1140 */ 1220, 1056, 1059, 1046, 1046, 123, 123, 124, 124, 124,
+	/* 1150 */ 124, 1142, 1645, 478, 1645, 375, 125, 114, 80, 1220,
+	/* 1160 */ 1220, 1056, 1059, 1046, 1046, 123, 123, 124, 124, 124,
+	/* 1170 */ 124, 1500, 333, 478, 335, 122, 122, 122, 122, 121,
+	/* 1180 */ 121, 120, 120, 120, 119, 116, 448, 203, 1425, 572,
+	/* 1190 */ 1300, 868, 468, 1216, 440, 122, 122, 122, 122, 121,
+	/* 1200 */ 121, 120, 120, 120, 119, 116, 448, 557, 1142, 1646,
+	/* 1210 */ 543, 1646, 15, 15, 898, 122, 122, 122, 122, 121,
+	/* 1220 */ 121, 120, 120, 120, 119, 116, 448, 572, 298, 542,
+	/* 1230 */ 1140, 1425, 1564, 1565, 1337, 413, 6, 6, 1174, 1272,
+	/* 1240 */ 419, 320, 284, 284, 1425, 512, 569, 529, 300, 461,
+	/* 1250 */ 43, 43, 572, 899, 12, 569, 334, 482, 429, 411,
+	/* 1260 */ 126, 80, 1220, 1220, 1056, 1059, 1046, 1046, 123, 123,
+	/* 1270 */ 124, 124, 124, 124, 572, 57, 57, 288, 1197, 1425,
+	/* 1280 */ 500, 462, 396, 396, 395, 273, 393, 1140, 1563, 853,
+	/* 1290 */ 1174, 411, 6, 572, 321, 1163, 474, 44, 44, 1562,
+	/* 1300 */ 1119, 430, 234, 6, 323, 256, 544, 256, 1163, 435,
+	/* 1310 */ 572, 1163, 322, 17, 491, 1120, 58, 58, 122, 122,
+	/* 1320 */ 122, 122, 121, 121, 120, 120, 120, 119, 116, 448,
+	/* 1330 */ 1121, 216, 485, 59, 59, 1197, 1198, 1199, 111, 564,
+	/* 1340 */ 324, 4, 236, 460, 530, 572, 237, 460, 572, 441,
+	/* 1350 */ 168, 560, 424, 141, 483, 567, 572, 293, 572, 1100,
+	/* 1360 */ 572, 293, 572, 1100, 535, 572, 876, 8, 60, 60,
+	/* 1370 */ 235, 61, 61, 572, 418, 572, 418, 572, 449, 62,
+	/* 1380 */ 62, 45, 45, 46, 46, 47, 47, 199, 49, 49,
+	/* 1390 */ 561, 572, 363, 572, 100, 490, 50, 50, 63, 63,
+	/* 1400 */ 64, 64, 565, 419, 539, 414, 572, 1033, 572, 538,
+	/* 1410 */ 316, 563, 316, 563, 65, 65, 14, 14, 572, 1033,
+	/* 1420 */ 572, 516, 938, 876, 1024, 109, 109, 937, 1023, 66,
+	/* 1430 */ 66, 131, 131, 110, 455, 449, 574, 573, 420, 177,
+	/* 1440 */ 1023, 132, 132, 67, 67, 572, 471, 572, 938, 475,
+	/* 1450 */ 1370, 283, 226, 937, 315, 1369, 411, 572, 463, 411,
+	/* 1460 */ 1023, 1023, 1025, 239, 411, 86, 213, 1356, 52, 52,
+	/* 1470 */ 68, 68, 1023, 1023, 1025, 1026, 27, 1590, 1185, 451,
+	/* 1480 */ 69, 69, 288, 97, 108, 1546, 106, 396, 396, 395,
+	/* 1490 */ 273, 393, 572, 883, 853, 889, 572, 111, 564, 470,
+	/* 1500 */ 4, 572, 152, 30, 38, 572, 1137, 234, 400, 323,
+	/* 1510 */ 111, 564, 531, 4, 567, 53, 53, 322, 572, 163,
+	/* 1520 */ 163, 572, 341, 472, 164, 164, 337, 567, 76, 76,
+	/* 1530 */ 572, 289, 1519, 572, 31, 1518, 572, 449, 342, 487,
+	/* 1540 */ 100, 54, 54, 348, 72, 72, 296, 236, 1085, 561,
+	/* 1550 */ 449, 883, 1366, 134, 134, 168, 73, 73, 141, 161,
+	/* 1560 */ 161, 1579, 561, 539, 572, 319, 572, 352, 540, 1015,
+	/* 1570 */ 477, 261, 261, 897, 896, 235, 539, 572, 1033, 572,
+	/* 1580 */ 479, 538, 261, 371, 109, 109, 525, 136, 136, 130,
+	/* 1590 */ 130, 1033, 110, 370, 449, 574, 573, 109, 109, 1023,
+	/* 1600 */ 162, 162, 156, 156, 572, 110, 1085, 449, 574, 573,
+	/* 1610 */ 414, 355, 1023, 572, 357, 316, 563, 572, 347, 572,
+	/* 1620 */ 100, 501, 361, 258, 100, 904, 905, 140, 140, 359,
+	/* 1630 */ 1316, 1023, 1023, 1025, 1026, 27, 139, 139, 366, 455,
+	/* 1640 */ 137, 137, 138, 138, 1023, 1023, 1025, 1026, 27, 1185,
+	/* 1650 */ 451, 572, 376, 288, 111, 564, 1027, 4, 396, 396,
+	/* 1660 */ 395, 273, 393, 572, 1146, 853, 572, 1081, 572, 258,
+	/* 1670 */ 496, 567, 572, 211, 75, 75, 559, 968, 234, 261,
+	/* 1680 */ 323, 111, 564, 935, 4, 113, 77, 77, 322, 74,
+	/* 1690 */ 74, 42, 42, 1379, 449, 48, 48, 1424, 567, 980,
+	/* 1700 */ 981, 1097, 1096, 1097, 1096, 866, 561, 150, 936, 1352,
+	/* 1710 */ 113, 1364, 558, 1430, 1027, 1279, 1270, 1258, 236, 1257,
+	/* 1720 */ 1259, 449, 1598, 1349, 308, 276, 168, 309, 11, 141,
+	/* 1730 */ 397, 310, 232, 561, 1411, 1033, 339, 291, 329, 219,
+	/* 1740 */ 340, 109, 109, 942, 297, 1416, 235, 345, 481, 110,
+	/* 1750 */ 506, 449, 574, 573, 332, 1415, 1023, 404, 1299, 369,
+	/* 1760 */ 223, 1491, 1033, 1490, 1361, 1362, 1360, 1359, 109, 109,
+	/* 1770 */ 204, 1601, 1236, 562, 265, 218, 110, 205, 449, 574,
+	/* 1780 */ 573, 414, 391, 1023, 1538, 179, 316, 563, 1023, 1023,
+	/* 1790 */ 1025, 1026, 27, 230, 1536, 1233, 79, 564, 85, 4,
+	/* 1800 */ 422, 215, 552, 81, 84, 188, 1412, 128, 1406, 550,
+	/* 1810 */ 455, 35, 328, 567, 173, 1023, 1023, 1025, 1026, 27,
+	/* 1820 */ 181, 1496, 1399, 331, 465, 183, 184, 185, 186, 466,
+	/* 1830 */ 499, 242, 98, 402, 1418, 1420, 449, 1417, 473, 36,
+	/* 1840 */ 192, 488, 405, 1507, 246, 91, 494, 196, 561, 1485,
+	/* 1850 */ 350, 497, 277, 354, 248, 249, 111, 564, 1260, 4,
+	/* 1860 */ 250, 407, 515, 436, 1319, 1310, 93, 1318, 1317, 889,
+	/* 1870 */ 1309, 224, 1584, 567, 438, 524, 439, 1033, 263, 264,
+	/* 1880 */ 442, 1293, 10, 109, 109, 1287, 408, 1292, 1286, 368,
+	/* 1890 */ 1285, 110, 1614, 449, 574, 573, 449, 306, 1023, 307,
+	/* 1900 */ 374, 1384, 1570, 1472, 1383, 385, 105, 314, 561, 99,
+	/* 1910 */ 1569, 534, 34, 576, 1191, 272, 1342, 551, 383, 274,
+	/* 1920 */ 1341, 210, 389, 390, 275, 577, 1255, 1250, 415, 165,
+	/* 1930 */ 1023, 1023, 1025, 1026, 27, 147, 1523, 1033, 166, 1524,
+	/* 1940 */ 416, 1522, 178, 109, 109, 1521, 304, 167, 840, 450,
+	/* 1950 */ 220, 110, 221, 449, 574, 573, 212, 78, 1023, 318,
+	/* 1960 */ 231, 1095, 1093, 144, 180, 326, 169, 1215, 241, 182,
+	/* 1970 */ 921, 338, 238, 1109, 187, 170, 171, 425, 427, 189,
+	/* 1980 */ 87, 88, 89, 90, 172, 1112, 243, 1108, 244, 158,
+	/* 1990 */ 1023, 1023, 1025, 1026, 27, 18, 245, 1230, 493, 349,
+	/* 2000 */ 1101, 261, 247, 193, 194, 37, 370, 855, 498, 251,
+	/* 2010 */ 195, 510, 92, 19, 174, 362, 502, 20, 507, 887,
+	/* 2020 */ 365, 900, 94, 305, 159, 95, 517, 96, 1179, 160,
+	/* 2030 */ 1062, 1148, 39, 1147, 225, 280, 282, 972, 198, 966,
+	/* 2040 */ 113, 1165, 1169, 260, 1167, 21, 1173, 7, 22, 1153,
+	/* 2050 */ 33, 23, 24, 25, 1172, 546, 26, 202, 100, 102,
+	/* 2060 */ 1076, 103, 1063, 1061, 1065, 1118, 1066, 1117, 266, 267,
+	/* 2070 */ 28, 40, 931, 1028, 867, 112, 29, 568, 394, 143,
+	/* 2080 */ 1187, 268, 176, 1186, 269, 1246, 1246, 1246, 1246, 1246,
+	/* 2090 */ 1246, 1246, 1246, 1246, 1246, 1607, 1246, 1246, 1246, 1246,
+	/* 2100 */ 1606,
 }
 var yy_lookahead = []YYCODETYPE{
 	/* 0 */ 193, 193, 193, 274, 275, 276, 193, 274, 275, 276,
@@ -971,54 +989,54 @@ var yy_lookahead = []YYCODETYPE{
 	/* 1600 */ 216, 217, 216, 217, 193, 114, 117, 116, 117, 118,
 	/* 1610 */ 133, 193, 121, 193, 193, 138, 139, 193, 23, 193,
 	/* 1620 */ 25, 23, 23, 25, 25, 7, 8, 216, 217, 193,
-	/* 1630 */ 193, 153, 154, 155, 156, 157, 216, 217, 59, 162,
+	/* 1630 */ 193, 153, 154, 155, 156, 157, 216, 217, 193, 162,
 	/* 1640 */ 216, 217, 216, 217, 153, 154, 155, 156, 157, 1,
-	/* 1650 */ 2, 193, 193, 5, 19, 20, 193, 22, 10, 11,
-	/* 1660 */ 12, 13, 14, 193, 97, 17, 193, 23, 23, 25,
-	/* 1670 */ 25, 36, 83, 84, 216, 217, 236, 23, 30, 25,
-	/* 1680 */ 32, 19, 20, 153, 22, 155, 216, 217, 40, 216,
-	/* 1690 */ 217, 153, 193, 155, 59, 23, 117, 25, 36, 23,
-	/* 1700 */ 193, 25, 193, 193, 193, 193, 71, 193, 242, 193,
-	/* 1710 */ 193, 193, 193, 193, 243, 288, 287, 214, 70, 255,
-	/* 1720 */ 255, 59, 255, 255, 191, 297, 78, 271, 267, 81,
-	/* 1730 */ 245, 293, 246, 71, 246, 100, 267, 245, 293, 249,
-	/* 1740 */ 219, 106, 107, 108, 220, 271, 98, 271, 225, 114,
-	/* 1750 */ 271, 116, 117, 118, 229, 219, 121, 259, 219, 259,
-	/* 1760 */ 259, 259, 100, 60, 280, 196, 141, 38, 106, 107,
-	/* 1770 */ 200, 249, 245, 200, 243, 200, 114, 151, 116, 117,
-	/* 1780 */ 118, 133, 150, 121, 297, 272, 138, 139, 153, 154,
-	/* 1790 */ 155, 156, 157, 297, 22, 43, 19, 20, 294, 22,
-	/* 1800 */ 294, 18, 270, 237, 200, 234, 18, 199, 149, 283,
-	/* 1810 */ 162, 237, 272, 36, 237, 153, 154, 155, 156, 157,
-	/* 1820 */ 237, 272, 246, 270, 246, 234, 246, 234, 246, 200,
-	/* 1830 */ 199, 158, 290, 62, 289, 199, 59, 200, 22, 200,
-	/* 1840 */ 221, 199, 221, 200, 64, 199, 115, 227, 71, 22,
-	/* 1850 */ 126, 218, 218, 165, 224, 227, 218, 24, 113, 305,
-	/* 1860 */ 312, 200, 221, 282, 224, 218, 282, 91, 218, 220,
-	/* 1870 */ 218, 218, 221, 317, 82, 317, 265, 100, 265, 145,
-	/* 1880 */ 148, 22, 277, 106, 107, 200, 158, 147, 279, 146,
-	/* 1890 */ 25, 114, 202, 116, 117, 118, 13, 6, 121, 194,
-	/* 1900 */ 194, 192, 192, 192, 248, 250, 249, 247, 250, 246,
-	/* 1910 */ 303, 207, 300, 303, 213, 213, 222, 222, 4, 207,
-	/* 1920 */ 213, 213, 207, 3, 22, 163, 15, 214, 214, 213,
-	/* 1930 */ 153, 154, 155, 156, 157, 16, 23, 139, 23, 130,
-	/* 1940 */ 151, 25, 24, 142, 20, 16, 144, 1, 142, 130,
-	/* 1950 */ 130, 61, 53, 53, 37, 151, 53, 53, 130, 116,
-	/* 1960 */ 34, 1, 141, 5, 22, 115, 161, 75, 25, 68,
-	/* 1970 */ 141, 41, 115, 68, 24, 20, 19, 131, 125, 67,
-	/* 1980 */ 24, 22, 59, 22, 22, 96, 23, 22, 67, 22,
-	/* 1990 */ 37, 23, 28, 149, 67, 22, 25, 23, 23, 23,
-	/* 2000 */ 23, 22, 141, 97, 23, 23, 34, 22, 25, 116,
-	/* 2010 */ 143, 75, 34, 88, 34, 75, 23, 86, 34, 22,
-	/* 2020 */ 34, 93, 34, 24, 34, 25, 25, 23, 23, 23,
-	/* 2030 */ 23, 44, 23, 25, 23, 22, 11, 22, 22, 25,
-	/* 2040 */ 23, 23, 22, 22, 135, 15, 23, 25, 141, 141,
-	/* 2050 */ 1, 141, 1, 319, 319, 319, 319, 319, 319, 319,
-	/* 2060 */ 319, 319, 319, 141, 319, 319, 319, 319, 319, 319,
-	/* 2070 */ 319, 319, 319, 319, 319, 319, 319, 319, 319, 319,
-	/* 2080 */ 319, 319, 319, 319, 319, 319, 319, 319, 319, 319,
-	/* 2090 */ 319, 319, 319, 319, 319, 319, 319, 319, 319, 319,
-	/* 2100 */ 319, 319, 319, 319, 319, 319, 319, 319, 319, 319,
+	/* 1650 */ 2, 193, 193, 5, 19, 20, 59, 22, 10, 11,
+	/* 1660 */ 12, 13, 14, 193, 97, 17, 193, 23, 193, 25,
+	/* 1670 */ 288, 36, 193, 242, 216, 217, 236, 23, 30, 25,
+	/* 1680 */ 32, 19, 20, 23, 22, 25, 216, 217, 40, 216,
+	/* 1690 */ 217, 216, 217, 193, 59, 216, 217, 193, 36, 83,
+	/* 1700 */ 84, 153, 153, 155, 155, 23, 71, 25, 23, 193,
+	/* 1710 */ 25, 193, 193, 193, 117, 193, 193, 193, 70, 193,
+	/* 1720 */ 193, 59, 193, 255, 255, 287, 78, 255, 243, 81,
+	/* 1730 */ 191, 255, 297, 71, 271, 100, 293, 245, 267, 214,
+	/* 1740 */ 246, 106, 107, 108, 246, 271, 98, 245, 293, 114,
+	/* 1750 */ 220, 116, 117, 118, 267, 271, 121, 271, 225, 219,
+	/* 1760 */ 229, 219, 100, 219, 259, 259, 259, 259, 106, 107,
+	/* 1770 */ 249, 196, 60, 280, 141, 243, 114, 249, 116, 117,
+	/* 1780 */ 118, 133, 245, 121, 200, 297, 138, 139, 153, 154,
+	/* 1790 */ 155, 156, 157, 297, 200, 38, 19, 20, 151, 22,
+	/* 1800 */ 200, 150, 140, 294, 294, 22, 272, 148, 250, 145,
+	/* 1810 */ 162, 270, 249, 36, 43, 153, 154, 155, 156, 157,
+	/* 1820 */ 234, 283, 250, 249, 18, 237, 237, 237, 237, 200,
+	/* 1830 */ 18, 199, 149, 246, 272, 234, 59, 272, 246, 270,
+	/* 1840 */ 234, 200, 246, 290, 199, 158, 62, 22, 71, 246,
+	/* 1850 */ 289, 221, 200, 200, 199, 199, 19, 20, 200, 22,
+	/* 1860 */ 199, 221, 115, 64, 218, 227, 22, 218, 218, 126,
+	/* 1870 */ 227, 165, 312, 36, 24, 305, 113, 100, 200, 91,
+	/* 1880 */ 82, 224, 22, 106, 107, 218, 221, 224, 220, 218,
+	/* 1890 */ 218, 114, 218, 116, 117, 118, 59, 282, 121, 282,
+	/* 1900 */ 221, 265, 317, 277, 265, 200, 158, 279, 71, 147,
+	/* 1910 */ 317, 146, 25, 202, 13, 194, 250, 140, 249, 194,
+	/* 1920 */ 250, 248, 247, 246, 6, 192, 192, 192, 303, 207,
+	/* 1930 */ 153, 154, 155, 156, 157, 222, 213, 100, 207, 213,
+	/* 1940 */ 303, 213, 300, 106, 107, 213, 222, 207, 4, 3,
+	/* 1950 */ 214, 114, 214, 116, 117, 118, 22, 213, 121, 163,
+	/* 1960 */ 15, 23, 23, 16, 151, 139, 130, 25, 144, 142,
+	/* 1970 */ 20, 16, 24, 1, 142, 130, 130, 61, 37, 151,
+	/* 1980 */ 53, 53, 53, 53, 130, 116, 34, 1, 141, 5,
+	/* 1990 */ 153, 154, 155, 156, 157, 22, 115, 75, 41, 161,
+	/* 2000 */ 68, 25, 141, 68, 115, 24, 131, 20, 19, 125,
+	/* 2010 */ 22, 96, 22, 22, 37, 23, 67, 22, 67, 59,
+	/* 2020 */ 24, 28, 22, 67, 23, 149, 22, 25, 23, 23,
+	/* 2030 */ 23, 23, 22, 97, 141, 23, 23, 116, 22, 143,
+	/* 2040 */ 25, 88, 75, 34, 86, 34, 75, 44, 34, 23,
+	/* 2050 */ 22, 34, 34, 34, 93, 24, 34, 25, 25, 142,
+	/* 2060 */ 23, 142, 23, 23, 23, 23, 11, 23, 25, 22,
+	/* 2070 */ 22, 22, 135, 23, 23, 22, 22, 25, 15, 23,
+	/* 2080 */ 1, 141, 25, 1, 141, 319, 319, 319, 319, 319,
+	/* 2090 */ 319, 319, 319, 319, 319, 141, 319, 319, 319, 319,
+	/* 2100 */ 141, 319, 319, 319, 319, 319, 319, 319, 319, 319,
 	/* 2110 */ 319, 319, 319, 319, 319, 319, 319, 319, 319, 319,
 	/* 2120 */ 319, 319, 319, 319, 319, 319, 319, 319, 319, 319,
 	/* 2130 */ 319, 319, 319, 319, 319, 319, 319, 319, 319, 319,
@@ -1032,76 +1050,81 @@ var yy_lookahead = []YYCODETYPE{
 	/* 2210 */ 319, 319, 319, 319, 319, 319, 319, 319, 319, 319,
 	/* 2220 */ 319, 319, 319, 319, 319, 319, 319, 319, 319, 319,
 	/* 2230 */ 319, 319, 319, 319, 319, 319, 319, 319, 319, 319,
-	/* 2240 */ 319, 319, 319, 319, 319, 319, 319, 319, 319,
+	/* 2240 */ 319, 319, 319, 319, 319, 319, 319, 319, 319, 319,
+	/* 2250 */ 319, 319, 319, 319, 319, 319, 319, 319, 319, 319,
+	/* 2260 */ 319, 319, 319, 319, 319, 319, 319, 319, 319, 319,
+	/* 2270 */ 319, 319, 319, 319, 319, 319, 319, 319, 319, 319,
+	/* 2280 */ 319, 319, 319, 319, 319, 319,
 }
 
-const YY_SHIFT_COUNT = 569
+const YY_SHIFT_COUNT = 579
 const YY_SHIFT_MIN = 0
-const YY_SHIFT_MAX = 2051
+const YY_SHIFT_MAX = 2082
 
 var yy_shift_ofst = []uint16{
-	/* 0 */ 1648, 1477, 1272, 322, 322, 1, 1319, 1478, 1491, 1662,
-	/* 10 */ 1662, 1662, 471, 0, 0, 214, 1093, 1662, 1662, 1662,
-	/* 20 */ 1662, 1662, 1662, 1662, 1662, 1662, 1662, 1662, 1662, 1662,
+	/* 0 */ 1648, 1477, 1272, 322, 322, 1, 1319, 1478, 1491, 1837,
+	/* 10 */ 1837, 1837, 471, 0, 0, 214, 1093, 1837, 1837, 1837,
+	/* 20 */ 1837, 1837, 1837, 1837, 1837, 1837, 1837, 1837, 1837, 1837,
 	/* 30 */ 271, 271, 1219, 1219, 216, 88, 1, 1, 1, 1,
 	/* 40 */ 1, 40, 111, 258, 361, 469, 512, 583, 622, 693,
 	/* 50 */ 732, 803, 842, 913, 1073, 1093, 1093, 1093, 1093, 1093,
 	/* 60 */ 1093, 1093, 1093, 1093, 1093, 1093, 1093, 1093, 1093, 1093,
 	/* 70 */ 1093, 1093, 1093, 1113, 1093, 1216, 957, 957, 1635, 1662,
-	/* 80 */ 1662, 1662, 1662, 1662, 1662, 1662, 1662, 1662, 1662, 1662,
-	/* 90 */ 1662, 1662, 1662, 1662, 1662, 1662, 1662, 1662, 1662, 1662,
-	/* 100 */ 1662, 1662, 1662, 1662, 1662, 1662, 1662, 1662, 1662, 1662,
-	/* 110 */ 1662, 1662, 1662, 1662, 1777, 1662, 1662, 1662, 1662, 1662,
-	/* 120 */ 1662, 1662, 1662, 1662, 1662, 1662, 1662, 1662, 137, 181,
-	/* 130 */ 181, 181, 181, 181, 94, 430, 66, 65, 112, 366,
-	/* 140 */ 533, 533, 740, 1261, 533, 533, 79, 79, 533, 412,
-	/* 150 */ 412, 412, 77, 412, 123, 113, 113, 22, 22, 2064,
-	/* 160 */ 2064, 328, 328, 328, 239, 468, 468, 468, 468, 1015,
-	/* 170 */ 1015, 409, 366, 1129, 1186, 533, 533, 533, 533, 533,
+	/* 80 */ 1777, 1837, 1837, 1837, 1837, 1837, 1837, 1837, 1837, 1837,
+	/* 90 */ 1837, 1837, 1837, 1837, 1837, 1837, 1837, 1837, 1837, 1837,
+	/* 100 */ 1837, 1837, 1837, 1837, 1837, 1837, 1837, 1837, 1837, 1837,
+	/* 110 */ 1837, 1837, 1837, 1837, 1837, 1837, 1837, 1837, 1837, 1837,
+	/* 120 */ 1837, 1837, 1837, 1837, 1837, 1837, 1837, 1837, 1837, 1837,
+	/* 130 */ 137, 181, 181, 181, 181, 181, 181, 181, 94, 430,
+	/* 140 */ 66, 65, 112, 366, 533, 533, 740, 1261, 533, 533,
+	/* 150 */ 79, 79, 533, 412, 412, 412, 77, 412, 123, 113,
+	/* 160 */ 113, 22, 22, 2101, 2101, 328, 328, 328, 239, 468,
+	/* 170 */ 468, 468, 468, 1015, 1015, 409, 366, 1129, 1186, 533,
 	/* 180 */ 533, 533, 533, 533, 533, 533, 533, 533, 533, 533,
-	/* 190 */ 533, 533, 533, 533, 533, 969, 621, 621, 533, 642,
-	/* 200 */ 788, 788, 1228, 1228, 822, 822, 67, 1274, 2064, 2064,
-	/* 210 */ 2064, 2064, 2064, 2064, 2064, 1307, 954, 954, 585, 472,
-	/* 220 */ 640, 387, 695, 538, 541, 700, 533, 533, 533, 533,
-	/* 230 */ 533, 533, 533, 533, 533, 533, 222, 533, 533, 533,
-	/* 240 */ 533, 533, 533, 533, 533, 533, 533, 533, 533, 1179,
-	/* 250 */ 1179, 1179, 533, 533, 533, 565, 533, 533, 533, 916,
-	/* 260 */ 1144, 533, 533, 1288, 533, 533, 533, 533, 533, 533,
-	/* 270 */ 533, 533, 639, 1330, 209, 1076, 1076, 1076, 1076, 580,
-	/* 280 */ 209, 209, 1313, 768, 917, 649, 1181, 1316, 405, 1316,
-	/* 290 */ 1238, 249, 1181, 1181, 249, 1181, 405, 1238, 1369, 464,
-	/* 300 */ 1259, 1012, 1012, 1012, 1368, 1368, 1368, 1368, 184, 184,
-	/* 310 */ 1326, 904, 1287, 1480, 1703, 1703, 1625, 1625, 1729, 1729,
-	/* 320 */ 1625, 1626, 1632, 1772, 1752, 1783, 1783, 1783, 1783, 1625,
-	/* 330 */ 1788, 1659, 1632, 1632, 1659, 1772, 1752, 1659, 1752, 1659,
-	/* 340 */ 1625, 1788, 1673, 1771, 1625, 1788, 1816, 1625, 1788, 1625,
-	/* 350 */ 1788, 1816, 1731, 1731, 1731, 1780, 1827, 1827, 1816, 1731,
-	/* 360 */ 1724, 1731, 1780, 1731, 1731, 1688, 1833, 1745, 1745, 1816,
-	/* 370 */ 1625, 1776, 1776, 1792, 1792, 1732, 1734, 1859, 1625, 1728,
-	/* 380 */ 1732, 1740, 1743, 1659, 1865, 1883, 1883, 1891, 1891, 1891,
-	/* 390 */ 2064, 2064, 2064, 2064, 2064, 2064, 2064, 2064, 2064, 2064,
-	/* 400 */ 2064, 2064, 2064, 2064, 2064, 207, 1095, 331, 620, 903,
-	/* 410 */ 806, 1074, 1483, 1432, 1481, 1322, 1370, 1394, 1515, 1291,
-	/* 420 */ 1546, 1547, 1557, 1595, 1598, 1599, 1434, 1453, 1618, 1462,
-	/* 430 */ 1567, 1489, 1644, 1645, 1589, 1654, 1530, 1538, 1672, 1676,
-	/* 440 */ 1579, 742, 1914, 1920, 1902, 1762, 1911, 1919, 1913, 1915,
-	/* 450 */ 1798, 1789, 1809, 1916, 1916, 1918, 1801, 1924, 1802, 1929,
-	/* 460 */ 1946, 1806, 1819, 1916, 1820, 1890, 1917, 1916, 1804, 1899,
-	/* 470 */ 1900, 1903, 1904, 1828, 1843, 1926, 1821, 1960, 1958, 1942,
-	/* 480 */ 1850, 1805, 1901, 1943, 1905, 1892, 1930, 1829, 1857, 1950,
-	/* 490 */ 1955, 1957, 1846, 1853, 1959, 1912, 1961, 1962, 1963, 1965,
-	/* 500 */ 1921, 1923, 1956, 1889, 1964, 1967, 1927, 1953, 1968, 1844,
-	/* 510 */ 1973, 1974, 1975, 1976, 1971, 1977, 1979, 1906, 1861, 1981,
-	/* 520 */ 1982, 1893, 1972, 1985, 1867, 1983, 1978, 1980, 1984, 1986,
-	/* 530 */ 1925, 1936, 1931, 1987, 1940, 1928, 1988, 1993, 1997, 1999,
-	/* 540 */ 2000, 2001, 1990, 2004, 1983, 2005, 2006, 2007, 2009, 2008,
-	/* 550 */ 2011, 2013, 2025, 2015, 2016, 2017, 2018, 2020, 2021, 2014,
-	/* 560 */ 1909, 1907, 1908, 1910, 1922, 2022, 2023, 2030, 2049, 2051,
+	/* 190 */ 533, 533, 533, 533, 533, 533, 533, 533, 533, 969,
+	/* 200 */ 621, 621, 533, 642, 788, 788, 1228, 1228, 822, 822,
+	/* 210 */ 67, 1274, 2101, 2101, 2101, 2101, 2101, 2101, 2101, 1307,
+	/* 220 */ 954, 954, 585, 472, 640, 387, 695, 538, 541, 700,
+	/* 230 */ 533, 533, 533, 533, 533, 533, 533, 533, 533, 533,
+	/* 240 */ 222, 533, 533, 533, 533, 533, 533, 533, 533, 533,
+	/* 250 */ 533, 533, 533, 1179, 1179, 1179, 533, 533, 533, 565,
+	/* 260 */ 533, 533, 533, 916, 1144, 533, 533, 1288, 533, 533,
+	/* 270 */ 533, 533, 533, 533, 533, 533, 639, 1330, 209, 1076,
+	/* 280 */ 1076, 1076, 1076, 580, 209, 209, 1313, 768, 917, 649,
+	/* 290 */ 1181, 1316, 405, 1316, 1238, 249, 1181, 1181, 249, 1181,
+	/* 300 */ 405, 1238, 1369, 464, 1259, 1012, 1012, 1012, 1368, 1368,
+	/* 310 */ 1368, 1368, 184, 184, 1326, 904, 1287, 1480, 1712, 1712,
+	/* 320 */ 1633, 1633, 1757, 1757, 1633, 1647, 1651, 1783, 1659, 1664,
+	/* 330 */ 1771, 1659, 1664, 1806, 1806, 1806, 1806, 1633, 1812, 1683,
+	/* 340 */ 1651, 1651, 1683, 1783, 1771, 1683, 1771, 1683, 1633, 1812,
+	/* 350 */ 1687, 1784, 1633, 1812, 1825, 1633, 1812, 1633, 1812, 1825,
+	/* 360 */ 1747, 1747, 1747, 1799, 1844, 1844, 1825, 1747, 1743, 1747,
+	/* 370 */ 1799, 1747, 1747, 1706, 1850, 1763, 1763, 1825, 1633, 1788,
+	/* 380 */ 1788, 1798, 1798, 1659, 1664, 1860, 1633, 1748, 1659, 1762,
+	/* 390 */ 1765, 1683, 1887, 1901, 1901, 1918, 1918, 1918, 2101, 2101,
+	/* 400 */ 2101, 2101, 2101, 2101, 2101, 2101, 2101, 2101, 2101, 2101,
+	/* 410 */ 2101, 2101, 2101, 207, 1095, 331, 620, 903, 806, 1074,
+	/* 420 */ 1483, 1432, 1481, 1322, 1370, 1394, 1515, 1291, 1546, 1547,
+	/* 430 */ 1557, 1595, 1598, 1599, 1434, 1453, 1618, 1462, 1567, 1489,
+	/* 440 */ 1644, 1654, 1616, 1660, 1548, 1549, 1682, 1685, 1597, 742,
+	/* 450 */ 1944, 1946, 1934, 1796, 1945, 1947, 1938, 1939, 1826, 1813,
+	/* 460 */ 1836, 1942, 1942, 1948, 1827, 1950, 1824, 1955, 1972, 1832,
+	/* 470 */ 1845, 1942, 1846, 1916, 1941, 1942, 1828, 1927, 1928, 1929,
+	/* 480 */ 1930, 1854, 1869, 1952, 1847, 1986, 1984, 1973, 1881, 1838,
+	/* 490 */ 1932, 1976, 1935, 1922, 1957, 1861, 1889, 1981, 1987, 1989,
+	/* 500 */ 1875, 1884, 1988, 1949, 1990, 1991, 1992, 1995, 1951, 1960,
+	/* 510 */ 1996, 1915, 1993, 2000, 1956, 1977, 2001, 1876, 2004, 2005,
+	/* 520 */ 2006, 2007, 2002, 2008, 2010, 1936, 1893, 2012, 2013, 1921,
+	/* 530 */ 2009, 2016, 1896, 2015, 2011, 2014, 2017, 2018, 1953, 1967,
+	/* 540 */ 1958, 2003, 1971, 1961, 2019, 2026, 2028, 2031, 2032, 2033,
+	/* 550 */ 2022, 1917, 1919, 2037, 2015, 2039, 2040, 2041, 2042, 2043,
+	/* 560 */ 2044, 2047, 2055, 2048, 2049, 2050, 2051, 2053, 2054, 2052,
+	/* 570 */ 1937, 1940, 1943, 1954, 1959, 2057, 2056, 2063, 2079, 2082,
 }
 
-const YY_REDUCE_COUNT = 404
+const YY_REDUCE_COUNT = 412
 const YY_REDUCE_MIN = -271
-const YY_REDUCE_MAX = 1716
+const YY_REDUCE_MAX = 1744
 
 var yy_reduce_ofst = []int16{
 	/* 0 */ -125, 733, 789, 241, 293, -123, -193, -191, -183, -187,
@@ -1116,94 +1139,96 @@ var yy_reduce_ofst = []int16{
 	/* 90 */ 1155, 1163, 1165, 1167, 1169, 1172, 1180, 1182, 1184, 1198,
 	/* 100 */ 1200, 1213, 1215, 1225, 1227, 1252, 1254, 1264, 1299, 1303,
 	/* 110 */ 1308, 1312, 1325, 1328, 1337, 1340, 1343, 1371, 1373, 1384,
-	/* 120 */ 1386, 1411, 1420, 1424, 1426, 1458, 1470, 1473, -271, -271,
-	/* 130 */ -271, -271, -271, -271, -271, -271, -271, 138, 459, 396,
-	/* 140 */ -158, 470, 302, -212, 521, 201, -195, -92, 559, 630,
-	/* 150 */ 632, 630, -271, 632, 901, 63, 407, -271, -271, -271,
-	/* 160 */ -271, 161, 161, 161, 251, 335, 847, 960, 980, 537,
-	/* 170 */ 588, 618, 628, 688, 688, -166, -161, 674, 790, 794,
-	/* 180 */ 799, 851, 852, -122, 680, -120, 995, 1038, 415, 1051,
-	/* 190 */ 893, 798, 962, 400, 1086, 779, 923, 924, 263, 1041,
-	/* 200 */ 979, 990, 1083, 1097, 1031, 1194, 362, 994, 1139, 1005,
-	/* 210 */ 1037, 1202, 1205, 1195, 1210, -194, 56, 185, -135, 232,
-	/* 220 */ 522, 560, 601, 617, 669, 683, 711, 856, 908, 941,
-	/* 230 */ 1048, 1101, 1147, 1257, 1262, 1265, 392, 1292, 1333, 1339,
-	/* 240 */ 1342, 1346, 1350, 1359, 1374, 1418, 1421, 1436, 1437, 593,
-	/* 250 */ 755, 770, 997, 1459, 1463, 1209, 1499, 1507, 1509, 1132,
-	/* 260 */ 1243, 1510, 1511, 1440, 1512, 560, 1514, 1516, 1517, 1518,
-	/* 270 */ 1519, 1520, 1427, 1429, 1466, 1464, 1465, 1467, 1468, 1209,
-	/* 280 */ 1466, 1466, 1471, 1503, 1533, 1428, 1456, 1461, 1485, 1469,
-	/* 290 */ 1438, 1486, 1474, 1476, 1488, 1479, 1492, 1445, 1524, 1525,
-	/* 300 */ 1523, 1521, 1536, 1539, 1498, 1500, 1501, 1502, 1490, 1522,
-	/* 310 */ 1484, 1527, 1531, 1569, 1487, 1496, 1570, 1573, 1504, 1506,
-	/* 320 */ 1575, 1526, 1513, 1532, 1571, 1566, 1574, 1577, 1583, 1604,
-	/* 330 */ 1608, 1576, 1540, 1549, 1578, 1553, 1591, 1580, 1593, 1582,
-	/* 340 */ 1629, 1631, 1542, 1545, 1637, 1636, 1619, 1639, 1642, 1643,
-	/* 350 */ 1646, 1621, 1633, 1634, 1638, 1620, 1630, 1640, 1641, 1647,
-	/* 360 */ 1649, 1650, 1628, 1652, 1653, 1548, 1554, 1581, 1584, 1651,
-	/* 370 */ 1661, 1556, 1558, 1611, 1613, 1655, 1657, 1605, 1685, 1609,
-	/* 380 */ 1658, 1656, 1660, 1663, 1690, 1705, 1706, 1709, 1710, 1711,
-	/* 390 */ 1607, 1610, 1612, 1704, 1701, 1702, 1707, 1708, 1712, 1694,
-	/* 400 */ 1695, 1713, 1714, 1716, 1715,
+	/* 120 */ 1386, 1411, 1420, 1424, 1426, 1458, 1470, 1473, 1475, 1479,
+	/* 130 */ -271, -271, -271, -271, -271, -271, -271, -271, -271, -271,
+	/* 140 */ -271, 138, 459, 396, -158, 470, 302, -212, 521, 201,
+	/* 150 */ -195, -92, 559, 630, 632, 630, -271, 632, 901, 63,
+	/* 160 */ 407, -271, -271, -271, -271, 161, 161, 161, 251, 335,
+	/* 170 */ 847, 960, 980, 537, 588, 618, 628, 688, 688, -166,
+	/* 180 */ -161, 674, 790, 794, 799, 851, 852, -122, 680, -120,
+	/* 190 */ 995, 1038, 415, 1051, 893, 798, 962, 400, 1086, 779,
+	/* 200 */ 923, 924, 263, 1041, 979, 990, 1083, 1097, 1031, 1194,
+	/* 210 */ 362, 994, 1139, 1005, 1037, 1202, 1205, 1195, 1210, -194,
+	/* 220 */ 56, 185, -135, 232, 522, 560, 601, 617, 669, 683,
+	/* 230 */ 711, 856, 908, 941, 1048, 1101, 1147, 1257, 1262, 1265,
+	/* 240 */ 392, 1292, 1333, 1339, 1342, 1346, 1350, 1359, 1374, 1418,
+	/* 250 */ 1421, 1436, 1437, 593, 755, 770, 997, 1445, 1459, 1209,
+	/* 260 */ 1500, 1504, 1516, 1132, 1243, 1518, 1519, 1440, 1520, 560,
+	/* 270 */ 1522, 1523, 1524, 1526, 1527, 1529, 1382, 1438, 1431, 1468,
+	/* 280 */ 1469, 1472, 1476, 1209, 1431, 1431, 1485, 1525, 1539, 1435,
+	/* 290 */ 1463, 1471, 1492, 1487, 1443, 1494, 1474, 1484, 1498, 1486,
+	/* 300 */ 1502, 1455, 1530, 1531, 1533, 1540, 1542, 1544, 1505, 1506,
+	/* 310 */ 1507, 1508, 1521, 1528, 1493, 1537, 1532, 1575, 1488, 1496,
+	/* 320 */ 1584, 1594, 1509, 1510, 1600, 1538, 1534, 1541, 1558, 1563,
+	/* 330 */ 1586, 1572, 1574, 1588, 1589, 1590, 1591, 1629, 1632, 1587,
+	/* 340 */ 1562, 1565, 1592, 1569, 1601, 1596, 1606, 1603, 1641, 1645,
+	/* 350 */ 1553, 1561, 1652, 1655, 1630, 1653, 1656, 1658, 1661, 1640,
+	/* 360 */ 1646, 1649, 1650, 1638, 1657, 1663, 1665, 1667, 1668, 1671,
+	/* 370 */ 1643, 1672, 1674, 1560, 1570, 1615, 1617, 1679, 1678, 1585,
+	/* 380 */ 1593, 1636, 1639, 1666, 1669, 1626, 1705, 1628, 1670, 1673,
+	/* 390 */ 1675, 1677, 1711, 1721, 1725, 1733, 1734, 1735, 1625, 1637,
+	/* 400 */ 1642, 1722, 1723, 1726, 1728, 1732, 1731, 1713, 1724, 1736,
+	/* 410 */ 1738, 1744, 1740,
 }
 var yy_default = []YYACTIONTYPE{
-	/* 0 */ 1637, 1637, 1637, 1465, 1232, 1343, 1232, 1232, 1232, 1465,
-	/* 10 */ 1465, 1465, 1232, 1373, 1373, 1518, 1265, 1232, 1232, 1232,
-	/* 20 */ 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1464, 1232, 1232,
-	/* 30 */ 1232, 1232, 1553, 1553, 1232, 1232, 1232, 1232, 1232, 1232,
-	/* 40 */ 1232, 1232, 1382, 1232, 1389, 1232, 1232, 1232, 1232, 1232,
-	/* 50 */ 1466, 1467, 1232, 1232, 1232, 1517, 1519, 1482, 1396, 1395,
-	/* 60 */ 1394, 1393, 1500, 1361, 1387, 1380, 1384, 1460, 1461, 1459,
-	/* 70 */ 1463, 1467, 1466, 1232, 1383, 1430, 1444, 1429, 1232, 1232,
-	/* 80 */ 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232,
-	/* 90 */ 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232,
-	/* 100 */ 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232,
-	/* 110 */ 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232,
-	/* 120 */ 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1438, 1443,
-	/* 130 */ 1450, 1442, 1439, 1432, 1431, 1433, 1434, 1232, 1232, 1256,
-	/* 140 */ 1232, 1232, 1253, 1307, 1232, 1232, 1232, 1232, 1232, 1537,
-	/* 150 */ 1536, 1232, 1435, 1232, 1265, 1424, 1423, 1447, 1436, 1446,
-	/* 160 */ 1445, 1525, 1589, 1588, 1483, 1232, 1232, 1232, 1232, 1232,
-	/* 170 */ 1232, 1553, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232,
-	/* 180 */ 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232,
-	/* 190 */ 1232, 1232, 1232, 1232, 1232, 1363, 1553, 1553, 1232, 1265,
-	/* 200 */ 1553, 1553, 1364, 1364, 1261, 1261, 1367, 1232, 1532, 1334,
-	/* 210 */ 1334, 1334, 1334, 1343, 1334, 1232, 1232, 1232, 1232, 1232,
-	/* 220 */ 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232,
-	/* 230 */ 1522, 1520, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232,
-	/* 240 */ 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232,
-	/* 250 */ 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1339,
-	/* 260 */ 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232,
-	/* 270 */ 1232, 1582, 1232, 1495, 1321, 1339, 1339, 1339, 1339, 1341,
-	/* 280 */ 1322, 1320, 1333, 1266, 1239, 1629, 1399, 1388, 1340, 1388,
-	/* 290 */ 1626, 1386, 1399, 1399, 1386, 1399, 1340, 1626, 1282, 1605,
-	/* 300 */ 1277, 1373, 1373, 1373, 1363, 1363, 1363, 1363, 1367, 1367,
-	/* 310 */ 1462, 1340, 1333, 1232, 1629, 1629, 1349, 1349, 1628, 1628,
-	/* 320 */ 1349, 1483, 1613, 1408, 1310, 1316, 1316, 1316, 1316, 1349,
-	/* 330 */ 1250, 1386, 1613, 1613, 1386, 1408, 1310, 1386, 1310, 1386,
-	/* 340 */ 1349, 1250, 1499, 1623, 1349, 1250, 1473, 1349, 1250, 1349,
-	/* 350 */ 1250, 1473, 1308, 1308, 1308, 1297, 1232, 1232, 1473, 1308,
-	/* 360 */ 1282, 1308, 1297, 1308, 1308, 1571, 1232, 1477, 1477, 1473,
-	/* 370 */ 1349, 1563, 1563, 1376, 1376, 1381, 1367, 1468, 1349, 1232,
-	/* 380 */ 1381, 1379, 1377, 1386, 1300, 1585, 1585, 1581, 1581, 1581,
-	/* 390 */ 1634, 1634, 1532, 1598, 1265, 1265, 1265, 1265, 1598, 1284,
-	/* 400 */ 1284, 1266, 1266, 1265, 1598, 1232, 1232, 1232, 1232, 1232,
-	/* 410 */ 1232, 1593, 1232, 1527, 1484, 1353, 1232, 1232, 1232, 1232,
-	/* 420 */ 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232,
-	/* 430 */ 1538, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232,
-	/* 440 */ 1232, 1413, 1232, 1235, 1529, 1232, 1232, 1232, 1232, 1232,
-	/* 450 */ 1232, 1232, 1232, 1390, 1391, 1354, 1232, 1232, 1232, 1232,
-	/* 460 */ 1232, 1232, 1232, 1405, 1232, 1232, 1232, 1400, 1232, 1232,
-	/* 470 */ 1232, 1232, 1232, 1232, 1232, 1232, 1625, 1232, 1232, 1232,
-	/* 480 */ 1232, 1232, 1232, 1498, 1497, 1232, 1232, 1351, 1232, 1232,
-	/* 490 */ 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232,
-	/* 500 */ 1232, 1280, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232,
-	/* 510 */ 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232,
-	/* 520 */ 1232, 1232, 1232, 1232, 1232, 1378, 1232, 1232, 1232, 1232,
-	/* 530 */ 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232,
-	/* 540 */ 1568, 1368, 1232, 1232, 1616, 1232, 1232, 1232, 1232, 1232,
-	/* 550 */ 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1232, 1609,
-	/* 560 */ 1324, 1415, 1232, 1414, 1418, 1254, 1232, 1244, 1232, 1232,
+	/* 0 */ 1651, 1651, 1651, 1480, 1244, 1357, 1244, 1244, 1244, 1480,
+	/* 10 */ 1480, 1480, 1244, 1387, 1387, 1533, 1277, 1244, 1244, 1244,
+	/* 20 */ 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1479, 1244, 1244,
+	/* 30 */ 1244, 1244, 1568, 1568, 1244, 1244, 1244, 1244, 1244, 1244,
+	/* 40 */ 1244, 1244, 1396, 1244, 1403, 1244, 1244, 1244, 1244, 1244,
+	/* 50 */ 1481, 1482, 1244, 1244, 1244, 1532, 1534, 1497, 1410, 1409,
+	/* 60 */ 1408, 1407, 1515, 1375, 1401, 1394, 1398, 1476, 1477, 1475,
+	/* 70 */ 1629, 1482, 1481, 1244, 1397, 1444, 1460, 1443, 1244, 1244,
+	/* 80 */ 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244,
+	/* 90 */ 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244,
+	/* 100 */ 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244,
+	/* 110 */ 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244,
+	/* 120 */ 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244,
+	/* 130 */ 1452, 1459, 1458, 1457, 1466, 1456, 1453, 1446, 1445, 1447,
+	/* 140 */ 1448, 1244, 1244, 1268, 1244, 1244, 1265, 1321, 1244, 1244,
+	/* 150 */ 1244, 1244, 1244, 1552, 1551, 1244, 1449, 1244, 1277, 1438,
+	/* 160 */ 1437, 1463, 1450, 1462, 1461, 1540, 1604, 1603, 1498, 1244,
+	/* 170 */ 1244, 1244, 1244, 1244, 1244, 1568, 1244, 1244, 1244, 1244,
+	/* 180 */ 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244,
+	/* 190 */ 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1377,
+	/* 200 */ 1568, 1568, 1244, 1277, 1568, 1568, 1378, 1378, 1273, 1273,
+	/* 210 */ 1381, 1244, 1547, 1348, 1348, 1348, 1348, 1357, 1348, 1244,
+	/* 220 */ 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244,
+	/* 230 */ 1244, 1244, 1244, 1244, 1537, 1535, 1244, 1244, 1244, 1244,
+	/* 240 */ 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244,
+	/* 250 */ 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244,
+	/* 260 */ 1244, 1244, 1244, 1353, 1244, 1244, 1244, 1244, 1244, 1244,
+	/* 270 */ 1244, 1244, 1244, 1244, 1244, 1597, 1244, 1510, 1335, 1353,
+	/* 280 */ 1353, 1353, 1353, 1355, 1336, 1334, 1347, 1278, 1251, 1643,
+	/* 290 */ 1413, 1402, 1354, 1402, 1640, 1400, 1413, 1413, 1400, 1413,
+	/* 300 */ 1354, 1640, 1296, 1618, 1289, 1387, 1387, 1387, 1377, 1377,
+	/* 310 */ 1377, 1377, 1381, 1381, 1478, 1354, 1347, 1244, 1643, 1643,
+	/* 320 */ 1363, 1363, 1642, 1642, 1363, 1498, 1626, 1422, 1395, 1381,
+	/* 330 */ 1324, 1395, 1381, 1330, 1330, 1330, 1330, 1363, 1262, 1400,
+	/* 340 */ 1626, 1626, 1400, 1422, 1324, 1400, 1324, 1400, 1363, 1262,
+	/* 350 */ 1514, 1637, 1363, 1262, 1488, 1363, 1262, 1363, 1262, 1488,
+	/* 360 */ 1322, 1322, 1322, 1311, 1244, 1244, 1488, 1322, 1296, 1322,
+	/* 370 */ 1311, 1322, 1322, 1586, 1244, 1492, 1492, 1488, 1363, 1578,
+	/* 380 */ 1578, 1390, 1390, 1395, 1381, 1483, 1363, 1244, 1395, 1393,
+	/* 390 */ 1391, 1400, 1314, 1600, 1600, 1596, 1596, 1596, 1648, 1648,
+	/* 400 */ 1547, 1613, 1277, 1277, 1277, 1277, 1613, 1298, 1298, 1278,
+	/* 410 */ 1278, 1277, 1613, 1244, 1244, 1244, 1244, 1244, 1244, 1608,
+	/* 420 */ 1244, 1542, 1499, 1367, 1244, 1244, 1244, 1244, 1244, 1244,
+	/* 430 */ 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1553, 1244,
+	/* 440 */ 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1427,
+	/* 450 */ 1244, 1247, 1544, 1244, 1244, 1244, 1244, 1244, 1244, 1244,
+	/* 460 */ 1244, 1404, 1405, 1368, 1244, 1244, 1244, 1244, 1244, 1244,
+	/* 470 */ 1244, 1419, 1244, 1244, 1244, 1414, 1244, 1244, 1244, 1244,
+	/* 480 */ 1244, 1244, 1244, 1244, 1639, 1244, 1244, 1244, 1244, 1244,
+	/* 490 */ 1244, 1513, 1512, 1244, 1244, 1365, 1244, 1244, 1244, 1244,
+	/* 500 */ 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1294,
+	/* 510 */ 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244,
+	/* 520 */ 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244,
+	/* 530 */ 1244, 1244, 1244, 1392, 1244, 1244, 1244, 1244, 1244, 1244,
+	/* 540 */ 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1583, 1382,
+	/* 550 */ 1244, 1244, 1244, 1244, 1630, 1244, 1244, 1244, 1244, 1244,
+	/* 560 */ 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1244, 1622,
+	/* 570 */ 1338, 1429, 1244, 1428, 1432, 1266, 1244, 1256, 1244, 1244,
 }
 
 /********** End of lemon-generated parsing tables *****************************/
@@ -1447,6 +1472,8 @@ type yyParser struct {
 	// #endif
 	/* A place to hold %extra_argument */
 	pParse *ctxDecl/* A place to hold %extra_context */
+	yystackDepth int /* Maximum depth of the stack.  Zero for no limit */
+	yyfallback bool /* True if the last token was shifted as its %fallback */
 	yystack []yyStackEntry
 }
 
@@ -1852,364 +1879,366 @@ var yyRuleName = []string{
 	/*  42 */ "ccons ::= REFERENCES nm eidlist_opt refargs",
 	/*  43 */ "ccons ::= defer_subclause",
 	/*  44 */ "ccons ::= COLLATE ID|STRING",
-	/*  45 */ "generated ::= LP expr RP",
-	/*  46 */ "generated ::= LP expr RP ID",
-	/*  47 */ "autoinc ::=",
-	/*  48 */ "autoinc ::= AUTOINCR",
-	/*  49 */ "refargs ::=",
-	/*  50 */ "refargs ::= refargs refarg",
-	/*  51 */ "refarg ::= MATCH nm",
-	/*  52 */ "refarg ::= ON INSERT refact",
-	/*  53 */ "refarg ::= ON DELETE refact",
-	/*  54 */ "refarg ::= ON UPDATE refact",
-	/*  55 */ "refact ::= SET NULL",
-	/*  56 */ "refact ::= SET DEFAULT",
-	/*  57 */ "refact ::= CASCADE",
-	/*  58 */ "refact ::= RESTRICT",
-	/*  59 */ "refact ::= NO ACTION",
-	/*  60 */ "defer_subclause ::= NOT DEFERRABLE init_deferred_pred_opt",
-	/*  61 */ "defer_subclause ::= DEFERRABLE init_deferred_pred_opt",
-	/*  62 */ "init_deferred_pred_opt ::=",
-	/*  63 */ "init_deferred_pred_opt ::= INITIALLY DEFERRED",
-	/*  64 */ "init_deferred_pred_opt ::= INITIALLY IMMEDIATE",
-	/*  65 */ "conslist_opt ::=",
-	/*  66 */ "tconscomma ::= COMMA",
-	/*  67 */ "tcons ::= CONSTRAINT nm",
-	/*  68 */ "tcons ::= PRIMARY KEY LP sortlist autoinc RP onconf",
-	/*  69 */ "tcons ::= UNIQUE LP sortlist RP onconf",
-	/*  70 */ "tcons ::= CHECK LP expr RP onconf",
-	/*  71 */ "tcons ::= FOREIGN KEY LP eidlist RP REFERENCES nm eidlist_opt refargs defer_subclause_opt",
-	/*  72 */ "defer_subclause_opt ::=",
-	/*  73 */ "onconf ::=",
-	/*  74 */ "onconf ::= ON CONFLICT resolvetype",
-	/*  75 */ "orconf ::=",
-	/*  76 */ "orconf ::= OR resolvetype",
-	/*  77 */ "resolvetype ::= IGNORE",
-	/*  78 */ "resolvetype ::= REPLACE",
-	/*  79 */ "cmd ::= DROP TABLE ifexists fullname",
-	/*  80 */ "ifexists ::= IF EXISTS",
-	/*  81 */ "ifexists ::=",
-	/*  82 */ "cmd ::= createkw temp VIEW ifnotexists nm dbnm eidlist_opt AS select",
-	/*  83 */ "cmd ::= DROP VIEW ifexists fullname",
-	/*  84 */ "cmd ::= select",
-	/*  85 */ "select ::= WITH wqlist selectnowith",
-	/*  86 */ "select ::= WITH RECURSIVE wqlist selectnowith",
-	/*  87 */ "select ::= selectnowith",
-	/*  88 */ "selectnowith ::= selectnowith multiselect_op oneselect",
-	/*  89 */ "multiselect_op ::= UNION",
-	/*  90 */ "multiselect_op ::= UNION ALL",
-	/*  91 */ "multiselect_op ::= EXCEPT|INTERSECT",
-	/*  92 */ "oneselect ::= SELECT distinct selcollist from where_opt groupby_opt having_opt orderby_opt limit_opt",
-	/*  93 */ "oneselect ::= SELECT distinct selcollist from where_opt groupby_opt having_opt window_clause orderby_opt limit_opt",
-	/*  94 */ "values ::= VALUES LP nexprlist RP",
-	/*  95 */ "values ::= values COMMA LP nexprlist RP",
-	/*  96 */ "distinct ::= DISTINCT",
-	/*  97 */ "distinct ::= ALL",
-	/*  98 */ "distinct ::=",
-	/*  99 */ "sclp ::=",
-	/* 100 */ "selcollist ::= sclp scanpt expr scanpt as",
-	/* 101 */ "selcollist ::= sclp scanpt STAR",
-	/* 102 */ "selcollist ::= sclp scanpt nm DOT STAR",
-	/* 103 */ "as ::= AS nm",
-	/* 104 */ "as ::=",
-	/* 105 */ "from ::=",
-	/* 106 */ "from ::= FROM seltablist",
-	/* 107 */ "stl_prefix ::= seltablist joinop",
-	/* 108 */ "stl_prefix ::=",
-	/* 109 */ "seltablist ::= stl_prefix nm dbnm as on_using",
-	/* 110 */ "seltablist ::= stl_prefix nm dbnm as indexed_by on_using",
-	/* 111 */ "seltablist ::= stl_prefix nm dbnm LP exprlist RP as on_using",
-	/* 112 */ "seltablist ::= stl_prefix LP select RP as on_using",
-	/* 113 */ "seltablist ::= stl_prefix LP seltablist RP as on_using",
-	/* 114 */ "dbnm ::=",
-	/* 115 */ "dbnm ::= DOT nm",
-	/* 116 */ "fullname ::= nm",
-	/* 117 */ "fullname ::= nm DOT nm",
-	/* 118 */ "xfullname ::= nm",
-	/* 119 */ "xfullname ::= nm DOT nm",
-	/* 120 */ "xfullname ::= nm DOT nm AS nm",
-	/* 121 */ "xfullname ::= nm AS nm",
-	/* 122 */ "joinop ::= COMMA|JOIN",
-	/* 123 */ "joinop ::= JOIN_KW JOIN",
-	/* 124 */ "joinop ::= JOIN_KW nm JOIN",
-	/* 125 */ "joinop ::= JOIN_KW nm nm JOIN",
-	/* 126 */ "on_using ::= ON expr",
-	/* 127 */ "on_using ::= USING LP idlist RP",
-	/* 128 */ "on_using ::=",
-	/* 129 */ "indexed_opt ::=",
-	/* 130 */ "indexed_by ::= INDEXED BY nm",
-	/* 131 */ "indexed_by ::= NOT INDEXED",
-	/* 132 */ "orderby_opt ::=",
-	/* 133 */ "orderby_opt ::= ORDER BY sortlist",
-	/* 134 */ "sortlist ::= sortlist COMMA expr sortorder nulls",
-	/* 135 */ "sortlist ::= expr sortorder nulls",
-	/* 136 */ "sortorder ::= ASC",
-	/* 137 */ "sortorder ::= DESC",
-	/* 138 */ "sortorder ::=",
-	/* 139 */ "nulls ::= NULLS FIRST",
-	/* 140 */ "nulls ::= NULLS LAST",
-	/* 141 */ "nulls ::=",
-	/* 142 */ "groupby_opt ::=",
-	/* 143 */ "groupby_opt ::= GROUP BY nexprlist",
-	/* 144 */ "having_opt ::=",
-	/* 145 */ "having_opt ::= HAVING expr",
-	/* 146 */ "limit_opt ::=",
-	/* 147 */ "limit_opt ::= LIMIT expr",
-	/* 148 */ "limit_opt ::= LIMIT expr OFFSET expr",
-	/* 149 */ "limit_opt ::= LIMIT expr COMMA expr",
-	/* 150 */ "cmd ::= with DELETE FROM xfullname indexed_opt where_opt_ret",
-	/* 151 */ "where_opt ::=",
-	/* 152 */ "where_opt ::= WHERE expr",
-	/* 153 */ "where_opt_ret ::=",
-	/* 154 */ "where_opt_ret ::= WHERE expr",
-	/* 155 */ "where_opt_ret ::= RETURNING selcollist",
-	/* 156 */ "where_opt_ret ::= WHERE expr RETURNING selcollist",
-	/* 157 */ "cmd ::= with UPDATE orconf xfullname indexed_opt SET setlist from where_opt_ret",
-	/* 158 */ "setlist ::= setlist COMMA nm EQ expr",
-	/* 159 */ "setlist ::= setlist COMMA LP idlist RP EQ expr",
-	/* 160 */ "setlist ::= nm EQ expr",
-	/* 161 */ "setlist ::= LP idlist RP EQ expr",
-	/* 162 */ "cmd ::= with insert_cmd INTO xfullname idlist_opt select upsert",
-	/* 163 */ "cmd ::= with insert_cmd INTO xfullname idlist_opt DEFAULT VALUES returning",
-	/* 164 */ "upsert ::=",
-	/* 165 */ "upsert ::= RETURNING selcollist",
-	/* 166 */ "upsert ::= ON CONFLICT LP sortlist RP where_opt DO UPDATE SET setlist where_opt upsert",
-	/* 167 */ "upsert ::= ON CONFLICT LP sortlist RP where_opt DO NOTHING upsert",
-	/* 168 */ "upsert ::= ON CONFLICT DO NOTHING returning",
-	/* 169 */ "upsert ::= ON CONFLICT DO UPDATE SET setlist where_opt returning",
-	/* 170 */ "returning ::= RETURNING selcollist",
-	/* 171 */ "insert_cmd ::= INSERT orconf",
-	/* 172 */ "insert_cmd ::= REPLACE",
-	/* 173 */ "idlist_opt ::=",
-	/* 174 */ "idlist_opt ::= LP idlist RP",
-	/* 175 */ "idlist ::= idlist COMMA nm",
-	/* 176 */ "idlist ::= nm",
-	/* 177 */ "expr ::= LP expr RP",
-	/* 178 */ "expr ::= ID|INDEXED",
-	/* 179 */ "expr ::= JOIN_KW",
-	/* 180 */ "expr ::= nm DOT nm",
-	/* 181 */ "expr ::= nm DOT nm DOT nm",
-	/* 182 */ "term ::= NULL|FLOAT|BLOB",
-	/* 183 */ "term ::= STRING",
-	/* 184 */ "term ::= INTEGER",
-	/* 185 */ "expr ::= VARIABLE",
-	/* 186 */ "expr ::= expr COLLATE ID|STRING",
-	/* 187 */ "expr ::= CAST LP expr AS typetoken RP",
-	/* 188 */ "expr ::= ID|INDEXED LP distinct exprlist RP",
-	/* 189 */ "expr ::= ID|INDEXED LP STAR RP",
-	/* 190 */ "expr ::= ID|INDEXED LP distinct exprlist RP filter_over",
-	/* 191 */ "expr ::= ID|INDEXED LP STAR RP filter_over",
-	/* 192 */ "term ::= CTIME_KW",
-	/* 193 */ "expr ::= LP nexprlist COMMA expr RP",
-	/* 194 */ "expr ::= expr AND expr",
-	/* 195 */ "expr ::= expr OR expr",
-	/* 196 */ "expr ::= expr LT|GT|GE|LE expr",
-	/* 197 */ "expr ::= expr EQ|NE expr",
-	/* 198 */ "expr ::= expr BITAND|BITOR|LSHIFT|RSHIFT expr",
-	/* 199 */ "expr ::= expr PLUS|MINUS expr",
-	/* 200 */ "expr ::= expr STAR|SLASH|REM expr",
-	/* 201 */ "expr ::= expr CONCAT expr",
-	/* 202 */ "likeop ::= NOT LIKE_KW|MATCH",
-	/* 203 */ "expr ::= expr likeop expr",
-	/* 204 */ "expr ::= expr likeop expr ESCAPE expr",
-	/* 205 */ "expr ::= expr ISNULL|NOTNULL",
-	/* 206 */ "expr ::= expr NOT NULL",
-	/* 207 */ "expr ::= expr IS expr",
-	/* 208 */ "expr ::= expr IS NOT expr",
-	/* 209 */ "expr ::= NOT expr",
-	/* 210 */ "expr ::= BITNOT expr",
-	/* 211 */ "expr ::= PLUS|MINUS expr",
-	/* 212 */ "expr ::= expr PTR expr",
-	/* 213 */ "between_op ::= BETWEEN",
-	/* 214 */ "between_op ::= NOT BETWEEN",
-	/* 215 */ "expr ::= expr between_op expr AND expr",
-	/* 216 */ "in_op ::= IN",
-	/* 217 */ "in_op ::= NOT IN",
-	/* 218 */ "expr ::= expr in_op LP exprlist RP",
-	/* 219 */ "expr ::= LP select RP",
-	/* 220 */ "expr ::= expr in_op LP select RP",
-	/* 221 */ "expr ::= expr in_op nm dbnm paren_exprlist",
-	/* 222 */ "expr ::= EXISTS LP select RP",
-	/* 223 */ "expr ::= CASE case_operand case_exprlist case_else END",
-	/* 224 */ "case_exprlist ::= case_exprlist WHEN expr THEN expr",
-	/* 225 */ "case_exprlist ::= WHEN expr THEN expr",
-	/* 226 */ "case_else ::= ELSE expr",
-	/* 227 */ "case_else ::=",
-	/* 228 */ "case_operand ::= expr",
-	/* 229 */ "case_operand ::=",
-	/* 230 */ "exprlist ::=",
-	/* 231 */ "nexprlist ::= nexprlist COMMA expr",
-	/* 232 */ "nexprlist ::= expr",
-	/* 233 */ "paren_exprlist ::=",
-	/* 234 */ "paren_exprlist ::= LP exprlist RP",
-	/* 235 */ "cmd ::= createkw uniqueflag INDEX ifnotexists nm dbnm ON nm LP sortlist RP where_opt",
-	/* 236 */ "uniqueflag ::= UNIQUE",
-	/* 237 */ "uniqueflag ::=",
-	/* 238 */ "eidlist_opt ::=",
-	/* 239 */ "eidlist_opt ::= LP eidlist RP",
-	/* 240 */ "eidlist ::= eidlist COMMA nm collate sortorder",
-	/* 241 */ "eidlist ::= nm collate sortorder",
-	/* 242 */ "collate ::=",
-	/* 243 */ "collate ::= COLLATE ID|STRING",
-	/* 244 */ "cmd ::= DROP INDEX ifexists fullname",
-	/* 245 */ "cmd ::= VACUUM vinto",
-	/* 246 */ "cmd ::= VACUUM nm vinto",
-	/* 247 */ "vinto ::= INTO expr",
-	/* 248 */ "vinto ::=",
-	/* 249 */ "cmd ::= PRAGMA nm dbnm",
-	/* 250 */ "cmd ::= PRAGMA nm dbnm EQ nmnum",
-	/* 251 */ "cmd ::= PRAGMA nm dbnm LP nmnum RP",
-	/* 252 */ "cmd ::= PRAGMA nm dbnm EQ minus_num",
-	/* 253 */ "cmd ::= PRAGMA nm dbnm LP minus_num RP",
-	/* 254 */ "plus_num ::= PLUS INTEGER|FLOAT",
-	/* 255 */ "minus_num ::= MINUS INTEGER|FLOAT",
-	/* 256 */ "cmd ::= createkw trigger_decl BEGIN trigger_cmd_list END",
-	/* 257 */ "trigger_decl ::= temp TRIGGER ifnotexists nm dbnm trigger_time trigger_event ON fullname foreach_clause when_clause",
-	/* 258 */ "trigger_time ::= BEFORE|AFTER",
-	/* 259 */ "trigger_time ::= INSTEAD OF",
-	/* 260 */ "trigger_time ::=",
-	/* 261 */ "trigger_event ::= DELETE|INSERT",
-	/* 262 */ "trigger_event ::= UPDATE",
-	/* 263 */ "trigger_event ::= UPDATE OF idlist",
-	/* 264 */ "when_clause ::=",
-	/* 265 */ "when_clause ::= WHEN expr",
-	/* 266 */ "trigger_cmd_list ::= trigger_cmd_list trigger_cmd SEMI",
-	/* 267 */ "trigger_cmd_list ::= trigger_cmd SEMI",
-	/* 268 */ "trnm ::= nm DOT nm",
-	/* 269 */ "tridxby ::= INDEXED BY nm",
-	/* 270 */ "tridxby ::= NOT INDEXED",
-	/* 271 */ "trigger_cmd ::= UPDATE orconf trnm tridxby SET setlist from where_opt scanpt",
-	/* 272 */ "trigger_cmd ::= scanpt insert_cmd INTO trnm idlist_opt select upsert scanpt",
-	/* 273 */ "trigger_cmd ::= DELETE FROM trnm tridxby where_opt scanpt",
-	/* 274 */ "trigger_cmd ::= scanpt select scanpt",
-	/* 275 */ "expr ::= RAISE LP IGNORE RP",
-	/* 276 */ "expr ::= RAISE LP raisetype COMMA nm RP",
-	/* 277 */ "raisetype ::= ROLLBACK",
-	/* 278 */ "raisetype ::= ABORT",
-	/* 279 */ "raisetype ::= FAIL",
-	/* 280 */ "cmd ::= DROP TRIGGER ifexists fullname",
-	/* 281 */ "cmd ::= ATTACH database_kw_opt expr AS expr key_opt",
-	/* 282 */ "cmd ::= DETACH database_kw_opt expr",
-	/* 283 */ "key_opt ::=",
-	/* 284 */ "key_opt ::= KEY expr",
-	/* 285 */ "cmd ::= REINDEX",
-	/* 286 */ "cmd ::= REINDEX nm dbnm",
-	/* 287 */ "cmd ::= ANALYZE",
-	/* 288 */ "cmd ::= ANALYZE nm dbnm",
-	/* 289 */ "cmd ::= ALTER TABLE fullname RENAME TO nm",
-	/* 290 */ "cmd ::= ALTER TABLE add_column_fullname ADD kwcolumn_opt columnname carglist",
-	/* 291 */ "cmd ::= ALTER TABLE fullname DROP kwcolumn_opt nm",
-	/* 292 */ "add_column_fullname ::= fullname",
-	/* 293 */ "cmd ::= ALTER TABLE fullname RENAME kwcolumn_opt nm TO nm",
-	/* 294 */ "cmd ::= create_vtab",
-	/* 295 */ "cmd ::= create_vtab LP vtabarglist RP",
-	/* 296 */ "create_vtab ::= createkw VIRTUAL TABLE ifnotexists nm dbnm USING nm",
-	/* 297 */ "vtabarg ::=",
-	/* 298 */ "vtabargtoken ::= ANY",
-	/* 299 */ "vtabargtoken ::= lp anylist RP",
-	/* 300 */ "lp ::= LP",
-	/* 301 */ "with ::= WITH wqlist",
-	/* 302 */ "with ::= WITH RECURSIVE wqlist",
-	/* 303 */ "wqas ::= AS",
-	/* 304 */ "wqas ::= AS MATERIALIZED",
-	/* 305 */ "wqas ::= AS NOT MATERIALIZED",
-	/* 306 */ "wqitem ::= nm eidlist_opt wqas LP select RP",
-	/* 307 */ "wqlist ::= wqitem",
-	/* 308 */ "wqlist ::= wqlist COMMA wqitem",
-	/* 309 */ "windowdefn_list ::= windowdefn",
-	/* 310 */ "windowdefn_list ::= windowdefn_list COMMA windowdefn",
-	/* 311 */ "windowdefn ::= nm AS LP window RP",
-	/* 312 */ "window ::= PARTITION BY nexprlist orderby_opt frame_opt",
-	/* 313 */ "window ::= nm PARTITION BY nexprlist orderby_opt frame_opt",
-	/* 314 */ "window ::= ORDER BY sortlist frame_opt",
-	/* 315 */ "window ::= nm ORDER BY sortlist frame_opt",
-	/* 316 */ "window ::= frame_opt",
-	/* 317 */ "window ::= nm frame_opt",
-	/* 318 */ "frame_opt ::=",
-	/* 319 */ "frame_opt ::= range_or_rows frame_bound_s frame_exclude_opt",
-	/* 320 */ "frame_opt ::= range_or_rows BETWEEN frame_bound_s AND frame_bound_e frame_exclude_opt",
-	/* 321 */ "range_or_rows ::= RANGE|ROWS|GROUPS",
-	/* 322 */ "frame_bound_s ::= frame_bound",
-	/* 323 */ "frame_bound_s ::= UNBOUNDED PRECEDING",
-	/* 324 */ "frame_bound_e ::= frame_bound",
-	/* 325 */ "frame_bound_e ::= UNBOUNDED FOLLOWING",
-	/* 326 */ "frame_bound ::= expr PRECEDING|FOLLOWING",
-	/* 327 */ "frame_bound ::= CURRENT ROW",
-	/* 328 */ "frame_exclude_opt ::=",
-	/* 329 */ "frame_exclude_opt ::= EXCLUDE frame_exclude",
-	/* 330 */ "frame_exclude ::= NO OTHERS",
-	/* 331 */ "frame_exclude ::= CURRENT ROW",
-	/* 332 */ "frame_exclude ::= GROUP|TIES",
-	/* 333 */ "window_clause ::= WINDOW windowdefn_list",
-	/* 334 */ "filter_over ::= filter_clause over_clause",
-	/* 335 */ "filter_over ::= over_clause",
-	/* 336 */ "filter_over ::= filter_clause",
-	/* 337 */ "over_clause ::= OVER LP window RP",
-	/* 338 */ "over_clause ::= OVER nm",
-	/* 339 */ "filter_clause ::= FILTER LP WHERE expr RP",
-	/* 340 */ "input ::= cmdlist",
-	/* 341 */ "cmdlist ::= cmdlist ecmd",
-	/* 342 */ "cmdlist ::= ecmd",
-	/* 343 */ "ecmd ::= SEMI",
-	/* 344 */ "ecmd ::= cmdx SEMI",
-	/* 345 */ "ecmd ::= explain cmdx SEMI",
-	/* 346 */ "trans_opt ::=",
-	/* 347 */ "trans_opt ::= TRANSACTION",
-	/* 348 */ "trans_opt ::= TRANSACTION nm",
-	/* 349 */ "savepoint_opt ::= SAVEPOINT",
-	/* 350 */ "savepoint_opt ::=",
-	/* 351 */ "cmd ::= create_table create_table_args",
-	/* 352 */ "table_option_set ::= table_option",
-	/* 353 */ "columnlist ::= columnlist COMMA columnname carglist",
-	/* 354 */ "columnlist ::= columnname carglist",
-	/* 355 */ "nm ::= ID|INDEXED",
-	/* 356 */ "nm ::= STRING",
-	/* 357 */ "nm ::= JOIN_KW",
-	/* 358 */ "typetoken ::= typename",
-	/* 359 */ "typename ::= ID|STRING",
-	/* 360 */ "signed ::= plus_num",
-	/* 361 */ "signed ::= minus_num",
-	/* 362 */ "carglist ::= carglist ccons",
-	/* 363 */ "carglist ::=",
-	/* 364 */ "ccons ::= NULL onconf",
-	/* 365 */ "ccons ::= GENERATED ALWAYS AS generated",
-	/* 366 */ "ccons ::= AS generated",
-	/* 367 */ "conslist_opt ::= COMMA conslist",
-	/* 368 */ "conslist ::= conslist tconscomma tcons",
-	/* 369 */ "conslist ::= tcons",
-	/* 370 */ "tconscomma ::=",
-	/* 371 */ "defer_subclause_opt ::= defer_subclause",
-	/* 372 */ "resolvetype ::= raisetype",
-	/* 373 */ "selectnowith ::= oneselect",
-	/* 374 */ "oneselect ::= values",
-	/* 375 */ "sclp ::= selcollist COMMA",
-	/* 376 */ "as ::= ID|STRING",
-	/* 377 */ "indexed_opt ::= indexed_by",
-	/* 378 */ "returning ::=",
-	/* 379 */ "expr ::= term",
-	/* 380 */ "likeop ::= LIKE_KW|MATCH",
-	/* 381 */ "exprlist ::= nexprlist",
-	/* 382 */ "nmnum ::= plus_num",
-	/* 383 */ "nmnum ::= nm",
-	/* 384 */ "nmnum ::= ON",
-	/* 385 */ "nmnum ::= DELETE",
-	/* 386 */ "nmnum ::= DEFAULT",
-	/* 387 */ "plus_num ::= INTEGER|FLOAT",
-	/* 388 */ "foreach_clause ::=",
-	/* 389 */ "foreach_clause ::= FOR EACH ROW",
-	/* 390 */ "trnm ::= nm",
-	/* 391 */ "tridxby ::=",
-	/* 392 */ "database_kw_opt ::= DATABASE",
-	/* 393 */ "database_kw_opt ::=",
-	/* 394 */ "kwcolumn_opt ::=",
-	/* 395 */ "kwcolumn_opt ::= COLUMNKW",
-	/* 396 */ "vtabarglist ::= vtabarg",
-	/* 397 */ "vtabarglist ::= vtabarglist COMMA vtabarg",
-	/* 398 */ "vtabarg ::= vtabarg vtabargtoken",
-	/* 399 */ "anylist ::=",
-	/* 400 */ "anylist ::= anylist LP anylist RP",
-	/* 401 */ "anylist ::= anylist ANY",
-	/* 402 */ "with ::=",
+	/*  45 */ "ccons ::= GENERATED ALWAYS AS generated",
+	/*  46 */ "ccons ::= AS generated",
+	/*  47 */ "generated ::= LP expr RP",
+	/*  48 */ "generated ::= LP expr RP ID",
+	/*  49 */ "autoinc ::=",
+	/*  50 */ "autoinc ::= AUTOINCR",
+	/*  51 */ "refargs ::=",
+	/*  52 */ "refargs ::= refargs refarg",
+	/*  53 */ "refarg ::= MATCH nm",
+	/*  54 */ "refarg ::= ON INSERT refact",
+	/*  55 */ "refarg ::= ON DELETE refact",
+	/*  56 */ "refarg ::= ON UPDATE refact",
+	/*  57 */ "refact ::= SET NULL",
+	/*  58 */ "refact ::= SET DEFAULT",
+	/*  59 */ "refact ::= CASCADE",
+	/*  60 */ "refact ::= RESTRICT",
+	/*  61 */ "refact ::= NO ACTION",
+	/*  62 */ "defer_subclause ::= NOT DEFERRABLE init_deferred_pred_opt",
+	/*  63 */ "defer_subclause ::= DEFERRABLE init_deferred_pred_opt",
+	/*  64 */ "init_deferred_pred_opt ::=",
+	/*  65 */ "init_deferred_pred_opt ::= INITIALLY DEFERRED",
+	/*  66 */ "init_deferred_pred_opt ::= INITIALLY IMMEDIATE",
+	/*  67 */ "conslist_opt ::=",
+	/*  68 */ "tconscomma ::= COMMA",
+	/*  69 */ "tcons ::= CONSTRAINT nm",
+	/*  70 */ "tcons ::= PRIMARY KEY LP sortlist autoinc RP onconf",
+	/*  71 */ "tcons ::= UNIQUE LP sortlist RP onconf",
+	/*  72 */ "tcons ::= CHECK LP expr RP onconf",
+	/*  73 */ "tcons ::= FOREIGN KEY LP eidlist RP REFERENCES nm eidlist_opt refargs defer_subclause_opt",
+	/*  74 */ "defer_subclause_opt ::=",
+	/*  75 */ "onconf ::=",
+	/*  76 */ "onconf ::= ON CONFLICT resolvetype",
+	/*  77 */ "orconf ::=",
+	/*  78 */ "orconf ::= OR resolvetype",
+	/*  79 */ "resolvetype ::= IGNORE",
+	/*  80 */ "resolvetype ::= REPLACE",
+	/*  81 */ "cmd ::= DROP TABLE ifexists fullname",
+	/*  82 */ "ifexists ::= IF EXISTS",
+	/*  83 */ "ifexists ::=",
+	/*  84 */ "cmd ::= createkw temp VIEW ifnotexists nm dbnm eidlist_opt AS select",
+	/*  85 */ "cmd ::= DROP VIEW ifexists fullname",
+	/*  86 */ "cmd ::= select",
+	/*  87 */ "select ::= WITH wqlist selectnowith",
+	/*  88 */ "select ::= WITH RECURSIVE wqlist selectnowith",
+	/*  89 */ "select ::= selectnowith",
+	/*  90 */ "selectnowith ::= selectnowith multiselect_op oneselect",
+	/*  91 */ "multiselect_op ::= UNION",
+	/*  92 */ "multiselect_op ::= UNION ALL",
+	/*  93 */ "multiselect_op ::= EXCEPT|INTERSECT",
+	/*  94 */ "oneselect ::= SELECT distinct selcollist from where_opt groupby_opt having_opt orderby_opt limit_opt",
+	/*  95 */ "oneselect ::= SELECT distinct selcollist from where_opt groupby_opt having_opt window_clause orderby_opt limit_opt",
+	/*  96 */ "values ::= VALUES LP nexprlist RP",
+	/*  97 */ "values ::= values COMMA LP nexprlist RP",
+	/*  98 */ "distinct ::= DISTINCT",
+	/*  99 */ "distinct ::= ALL",
+	/* 100 */ "distinct ::=",
+	/* 101 */ "sclp ::=",
+	/* 102 */ "selcollist ::= sclp scanpt expr scanpt as",
+	/* 103 */ "selcollist ::= sclp scanpt STAR",
+	/* 104 */ "selcollist ::= sclp scanpt nm DOT STAR",
+	/* 105 */ "as ::= AS nm",
+	/* 106 */ "as ::=",
+	/* 107 */ "from ::=",
+	/* 108 */ "from ::= FROM seltablist",
+	/* 109 */ "stl_prefix ::= seltablist joinop",
+	/* 110 */ "stl_prefix ::=",
+	/* 111 */ "seltablist ::= stl_prefix nm dbnm as on_using",
+	/* 112 */ "seltablist ::= stl_prefix nm dbnm as indexed_by on_using",
+	/* 113 */ "seltablist ::= stl_prefix nm dbnm LP exprlist RP as on_using",
+	/* 114 */ "seltablist ::= stl_prefix LP select RP as on_using",
+	/* 115 */ "seltablist ::= stl_prefix LP seltablist RP as on_using",
+	/* 116 */ "dbnm ::=",
+	/* 117 */ "dbnm ::= DOT nm",
+	/* 118 */ "fullname ::= nm",
+	/* 119 */ "fullname ::= nm DOT nm",
+	/* 120 */ "xfullname ::= nm",
+	/* 121 */ "xfullname ::= nm DOT nm",
+	/* 122 */ "xfullname ::= nm DOT nm AS nm",
+	/* 123 */ "xfullname ::= nm AS nm",
+	/* 124 */ "joinop ::= COMMA|JOIN",
+	/* 125 */ "joinop ::= JOIN_KW JOIN",
+	/* 126 */ "joinop ::= JOIN_KW nm JOIN",
+	/* 127 */ "joinop ::= JOIN_KW nm nm JOIN",
+	/* 128 */ "on_using ::= ON expr",
+	/* 129 */ "on_using ::= USING LP idlist RP",
+	/* 130 */ "on_using ::=",
+	/* 131 */ "indexed_opt ::=",
+	/* 132 */ "indexed_by ::= INDEXED BY nm",
+	/* 133 */ "indexed_by ::= NOT INDEXED",
+	/* 134 */ "orderby_opt ::=",
+	/* 135 */ "orderby_opt ::= ORDER BY sortlist",
+	/* 136 */ "sortlist ::= sortlist COMMA expr sortorder nulls",
+	/* 137 */ "sortlist ::= expr sortorder nulls",
+	/* 138 */ "sortorder ::= ASC",
+	/* 139 */ "sortorder ::= DESC",
+	/* 140 */ "sortorder ::=",
+	/* 141 */ "nulls ::= NULLS FIRST",
+	/* 142 */ "nulls ::= NULLS LAST",
+	/* 143 */ "nulls ::=",
+	/* 144 */ "groupby_opt ::=",
+	/* 145 */ "groupby_opt ::= GROUP BY nexprlist",
+	/* 146 */ "having_opt ::=",
+	/* 147 */ "having_opt ::= HAVING expr",
+	/* 148 */ "limit_opt ::=",
+	/* 149 */ "limit_opt ::= LIMIT expr",
+	/* 150 */ "limit_opt ::= LIMIT expr OFFSET expr",
+	/* 151 */ "limit_opt ::= LIMIT expr COMMA expr",
+	/* 152 */ "cmd ::= with DELETE FROM xfullname indexed_opt where_opt_ret orderby_opt limit_opt",
+	/* 153 */ "where_opt ::=",
+	/* 154 */ "where_opt ::= WHERE expr",
+	/* 155 */ "where_opt_ret ::=",
+	/* 156 */ "where_opt_ret ::= WHERE expr",
+	/* 157 */ "where_opt_ret ::= RETURNING selcollist",
+	/* 158 */ "where_opt_ret ::= WHERE expr RETURNING selcollist",
+	/* 159 */ "cmd ::= with UPDATE orconf xfullname indexed_opt SET setlist from where_opt_ret orderby_opt limit_opt",
+	/* 160 */ "setlist ::= setlist COMMA nm EQ expr",
+	/* 161 */ "setlist ::= setlist COMMA LP idlist RP EQ expr",
+	/* 162 */ "setlist ::= nm EQ expr",
+	/* 163 */ "setlist ::= LP idlist RP EQ expr",
+	/* 164 */ "cmd ::= with insert_cmd INTO xfullname idlist_opt select upsert",
+	/* 165 */ "cmd ::= with insert_cmd INTO xfullname idlist_opt DEFAULT VALUES returning",
+	/* 166 */ "upsert ::=",
+	/* 167 */ "upsert ::= RETURNING selcollist",
+	/* 168 */ "upsert ::= ON CONFLICT LP sortlist RP where_opt DO UPDATE SET setlist where_opt upsert",
+	/* 169 */ "upsert ::= ON CONFLICT LP sortlist RP where_opt DO NOTHING upsert",
+	/* 170 */ "upsert ::= ON CONFLICT DO NOTHING returning",
+	/* 171 */ "upsert ::= ON CONFLICT DO UPDATE SET setlist where_opt returning",
+	/* 172 */ "returning ::= RETURNING selcollist",
+	/* 173 */ "insert_cmd ::= INSERT orconf",
+	/* 174 */ "insert_cmd ::= REPLACE",
+	/* 175 */ "idlist_opt ::=",
+	/* 176 */ "idlist_opt ::= LP idlist RP",
+	/* 177 */ "idlist ::= idlist COMMA nm",
+	/* 178 */ "idlist ::= nm",
+	/* 179 */ "expr ::= LP expr RP",
+	/* 180 */ "expr ::= ID|INDEXED",
+	/* 181 */ "expr ::= JOIN_KW",
+	/* 182 */ "expr ::= nm DOT nm",
+	/* 183 */ "expr ::= nm DOT nm DOT nm",
+	/* 184 */ "term ::= NULL|FLOAT|BLOB",
+	/* 185 */ "term ::= STRING",
+	/* 186 */ "term ::= INTEGER",
+	/* 187 */ "expr ::= VARIABLE",
+	/* 188 */ "expr ::= expr COLLATE ID|STRING",
+	/* 189 */ "expr ::= CAST LP expr AS typetoken RP",
+	/* 190 */ "expr ::= ID|INDEXED LP distinct exprlist RP",
+	/* 191 */ "expr ::= ID|INDEXED LP STAR RP",
+	/* 192 */ "expr ::= ID|INDEXED LP distinct exprlist RP filter_over",
+	/* 193 */ "expr ::= ID|INDEXED LP STAR RP filter_over",
+	/* 194 */ "term ::= CTIME_KW",
+	/* 195 */ "expr ::= LP nexprlist COMMA expr RP",
+	/* 196 */ "expr ::= expr AND expr",
+	/* 197 */ "expr ::= expr OR expr",
+	/* 198 */ "expr ::= expr LT|GT|GE|LE expr",
+	/* 199 */ "expr ::= expr EQ|NE expr",
+	/* 200 */ "expr ::= expr BITAND|BITOR|LSHIFT|RSHIFT expr",
+	/* 201 */ "expr ::= expr PLUS|MINUS expr",
+	/* 202 */ "expr ::= expr STAR|SLASH|REM expr",
+	/* 203 */ "expr ::= expr CONCAT expr",
+	/* 204 */ "likeop ::= NOT LIKE_KW|MATCH",
+	/* 205 */ "expr ::= expr likeop expr",
+	/* 206 */ "expr ::= expr likeop expr ESCAPE expr",
+	/* 207 */ "expr ::= expr ISNULL|NOTNULL",
+	/* 208 */ "expr ::= expr NOT NULL",
+	/* 209 */ "expr ::= expr IS expr",
+	/* 210 */ "expr ::= expr IS NOT expr",
+	/* 211 */ "expr ::= expr IS NOT DISTINCT FROM expr",
+	/* 212 */ "expr ::= expr IS DISTINCT FROM expr",
+	/* 213 */ "expr ::= NOT expr",
+	/* 214 */ "expr ::= BITNOT expr",
+	/* 215 */ "expr ::= PLUS|MINUS expr",
+	/* 216 */ "expr ::= expr PTR expr",
+	/* 217 */ "between_op ::= BETWEEN",
+	/* 218 */ "between_op ::= NOT BETWEEN",
+	/* 219 */ "expr ::= expr between_op expr AND expr",
+	/* 220 */ "in_op ::= IN",
+	/* 221 */ "in_op ::= NOT IN",
+	/* 222 */ "expr ::= expr in_op LP exprlist RP",
+	/* 223 */ "expr ::= LP select RP",
+	/* 224 */ "expr ::= expr in_op LP select RP",
+	/* 225 */ "expr ::= expr in_op nm dbnm paren_exprlist",
+	/* 226 */ "expr ::= EXISTS LP select RP",
+	/* 227 */ "expr ::= CASE case_operand case_exprlist case_else END",
+	/* 228 */ "case_exprlist ::= case_exprlist WHEN expr THEN expr",
+	/* 229 */ "case_exprlist ::= WHEN expr THEN expr",
+	/* 230 */ "case_else ::= ELSE expr",
+	/* 231 */ "case_else ::=",
+	/* 232 */ "case_operand ::=",
+	/* 233 */ "exprlist ::=",
+	/* 234 */ "nexprlist ::= nexprlist COMMA expr",
+	/* 235 */ "nexprlist ::= expr",
+	/* 236 */ "paren_exprlist ::=",
+	/* 237 */ "paren_exprlist ::= LP exprlist RP",
+	/* 238 */ "cmd ::= createkw uniqueflag INDEX ifnotexists nm dbnm ON nm LP sortlist RP where_opt",
+	/* 239 */ "uniqueflag ::= UNIQUE",
+	/* 240 */ "uniqueflag ::=",
+	/* 241 */ "eidlist_opt ::=",
+	/* 242 */ "eidlist_opt ::= LP eidlist RP",
+	/* 243 */ "eidlist ::= eidlist COMMA nm collate sortorder",
+	/* 244 */ "eidlist ::= nm collate sortorder",
+	/* 245 */ "collate ::=",
+	/* 246 */ "collate ::= COLLATE ID|STRING",
+	/* 247 */ "cmd ::= DROP INDEX ifexists fullname",
+	/* 248 */ "cmd ::= VACUUM vinto",
+	/* 249 */ "cmd ::= VACUUM nm vinto",
+	/* 250 */ "vinto ::= INTO expr",
+	/* 251 */ "vinto ::=",
+	/* 252 */ "cmd ::= PRAGMA nm dbnm",
+	/* 253 */ "cmd ::= PRAGMA nm dbnm EQ nmnum",
+	/* 254 */ "cmd ::= PRAGMA nm dbnm LP nmnum RP",
+	/* 255 */ "cmd ::= PRAGMA nm dbnm EQ minus_num",
+	/* 256 */ "cmd ::= PRAGMA nm dbnm LP minus_num RP",
+	/* 257 */ "plus_num ::= PLUS INTEGER|FLOAT",
+	/* 258 */ "minus_num ::= MINUS INTEGER|FLOAT",
+	/* 259 */ "cmd ::= createkw trigger_decl BEGIN trigger_cmd_list END",
+	/* 260 */ "trigger_decl ::= temp TRIGGER ifnotexists nm dbnm trigger_time trigger_event ON fullname foreach_clause when_clause",
+	/* 261 */ "trigger_time ::= BEFORE|AFTER",
+	/* 262 */ "trigger_time ::= INSTEAD OF",
+	/* 263 */ "trigger_time ::=",
+	/* 264 */ "trigger_event ::= DELETE|INSERT",
+	/* 265 */ "trigger_event ::= UPDATE",
+	/* 266 */ "trigger_event ::= UPDATE OF idlist",
+	/* 267 */ "when_clause ::=",
+	/* 268 */ "when_clause ::= WHEN expr",
+	/* 269 */ "trigger_cmd_list ::= trigger_cmd_list trigger_cmd SEMI",
+	/* 270 */ "trigger_cmd_list ::= trigger_cmd SEMI",
+	/* 271 */ "trnm ::= nm DOT nm",
+	/* 272 */ "tridxby ::= INDEXED BY nm",
+	/* 273 */ "tridxby ::= NOT INDEXED",
+	/* 274 */ "trigger_cmd ::= UPDATE orconf trnm tridxby SET setlist from where_opt scanpt",
+	/* 275 */ "trigger_cmd ::= scanpt insert_cmd INTO trnm idlist_opt select upsert scanpt",
+	/* 276 */ "trigger_cmd ::= DELETE FROM trnm tridxby where_opt scanpt",
+	/* 277 */ "trigger_cmd ::= scanpt select scanpt",
+	/* 278 */ "expr ::= RAISE LP IGNORE RP",
+	/* 279 */ "expr ::= RAISE LP raisetype COMMA nm RP",
+	/* 280 */ "raisetype ::= ROLLBACK",
+	/* 281 */ "raisetype ::= ABORT",
+	/* 282 */ "raisetype ::= FAIL",
+	/* 283 */ "cmd ::= DROP TRIGGER ifexists fullname",
+	/* 284 */ "cmd ::= ATTACH database_kw_opt expr AS expr key_opt",
+	/* 285 */ "cmd ::= DETACH database_kw_opt expr",
+	/* 286 */ "key_opt ::=",
+	/* 287 */ "key_opt ::= KEY expr",
+	/* 288 */ "cmd ::= REINDEX",
+	/* 289 */ "cmd ::= REINDEX nm dbnm",
+	/* 290 */ "cmd ::= ANALYZE",
+	/* 291 */ "cmd ::= ANALYZE nm dbnm",
+	/* 292 */ "cmd ::= ALTER TABLE fullname RENAME TO nm",
+	/* 293 */ "cmd ::= ALTER TABLE add_column_fullname ADD kwcolumn_opt columnname carglist",
+	/* 294 */ "cmd ::= ALTER TABLE fullname DROP kwcolumn_opt nm",
+	/* 295 */ "add_column_fullname ::= fullname",
+	/* 296 */ "cmd ::= ALTER TABLE fullname RENAME kwcolumn_opt nm TO nm",
+	/* 297 */ "cmd ::= create_vtab",
+	/* 298 */ "cmd ::= create_vtab LP vtabarglist RP",
+	/* 299 */ "create_vtab ::= createkw VIRTUAL TABLE ifnotexists nm dbnm USING nm",
+	/* 300 */ "vtabarg ::=",
+	/* 301 */ "vtabargtoken ::= ANY",
+	/* 302 */ "vtabargtoken ::= lp anylist RP",
+	/* 303 */ "lp ::= LP",
+	/* 304 */ "with ::= WITH wqlist",
+	/* 305 */ "with ::= WITH RECURSIVE wqlist",
+	/* 306 */ "wqas ::= AS",
+	/* 307 */ "wqas ::= AS MATERIALIZED",
+	/* 308 */ "wqas ::= AS NOT MATERIALIZED",
+	/* 309 */ "wqitem ::= nm eidlist_opt wqas LP select RP",
+	/* 310 */ "wqlist ::= wqitem",
+	/* 311 */ "wqlist ::= wqlist COMMA wqitem",
+	/* 312 */ "windowdefn_list ::= windowdefn",
+	/* 313 */ "windowdefn_list ::= windowdefn_list COMMA windowdefn",
+	/* 314 */ "windowdefn ::= nm AS LP window RP",
+	/* 315 */ "window ::= PARTITION BY nexprlist orderby_opt frame_opt",
+	/* 316 */ "window ::= nm PARTITION BY nexprlist orderby_opt frame_opt",
+	/* 317 */ "window ::= ORDER BY sortlist frame_opt",
+	/* 318 */ "window ::= nm ORDER BY sortlist frame_opt",
+	/* 319 */ "window ::= frame_opt",
+	/* 320 */ "window ::= nm frame_opt",
+	/* 321 */ "frame_opt ::=",
+	/* 322 */ "frame_opt ::= range_or_rows frame_bound_s frame_exclude_opt",
+	/* 323 */ "frame_opt ::= range_or_rows BETWEEN frame_bound_s AND frame_bound_e frame_exclude_opt",
+	/* 324 */ "range_or_rows ::= RANGE|ROWS|GROUPS",
+	/* 325 */ "frame_bound_s ::= frame_bound",
+	/* 326 */ "frame_bound_s ::= UNBOUNDED PRECEDING",
+	/* 327 */ "frame_bound_e ::= frame_bound",
+	/* 328 */ "frame_bound_e ::= UNBOUNDED FOLLOWING",
+	/* 329 */ "frame_bound ::= expr PRECEDING|FOLLOWING",
+	/* 330 */ "frame_bound ::= CURRENT ROW",
+	/* 331 */ "frame_exclude_opt ::=",
+	/* 332 */ "frame_exclude_opt ::= EXCLUDE frame_exclude",
+	/* 333 */ "frame_exclude ::= NO OTHERS",
+	/* 334 */ "frame_exclude ::= CURRENT ROW",
+	/* 335 */ "frame_exclude ::= GROUP|TIES",
+	/* 336 */ "window_clause ::= WINDOW windowdefn_list",
+	/* 337 */ "filter_over ::= filter_clause over_clause",
+	/* 338 */ "filter_over ::= over_clause",
+	/* 339 */ "filter_over ::= filter_clause",
+	/* 340 */ "over_clause ::= OVER LP window RP",
+	/* 341 */ "over_clause ::= OVER nm",
+	/* 342 */ "filter_clause ::= FILTER LP WHERE expr RP",
+	/* 343 */ "input ::= cmdlist",
+	/* 344 */ "cmdlist ::= cmdlist ecmd",
+	/* 345 */ "cmdlist ::= ecmd",
+	/* 346 */ "ecmd ::= SEMI",
+	/* 347 */ "ecmd ::= cmdx SEMI",
+	/* 348 */ "ecmd ::= explain cmdx SEMI",
+	/* 349 */ "trans_opt ::=",
+	/* 350 */ "trans_opt ::= TRANSACTION",
+	/* 351 */ "trans_opt ::= TRANSACTION nm",
+	/* 352 */ "savepoint_opt ::= SAVEPOINT",
+	/* 353 */ "savepoint_opt ::=",
+	/* 354 */ "cmd ::= create_table create_table_args",
+	/* 355 */ "table_option_set ::= table_option",
+	/* 356 */ "columnlist ::= columnlist COMMA columnname carglist",
+	/* 357 */ "columnlist ::= columnname carglist",
+	/* 358 */ "nm ::= ID|INDEXED",
+	/* 359 */ "nm ::= STRING",
+	/* 360 */ "nm ::= JOIN_KW",
+	/* 361 */ "typetoken ::= typename",
+	/* 362 */ "typename ::= ID|STRING",
+	/* 363 */ "signed ::= plus_num",
+	/* 364 */ "signed ::= minus_num",
+	/* 365 */ "carglist ::= carglist ccons",
+	/* 366 */ "carglist ::=",
+	/* 367 */ "ccons ::= NULL onconf",
+	/* 368 */ "conslist_opt ::= COMMA conslist",
+	/* 369 */ "conslist ::= conslist tconscomma tcons",
+	/* 370 */ "conslist ::= tcons",
+	/* 371 */ "tconscomma ::=",
+	/* 372 */ "defer_subclause_opt ::= defer_subclause",
+	/* 373 */ "resolvetype ::= raisetype",
+	/* 374 */ "selectnowith ::= oneselect",
+	/* 375 */ "oneselect ::= values",
+	/* 376 */ "sclp ::= selcollist COMMA",
+	/* 377 */ "as ::= ID|STRING",
+	/* 378 */ "indexed_opt ::= indexed_by",
+	/* 379 */ "returning ::=",
+	/* 380 */ "expr ::= term",
+	/* 381 */ "likeop ::= LIKE_KW|MATCH",
+	/* 382 */ "case_operand ::= expr",
+	/* 383 */ "exprlist ::= nexprlist",
+	/* 384 */ "nmnum ::= plus_num",
+	/* 385 */ "nmnum ::= nm",
+	/* 386 */ "nmnum ::= ON",
+	/* 387 */ "nmnum ::= DELETE",
+	/* 388 */ "nmnum ::= DEFAULT",
+	/* 389 */ "plus_num ::= INTEGER|FLOAT",
+	/* 390 */ "foreach_clause ::=",
+	/* 391 */ "foreach_clause ::= FOR EACH ROW",
+	/* 392 */ "trnm ::= nm",
+	/* 393 */ "tridxby ::=",
+	/* 394 */ "database_kw_opt ::= DATABASE",
+	/* 395 */ "database_kw_opt ::=",
+	/* 396 */ "kwcolumn_opt ::=",
+	/* 397 */ "kwcolumn_opt ::= COLUMNKW",
+	/* 398 */ "vtabarglist ::= vtabarg",
+	/* 399 */ "vtabarglist ::= vtabarglist COMMA vtabarg",
+	/* 400 */ "vtabarg ::= vtabarg vtabargtoken",
+	/* 401 */ "anylist ::=",
+	/* 402 */ "anylist ::= anylist LP anylist RP",
+	/* 403 */ "anylist ::= anylist ANY",
+	/* 404 */ "with ::=",
 }
 
 /*
@@ -2248,7 +2277,11 @@ func (yypParser *yyParser) sqlite3ParserInit(pParse *ctxDecl) {
 	if !YYNOERRORRECOVERY {
 		yypParser.yyerrcnt = -1
 	}
-	if YYSTACKDEPTH > 0 {
+	yypParser.yystackDepth = YYSTACKDEPTH
+	if cap(yypParser.yystack) > 0 {
+		/* Reuse the stack left behind by a prior sqlite3ParserFinalize() */
+		yypParser.yystack = yypParser.yystack[:cap(yypParser.yystack)]
+	} else if YYSTACKDEPTH > 0 {
 		yypParser.yystack = make([]yyStackEntry, YYSTACKDEPTH)
 	} else {
 		yypParser.yystack = []yyStackEntry{{}}
@@ -2307,7 +2340,7 @@ func (yypParser *yyParser) yy_destructor(
     case 240: /* oneselect */
     case 252: /* values */
 {
-//line 513 "parse.y"
+//line 520 "parse.y"
 sqlite3SelectDelete(pParse.db, (yypminor.yy361));
 //line 2346 "parse.go"
 }
@@ -2324,7 +2357,7 @@ sqlite3SelectDelete(pParse.db, (yypminor.yy361));
     case 295: /* key_opt */
     case 311: /* filter_clause */
 {
-//line 1059 "parse.y"
+//line 1081 "parse.y"
 sqlite3ExprDelete(pParse.db, (yypminor.yy634));
 //line 2363 "parse.go"
 }
@@ -2343,7 +2376,7 @@ sqlite3ExprDelete(pParse.db, (yypminor.yy634));
     case 279: /* case_exprlist */
     case 310: /* part_opt */
 {
-//line 1477 "parse.y"
+//line 1604 "parse.y"
 sqlite3ExprListDelete(pParse.db, (yypminor.yy614));
 //line 2382 "parse.go"
 }
@@ -2354,14 +2387,14 @@ sqlite3ExprListDelete(pParse.db, (yypminor.yy614));
     case 257: /* stl_prefix */
     case 262: /* xfullname */
 {
-//line 779 "parse.y"
+//line 784 "parse.y"
 sqlite3SrcListDelete(pParse.db, (yypminor.yy157));
 //line 2393 "parse.go"
 }
       break
     case 241: /* wqlist */
 {
-//line 1768 "parse.y"
+//line 1898 "parse.y"
 sqlite3WithDelete(pParse.db, (yypminor.yy357));
 //line 2400 "parse.go"
 }
@@ -2369,7 +2402,7 @@ sqlite3WithDelete(pParse.db, (yypminor.yy357));
     case 251: /* window_clause */
     case 306: /* windowdefn_list */
 {
-//line 1897 "parse.y"
+//line 2033 "parse.y"
 sqlite3WindowListDelete(pParse.db, (yypminor.yy179));
 //line 2408 "parse.go"
 }
@@ -2377,7 +2410,7 @@ sqlite3WindowListDelete(pParse.db, (yypminor.yy179));
     case 263: /* idlist */
     case 270: /* idlist_opt */
 {
-//line 1044 "parse.y"
+//line 1066 "parse.y"
 sqlite3IdListDelete(pParse.db, (yypminor.yy106));
 //line 2416 "parse.go"
 }
@@ -2388,7 +2421,7 @@ sqlite3IdListDelete(pParse.db, (yypminor.yy106));
     case 309: /* frame_opt */
     case 312: /* over_clause */
 {
-//line 1834 "parse.y"
+//line 1970 "parse.y"
 sqlite3WindowDelete(pParse.db, (yypminor.yy179));
 //line 2427 "parse.go"
 }
@@ -2396,14 +2429,14 @@ sqlite3WindowDelete(pParse.db, (yypminor.yy179));
     case 286: /* trigger_cmd_list */
     case 291: /* trigger_cmd */
 {
-//line 1596 "parse.y"
+//line 1722 "parse.y"
 sqlite3DeleteTriggerStep(pParse.db, (yypminor.yy429));
 //line 2435 "parse.go"
 }
       break
     case 288: /* trigger_event */
 {
-//line 1582 "parse.y"
+//line 1708 "parse.y"
 sqlite3IdListDelete(pParse.db, (yypminor.yy121).b);
 //line 2442 "parse.go"
 }
@@ -2412,7 +2445,7 @@ sqlite3IdListDelete(pParse.db, (yypminor.yy121).b);
     case 315: /* frame_bound_s */
     case 316: /* frame_bound_e */
 {
-//line 1839 "parse.y"
+//line 1975 "parse.y"
 sqlite3ExprDelete(pParse.db, (yypminor.yy600).pExpr);
 //line 2451 "parse.go"
 }
@@ -2450,6 +2483,15 @@ func (pParser *yyParser) sqlite3ParserFinalize() {
 	for pParser.yytos > 0 {
 		pParser.yy_pop_parser_stack()
 	}
+	/* Drop references to semantic values and to the %extra_argument and
+	 ** %extra_context, so that a parser kept for reuse does not hold on
+	 ** to the parse tree of the previous statement.  The stack itself is
+	 ** kept for the next sqlite3ParserInit() */
+	yystack := pParser.yystack
+	for i := range yystack {
+		yystack[i] = yyStackEntry{}
+	}
+	*pParser = yyParser{yystack: yystack[:0]}
 }
 
 /*
@@ -2511,6 +2553,21 @@ func sqlite3ParserCoverage(out io.Writer) int {
 	return nMissed
 }
 
+/*
+** Return true if the look-ahead token lookAhead is not valid in state
+** stateno, so that yy_find_shift_action() uses its %fallback instead.
+ */
+func yy_is_fallback(lookAhead YYCODETYPE, stateno YYACTIONTYPE) bool {
+	iLookAhead := int(lookAhead)
+	if !YYFALLBACK || stateno > YY_MAX_SHIFT {
+		return false
+	}
+	if iLookAhead >= len(yyFallback) || yyFallback[iLookAhead] == 0 {
+		return false
+	}
+	return int(yy_lookahead[int(yy_shift_ofst[stateno])+iLookAhead]) != iLookAhead
+}
+
 /*
 ** Find the appropriate action for a parser given the terminal
 ** look-ahead token iLookAhead.
@@ -2628,7 +2685,7 @@ func (yypParser *yyParser) yyStackOverflow() {
 //line 47 "parse.y"
 
   sqlite3ErrorMsg(pParse, "parser stack overflow");
-//line 2665 "parse.go"
+//line 2689 "parse.go"
 	/******** End %stack_overflow code ********************************************/
 	 /* Suppress warning about unused %extra_argument var */
 	yypParser.pParse=pParse
@@ -2670,15 +2727,14 @@ func (yypParser *yyParser) yy_shift(
 			assert(yypParser.yyhwm == yypParser.yytos, "yypParser.yyhwm == yypParser.yytos")
 		}
 	}
-	if YYSTACKDEPTH > 0 {
-		if yypParser.yytos >= YYSTACKDEPTH {
+	if yypParser.yystackDepth > 0 {
+		if yypParser.yytos >= yypParser.yystackDepth {
 			yypParser.yyStackOverflow()
 			return
 		}
-	} else {
-		if yypParser.yytos+1 >= len(yypParser.yystack) {
-			yypParser.yyGrowStack()
-		}
+	}
+	if yypParser.yytos+1 >= len(yypParser.yystack) {
+		yypParser.yyGrowStack()
 	}
 
 	if yyNewState > YY_MAX_SHIFT {
@@ -2741,364 +2797,366 @@ var yyRuleInfoLhs = []YYCODETYPE{
 	215, /* (42) ccons ::= REFERENCES nm eidlist_opt refargs */
 	215, /* (43) ccons ::= defer_subclause */
 	215, /* (44) ccons ::= COLLATE ID|STRING */
-	224, /* (45) generated ::= LP expr RP */
-	224, /* (46) generated ::= LP expr RP ID */
-	220, /* (47) autoinc ::= */
-	220, /* (48) autoinc ::= AUTOINCR */
-	222, /* (49) refargs ::= */
-	222, /* (50) refargs ::= refargs refarg */
-	225, /* (51) refarg ::= MATCH nm */
-	225, /* (52) refarg ::= ON INSERT refact */
-	225, /* (53) refarg ::= ON DELETE refact */
-	225, /* (54) refarg ::= ON UPDATE refact */
-	226, /* (55) refact ::= SET NULL */
-	226, /* (56) refact ::= SET DEFAULT */
-	226, /* (57) refact ::= CASCADE */
-	226, /* (58) refact ::= RESTRICT */
-	226, /* (59) refact ::= NO ACTION */
-	223, /* (60) defer_subclause ::= NOT DEFERRABLE init_deferred_pred_opt */
-	223, /* (61) defer_subclause ::= DEFERRABLE init_deferred_pred_opt */
-	227, /* (62) init_deferred_pred_opt ::= */
-	227, /* (63) init_deferred_pred_opt ::= INITIALLY DEFERRED */
-	227, /* (64) init_deferred_pred_opt ::= INITIALLY IMMEDIATE */
-	202, /* (65) conslist_opt ::= */
-	229, /* (66) tconscomma ::= COMMA */
-	230, /* (67) tcons ::= CONSTRAINT nm */
-	230, /* (68) tcons ::= PRIMARY KEY LP sortlist autoinc RP onconf */
-	230, /* (69) tcons ::= UNIQUE LP sortlist RP onconf */
-	230, /* (70) tcons ::= CHECK LP expr RP onconf */
-	230, /* (71) tcons ::= FOREIGN KEY LP eidlist RP REFERENCES nm eidlist_opt refargs defer_subclause_opt */
-	233, /* (72) defer_subclause_opt ::= */
-	218, /* (73) onconf ::= */
-	218, /* (74) onconf ::= ON CONFLICT resolvetype */
-	234, /* (75) orconf ::= */
-	234, /* (76) orconf ::= OR resolvetype */
-	235, /* (77) resolvetype ::= IGNORE */
-	235, /* (78) resolvetype ::= REPLACE */
-	190, /* (79) cmd ::= DROP TABLE ifexists fullname */
-	237, /* (80) ifexists ::= IF EXISTS */
-	237, /* (81) ifexists ::= */
-	190, /* (82) cmd ::= createkw temp VIEW ifnotexists nm dbnm eidlist_opt AS select */
-	190, /* (83) cmd ::= DROP VIEW ifexists fullname */
-	190, /* (84) cmd ::= select */
-	204, /* (85) select ::= WITH wqlist selectnowith */
-	204, /* (86) select ::= WITH RECURSIVE wqlist selectnowith */
-	204, /* (87) select ::= selectnowith */
-	239, /* (88) selectnowith ::= selectnowith multiselect_op oneselect */
-	242, /* (89) multiselect_op ::= UNION */
-	242, /* (90) multiselect_op ::= UNION ALL */
-	242, /* (91) multiselect_op ::= EXCEPT|INTERSECT */
-	240, /* (92) oneselect ::= SELECT distinct selcollist from where_opt groupby_opt having_opt orderby_opt limit_opt */
-	240, /* (93) oneselect ::= SELECT distinct selcollist from where_opt groupby_opt having_opt window_clause orderby_opt limit_opt */
-	252, /* (94) values ::= VALUES LP nexprlist RP */
-	252, /* (95) values ::= values COMMA LP nexprlist RP */
-	243, /* (96) distinct ::= DISTINCT */
-	243, /* (97) distinct ::= ALL */
-	243, /* (98) distinct ::= */
-	254, /* (99) sclp ::= */
-	244, /* (100) selcollist ::= sclp scanpt expr scanpt as */
-	244, /* (101) selcollist ::= sclp scanpt STAR */
-	244, /* (102) selcollist ::= sclp scanpt nm DOT STAR */
-	255, /* (103) as ::= AS nm */
-	255, /* (104) as ::= */
-	245, /* (105) from ::= */
-	245, /* (106) from ::= FROM seltablist */
-	257, /* (107) stl_prefix ::= seltablist joinop */
-	257, /* (108) stl_prefix ::= */
-	256, /* (109) seltablist ::= stl_prefix nm dbnm as on_using */
-	256, /* (110) seltablist ::= stl_prefix nm dbnm as indexed_by on_using */
-	256, /* (111) seltablist ::= stl_prefix nm dbnm LP exprlist RP as on_using */
-	256, /* (112) seltablist ::= stl_prefix LP select RP as on_using */
-	256, /* (113) seltablist ::= stl_prefix LP seltablist RP as on_using */
-	200, /* (114) dbnm ::= */
-	200, /* (115) dbnm ::= DOT nm */
-	238, /* (116) fullname ::= nm */
-	238, /* (117) fullname ::= nm DOT nm */
-	262, /* (118) xfullname ::= nm */
-	262, /* (119) xfullname ::= nm DOT nm */
-	262, /* (120) xfullname ::= nm DOT nm AS nm */
-	262, /* (121) xfullname ::= nm AS nm */
-	258, /* (122) joinop ::= COMMA|JOIN */
-	258, /* (123) joinop ::= JOIN_KW JOIN */
-	258, /* (124) joinop ::= JOIN_KW nm JOIN */
-	258, /* (125) joinop ::= JOIN_KW nm nm JOIN */
-	259, /* (126) on_using ::= ON expr */
-	259, /* (127) on_using ::= USING LP idlist RP */
-	259, /* (128) on_using ::= */
-	264, /* (129) indexed_opt ::= */
-	260, /* (130) indexed_by ::= INDEXED BY nm */
-	260, /* (131) indexed_by ::= NOT INDEXED */
-	249, /* (132) orderby_opt ::= */
-	249, /* (133) orderby_opt ::= ORDER BY sortlist */
-	231, /* (134) sortlist ::= sortlist COMMA expr sortorder nulls */
-	231, /* (135) sortlist ::= expr sortorder nulls */
-	219, /* (136) sortorder ::= ASC */
-	219, /* (137) sortorder ::= DESC */
-	219, /* (138) sortorder ::= */
-	265, /* (139) nulls ::= NULLS FIRST */
-	265, /* (140) nulls ::= NULLS LAST */
-	265, /* (141) nulls ::= */
-	247, /* (142) groupby_opt ::= */
-	247, /* (143) groupby_opt ::= GROUP BY nexprlist */
-	248, /* (144) having_opt ::= */
-	248, /* (145) having_opt ::= HAVING expr */
-	250, /* (146) limit_opt ::= */
-	250, /* (147) limit_opt ::= LIMIT expr */
-	250, /* (148) limit_opt ::= LIMIT expr OFFSET expr */
-	250, /* (149) limit_opt ::= LIMIT expr COMMA expr */
-	190, /* (150) cmd ::= with DELETE FROM xfullname indexed_opt where_opt_ret */
-	246, /* (151) where_opt ::= */
-	246, /* (152) where_opt ::= WHERE expr */
-	267, /* (153) where_opt_ret ::= */
-	267, /* (154) where_opt_ret ::= WHERE expr */
-	267, /* (155) where_opt_ret ::= RETURNING selcollist */
-	267, /* (156) where_opt_ret ::= WHERE expr RETURNING selcollist */
-	190, /* (157) cmd ::= with UPDATE orconf xfullname indexed_opt SET setlist from where_opt_ret */
-	268, /* (158) setlist ::= setlist COMMA nm EQ expr */
-	268, /* (159) setlist ::= setlist COMMA LP idlist RP EQ expr */
-	268, /* (160) setlist ::= nm EQ expr */
-	268, /* (161) setlist ::= LP idlist RP EQ expr */
-	190, /* (162) cmd ::= with insert_cmd INTO xfullname idlist_opt select upsert */
-	190, /* (163) cmd ::= with insert_cmd INTO xfullname idlist_opt DEFAULT VALUES returning */
-	271, /* (164) upsert ::= */
-	271, /* (165) upsert ::= RETURNING selcollist */
-	271, /* (166) upsert ::= ON CONFLICT LP sortlist RP where_opt DO UPDATE SET setlist where_opt upsert */
-	271, /* (167) upsert ::= ON CONFLICT LP sortlist RP where_opt DO NOTHING upsert */
-	271, /* (168) upsert ::= ON CONFLICT DO NOTHING returning */
-	271, /* (169) upsert ::= ON CONFLICT DO UPDATE SET setlist where_opt returning */
-	272, /* (170) returning ::= RETURNING selcollist */
-	269, /* (171) insert_cmd ::= INSERT orconf */
-	269, /* (172) insert_cmd ::= REPLACE */
-	270, /* (173) idlist_opt ::= */
-	270, /* (174) idlist_opt ::= LP idlist RP */
-	263, /* (175) idlist ::= idlist COMMA nm */
-	263, /* (176) idlist ::= nm */
-	217, /* (177) expr ::= LP expr RP */
-	217, /* (178) expr ::= ID|INDEXED */
-	217, /* (179) expr ::= JOIN_KW */
-	217, /* (180) expr ::= nm DOT nm */
-	217, /* (181) expr ::= nm DOT nm DOT nm */
-	216, /* (182) term ::= NULL|FLOAT|BLOB */
-	216, /* (183) term ::= STRING */
-	216, /* (184) term ::= INTEGER */
-	217, /* (185) expr ::= VARIABLE */
-	217, /* (186) expr ::= expr COLLATE ID|STRING */
-	217, /* (187) expr ::= CAST LP expr AS typetoken RP */
-	217, /* (188) expr ::= ID|INDEXED LP distinct exprlist RP */
-	217, /* (189) expr ::= ID|INDEXED LP STAR RP */
-	217, /* (190) expr ::= ID|INDEXED LP distinct exprlist RP filter_over */
-	217, /* (191) expr ::= ID|INDEXED LP STAR RP filter_over */
-	216, /* (192) term ::= CTIME_KW */
-	217, /* (193) expr ::= LP nexprlist COMMA expr RP */
-	217, /* (194) expr ::= expr AND expr */
-	217, /* (195) expr ::= expr OR expr */
-	217, /* (196) expr ::= expr LT|GT|GE|LE expr */
-	217, /* (197) expr ::= expr EQ|NE expr */
-	217, /* (198) expr ::= expr BITAND|BITOR|LSHIFT|RSHIFT expr */
-	217, /* (199) expr ::= expr PLUS|MINUS expr */
-	217, /* (200) expr ::= expr STAR|SLASH|REM expr */
-	217, /* (201) expr ::= expr CONCAT expr */
-	274, /* (202) likeop ::= NOT LIKE_KW|MATCH */
-	217, /* (203) expr ::= expr likeop expr */
-	217, /* (204) expr ::= expr likeop expr ESCAPE expr */
-	217, /* (205) expr ::= expr ISNULL|NOTNULL */
-	217, /* (206) expr ::= expr NOT NULL */
-	217, /* (207) expr ::= expr IS expr */
-	217, /* (208) expr ::= expr IS NOT expr */
-	217, /* (209) expr ::= NOT expr */
-	217, /* (210) expr ::= BITNOT expr */
-	217, /* (211) expr ::= PLUS|MINUS expr */
-	217, /* (212) expr ::= expr PTR expr */
-	275, /* (213) between_op ::= BETWEEN */
-	275, /* (214) between_op ::= NOT BETWEEN */
-	217, /* (215) expr ::= expr between_op expr AND expr */
-	276, /* (216) in_op ::= IN */
-	276, /* (217) in_op ::= NOT IN */
-	217, /* (218) expr ::= expr in_op LP exprlist RP */
-	217, /* (219) expr ::= LP select RP */
-	217, /* (220) expr ::= expr in_op LP select RP */
-	217, /* (221) expr ::= expr in_op nm dbnm paren_exprlist */
-	217, /* (222) expr ::= EXISTS LP select RP */
-	217, /* (223) expr ::= CASE case_operand case_exprlist case_else END */
-	279, /* (224) case_exprlist ::= case_exprlist WHEN expr THEN expr */
-	279, /* (225) case_exprlist ::= WHEN expr THEN expr */
-	280, /* (226) case_else ::= ELSE expr */
-	280, /* (227) case_else ::= */
-	278, /* (228) case_operand ::= expr */
-	278, /* (229) case_operand ::= */
-	261, /* (230) exprlist ::= */
-	253, /* (231) nexprlist ::= nexprlist COMMA expr */
-	253, /* (232) nexprlist ::= expr */
-	277, /* (233) paren_exprlist ::= */
-	277, /* (234) paren_exprlist ::= LP exprlist RP */
-	190, /* (235) cmd ::= createkw uniqueflag INDEX ifnotexists nm dbnm ON nm LP sortlist RP where_opt */
-	281, /* (236) uniqueflag ::= UNIQUE */
-	281, /* (237) uniqueflag ::= */
-	221, /* (238) eidlist_opt ::= */
-	221, /* (239) eidlist_opt ::= LP eidlist RP */
-	232, /* (240) eidlist ::= eidlist COMMA nm collate sortorder */
-	232, /* (241) eidlist ::= nm collate sortorder */
-	282, /* (242) collate ::= */
-	282, /* (243) collate ::= COLLATE ID|STRING */
-	190, /* (244) cmd ::= DROP INDEX ifexists fullname */
-	190, /* (245) cmd ::= VACUUM vinto */
-	190, /* (246) cmd ::= VACUUM nm vinto */
-	283, /* (247) vinto ::= INTO expr */
-	283, /* (248) vinto ::= */
-	190, /* (249) cmd ::= PRAGMA nm dbnm */
-	190, /* (250) cmd ::= PRAGMA nm dbnm EQ nmnum */
-	190, /* (251) cmd ::= PRAGMA nm dbnm LP nmnum RP */
-	190, /* (252) cmd ::= PRAGMA nm dbnm EQ minus_num */
-	190, /* (253) cmd ::= PRAGMA nm dbnm LP minus_num RP */
-	211, /* (254) plus_num ::= PLUS INTEGER|FLOAT */
-	212, /* (255) minus_num ::= MINUS INTEGER|FLOAT */
-	190, /* (256) cmd ::= createkw trigger_decl BEGIN trigger_cmd_list END */
-	285, /* (257) trigger_decl ::= temp TRIGGER ifnotexists nm dbnm trigger_time trigger_event ON fullname foreach_clause when_clause */
-	287, /* (258) trigger_time ::= BEFORE|AFTER */
-	287, /* (259) trigger_time ::= INSTEAD OF */
-	287, /* (260) trigger_time ::= */
-	288, /* (261) trigger_event ::= DELETE|INSERT */
-	288, /* (262) trigger_event ::= UPDATE */
-	288, /* (263) trigger_event ::= UPDATE OF idlist */
-	290, /* (264) when_clause ::= */
-	290, /* (265) when_clause ::= WHEN expr */
-	286, /* (266) trigger_cmd_list ::= trigger_cmd_list trigger_cmd SEMI */
-	286, /* (267) trigger_cmd_list ::= trigger_cmd SEMI */
-	292, /* (268) trnm ::= nm DOT nm */
-	293, /* (269) tridxby ::= INDEXED BY nm */
-	293, /* (270) tridxby ::= NOT INDEXED */
-	291, /* (271) trigger_cmd ::= UPDATE orconf trnm tridxby SET setlist from where_opt scanpt */
-	291, /* (272) trigger_cmd ::= scanpt insert_cmd INTO trnm idlist_opt select upsert scanpt */
-	291, /* (273) trigger_cmd ::= DELETE FROM trnm tridxby where_opt scanpt */
-	291, /* (274) trigger_cmd ::= scanpt select scanpt */
-	217, /* (275) expr ::= RAISE LP IGNORE RP */
-	217, /* (276) expr ::= RAISE LP raisetype COMMA nm RP */
-	236, /* (277) raisetype ::= ROLLBACK */
-	236, /* (278) raisetype ::= ABORT */
-	236, /* (279) raisetype ::= FAIL */
-	190, /* (280) cmd ::= DROP TRIGGER ifexists fullname */
-	190, /* (281) cmd ::= ATTACH database_kw_opt expr AS expr key_opt */
-	190, /* (282) cmd ::= DETACH database_kw_opt expr */
-	295, /* (283) key_opt ::= */
-	295, /* (284) key_opt ::= KEY expr */
-	190, /* (285) cmd ::= REINDEX */
-	190, /* (286) cmd ::= REINDEX nm dbnm */
-	190, /* (287) cmd ::= ANALYZE */
-	190, /* (288) cmd ::= ANALYZE nm dbnm */
-	190, /* (289) cmd ::= ALTER TABLE fullname RENAME TO nm */
-	190, /* (290) cmd ::= ALTER TABLE add_column_fullname ADD kwcolumn_opt columnname carglist */
-	190, /* (291) cmd ::= ALTER TABLE fullname DROP kwcolumn_opt nm */
-	296, /* (292) add_column_fullname ::= fullname */
-	190, /* (293) cmd ::= ALTER TABLE fullname RENAME kwcolumn_opt nm TO nm */
-	190, /* (294) cmd ::= create_vtab */
-	190, /* (295) cmd ::= create_vtab LP vtabarglist RP */
-	298, /* (296) create_vtab ::= createkw VIRTUAL TABLE ifnotexists nm dbnm USING nm */
-	300, /* (297) vtabarg ::= */
-	301, /* (298) vtabargtoken ::= ANY */
-	301, /* (299) vtabargtoken ::= lp anylist RP */
-	302, /* (300) lp ::= LP */
-	266, /* (301) with ::= WITH wqlist */
-	266, /* (302) with ::= WITH RECURSIVE wqlist */
-	305, /* (303) wqas ::= AS */
-	305, /* (304) wqas ::= AS MATERIALIZED */
-	305, /* (305) wqas ::= AS NOT MATERIALIZED */
-	304, /* (306) wqitem ::= nm eidlist_opt wqas LP select RP */
-	241, /* (307) wqlist ::= wqitem */
-	241, /* (308) wqlist ::= wqlist COMMA wqitem */
-	306, /* (309) windowdefn_list ::= windowdefn */
-	306, /* (310) windowdefn_list ::= windowdefn_list COMMA windowdefn */
-	307, /* (311) windowdefn ::= nm AS LP window RP */
-	308, /* (312) window ::= PARTITION BY nexprlist orderby_opt frame_opt */
-	308, /* (313) window ::= nm PARTITION BY nexprlist orderby_opt frame_opt */
-	308, /* (314) window ::= ORDER BY sortlist frame_opt */
-	308, /* (315) window ::= nm ORDER BY sortlist frame_opt */
-	308, /* (316) window ::= frame_opt */
-	308, /* (317) window ::= nm frame_opt */
-	309, /* (318) frame_opt ::= */
-	309, /* (319) frame_opt ::= range_or_rows frame_bound_s frame_exclude_opt */
-	309, /* (320) frame_opt ::= range_or_rows BETWEEN frame_bound_s AND frame_bound_e frame_exclude_opt */
-	313, /* (321) range_or_rows ::= RANGE|ROWS|GROUPS */
-	315, /* (322) frame_bound_s ::= frame_bound */
-	315, /* (323) frame_bound_s ::= UNBOUNDED PRECEDING */
-	316, /* (324) frame_bound_e ::= frame_bound */
-	316, /* (325) frame_bound_e ::= UNBOUNDED FOLLOWING */
-	314, /* (326) frame_bound ::= expr PRECEDING|FOLLOWING */
-	314, /* (327) frame_bound ::= CURRENT ROW */
-	317, /* (328) frame_exclude_opt ::= */
-	317, /* (329) frame_exclude_opt ::= EXCLUDE frame_exclude */
-	318, /* (330) frame_exclude ::= NO OTHERS */
-	318, /* (331) frame_exclude ::= CURRENT ROW */
-	318, /* (332) frame_exclude ::= GROUP|TIES */
-	251, /* (333) window_clause ::= WINDOW windowdefn_list */
-	273, /* (334) filter_over ::= filter_clause over_clause */
-	273, /* (335) filter_over ::= over_clause */
-	273, /* (336) filter_over ::= filter_clause */
-	312, /* (337) over_clause ::= OVER LP window RP */
-	312, /* (338) over_clause ::= OVER nm */
-	311, /* (339) filter_clause ::= FILTER LP WHERE expr RP */
-	185, /* (340) input ::= cmdlist */
-	186, /* (341) cmdlist ::= cmdlist ecmd */
-	186, /* (342) cmdlist ::= ecmd */
-	187, /* (343) ecmd ::= SEMI */
-	187, /* (344) ecmd ::= cmdx SEMI */
-	187, /* (345) ecmd ::= explain cmdx SEMI */
-	192, /* (346) trans_opt ::= */
-	192, /* (347) trans_opt ::= TRANSACTION */
-	192, /* (348) trans_opt ::= TRANSACTION nm */
-	194, /* (349) savepoint_opt ::= SAVEPOINT */
-	194, /* (350) savepoint_opt ::= */
-	190, /* (351) cmd ::= create_table create_table_args */
-	203, /* (352) table_option_set ::= table_option */
-	201, /* (353) columnlist ::= columnlist COMMA columnname carglist */
-	201, /* (354) columnlist ::= columnname carglist */
-	193, /* (355) nm ::= ID|INDEXED */
-	193, /* (356) nm ::= STRING */
-	193, /* (357) nm ::= JOIN_KW */
-	208, /* (358) typetoken ::= typename */
-	209, /* (359) typename ::= ID|STRING */
-	210, /* (360) signed ::= plus_num */
-	210, /* (361) signed ::= minus_num */
-	207, /* (362) carglist ::= carglist ccons */
-	207, /* (363) carglist ::= */
-	215, /* (364) ccons ::= NULL onconf */
-	215, /* (365) ccons ::= GENERATED ALWAYS AS generated */
-	215, /* (366) ccons ::= AS generated */
-	202, /* (367) conslist_opt ::= COMMA conslist */
-	228, /* (368) conslist ::= conslist tconscomma tcons */
-	228, /* (369) conslist ::= tcons */
-	229, /* (370) tconscomma ::= */
-	233, /* (371) defer_subclause_opt ::= defer_subclause */
-	235, /* (372) resolvetype ::= raisetype */
-	239, /* (373) selectnowith ::= oneselect */
-	240, /* (374) oneselect ::= values */
-	254, /* (375) sclp ::= selcollist COMMA */
-	255, /* (376) as ::= ID|STRING */
-	264, /* (377) indexed_opt ::= indexed_by */
-	272, /* (378) returning ::= */
-	217, /* (379) expr ::= term */
-	274, /* (380) likeop ::= LIKE_KW|MATCH */
-	261, /* (381) exprlist ::= nexprlist */
-	284, /* (382) nmnum ::= plus_num */
-	284, /* (383) nmnum ::= nm */
-	284, /* (384) nmnum ::= ON */
-	284, /* (385) nmnum ::= DELETE */
-	284, /* (386) nmnum ::= DEFAULT */
-	211, /* (387) plus_num ::= INTEGER|FLOAT */
-	289, /* (388) foreach_clause ::= */
-	289, /* (389) foreach_clause ::= FOR EACH ROW */
-	292, /* (390) trnm ::= nm */
-	293, /* (391) tridxby ::= */
-	294, /* (392) database_kw_opt ::= DATABASE */
-	294, /* (393) database_kw_opt ::= */
-	297, /* (394) kwcolumn_opt ::= */
-	297, /* (395) kwcolumn_opt ::= COLUMNKW */
-	299, /* (396) vtabarglist ::= vtabarg */
-	299, /* (397) vtabarglist ::= vtabarglist COMMA vtabarg */
-	300, /* (398) vtabarg ::= vtabarg vtabargtoken */
-	303, /* (399) anylist ::= */
-	303, /* (400) anylist ::= anylist LP anylist RP */
-	303, /* (401) anylist ::= anylist ANY */
-	266, /* (402) with ::= */
+	215, /* (45) ccons ::= GENERATED ALWAYS AS generated */
+	215, /* (46) ccons ::= AS generated */
+	224, /* (47) generated ::= LP expr RP */
+	224, /* (48) generated ::= LP expr RP ID */
+	220, /* (49) autoinc ::= */
+	220, /* (50) autoinc ::= AUTOINCR */
+	222, /* (51) refargs ::= */
+	222, /* (52) refargs ::= refargs refarg */
+	225, /* (53) refarg ::= MATCH nm */
+	225, /* (54) refarg ::= ON INSERT refact */
+	225, /* (55) refarg ::= ON DELETE refact */
+	225, /* (56) refarg ::= ON UPDATE refact */
+	226, /* (57) refact ::= SET NULL */
+	226, /* (58) refact ::= SET DEFAULT */
+	226, /* (59) refact ::= CASCADE */
+	226, /* (60) refact ::= RESTRICT */
+	226, /* (61) refact ::= NO ACTION */
+	223, /* (62) defer_subclause ::= NOT DEFERRABLE init_deferred_pred_opt */
+	223, /* (63) defer_subclause ::= DEFERRABLE init_deferred_pred_opt */
+	227, /* (64) init_deferred_pred_opt ::= */
+	227, /* (65) init_deferred_pred_opt ::= INITIALLY DEFERRED */
+	227, /* (66) init_deferred_pred_opt ::= INITIALLY IMMEDIATE */
+	202, /* (67) conslist_opt ::= */
+	229, /* (68) tconscomma ::= COMMA */
+	230, /* (69) tcons ::= CONSTRAINT nm */
+	230, /* (70) tcons ::= PRIMARY KEY LP sortlist autoinc RP onconf */
+	230, /* (71) tcons ::= UNIQUE LP sortlist RP onconf */
+	230, /* (72) tcons ::= CHECK LP expr RP onconf */
+	230, /* (73) tcons ::= FOREIGN KEY LP eidlist RP REFERENCES nm eidlist_opt refargs defer_subclause_opt */
+	233, /* (74) defer_subclause_opt ::= */
+	218, /* (75) onconf ::= */
+	218, /* (76) onconf ::= ON CONFLICT resolvetype */
+	234, /* (77) orconf ::= */
+	234, /* (78) orconf ::= OR resolvetype */
+	235, /* (79) resolvetype ::= IGNORE */
+	235, /* (80) resolvetype ::= REPLACE */
+	190, /* (81) cmd ::= DROP TABLE ifexists fullname */
+	237, /* (82) ifexists ::= IF EXISTS */
+	237, /* (83) ifexists ::= */
+	190, /* (84) cmd ::= createkw temp VIEW ifnotexists nm dbnm eidlist_opt AS select */
+	190, /* (85) cmd ::= DROP VIEW ifexists fullname */
+	190, /* (86) cmd ::= select */
+	204, /* (87) select ::= WITH wqlist selectnowith */
+	204, /* (88) select ::= WITH RECURSIVE wqlist selectnowith */
+	204, /* (89) select ::= selectnowith */
+	239, /* (90) selectnowith ::= selectnowith multiselect_op oneselect */
+	242, /* (91) multiselect_op ::= UNION */
+	242, /* (92) multiselect_op ::= UNION ALL */
+	242, /* (93) multiselect_op ::= EXCEPT|INTERSECT */
+	240, /* (94) oneselect ::= SELECT distinct selcollist from where_opt groupby_opt having_opt orderby_opt limit_opt */
+	240, /* (95) oneselect ::= SELECT distinct selcollist from where_opt groupby_opt having_opt window_clause orderby_opt limit_opt */
+	252, /* (96) values ::= VALUES LP nexprlist RP */
+	252, /* (97) values ::= values COMMA LP nexprlist RP */
+	243, /* (98) distinct ::= DISTINCT */
+	243, /* (99) distinct ::= ALL */
+	243, /* (100) distinct ::= */
+	254, /* (101) sclp ::= */
+	244, /* (102) selcollist ::= sclp scanpt expr scanpt as */
+	244, /* (103) selcollist ::= sclp scanpt STAR */
+	244, /* (104) selcollist ::= sclp scanpt nm DOT STAR */
+	255, /* (105) as ::= AS nm */
+	255, /* (106) as ::= */
+	245, /* (107) from ::= */
+	245, /* (108) from ::= FROM seltablist */
+	257, /* (109) stl_prefix ::= seltablist joinop */
+	257, /* (110) stl_prefix ::= */
+	256, /* (111) seltablist ::= stl_prefix nm dbnm as on_using */
+	256, /* (112) seltablist ::= stl_prefix nm dbnm as indexed_by on_using */
+	256, /* (113) seltablist ::= stl_prefix nm dbnm LP exprlist RP as on_using */
+	256, /* (114) seltablist ::= stl_prefix LP select RP as on_using */
+	256, /* (115) seltablist ::= stl_prefix LP seltablist RP as on_using */
+	200, /* (116) dbnm ::= */
+	200, /* (117) dbnm ::= DOT nm */
+	238, /* (118) fullname ::= nm */
+	238, /* (119) fullname ::= nm DOT nm */
+	262, /* (120) xfullname ::= nm */
+	262, /* (121) xfullname ::= nm DOT nm */
+	262, /* (122) xfullname ::= nm DOT nm AS nm */
+	262, /* (123) xfullname ::= nm AS nm */
+	258, /* (124) joinop ::= COMMA|JOIN */
+	258, /* (125) joinop ::= JOIN_KW JOIN */
+	258, /* (126) joinop ::= JOIN_KW nm JOIN */
+	258, /* (127) joinop ::= JOIN_KW nm nm JOIN */
+	259, /* (128) on_using ::= ON expr */
+	259, /* (129) on_using ::= USING LP idlist RP */
+	259, /* (130) on_using ::= */
+	264, /* (131) indexed_opt ::= */
+	260, /* (132) indexed_by ::= INDEXED BY nm */
+	260, /* (133) indexed_by ::= NOT INDEXED */
+	249, /* (134) orderby_opt ::= */
+	249, /* (135) orderby_opt ::= ORDER BY sortlist */
+	231, /* (136) sortlist ::= sortlist COMMA expr sortorder nulls */
+	231, /* (137) sortlist ::= expr sortorder nulls */
+	219, /* (138) sortorder ::= ASC */
+	219, /* (139) sortorder ::= DESC */
+	219, /* (140) sortorder ::= */
+	265, /* (141) nulls ::= NULLS FIRST */
+	265, /* (142) nulls ::= NULLS LAST */
+	265, /* (143) nulls ::= */
+	247, /* (144) groupby_opt ::= */
+	247, /* (145) groupby_opt ::= GROUP BY nexprlist */
+	248, /* (146) having_opt ::= */
+	248, /* (147) having_opt ::= HAVING expr */
+	250, /* (148) limit_opt ::= */
+	250, /* (149) limit_opt ::= LIMIT expr */
+	250, /* (150) limit_opt ::= LIMIT expr OFFSET expr */
+	250, /* (151) limit_opt ::= LIMIT expr COMMA expr */
+	190, /* (152) cmd ::= with DELETE FROM xfullname indexed_opt where_opt_ret orderby_opt limit_opt */
+	246, /* (153) where_opt ::= */
+	246, /* (154) where_opt ::= WHERE expr */
+	267, /* (155) where_opt_ret ::= */
+	267, /* (156) where_opt_ret ::= WHERE expr */
+	267, /* (157) where_opt_ret ::= RETURNING selcollist */
+	267, /* (158) where_opt_ret ::= WHERE expr RETURNING selcollist */
+	190, /* (159) cmd ::= with UPDATE orconf xfullname indexed_opt SET setlist from where_opt_ret orderby_opt limit_opt */
+	268, /* (160) setlist ::= setlist COMMA nm EQ expr */
+	268, /* (161) setlist ::= setlist COMMA LP idlist RP EQ expr */
+	268, /* (162) setlist ::= nm EQ expr */
+	268, /* (163) setlist ::= LP idlist RP EQ expr */
+	190, /* (164) cmd ::= with insert_cmd INTO xfullname idlist_opt select upsert */
+	190, /* (165) cmd ::= with insert_cmd INTO xfullname idlist_opt DEFAULT VALUES returning */
+	271, /* (166) upsert ::= */
+	271, /* (167) upsert ::= RETURNING selcollist */
+	271, /* (168) upsert ::= ON CONFLICT LP sortlist RP where_opt DO UPDATE SET setlist where_opt upsert */
+	271, /* (169) upsert ::= ON CONFLICT LP sortlist RP where_opt DO NOTHING upsert */
+	271, /* (170) upsert ::= ON CONFLICT DO NOTHING returning */
+	271, /* (171) upsert ::= ON CONFLICT DO UPDATE SET setlist where_opt returning */
+	272, /* (172) returning ::= RETURNING selcollist */
+	269, /* (173) insert_cmd ::= INSERT orconf */
+	269, /* (174) insert_cmd ::= REPLACE */
+	270, /* (175) idlist_opt ::= */
+	270, /* (176) idlist_opt ::= LP idlist RP */
+	263, /* (177) idlist ::= idlist COMMA nm */
+	263, /* (178) idlist ::= nm */
+	217, /* (179) expr ::= LP expr RP */
+	217, /* (180) expr ::= ID|INDEXED */
+	217, /* (181) expr ::= JOIN_KW */
+	217, /* (182) expr ::= nm DOT nm */
+	217, /* (183) expr ::= nm DOT nm DOT nm */
+	216, /* (184) term ::= NULL|FLOAT|BLOB */
+	216, /* (185) term ::= STRING */
+	216, /* (186) term ::= INTEGER */
+	217, /* (187) expr ::= VARIABLE */
+	217, /* (188) expr ::= expr COLLATE ID|STRING */
+	217, /* (189) expr ::= CAST LP expr AS typetoken RP */
+	217, /* (190) expr ::= ID|INDEXED LP distinct exprlist RP */
+	217, /* (191) expr ::= ID|INDEXED LP STAR RP */
+	217, /* (192) expr ::= ID|INDEXED LP distinct exprlist RP filter_over */
+	217, /* (193) expr ::= ID|INDEXED LP STAR RP filter_over */
+	216, /* (194) term ::= CTIME_KW */
+	217, /* (195) expr ::= LP nexprlist COMMA expr RP */
+	217, /* (196) expr ::= expr AND expr */
+	217, /* (197) expr ::= expr OR expr */
+	217, /* (198) expr ::= expr LT|GT|GE|LE expr */
+	217, /* (199) expr ::= expr EQ|NE expr */
+	217, /* (200) expr ::= expr BITAND|BITOR|LSHIFT|RSHIFT expr */
+	217, /* (201) expr ::= expr PLUS|MINUS expr */
+	217, /* (202) expr ::= expr STAR|SLASH|REM expr */
+	217, /* (203) expr ::= expr CONCAT expr */
+	274, /* (204) likeop ::= NOT LIKE_KW|MATCH */
+	217, /* (205) expr ::= expr likeop expr */
+	217, /* (206) expr ::= expr likeop expr ESCAPE expr */
+	217, /* (207) expr ::= expr ISNULL|NOTNULL */
+	217, /* (208) expr ::= expr NOT NULL */
+	217, /* (209) expr ::= expr IS expr */
+	217, /* (210) expr ::= expr IS NOT expr */
+	217, /* (211) expr ::= expr IS NOT DISTINCT FROM expr */
+	217, /* (212) expr ::= expr IS DISTINCT FROM expr */
+	217, /* (213) expr ::= NOT expr */
+	217, /* (214) expr ::= BITNOT expr */
+	217, /* (215) expr ::= PLUS|MINUS expr */
+	217, /* (216) expr ::= expr PTR expr */
+	275, /* (217) between_op ::= BETWEEN */
+	275, /* (218) between_op ::= NOT BETWEEN */
+	217, /* (219) expr ::= expr between_op expr AND expr */
+	276, /* (220) in_op ::= IN */
+	276, /* (221) in_op ::= NOT IN */
+	217, /* (222) expr ::= expr in_op LP exprlist RP */
+	217, /* (223) expr ::= LP select RP */
+	217, /* (224) expr ::= expr in_op LP select RP */
+	217, /* (225) expr ::= expr in_op nm dbnm paren_exprlist */
+	217, /* (226) expr ::= EXISTS LP select RP */
+	217, /* (227) expr ::= CASE case_operand case_exprlist case_else END */
+	279, /* (228) case_exprlist ::= case_exprlist WHEN expr THEN expr */
+	279, /* (229) case_exprlist ::= WHEN expr THEN expr */
+	280, /* (230) case_else ::= ELSE expr */
+	280, /* (231) case_else ::= */
+	278, /* (232) case_operand ::= */
+	261, /* (233) exprlist ::= */
+	253, /* (234) nexprlist ::= nexprlist COMMA expr */
+	253, /* (235) nexprlist ::= expr */
+	277, /* (236) paren_exprlist ::= */
+	277, /* (237) paren_exprlist ::= LP exprlist RP */
+	190, /* (238) cmd ::= createkw uniqueflag INDEX ifnotexists nm dbnm ON nm LP sortlist RP where_opt */
+	281, /* (239) uniqueflag ::= UNIQUE */
+	281, /* (240) uniqueflag ::= */
+	221, /* (241) eidlist_opt ::= */
+	221, /* (242) eidlist_opt ::= LP eidlist RP */
+	232, /* (243) eidlist ::= eidlist COMMA nm collate sortorder */
+	232, /* (244) eidlist ::= nm collate sortorder */
+	282, /* (245) collate ::= */
+	282, /* (246) collate ::= COLLATE ID|STRING */
+	190, /* (247) cmd ::= DROP INDEX ifexists fullname */
+	190, /* (248) cmd ::= VACUUM vinto */
+	190, /* (249) cmd ::= VACUUM nm vinto */
+	283, /* (250) vinto ::= INTO expr */
+	283, /* (251) vinto ::= */
+	190, /* (252) cmd ::= PRAGMA nm dbnm */
+	190, /* (253) cmd ::= PRAGMA nm dbnm EQ nmnum */
+	190, /* (254) cmd ::= PRAGMA nm dbnm LP nmnum RP */
+	190, /* (255) cmd ::= PRAGMA nm dbnm EQ minus_num */
+	190, /* (256) cmd ::= PRAGMA nm dbnm LP minus_num RP */
+	211, /* (257) plus_num ::= PLUS INTEGER|FLOAT */
+	212, /* (258) minus_num ::= MINUS INTEGER|FLOAT */
+	190, /* (259) cmd ::= createkw trigger_decl BEGIN trigger_cmd_list END */
+	285, /* (260) trigger_decl ::= temp TRIGGER ifnotexists nm dbnm trigger_time trigger_event ON fullname foreach_clause when_clause */
+	287, /* (261) trigger_time ::= BEFORE|AFTER */
+	287, /* (262) trigger_time ::= INSTEAD OF */
+	287, /* (263) trigger_time ::= */
+	288, /* (264) trigger_event ::= DELETE|INSERT */
+	288, /* (265) trigger_event ::= UPDATE */
+	288, /* (266) trigger_event ::= UPDATE OF idlist */
+	290, /* (267) when_clause ::= */
+	290, /* (268) when_clause ::= WHEN expr */
+	286, /* (269) trigger_cmd_list ::= trigger_cmd_list trigger_cmd SEMI */
+	286, /* (270) trigger_cmd_list ::= trigger_cmd SEMI */
+	292, /* (271) trnm ::= nm DOT nm */
+	293, /* (272) tridxby ::= INDEXED BY nm */
+	293, /* (273) tridxby ::= NOT INDEXED */
+	291, /* (274) trigger_cmd ::= UPDATE orconf trnm tridxby SET setlist from where_opt scanpt */
+	291, /* (275) trigger_cmd ::= scanpt insert_cmd INTO trnm idlist_opt select upsert scanpt */
+	291, /* (276) trigger_cmd ::= DELETE FROM trnm tridxby where_opt scanpt */
+	291, /* (277) trigger_cmd ::= scanpt select scanpt */
+	217, /* (278) expr ::= RAISE LP IGNORE RP */
+	217, /* (279) expr ::= RAISE LP raisetype COMMA nm RP */
+	236, /* (280) raisetype ::= ROLLBACK */
+	236, /* (281) raisetype ::= ABORT */
+	236, /* (282) raisetype ::= FAIL */
+	190, /* (283) cmd ::= DROP TRIGGER ifexists fullname */
+	190, /* (284) cmd ::= ATTACH database_kw_opt expr AS expr key_opt */
+	190, /* (285) cmd ::= DETACH database_kw_opt expr */
+	295, /* (286) key_opt ::= */
+	295, /* (287) key_opt ::= KEY expr */
+	190, /* (288) cmd ::= REINDEX */
+	190, /* (289) cmd ::= REINDEX nm dbnm */
+	190, /* (290) cmd ::= ANALYZE */
+	190, /* (291) cmd ::= ANALYZE nm dbnm */
+	190, /* (292) cmd ::= ALTER TABLE fullname RENAME TO nm */
+	190, /* (293) cmd ::= ALTER TABLE add_column_fullname ADD kwcolumn_opt columnname carglist */
+	190, /* (294) cmd ::= ALTER TABLE fullname DROP kwcolumn_opt nm */
+	296, /* (295) add_column_fullname ::= fullname */
+	190, /* (296) cmd ::= ALTER TABLE fullname RENAME kwcolumn_opt nm TO nm */
+	190, /* (297) cmd ::= create_vtab */
+	190, /* (298) cmd ::= create_vtab LP vtabarglist RP */
+	298, /* (299) create_vtab ::= createkw VIRTUAL TABLE ifnotexists nm dbnm USING nm */
+	300, /* (300) vtabarg ::= */
+	301, /* (301) vtabargtoken ::= ANY */
+	301, /* (302) vtabargtoken ::= lp anylist RP */
+	302, /* (303) lp ::= LP */
+	266, /* (304) with ::= WITH wqlist */
+	266, /* (305) with ::= WITH RECURSIVE wqlist */
+	305, /* (306) wqas ::= AS */
+	305, /* (307) wqas ::= AS MATERIALIZED */
+	305, /* (308) wqas ::= AS NOT MATERIALIZED */
+	304, /* (309) wqitem ::= nm eidlist_opt wqas LP select RP */
+	241, /* (310) wqlist ::= wqitem */
+	241, /* (311) wqlist ::= wqlist COMMA wqitem */
+	306, /* (312) windowdefn_list ::= windowdefn */
+	306, /* (313) windowdefn_list ::= windowdefn_list COMMA windowdefn */
+	307, /* (314) windowdefn ::= nm AS LP window RP */
+	308, /* (315) window ::= PARTITION BY nexprlist orderby_opt frame_opt */
+	308, /* (316) window ::= nm PARTITION BY nexprlist orderby_opt frame_opt */
+	308, /* (317) window ::= ORDER BY sortlist frame_opt */
+	308, /* (318) window ::= nm ORDER BY sortlist frame_opt */
+	308, /* (319) window ::= frame_opt */
+	308, /* (320) window ::= nm frame_opt */
+	309, /* (321) frame_opt ::= */
+	309, /* (322) frame_opt ::= range_or_rows frame_bound_s frame_exclude_opt */
+	309, /* (323) frame_opt ::= range_or_rows BETWEEN frame_bound_s AND frame_bound_e frame_exclude_opt */
+	313, /* (324) range_or_rows ::= RANGE|ROWS|GROUPS */
+	315, /* (325) frame_bound_s ::= frame_bound */
+	315, /* (326) frame_bound_s ::= UNBOUNDED PRECEDING */
+	316, /* (327) frame_bound_e ::= frame_bound */
+	316, /* (328) frame_bound_e ::= UNBOUNDED FOLLOWING */
+	314, /* (329) frame_bound ::= expr PRECEDING|FOLLOWING */
+	314, /* (330) frame_bound ::= CURRENT ROW */
+	317, /* (331) frame_exclude_opt ::= */
+	317, /* (332) frame_exclude_opt ::= EXCLUDE frame_exclude */
+	318, /* (333) frame_exclude ::= NO OTHERS */
+	318, /* (334) frame_exclude ::= CURRENT ROW */
+	318, /* (335) frame_exclude ::= GROUP|TIES */
+	251, /* (336) window_clause ::= WINDOW windowdefn_list */
+	273, /* (337) filter_over ::= filter_clause over_clause */
+	273, /* (338) filter_over ::= over_clause */
+	273, /* (339) filter_over ::= filter_clause */
+	312, /* (340) over_clause ::= OVER LP window RP */
+	312, /* (341) over_clause ::= OVER nm */
+	311, /* (342) filter_clause ::= FILTER LP WHERE expr RP */
+	185, /* (343) input ::= cmdlist */
+	186, /* (344) cmdlist ::= cmdlist ecmd */
+	186, /* (345) cmdlist ::= ecmd */
+	187, /* (346) ecmd ::= SEMI */
+	187, /* (347) ecmd ::= cmdx SEMI */
+	187, /* (348) ecmd ::= explain cmdx SEMI */
+	192, /* (349) trans_opt ::= */
+	192, /* (350) trans_opt ::= TRANSACTION */
+	192, /* (351) trans_opt ::= TRANSACTION nm */
+	194, /* (352) savepoint_opt ::= SAVEPOINT */
+	194, /* (353) savepoint_opt ::= */
+	190, /* (354) cmd ::= create_table create_table_args */
+	203, /* (355) table_option_set ::= table_option */
+	201, /* (356) columnlist ::= columnlist COMMA columnname carglist */
+	201, /* (357) columnlist ::= columnname carglist */
+	193, /* (358) nm ::= ID|INDEXED */
+	193, /* (359) nm ::= STRING */
+	193, /* (360) nm ::= JOIN_KW */
+	208, /* (361) typetoken ::= typename */
+	209, /* (362) typename ::= ID|STRING */
+	210, /* (363) signed ::= plus_num */
+	210, /* (364) signed ::= minus_num */
+	207, /* (365) carglist ::= carglist ccons */
+	207, /* (366) carglist ::= */
+	215, /* (367) ccons ::= NULL onconf */
+	202, /* (368) conslist_opt ::= COMMA conslist */
+	228, /* (369) conslist ::= conslist tconscomma tcons */
+	228, /* (370) conslist ::= tcons */
+	229, /* (371) tconscomma ::= */
+	233, /* (372) defer_subclause_opt ::= defer_subclause */
+	235, /* (373) resolvetype ::= raisetype */
+	239, /* (374) selectnowith ::= oneselect */
+	240, /* (375) oneselect ::= values */
+	254, /* (376) sclp ::= selcollist COMMA */
+	255, /* (377) as ::= ID|STRING */
+	264, /* (378) indexed_opt ::= indexed_by */
+	272, /* (379) returning ::= */
+	217, /* (380) expr ::= term */
+	274, /* (381) likeop ::= LIKE_KW|MATCH */
+	278, /* (382) case_operand ::= expr */
+	261, /* (383) exprlist ::= nexprlist */
+	284, /* (384) nmnum ::= plus_num */
+	284, /* (385) nmnum ::= nm */
+	284, /* (386) nmnum ::= ON */
+	284, /* (387) nmnum ::= DELETE */
+	284, /* (388) nmnum ::= DEFAULT */
+	211, /* (389) plus_num ::= INTEGER|FLOAT */
+	289, /* (390) foreach_clause ::= */
+	289, /* (391) foreach_clause ::= FOR EACH ROW */
+	292, /* (392) trnm ::= nm */
+	293, /* (393) tridxby ::= */
+	294, /* (394) database_kw_opt ::= DATABASE */
+	294, /* (395) database_kw_opt ::= */
+	297, /* (396) kwcolumn_opt ::= */
+	297, /* (397) kwcolumn_opt ::= COLUMNKW */
+	299, /* (398) vtabarglist ::= vtabarg */
+	299, /* (399) vtabarglist ::= vtabarglist COMMA vtabarg */
+	300, /* (400) vtabarg ::= vtabarg vtabargtoken */
+	303, /* (401) anylist ::= */
+	303, /* (402) anylist ::= anylist LP anylist RP */
+	303, /* (403) anylist ::= anylist ANY */
+	266, /* (404) with ::= */
 }
 
 /* For rule J, yyRuleInfoNRhs[J] contains the negative of the number
@@ -3149,364 +3207,366 @@ var yyRuleInfoNRhs = []int8{
 	-4, /* (42) ccons ::= REFERENCES nm eidlist_opt refargs */
 	-1, /* (43) ccons ::= defer_subclause */
 	-2, /* (44) ccons ::= COLLATE ID|STRING */
-	-3, /* (45) generated ::= LP expr RP */
-	-4, /* (46) generated ::= LP expr RP ID */
-	0, /* (47) autoinc ::= */
-	-1, /* (48) autoinc ::= AUTOINCR */
-	0, /* (49) refargs ::= */
-	-2, /* (50) refargs ::= refargs refarg */
-	-2, /* (51) refarg ::= MATCH nm */
-	-3, /* (52) refarg ::= ON INSERT refact */
-	-3, /* (53) refarg ::= ON DELETE refact */
-	-3, /* (54) refarg ::= ON UPDATE refact */
-	-2, /* (55) refact ::= SET NULL */
-	-2, /* (56) refact ::= SET DEFAULT */
-	-1, /* (57) refact ::= CASCADE */
-	-1, /* (58) refact ::= RESTRICT */
-	-2, /* (59) refact ::= NO ACTION */
-	-3, /* (60) defer_subclause ::= NOT DEFERRABLE init_deferred_pred_opt */
-	-2, /* (61) defer_subclause ::= DEFERRABLE init_deferred_pred_opt */
-	0, /* (62) init_deferred_pred_opt ::= */
-	-2, /* (63) init_deferred_pred_opt ::= INITIALLY DEFERRED */
-	-2, /* (64) init_deferred_pred_opt ::= INITIALLY IMMEDIATE */
-	0, /* (65) conslist_opt ::= */
-	-1, /* (66) tconscomma ::= COMMA */
-	-2, /* (67) tcons ::= CONSTRAINT nm */
-	-7, /* (68) tcons ::= PRIMARY KEY LP sortlist autoinc RP onconf */
-	-5, /* (69) tcons ::= UNIQUE LP sortlist RP onconf */
-	-5, /* (70) tcons ::= CHECK LP expr RP onconf */
-	-10, /* (71) tcons ::= FOREIGN KEY LP eidlist RP REFERENCES nm eidlist_opt refargs defer_subclause_opt */
-	0, /* (72) defer_subclause_opt ::= */
-	0, /* (73) onconf ::= */
-	-3, /* (74) onconf ::= ON CONFLICT resolvetype */
-	0, /* (75) orconf ::= */
-	-2, /* (76) orconf ::= OR resolvetype */
-	-1, /* (77) resolvetype ::= IGNORE */
-	-1, /* (78) resolvetype ::= REPLACE */
-	-4, /* (79) cmd ::= DROP TABLE ifexists fullname */
-	-2, /* (80) ifexists ::= IF EXISTS */
-	0, /* (81) ifexists ::= */
-	-9, /* (82) cmd ::= createkw temp VIEW ifnotexists nm dbnm eidlist_opt AS select */
-	-4, /* (83) cmd ::= DROP VIEW ifexists fullname */
-	-1, /* (84) cmd ::= select */
-	-3, /* (85) select ::= WITH wqlist selectnowith */
-	-4, /* (86) select ::= WITH RECURSIVE wqlist selectnowith */
-	-1, /* (87) select ::= selectnowith */
-	-3, /* (88) selectnowith ::= selectnowith multiselect_op oneselect */
-	-1, /* (89) multiselect_op ::= UNION */
-	-2, /* (90) multiselect_op ::= UNION ALL */
-	-1, /* (91) multiselect_op ::= EXCEPT|INTERSECT */
-	-9, /* (92) oneselect ::= SELECT distinct selcollist from where_opt groupby_opt having_opt orderby_opt limit_opt */
-	-10, /* (93) oneselect ::= SELECT distinct selcollist from where_opt groupby_opt having_opt window_clause orderby_opt limit_opt */
-	-4, /* (94) values ::= VALUES LP nexprlist RP */
-	-5, /* (95) values ::= values COMMA LP nexprlist RP */
-	-1, /* (96) distinct ::= DISTINCT */
-	-1, /* (97) distinct ::= ALL */
-	0, /* (98) distinct ::= */
-	0, /* (99) sclp ::= */
-	-5, /* (100) selcollist ::= sclp scanpt expr scanpt as */
-	-3, /* (101) selcollist ::= sclp scanpt STAR */
-	-5, /* (102) selcollist ::= sclp scanpt nm DOT STAR */
-	-2, /* (103) as ::= AS nm */
-	0, /* (104) as ::= */
-	0, /* (105) from ::= */
-	-2, /* (106) from ::= FROM seltablist */
-	-2, /* (107) stl_prefix ::= seltablist joinop */
-	0, /* (108) stl_prefix ::= */
-	-5, /* (109) seltablist ::= stl_prefix nm dbnm as on_using */
-	-6, /* (110) seltablist ::= stl_prefix nm dbnm as indexed_by on_using */
-	-8, /* (111) seltablist ::= stl_prefix nm dbnm LP exprlist RP as on_using */
-	-6, /* (112) seltablist ::= stl_prefix LP select RP as on_using */
-	-6, /* (113) seltablist ::= stl_prefix LP seltablist RP as on_using */
-	0, /* (114) dbnm ::= */
-	-2, /* (115) dbnm ::= DOT nm */
-	-1, /* (116) fullname ::= nm */
-	-3, /* (117) fullname ::= nm DOT nm */
-	-1, /* (118) xfullname ::= nm */
-	-3, /* (119) xfullname ::= nm DOT nm */
-	-5, /* (120) xfullname ::= nm DOT nm AS nm */
-	-3, /* (121) xfullname ::= nm AS nm */
-	-1, /* (122) joinop ::= COMMA|JOIN */
-	-2, /* (123) joinop ::= JOIN_KW JOIN */
-	-3, /* (124) joinop ::= JOIN_KW nm JOIN */
-	-4, /* (125) joinop ::= JOIN_KW nm nm JOIN */
-	-2, /* (126) on_using ::= ON expr */
-	-4, /* (127) on_using ::= USING LP idlist RP */
-	0, /* (128) on_using ::= */
-	0, /* (129) indexed_opt ::= */
-	-3, /* (130) indexed_by ::= INDEXED BY nm */
-	-2, /* (131) indexed_by ::= NOT INDEXED */
-	0, /* (132) orderby_opt ::= */
-	-3, /* (133) orderby_opt ::= ORDER BY sortlist */
-	-5, /* (134) sortlist ::= sortlist COMMA expr sortorder nulls */
-	-3, /* (135) sortlist ::= expr sortorder nulls */
-	-1, /* (136) sortorder ::= ASC */
-	-1, /* (137) sortorder ::= DESC */
-	0, /* (138) sortorder ::= */
-	-2, /* (139) nulls ::= NULLS FIRST */
-	-2, /* (140) nulls ::= NULLS LAST */
-	0, /* (141) nulls ::= */
-	0, /* (142) groupby_opt ::= */
-	-3, /* (143) groupby_opt ::= GROUP BY nexprlist */
-	0, /* (144) having_opt ::= */
-	-2, /* (145) having_opt ::= HAVING expr */
-	0, /* (146) limit_opt ::= */
-	-2, /* (147) limit_opt ::= LIMIT expr */
-	-4, /* (148) limit_opt ::= LIMIT expr OFFSET expr */
-	-4, /* (149) limit_opt ::= LIMIT expr COMMA expr */
-	-6, /* (150) cmd ::= with DELETE FROM xfullname indexed_opt where_opt_ret */
-	0, /* (151) where_opt ::= */
-	-2, /* (152) where_opt ::= WHERE expr */
-	0, /* (153) where_opt_ret ::= */
-	-2, /* (154) where_opt_ret ::= WHERE expr */
-	-2, /* (155) where_opt_ret ::= RETURNING selcollist */
-	-4, /* (156) where_opt_ret ::= WHERE expr RETURNING selcollist */
-	-9, /* (157) cmd ::= with UPDATE orconf xfullname indexed_opt SET setlist from where_opt_ret */
-	-5, /* (158) setlist ::= setlist COMMA nm EQ expr */
-	-7, /* (159) setlist ::= setlist COMMA LP idlist RP EQ expr */
-	-3, /* (160) setlist ::= nm EQ expr */
-	-5, /* (161) setlist ::= LP idlist RP EQ expr */
-	-7, /* (162) cmd ::= with insert_cmd INTO xfullname idlist_opt select upsert */
-	-8, /* (163) cmd ::= with insert_cmd INTO xfullname idlist_opt DEFAULT VALUES returning */
-	0, /* (164) upsert ::= */
-	-2, /* (165) upsert ::= RETURNING selcollist */
-	-12, /* (166) upsert ::= ON CONFLICT LP sortlist RP where_opt DO UPDATE SET setlist where_opt upsert */
-	-9, /* (167) upsert ::= ON CONFLICT LP sortlist RP where_opt DO NOTHING upsert */
-	-5, /* (168) upsert ::= ON CONFLICT DO NOTHING returning */
-	-8, /* (169) upsert ::= ON CONFLICT DO UPDATE SET setlist where_opt returning */
-	-2, /* (170) returning ::= RETURNING selcollist */
-	-2, /* (171) insert_cmd ::= INSERT orconf */
-	-1, /* (172) insert_cmd ::= REPLACE */
-	0, /* (173) idlist_opt ::= */
-	-3, /* (174) idlist_opt ::= LP idlist RP */
-	-3, /* (175) idlist ::= idlist COMMA nm */
-	-1, /* (176) idlist ::= nm */
-	-3, /* (177) expr ::= LP expr RP */
-	-1, /* (178) expr ::= ID|INDEXED */
-	-1, /* (179) expr ::= JOIN_KW */
-	-3, /* (180) expr ::= nm DOT nm */
-	-5, /* (181) expr ::= nm DOT nm DOT nm */
-	-1, /* (182) term ::= NULL|FLOAT|BLOB */
-	-1, /* (183) term ::= STRING */
-	-1, /* (184) term ::= INTEGER */
-	-1, /* (185) expr ::= VARIABLE */
-	-3, /* (186) expr ::= expr COLLATE ID|STRING */
-	-6, /* (187) expr ::= CAST LP expr AS typetoken RP */
-	-5, /* (188) expr ::= ID|INDEXED LP distinct exprlist RP */
-	-4, /* (189) expr ::= ID|INDEXED LP STAR RP */
-	-6, /* (190) expr ::= ID|INDEXED LP distinct exprlist RP filter_over */
-	-5, /* (191) expr ::= ID|INDEXED LP STAR RP filter_over */
-	-1, /* (192) term ::= CTIME_KW */
-	-5, /* (193) expr ::= LP nexprlist COMMA expr RP */
-	-3, /* (194) expr ::= expr AND expr */
-	-3, /* (195) expr ::= expr OR expr */
-	-3, /* (196) expr ::= expr LT|GT|GE|LE expr */
-	-3, /* (197) expr ::= expr EQ|NE expr */
-	-3, /* (198) expr ::= expr BITAND|BITOR|LSHIFT|RSHIFT expr */
-	-3, /* (199) expr ::= expr PLUS|MINUS expr */
-	-3, /* (200) expr ::= expr STAR|SLASH|REM expr */
-	-3, /* (201) expr ::= expr CONCAT expr */
-	-2, /* (202) likeop ::= NOT LIKE_KW|MATCH */
-	-3, /* (203) expr ::= expr likeop expr */
-	-5, /* (204) expr ::= expr likeop expr ESCAPE expr */
-	-2, /* (205) expr ::= expr ISNULL|NOTNULL */
-	-3, /* (206) expr ::= expr NOT NULL */
-	-3, /* (207) expr ::= expr IS expr */
-	-4, /* (208) expr ::= expr IS NOT expr */
-	-2, /* (209) expr ::= NOT expr */
-	-2, /* (210) expr ::= BITNOT expr */
-	-2, /* (211) expr ::= PLUS|MINUS expr */
-	-3, /* (212) expr ::= expr PTR expr */
-	-1, /* (213) between_op ::= BETWEEN */
-	-2, /* (214) between_op ::= NOT BETWEEN */
-	-5, /* (215) expr ::= expr between_op expr AND expr */
-	-1, /* (216) in_op ::= IN */
-	-2, /* (217) in_op ::= NOT IN */
-	-5, /* (218) expr ::= expr in_op LP exprlist RP */
-	-3, /* (219) expr ::= LP select RP */
-	-5, /* (220) expr ::= expr in_op LP select RP */
-	-5, /* (221) expr ::= expr in_op nm dbnm paren_exprlist */
-	-4, /* (222) expr ::= EXISTS LP select RP */
-	-5, /* (223) expr ::= CASE case_operand case_exprlist case_else END */
-	-5, /* (224) case_exprlist ::= case_exprlist WHEN expr THEN expr */
-	-4, /* (225) case_exprlist ::= WHEN expr THEN expr */
-	-2, /* (226) case_else ::= ELSE expr */
-	0, /* (227) case_else ::= */
-	-1, /* (228) case_operand ::= expr */
-	0, /* (229) case_operand ::= */
-	0, /* (230) exprlist ::= */
-	-3, /* (231) nexprlist ::= nexprlist COMMA expr */
-	-1, /* (232) nexprlist ::= expr */
-	0, /* (233) paren_exprlist ::= */
-	-3, /* (234) paren_exprlist ::= LP exprlist RP */
-	-12, /* (235) cmd ::= createkw uniqueflag INDEX ifnotexists nm dbnm ON nm LP sortlist RP where_opt */
-	-1, /* (236) uniqueflag ::= UNIQUE */
-	0, /* (237) uniqueflag ::= */
-	0, /* (238) eidlist_opt ::= */
-	-3, /* (239) eidlist_opt ::= LP eidlist RP */
-	-5, /* (240) eidlist ::= eidlist COMMA nm collate sortorder */
-	-3, /* (241) eidlist ::= nm collate sortorder */
-	0, /* (242) collate ::= */
-	-2, /* (243) collate ::= COLLATE ID|STRING */
-	-4, /* (244) cmd ::= DROP INDEX ifexists fullname */
-	-2, /* (245) cmd ::= VACUUM vinto */
-	-3, /* (246) cmd ::= VACUUM nm vinto */
-	-2, /* (247) vinto ::= INTO expr */
-	0, /* (248) vinto ::= */
-	-3, /* (249) cmd ::= PRAGMA nm dbnm */
-	-5, /* (250) cmd ::= PRAGMA nm dbnm EQ nmnum */
-	-6, /* (251) cmd ::= PRAGMA nm dbnm LP nmnum RP */
-	-5, /* (252) cmd ::= PRAGMA nm dbnm EQ minus_num */
-	-6, /* (253) cmd ::= PRAGMA nm dbnm LP minus_num RP */
-	-2, /* (254) plus_num ::= PLUS INTEGER|FLOAT */
-	-2, /* (255) minus_num ::= MINUS INTEGER|FLOAT */
-	-5, /* (256) cmd ::= createkw trigger_decl BEGIN trigger_cmd_list END */
-	-11, /* (257) trigger_decl ::= temp TRIGGER ifnotexists nm dbnm trigger_time trigger_event ON fullname foreach_clause when_clause */
-	-1, /* (258) trigger_time ::= BEFORE|AFTER */
-	-2, /* (259) trigger_time ::= INSTEAD OF */
-	0, /* (260) trigger_time ::= */
-	-1, /* (261) trigger_event ::= DELETE|INSERT */
-	-1, /* (262) trigger_event ::= UPDATE */
-	-3, /* (263) trigger_event ::= UPDATE OF idlist */
-	0, /* (264) when_clause ::= */
-	-2, /* (265) when_clause ::= WHEN expr */
-	-3, /* (266) trigger_cmd_list ::= trigger_cmd_list trigger_cmd SEMI */
-	-2, /* (267) trigger_cmd_list ::= trigger_cmd SEMI */
-	-3, /* (268) trnm ::= nm DOT nm */
-	-3, /* (269) tridxby ::= INDEXED BY nm */
-	-2, /* (270) tridxby ::= NOT INDEXED */
-	-9, /* (271) trigger_cmd ::= UPDATE orconf trnm tridxby SET setlist from where_opt scanpt */
-	-8, /* (272) trigger_cmd ::= scanpt insert_cmd INTO trnm idlist_opt select upsert scanpt */
-	-6, /* (273) trigger_cmd ::= DELETE FROM trnm tridxby where_opt scanpt */
-	-3, /* (274) trigger_cmd ::= scanpt select scanpt */
-	-4, /* (275) expr ::= RAISE LP IGNORE RP */
-	-6, /* (276) expr ::= RAISE LP raisetype COMMA nm RP */
-	-1, /* (277) raisetype ::= ROLLBACK */
-	-1, /* (278) raisetype ::= ABORT */
-	-1, /* (279) raisetype ::= FAIL */
-	-4, /* (280) cmd ::= DROP TRIGGER ifexists fullname */
-	-6, /* (281) cmd ::= ATTACH database_kw_opt expr AS expr key_opt */
-	-3, /* (282) cmd ::= DETACH database_kw_opt expr */
-	0, /* (283) key_opt ::= */
-	-2, /* (284) key_opt ::= KEY expr */
-	-1, /* (285) cmd ::= REINDEX */
-	-3, /* (286) cmd ::= REINDEX nm dbnm */
-	-1, /* (287) cmd ::= ANALYZE */
-	-3, /* (288) cmd ::= ANALYZE nm dbnm */
-	-6, /* (289) cmd ::= ALTER TABLE fullname RENAME TO nm */
-	-7, /* (290) cmd ::= ALTER TABLE add_column_fullname ADD kwcolumn_opt columnname carglist */
-	-6, /* (291) cmd ::= ALTER TABLE fullname DROP kwcolumn_opt nm */
-	-1, /* (292) add_column_fullname ::= fullname */
-	-8, /* (293) cmd ::= ALTER TABLE fullname RENAME kwcolumn_opt nm TO nm */
-	-1, /* (294) cmd ::= create_vtab */
-	-4, /* (295) cmd ::= create_vtab LP vtabarglist RP */
-	-8, /* (296) create_vtab ::= createkw VIRTUAL TABLE ifnotexists nm dbnm USING nm */
-	0, /* (297) vtabarg ::= */
-	-1, /* (298) vtabargtoken ::= ANY */
-	-3, /* (299) vtabargtoken ::= lp anylist RP */
-	-1, /* (300) lp ::= LP */
-	-2, /* (301) with ::= WITH wqlist */
-	-3, /* (302) with ::= WITH RECURSIVE wqlist */
-	-1, /* (303) wqas ::= AS */
-	-2, /* (304) wqas ::= AS MATERIALIZED */
-	-3, /* (305) wqas ::= AS NOT MATERIALIZED */
-	-6, /* (306) wqitem ::= nm eidlist_opt wqas LP select RP */
-	-1, /* (307) wqlist ::= wqitem */
-	-3, /* (308) wqlist ::= wqlist COMMA wqitem */
-	-1, /* (309) windowdefn_list ::= windowdefn */
-	-3, /* (310) windowdefn_list ::= windowdefn_list COMMA windowdefn */
-	-5, /* (311) windowdefn ::= nm AS LP window RP */
-	-5, /* (312) window ::= PARTITION BY nexprlist orderby_opt frame_opt */
-	-6, /* (313) window ::= nm PARTITION BY nexprlist orderby_opt frame_opt */
-	-4, /* (314) window ::= ORDER BY sortlist frame_opt */
-	-5, /* (315) window ::= nm ORDER BY sortlist frame_opt */
-	-1, /* (316) window ::= frame_opt */
-	-2, /* (317) window ::= nm frame_opt */
-	0, /* (318) frame_opt ::= */
-	-3, /* (319) frame_opt ::= range_or_rows frame_bound_s frame_exclude_opt */
-	-6, /* (320) frame_opt ::= range_or_rows BETWEEN frame_bound_s AND frame_bound_e frame_exclude_opt */
-	-1, /* (321) range_or_rows ::= RANGE|ROWS|GROUPS */
-	-1, /* (322) frame_bound_s ::= frame_bound */
-	-2, /* (323) frame_bound_s ::= UNBOUNDED PRECEDING */
-	-1, /* (324) frame_bound_e ::= frame_bound */
-	-2, /* (325) frame_bound_e ::= UNBOUNDED FOLLOWING */
-	-2, /* (326) frame_bound ::= expr PRECEDING|FOLLOWING */
-	-2, /* (327) frame_bound ::= CURRENT ROW */
-	0, /* (328) frame_exclude_opt ::= */
-	-2, /* (329) frame_exclude_opt ::= EXCLUDE frame_exclude */
-	-2, /* (330) frame_exclude ::= NO OTHERS */
-	-2, /* (331) frame_exclude ::= CURRENT ROW */
-	-1, /* (332) frame_exclude ::= GROUP|TIES */
-	-2, /* (333) window_clause ::= WINDOW windowdefn_list */
-	-2, /* (334) filter_over ::= filter_clause over_clause */
-	-1, /* (335) filter_over ::= over_clause */
-	-1, /* (336) filter_over ::= filter_clause */
-	-4, /* (337) over_clause ::= OVER LP window RP */
-	-2, /* (338) over_clause ::= OVER nm */
-	-5, /* (339) filter_clause ::= FILTER LP WHERE expr RP */
-	-1, /* (340) input ::= cmdlist */
-	-2, /* (341) cmdlist ::= cmdlist ecmd */
-	-1, /* (342) cmdlist ::= ecmd */
-	-1, /* (343) ecmd ::= SEMI */
-	-2, /* (344) ecmd ::= cmdx SEMI */
-	-3, /* (345) ecmd ::= explain cmdx SEMI */
-	0, /* (346) trans_opt ::= */
-	-1, /* (347) trans_opt ::= TRANSACTION */
-	-2, /* (348) trans_opt ::= TRANSACTION nm */
-	-1, /* (349) savepoint_opt ::= SAVEPOINT */
-	0, /* (350) savepoint_opt ::= */
-	-2, /* (351) cmd ::= create_table create_table_args */
-	-1, /* (352) table_option_set ::= table_option */
-	-4, /* (353) columnlist ::= columnlist COMMA columnname carglist */
-	-2, /* (354) columnlist ::= columnname carglist */
-	-1, /* (355) nm ::= ID|INDEXED */
-	-1, /* (356) nm ::= STRING */
-	-1, /* (357) nm ::= JOIN_KW */
-	-1, /* (358) typetoken ::= typename */
-	-1, /* (359) typename ::= ID|STRING */
-	-1, /* (360) signed ::= plus_num */
-	-1, /* (361) signed ::= minus_num */
-	-2, /* (362) carglist ::= carglist ccons */
-	0, /* (363) carglist ::= */
-	-2, /* (364) ccons ::= NULL onconf */
-	-4, /* (365) ccons ::= GENERATED ALWAYS AS generated */
-	-2, /* (366) ccons ::= AS generated */
-	-2, /* (367) conslist_opt ::= COMMA conslist */
-	-3, /* (368) conslist ::= conslist tconscomma tcons */
-	-1, /* (369) conslist ::= tcons */
-	0, /* (370) tconscomma ::= */
-	-1, /* (371) defer_subclause_opt ::= defer_subclause */
-	-1, /* (372) resolvetype ::= raisetype */
-	-1, /* (373) selectnowith ::= oneselect */
-	-1, /* (374) oneselect ::= values */
-	-2, /* (375) sclp ::= selcollist COMMA */
-	-1, /* (376) as ::= ID|STRING */
-	-1, /* (377) indexed_opt ::= indexed_by */
-	0, /* (378) returning ::= */
-	-1, /* (379) expr ::= term */
-	-1, /* (380) likeop ::= LIKE_KW|MATCH */
-	-1, /* (381) exprlist ::= nexprlist */
-	-1, /* (382) nmnum ::= plus_num */
-	-1, /* (383) nmnum ::= nm */
-	-1, /* (384) nmnum ::= ON */
-	-1, /* (385) nmnum ::= DELETE */
-	-1, /* (386) nmnum ::= DEFAULT */
-	-1, /* (387) plus_num ::= INTEGER|FLOAT */
-	0, /* (388) foreach_clause ::= */
-	-3, /* (389) foreach_clause ::= FOR EACH ROW */
-	-1, /* (390) trnm ::= nm */
-	0, /* (391) tridxby ::= */
-	-1, /* (392) database_kw_opt ::= DATABASE */
-	0, /* (393) database_kw_opt ::= */
-	0, /* (394) kwcolumn_opt ::= */
-	-1, /* (395) kwcolumn_opt ::= COLUMNKW */
-	-1, /* (396) vtabarglist ::= vtabarg */
-	-3, /* (397) vtabarglist ::= vtabarglist COMMA vtabarg */
-	-2, /* (398) vtabarg ::= vtabarg vtabargtoken */
-	0, /* (399) anylist ::= */
-	-4, /* (400) anylist ::= anylist LP anylist RP */
-	-2, /* (401) anylist ::= anylist ANY */
-	0, /* (402) with ::= */
+	-4, /* (45) ccons ::= GENERATED ALWAYS AS generated */
+	-2, /* (46) ccons ::= AS generated */
+	-3, /* (47) generated ::= LP expr RP */
+	-4, /* (48) generated ::= LP expr RP ID */
+	0, /* (49) autoinc ::= */
+	-1, /* (50) autoinc ::= AUTOINCR */
+	0, /* (51) refargs ::= */
+	-2, /* (52) refargs ::= refargs refarg */
+	-2, /* (53) refarg ::= MATCH nm */
+	-3, /* (54) refarg ::= ON INSERT refact */
+	-3, /* (55) refarg ::= ON DELETE refact */
+	-3, /* (56) refarg ::= ON UPDATE refact */
+	-2, /* (57) refact ::= SET NULL */
+	-2, /* (58) refact ::= SET DEFAULT */
+	-1, /* (59) refact ::= CASCADE */
+	-1, /* (60) refact ::= RESTRICT */
+	-2, /* (61) refact ::= NO ACTION */
+	-3, /* (62) defer_subclause ::= NOT DEFERRABLE init_deferred_pred_opt */
+	-2, /* (63) defer_subclause ::= DEFERRABLE init_deferred_pred_opt */
+	0, /* (64) init_deferred_pred_opt ::= */
+	-2, /* (65) init_deferred_pred_opt ::= INITIALLY DEFERRED */
+	-2, /* (66) init_deferred_pred_opt ::= INITIALLY IMMEDIATE */
+	0, /* (67) conslist_opt ::= */
+	-1, /* (68) tconscomma ::= COMMA */
+	-2, /* (69) tcons ::= CONSTRAINT nm */
+	-7, /* (70) tcons ::= PRIMARY KEY LP sortlist autoinc RP onconf */
+	-5, /* (71) tcons ::= UNIQUE LP sortlist RP onconf */
+	-5, /* (72) tcons ::= CHECK LP expr RP onconf */
+	-10, /* (73) tcons ::= FOREIGN KEY LP eidlist RP REFERENCES nm eidlist_opt refargs defer_subclause_opt */
+	0, /* (74) defer_subclause_opt ::= */
+	0, /* (75) onconf ::= */
+	-3, /* (76) onconf ::= ON CONFLICT resolvetype */
+	0, /* (77) orconf ::= */
+	-2, /* (78) orconf ::= OR resolvetype */
+	-1, /* (79) resolvetype ::= IGNORE */
+	-1, /* (80) resolvetype ::= REPLACE */
+	-4, /* (81) cmd ::= DROP TABLE ifexists fullname */
+	-2, /* (82) ifexists ::= IF EXISTS */
+	0, /* (83) ifexists ::= */
+	-9, /* (84) cmd ::= createkw temp VIEW ifnotexists nm dbnm eidlist_opt AS select */
+	-4, /* (85) cmd ::= DROP VIEW ifexists fullname */
+	-1, /* (86) cmd ::= select */
+	-3, /* (87) select ::= WITH wqlist selectnowith */
+	-4, /* (88) select ::= WITH RECURSIVE wqlist selectnowith */
+	-1, /* (89) select ::= selectnowith */
+	-3, /* (90) selectnowith ::= selectnowith multiselect_op oneselect */
+	-1, /* (91) multiselect_op ::= UNION */
+	-2, /* (92) multiselect_op ::= UNION ALL */
+	-1, /* (93) multiselect_op ::= EXCEPT|INTERSECT */
+	-9, /* (94) oneselect ::= SELECT distinct selcollist from where_opt groupby_opt having_opt orderby_opt limit_opt */
+	-10, /* (95) oneselect ::= SELECT distinct selcollist from where_opt groupby_opt having_opt window_clause orderby_opt limit_opt */
+	-4, /* (96) values ::= VALUES LP nexprlist RP */
+	-5, /* (97) values ::= values COMMA LP nexprlist RP */
+	-1, /* (98) distinct ::= DISTINCT */
+	-1, /* (99) distinct ::= ALL */
+	0, /* (100) distinct ::= */
+	0, /* (101) sclp ::= */
+	-5, /* (102) selcollist ::= sclp scanpt expr scanpt as */
+	-3, /* (103) selcollist ::= sclp scanpt STAR */
+	-5, /* (104) selcollist ::= sclp scanpt nm DOT STAR */
+	-2, /* (105) as ::= AS nm */
+	0, /* (106) as ::= */
+	0, /* (107) from ::= */
+	-2, /* (108) from ::= FROM seltablist */
+	-2, /* (109) stl_prefix ::= seltablist joinop */
+	0, /* (110) stl_prefix ::= */
+	-5, /* (111) seltablist ::= stl_prefix nm dbnm as on_using */
+	-6, /* (112) seltablist ::= stl_prefix nm dbnm as indexed_by on_using */
+	-8, /* (113) seltablist ::= stl_prefix nm dbnm LP exprlist RP as on_using */
+	-6, /* (114) seltablist ::= stl_prefix LP select RP as on_using */
+	-6, /* (115) seltablist ::= stl_prefix LP seltablist RP as on_using */
+	0, /* (116) dbnm ::= */
+	-2, /* (117) dbnm ::= DOT nm */
+	-1, /* (118) fullname ::= nm */
+	-3, /* (119) fullname ::= nm DOT nm */
+	-1, /* (120) xfullname ::= nm */
+	-3, /* (121) xfullname ::= nm DOT nm */
+	-5, /* (122) xfullname ::= nm DOT nm AS nm */
+	-3, /* (123) xfullname ::= nm AS nm */
+	-1, /* (124) joinop ::= COMMA|JOIN */
+	-2, /* (125) joinop ::= JOIN_KW JOIN */
+	-3, /* (126) joinop ::= JOIN_KW nm JOIN */
+	-4, /* (127) joinop ::= JOIN_KW nm nm JOIN */
+	-2, /* (128) on_using ::= ON expr */
+	-4, /* (129) on_using ::= USING LP idlist RP */
+	0, /* (130) on_using ::= */
+	0, /* (131) indexed_opt ::= */
+	-3, /* (132) indexed_by ::= INDEXED BY nm */
+	-2, /* (133) indexed_by ::= NOT INDEXED */
+	0, /* (134) orderby_opt ::= */
+	-3, /* (135) orderby_opt ::= ORDER BY sortlist */
+	-5, /* (136) sortlist ::= sortlist COMMA expr sortorder nulls */
+	-3, /* (137) sortlist ::= expr sortorder nulls */
+	-1, /* (138) sortorder ::= ASC */
+	-1, /* (139) sortorder ::= DESC */
+	0, /* (140) sortorder ::= */
+	-2, /* (141) nulls ::= NULLS FIRST */
+	-2, /* (142) nulls ::= NULLS LAST */
+	0, /* (143) nulls ::= */
+	0, /* (144) groupby_opt ::= */
+	-3, /* (145) groupby_opt ::= GROUP BY nexprlist */
+	0, /* (146) having_opt ::= */
+	-2, /* (147) having_opt ::= HAVING expr */
+	0, /* (148) limit_opt ::= */
+	-2, /* (149) limit_opt ::= LIMIT expr */
+	-4, /* (150) limit_opt ::= LIMIT expr OFFSET expr */
+	-4, /* (151) limit_opt ::= LIMIT expr COMMA expr */
+	-8, /* (152) cmd ::= with DELETE FROM xfullname indexed_opt where_opt_ret orderby_opt limit_opt */
+	0, /* (153) where_opt ::= */
+	-2, /* (154) where_opt ::= WHERE expr */
+	0, /* (155) where_opt_ret ::= */
+	-2, /* (156) where_opt_ret ::= WHERE expr */
+	-2, /* (157) where_opt_ret ::= RETURNING selcollist */
+	-4, /* (158) where_opt_ret ::= WHERE expr RETURNING selcollist */
+	-11, /* (159) cmd ::= with UPDATE orconf xfullname indexed_opt SET setlist from where_opt_ret orderby_opt limit_opt */
+	-5, /* (160) setlist ::= setlist COMMA nm EQ expr */
+	-7, /* (161) setlist ::= setlist COMMA LP idlist RP EQ expr */
+	-3, /* (162) setlist ::= nm EQ expr */
+	-5, /* (163) setlist ::= LP idlist RP EQ expr */
+	-7, /* (164) cmd ::= with insert_cmd INTO xfullname idlist_opt select upsert */
+	-8, /* (165) cmd ::= with insert_cmd INTO xfullname idlist_opt DEFAULT VALUES returning */
+	0, /* (166) upsert ::= */
+	-2, /* (167) upsert ::= RETURNING selcollist */
+	-12, /* (168) upsert ::= ON CONFLICT LP sortlist RP where_opt DO UPDATE SET setlist where_opt upsert */
+	-9, /* (169) upsert ::= ON CONFLICT LP sortlist RP where_opt DO NOTHING upsert */
+	-5, /* (170) upsert ::= ON CONFLICT DO NOTHING returning */
+	-8, /* (171) upsert ::= ON CONFLICT DO UPDATE SET setlist where_opt returning */
+	-2, /* (172) returning ::= RETURNING selcollist */
+	-2, /* (173) insert_cmd ::= INSERT orconf */
+	-1, /* (174) insert_cmd ::= REPLACE */
+	0, /* (175) idlist_opt ::= */
+	-3, /* (176) idlist_opt ::= LP idlist RP */
+	-3, /* (177) idlist ::= idlist COMMA nm */
+	-1, /* (178) idlist ::= nm */
+	-3, /* (179) expr ::= LP expr RP */
+	-1, /* (180) expr ::= ID|INDEXED */
+	-1, /* (181) expr ::= JOIN_KW */
+	-3, /* (182) expr ::= nm DOT nm */
+	-5, /* (183) expr ::= nm DOT nm DOT nm */
+	-1, /* (184) term ::= NULL|FLOAT|BLOB */
+	-1, /* (185) term ::= STRING */
+	-1, /* (186) term ::= INTEGER */
+	-1, /* (187) expr ::= VARIABLE */
+	-3, /* (188) expr ::= expr COLLATE ID|STRING */
+	-6, /* (189) expr ::= CAST LP expr AS typetoken RP */
+	-5, /* (190) expr ::= ID|INDEXED LP distinct exprlist RP */
+	-4, /* (191) expr ::= ID|INDEXED LP STAR RP */
+	-6, /* (192) expr ::= ID|INDEXED LP distinct exprlist RP filter_over */
+	-5, /* (193) expr ::= ID|INDEXED LP STAR RP filter_over */
+	-1, /* (194) term ::= CTIME_KW */
+	-5, /* (195) expr ::= LP nexprlist COMMA expr RP */
+	-3, /* (196) expr ::= expr AND expr */
+	-3, /* (197) expr ::= expr OR expr */
+	-3, /* (198) expr ::= expr LT|GT|GE|LE expr */
+	-3, /* (199) expr ::= expr EQ|NE expr */
+	-3, /* (200) expr ::= expr BITAND|BITOR|LSHIFT|RSHIFT expr */
+	-3, /* (201) expr ::= expr PLUS|MINUS expr */
+	-3, /* (202) expr ::= expr STAR|SLASH|REM expr */
+	-3, /* (203) expr ::= expr CONCAT expr */
+	-2, /* (204) likeop ::= NOT LIKE_KW|MATCH */
+	-3, /* (205) expr ::= expr likeop expr */
+	-5, /* (206) expr ::= expr likeop expr ESCAPE expr */
+	-2, /* (207) expr ::= expr ISNULL|NOTNULL */
+	-3, /* (208) expr ::= expr NOT NULL */
+	-3, /* (209) expr ::= expr IS expr */
+	-4, /* (210) expr ::= expr IS NOT expr */
+	-6, /* (211) expr ::= expr IS NOT DISTINCT FROM expr */
+	-5, /* (212) expr ::= expr IS DISTINCT FROM expr */
+	-2, /* (213) expr ::= NOT expr */
+	-2, /* (214) expr ::= BITNOT expr */
+	-2, /* (215) expr ::= PLUS|MINUS expr */
+	-3, /* (216) expr ::= expr PTR expr */
+	-1, /* (217) between_op ::= BETWEEN */
+	-2, /* (218) between_op ::= NOT BETWEEN */
+	-5, /* (219) expr ::= expr between_op expr AND expr */
+	-1, /* (220) in_op ::= IN */
+	-2, /* (221) in_op ::= NOT IN */
+	-5, /* (222) expr ::= expr in_op LP exprlist RP */
+	-3, /* (223) expr ::= LP select RP */
+	-5, /* (224) expr ::= expr in_op LP select RP */
+	-5, /* (225) expr ::= expr in_op nm dbnm paren_exprlist */
+	-4, /* (226) expr ::= EXISTS LP select RP */
+	-5, /* (227) expr ::= CASE case_operand case_exprlist case_else END */
+	-5, /* (228) case_exprlist ::= case_exprlist WHEN expr THEN expr */
+	-4, /* (229) case_exprlist ::= WHEN expr THEN expr */
+	-2, /* (230) case_else ::= ELSE expr */
+	0, /* (231) case_else ::= */
+	0, /* (232) case_operand ::= */
+	0, /* (233) exprlist ::= */
+	-3, /* (234) nexprlist ::= nexprlist COMMA expr */
+	-1, /* (235) nexprlist ::= expr */
+	0, /* (236) paren_exprlist ::= */
+	-3, /* (237) paren_exprlist ::= LP exprlist RP */
+	-12, /* (238) cmd ::= createkw uniqueflag INDEX ifnotexists nm dbnm ON nm LP sortlist RP where_opt */
+	-1, /* (239) uniqueflag ::= UNIQUE */
+	0, /* (240) uniqueflag ::= */
+	0, /* (241) eidlist_opt ::= */
+	-3, /* (242) eidlist_opt ::= LP eidlist RP */
+	-5, /* (243) eidlist ::= eidlist COMMA nm collate sortorder */
+	-3, /* (244) eidlist ::= nm collate sortorder */
+	0, /* (245) collate ::= */
+	-2, /* (246) collate ::= COLLATE ID|STRING */
+	-4, /* (247) cmd ::= DROP INDEX ifexists fullname */
+	-2, /* (248) cmd ::= VACUUM vinto */
+	-3, /* (249) cmd ::= VACUUM nm vinto */
+	-2, /* (250) vinto ::= INTO expr */
+	0, /* (251) vinto ::= */
+	-3, /* (252) cmd ::= PRAGMA nm dbnm */
+	-5, /* (253) cmd ::= PRAGMA nm dbnm EQ nmnum */
+	-6, /* (254) cmd ::= PRAGMA nm dbnm LP nmnum RP */
+	-5, /* (255) cmd ::= PRAGMA nm dbnm EQ minus_num */
+	-6, /* (256) cmd ::= PRAGMA nm dbnm LP minus_num RP */
+	-2, /* (257) plus_num ::= PLUS INTEGER|FLOAT */
+	-2, /* (258) minus_num ::= MINUS INTEGER|FLOAT */
+	-5, /* (259) cmd ::= createkw trigger_decl BEGIN trigger_cmd_list END */
+	-11, /* (260) trigger_decl ::= temp TRIGGER ifnotexists nm dbnm trigger_time trigger_event ON fullname foreach_clause when_clause */
+	-1, /* (261) trigger_time ::= BEFORE|AFTER */
+	-2, /* (262) trigger_time ::= INSTEAD OF */
+	0, /* (263) trigger_time ::= */
+	-1, /* (264) trigger_event ::= DELETE|INSERT */
+	-1, /* (265) trigger_event ::= UPDATE */
+	-3, /* (266) trigger_event ::= UPDATE OF idlist */
+	0, /* (267) when_clause ::= */
+	-2, /* (268) when_clause ::= WHEN expr */
+	-3, /* (269) trigger_cmd_list ::= trigger_cmd_list trigger_cmd SEMI */
+	-2, /* (270) trigger_cmd_list ::= trigger_cmd SEMI */
+	-3, /* (271) trnm ::= nm DOT nm */
+	-3, /* (272) tridxby ::= INDEXED BY nm */
+	-2, /* (273) tridxby ::= NOT INDEXED */
+	-9, /* (274) trigger_cmd ::= UPDATE orconf trnm tridxby SET setlist from where_opt scanpt */
+	-8, /* (275) trigger_cmd ::= scanpt insert_cmd INTO trnm idlist_opt select upsert scanpt */
+	-6, /* (276) trigger_cmd ::= DELETE FROM trnm tridxby where_opt scanpt */
+	-3, /* (277) trigger_cmd ::= scanpt select scanpt */
+	-4, /* (278) expr ::= RAISE LP IGNORE RP */
+	-6, /* (279) expr ::= RAISE LP raisetype COMMA nm RP */
+	-1, /* (280) raisetype ::= ROLLBACK */
+	-1, /* (281) raisetype ::= ABORT */
+	-1, /* (282) raisetype ::= FAIL */
+	-4, /* (283) cmd ::= DROP TRIGGER ifexists fullname */
+	-6, /* (284) cmd ::= ATTACH database_kw_opt expr AS expr key_opt */
+	-3, /* (285) cmd ::= DETACH database_kw_opt expr */
+	0, /* (286) key_opt ::= */
+	-2, /* (287) key_opt ::= KEY expr */
+	-1, /* (288) cmd ::= REINDEX */
+	-3, /* (289) cmd ::= REINDEX nm dbnm */
+	-1, /* (290) cmd ::= ANALYZE */
+	-3, /* (291) cmd ::= ANALYZE nm dbnm */
+	-6, /* (292) cmd ::= ALTER TABLE fullname RENAME TO nm */
+	-7, /* (293) cmd ::= ALTER TABLE add_column_fullname ADD kwcolumn_opt columnname carglist */
+	-6, /* (294) cmd ::= ALTER TABLE fullname DROP kwcolumn_opt nm */
+	-1, /* (295) add_column_fullname ::= fullname */
+	-8, /* (296) cmd ::= ALTER TABLE fullname RENAME kwcolumn_opt nm TO nm */
+	-1, /* (297) cmd ::= create_vtab */
+	-4, /* (298) cmd ::= create_vtab LP vtabarglist RP */
+	-8, /* (299) create_vtab ::= createkw VIRTUAL TABLE ifnotexists nm dbnm USING nm */
+	0, /* (300) vtabarg ::= */
+	-1, /* (301) vtabargtoken ::= ANY */
+	-3, /* (302) vtabargtoken ::= lp anylist RP */
+	-1, /* (303) lp ::= LP */
+	-2, /* (304) with ::= WITH wqlist */
+	-3, /* (305) with ::= WITH RECURSIVE wqlist */
+	-1, /* (306) wqas ::= AS */
+	-2, /* (307) wqas ::= AS MATERIALIZED */
+	-3, /* (308) wqas ::= AS NOT MATERIALIZED */
+	-6, /* (309) wqitem ::= nm eidlist_opt wqas LP select RP */
+	-1, /* (310) wqlist ::= wqitem */
+	-3, /* (311) wqlist ::= wqlist COMMA wqitem */
+	-1, /* (312) windowdefn_list ::= windowdefn */
+	-3, /* (313) windowdefn_list ::= windowdefn_list COMMA windowdefn */
+	-5, /* (314) windowdefn ::= nm AS LP window RP */
+	-5, /* (315) window ::= PARTITION BY nexprlist orderby_opt frame_opt */
+	-6, /* (316) window ::= nm PARTITION BY nexprlist orderby_opt frame_opt */
+	-4, /* (317) window ::= ORDER BY sortlist frame_opt */
+	-5, /* (318) window ::= nm ORDER BY sortlist frame_opt */
+	-1, /* (319) window ::= frame_opt */
+	-2, /* (320) window ::= nm frame_opt */
+	0, /* (321) frame_opt ::= */
+	-3, /* (322) frame_opt ::= range_or_rows frame_bound_s frame_exclude_opt */
+	-6, /* (323) frame_opt ::= range_or_rows BETWEEN frame_bound_s AND frame_bound_e frame_exclude_opt */
+	-1, /* (324) range_or_rows ::= RANGE|ROWS|GROUPS */
+	-1, /* (325) frame_bound_s ::= frame_bound */
+	-2, /* (326) frame_bound_s ::= UNBOUNDED PRECEDING */
+	-1, /* (327) frame_bound_e ::= frame_bound */
+	-2, /* (328) frame_bound_e ::= UNBOUNDED FOLLOWING */
+	-2, /* (329) frame_bound ::= expr PRECEDING|FOLLOWING */
+	-2, /* (330) frame_bound ::= CURRENT ROW */
+	0, /* (331) frame_exclude_opt ::= */
+	-2, /* (332) frame_exclude_opt ::= EXCLUDE frame_exclude */
+	-2, /* (333) frame_exclude ::= NO OTHERS */
+	-2, /* (334) frame_exclude ::= CURRENT ROW */
+	-1, /* (335) frame_exclude ::= GROUP|TIES */
+	-2, /* (336) window_clause ::= WINDOW windowdefn_list */
+	-2, /* (337) filter_over ::= filter_clause over_clause */
+	-1, /* (338) filter_over ::= over_clause */
+	-1, /* (339) filter_over ::= filter_clause */
+	-4, /* (340) over_clause ::= OVER LP window RP */
+	-2, /* (341) over_clause ::= OVER nm */
+	-5, /* (342) filter_clause ::= FILTER LP WHERE expr RP */
+	-1, /* (343) input ::= cmdlist */
+	-2, /* (344) cmdlist ::= cmdlist ecmd */
+	-1, /* (345) cmdlist ::= ecmd */
+	-1, /* (346) ecmd ::= SEMI */
+	-2, /* (347) ecmd ::= cmdx SEMI */
+	-3, /* (348) ecmd ::= explain cmdx SEMI */
+	0, /* (349) trans_opt ::= */
+	-1, /* (350) trans_opt ::= TRANSACTION */
+	-2, /* (351) trans_opt ::= TRANSACTION nm */
+	-1, /* (352) savepoint_opt ::= SAVEPOINT */
+	0, /* (353) savepoint_opt ::= */
+	-2, /* (354) cmd ::= create_table create_table_args */
+	-1, /* (355) table_option_set ::= table_option */
+	-4, /* (356) columnlist ::= columnlist COMMA columnname carglist */
+	-2, /* (357) columnlist ::= columnname carglist */
+	-1, /* (358) nm ::= ID|INDEXED */
+	-1, /* (359) nm ::= STRING */
+	-1, /* (360) nm ::= JOIN_KW */
+	-1, /* (361) typetoken ::= typename */
+	-1, /* (362) typename ::= ID|STRING */
+	-1, /* (363) signed ::= plus_num */
+	-1, /* (364) signed ::= minus_num */
+	-2, /* (365) carglist ::= carglist ccons */
+	0, /* (366) carglist ::= */
+	-2, /* (367) ccons ::= NULL onconf */
+	-2, /* (368) conslist_opt ::= COMMA conslist */
+	-3, /* (369) conslist ::= conslist tconscomma tcons */
+	-1, /* (370) conslist ::= tcons */
+	0, /* (371) tconscomma ::= */
+	-1, /* (372) defer_subclause_opt ::= defer_subclause */
+	-1, /* (373) resolvetype ::= raisetype */
+	-1, /* (374) selectnowith ::= oneselect */
+	-1, /* (375) oneselect ::= values */
+	-2, /* (376) sclp ::= selcollist COMMA */
+	-1, /* (377) as ::= ID|STRING */
+	-1, /* (378) indexed_opt ::= indexed_by */
+	0, /* (379) returning ::= */
+	-1, /* (380) expr ::= term */
+	-1, /* (381) likeop ::= LIKE_KW|MATCH */
+	-1, /* (382) case_operand ::= expr */
+	-1, /* (383) exprlist ::= nexprlist */
+	-1, /* (384) nmnum ::= plus_num */
+	-1, /* (385) nmnum ::= nm */
+	-1, /* (386) nmnum ::= ON */
+	-1, /* (387) nmnum ::= DELETE */
+	-1, /* (388) nmnum ::= DEFAULT */
+	-1, /* (389) plus_num ::= INTEGER|FLOAT */
+	0, /* (390) foreach_clause ::= */
+	-3, /* (391) foreach_clause ::= FOR EACH ROW */
+	-1, /* (392) trnm ::= nm */
+	0, /* (393) tridxby ::= */
+	-1, /* (394) database_kw_opt ::= DATABASE */
+	0, /* (395) database_kw_opt ::= */
+	0, /* (396) kwcolumn_opt ::= */
+	-1, /* (397) kwcolumn_opt ::= COLUMNKW */
+	-1, /* (398) vtabarglist ::= vtabarg */
+	-3, /* (399) vtabarglist ::= vtabarglist COMMA vtabarg */
+	-2, /* (400) vtabarg ::= vtabarg vtabargtoken */
+	0, /* (401) anylist ::= */
+	-4, /* (402) anylist ::= anylist LP anylist RP */
+	-2, /* (403) anylist ::= anylist ANY */
+	0, /* (404) with ::= */
 }
 
 /*
@@ -3549,27 +3609,27 @@ func (yypParser *yyParser) yy_reduce(
       case 0: /* explain ::= EXPLAIN */
 //line 162 "parse.y"
 { pParse.explain = 1; }
-//line 3586 "parse.go"
+//line 3613 "parse.go"
         break
       case 1: /* explain ::= EXPLAIN QUERY PLAN */
 //line 163 "parse.y"
 { pParse.explain = 2; }
-//line 3591 "parse.go"
+//line 3618 "parse.go"
         break
       case 2: /* cmdx ::= cmd */
 //line 165 "parse.y"
 { sqlite3FinishCoding(pParse); }
-//line 3596 "parse.go"
+//line 3623 "parse.go"
         break
       case 3: /* cmd ::= BEGIN transtype trans_opt */
 //line 170 "parse.y"
 {sqlite3BeginTransaction(pParse, yypParser.yystack[yypParser.yytos+ -1].minor.yy236);}
-//line 3601 "parse.go"
+//line 3628 "parse.go"
         break
       case 4: /* transtype ::= */
 //line 175 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 1].minor.yy236 = TK_DEFERRED;}
-//line 3606 "parse.go"
+//line 3633 "parse.go"
         break
       case 5: /* transtype ::= DEFERRED */
         fallthrough
@@ -3578,71 +3638,71 @@ func (yypParser *yyParser) yy_reduce(
       case 7: /* transtype ::= EXCLUSIVE */ yytestcase(yyruleno==7);
 //line 176 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 0].minor.yy236 = yypParser.yystack[yypParser.yytos+ 0].major; /*A-overwrites-X*/}
-//line 3615 "parse.go"
+//line 3642 "parse.go"
         break
       case 8: /* cmd ::= COMMIT|END trans_opt */
         fallthrough
       case 9: /* cmd ::= ROLLBACK trans_opt */ yytestcase(yyruleno==9);
 //line 179 "parse.y"
 {sqlite3EndTransaction(pParse,yypParser.yystack[yypParser.yytos+ -1].major);}
-//line 3622 "parse.go"
+//line 3649 "parse.go"
         break
       case 10: /* cmd ::= SAVEPOINT nm */
 //line 184 "parse.y"
 {
   sqlite3Savepoint(pParse, SAVEPOINT_BEGIN, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
 }
-//line 3629 "parse.go"
+//line 3656 "parse.go"
         break
       case 11: /* cmd ::= RELEASE savepoint_opt nm */
 //line 187 "parse.y"
 {
   sqlite3Savepoint(pParse, SAVEPOINT_RELEASE, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
 }
-//line 3636 "parse.go"
+//line 3663 "parse.go"
         break
       case 12: /* cmd ::= ROLLBACK trans_opt TO savepoint_opt nm */
 //line 190 "parse.y"
 {
   sqlite3Savepoint(pParse, SAVEPOINT_ROLLBACK, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
 }
-//line 3643 "parse.go"
+//line 3670 "parse.go"
         break
       case 13: /* create_table ::= createkw temp TABLE ifnotexists nm dbnm */
 //line 197 "parse.y"
 {
    sqlite3StartTable(pParse,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0,yypParser.yystack[yypParser.yytos+ -4].minor.yy394,0,0,yypParser.yystack[yypParser.yytos+ -2].minor.yy394);
 }
-//line 3650 "parse.go"
+//line 3677 "parse.go"
         break
       case 14: /* createkw ::= CREATE */
 //line 200 "parse.y"
 {disableLookaside(pParse);}
-//line 3655 "parse.go"
+//line 3682 "parse.go"
         break
       case 15: /* ifnotexists ::= */
         fallthrough
       case 18: /* temp ::= */ yytestcase(yyruleno==18);
         fallthrough
-      case 47: /* autoinc ::= */ yytestcase(yyruleno==47);
+      case 49: /* autoinc ::= */ yytestcase(yyruleno==49);
         fallthrough
-      case 62: /* init_deferred_pred_opt ::= */ yytestcase(yyruleno==62);
+      case 64: /* init_deferred_pred_opt ::= */ yytestcase(yyruleno==64);
         fallthrough
-      case 72: /* defer_subclause_opt ::= */ yytestcase(yyruleno==72);
+      case 74: /* defer_subclause_opt ::= */ yytestcase(yyruleno==74);
         fallthrough
-      case 81: /* ifexists ::= */ yytestcase(yyruleno==81);
+      case 83: /* ifexists ::= */ yytestcase(yyruleno==83);
         fallthrough
-      case 98: /* distinct ::= */ yytestcase(yyruleno==98);
+      case 100: /* distinct ::= */ yytestcase(yyruleno==100);
         fallthrough
-      case 242: /* collate ::= */ yytestcase(yyruleno==242);
+      case 245: /* collate ::= */ yytestcase(yyruleno==245);
 //line 203 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 1].minor.yy394 = 0;}
-//line 3674 "parse.go"
+//line 3701 "parse.go"
         break
       case 16: /* ifnotexists ::= IF NOT EXISTS */
 //line 204 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -2].minor.yy394 = 1;}
-//line 3679 "parse.go"
+//line 3706 "parse.go"
         break
       case 17: /* temp ::= TEMP */
 //line 207 "parse.y"
@@ -3653,14 +3713,14 @@ func (yypParser *yyParser) yy_reduce(
     yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = 0;
   }
 }
-//line 3690 "parse.go"
+//line 3717 "parse.go"
         break
       case 19: /* create_table_args ::= LP columnlist conslist_opt RP table_option_set */
 //line 216 "parse.y"
 {
   sqlite3EndTable(pParse,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,yypParser.yystack[yypParser.yytos+ 0].minor.yy338,nil);
 }
-//line 3697 "parse.go"
+//line 3724 "parse.go"
         break
       case 20: /* create_table_args ::= AS select */
 //line 219 "parse.y"
@@ -3668,17 +3728,17 @@ func (yypParser *yyParser) yy_reduce(
   sqlite3EndTable(pParse,nil,nil,0,yypParser.yystack[yypParser.yytos+ 0].minor.yy361);
   sqlite3SelectDelete(pParse.db, yypParser.yystack[yypParser.yytos+ 0].minor.yy361);
 }
-//line 3705 "parse.go"
+//line 3732 "parse.go"
         break
       case 21: /* table_option_set ::= */
 //line 225 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 1].minor.yy338 = 0;}
-//line 3710 "parse.go"
+//line 3737 "parse.go"
         break
       case 22: /* table_option_set ::= table_option_set COMMA table_option */
 //line 227 "parse.y"
 {yylhsminor.yy338 = yypParser.yystack[yypParser.yytos+ -2].minor.yy338|yypParser.yystack[yypParser.yytos+ 0].minor.yy338;}
-//line 3715 "parse.go"
+//line 3742 "parse.go"
   yypParser.yystack[yypParser.yytos+ -2].minor.yy338 = yylhsminor.yy338;
         break
       case 23: /* table_option ::= WITHOUT nm */
@@ -3691,348 +3751,359 @@ func (yypParser *yyParser) yy_reduce(
     sqlite3ErrorMsg(pParse, "unknown table option: %.*s", yypParser.yystack[yypParser.yytos+ 0].minor.yy0.n, yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z);
   }
 }
-//line 3728 "parse.go"
+//line 3755 "parse.go"
         break
       case 24: /* table_option ::= nm */
 //line 236 "parse.y"
 {
   if( yypParser.yystack[yypParser.yytos+ 0].minor.yy0.n==6 && sqlite3_strnicmp(yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z,[]byte("strict"),6)==0 ){
+    sqlite3CheckVersion(pParse, 3037000, "STRICT", &yypParser.yystack[yypParser.yytos+ 0].minor.yy0, nil);
     yylhsminor.yy338 = TF_Strict;
   }else{
     yylhsminor.yy338 = 0;
     sqlite3ErrorMsg(pParse, "unknown table option: %.*s", yypParser.yystack[yypParser.yytos+ 0].minor.yy0.n, yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z);
   }
 }
-//line 3740 "parse.go"
+//line 3768 "parse.go"
   yypParser.yystack[yypParser.yytos+ 0].minor.yy338 = yylhsminor.yy338;
         break
       case 25: /* columnname ::= nm typetoken */
-//line 246 "parse.y"
+//line 247 "parse.y"
 {sqlite3AddColumn(pParse,yypParser.yystack[yypParser.yytos+ -1].minor.yy0,yypParser.yystack[yypParser.yytos+ 0].minor.yy0);}
-//line 3746 "parse.go"
+//line 3774 "parse.go"
         break
       case 26: /* typetoken ::= */
-//line 333 "parse.y"
+//line 334 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 1].minor.yy0.n = 0; yypParser.yystack[yypParser.yytos+ 1].minor.yy0.z = []byte{};}
-//line 3751 "parse.go"
+//line 3779 "parse.go"
         break
       case 27: /* typetoken ::= typename LP signed RP */
-//line 335 "parse.y"
+//line 336 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -3].minor.yy0.n = uint(len(yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z) - len(yypParser.yystack[yypParser.yytos+ -3].minor.yy0.z));
+  yypParser.yystack[yypParser.yytos+ -3].minor.yy0.n = uint(len(yypParser.yystack[yypParser.yytos+ -3].minor.yy0.z) - len(yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z)) + yypParser.yystack[yypParser.yytos+ 0].minor.yy0.n;
 }
-//line 3758 "parse.go"
+//line 3786 "parse.go"
         break
       case 28: /* typetoken ::= typename LP signed COMMA signed RP */
-//line 338 "parse.y"
+//line 339 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -5].minor.yy0.n = uint(len(yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z) - len(yypParser.yystack[yypParser.yytos+ -5].minor.yy0.z));
+  yypParser.yystack[yypParser.yytos+ -5].minor.yy0.n = uint(len(yypParser.yystack[yypParser.yytos+ -5].minor.yy0.z) - len(yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z)) + yypParser.yystack[yypParser.yytos+ 0].minor.yy0.n;
 }
-//line 3765 "parse.go"
+//line 3793 "parse.go"
         break
       case 29: /* typename ::= typename ID|STRING */
-//line 343 "parse.y"
-{yypParser.yystack[yypParser.yytos+ -1].minor.yy0.n=yypParser.yystack[yypParser.yytos+ 0].minor.yy0.n+(int)(yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z-yypParser.yystack[yypParser.yytos+ -1].minor.yy0.z);}
-//line 3770 "parse.go"
+//line 344 "parse.y"
+{yypParser.yystack[yypParser.yytos+ -1].minor.yy0.n=yypParser.yystack[yypParser.yytos+ 0].minor.yy0.n+uint(len(yypParser.yystack[yypParser.yytos+ -1].minor.yy0.z)-len(yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z));}
+//line 3798 "parse.go"
         break
       case 30: /* scanpt ::= */
-//line 361 "parse.y"
+//line 362 "parse.y"
 {
   assert( yyLookahead!=YYNOCODE, "yyLookahead!=YYNOCODE");
   yypParser.yystack[yypParser.yytos+ 1].minor.yy79 = yyLookaheadToken.z;
 }
-//line 3778 "parse.go"
+//line 3806 "parse.go"
         break
       case 31: /* scantok ::= */
-//line 365 "parse.y"
+//line 366 "parse.y"
 {
   assert( yyLookahead!=YYNOCODE, "yyLookahead!=YYNOCODE");
   yypParser.yystack[yypParser.yytos+ 1].minor.yy0 = yyLookaheadToken;
 }
-//line 3786 "parse.go"
+//line 3814 "parse.go"
         break
       case 32: /* ccons ::= CONSTRAINT nm */
         fallthrough
-      case 67: /* tcons ::= CONSTRAINT nm */ yytestcase(yyruleno==67);
-//line 375 "parse.y"
+      case 69: /* tcons ::= CONSTRAINT nm */ yytestcase(yyruleno==69);
+//line 376 "parse.y"
 {pParse.constraintName = yypParser.yystack[yypParser.yytos+ 0].minor.yy0;}
-//line 3793 "parse.go"
+//line 3821 "parse.go"
         break
       case 33: /* ccons ::= DEFAULT scantok term */
-//line 377 "parse.y"
-{/*sqlite3AddDefaultValue(pParse,yypParser.yystack[yypParser.yytos+ 0].minor.yy634,yypParser.yystack[yypParser.yytos+ -1].minor.yy0.z,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0.z[yypParser.yystack[yypParser.yytos+ -1].minor.yy0.n]);*/}
-//line 3798 "parse.go"
+//line 378 "parse.y"
+{sqlite3AddDefaultValue(pParse,yypParser.yystack[yypParser.yytos+ 0].minor.yy634,yypParser.yystack[yypParser.yytos+ -1].minor.yy0.z,yypParser.yystack[yypParser.yytos+ -1].minor.yy0.z[yypParser.yystack[yypParser.yytos+ -1].minor.yy0.n:]);}
+//line 3826 "parse.go"
         break
       case 34: /* ccons ::= DEFAULT LP expr RP */
-//line 379 "parse.y"
-{/*sqlite3AddDefaultValue(pParse,yypParser.yystack[yypParser.yytos+ -1].minor.yy634,yypParser.yystack[yypParser.yytos+ -2].minor.yy0.z+1,yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z);*/}
-//line 3803 "parse.go"
+//line 380 "parse.y"
+{sqlite3AddDefaultValue(pParse,yypParser.yystack[yypParser.yytos+ -1].minor.yy634,yypParser.yystack[yypParser.yytos+ -2].minor.yy0.z[1:],yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z);}
+//line 3831 "parse.go"
         break
       case 35: /* ccons ::= DEFAULT PLUS scantok term */
-//line 381 "parse.y"
-{/*sqlite3AddDefaultValue(pParse,yypParser.yystack[yypParser.yytos+ 0].minor.yy634,yypParser.yystack[yypParser.yytos+ -2].minor.yy0.z,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0.z[yypParser.yystack[yypParser.yytos+ -1].minor.yy0.n]);*/}
-//line 3808 "parse.go"
+//line 382 "parse.y"
+{sqlite3AddDefaultValue(pParse,yypParser.yystack[yypParser.yytos+ 0].minor.yy634,yypParser.yystack[yypParser.yytos+ -2].minor.yy0.z,yypParser.yystack[yypParser.yytos+ -1].minor.yy0.z[yypParser.yystack[yypParser.yytos+ -1].minor.yy0.n:]);}
+//line 3836 "parse.go"
         break
       case 36: /* ccons ::= DEFAULT MINUS scantok term */
-//line 382 "parse.y"
+//line 383 "parse.y"
 {
   p := sqlite3PExpr(pParse, TK_UMINUS, yypParser.yystack[yypParser.yytos+ 0].minor.yy634, nil);
-  // sqlite3AddDefaultValue(pParse,p,yypParser.yystack[yypParser.yytos+ -2].minor.yy0.z,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0.z[yypParser.yystack[yypParser.yytos+ -1].minor.yy0.n]);
+  sqlite3AddDefaultValue(pParse,p,yypParser.yystack[yypParser.yytos+ -2].minor.yy0.z,yypParser.yystack[yypParser.yytos+ -1].minor.yy0.z[yypParser.yystack[yypParser.yytos+ -1].minor.yy0.n:]);
 }
-//line 3816 "parse.go"
+//line 3844 "parse.go"
         break
       case 37: /* ccons ::= DEFAULT scantok ID|INDEXED */
-//line 386 "parse.y"
+//line 387 "parse.y"
 {
   p := tokenExpr(pParse, TK_STRING, yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
   if( p != nil){
     sqlite3ExprIdToTrueFalse(p);
     testcase( p.op==TK_TRUEFALSE && sqlite3ExprTruthValue(p) );
   }
-  // sqlite3AddDefaultValue(pParse,p,yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z,yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z+yypParser.yystack[yypParser.yytos+ 0].minor.yy0.n);
+  sqlite3AddDefaultValue(pParse,p,yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z,yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z[yypParser.yystack[yypParser.yytos+ 0].minor.yy0.n:]);
 }
-//line 3828 "parse.go"
+//line 3856 "parse.go"
         break
       case 38: /* ccons ::= NOT NULL onconf */
-//line 399 "parse.y"
+//line 400 "parse.y"
 {sqlite3AddNotNull(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy394);}
-//line 3833 "parse.go"
+//line 3861 "parse.go"
         break
       case 39: /* ccons ::= PRIMARY KEY sortorder onconf autoinc */
-//line 401 "parse.y"
+//line 402 "parse.y"
 {sqlite3AddPrimaryKey(pParse,nil,yypParser.yystack[yypParser.yytos+ -1].minor.yy394,yypParser.yystack[yypParser.yytos+ 0].minor.yy394,yypParser.yystack[yypParser.yytos+ -2].minor.yy394);}
-//line 3838 "parse.go"
+//line 3866 "parse.go"
         break
       case 40: /* ccons ::= UNIQUE onconf */
-//line 402 "parse.y"
+//line 403 "parse.y"
 {sqlite3CreateIndex(pParse,nil,nil,nil,nil,yypParser.yystack[yypParser.yytos+ 0].minor.yy394,nil,nil,0,0,
                                    SQLITE_IDXTYPE_UNIQUE);}
-//line 3844 "parse.go"
+//line 3872 "parse.go"
         break
       case 41: /* ccons ::= CHECK LP expr RP */
-//line 404 "parse.y"
+//line 405 "parse.y"
 {sqlite3AddCheckConstraint(pParse,yypParser.yystack[yypParser.yytos+ -1].minor.yy634,yypParser.yystack[yypParser.yytos+ -2].minor.yy0.z,yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z);}
-//line 3849 "parse.go"
+//line 3877 "parse.go"
         break
       case 42: /* ccons ::= REFERENCES nm eidlist_opt refargs */
-//line 406 "parse.y"
+//line 407 "parse.y"
 {sqlite3CreateForeignKey(pParse,nil,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0,yypParser.yystack[yypParser.yytos+ -1].minor.yy614,yypParser.yystack[yypParser.yytos+ 0].minor.yy394);}
-//line 3854 "parse.go"
+//line 3882 "parse.go"
         break
       case 43: /* ccons ::= defer_subclause */
-//line 407 "parse.y"
+//line 408 "parse.y"
 {sqlite3DeferForeignKey(pParse,yypParser.yystack[yypParser.yytos+ 0].minor.yy394);}
-//line 3859 "parse.go"
+//line 3887 "parse.go"
         break
       case 44: /* ccons ::= COLLATE ID|STRING */
-//line 408 "parse.y"
+//line 409 "parse.y"
 {sqlite3AddCollateType(pParse, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);}
-//line 3864 "parse.go"
+//line 3892 "parse.go"
         break
-      case 45: /* generated ::= LP expr RP */
+      case 45: /* ccons ::= GENERATED ALWAYS AS generated */
 //line 411 "parse.y"
-{sqlite3AddGenerated(pParse,yypParser.yystack[yypParser.yytos+ -1].minor.yy634,nil);}
-//line 3869 "parse.go"
+{sqlite3CheckVersion(pParse, 3031000, "generated columns", &yypParser.yystack[yypParser.yytos+ -3].minor.yy0, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);}
+//line 3897 "parse.go"
         break
-      case 46: /* generated ::= LP expr RP ID */
+      case 46: /* ccons ::= AS generated */
 //line 412 "parse.y"
-{sqlite3AddGenerated(pParse,yypParser.yystack[yypParser.yytos+ -2].minor.yy634,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0);}
-//line 3874 "parse.go"
+{sqlite3CheckVersion(pParse, 3031000, "generated columns", &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);}
+//line 3902 "parse.go"
         break
-      case 48: /* autoinc ::= AUTOINCR */
-//line 417 "parse.y"
+      case 47: /* generated ::= LP expr RP */
+//line 418 "parse.y"
+{sqlite3AddGenerated(pParse,yypParser.yystack[yypParser.yytos+ -1].minor.yy634,nil); yypParser.yystack[yypParser.yytos+ -2].minor.yy0 = yypParser.yystack[yypParser.yytos+ 0].minor.yy0;}
+//line 3907 "parse.go"
+        break
+      case 48: /* generated ::= LP expr RP ID */
+//line 419 "parse.y"
+{sqlite3AddGenerated(pParse,yypParser.yystack[yypParser.yytos+ -2].minor.yy634,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0); yypParser.yystack[yypParser.yytos+ -3].minor.yy0 = yypParser.yystack[yypParser.yytos+ 0].minor.yy0;}
+//line 3912 "parse.go"
+        break
+      case 50: /* autoinc ::= AUTOINCR */
+//line 424 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = 1;}
-//line 3879 "parse.go"
+//line 3917 "parse.go"
         break
-      case 49: /* refargs ::= */
-//line 425 "parse.y"
+      case 51: /* refargs ::= */
+//line 432 "parse.y"
 { yypParser.yystack[yypParser.yytos+ 1].minor.yy394 = OE_None*0x0101; /* EV: R-19803-45884 */}
-//line 3884 "parse.go"
+//line 3922 "parse.go"
         break
-      case 50: /* refargs ::= refargs refarg */
-//line 426 "parse.y"
-{ /* yypParser.yystack[yypParser.yytos+ -1].minor.yy394 = (yypParser.yystack[yypParser.yytos+ -1].minor.yy394 & ~yypParser.yystack[yypParser.yytos+ 0].minor.yy533.mask) | yypParser.yystack[yypParser.yytos+ 0].minor.yy533.value; */}
-//line 3889 "parse.go"
+      case 52: /* refargs ::= refargs refarg */
+//line 433 "parse.y"
+{ yypParser.yystack[yypParser.yytos+ -1].minor.yy394 = (yypParser.yystack[yypParser.yytos+ -1].minor.yy394 &^ yypParser.yystack[yypParser.yytos+ 0].minor.yy533.mask) | yypParser.yystack[yypParser.yytos+ 0].minor.yy533.value; }
+//line 3927 "parse.go"
         break
-      case 51: /* refarg ::= MATCH nm */
-//line 428 "parse.y"
+      case 53: /* refarg ::= MATCH nm */
+//line 435 "parse.y"
 { yypParser.yystack[yypParser.yytos+ -1].minor.yy533.value = 0;     yypParser.yystack[yypParser.yytos+ -1].minor.yy533.mask = 0x000000; }
-//line 3894 "parse.go"
+//line 3932 "parse.go"
         break
-      case 52: /* refarg ::= ON INSERT refact */
-//line 429 "parse.y"
+      case 54: /* refarg ::= ON INSERT refact */
+//line 436 "parse.y"
 { yypParser.yystack[yypParser.yytos+ -2].minor.yy533.value = 0;     yypParser.yystack[yypParser.yytos+ -2].minor.yy533.mask = 0x000000; }
-//line 3899 "parse.go"
+//line 3937 "parse.go"
         break
-      case 53: /* refarg ::= ON DELETE refact */
-//line 430 "parse.y"
+      case 55: /* refarg ::= ON DELETE refact */
+//line 437 "parse.y"
 { yypParser.yystack[yypParser.yytos+ -2].minor.yy533.value = yypParser.yystack[yypParser.yytos+ 0].minor.yy394;     yypParser.yystack[yypParser.yytos+ -2].minor.yy533.mask = 0x0000ff; }
-//line 3904 "parse.go"
+//line 3942 "parse.go"
         break
-      case 54: /* refarg ::= ON UPDATE refact */
-//line 431 "parse.y"
+      case 56: /* refarg ::= ON UPDATE refact */
+//line 438 "parse.y"
 { yypParser.yystack[yypParser.yytos+ -2].minor.yy533.value = yypParser.yystack[yypParser.yytos+ 0].minor.yy394<<8;  yypParser.yystack[yypParser.yytos+ -2].minor.yy533.mask = 0x00ff00; }
-//line 3909 "parse.go"
+//line 3947 "parse.go"
         break
-      case 55: /* refact ::= SET NULL */
-//line 433 "parse.y"
+      case 57: /* refact ::= SET NULL */
+//line 440 "parse.y"
 { yypParser.yystack[yypParser.yytos+ -1].minor.yy394 = OE_SetNull;  /* EV: R-33326-45252 */}
-//line 3914 "parse.go"
+//line 3952 "parse.go"
         break
-      case 56: /* refact ::= SET DEFAULT */
-//line 434 "parse.y"
+      case 58: /* refact ::= SET DEFAULT */
+//line 441 "parse.y"
 { yypParser.yystack[yypParser.yytos+ -1].minor.yy394 = OE_SetDflt;  /* EV: R-33326-45252 */}
-//line 3919 "parse.go"
+//line 3957 "parse.go"
         break
-      case 57: /* refact ::= CASCADE */
-//line 435 "parse.y"
+      case 59: /* refact ::= CASCADE */
+//line 442 "parse.y"
 { yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = OE_Cascade;  /* EV: R-33326-45252 */}
-//line 3924 "parse.go"
+//line 3962 "parse.go"
         break
-      case 58: /* refact ::= RESTRICT */
-//line 436 "parse.y"
+      case 60: /* refact ::= RESTRICT */
+//line 443 "parse.y"
 { yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = OE_Restrict; /* EV: R-33326-45252 */}
-//line 3929 "parse.go"
+//line 3967 "parse.go"
         break
-      case 59: /* refact ::= NO ACTION */
-//line 437 "parse.y"
+      case 61: /* refact ::= NO ACTION */
+//line 444 "parse.y"
 { yypParser.yystack[yypParser.yytos+ -1].minor.yy394 = OE_None;     /* EV: R-33326-45252 */}
-//line 3934 "parse.go"
+//line 3972 "parse.go"
         break
-      case 60: /* defer_subclause ::= NOT DEFERRABLE init_deferred_pred_opt */
-//line 439 "parse.y"
+      case 62: /* defer_subclause ::= NOT DEFERRABLE init_deferred_pred_opt */
+//line 446 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -2].minor.yy394 = 0;}
-//line 3939 "parse.go"
+//line 3977 "parse.go"
         break
-      case 61: /* defer_subclause ::= DEFERRABLE init_deferred_pred_opt */
+      case 63: /* defer_subclause ::= DEFERRABLE init_deferred_pred_opt */
         fallthrough
-      case 76: /* orconf ::= OR resolvetype */ yytestcase(yyruleno==76);
+      case 78: /* orconf ::= OR resolvetype */ yytestcase(yyruleno==78);
         fallthrough
-      case 171: /* insert_cmd ::= INSERT orconf */ yytestcase(yyruleno==171);
-//line 440 "parse.y"
+      case 173: /* insert_cmd ::= INSERT orconf */ yytestcase(yyruleno==173);
+//line 447 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -1].minor.yy394 = yypParser.yystack[yypParser.yytos+ 0].minor.yy394;}
-//line 3948 "parse.go"
+//line 3986 "parse.go"
         break
-      case 63: /* init_deferred_pred_opt ::= INITIALLY DEFERRED */
+      case 65: /* init_deferred_pred_opt ::= INITIALLY DEFERRED */
         fallthrough
-      case 80: /* ifexists ::= IF EXISTS */ yytestcase(yyruleno==80);
+      case 82: /* ifexists ::= IF EXISTS */ yytestcase(yyruleno==82);
         fallthrough
-      case 214: /* between_op ::= NOT BETWEEN */ yytestcase(yyruleno==214);
+      case 218: /* between_op ::= NOT BETWEEN */ yytestcase(yyruleno==218);
         fallthrough
-      case 217: /* in_op ::= NOT IN */ yytestcase(yyruleno==217);
+      case 221: /* in_op ::= NOT IN */ yytestcase(yyruleno==221);
         fallthrough
-      case 243: /* collate ::= COLLATE ID|STRING */ yytestcase(yyruleno==243);
-//line 443 "parse.y"
+      case 246: /* collate ::= COLLATE ID|STRING */ yytestcase(yyruleno==246);
+//line 450 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -1].minor.yy394 = 1;}
-//line 3961 "parse.go"
+//line 3999 "parse.go"
         break
-      case 64: /* init_deferred_pred_opt ::= INITIALLY IMMEDIATE */
-//line 444 "parse.y"
+      case 66: /* init_deferred_pred_opt ::= INITIALLY IMMEDIATE */
+//line 451 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -1].minor.yy394 = 0;}
-//line 3966 "parse.go"
+//line 4004 "parse.go"
         break
-      case 65: /* conslist_opt ::= */
+      case 67: /* conslist_opt ::= */
         fallthrough
-      case 104: /* as ::= */ yytestcase(yyruleno==104);
-//line 446 "parse.y"
-{yypParser.yystack[yypParser.yytos+ 1].minor.yy0.n = 0; yypParser.yystack[yypParser.yytos+ 1].minor.yy0.z = 0;}
-//line 3973 "parse.go"
+      case 106: /* as ::= */ yytestcase(yyruleno==106);
+//line 453 "parse.y"
+{yypParser.yystack[yypParser.yytos+ 1].minor.yy0.n = 0; yypParser.yystack[yypParser.yytos+ 1].minor.yy0.z = nil;}
+//line 4011 "parse.go"
         break
-      case 66: /* tconscomma ::= COMMA */
-//line 450 "parse.y"
+      case 68: /* tconscomma ::= COMMA */
+//line 457 "parse.y"
 {pParse.constraintName.n = 0;}
-//line 3978 "parse.go"
+//line 4016 "parse.go"
         break
-      case 68: /* tcons ::= PRIMARY KEY LP sortlist autoinc RP onconf */
-//line 454 "parse.y"
+      case 70: /* tcons ::= PRIMARY KEY LP sortlist autoinc RP onconf */
+//line 461 "parse.y"
 {sqlite3AddPrimaryKey(pParse,yypParser.yystack[yypParser.yytos+ -3].minor.yy614,yypParser.yystack[yypParser.yytos+ 0].minor.yy394,yypParser.yystack[yypParser.yytos+ -2].minor.yy394,0);}
-//line 3983 "parse.go"
+//line 4021 "parse.go"
         break
-      case 69: /* tcons ::= UNIQUE LP sortlist RP onconf */
-//line 456 "parse.y"
-{sqlite3CreateIndex(pParse,nil,nil,nil,nil,yypParser.yystack[yypParser.yytos+ -2].minor.yy614,yypParser.yystack[yypParser.yytos+ 0].minor.yy394,nil,0,0,
+      case 71: /* tcons ::= UNIQUE LP sortlist RP onconf */
+//line 463 "parse.y"
+{sqlite3CreateIndex(pParse,nil,nil,nil,yypParser.yystack[yypParser.yytos+ -2].minor.yy614,yypParser.yystack[yypParser.yytos+ 0].minor.yy394,nil,nil,0,0,
                                        SQLITE_IDXTYPE_UNIQUE);}
-//line 3989 "parse.go"
+//line 4027 "parse.go"
         break
-      case 70: /* tcons ::= CHECK LP expr RP onconf */
-//line 459 "parse.y"
+      case 72: /* tcons ::= CHECK LP expr RP onconf */
+//line 466 "parse.y"
 {sqlite3AddCheckConstraint(pParse,yypParser.yystack[yypParser.yytos+ -2].minor.yy634,yypParser.yystack[yypParser.yytos+ -3].minor.yy0.z,yypParser.yystack[yypParser.yytos+ -1].minor.yy0.z);}
-//line 3994 "parse.go"
+//line 4032 "parse.go"
         break
-      case 71: /* tcons ::= FOREIGN KEY LP eidlist RP REFERENCES nm eidlist_opt refargs defer_subclause_opt */
-//line 461 "parse.y"
+      case 73: /* tcons ::= FOREIGN KEY LP eidlist RP REFERENCES nm eidlist_opt refargs defer_subclause_opt */
+//line 468 "parse.y"
 {
     sqlite3CreateForeignKey(pParse, yypParser.yystack[yypParser.yytos+ -6].minor.yy614, &yypParser.yystack[yypParser.yytos+ -3].minor.yy0, yypParser.yystack[yypParser.yytos+ -2].minor.yy614, yypParser.yystack[yypParser.yytos+ -1].minor.yy394);
     sqlite3DeferForeignKey(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy394);
 }
-//line 4002 "parse.go"
+//line 4040 "parse.go"
         break
-      case 73: /* onconf ::= */
+      case 75: /* onconf ::= */
         fallthrough
-      case 75: /* orconf ::= */ yytestcase(yyruleno==75);
-//line 475 "parse.y"
+      case 77: /* orconf ::= */ yytestcase(yyruleno==77);
+//line 482 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 1].minor.yy394 = OE_Default;}
-//line 4009 "parse.go"
+//line 4047 "parse.go"
         break
-      case 74: /* onconf ::= ON CONFLICT resolvetype */
-//line 476 "parse.y"
+      case 76: /* onconf ::= ON CONFLICT resolvetype */
+//line 483 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -2].minor.yy394 = yypParser.yystack[yypParser.yytos+ 0].minor.yy394;}
-//line 4014 "parse.go"
+//line 4052 "parse.go"
         break
-      case 77: /* resolvetype ::= IGNORE */
-//line 480 "parse.y"
+      case 79: /* resolvetype ::= IGNORE */
+//line 487 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = OE_Ignore;}
-//line 4019 "parse.go"
+//line 4057 "parse.go"
         break
-      case 78: /* resolvetype ::= REPLACE */
+      case 80: /* resolvetype ::= REPLACE */
         fallthrough
-      case 172: /* insert_cmd ::= REPLACE */ yytestcase(yyruleno==172);
-//line 481 "parse.y"
+      case 174: /* insert_cmd ::= REPLACE */ yytestcase(yyruleno==174);
+//line 488 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = OE_Replace;}
-//line 4026 "parse.go"
+//line 4064 "parse.go"
         break
-      case 79: /* cmd ::= DROP TABLE ifexists fullname */
-//line 485 "parse.y"
+      case 81: /* cmd ::= DROP TABLE ifexists fullname */
+//line 492 "parse.y"
 {
   sqlite3DropTable(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy157, 0, yypParser.yystack[yypParser.yytos+ -1].minor.yy394);
 }
-//line 4033 "parse.go"
+//line 4071 "parse.go"
         break
-      case 82: /* cmd ::= createkw temp VIEW ifnotexists nm dbnm eidlist_opt AS select */
-//line 496 "parse.y"
+      case 84: /* cmd ::= createkw temp VIEW ifnotexists nm dbnm eidlist_opt AS select */
+//line 503 "parse.y"
 {
   sqlite3CreateView(pParse, &yypParser.yystack[yypParser.yytos+ -8].minor.yy0, &yypParser.yystack[yypParser.yytos+ -4].minor.yy0, &yypParser.yystack[yypParser.yytos+ -3].minor.yy0, yypParser.yystack[yypParser.yytos+ -2].minor.yy614, yypParser.yystack[yypParser.yytos+ 0].minor.yy361, yypParser.yystack[yypParser.yytos+ -7].minor.yy394, yypParser.yystack[yypParser.yytos+ -5].minor.yy394);
 }
-//line 4040 "parse.go"
+//line 4078 "parse.go"
         break
-      case 83: /* cmd ::= DROP VIEW ifexists fullname */
-//line 499 "parse.y"
+      case 85: /* cmd ::= DROP VIEW ifexists fullname */
+//line 506 "parse.y"
 {
   sqlite3DropTable(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy157, 1, yypParser.yystack[yypParser.yytos+ -1].minor.yy394);
 }
-//line 4047 "parse.go"
+//line 4085 "parse.go"
         break
-      case 84: /* cmd ::= select */
-//line 506 "parse.y"
+      case 86: /* cmd ::= select */
+//line 513 "parse.y"
 {
   dest := SelectDest{eDest: SRT_Output};
   sqlite3Select(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy361, &dest);
   sqlite3SelectDelete(pParse.db, yypParser.yystack[yypParser.yytos+ 0].minor.yy361);
 }
-//line 4056 "parse.go"
+//line 4094 "parse.go"
         break
-      case 85: /* select ::= WITH wqlist selectnowith */
-//line 568 "parse.y"
+      case 87: /* select ::= WITH wqlist selectnowith */
+//line 576 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -2].minor.yy361 = attachWithToSelect(pParse,yypParser.yystack[yypParser.yytos+ 0].minor.yy361,yypParser.yystack[yypParser.yytos+ -1].minor.yy357);}
-//line 4061 "parse.go"
+//line 4099 "parse.go"
         break
-      case 86: /* select ::= WITH RECURSIVE wqlist selectnowith */
-//line 570 "parse.y"
+      case 88: /* select ::= WITH RECURSIVE wqlist selectnowith */
+//line 578 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -3].minor.yy361 = attachWithToSelect(pParse,yypParser.yystack[yypParser.yytos+ 0].minor.yy361,yypParser.yystack[yypParser.yytos+ -1].minor.yy357);}
-//line 4066 "parse.go"
+//line 4104 "parse.go"
         break
-      case 87: /* select ::= selectnowith */
-//line 572 "parse.y"
+      case 89: /* select ::= selectnowith */
+//line 580 "parse.y"
 {
   p := yypParser.yystack[yypParser.yytos+ 0].minor.yy361;
   if( p != nil){
@@ -4040,10 +4111,10 @@ func (yypParser *yyParser) yy_reduce(
   }
   yypParser.yystack[yypParser.yytos+ 0].minor.yy361 = p; /*A-overwrites-X*/
 }
-//line 4077 "parse.go"
+//line 4115 "parse.go"
         break
-      case 88: /* selectnowith ::= selectnowith multiselect_op oneselect */
-//line 582 "parse.y"
+      case 90: /* selectnowith ::= selectnowith multiselect_op oneselect */
+//line 590 "parse.y"
 {
   pRhs := yypParser.yystack[yypParser.yytos+ 0].minor.yy361;
   pLhs := yypParser.yystack[yypParser.yytos+ -2].minor.yy361;
@@ -4059,9 +4130,9 @@ func (yypParser *yyParser) yy_reduce(
     pRhs.op = uint8(yypParser.yystack[yypParser.yytos+ -1].minor.yy394);
     pRhs.pPrior = pLhs;
     if( ALWAYS(pLhs != nil) ) {
-      // pLhs.selFlags &= ~SF_MultiValue;
+      pLhs.selFlags &^= SF_MultiValue;
     }
-    // pRhs.selFlags &= ~SF_MultiValue;
+    pRhs.selFlags &^= SF_MultiValue;
     if( yypParser.yystack[yypParser.yytos+ -1].minor.yy394!=TK_ALL ) {
       pParse.hasCompound = 1;
     }
@@ -4070,53 +4141,54 @@ func (yypParser *yyParser) yy_reduce(
   }
   yypParser.yystack[yypParser.yytos+ -2].minor.yy361 = pRhs;
 }
-//line 4107 "parse.go"
+//line 4145 "parse.go"
         break
-      case 89: /* multiselect_op ::= UNION */
+      case 91: /* multiselect_op ::= UNION */
         fallthrough
-      case 91: /* multiselect_op ::= EXCEPT|INTERSECT */ yytestcase(yyruleno==91);
-//line 609 "parse.y"
-{yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = yypParser.yystack[yypParser.yytos+ 0].major; /*A-overwrites-OP*/}
-//line 4114 "parse.go"
+      case 93: /* multiselect_op ::= EXCEPT|INTERSECT */ yytestcase(yyruleno==93);
+//line 617 "parse.y"
+{yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = int(yypParser.yystack[yypParser.yytos+ 0].major); /*A-overwrites-OP*/}
+//line 4152 "parse.go"
         break
-      case 90: /* multiselect_op ::= UNION ALL */
-//line 610 "parse.y"
+      case 92: /* multiselect_op ::= UNION ALL */
+//line 618 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -1].minor.yy394 = TK_ALL;}
-//line 4119 "parse.go"
+//line 4157 "parse.go"
         break
-      case 92: /* oneselect ::= SELECT distinct selcollist from where_opt groupby_opt having_opt orderby_opt limit_opt */
-//line 616 "parse.y"
+      case 94: /* oneselect ::= SELECT distinct selcollist from where_opt groupby_opt having_opt orderby_opt limit_opt */
+//line 624 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -8].minor.yy361 = sqlite3SelectNew(pParse,yypParser.yystack[yypParser.yytos+ -6].minor.yy614,yypParser.yystack[yypParser.yytos+ -5].minor.yy157,yypParser.yystack[yypParser.yytos+ -4].minor.yy634,yypParser.yystack[yypParser.yytos+ -3].minor.yy614,yypParser.yystack[yypParser.yytos+ -2].minor.yy634,yypParser.yystack[yypParser.yytos+ -1].minor.yy614,yypParser.yystack[yypParser.yytos+ -7].minor.yy394,yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
+  yypParser.yystack[yypParser.yytos+ -8].minor.yy361 = sqlite3SelectNew(pParse,yypParser.yystack[yypParser.yytos+ -6].minor.yy614,yypParser.yystack[yypParser.yytos+ -5].minor.yy157,yypParser.yystack[yypParser.yytos+ -4].minor.yy634,yypParser.yystack[yypParser.yytos+ -3].minor.yy614,yypParser.yystack[yypParser.yytos+ -2].minor.yy634,yypParser.yystack[yypParser.yytos+ -1].minor.yy614,uint32(yypParser.yystack[yypParser.yytos+ -7].minor.yy394),yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
 }
-//line 4126 "parse.go"
+//line 4164 "parse.go"
         break
-      case 93: /* oneselect ::= SELECT distinct selcollist from where_opt groupby_opt having_opt window_clause orderby_opt limit_opt */
-//line 622 "parse.y"
+      case 95: /* oneselect ::= SELECT distinct selcollist from where_opt groupby_opt having_opt window_clause orderby_opt limit_opt */
+//line 630 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -9].minor.yy361 = sqlite3SelectNew(pParse,yypParser.yystack[yypParser.yytos+ -7].minor.yy614,yypParser.yystack[yypParser.yytos+ -6].minor.yy157,yypParser.yystack[yypParser.yytos+ -5].minor.yy634,yypParser.yystack[yypParser.yytos+ -4].minor.yy614,yypParser.yystack[yypParser.yytos+ -3].minor.yy634,yypParser.yystack[yypParser.yytos+ -1].minor.yy614,yypParser.yystack[yypParser.yytos+ -8].minor.yy394,yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
+  yypParser.yystack[yypParser.yytos+ -9].minor.yy361 = sqlite3SelectNew(pParse,yypParser.yystack[yypParser.yytos+ -7].minor.yy614,yypParser.yystack[yypParser.yytos+ -6].minor.yy157,yypParser.yystack[yypParser.yytos+ -5].minor.yy634,yypParser.yystack[yypParser.yytos+ -4].minor.yy614,yypParser.yystack[yypParser.yytos+ -3].minor.yy634,yypParser.yystack[yypParser.yytos+ -1].minor.yy614,uint32(yypParser.yystack[yypParser.yytos+ -8].minor.yy394),yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
   if( yypParser.yystack[yypParser.yytos+ -9].minor.yy361 != nil){
     yypParser.yystack[yypParser.yytos+ -9].minor.yy361.pWinDefn = yypParser.yystack[yypParser.yytos+ -2].minor.yy179;
   }else{
     sqlite3WindowListDelete(pParse.db, yypParser.yystack[yypParser.yytos+ -2].minor.yy179);
   }
 }
-//line 4138 "parse.go"
+//line 4176 "parse.go"
         break
-      case 94: /* values ::= VALUES LP nexprlist RP */
-//line 637 "parse.y"
+      case 96: /* values ::= VALUES LP nexprlist RP */
+//line 645 "parse.y"
 {
   yypParser.yystack[yypParser.yytos+ -3].minor.yy361 = sqlite3SelectNew(pParse,yypParser.yystack[yypParser.yytos+ -1].minor.yy614,nil,nil,nil,nil,nil,SF_Values,nil);
 }
-//line 4145 "parse.go"
+//line 4183 "parse.go"
         break
-      case 95: /* values ::= values COMMA LP nexprlist RP */
-//line 640 "parse.y"
+      case 97: /* values ::= values COMMA LP nexprlist RP */
+//line 648 "parse.y"
 {
-  pRight, pLeft := yypParser.yystack[yypParser.yytos+ -4].minor.yy361;
+  var pRight *Select;
+  pLeft := yypParser.yystack[yypParser.yytos+ -4].minor.yy361;
   pRight = sqlite3SelectNew(pParse,yypParser.yystack[yypParser.yytos+ -1].minor.yy614,nil,nil,nil,nil,nil,SF_Values|SF_MultiValue,nil);
   if( ALWAYS(pLeft != nil) ) {
-    // pLeft.selFlags &= ~SF_MultiValue;
+    pLeft.selFlags &^= SF_MultiValue;
   } 
   if( pRight != nil){
     pRight.op = TK_ALL;
@@ -4126,35 +4198,35 @@ func (yypParser *yyParser) yy_reduce(
     yypParser.yystack[yypParser.yytos+ -4].minor.yy361 = pLeft;
   }
 }
-//line 4163 "parse.go"
+//line 4202 "parse.go"
         break
-      case 96: /* distinct ::= DISTINCT */
-//line 659 "parse.y"
+      case 98: /* distinct ::= DISTINCT */
+//line 668 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = SF_Distinct;}
-//line 4168 "parse.go"
+//line 4207 "parse.go"
         break
-      case 97: /* distinct ::= ALL */
-//line 660 "parse.y"
+      case 99: /* distinct ::= ALL */
+//line 669 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = SF_All;}
-//line 4173 "parse.go"
+//line 4212 "parse.go"
         break
-      case 99: /* sclp ::= */
+      case 101: /* sclp ::= */
         fallthrough
-      case 132: /* orderby_opt ::= */ yytestcase(yyruleno==132);
+      case 134: /* orderby_opt ::= */ yytestcase(yyruleno==134);
         fallthrough
-      case 142: /* groupby_opt ::= */ yytestcase(yyruleno==142);
+      case 144: /* groupby_opt ::= */ yytestcase(yyruleno==144);
         fallthrough
-      case 230: /* exprlist ::= */ yytestcase(yyruleno==230);
+      case 233: /* exprlist ::= */ yytestcase(yyruleno==233);
         fallthrough
-      case 233: /* paren_exprlist ::= */ yytestcase(yyruleno==233);
+      case 236: /* paren_exprlist ::= */ yytestcase(yyruleno==236);
         fallthrough
-      case 238: /* eidlist_opt ::= */ yytestcase(yyruleno==238);
-//line 673 "parse.y"
-{yypParser.yystack[yypParser.yytos+ 1].minor.yy614 = 0;}
-//line 4188 "parse.go"
+      case 241: /* eidlist_opt ::= */ yytestcase(yyruleno==241);
+//line 682 "parse.y"
+{yypParser.yystack[yypParser.yytos+ 1].minor.yy614 = nil;}
+//line 4227 "parse.go"
         break
-      case 100: /* selcollist ::= sclp scanpt expr scanpt as */
-//line 674 "parse.y"
+      case 102: /* selcollist ::= sclp scanpt expr scanpt as */
+//line 683 "parse.y"
 {
    yypParser.yystack[yypParser.yytos+ -4].minor.yy614 = sqlite3ExprListAppend(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy614, yypParser.yystack[yypParser.yytos+ -2].minor.yy634);
    if( yypParser.yystack[yypParser.yytos+ 0].minor.yy0.n>0 ) {
@@ -4162,513 +4234,553 @@ func (yypParser *yyParser) yy_reduce(
    }
    sqlite3ExprListSetSpan(pParse,yypParser.yystack[yypParser.yytos+ -4].minor.yy614,yypParser.yystack[yypParser.yytos+ -3].minor.yy79,yypParser.yystack[yypParser.yytos+ -1].minor.yy79);
 }
-//line 4199 "parse.go"
+//line 4238 "parse.go"
         break
-      case 101: /* selcollist ::= sclp scanpt STAR */
-//line 681 "parse.y"
+      case 103: /* selcollist ::= sclp scanpt STAR */
+//line 690 "parse.y"
 {
-  Expr *p = sqlite3Expr(pParse.db, TK_ASTERISK, 0);
+  p := sqlite3Expr(pParse.db, TK_ASTERISK, nil);
   yypParser.yystack[yypParser.yytos+ -2].minor.yy614 = sqlite3ExprListAppend(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy614, p);
 }
-//line 4207 "parse.go"
+//line 4246 "parse.go"
         break
-      case 102: /* selcollist ::= sclp scanpt nm DOT STAR */
-//line 685 "parse.y"
+      case 104: /* selcollist ::= sclp scanpt nm DOT STAR */
+//line 694 "parse.y"
 {
-  pRight := sqlite3PExpr(pParse, TK_ASTERISK, 0, 0);
+  pRight := sqlite3PExpr(pParse, TK_ASTERISK, nil, nil);
   pLeft := tokenExpr(pParse, TK_ID, yypParser.yystack[yypParser.yytos+ -2].minor.yy0);
   pDot := sqlite3PExpr(pParse, TK_DOT, pLeft, pRight);
   yypParser.yystack[yypParser.yytos+ -4].minor.yy614 = sqlite3ExprListAppend(pParse,yypParser.yystack[yypParser.yytos+ -4].minor.yy614, pDot);
 }
-//line 4217 "parse.go"
+//line 4256 "parse.go"
         break
-      case 103: /* as ::= AS nm */
+      case 105: /* as ::= AS nm */
         fallthrough
-      case 115: /* dbnm ::= DOT nm */ yytestcase(yyruleno==115);
+      case 117: /* dbnm ::= DOT nm */ yytestcase(yyruleno==117);
         fallthrough
-      case 254: /* plus_num ::= PLUS INTEGER|FLOAT */ yytestcase(yyruleno==254);
+      case 257: /* plus_num ::= PLUS INTEGER|FLOAT */ yytestcase(yyruleno==257);
         fallthrough
-      case 255: /* minus_num ::= MINUS INTEGER|FLOAT */ yytestcase(yyruleno==255);
-//line 696 "parse.y"
+      case 258: /* minus_num ::= MINUS INTEGER|FLOAT */ yytestcase(yyruleno==258);
+//line 705 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -1].minor.yy0 = yypParser.yystack[yypParser.yytos+ 0].minor.yy0;}
-//line 4228 "parse.go"
+//line 4267 "parse.go"
         break
-      case 105: /* from ::= */
+      case 107: /* from ::= */
         fallthrough
-      case 108: /* stl_prefix ::= */ yytestcase(yyruleno==108);
-//line 710 "parse.y"
-{yypParser.yystack[yypParser.yytos+ 1].minor.yy157 = 0;}
-//line 4235 "parse.go"
+      case 110: /* stl_prefix ::= */ yytestcase(yyruleno==110);
+//line 719 "parse.y"
+{yypParser.yystack[yypParser.yytos+ 1].minor.yy157 = nil;}
+//line 4274 "parse.go"
         break
-      case 106: /* from ::= FROM seltablist */
-//line 711 "parse.y"
+      case 108: /* from ::= FROM seltablist */
+//line 720 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -1].minor.yy157 = yypParser.yystack[yypParser.yytos+ 0].minor.yy157;
-  sqlite3SrcListShiftJoinType(pParse,yypParser.yystack[yypParser.yytos+ -1].minor.yy157);
+  yylhsminor.yy157 = yypParser.yystack[yypParser.yytos+ 0].minor.yy157;
+  if( yylhsminor.yy157!=nil ){ yylhsminor.yy157.sFrom = yypParser.yystack[yypParser.yytos+ -1].minor.yy0; }
+  sqlite3SrcListShiftJoinType(pParse,yylhsminor.yy157);
 }
-//line 4243 "parse.go"
+//line 4283 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -1].minor.yy157 = yylhsminor.yy157;
         break
-      case 107: /* stl_prefix ::= seltablist joinop */
-//line 719 "parse.y"
+      case 109: /* stl_prefix ::= seltablist joinop */
+//line 729 "parse.y"
 {
-   if( ALWAYS(yypParser.yystack[yypParser.yytos+ -1].minor.yy157 && yypParser.yystack[yypParser.yytos+ -1].minor.yy157.nSrc>0) ) {
+   if( ALWAYS(yypParser.yystack[yypParser.yytos+ -1].minor.yy157!=nil && yypParser.yystack[yypParser.yytos+ -1].minor.yy157.nSrc>0) ) {
      yypParser.yystack[yypParser.yytos+ -1].minor.yy157.a[yypParser.yystack[yypParser.yytos+ -1].minor.yy157.nSrc-1].fg.jointype = uint8(yypParser.yystack[yypParser.yytos+ 0].minor.yy394);
    }
 }
-//line 4252 "parse.go"
+//line 4293 "parse.go"
         break
-      case 109: /* seltablist ::= stl_prefix nm dbnm as on_using */
-//line 725 "parse.y"
+      case 111: /* seltablist ::= stl_prefix nm dbnm as on_using */
+//line 735 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -4].minor.yy157 = sqlite3SrcListAppendFromTerm(pParse,yypParser.yystack[yypParser.yytos+ -4].minor.yy157,&yypParser.yystack[yypParser.yytos+ -3].minor.yy0,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,0,&yypParser.yystack[yypParser.yytos+ 0].minor.yy561);
+  yypParser.yystack[yypParser.yytos+ -4].minor.yy157 = sqlite3SrcListAppendFromTerm(pParse,yypParser.yystack[yypParser.yytos+ -4].minor.yy157,&yypParser.yystack[yypParser.yytos+ -3].minor.yy0,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,nil,&yypParser.yystack[yypParser.yytos+ 0].minor.yy561);
 }
-//line 4259 "parse.go"
+//line 4300 "parse.go"
         break
-      case 110: /* seltablist ::= stl_prefix nm dbnm as indexed_by on_using */
-//line 728 "parse.y"
+      case 112: /* seltablist ::= stl_prefix nm dbnm as indexed_by on_using */
+//line 738 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -5].minor.yy157 = sqlite3SrcListAppendFromTerm(pParse,yypParser.yystack[yypParser.yytos+ -5].minor.yy157,&yypParser.yystack[yypParser.yytos+ -4].minor.yy0,&yypParser.yystack[yypParser.yytos+ -3].minor.yy0,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0,0,&yypParser.yystack[yypParser.yytos+ 0].minor.yy561);
+  yypParser.yystack[yypParser.yytos+ -5].minor.yy157 = sqlite3SrcListAppendFromTerm(pParse,yypParser.yystack[yypParser.yytos+ -5].minor.yy157,&yypParser.yystack[yypParser.yytos+ -4].minor.yy0,&yypParser.yystack[yypParser.yytos+ -3].minor.yy0,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0,nil,&yypParser.yystack[yypParser.yytos+ 0].minor.yy561);
   sqlite3SrcListIndexedBy(pParse, yypParser.yystack[yypParser.yytos+ -5].minor.yy157, &yypParser.yystack[yypParser.yytos+ -1].minor.yy0);
 }
-//line 4267 "parse.go"
+//line 4308 "parse.go"
         break
-      case 111: /* seltablist ::= stl_prefix nm dbnm LP exprlist RP as on_using */
-//line 732 "parse.y"
+      case 113: /* seltablist ::= stl_prefix nm dbnm LP exprlist RP as on_using */
+//line 742 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -7].minor.yy157 = sqlite3SrcListAppendFromTerm(pParse,yypParser.yystack[yypParser.yytos+ -7].minor.yy157,&yypParser.yystack[yypParser.yytos+ -6].minor.yy0,&yypParser.yystack[yypParser.yytos+ -5].minor.yy0,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,0,&yypParser.yystack[yypParser.yytos+ 0].minor.yy561);
+  yypParser.yystack[yypParser.yytos+ -7].minor.yy157 = sqlite3SrcListAppendFromTerm(pParse,yypParser.yystack[yypParser.yytos+ -7].minor.yy157,&yypParser.yystack[yypParser.yytos+ -6].minor.yy0,&yypParser.yystack[yypParser.yytos+ -5].minor.yy0,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,nil,&yypParser.yystack[yypParser.yytos+ 0].minor.yy561);
   sqlite3SrcListFuncArgs(pParse, yypParser.yystack[yypParser.yytos+ -7].minor.yy157, yypParser.yystack[yypParser.yytos+ -3].minor.yy614);
 }
-//line 4275 "parse.go"
+//line 4316 "parse.go"
         break
-      case 112: /* seltablist ::= stl_prefix LP select RP as on_using */
-//line 737 "parse.y"
+      case 114: /* seltablist ::= stl_prefix LP select RP as on_using */
+//line 747 "parse.y"
 {
-    yypParser.yystack[yypParser.yytos+ -5].minor.yy157 = sqlite3SrcListAppendFromTerm(pParse,yypParser.yystack[yypParser.yytos+ -5].minor.yy157,0,0,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,yypParser.yystack[yypParser.yytos+ -3].minor.yy361,&yypParser.yystack[yypParser.yytos+ 0].minor.yy561);
+    yypParser.yystack[yypParser.yytos+ -5].minor.yy157 = sqlite3SrcListAppendFromTerm(pParse,yypParser.yystack[yypParser.yytos+ -5].minor.yy157,nil,nil,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,yypParser.yystack[yypParser.yytos+ -3].minor.yy361,&yypParser.yystack[yypParser.yytos+ 0].minor.yy561);
   }
-//line 4282 "parse.go"
+//line 4323 "parse.go"
         break
-      case 113: /* seltablist ::= stl_prefix LP seltablist RP as on_using */
-//line 740 "parse.y"
+      case 115: /* seltablist ::= stl_prefix LP seltablist RP as on_using */
+//line 750 "parse.y"
 {
-    if( yypParser.yystack[yypParser.yytos+ -5].minor.yy157==0 && yypParser.yystack[yypParser.yytos+ -1].minor.yy0.n==0 && yypParser.yystack[yypParser.yytos+ 0].minor.yy561.pOn==0 && yypParser.yystack[yypParser.yytos+ 0].minor.yy561.pUsing==0 ){
+    if( yypParser.yystack[yypParser.yytos+ -5].minor.yy157==nil && yypParser.yystack[yypParser.yytos+ -1].minor.yy0.n==0 && yypParser.yystack[yypParser.yytos+ 0].minor.yy561.pOn==nil && yypParser.yystack[yypParser.yytos+ 0].minor.yy561.pUsing==nil ){
       yypParser.yystack[yypParser.yytos+ -5].minor.yy157 = yypParser.yystack[yypParser.yytos+ -3].minor.yy157;
     }else if( yypParser.yystack[yypParser.yytos+ -3].minor.yy157.nSrc==1 ){
-      yypParser.yystack[yypParser.yytos+ -5].minor.yy157 = sqlite3SrcListAppendFromTerm(pParse,yypParser.yystack[yypParser.yytos+ -5].minor.yy157,0,0,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,0,&yypParser.yystack[yypParser.yytos+ 0].minor.yy561);
-      if( yypParser.yystack[yypParser.yytos+ -5].minor.yy157 ){
+      yypParser.yystack[yypParser.yytos+ -5].minor.yy157 = sqlite3SrcListAppendFromTerm(pParse,yypParser.yystack[yypParser.yytos+ -5].minor.yy157,nil,nil,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,nil,&yypParser.yystack[yypParser.yytos+ 0].minor.yy561);
+      if( yypParser.yystack[yypParser.yytos+ -5].minor.yy157!=nil ){
         pNew := &yypParser.yystack[yypParser.yytos+ -5].minor.yy157.a[yypParser.yystack[yypParser.yytos+ -5].minor.yy157.nSrc-1];
-        pOld := yypParser.yystack[yypParser.yytos+ -3].minor.yy157.a;
+        pOld := &yypParser.yystack[yypParser.yytos+ -3].minor.yy157.a[0];
         pNew.zName = pOld.zName;
         pNew.zDatabase = pOld.zDatabase;
         pNew.pSelect = pOld.pSelect;
-        if( pNew.pSelect && (pNew.pSelect.selFlags & SF_NestedFrom)!=0 ){
-          pNew.fg.isNestedFrom = 1;
-        }
-        if( pOld.fg.isTabFunc ){
+        if( pOld.u1.pFuncArg!=nil ){
           pNew.u1.pFuncArg = pOld.u1.pFuncArg;
-          pOld.u1.pFuncArg = 0;
-          pOld.fg.isTabFunc = 0;
-          pNew.fg.isTabFunc = 1;
+          pOld.u1.pFuncArg = nil;
         }
-        pOld.zName = 0;
-        pOld.zDatabase = 0;
-        pOld.pSelect = 0;
+        pOld.zName = nil;
+        pOld.zDatabase = nil;
+        pOld.pSelect = nil;
       }
       sqlite3SrcListDelete(pParse.db, yypParser.yystack[yypParser.yytos+ -3].minor.yy157);
     }else{
       var pSubquery *Select;
       sqlite3SrcListShiftJoinType(pParse,yypParser.yystack[yypParser.yytos+ -3].minor.yy157);
-      pSubquery = sqlite3SelectNew(pParse,0,yypParser.yystack[yypParser.yytos+ -3].minor.yy157,0,0,0,0,SF_NestedFrom,0);
-      yypParser.yystack[yypParser.yytos+ -5].minor.yy157 = sqlite3SrcListAppendFromTerm(pParse,yypParser.yystack[yypParser.yytos+ -5].minor.yy157,0,0,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,pSubquery,&yypParser.yystack[yypParser.yytos+ 0].minor.yy561);
+      pSubquery = sqlite3SelectNew(pParse,nil,yypParser.yystack[yypParser.yytos+ -3].minor.yy157,nil,nil,nil,nil,SF_NestedFrom,nil);
+      yypParser.yystack[yypParser.yytos+ -5].minor.yy157 = sqlite3SrcListAppendFromTerm(pParse,yypParser.yystack[yypParser.yytos+ -5].minor.yy157,nil,nil,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,pSubquery,&yypParser.yystack[yypParser.yytos+ 0].minor.yy561);
     }
   }
-//line 4318 "parse.go"
+//line 4354 "parse.go"
         break
-      case 114: /* dbnm ::= */
+      case 116: /* dbnm ::= */
         fallthrough
-      case 129: /* indexed_opt ::= */ yytestcase(yyruleno==129);
-//line 775 "parse.y"
-{yypParser.yystack[yypParser.yytos+ 1].minor.yy0.z=0; yypParser.yystack[yypParser.yytos+ 1].minor.yy0.n=0;}
-//line 4325 "parse.go"
-        break
-      case 116: /* fullname ::= nm */
+      case 131: /* indexed_opt ::= */ yytestcase(yyruleno==131);
 //line 780 "parse.y"
+{yypParser.yystack[yypParser.yytos+ 1].minor.yy0.z=nil; yypParser.yystack[yypParser.yytos+ 1].minor.yy0.n=0;}
+//line 4361 "parse.go"
+        break
+      case 118: /* fullname ::= nm */
+//line 785 "parse.y"
 {
-  yylhsminor.yy157 = sqlite3SrcListAppend(pParse,0,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0,0);
-  if( IN_RENAME_OBJECT && yylhsminor.yy157 ) {
+  yylhsminor.yy157 = sqlite3SrcListAppend(pParse,nil,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0,nil);
+  if( pParse.eParseMode>=PARSE_MODE_RENAME && yylhsminor.yy157!=nil ) {
     sqlite3RenameTokenMap(pParse, yylhsminor.yy157.a[0].zName, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
   }
 }
-//line 4335 "parse.go"
+//line 4371 "parse.go"
   yypParser.yystack[yypParser.yytos+ 0].minor.yy157 = yylhsminor.yy157;
         break
-      case 117: /* fullname ::= nm DOT nm */
-//line 786 "parse.y"
+      case 119: /* fullname ::= nm DOT nm */
+//line 791 "parse.y"
 {
-  yylhsminor.yy157 = sqlite3SrcListAppend(pParse,0,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
-  if( IN_RENAME_OBJECT && yylhsminor.yy157 ) {
+  yylhsminor.yy157 = sqlite3SrcListAppend(pParse,nil,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
+  if( pParse.eParseMode>=PARSE_MODE_RENAME && yylhsminor.yy157!=nil ) {
     sqlite3RenameTokenMap(pParse, yylhsminor.yy157.a[0].zName, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
   }
 }
-//line 4346 "parse.go"
+//line 4382 "parse.go"
   yypParser.yystack[yypParser.yytos+ -2].minor.yy157 = yylhsminor.yy157;
         break
-      case 118: /* xfullname ::= nm */
-//line 796 "parse.y"
-{yypParser.yystack[yypParser.yytos+ 0].minor.yy157 = sqlite3SrcListAppend(pParse,0,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0,0); /*A-overwrites-X*/}
-//line 4352 "parse.go"
+      case 120: /* xfullname ::= nm */
+//line 801 "parse.y"
+{yypParser.yystack[yypParser.yytos+ 0].minor.yy157 = sqlite3SrcListAppend(pParse,nil,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0,nil); /*A-overwrites-X*/}
+//line 4388 "parse.go"
         break
-      case 119: /* xfullname ::= nm DOT nm */
-//line 798 "parse.y"
-{yypParser.yystack[yypParser.yytos+ -2].minor.yy157 = sqlite3SrcListAppend(pParse,0,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0); /*A-overwrites-X*/}
-//line 4357 "parse.go"
+      case 121: /* xfullname ::= nm DOT nm */
+//line 803 "parse.y"
+{yypParser.yystack[yypParser.yytos+ -2].minor.yy157 = sqlite3SrcListAppend(pParse,nil,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0); /*A-overwrites-X*/}
+//line 4393 "parse.go"
         break
-      case 120: /* xfullname ::= nm DOT nm AS nm */
-//line 799 "parse.y"
+      case 122: /* xfullname ::= nm DOT nm AS nm */
+//line 804 "parse.y"
 {
-   yypParser.yystack[yypParser.yytos+ -4].minor.yy157 = sqlite3SrcListAppend(pParse,0,&yypParser.yystack[yypParser.yytos+ -4].minor.yy0,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0); /*A-overwrites-X*/
-   if( yypParser.yystack[yypParser.yytos+ -4].minor.yy157 ) {
+   yypParser.yystack[yypParser.yytos+ -4].minor.yy157 = sqlite3SrcListAppend(pParse,nil,&yypParser.yystack[yypParser.yytos+ -4].minor.yy0,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0); /*A-overwrites-X*/
+   if( yypParser.yystack[yypParser.yytos+ -4].minor.yy157!=nil ) {
      yypParser.yystack[yypParser.yytos+ -4].minor.yy157.a[0].zAlias = sqlite3NameFromToken(pParse.db, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
    }
 }
-//line 4367 "parse.go"
+//line 4403 "parse.go"
         break
-      case 121: /* xfullname ::= nm AS nm */
-//line 805 "parse.y"
+      case 123: /* xfullname ::= nm AS nm */
+//line 810 "parse.y"
 {  
-   yypParser.yystack[yypParser.yytos+ -2].minor.yy157 = sqlite3SrcListAppend(pParse,0,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0,0); /*A-overwrites-X*/
-   if( yypParser.yystack[yypParser.yytos+ -2].minor.yy157 ) {
+   yypParser.yystack[yypParser.yytos+ -2].minor.yy157 = sqlite3SrcListAppend(pParse,nil,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0,nil); /*A-overwrites-X*/
+   if( yypParser.yystack[yypParser.yytos+ -2].minor.yy157!=nil ) {
      yypParser.yystack[yypParser.yytos+ -2].minor.yy157.a[0].zAlias = sqlite3NameFromToken(pParse.db, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
    }
 }
-//line 4377 "parse.go"
+//line 4413 "parse.go"
         break
-      case 122: /* joinop ::= COMMA|JOIN */
-//line 813 "parse.y"
+      case 124: /* joinop ::= COMMA|JOIN */
+//line 818 "parse.y"
 { yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = JT_INNER; }
-//line 4382 "parse.go"
+//line 4418 "parse.go"
         break
-      case 123: /* joinop ::= JOIN_KW JOIN */
-//line 815 "parse.y"
-{yypParser.yystack[yypParser.yytos+ -1].minor.yy394 = sqlite3JoinType(pParse,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,0,0);  /*X-overwrites-A*/}
-//line 4387 "parse.go"
+      case 125: /* joinop ::= JOIN_KW JOIN */
+//line 820 "parse.y"
+{yypParser.yystack[yypParser.yytos+ -1].minor.yy394 = sqlite3JoinType(pParse,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,nil,nil);  /*X-overwrites-A*/}
+//line 4423 "parse.go"
         break
-      case 124: /* joinop ::= JOIN_KW nm JOIN */
-//line 817 "parse.y"
-{yypParser.yystack[yypParser.yytos+ -2].minor.yy394 = sqlite3JoinType(pParse,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,0); /*X-overwrites-A*/}
-//line 4392 "parse.go"
+      case 126: /* joinop ::= JOIN_KW nm JOIN */
+//line 822 "parse.y"
+{yypParser.yystack[yypParser.yytos+ -2].minor.yy394 = sqlite3JoinType(pParse,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,nil); /*X-overwrites-A*/}
+//line 4428 "parse.go"
         break
-      case 125: /* joinop ::= JOIN_KW nm nm JOIN */
-//line 819 "parse.y"
+      case 127: /* joinop ::= JOIN_KW nm nm JOIN */
+//line 824 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -3].minor.yy394 = sqlite3JoinType(pParse,&yypParser.yystack[yypParser.yytos+ -3].minor.yy0,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0);/*X-overwrites-A*/}
-//line 4397 "parse.go"
+//line 4433 "parse.go"
         break
-      case 126: /* on_using ::= ON expr */
-//line 840 "parse.y"
-{yypParser.yystack[yypParser.yytos+ -1].minor.yy561.pOn = yypParser.yystack[yypParser.yytos+ 0].minor.yy634; yypParser.yystack[yypParser.yytos+ -1].minor.yy561.pUsing = 0;}
-//line 4402 "parse.go"
+      case 128: /* on_using ::= ON expr */
+//line 845 "parse.y"
+{yypParser.yystack[yypParser.yytos+ -1].minor.yy561.pOn = yypParser.yystack[yypParser.yytos+ 0].minor.yy634; yypParser.yystack[yypParser.yytos+ -1].minor.yy561.pUsing = nil;}
+//line 4438 "parse.go"
         break
-      case 127: /* on_using ::= USING LP idlist RP */
-//line 841 "parse.y"
-{yypParser.yystack[yypParser.yytos+ -3].minor.yy561.pOn = 0; yypParser.yystack[yypParser.yytos+ -3].minor.yy561.pUsing = yypParser.yystack[yypParser.yytos+ -1].minor.yy106;}
-//line 4407 "parse.go"
+      case 129: /* on_using ::= USING LP idlist RP */
+//line 846 "parse.y"
+{yypParser.yystack[yypParser.yytos+ -3].minor.yy561.pOn = nil; yypParser.yystack[yypParser.yytos+ -3].minor.yy561.pUsing = yypParser.yystack[yypParser.yytos+ -1].minor.yy106;}
+//line 4443 "parse.go"
         break
-      case 128: /* on_using ::= */
-//line 842 "parse.y"
-{yypParser.yystack[yypParser.yytos+ 1].minor.yy561.pOn = 0; yypParser.yystack[yypParser.yytos+ 1].minor.yy561.pUsing = 0;}
-//line 4412 "parse.go"
+      case 130: /* on_using ::= */
+//line 847 "parse.y"
+{yypParser.yystack[yypParser.yytos+ 1].minor.yy561.pOn = nil; yypParser.yystack[yypParser.yytos+ 1].minor.yy561.pUsing = nil;}
+//line 4448 "parse.go"
         break
-      case 130: /* indexed_by ::= INDEXED BY nm */
-//line 858 "parse.y"
+      case 132: /* indexed_by ::= INDEXED BY nm */
+//line 863 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -2].minor.yy0 = yypParser.yystack[yypParser.yytos+ 0].minor.yy0;}
-//line 4417 "parse.go"
+//line 4453 "parse.go"
         break
-      case 131: /* indexed_by ::= NOT INDEXED */
-//line 859 "parse.y"
-{yypParser.yystack[yypParser.yytos+ -1].minor.yy0.z=0; yypParser.yystack[yypParser.yytos+ -1].minor.yy0.n=1;}
-//line 4422 "parse.go"
+      case 133: /* indexed_by ::= NOT INDEXED */
+//line 864 "parse.y"
+{yypParser.yystack[yypParser.yytos+ -1].minor.yy0.z=nil; yypParser.yystack[yypParser.yytos+ -1].minor.yy0.n=1;}
+//line 4458 "parse.go"
         break
-      case 133: /* orderby_opt ::= ORDER BY sortlist */
+      case 135: /* orderby_opt ::= ORDER BY sortlist */
         fallthrough
-      case 143: /* groupby_opt ::= GROUP BY nexprlist */ yytestcase(yyruleno==143);
-//line 872 "parse.y"
+      case 145: /* groupby_opt ::= GROUP BY nexprlist */ yytestcase(yyruleno==145);
+//line 877 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -2].minor.yy614 = yypParser.yystack[yypParser.yytos+ 0].minor.yy614;}
-//line 4429 "parse.go"
+//line 4465 "parse.go"
         break
-      case 134: /* sortlist ::= sortlist COMMA expr sortorder nulls */
-//line 873 "parse.y"
+      case 136: /* sortlist ::= sortlist COMMA expr sortorder nulls */
+//line 878 "parse.y"
 {
   yypParser.yystack[yypParser.yytos+ -4].minor.yy614 = sqlite3ExprListAppend(pParse,yypParser.yystack[yypParser.yytos+ -4].minor.yy614,yypParser.yystack[yypParser.yytos+ -2].minor.yy634);
   sqlite3ExprListSetSortOrder(yypParser.yystack[yypParser.yytos+ -4].minor.yy614,yypParser.yystack[yypParser.yytos+ -1].minor.yy394,yypParser.yystack[yypParser.yytos+ 0].minor.yy394);
 }
-//line 4437 "parse.go"
+//line 4473 "parse.go"
         break
-      case 135: /* sortlist ::= expr sortorder nulls */
-//line 877 "parse.y"
+      case 137: /* sortlist ::= expr sortorder nulls */
+//line 882 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -2].minor.yy614 = sqlite3ExprListAppend(pParse,0,yypParser.yystack[yypParser.yytos+ -2].minor.yy634); /*A-overwrites-Y*/
+  yypParser.yystack[yypParser.yytos+ -2].minor.yy614 = sqlite3ExprListAppend(pParse,nil,yypParser.yystack[yypParser.yytos+ -2].minor.yy634); /*A-overwrites-Y*/
   sqlite3ExprListSetSortOrder(yypParser.yystack[yypParser.yytos+ -2].minor.yy614,yypParser.yystack[yypParser.yytos+ -1].minor.yy394,yypParser.yystack[yypParser.yytos+ 0].minor.yy394);
 }
-//line 4445 "parse.go"
+//line 4481 "parse.go"
         break
-      case 136: /* sortorder ::= ASC */
-//line 884 "parse.y"
+      case 138: /* sortorder ::= ASC */
+//line 889 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = SQLITE_SO_ASC;}
-//line 4450 "parse.go"
+//line 4486 "parse.go"
         break
-      case 137: /* sortorder ::= DESC */
-//line 885 "parse.y"
+      case 139: /* sortorder ::= DESC */
+//line 890 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = SQLITE_SO_DESC;}
-//line 4455 "parse.go"
+//line 4491 "parse.go"
         break
-      case 138: /* sortorder ::= */
+      case 140: /* sortorder ::= */
         fallthrough
-      case 141: /* nulls ::= */ yytestcase(yyruleno==141);
-//line 886 "parse.y"
+      case 143: /* nulls ::= */ yytestcase(yyruleno==143);
+//line 891 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 1].minor.yy394 = SQLITE_SO_UNDEFINED;}
-//line 4462 "parse.go"
+//line 4498 "parse.go"
         break
-      case 139: /* nulls ::= NULLS FIRST */
-//line 889 "parse.y"
-{yypParser.yystack[yypParser.yytos+ -1].minor.yy394 = SQLITE_SO_ASC;}
-//line 4467 "parse.go"
+      case 141: /* nulls ::= NULLS FIRST */
+//line 894 "parse.y"
+{
+  sqlite3CheckVersion(pParse, 3030000, "NULLS FIRST", &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
+  yylhsminor.yy394 = SQLITE_SO_ASC;
+}
+//line 4506 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -1].minor.yy394 = yylhsminor.yy394;
         break
-      case 140: /* nulls ::= NULLS LAST */
-//line 890 "parse.y"
-{yypParser.yystack[yypParser.yytos+ -1].minor.yy394 = SQLITE_SO_DESC;}
-//line 4472 "parse.go"
+      case 142: /* nulls ::= NULLS LAST */
+//line 898 "parse.y"
+{
+  sqlite3CheckVersion(pParse, 3030000, "NULLS LAST", &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
+  yylhsminor.yy394 = SQLITE_SO_DESC;
+}
+//line 4515 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -1].minor.yy394 = yylhsminor.yy394;
         break
-      case 144: /* having_opt ::= */
+      case 146: /* having_opt ::= */
         fallthrough
-      case 146: /* limit_opt ::= */ yytestcase(yyruleno==146);
+      case 148: /* limit_opt ::= */ yytestcase(yyruleno==148);
         fallthrough
-      case 151: /* where_opt ::= */ yytestcase(yyruleno==151);
+      case 153: /* where_opt ::= */ yytestcase(yyruleno==153);
         fallthrough
-      case 153: /* where_opt_ret ::= */ yytestcase(yyruleno==153);
+      case 155: /* where_opt_ret ::= */ yytestcase(yyruleno==155);
         fallthrough
-      case 227: /* case_else ::= */ yytestcase(yyruleno==227);
+      case 231: /* case_else ::= */ yytestcase(yyruleno==231);
         fallthrough
-      case 229: /* case_operand ::= */ yytestcase(yyruleno==229);
+      case 232: /* case_operand ::= */ yytestcase(yyruleno==232);
         fallthrough
-      case 248: /* vinto ::= */ yytestcase(yyruleno==248);
-//line 900 "parse.y"
-{yypParser.yystack[yypParser.yytos+ 1].minor.yy634 = 0;}
-//line 4489 "parse.go"
+      case 251: /* vinto ::= */ yytestcase(yyruleno==251);
+//line 911 "parse.y"
+{yypParser.yystack[yypParser.yytos+ 1].minor.yy634 = nil;}
+//line 4533 "parse.go"
         break
-      case 145: /* having_opt ::= HAVING expr */
+      case 147: /* having_opt ::= HAVING expr */
         fallthrough
-      case 152: /* where_opt ::= WHERE expr */ yytestcase(yyruleno==152);
+      case 154: /* where_opt ::= WHERE expr */ yytestcase(yyruleno==154);
         fallthrough
-      case 154: /* where_opt_ret ::= WHERE expr */ yytestcase(yyruleno==154);
+      case 156: /* where_opt_ret ::= WHERE expr */ yytestcase(yyruleno==156);
         fallthrough
-      case 226: /* case_else ::= ELSE expr */ yytestcase(yyruleno==226);
+      case 230: /* case_else ::= ELSE expr */ yytestcase(yyruleno==230);
         fallthrough
-      case 247: /* vinto ::= INTO expr */ yytestcase(yyruleno==247);
-//line 901 "parse.y"
+      case 250: /* vinto ::= INTO expr */ yytestcase(yyruleno==250);
+//line 912 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -1].minor.yy634 = yypParser.yystack[yypParser.yytos+ 0].minor.yy634;}
-//line 4502 "parse.go"
+//line 4546 "parse.go"
         break
-      case 147: /* limit_opt ::= LIMIT expr */
-//line 915 "parse.y"
-{yypParser.yystack[yypParser.yytos+ -1].minor.yy634 = sqlite3PExpr(pParse,TK_LIMIT,yypParser.yystack[yypParser.yytos+ 0].minor.yy634,0);}
-//line 4507 "parse.go"
+      case 149: /* limit_opt ::= LIMIT expr */
+//line 926 "parse.y"
+{yypParser.yystack[yypParser.yytos+ -1].minor.yy634 = sqlite3PExpr(pParse,TK_LIMIT,yypParser.yystack[yypParser.yytos+ 0].minor.yy634,nil);}
+//line 4551 "parse.go"
         break
-      case 148: /* limit_opt ::= LIMIT expr OFFSET expr */
-//line 917 "parse.y"
+      case 150: /* limit_opt ::= LIMIT expr OFFSET expr */
+//line 928 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -3].minor.yy634 = sqlite3PExpr(pParse,TK_LIMIT,yypParser.yystack[yypParser.yytos+ -2].minor.yy634,yypParser.yystack[yypParser.yytos+ 0].minor.yy634);}
-//line 4512 "parse.go"
+//line 4556 "parse.go"
         break
-      case 149: /* limit_opt ::= LIMIT expr COMMA expr */
-//line 919 "parse.y"
+      case 151: /* limit_opt ::= LIMIT expr COMMA expr */
+//line 930 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -3].minor.yy634 = sqlite3PExpr(pParse,TK_LIMIT,yypParser.yystack[yypParser.yytos+ 0].minor.yy634,yypParser.yystack[yypParser.yytos+ -2].minor.yy634);}
-//line 4517 "parse.go"
+//line 4561 "parse.go"
         break
-      case 150: /* cmd ::= with DELETE FROM xfullname indexed_opt where_opt_ret */
-//line 937 "parse.y"
+      case 152: /* cmd ::= with DELETE FROM xfullname indexed_opt where_opt_ret orderby_opt limit_opt */
+//line 936 "parse.y"
 {
-  sqlite3SrcListIndexedBy(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy157, &yypParser.yystack[yypParser.yytos+ -1].minor.yy0);
-  sqlite3DeleteFrom(pParse,yypParser.yystack[yypParser.yytos+ -2].minor.yy157,yypParser.yystack[yypParser.yytos+ 0].minor.yy634,0,0);
+  sqlite3SrcListIndexedBy(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy157, &yypParser.yystack[yypParser.yytos+ -3].minor.yy0);
+  if( (yypParser.yystack[yypParser.yytos+ -1].minor.yy614!=nil || yypParser.yystack[yypParser.yytos+ 0].minor.yy634!=nil) && pParse.db.bUpdateDeleteLimit==0 ){
+    updateDeleteLimitError(pParse,yypParser.yystack[yypParser.yytos+ -1].minor.yy614,yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
+    yypParser.yystack[yypParser.yytos+ -1].minor.yy614 = nil;
+    yypParser.yystack[yypParser.yytos+ 0].minor.yy634 = nil;
+  }
+  sqlite3DeleteFrom(pParse,yypParser.yystack[yypParser.yytos+ -4].minor.yy157,yypParser.yystack[yypParser.yytos+ -2].minor.yy634,yypParser.yystack[yypParser.yytos+ -1].minor.yy614,yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
 }
-//line 4525 "parse.go"
+//line 4574 "parse.go"
         break
-      case 155: /* where_opt_ret ::= RETURNING selcollist */
-//line 953 "parse.y"
-{sqlite3AddReturning(pParse,yypParser.yystack[yypParser.yytos+ 0].minor.yy614); yypParser.yystack[yypParser.yytos+ -1].minor.yy634 = 0;}
-//line 4530 "parse.go"
+      case 157: /* where_opt_ret ::= RETURNING selcollist */
+//line 962 "parse.y"
+{sqlite3CheckVersion(pParse, 3035000, "RETURNING", &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, nil);
+        sqlite3AddReturning(pParse,yypParser.yystack[yypParser.yytos+ 0].minor.yy614); yylhsminor.yy634 = nil;}
+//line 4580 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -1].minor.yy634 = yylhsminor.yy634;
         break
-      case 156: /* where_opt_ret ::= WHERE expr RETURNING selcollist */
-//line 955 "parse.y"
-{sqlite3AddReturning(pParse,yypParser.yystack[yypParser.yytos+ 0].minor.yy614); yypParser.yystack[yypParser.yytos+ -3].minor.yy634 = yypParser.yystack[yypParser.yytos+ -2].minor.yy634;}
-//line 4535 "parse.go"
+      case 158: /* where_opt_ret ::= WHERE expr RETURNING selcollist */
+//line 965 "parse.y"
+{sqlite3CheckVersion(pParse, 3035000, "RETURNING", &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, nil);
+        sqlite3AddReturning(pParse,yypParser.yystack[yypParser.yytos+ 0].minor.yy614); yypParser.yystack[yypParser.yytos+ -3].minor.yy634 = yypParser.yystack[yypParser.yytos+ -2].minor.yy634;}
+//line 4587 "parse.go"
         break
-      case 157: /* cmd ::= with UPDATE orconf xfullname indexed_opt SET setlist from where_opt_ret */
-//line 976 "parse.y"
+      case 159: /* cmd ::= with UPDATE orconf xfullname indexed_opt SET setlist from where_opt_ret orderby_opt limit_opt */
+//line 972 "parse.y"
 {
-  sqlite3SrcListIndexedBy(pParse, yypParser.yystack[yypParser.yytos+ -5].minor.yy157, &yypParser.yystack[yypParser.yytos+ -4].minor.yy0);
-  sqlite3ExprListCheckLength(pParse,yypParser.yystack[yypParser.yytos+ -2].minor.yy614,"set list"); 
-  yypParser.yystack[yypParser.yytos+ -5].minor.yy157 = sqlite3SrcListAppendList(pParse, yypParser.yystack[yypParser.yytos+ -5].minor.yy157, yypParser.yystack[yypParser.yytos+ -1].minor.yy157);
-  sqlite3Update(pParse,yypParser.yystack[yypParser.yytos+ -5].minor.yy157,yypParser.yystack[yypParser.yytos+ -2].minor.yy614,yypParser.yystack[yypParser.yytos+ 0].minor.yy634,yypParser.yystack[yypParser.yytos+ -6].minor.yy394,0,0,0);
+  sqlite3SrcListIndexedBy(pParse, yypParser.yystack[yypParser.yytos+ -7].minor.yy157, &yypParser.yystack[yypParser.yytos+ -6].minor.yy0);
+  if( yypParser.yystack[yypParser.yytos+ -3].minor.yy157!=nil ){ sqlite3CheckVersion(pParse, 3033000, "UPDATE FROM", &yypParser.yystack[yypParser.yytos+ -3].minor.yy157.sFrom, nil); }
+  yypParser.yystack[yypParser.yytos+ -7].minor.yy157 = sqlite3SrcListAppendList(pParse, yypParser.yystack[yypParser.yytos+ -7].minor.yy157, yypParser.yystack[yypParser.yytos+ -3].minor.yy157);
+  sqlite3ExprListCheckLength(pParse,yypParser.yystack[yypParser.yytos+ -4].minor.yy614,"set list"); 
+  if( (yypParser.yystack[yypParser.yytos+ -1].minor.yy614!=nil || yypParser.yystack[yypParser.yytos+ 0].minor.yy634!=nil) && pParse.db.bUpdateDeleteLimit==0 ){
+    updateDeleteLimitError(pParse,yypParser.yystack[yypParser.yytos+ -1].minor.yy614,yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
+    yypParser.yystack[yypParser.yytos+ -1].minor.yy614 = nil;
+    yypParser.yystack[yypParser.yytos+ 0].minor.yy634 = nil;
+  }
+  sqlite3Update(pParse,yypParser.yystack[yypParser.yytos+ -7].minor.yy157,yypParser.yystack[yypParser.yytos+ -4].minor.yy614,yypParser.yystack[yypParser.yytos+ -2].minor.yy634,yypParser.yystack[yypParser.yytos+ -8].minor.yy394,yypParser.yystack[yypParser.yytos+ -1].minor.yy614,yypParser.yystack[yypParser.yytos+ 0].minor.yy634,nil);
 }
-//line 4545 "parse.go"
+//line 4603 "parse.go"
         break
-      case 158: /* setlist ::= setlist COMMA nm EQ expr */
-//line 989 "parse.y"
+      case 160: /* setlist ::= setlist COMMA nm EQ expr */
+//line 1000 "parse.y"
 {
   yypParser.yystack[yypParser.yytos+ -4].minor.yy614 = sqlite3ExprListAppend(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy614, yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
   sqlite3ExprListSetName(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy614, &yypParser.yystack[yypParser.yytos+ -2].minor.yy0, 1);
 }
-//line 4553 "parse.go"
+//line 4611 "parse.go"
         break
-      case 159: /* setlist ::= setlist COMMA LP idlist RP EQ expr */
-//line 993 "parse.y"
+      case 161: /* setlist ::= setlist COMMA LP idlist RP EQ expr */
+//line 1004 "parse.y"
 {
   yypParser.yystack[yypParser.yytos+ -6].minor.yy614 = sqlite3ExprListAppendVector(pParse, yypParser.yystack[yypParser.yytos+ -6].minor.yy614, yypParser.yystack[yypParser.yytos+ -3].minor.yy106, yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
 }
-//line 4560 "parse.go"
+//line 4618 "parse.go"
         break
-      case 160: /* setlist ::= nm EQ expr */
-//line 996 "parse.y"
+      case 162: /* setlist ::= nm EQ expr */
+//line 1007 "parse.y"
 {
-  yylhsminor.yy614 = sqlite3ExprListAppend(pParse, 0, yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
+  yylhsminor.yy614 = sqlite3ExprListAppend(pParse, nil, yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
   sqlite3ExprListSetName(pParse, yylhsminor.yy614, &yypParser.yystack[yypParser.yytos+ -2].minor.yy0, 1);
 }
-//line 4568 "parse.go"
+//line 4626 "parse.go"
   yypParser.yystack[yypParser.yytos+ -2].minor.yy614 = yylhsminor.yy614;
         break
-      case 161: /* setlist ::= LP idlist RP EQ expr */
-//line 1000 "parse.y"
+      case 163: /* setlist ::= LP idlist RP EQ expr */
+//line 1011 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -4].minor.yy614 = sqlite3ExprListAppendVector(pParse, 0, yypParser.yystack[yypParser.yytos+ -3].minor.yy106, yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
+  yypParser.yystack[yypParser.yytos+ -4].minor.yy614 = sqlite3ExprListAppendVector(pParse, nil, yypParser.yystack[yypParser.yytos+ -3].minor.yy106, yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
 }
-//line 4576 "parse.go"
+//line 4634 "parse.go"
         break
-      case 162: /* cmd ::= with insert_cmd INTO xfullname idlist_opt select upsert */
-//line 1007 "parse.y"
+      case 164: /* cmd ::= with insert_cmd INTO xfullname idlist_opt select upsert */
+//line 1018 "parse.y"
 {
   sqlite3Insert(pParse, yypParser.yystack[yypParser.yytos+ -3].minor.yy157, yypParser.yystack[yypParser.yytos+ -1].minor.yy361, yypParser.yystack[yypParser.yytos+ -2].minor.yy106, yypParser.yystack[yypParser.yytos+ -5].minor.yy394, yypParser.yystack[yypParser.yytos+ 0].minor.yy442);
 }
-//line 4583 "parse.go"
+//line 4641 "parse.go"
         break
-      case 163: /* cmd ::= with insert_cmd INTO xfullname idlist_opt DEFAULT VALUES returning */
-//line 1011 "parse.y"
+      case 165: /* cmd ::= with insert_cmd INTO xfullname idlist_opt DEFAULT VALUES returning */
+//line 1022 "parse.y"
 {
-  sqlite3Insert(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy157, 0, yypParser.yystack[yypParser.yytos+ -3].minor.yy106, yypParser.yystack[yypParser.yytos+ -6].minor.yy394, 0);
+  sqlite3Insert(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy157, nil, yypParser.yystack[yypParser.yytos+ -3].minor.yy106, yypParser.yystack[yypParser.yytos+ -6].minor.yy394, nil);
 }
-//line 4590 "parse.go"
+//line 4648 "parse.go"
         break
-      case 164: /* upsert ::= */
-//line 1022 "parse.y"
-{ yypParser.yystack[yypParser.yytos+ 1].minor.yy442 = 0; }
-//line 4595 "parse.go"
-        break
-      case 165: /* upsert ::= RETURNING selcollist */
-//line 1023 "parse.y"
-{ yypParser.yystack[yypParser.yytos+ -1].minor.yy442 = 0; sqlite3AddReturning(pParse,yypParser.yystack[yypParser.yytos+ 0].minor.yy614); }
-//line 4600 "parse.go"
-        break
-      case 166: /* upsert ::= ON CONFLICT LP sortlist RP where_opt DO UPDATE SET setlist where_opt upsert */
-//line 1026 "parse.y"
-{ yypParser.yystack[yypParser.yytos+ -11].minor.yy442 = sqlite3UpsertNew(pParse.db,yypParser.yystack[yypParser.yytos+ -8].minor.yy614,yypParser.yystack[yypParser.yytos+ -6].minor.yy634,yypParser.yystack[yypParser.yytos+ -2].minor.yy614,yypParser.yystack[yypParser.yytos+ -1].minor.yy634,yypParser.yystack[yypParser.yytos+ 0].minor.yy442);}
-//line 4605 "parse.go"
-        break
-      case 167: /* upsert ::= ON CONFLICT LP sortlist RP where_opt DO NOTHING upsert */
-//line 1028 "parse.y"
-{ yypParser.yystack[yypParser.yytos+ -8].minor.yy442 = sqlite3UpsertNew(pParse.db,yypParser.yystack[yypParser.yytos+ -5].minor.yy614,yypParser.yystack[yypParser.yytos+ -3].minor.yy634,0,0,yypParser.yystack[yypParser.yytos+ 0].minor.yy442); }
-//line 4610 "parse.go"
-        break
-      case 168: /* upsert ::= ON CONFLICT DO NOTHING returning */
-//line 1030 "parse.y"
-{ yypParser.yystack[yypParser.yytos+ -4].minor.yy442 = sqlite3UpsertNew(pParse.db,0,0,0,0,0); }
-//line 4615 "parse.go"
-        break
-      case 169: /* upsert ::= ON CONFLICT DO UPDATE SET setlist where_opt returning */
-//line 1032 "parse.y"
-{ yypParser.yystack[yypParser.yytos+ -7].minor.yy442 = sqlite3UpsertNew(pParse.db,0,0,yypParser.yystack[yypParser.yytos+ -2].minor.yy614,yypParser.yystack[yypParser.yytos+ -1].minor.yy634,0);}
-//line 4620 "parse.go"
-        break
-      case 170: /* returning ::= RETURNING selcollist */
+      case 166: /* upsert ::= */
+//line 1033 "parse.y"
+{ yypParser.yystack[yypParser.yytos+ 1].minor.yy442 = nil; }
+//line 4653 "parse.go"
+        break
+      case 167: /* upsert ::= RETURNING selcollist */
 //line 1034 "parse.y"
-{sqlite3AddReturning(pParse,yypParser.yystack[yypParser.yytos+ 0].minor.yy614);}
-//line 4625 "parse.go"
+{
+  yylhsminor.yy442 = nil;
+  sqlite3CheckVersion(pParse, 3035000, "RETURNING", &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, nil);
+  sqlite3AddReturning(pParse,yypParser.yystack[yypParser.yytos+ 0].minor.yy614);
+}
+//line 4662 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -1].minor.yy442 = yylhsminor.yy442;
+        break
+      case 168: /* upsert ::= ON CONFLICT LP sortlist RP where_opt DO UPDATE SET setlist where_opt upsert */
+//line 1041 "parse.y"
+{ sqlite3CheckVersion(pParse, 3024000, "UPSERT", &yypParser.yystack[yypParser.yytos+ -11].minor.yy0, &yypParser.yystack[yypParser.yytos+ -10].minor.yy0);
+                yylhsminor.yy442 = sqlite3UpsertNew(pParse.db,yypParser.yystack[yypParser.yytos+ -8].minor.yy614,yypParser.yystack[yypParser.yytos+ -6].minor.yy634,yypParser.yystack[yypParser.yytos+ -2].minor.yy614,yypParser.yystack[yypParser.yytos+ -1].minor.yy634,yypParser.yystack[yypParser.yytos+ 0].minor.yy442);}
+//line 4669 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -11].minor.yy442 = yylhsminor.yy442;
         break
-      case 173: /* idlist_opt ::= */
-//line 1046 "parse.y"
-{yypParser.yystack[yypParser.yytos+ 1].minor.yy106 = 0;}
-//line 4630 "parse.go"
+      case 169: /* upsert ::= ON CONFLICT LP sortlist RP where_opt DO NOTHING upsert */
+//line 1044 "parse.y"
+{ sqlite3CheckVersion(pParse, 3024000, "UPSERT", &yypParser.yystack[yypParser.yytos+ -8].minor.yy0, &yypParser.yystack[yypParser.yytos+ -7].minor.yy0);
+                yylhsminor.yy442 = sqlite3UpsertNew(pParse.db,yypParser.yystack[yypParser.yytos+ -5].minor.yy614,yypParser.yystack[yypParser.yytos+ -3].minor.yy634,nil,nil,yypParser.yystack[yypParser.yytos+ 0].minor.yy442); }
+//line 4676 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -8].minor.yy442 = yylhsminor.yy442;
         break
-      case 174: /* idlist_opt ::= LP idlist RP */
+      case 170: /* upsert ::= ON CONFLICT DO NOTHING returning */
 //line 1047 "parse.y"
+{ sqlite3CheckVersion(pParse, 3024000, "UPSERT", &yypParser.yystack[yypParser.yytos+ -4].minor.yy0, &yypParser.yystack[yypParser.yytos+ -3].minor.yy0);
+                yylhsminor.yy442 = sqlite3UpsertNew(pParse.db,nil,nil,nil,nil,nil); }
+//line 4683 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -4].minor.yy442 = yylhsminor.yy442;
+        break
+      case 171: /* upsert ::= ON CONFLICT DO UPDATE SET setlist where_opt returning */
+//line 1050 "parse.y"
+{ sqlite3CheckVersion(pParse, 3024000, "UPSERT", &yypParser.yystack[yypParser.yytos+ -7].minor.yy0, &yypParser.yystack[yypParser.yytos+ -6].minor.yy0);
+                yylhsminor.yy442 = sqlite3UpsertNew(pParse.db,nil,nil,yypParser.yystack[yypParser.yytos+ -2].minor.yy614,yypParser.yystack[yypParser.yytos+ -1].minor.yy634,nil);}
+//line 4690 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -7].minor.yy442 = yylhsminor.yy442;
+        break
+      case 172: /* returning ::= RETURNING selcollist */
+//line 1053 "parse.y"
+{
+  sqlite3CheckVersion(pParse, 3035000, "RETURNING", &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, nil);
+  sqlite3AddReturning(pParse,yypParser.yystack[yypParser.yytos+ 0].minor.yy614);
+}
+//line 4699 "parse.go"
+        break
+      case 175: /* idlist_opt ::= */
+//line 1068 "parse.y"
+{yypParser.yystack[yypParser.yytos+ 1].minor.yy106 = nil;}
+//line 4704 "parse.go"
+        break
+      case 176: /* idlist_opt ::= LP idlist RP */
+//line 1069 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -2].minor.yy106 = yypParser.yystack[yypParser.yytos+ -1].minor.yy106;}
-//line 4635 "parse.go"
+//line 4709 "parse.go"
         break
-      case 175: /* idlist ::= idlist COMMA nm */
-//line 1049 "parse.y"
+      case 177: /* idlist ::= idlist COMMA nm */
+//line 1071 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -2].minor.yy106 = sqlite3IdListAppend(pParse,yypParser.yystack[yypParser.yytos+ -2].minor.yy106,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0);}
-//line 4640 "parse.go"
+//line 4714 "parse.go"
         break
-      case 176: /* idlist ::= nm */
-//line 1051 "parse.y"
-{yypParser.yystack[yypParser.yytos+ 0].minor.yy106 = sqlite3IdListAppend(pParse,0,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0); /*A-overwrites-Y*/}
-//line 4645 "parse.go"
+      case 178: /* idlist ::= nm */
+//line 1073 "parse.y"
+{yypParser.yystack[yypParser.yytos+ 0].minor.yy106 = sqlite3IdListAppend(pParse,nil,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0); /*A-overwrites-Y*/}
+//line 4719 "parse.go"
         break
-      case 177: /* expr ::= LP expr RP */
-//line 1101 "parse.y"
-{yypParser.yystack[yypParser.yytos+ -2].minor.yy634 = yypParser.yystack[yypParser.yytos+ -1].minor.yy634;}
-//line 4650 "parse.go"
+      case 179: /* expr ::= LP expr RP */
+//line 1133 "parse.y"
+{yylhsminor.yy634 = yypParser.yystack[yypParser.yytos+ -1].minor.yy634; exprSpan(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ -2].minor.yy0.z);}
+//line 4724 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -2].minor.yy634 = yylhsminor.yy634;
         break
-      case 178: /* expr ::= ID|INDEXED */
+      case 180: /* expr ::= ID|INDEXED */
         fallthrough
-      case 179: /* expr ::= JOIN_KW */ yytestcase(yyruleno==179);
-//line 1102 "parse.y"
+      case 181: /* expr ::= JOIN_KW */ yytestcase(yyruleno==181);
+//line 1134 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 0].minor.yy634=tokenExpr(pParse,TK_ID,yypParser.yystack[yypParser.yytos+ 0].minor.yy0); /*A-overwrites-X*/}
-//line 4657 "parse.go"
+//line 4732 "parse.go"
         break
-      case 180: /* expr ::= nm DOT nm */
-//line 1104 "parse.y"
+      case 182: /* expr ::= nm DOT nm */
+//line 1136 "parse.y"
 {
-  Expr *temp1 = tokenExpr(pParse,TK_ID,yypParser.yystack[yypParser.yytos+ -2].minor.yy0);
-  Expr *temp2 = tokenExpr(pParse,TK_ID,yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
+  temp1 := tokenExpr(pParse,TK_ID,yypParser.yystack[yypParser.yytos+ -2].minor.yy0);
+  temp2 := tokenExpr(pParse,TK_ID,yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
   yylhsminor.yy634 = sqlite3PExpr(pParse, TK_DOT, temp1, temp2);
+  exprSpan(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ -2].minor.yy0.z);
 }
-//line 4666 "parse.go"
+//line 4742 "parse.go"
   yypParser.yystack[yypParser.yytos+ -2].minor.yy634 = yylhsminor.yy634;
         break
-      case 181: /* expr ::= nm DOT nm DOT nm */
-//line 1109 "parse.y"
+      case 183: /* expr ::= nm DOT nm DOT nm */
+//line 1142 "parse.y"
 {
   temp1 := tokenExpr(pParse,TK_ID,yypParser.yystack[yypParser.yytos+ -4].minor.yy0);
   temp2 := tokenExpr(pParse,TK_ID,yypParser.yystack[yypParser.yytos+ -2].minor.yy0);
   temp3 := tokenExpr(pParse,TK_ID,yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
   temp4 := sqlite3PExpr(pParse, TK_DOT, temp2, temp3);
-  if( IN_RENAME_OBJECT ){
-    sqlite3RenameTokenRemap(pParse, 0, temp1);
+  exprSpan(pParse, temp4, yypParser.yystack[yypParser.yytos+ -2].minor.yy0.z);
+  if( pParse.eParseMode>=PARSE_MODE_RENAME ){
+    sqlite3RenameTokenRemap(pParse, nil, temp1);
   }
   yylhsminor.yy634 = sqlite3PExpr(pParse, TK_DOT, temp1, temp4);
+  exprSpan(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ -4].minor.yy0.z);
 }
-//line 4681 "parse.go"
+//line 4759 "parse.go"
   yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = yylhsminor.yy634;
         break
-      case 182: /* term ::= NULL|FLOAT|BLOB */
+      case 184: /* term ::= NULL|FLOAT|BLOB */
         fallthrough
-      case 183: /* term ::= STRING */ yytestcase(yyruleno==183);
-//line 1119 "parse.y"
-{yypParser.yystack[yypParser.yytos+ 0].minor.yy634=tokenExpr(pParse,yypParser.yystack[yypParser.yytos+ 0].major,yypParser.yystack[yypParser.yytos+ 0].minor.yy0); /*A-overwrites-X*/}
-//line 4689 "parse.go"
+      case 185: /* term ::= STRING */ yytestcase(yyruleno==185);
+//line 1154 "parse.y"
+{yypParser.yystack[yypParser.yytos+ 0].minor.yy634=tokenExpr(pParse,int(yypParser.yystack[yypParser.yytos+ 0].major),yypParser.yystack[yypParser.yytos+ 0].minor.yy0); /*A-overwrites-X*/}
+//line 4767 "parse.go"
         break
-      case 184: /* term ::= INTEGER */
-//line 1121 "parse.y"
+      case 186: /* term ::= INTEGER */
+//line 1156 "parse.y"
 {
   yylhsminor.yy634 = sqlite3ExprAlloc(pParse.db, TK_INTEGER, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0, 1);
-  if( yylhsminor.yy634 ) {
-    yylhsminor.yy634.w.iOfst = (int)(yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z - pParse.zTail);
+  if( yylhsminor.yy634!=nil ) {
+    yylhsminor.yy634.w.iOfst = len(pParse.zTail) - len(yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z);
+    exprSpan(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z);
   }
 }
-//line 4699 "parse.go"
+//line 4778 "parse.go"
   yypParser.yystack[yypParser.yytos+ 0].minor.yy634 = yylhsminor.yy634;
         break
-      case 185: /* expr ::= VARIABLE */
-//line 1127 "parse.y"
+      case 187: /* expr ::= VARIABLE */
+//line 1163 "parse.y"
 {
-  if( !(yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z[0]=='#' && sqlite3Isdigit(yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z[1])) ){
+  if( !(yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z[0]=='#' && yypParser.yystack[yypParser.yytos+ 0].minor.yy0.n>1 && sqlite3Isdigit(yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z[1])) ){
     n := yypParser.yystack[yypParser.yytos+ 0].minor.yy0.n;
     yypParser.yystack[yypParser.yytos+ 0].minor.yy634 = tokenExpr(pParse, TK_VARIABLE, yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
     sqlite3ExprAssignVarNumber(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy634, n);
@@ -4681,233 +4793,304 @@ func (yypParser *yyParser) yy_reduce(
     assert( t.n>=2 , "t.n>=2");
     if( pParse.nested==0 ){
       sqlite3ErrorMsg(pParse, "near \"%T\": syntax error", &t);
-      yypParser.yystack[yypParser.yytos+ 0].minor.yy634 = 0;
+      yypParser.yystack[yypParser.yytos+ 0].minor.yy634 = nil;
     }else{
-      yypParser.yystack[yypParser.yytos+ 0].minor.yy634 = sqlite3PExpr(pParse, TK_REGISTER, 0, 0);
-      if( yypParser.yystack[yypParser.yytos+ 0].minor.yy634 ) {
-        sqlite3GetInt32(&t.z[1], &yypParser.yystack[yypParser.yytos+ 0].minor.yy634.iTable);
+      yypParser.yystack[yypParser.yytos+ 0].minor.yy634 = sqlite3PExpr(pParse, TK_REGISTER, nil, nil);
+      if( yypParser.yystack[yypParser.yytos+ 0].minor.yy634!=nil ) {
+        sqlite3GetInt32(t.z[1:t.n], &yypParser.yystack[yypParser.yytos+ 0].minor.yy634.iTable);
       }
     }
   }
 }
-//line 4727 "parse.go"
+//line 4806 "parse.go"
         break
-      case 186: /* expr ::= expr COLLATE ID|STRING */
-//line 1150 "parse.y"
+      case 188: /* expr ::= expr COLLATE ID|STRING */
+//line 1186 "parse.y"
 {
+  z := exprSpanStart(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy634);
   yypParser.yystack[yypParser.yytos+ -2].minor.yy634 = sqlite3ExprAddCollateToken(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy634, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0, 1);
+  exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy634, z);
 }
-//line 4734 "parse.go"
+//line 4815 "parse.go"
         break
-      case 187: /* expr ::= CAST LP expr AS typetoken RP */
-//line 1154 "parse.y"
+      case 189: /* expr ::= CAST LP expr AS typetoken RP */
+//line 1192 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -5].minor.yy634 = sqlite3ExprAlloc(pParse.db, TK_CAST, &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, 1);
-  sqlite3ExprAttachSubtrees(pParse.db, yypParser.yystack[yypParser.yytos+ -5].minor.yy634, yypParser.yystack[yypParser.yytos+ -3].minor.yy634, 0);
+  yylhsminor.yy634 = sqlite3ExprAlloc(pParse.db, TK_CAST, &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, 1);
+  sqlite3ExprAttachSubtrees(pParse.db, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ -3].minor.yy634, nil);
+  exprSpan(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ -5].minor.yy0.z);
 }
-//line 4742 "parse.go"
+//line 4824 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -5].minor.yy634 = yylhsminor.yy634;
         break
-      case 188: /* expr ::= ID|INDEXED LP distinct exprlist RP */
-//line 1161 "parse.y"
+      case 190: /* expr ::= ID|INDEXED LP distinct exprlist RP */
+//line 1200 "parse.y"
 {
   yylhsminor.yy634 = sqlite3ExprFunction(pParse, yypParser.yystack[yypParser.yytos+ -1].minor.yy614, &yypParser.yystack[yypParser.yytos+ -4].minor.yy0, yypParser.yystack[yypParser.yytos+ -2].minor.yy394);
+  exprSpan(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ -4].minor.yy0.z);
 }
-//line 4749 "parse.go"
+//line 4833 "parse.go"
   yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = yylhsminor.yy634;
         break
-      case 189: /* expr ::= ID|INDEXED LP STAR RP */
-//line 1164 "parse.y"
+      case 191: /* expr ::= ID|INDEXED LP STAR RP */
+//line 1204 "parse.y"
 {
-  yylhsminor.yy634 = sqlite3ExprFunction(pParse, 0, &yypParser.yystack[yypParser.yytos+ -3].minor.yy0, 0);
+  yylhsminor.yy634 = sqlite3ExprFunction(pParse, nil, &yypParser.yystack[yypParser.yytos+ -3].minor.yy0, 0);
+  exprSpan(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ -3].minor.yy0.z);
 }
-//line 4757 "parse.go"
+//line 4842 "parse.go"
   yypParser.yystack[yypParser.yytos+ -3].minor.yy634 = yylhsminor.yy634;
         break
-      case 190: /* expr ::= ID|INDEXED LP distinct exprlist RP filter_over */
-//line 1169 "parse.y"
+      case 192: /* expr ::= ID|INDEXED LP distinct exprlist RP filter_over */
+//line 1210 "parse.y"
 {
   yylhsminor.yy634 = sqlite3ExprFunction(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy614, &yypParser.yystack[yypParser.yytos+ -5].minor.yy0, yypParser.yystack[yypParser.yytos+ -3].minor.yy394);
   sqlite3WindowAttach(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ 0].minor.yy179);
+  exprSpan(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ -5].minor.yy0.z);
 }
-//line 4766 "parse.go"
+//line 4852 "parse.go"
   yypParser.yystack[yypParser.yytos+ -5].minor.yy634 = yylhsminor.yy634;
         break
-      case 191: /* expr ::= ID|INDEXED LP STAR RP filter_over */
-//line 1173 "parse.y"
+      case 193: /* expr ::= ID|INDEXED LP STAR RP filter_over */
+//line 1215 "parse.y"
 {
-  yylhsminor.yy634 = sqlite3ExprFunction(pParse, 0, &yypParser.yystack[yypParser.yytos+ -4].minor.yy0, 0);
+  yylhsminor.yy634 = sqlite3ExprFunction(pParse, nil, &yypParser.yystack[yypParser.yytos+ -4].minor.yy0, 0);
   sqlite3WindowAttach(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ 0].minor.yy179);
+  exprSpan(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ -4].minor.yy0.z);
 }
-//line 4775 "parse.go"
+//line 4862 "parse.go"
   yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = yylhsminor.yy634;
         break
-      case 192: /* term ::= CTIME_KW */
-//line 1179 "parse.y"
+      case 194: /* term ::= CTIME_KW */
+//line 1222 "parse.y"
 {
-  yylhsminor.yy634 = sqlite3ExprFunction(pParse, 0, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0, 0);
+  yylhsminor.yy634 = sqlite3ExprFunction(pParse, nil, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0, 0);
+  exprSpan(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z);
 }
-//line 4783 "parse.go"
+//line 4871 "parse.go"
   yypParser.yystack[yypParser.yytos+ 0].minor.yy634 = yylhsminor.yy634;
         break
-      case 193: /* expr ::= LP nexprlist COMMA expr RP */
-//line 1183 "parse.y"
+      case 195: /* expr ::= LP nexprlist COMMA expr RP */
+//line 1227 "parse.y"
 {
-  ExprList *pList = sqlite3ExprListAppend(pParse, yypParser.yystack[yypParser.yytos+ -3].minor.yy614, yypParser.yystack[yypParser.yytos+ -1].minor.yy634);
-  yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_VECTOR, 0, 0);
-  if( yypParser.yystack[yypParser.yytos+ -4].minor.yy634 ){
-    yypParser.yystack[yypParser.yytos+ -4].minor.yy634.x.pList = pList;
-    if( ALWAYS(pList.nExpr) ){
-      yypParser.yystack[yypParser.yytos+ -4].minor.yy634.flags |= pList.a[0].pExpr.flags & EP_Propagate;
+  pList := sqlite3ExprListAppend(pParse, yypParser.yystack[yypParser.yytos+ -3].minor.yy614, yypParser.yystack[yypParser.yytos+ -1].minor.yy634);
+  yylhsminor.yy634 = sqlite3PExpr(pParse, TK_VECTOR, nil, nil);
+  if( yylhsminor.yy634!=nil ){
+    yylhsminor.yy634.x.pList = pList;
+    if( ALWAYS(pList.nExpr>0) ){
+      yylhsminor.yy634.flags |= pList.a[0].pExpr.flags & EP_Propagate;
     }
   }else{
     sqlite3ExprListDelete(pParse.db, pList);
   }
+  exprSpan(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ -4].minor.yy0.z);
 }
-//line 4800 "parse.go"
+//line 4889 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = yylhsminor.yy634;
         break
-      case 194: /* expr ::= expr AND expr */
-//line 1196 "parse.y"
-{yypParser.yystack[yypParser.yytos+ -2].minor.yy634=sqlite3ExprAnd(pParse,yypParser.yystack[yypParser.yytos+ -2].minor.yy634,yypParser.yystack[yypParser.yytos+ 0].minor.yy634);}
-//line 4805 "parse.go"
+      case 196: /* expr ::= expr AND expr */
+//line 1241 "parse.y"
+{
+  z := exprSpanStart(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy634);
+  yypParser.yystack[yypParser.yytos+ -2].minor.yy634 = sqlite3ExprAnd(pParse,yypParser.yystack[yypParser.yytos+ -2].minor.yy634,yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
+  exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy634, z);
+}
+//line 4899 "parse.go"
         break
-      case 195: /* expr ::= expr OR expr */
+      case 197: /* expr ::= expr OR expr */
         fallthrough
-      case 196: /* expr ::= expr LT|GT|GE|LE expr */ yytestcase(yyruleno==196);
+      case 198: /* expr ::= expr LT|GT|GE|LE expr */ yytestcase(yyruleno==198);
         fallthrough
-      case 197: /* expr ::= expr EQ|NE expr */ yytestcase(yyruleno==197);
+      case 199: /* expr ::= expr EQ|NE expr */ yytestcase(yyruleno==199);
         fallthrough
-      case 198: /* expr ::= expr BITAND|BITOR|LSHIFT|RSHIFT expr */ yytestcase(yyruleno==198);
+      case 200: /* expr ::= expr BITAND|BITOR|LSHIFT|RSHIFT expr */ yytestcase(yyruleno==200);
         fallthrough
-      case 199: /* expr ::= expr PLUS|MINUS expr */ yytestcase(yyruleno==199);
+      case 201: /* expr ::= expr PLUS|MINUS expr */ yytestcase(yyruleno==201);
         fallthrough
-      case 200: /* expr ::= expr STAR|SLASH|REM expr */ yytestcase(yyruleno==200);
+      case 202: /* expr ::= expr STAR|SLASH|REM expr */ yytestcase(yyruleno==202);
         fallthrough
-      case 201: /* expr ::= expr CONCAT expr */ yytestcase(yyruleno==201);
-//line 1197 "parse.y"
-{yypParser.yystack[yypParser.yytos+ -2].minor.yy634=sqlite3PExpr(pParse,yypParser.yystack[yypParser.yytos+ -1].major,yypParser.yystack[yypParser.yytos+ -2].minor.yy634,yypParser.yystack[yypParser.yytos+ 0].minor.yy634);}
-//line 4822 "parse.go"
+      case 203: /* expr ::= expr CONCAT expr */ yytestcase(yyruleno==203);
+//line 1246 "parse.y"
+{
+  z := exprSpanStart(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy634);
+  yypParser.yystack[yypParser.yytos+ -2].minor.yy634 = sqlite3PExpr(pParse,int(yypParser.yystack[yypParser.yytos+ -1].major),yypParser.yystack[yypParser.yytos+ -2].minor.yy634,yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
+  exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy634, z);
+}
+//line 4920 "parse.go"
         break
-      case 202: /* likeop ::= NOT LIKE_KW|MATCH */
-//line 1210 "parse.y"
+      case 204: /* likeop ::= NOT LIKE_KW|MATCH */
+//line 1283 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -1].minor.yy0=yypParser.yystack[yypParser.yytos+ 0].minor.yy0; yypParser.yystack[yypParser.yytos+ -1].minor.yy0.n|=0x80000000; /*yypParser.yystack[yypParser.yytos+ -1].minor.yy0-overwrite-yypParser.yystack[yypParser.yytos+ 0].minor.yy0*/}
-//line 4827 "parse.go"
+//line 4925 "parse.go"
         break
-      case 203: /* expr ::= expr likeop expr */
-//line 1211 "parse.y"
+      case 205: /* expr ::= expr likeop expr */
+//line 1284 "parse.y"
 {
   var pList *ExprList;
+  z := exprSpanStart(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy634);
   bNot := int(yypParser.yystack[yypParser.yytos+ -1].minor.yy0.n & 0x80000000);
   yypParser.yystack[yypParser.yytos+ -1].minor.yy0.n &= 0x7fffffff;
-  pList = sqlite3ExprListAppend(pParse,0, yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
+  pList = sqlite3ExprListAppend(pParse,nil, yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
   pList = sqlite3ExprListAppend(pParse,pList, yypParser.yystack[yypParser.yytos+ -2].minor.yy634);
   yypParser.yystack[yypParser.yytos+ -2].minor.yy634 = sqlite3ExprFunction(pParse, pList, &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, 0);
-  if( bNot ) {
-    yypParser.yystack[yypParser.yytos+ -2].minor.yy634 = sqlite3PExpr(pParse, TK_NOT, yypParser.yystack[yypParser.yytos+ -2].minor.yy634, 0);
+  exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy634, z);
+  if( bNot!=0 ) {
+    yypParser.yystack[yypParser.yytos+ -2].minor.yy634 = sqlite3PExpr(pParse, TK_NOT, yypParser.yystack[yypParser.yytos+ -2].minor.yy634, nil);
+    exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy634, z);
   }
-  if( yypParser.yystack[yypParser.yytos+ -2].minor.yy634 ) {
+  if( yypParser.yystack[yypParser.yytos+ -2].minor.yy634!=nil ) {
     yypParser.yystack[yypParser.yytos+ -2].minor.yy634.flags |= EP_InfixFunc;
   }
 }
-//line 4845 "parse.go"
+//line 4946 "parse.go"
         break
-      case 204: /* expr ::= expr likeop expr ESCAPE expr */
-//line 1225 "parse.y"
+      case 206: /* expr ::= expr likeop expr ESCAPE expr */
+//line 1301 "parse.y"
 {
   var pList *ExprList;
+  z := exprSpanStart(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634);
   bNot := int(yypParser.yystack[yypParser.yytos+ -3].minor.yy0.n & 0x80000000);
   yypParser.yystack[yypParser.yytos+ -3].minor.yy0.n &= 0x7fffffff;
-  pList = sqlite3ExprListAppend(pParse,0, yypParser.yystack[yypParser.yytos+ -2].minor.yy634);
+  pList = sqlite3ExprListAppend(pParse,nil, yypParser.yystack[yypParser.yytos+ -2].minor.yy634);
   pList = sqlite3ExprListAppend(pParse,pList, yypParser.yystack[yypParser.yytos+ -4].minor.yy634);
   pList = sqlite3ExprListAppend(pParse,pList, yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
   yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3ExprFunction(pParse, pList, &yypParser.yystack[yypParser.yytos+ -3].minor.yy0, 0);
-  if( bNot ) {
-    yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_NOT, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, 0);
+  exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, z);
+  if( bNot!=0 ) {
+    yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_NOT, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, nil);
+    exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, z);
   }
-  if( yypParser.yystack[yypParser.yytos+ -4].minor.yy634 ) {
+  if( yypParser.yystack[yypParser.yytos+ -4].minor.yy634!=nil ) {
     yypParser.yystack[yypParser.yytos+ -4].minor.yy634.flags |= EP_InfixFunc;
   }
 }
-//line 4864 "parse.go"
+//line 4968 "parse.go"
         break
-      case 205: /* expr ::= expr ISNULL|NOTNULL */
-//line 1241 "parse.y"
-{yypParser.yystack[yypParser.yytos+ -1].minor.yy634 = sqlite3PExpr(pParse,yypParser.yystack[yypParser.yytos+ 0].major,yypParser.yystack[yypParser.yytos+ -1].minor.yy634,0);}
-//line 4869 "parse.go"
+      case 207: /* expr ::= expr ISNULL|NOTNULL */
+//line 1320 "parse.y"
+{
+  z := exprSpanStart(pParse, yypParser.yystack[yypParser.yytos+ -1].minor.yy634);
+  yypParser.yystack[yypParser.yytos+ -1].minor.yy634 = sqlite3PExpr(pParse,int(yypParser.yystack[yypParser.yytos+ 0].major),yypParser.yystack[yypParser.yytos+ -1].minor.yy634,nil);
+  exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -1].minor.yy634, z);
+}
+//line 4977 "parse.go"
         break
-      case 206: /* expr ::= expr NOT NULL */
-//line 1242 "parse.y"
-{yypParser.yystack[yypParser.yytos+ -2].minor.yy634 = sqlite3PExpr(pParse,TK_NOTNULL,yypParser.yystack[yypParser.yytos+ -2].minor.yy634,0);}
-//line 4874 "parse.go"
+      case 208: /* expr ::= expr NOT NULL */
+//line 1325 "parse.y"
+{
+  z := exprSpanStart(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy634);
+  yypParser.yystack[yypParser.yytos+ -2].minor.yy634 = sqlite3PExpr(pParse,TK_NOTNULL,yypParser.yystack[yypParser.yytos+ -2].minor.yy634,nil);
+  exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy634, z);
+}
+//line 4986 "parse.go"
         break
-      case 207: /* expr ::= expr IS expr */
-//line 1263 "parse.y"
+      case 209: /* expr ::= expr IS expr */
+//line 1350 "parse.y"
 {
+  z := exprSpanStart(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy634);
   yypParser.yystack[yypParser.yytos+ -2].minor.yy634 = sqlite3PExpr(pParse,TK_IS,yypParser.yystack[yypParser.yytos+ -2].minor.yy634,yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
   binaryToUnaryIfNull(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy634, yypParser.yystack[yypParser.yytos+ -2].minor.yy634, TK_ISNULL);
+  exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy634, z);
 }
-//line 4882 "parse.go"
+//line 4996 "parse.go"
         break
-      case 208: /* expr ::= expr IS NOT expr */
-//line 1267 "parse.y"
+      case 210: /* expr ::= expr IS NOT expr */
+//line 1356 "parse.y"
 {
+  z := exprSpanStart(pParse, yypParser.yystack[yypParser.yytos+ -3].minor.yy634);
   yypParser.yystack[yypParser.yytos+ -3].minor.yy634 = sqlite3PExpr(pParse,TK_ISNOT,yypParser.yystack[yypParser.yytos+ -3].minor.yy634,yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
   binaryToUnaryIfNull(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy634, yypParser.yystack[yypParser.yytos+ -3].minor.yy634, TK_NOTNULL);
+  exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -3].minor.yy634, z);
 }
-//line 4890 "parse.go"
+//line 5006 "parse.go"
+        break
+      case 211: /* expr ::= expr IS NOT DISTINCT FROM expr */
+//line 1362 "parse.y"
+{
+  z := exprSpanStart(pParse, yypParser.yystack[yypParser.yytos+ -5].minor.yy634);
+  sqlite3CheckVersion(pParse, 3039000, "IS NOT DISTINCT FROM", &yypParser.yystack[yypParser.yytos+ -4].minor.yy0, &yypParser.yystack[yypParser.yytos+ -1].minor.yy0);
+  yypParser.yystack[yypParser.yytos+ -5].minor.yy634 = sqlite3PExpr(pParse,TK_IS,yypParser.yystack[yypParser.yytos+ -5].minor.yy634,yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
+  binaryToUnaryIfNull(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy634, yypParser.yystack[yypParser.yytos+ -5].minor.yy634, TK_ISNULL);
+  exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -5].minor.yy634, z);
+}
+//line 5017 "parse.go"
+        break
+      case 212: /* expr ::= expr IS DISTINCT FROM expr */
+//line 1369 "parse.y"
+{
+  z := exprSpanStart(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634);
+  sqlite3CheckVersion(pParse, 3039000, "IS DISTINCT FROM", &yypParser.yystack[yypParser.yytos+ -3].minor.yy0, &yypParser.yystack[yypParser.yytos+ -1].minor.yy0);
+  yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse,TK_ISNOT,yypParser.yystack[yypParser.yytos+ -4].minor.yy634,yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
+  binaryToUnaryIfNull(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy634, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, TK_NOTNULL);
+  exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, z);
+}
+//line 5028 "parse.go"
         break
-      case 209: /* expr ::= NOT expr */
+      case 213: /* expr ::= NOT expr */
         fallthrough
-      case 210: /* expr ::= BITNOT expr */ yytestcase(yyruleno==210);
-//line 1273 "parse.y"
-{yypParser.yystack[yypParser.yytos+ -1].minor.yy634 = sqlite3PExpr(pParse, yypParser.yystack[yypParser.yytos+ -1].major, yypParser.yystack[yypParser.yytos+ 0].minor.yy634, 0);/*A-overwrites-B*/}
-//line 4897 "parse.go"
+      case 214: /* expr ::= BITNOT expr */ yytestcase(yyruleno==214);
+//line 1377 "parse.y"
+{
+  yylhsminor.yy634 = sqlite3PExpr(pParse, int(yypParser.yystack[yypParser.yytos+ -1].major), yypParser.yystack[yypParser.yytos+ 0].minor.yy634, nil);
+  exprSpan(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ -1].minor.yy0.z);
+}
+//line 5038 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -1].minor.yy634 = yylhsminor.yy634;
         break
-      case 211: /* expr ::= PLUS|MINUS expr */
-//line 1276 "parse.y"
+      case 215: /* expr ::= PLUS|MINUS expr */
+//line 1385 "parse.y"
 {
-  // yypParser.yystack[yypParser.yytos+ -1].minor.yy634 = sqlite3PExpr(pParse, yypParser.yystack[yypParser.yytos+ -1].major==TK_PLUS ? TK_UPLUS : TK_UMINUS, yypParser.yystack[yypParser.yytos+ 0].minor.yy634, 0);
-  /*A-overwrites-B*/
+  op := TK_UMINUS
+  if( yypParser.yystack[yypParser.yytos+ -1].major==TK_PLUS ) { op = TK_UPLUS }
+  yylhsminor.yy634 = sqlite3PExpr(pParse, op, yypParser.yystack[yypParser.yytos+ 0].minor.yy634, nil);
+  exprSpan(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ -1].minor.yy0.z);
 }
-//line 4905 "parse.go"
+//line 5049 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -1].minor.yy634 = yylhsminor.yy634;
         break
-      case 212: /* expr ::= expr PTR expr */
-//line 1281 "parse.y"
+      case 216: /* expr ::= expr PTR expr */
+//line 1392 "parse.y"
 {
-  ExprList *pList = sqlite3ExprListAppend(pParse, 0, yypParser.yystack[yypParser.yytos+ -2].minor.yy634);
-  pList = sqlite3ExprListAppend(pParse, pList, yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
-  yylhsminor.yy634 = sqlite3ExprFunction(pParse, pList, &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, 0);
+  z := exprSpanStart(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy634);
+  sqlite3CheckVersion(pParse, 3038000, string(yypParser.yystack[yypParser.yytos+ -1].minor.yy0.z[:yypParser.yystack[yypParser.yytos+ -1].minor.yy0.n]), &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, nil);
+  yylhsminor.yy634 = sqlite3ExprPtr(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy634, &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
+  exprSpan(pParse, yylhsminor.yy634, z);
 }
-//line 4914 "parse.go"
+//line 5060 "parse.go"
   yypParser.yystack[yypParser.yytos+ -2].minor.yy634 = yylhsminor.yy634;
         break
-      case 213: /* between_op ::= BETWEEN */
+      case 217: /* between_op ::= BETWEEN */
         fallthrough
-      case 216: /* in_op ::= IN */ yytestcase(yyruleno==216);
-//line 1288 "parse.y"
+      case 220: /* in_op ::= IN */ yytestcase(yyruleno==220);
+//line 1400 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = 0;}
-//line 4922 "parse.go"
+//line 5068 "parse.go"
         break
-      case 215: /* expr ::= expr between_op expr AND expr */
-//line 1290 "parse.y"
+      case 219: /* expr ::= expr between_op expr AND expr */
+//line 1402 "parse.y"
 {
-  pList := sqlite3ExprListAppend(pParse,0, yypParser.yystack[yypParser.yytos+ -2].minor.yy634);
+  z := exprSpanStart(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634);
+  pList := sqlite3ExprListAppend(pParse,nil, yypParser.yystack[yypParser.yytos+ -2].minor.yy634);
   pList = sqlite3ExprListAppend(pParse,pList, yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
-  yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_BETWEEN, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, 0);
-  if( yypParser.yystack[yypParser.yytos+ -4].minor.yy634 ){
+  yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_BETWEEN, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, nil);
+  if( yypParser.yystack[yypParser.yytos+ -4].minor.yy634!=nil ){
     yypParser.yystack[yypParser.yytos+ -4].minor.yy634.x.pList = pList;
   }else{
     sqlite3ExprListDelete(pParse.db, pList);
   } 
-  if( yypParser.yystack[yypParser.yytos+ -3].minor.yy394 ) {
-    yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_NOT, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, 0);
+  exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, z);
+  if( yypParser.yystack[yypParser.yytos+ -3].minor.yy394!=0 ) {
+    yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_NOT, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, nil);
+    exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, z);
   }
 }
-//line 4939 "parse.go"
+//line 5088 "parse.go"
         break
-      case 218: /* expr ::= expr in_op LP exprlist RP */
-//line 1307 "parse.y"
+      case 222: /* expr ::= expr in_op LP exprlist RP */
+//line 1422 "parse.y"
 {
-    if( yypParser.yystack[yypParser.yytos+ -1].minor.yy614==0 ){
+    z := exprSpanStart(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634);
+    if( yypParser.yystack[yypParser.yytos+ -1].minor.yy614==nil ){
       /* Expressions of the form
       **
       **      expr1 IN ()
@@ -4918,25 +5101,25 @@ func (yypParser *yyParser) yy_reduce(
       */
       sqlite3ExprUnmapAndDelete(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634);
       cnt := "0"
-      if (yypParser.yystack[yypParser.yytos+ -3].minor.yy394) {
+      if( yypParser.yystack[yypParser.yytos+ -3].minor.yy394!=0 ){
         cnt = "1"
       }
-      yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3Expr(pParse.db, TK_INTEGER, cnt);
+      yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3Expr(pParse.db, TK_INTEGER, []byte(cnt));
     }else{
       pRHS := yypParser.yystack[yypParser.yytos+ -1].minor.yy614.a[0].pExpr;
-      if( yypParser.yystack[yypParser.yytos+ -1].minor.yy614.nExpr==1 && sqlite3ExprIsConstant(pRHS) && yypParser.yystack[yypParser.yytos+ -4].minor.yy634.op!=TK_VECTOR ){
-        yypParser.yystack[yypParser.yytos+ -1].minor.yy614.a[0].pExpr = 0;
+      if( yypParser.yystack[yypParser.yytos+ -1].minor.yy614.nExpr==1 && sqlite3ExprIsConstant(pRHS)!=0 && yypParser.yystack[yypParser.yytos+ -4].minor.yy634.op!=TK_VECTOR ){
+        yypParser.yystack[yypParser.yytos+ -1].minor.yy614.a[0].pExpr = nil;
         sqlite3ExprListDelete(pParse.db, yypParser.yystack[yypParser.yytos+ -1].minor.yy614);
-        pRHS = sqlite3PExpr(pParse, TK_UPLUS, pRHS, 0);
+        pRHS = sqlite3PExpr(pParse, TK_UPLUS, pRHS, nil);
         yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_EQ, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, pRHS);
       }else{
-        yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_IN, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, 0);
-        if( yypParser.yystack[yypParser.yytos+ -4].minor.yy634==0 ){
+        yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_IN, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, nil);
+        if( yypParser.yystack[yypParser.yytos+ -4].minor.yy634==nil ){
           sqlite3ExprListDelete(pParse.db, yypParser.yystack[yypParser.yytos+ -1].minor.yy614);
         }else if( yypParser.yystack[yypParser.yytos+ -4].minor.yy634.pLeft.op==TK_VECTOR ){
           nExpr := yypParser.yystack[yypParser.yytos+ -4].minor.yy634.pLeft.x.pList.nExpr;
           pSelectRHS := sqlite3ExprListToValues(pParse, nExpr, yypParser.yystack[yypParser.yytos+ -1].minor.yy614);
-          if( pSelectRHS ){
+          if( pSelectRHS!=nil ){
             parserDoubleLinkSelect(pParse, pSelectRHS);
             sqlite3PExprAddSelect(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, pSelectRHS);
           }
@@ -4945,208 +5128,217 @@ func (yypParser *yyParser) yy_reduce(
           sqlite3ExprSetHeightAndFlags(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634);
         }
       }
-      if( yypParser.yystack[yypParser.yytos+ -3].minor.yy394 ) {
-        yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_NOT, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, 0);
+      exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, z);
+      if( yypParser.yystack[yypParser.yytos+ -3].minor.yy394!=0 ) {
+        yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_NOT, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, nil);
       }
     }
+    exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, z);
   }
-//line 4987 "parse.go"
+//line 5139 "parse.go"
         break
-      case 219: /* expr ::= LP select RP */
-//line 1351 "parse.y"
+      case 223: /* expr ::= LP select RP */
+//line 1469 "parse.y"
 {
-    yypParser.yystack[yypParser.yytos+ -2].minor.yy634 = sqlite3PExpr(pParse, TK_SELECT, 0, 0);
-    sqlite3PExprAddSelect(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy634, yypParser.yystack[yypParser.yytos+ -1].minor.yy361);
+    yylhsminor.yy634 = sqlite3PExpr(pParse, TK_SELECT, nil, nil);
+    sqlite3PExprAddSelect(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ -1].minor.yy361);
+    exprSpan(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ -2].minor.yy0.z);
   }
-//line 4995 "parse.go"
+//line 5148 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -2].minor.yy634 = yylhsminor.yy634;
         break
-      case 220: /* expr ::= expr in_op LP select RP */
-//line 1355 "parse.y"
+      case 224: /* expr ::= expr in_op LP select RP */
+//line 1474 "parse.y"
 {
-    yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_IN, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, 0);
+    z := exprSpanStart(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634);
+    yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_IN, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, nil);
     sqlite3PExprAddSelect(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, yypParser.yystack[yypParser.yytos+ -1].minor.yy361);
-    if( yypParser.yystack[yypParser.yytos+ -3].minor.yy394 ) {
-      yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_NOT, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, 0);
+    exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, z);
+    if( yypParser.yystack[yypParser.yytos+ -3].minor.yy394!=0 ) {
+      yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_NOT, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, nil);
+      exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, z);
     }
   }
-//line 5006 "parse.go"
+//line 5163 "parse.go"
         break
-      case 221: /* expr ::= expr in_op nm dbnm paren_exprlist */
-//line 1362 "parse.y"
+      case 225: /* expr ::= expr in_op nm dbnm paren_exprlist */
+//line 1484 "parse.y"
 {
-    pSrc := sqlite3SrcListAppend(pParse, 0,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0);
-    pSelect := sqlite3SelectNew(pParse, 0,pSrc,0,0,0,0,0,0);
-    if( yypParser.yystack[yypParser.yytos+ 0].minor.yy614 ) {
+    z := exprSpanStart(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634);
+    pSrc := sqlite3SrcListAppend(pParse, nil,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0);
+    pSelect := sqlite3SelectNew(pParse, nil,pSrc,nil,nil,nil,nil,0,nil);
+    if( yypParser.yystack[yypParser.yytos+ 0].minor.yy614!=nil ) {
       if (pSelect != nil) {
         sqlite3SrcListFuncArgs(pParse, pSrc, yypParser.yystack[yypParser.yytos+ 0].minor.yy614);
       } else {
         sqlite3SrcListFuncArgs(pParse, nil, yypParser.yystack[yypParser.yytos+ 0].minor.yy614);
       }
     }
-    yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_IN, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, 0);
+    yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_IN, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, nil);
     sqlite3PExprAddSelect(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, pSelect);
-    if( yypParser.yystack[yypParser.yytos+ -3].minor.yy394 ) {
-      yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_NOT, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, 0);
+    exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, z);
+    if( yypParser.yystack[yypParser.yytos+ -3].minor.yy394!=0 ) {
+      yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_NOT, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, nil);
+      exprSpan(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634, z);
     }
   }
-//line 5026 "parse.go"
+//line 5186 "parse.go"
         break
-      case 222: /* expr ::= EXISTS LP select RP */
-//line 1378 "parse.y"
+      case 226: /* expr ::= EXISTS LP select RP */
+//line 1503 "parse.y"
 {
     var p *Expr;
-    yypParser.yystack[yypParser.yytos+ -3].minor.yy634 = sqlite3PExpr(pParse, TK_EXISTS, 0, 0);
-    p = yypParser.yystack[yypParser.yytos+ -3].minor.yy634
+    yylhsminor.yy634 = sqlite3PExpr(pParse, TK_EXISTS, nil, nil);
+    p = yylhsminor.yy634
     sqlite3PExprAddSelect(pParse, p, yypParser.yystack[yypParser.yytos+ -1].minor.yy361);
+    exprSpan(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ -3].minor.yy0.z);
   }
-//line 5036 "parse.go"
+//line 5197 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -3].minor.yy634 = yylhsminor.yy634;
         break
-      case 223: /* expr ::= CASE case_operand case_exprlist case_else END */
-//line 1387 "parse.y"
+      case 227: /* expr ::= CASE case_operand case_exprlist case_else END */
+//line 1513 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = sqlite3PExpr(pParse, TK_CASE, yypParser.yystack[yypParser.yytos+ -3].minor.yy634, 0);
-  if( yypParser.yystack[yypParser.yytos+ -4].minor.yy634 ){
-    if( yypParser.yystack[yypParser.yytos+ -1].minor.yy634 ) {
-      yypParser.yystack[yypParser.yytos+ -4].minor.yy634.x.pList = sqlite3ExprListAppend(pParse,yypParser.yystack[yypParser.yytos+ -2].minor.yy614,yypParser.yystack[yypParser.yytos+ -1].minor.yy634);
+  yylhsminor.yy634 = sqlite3PExpr(pParse, TK_CASE, yypParser.yystack[yypParser.yytos+ -3].minor.yy634, nil);
+  exprSpan(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ -4].minor.yy0.z);
+  if( yylhsminor.yy634!=nil ){
+    if( yypParser.yystack[yypParser.yytos+ -1].minor.yy634!=nil ) {
+      yylhsminor.yy634.x.pList = sqlite3ExprListAppend(pParse,yypParser.yystack[yypParser.yytos+ -2].minor.yy614,yypParser.yystack[yypParser.yytos+ -1].minor.yy634);
     } else {
-      yypParser.yystack[yypParser.yytos+ -4].minor.yy634.x.pList = yypParser.yystack[yypParser.yytos+ -2].minor.yy614;
+      yylhsminor.yy634.x.pList = yypParser.yystack[yypParser.yytos+ -2].minor.yy614;
     }
-    sqlite3ExprSetHeightAndFlags(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy634);
+    sqlite3ExprSetHeightAndFlags(pParse, yylhsminor.yy634);
   }else{
     sqlite3ExprListDelete(pParse.db, yypParser.yystack[yypParser.yytos+ -2].minor.yy614);
     sqlite3ExprDelete(pParse.db, yypParser.yystack[yypParser.yytos+ -1].minor.yy634);
   }
 }
-//line 5054 "parse.go"
+//line 5217 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = yylhsminor.yy634;
         break
-      case 224: /* case_exprlist ::= case_exprlist WHEN expr THEN expr */
-//line 1403 "parse.y"
+      case 228: /* case_exprlist ::= case_exprlist WHEN expr THEN expr */
+//line 1530 "parse.y"
 {
   yypParser.yystack[yypParser.yytos+ -4].minor.yy614 = sqlite3ExprListAppend(pParse,yypParser.yystack[yypParser.yytos+ -4].minor.yy614, yypParser.yystack[yypParser.yytos+ -2].minor.yy634);
   yypParser.yystack[yypParser.yytos+ -4].minor.yy614 = sqlite3ExprListAppend(pParse,yypParser.yystack[yypParser.yytos+ -4].minor.yy614, yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
 }
-//line 5062 "parse.go"
+//line 5226 "parse.go"
         break
-      case 225: /* case_exprlist ::= WHEN expr THEN expr */
-//line 1407 "parse.y"
+      case 229: /* case_exprlist ::= WHEN expr THEN expr */
+//line 1534 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -3].minor.yy614 = sqlite3ExprListAppend(pParse,0, yypParser.yystack[yypParser.yytos+ -2].minor.yy634);
+  yypParser.yystack[yypParser.yytos+ -3].minor.yy614 = sqlite3ExprListAppend(pParse,nil, yypParser.yystack[yypParser.yytos+ -2].minor.yy634);
   yypParser.yystack[yypParser.yytos+ -3].minor.yy614 = sqlite3ExprListAppend(pParse,yypParser.yystack[yypParser.yytos+ -3].minor.yy614, yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
 }
-//line 5070 "parse.go"
-        break
-      case 228: /* case_operand ::= expr */
-//line 1417 "parse.y"
-{yypParser.yystack[yypParser.yytos+ 0].minor.yy634 = yypParser.yystack[yypParser.yytos+ 0].minor.yy634; /*A-overwrites-X*/}
-//line 5075 "parse.go"
+//line 5234 "parse.go"
         break
-      case 231: /* nexprlist ::= nexprlist COMMA expr */
-//line 1428 "parse.y"
+      case 234: /* nexprlist ::= nexprlist COMMA expr */
+//line 1555 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -2].minor.yy614 = sqlite3ExprListAppend(pParse,yypParser.yystack[yypParser.yytos+ -2].minor.yy614,yypParser.yystack[yypParser.yytos+ 0].minor.yy634);}
-//line 5080 "parse.go"
+//line 5239 "parse.go"
         break
-      case 232: /* nexprlist ::= expr */
-//line 1430 "parse.y"
-{yypParser.yystack[yypParser.yytos+ 0].minor.yy614 = sqlite3ExprListAppend(pParse,0,yypParser.yystack[yypParser.yytos+ 0].minor.yy634); /*A-overwrites-Y*/}
-//line 5085 "parse.go"
+      case 235: /* nexprlist ::= expr */
+//line 1557 "parse.y"
+{yypParser.yystack[yypParser.yytos+ 0].minor.yy614 = sqlite3ExprListAppend(pParse,nil,yypParser.yystack[yypParser.yytos+ 0].minor.yy634); /*A-overwrites-Y*/}
+//line 5244 "parse.go"
         break
-      case 234: /* paren_exprlist ::= LP exprlist RP */
+      case 237: /* paren_exprlist ::= LP exprlist RP */
         fallthrough
-      case 239: /* eidlist_opt ::= LP eidlist RP */ yytestcase(yyruleno==239);
-//line 1438 "parse.y"
+      case 242: /* eidlist_opt ::= LP eidlist RP */ yytestcase(yyruleno==242);
+//line 1565 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -2].minor.yy614 = yypParser.yystack[yypParser.yytos+ -1].minor.yy614;}
-//line 5092 "parse.go"
+//line 5251 "parse.go"
         break
-      case 235: /* cmd ::= createkw uniqueflag INDEX ifnotexists nm dbnm ON nm LP sortlist RP where_opt */
-//line 1445 "parse.y"
+      case 238: /* cmd ::= createkw uniqueflag INDEX ifnotexists nm dbnm ON nm LP sortlist RP where_opt */
+//line 1572 "parse.y"
 {
   sqlite3CreateIndex(pParse, &yypParser.yystack[yypParser.yytos+ -7].minor.yy0, &yypParser.yystack[yypParser.yytos+ -6].minor.yy0, 
-                     sqlite3SrcListAppend(pParse,0,&yypParser.yystack[yypParser.yytos+ -4].minor.yy0,0), yypParser.yystack[yypParser.yytos+ -2].minor.yy614, yypParser.yystack[yypParser.yytos+ -10].minor.yy394,
+                     sqlite3SrcListAppend(pParse,nil,&yypParser.yystack[yypParser.yytos+ -4].minor.yy0,nil), yypParser.yystack[yypParser.yytos+ -2].minor.yy614, yypParser.yystack[yypParser.yytos+ -10].minor.yy394,
                       &yypParser.yystack[yypParser.yytos+ -11].minor.yy0, yypParser.yystack[yypParser.yytos+ 0].minor.yy634, SQLITE_SO_ASC, yypParser.yystack[yypParser.yytos+ -8].minor.yy394, SQLITE_IDXTYPE_APPDEF);
-  if( IN_RENAME_OBJECT && pParse.pNewIndex ){
+  if( pParse.eParseMode>=PARSE_MODE_RENAME && pParse.pNewIndex!=nil ){
     sqlite3RenameTokenMap(pParse, pParse.pNewIndex.zName, &yypParser.yystack[yypParser.yytos+ -4].minor.yy0);
   }
 }
-//line 5104 "parse.go"
+//line 5263 "parse.go"
         break
-      case 236: /* uniqueflag ::= UNIQUE */
+      case 239: /* uniqueflag ::= UNIQUE */
         fallthrough
-      case 278: /* raisetype ::= ABORT */ yytestcase(yyruleno==278);
-//line 1455 "parse.y"
+      case 281: /* raisetype ::= ABORT */ yytestcase(yyruleno==281);
+//line 1582 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = OE_Abort;}
-//line 5111 "parse.go"
+//line 5270 "parse.go"
         break
-      case 237: /* uniqueflag ::= */
-//line 1456 "parse.y"
+      case 240: /* uniqueflag ::= */
+//line 1583 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 1].minor.yy394 = OE_None;}
-//line 5116 "parse.go"
+//line 5275 "parse.go"
         break
-      case 240: /* eidlist ::= eidlist COMMA nm collate sortorder */
-//line 1506 "parse.y"
+      case 243: /* eidlist ::= eidlist COMMA nm collate sortorder */
+//line 1632 "parse.y"
 {
   yypParser.yystack[yypParser.yytos+ -4].minor.yy614 = parserAddExprIdListTerm(pParse, yypParser.yystack[yypParser.yytos+ -4].minor.yy614, &yypParser.yystack[yypParser.yytos+ -2].minor.yy0, yypParser.yystack[yypParser.yytos+ -1].minor.yy394, yypParser.yystack[yypParser.yytos+ 0].minor.yy394);
 }
-//line 5123 "parse.go"
+//line 5282 "parse.go"
         break
-      case 241: /* eidlist ::= nm collate sortorder */
-//line 1509 "parse.y"
+      case 244: /* eidlist ::= nm collate sortorder */
+//line 1635 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -2].minor.yy614 = parserAddExprIdListTerm(pParse, 0, &yypParser.yystack[yypParser.yytos+ -2].minor.yy0, yypParser.yystack[yypParser.yytos+ -1].minor.yy394, yypParser.yystack[yypParser.yytos+ 0].minor.yy394); /*A-overwrites-Y*/
+  yypParser.yystack[yypParser.yytos+ -2].minor.yy614 = parserAddExprIdListTerm(pParse, nil, &yypParser.yystack[yypParser.yytos+ -2].minor.yy0, yypParser.yystack[yypParser.yytos+ -1].minor.yy394, yypParser.yystack[yypParser.yytos+ 0].minor.yy394); /*A-overwrites-Y*/
 }
-//line 5130 "parse.go"
+//line 5289 "parse.go"
         break
-      case 244: /* cmd ::= DROP INDEX ifexists fullname */
-//line 1520 "parse.y"
+      case 247: /* cmd ::= DROP INDEX ifexists fullname */
+//line 1646 "parse.y"
 {sqlite3DropIndex(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy157, yypParser.yystack[yypParser.yytos+ -1].minor.yy394);}
-//line 5135 "parse.go"
+//line 5294 "parse.go"
         break
-      case 245: /* cmd ::= VACUUM vinto */
-//line 1527 "parse.y"
-{sqlite3Vacuum(pParse,0,yypParser.yystack[yypParser.yytos+ 0].minor.yy634);}
-//line 5140 "parse.go"
+      case 248: /* cmd ::= VACUUM vinto */
+//line 1653 "parse.y"
+{sqlite3Vacuum(pParse,nil,yypParser.yystack[yypParser.yytos+ 0].minor.yy634);}
+//line 5299 "parse.go"
         break
-      case 246: /* cmd ::= VACUUM nm vinto */
-//line 1528 "parse.y"
+      case 249: /* cmd ::= VACUUM nm vinto */
+//line 1654 "parse.y"
 {sqlite3Vacuum(pParse,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,yypParser.yystack[yypParser.yytos+ 0].minor.yy634);}
-//line 5145 "parse.go"
+//line 5304 "parse.go"
         break
-      case 249: /* cmd ::= PRAGMA nm dbnm */
-//line 1536 "parse.y"
-{sqlite3Pragma(pParse,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0,0,0);}
-//line 5150 "parse.go"
+      case 252: /* cmd ::= PRAGMA nm dbnm */
+//line 1662 "parse.y"
+{sqlite3Pragma(pParse,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0,nil,0);}
+//line 5309 "parse.go"
         break
-      case 250: /* cmd ::= PRAGMA nm dbnm EQ nmnum */
-//line 1537 "parse.y"
+      case 253: /* cmd ::= PRAGMA nm dbnm EQ nmnum */
+//line 1663 "parse.y"
 {sqlite3Pragma(pParse,&yypParser.yystack[yypParser.yytos+ -3].minor.yy0,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0,0);}
-//line 5155 "parse.go"
+//line 5314 "parse.go"
         break
-      case 251: /* cmd ::= PRAGMA nm dbnm LP nmnum RP */
-//line 1538 "parse.y"
+      case 254: /* cmd ::= PRAGMA nm dbnm LP nmnum RP */
+//line 1664 "parse.y"
 {sqlite3Pragma(pParse,&yypParser.yystack[yypParser.yytos+ -4].minor.yy0,&yypParser.yystack[yypParser.yytos+ -3].minor.yy0,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,0);}
-//line 5160 "parse.go"
+//line 5319 "parse.go"
         break
-      case 252: /* cmd ::= PRAGMA nm dbnm EQ minus_num */
-//line 1540 "parse.y"
+      case 255: /* cmd ::= PRAGMA nm dbnm EQ minus_num */
+//line 1666 "parse.y"
 {sqlite3Pragma(pParse,&yypParser.yystack[yypParser.yytos+ -3].minor.yy0,&yypParser.yystack[yypParser.yytos+ -2].minor.yy0,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0,1);}
-//line 5165 "parse.go"
+//line 5324 "parse.go"
         break
-      case 253: /* cmd ::= PRAGMA nm dbnm LP minus_num RP */
-//line 1542 "parse.y"
+      case 256: /* cmd ::= PRAGMA nm dbnm LP minus_num RP */
+//line 1668 "parse.y"
 {sqlite3Pragma(pParse,&yypParser.yystack[yypParser.yytos+ -4].minor.yy0,&yypParser.yystack[yypParser.yytos+ -3].minor.yy0,&yypParser.yystack[yypParser.yytos+ -1].minor.yy0,1);}
-//line 5170 "parse.go"
+//line 5329 "parse.go"
         break
-      case 256: /* cmd ::= createkw trigger_decl BEGIN trigger_cmd_list END */
-//line 1558 "parse.y"
+      case 259: /* cmd ::= createkw trigger_decl BEGIN trigger_cmd_list END */
+//line 1684 "parse.y"
 {
   var all Token;
   all.z = yypParser.yystack[yypParser.yytos+ -3].minor.yy0.z;
-  all.n = int((yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z - yypParser.yystack[yypParser.yytos+ -3].minor.yy0.z) + yypParser.yystack[yypParser.yytos+ 0].minor.yy0.n);
+  all.n = uint(len(yypParser.yystack[yypParser.yytos+ -3].minor.yy0.z)-len(yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z)) + yypParser.yystack[yypParser.yytos+ 0].minor.yy0.n;
   sqlite3FinishTrigger(pParse, yypParser.yystack[yypParser.yytos+ -1].minor.yy429, &all);
 }
-//line 5180 "parse.go"
+//line 5339 "parse.go"
         break
-      case 257: /* trigger_decl ::= temp TRIGGER ifnotexists nm dbnm trigger_time trigger_event ON fullname foreach_clause when_clause */
-//line 1567 "parse.y"
+      case 260: /* trigger_decl ::= temp TRIGGER ifnotexists nm dbnm trigger_time trigger_event ON fullname foreach_clause when_clause */
+//line 1693 "parse.y"
 {
   sqlite3BeginTrigger(pParse, &yypParser.yystack[yypParser.yytos+ -7].minor.yy0, &yypParser.yystack[yypParser.yytos+ -6].minor.yy0, yypParser.yystack[yypParser.yytos+ -5].minor.yy394, yypParser.yystack[yypParser.yytos+ -4].minor.yy121.a, yypParser.yystack[yypParser.yytos+ -4].minor.yy121.b, yypParser.yystack[yypParser.yytos+ -2].minor.yy157, yypParser.yystack[yypParser.yytos+ 0].minor.yy634, yypParser.yystack[yypParser.yytos+ -10].minor.yy394, yypParser.yystack[yypParser.yytos+ -8].minor.yy394);
   if (yypParser.yystack[yypParser.yytos+ -6].minor.yy0.n==0) {
@@ -5155,571 +5347,596 @@ func (yypParser *yyParser) yy_reduce(
     yypParser.yystack[yypParser.yytos+ -10].minor.yy0 = yypParser.yystack[yypParser.yytos+ -6].minor.yy0;
   } /*A-overwrites-T*/
 }
-//line 5192 "parse.go"
+//line 5351 "parse.go"
         break
-      case 258: /* trigger_time ::= BEFORE|AFTER */
-//line 1577 "parse.y"
-{ yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = yypParser.yystack[yypParser.yytos+ 0].major; /*A-overwrites-X*/ }
-//line 5197 "parse.go"
+      case 261: /* trigger_time ::= BEFORE|AFTER */
+//line 1703 "parse.y"
+{ yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = int(yypParser.yystack[yypParser.yytos+ 0].major); /*A-overwrites-X*/ }
+//line 5356 "parse.go"
         break
-      case 259: /* trigger_time ::= INSTEAD OF */
-//line 1578 "parse.y"
+      case 262: /* trigger_time ::= INSTEAD OF */
+//line 1704 "parse.y"
 { yypParser.yystack[yypParser.yytos+ -1].minor.yy394 = TK_INSTEAD;}
-//line 5202 "parse.go"
+//line 5361 "parse.go"
         break
-      case 260: /* trigger_time ::= */
-//line 1579 "parse.y"
+      case 263: /* trigger_time ::= */
+//line 1705 "parse.y"
 { yypParser.yystack[yypParser.yytos+ 1].minor.yy394 = TK_BEFORE; }
-//line 5207 "parse.go"
+//line 5366 "parse.go"
         break
-      case 261: /* trigger_event ::= DELETE|INSERT */
+      case 264: /* trigger_event ::= DELETE|INSERT */
         fallthrough
-      case 262: /* trigger_event ::= UPDATE */ yytestcase(yyruleno==262);
-//line 1583 "parse.y"
-{yypParser.yystack[yypParser.yytos+ 0].minor.yy121.a = yypParser.yystack[yypParser.yytos+ 0].major; /*A-overwrites-X*/ yypParser.yystack[yypParser.yytos+ 0].minor.yy121.b = 0;}
-//line 5214 "parse.go"
+      case 265: /* trigger_event ::= UPDATE */ yytestcase(yyruleno==265);
+//line 1709 "parse.y"
+{yypParser.yystack[yypParser.yytos+ 0].minor.yy121.a = int(yypParser.yystack[yypParser.yytos+ 0].major); /*A-overwrites-X*/ yypParser.yystack[yypParser.yytos+ 0].minor.yy121.b = nil;}
+//line 5373 "parse.go"
         break
-      case 263: /* trigger_event ::= UPDATE OF idlist */
-//line 1585 "parse.y"
+      case 266: /* trigger_event ::= UPDATE OF idlist */
+//line 1711 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -2].minor.yy121.a = TK_UPDATE; yypParser.yystack[yypParser.yytos+ -2].minor.yy121.b = yypParser.yystack[yypParser.yytos+ 0].minor.yy106;}
-//line 5219 "parse.go"
+//line 5378 "parse.go"
         break
-      case 264: /* when_clause ::= */
+      case 267: /* when_clause ::= */
         fallthrough
-      case 283: /* key_opt ::= */ yytestcase(yyruleno==283);
-//line 1592 "parse.y"
-{ yypParser.yystack[yypParser.yytos+ 1].minor.yy634 = 0; }
-//line 5226 "parse.go"
+      case 286: /* key_opt ::= */ yytestcase(yyruleno==286);
+//line 1718 "parse.y"
+{ yypParser.yystack[yypParser.yytos+ 1].minor.yy634 = nil; }
+//line 5385 "parse.go"
         break
-      case 265: /* when_clause ::= WHEN expr */
+      case 268: /* when_clause ::= WHEN expr */
         fallthrough
-      case 284: /* key_opt ::= KEY expr */ yytestcase(yyruleno==284);
-//line 1593 "parse.y"
+      case 287: /* key_opt ::= KEY expr */ yytestcase(yyruleno==287);
+//line 1719 "parse.y"
 { yypParser.yystack[yypParser.yytos+ -1].minor.yy634 = yypParser.yystack[yypParser.yytos+ 0].minor.yy634; }
-//line 5233 "parse.go"
+//line 5392 "parse.go"
         break
-      case 266: /* trigger_cmd_list ::= trigger_cmd_list trigger_cmd SEMI */
-//line 1597 "parse.y"
+      case 269: /* trigger_cmd_list ::= trigger_cmd_list trigger_cmd SEMI */
+//line 1723 "parse.y"
 {
-  assert( yypParser.yystack[yypParser.yytos+ -2].minor.yy429!=0, "yypParser.yystack[yypParser.yytos+ -2].minor.yy429!=0");
+  assert( yypParser.yystack[yypParser.yytos+ -2].minor.yy429!=nil, "yypParser.yystack[yypParser.yytos+ -2].minor.yy429!=nil");
   yypParser.yystack[yypParser.yytos+ -2].minor.yy429.pLast.pNext = yypParser.yystack[yypParser.yytos+ -1].minor.yy429;
   yypParser.yystack[yypParser.yytos+ -2].minor.yy429.pLast = yypParser.yystack[yypParser.yytos+ -1].minor.yy429;
 }
-//line 5242 "parse.go"
+//line 5401 "parse.go"
         break
-      case 267: /* trigger_cmd_list ::= trigger_cmd SEMI */
-//line 1602 "parse.y"
+      case 270: /* trigger_cmd_list ::= trigger_cmd SEMI */
+//line 1728 "parse.y"
 { 
-  assert( yypParser.yystack[yypParser.yytos+ -1].minor.yy429!=0, "yypParser.yystack[yypParser.yytos+ -1].minor.yy429!=0");
+  assert( yypParser.yystack[yypParser.yytos+ -1].minor.yy429!=nil, "yypParser.yystack[yypParser.yytos+ -1].minor.yy429!=nil");
   yypParser.yystack[yypParser.yytos+ -1].minor.yy429.pLast = yypParser.yystack[yypParser.yytos+ -1].minor.yy429;
 }
-//line 5250 "parse.go"
+//line 5409 "parse.go"
         break
-      case 268: /* trnm ::= nm DOT nm */
-//line 1613 "parse.y"
+      case 271: /* trnm ::= nm DOT nm */
+//line 1739 "parse.y"
 {
   yypParser.yystack[yypParser.yytos+ -2].minor.yy0 = yypParser.yystack[yypParser.yytos+ 0].minor.yy0;
   sqlite3ErrorMsg(pParse, 
         "qualified table names are not allowed on INSERT, UPDATE, and DELETE " +
         "statements within triggers");
 }
-//line 5260 "parse.go"
+//line 5419 "parse.go"
         break
-      case 269: /* tridxby ::= INDEXED BY nm */
-//line 1625 "parse.y"
+      case 272: /* tridxby ::= INDEXED BY nm */
+//line 1751 "parse.y"
 {
   sqlite3ErrorMsg(pParse,
         "the INDEXED BY clause is not allowed on UPDATE or DELETE statements " +
         "within triggers");
 }
-//line 5269 "parse.go"
+//line 5428 "parse.go"
         break
-      case 270: /* tridxby ::= NOT INDEXED */
-//line 1630 "parse.y"
+      case 273: /* tridxby ::= NOT INDEXED */
+//line 1756 "parse.y"
 {
   sqlite3ErrorMsg(pParse,
         "the NOT INDEXED clause is not allowed on UPDATE or DELETE statements " +
         "within triggers");
 }
-//line 5278 "parse.go"
+//line 5437 "parse.go"
         break
-      case 271: /* trigger_cmd ::= UPDATE orconf trnm tridxby SET setlist from where_opt scanpt */
-//line 1643 "parse.y"
+      case 274: /* trigger_cmd ::= UPDATE orconf trnm tridxby SET setlist from where_opt scanpt */
+//line 1769 "parse.y"
 {yylhsminor.yy429 = sqlite3TriggerUpdateStep(pParse, &yypParser.yystack[yypParser.yytos+ -6].minor.yy0, yypParser.yystack[yypParser.yytos+ -2].minor.yy157, yypParser.yystack[yypParser.yytos+ -3].minor.yy614, yypParser.yystack[yypParser.yytos+ -1].minor.yy634, yypParser.yystack[yypParser.yytos+ -7].minor.yy394, yypParser.yystack[yypParser.yytos+ -8].minor.yy0.z, yypParser.yystack[yypParser.yytos+ 0].minor.yy79);}
-//line 5283 "parse.go"
+//line 5442 "parse.go"
   yypParser.yystack[yypParser.yytos+ -8].minor.yy429 = yylhsminor.yy429;
         break
-      case 272: /* trigger_cmd ::= scanpt insert_cmd INTO trnm idlist_opt select upsert scanpt */
-//line 1647 "parse.y"
+      case 275: /* trigger_cmd ::= scanpt insert_cmd INTO trnm idlist_opt select upsert scanpt */
+//line 1773 "parse.y"
 {
    yylhsminor.yy429 = sqlite3TriggerInsertStep(pParse,&yypParser.yystack[yypParser.yytos+ -4].minor.yy0,yypParser.yystack[yypParser.yytos+ -3].minor.yy106,yypParser.yystack[yypParser.yytos+ -2].minor.yy361,yypParser.yystack[yypParser.yytos+ -6].minor.yy394,yypParser.yystack[yypParser.yytos+ -1].minor.yy442,yypParser.yystack[yypParser.yytos+ -7].minor.yy79,yypParser.yystack[yypParser.yytos+ 0].minor.yy79);/*yylhsminor.yy429-overwrites-yypParser.yystack[yypParser.yytos+ -6].minor.yy394*/
 }
-//line 5291 "parse.go"
+//line 5450 "parse.go"
   yypParser.yystack[yypParser.yytos+ -7].minor.yy429 = yylhsminor.yy429;
         break
-      case 273: /* trigger_cmd ::= DELETE FROM trnm tridxby where_opt scanpt */
-//line 1652 "parse.y"
+      case 276: /* trigger_cmd ::= DELETE FROM trnm tridxby where_opt scanpt */
+//line 1778 "parse.y"
 {yylhsminor.yy429 = sqlite3TriggerDeleteStep(pParse, &yypParser.yystack[yypParser.yytos+ -3].minor.yy0, yypParser.yystack[yypParser.yytos+ -1].minor.yy634, yypParser.yystack[yypParser.yytos+ -5].minor.yy0.z, yypParser.yystack[yypParser.yytos+ 0].minor.yy79);}
-//line 5297 "parse.go"
+//line 5456 "parse.go"
   yypParser.yystack[yypParser.yytos+ -5].minor.yy429 = yylhsminor.yy429;
         break
-      case 274: /* trigger_cmd ::= scanpt select scanpt */
-//line 1656 "parse.y"
+      case 277: /* trigger_cmd ::= scanpt select scanpt */
+//line 1782 "parse.y"
 {yylhsminor.yy429 = sqlite3TriggerSelectStep(pParse.db, yypParser.yystack[yypParser.yytos+ -1].minor.yy361, yypParser.yystack[yypParser.yytos+ -2].minor.yy79, yypParser.yystack[yypParser.yytos+ 0].minor.yy79); /*yylhsminor.yy429-overwrites-yypParser.yystack[yypParser.yytos+ -1].minor.yy361*/}
-//line 5303 "parse.go"
+//line 5462 "parse.go"
   yypParser.yystack[yypParser.yytos+ -2].minor.yy429 = yylhsminor.yy429;
         break
-      case 275: /* expr ::= RAISE LP IGNORE RP */
-//line 1659 "parse.y"
+      case 278: /* expr ::= RAISE LP IGNORE RP */
+//line 1785 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -3].minor.yy634 = sqlite3PExpr(pParse, TK_RAISE, 0, 0); 
-  if( yypParser.yystack[yypParser.yytos+ -3].minor.yy634 ){
-    yypParser.yystack[yypParser.yytos+ -3].minor.yy634.affExpr = OE_Ignore;
+  yylhsminor.yy634 = sqlite3PExpr(pParse, TK_RAISE, nil, nil); 
+  if( yylhsminor.yy634!=nil ){
+    yylhsminor.yy634.affExpr = OE_Ignore;
   }
+  exprSpan(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ -3].minor.yy0.z);
 }
-//line 5314 "parse.go"
+//line 5474 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -3].minor.yy634 = yylhsminor.yy634;
         break
-      case 276: /* expr ::= RAISE LP raisetype COMMA nm RP */
-//line 1665 "parse.y"
+      case 279: /* expr ::= RAISE LP raisetype COMMA nm RP */
+//line 1792 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -5].minor.yy634 = sqlite3ExprAlloc(pParse.db, TK_RAISE, &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, 1);
-  if( yypParser.yystack[yypParser.yytos+ -5].minor.yy634 ) {
-    yypParser.yystack[yypParser.yytos+ -5].minor.yy634.affExpr = rune(yypParser.yystack[yypParser.yytos+ -3].minor.yy394);
+  yylhsminor.yy634 = sqlite3ExprAlloc(pParse.db, TK_RAISE, &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, 1);
+  if( yylhsminor.yy634!=nil ) {
+    yylhsminor.yy634.affExpr = rune(yypParser.yystack[yypParser.yytos+ -3].minor.yy394);
   }
+  exprSpan(pParse, yylhsminor.yy634, yypParser.yystack[yypParser.yytos+ -5].minor.yy0.z);
 }
-//line 5324 "parse.go"
+//line 5486 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -5].minor.yy634 = yylhsminor.yy634;
         break
-      case 277: /* raisetype ::= ROLLBACK */
-//line 1674 "parse.y"
+      case 280: /* raisetype ::= ROLLBACK */
+//line 1802 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = OE_Rollback;}
-//line 5329 "parse.go"
+//line 5492 "parse.go"
         break
-      case 279: /* raisetype ::= FAIL */
-//line 1676 "parse.y"
+      case 282: /* raisetype ::= FAIL */
+//line 1804 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = OE_Fail;}
-//line 5334 "parse.go"
+//line 5497 "parse.go"
         break
-      case 280: /* cmd ::= DROP TRIGGER ifexists fullname */
-//line 1681 "parse.y"
+      case 283: /* cmd ::= DROP TRIGGER ifexists fullname */
+//line 1809 "parse.y"
 {
   sqlite3DropTrigger(pParse,yypParser.yystack[yypParser.yytos+ 0].minor.yy157,yypParser.yystack[yypParser.yytos+ -1].minor.yy394);
 }
-//line 5341 "parse.go"
+//line 5504 "parse.go"
         break
-      case 281: /* cmd ::= ATTACH database_kw_opt expr AS expr key_opt */
-//line 1688 "parse.y"
+      case 284: /* cmd ::= ATTACH database_kw_opt expr AS expr key_opt */
+//line 1816 "parse.y"
 {
   sqlite3Attach(pParse, yypParser.yystack[yypParser.yytos+ -3].minor.yy634, yypParser.yystack[yypParser.yytos+ -1].minor.yy634, yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
 }
-//line 5348 "parse.go"
+//line 5511 "parse.go"
         break
-      case 282: /* cmd ::= DETACH database_kw_opt expr */
-//line 1691 "parse.y"
+      case 285: /* cmd ::= DETACH database_kw_opt expr */
+//line 1819 "parse.y"
 {
   sqlite3Detach(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
 }
-//line 5355 "parse.go"
+//line 5518 "parse.go"
         break
-      case 285: /* cmd ::= REINDEX */
-//line 1706 "parse.y"
-{sqlite3Reindex(pParse, 0, 0);}
-//line 5360 "parse.go"
+      case 288: /* cmd ::= REINDEX */
+//line 1834 "parse.y"
+{sqlite3Reindex(pParse, nil, nil);}
+//line 5523 "parse.go"
         break
-      case 286: /* cmd ::= REINDEX nm dbnm */
-//line 1707 "parse.y"
+      case 289: /* cmd ::= REINDEX nm dbnm */
+//line 1835 "parse.y"
 {sqlite3Reindex(pParse, &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);}
-//line 5365 "parse.go"
+//line 5528 "parse.go"
         break
-      case 287: /* cmd ::= ANALYZE */
-//line 1712 "parse.y"
-{sqlite3Analyze(pParse, 0, 0);}
-//line 5370 "parse.go"
+      case 290: /* cmd ::= ANALYZE */
+//line 1840 "parse.y"
+{sqlite3Analyze(pParse, nil, nil);}
+//line 5533 "parse.go"
         break
-      case 288: /* cmd ::= ANALYZE nm dbnm */
-//line 1713 "parse.y"
+      case 291: /* cmd ::= ANALYZE nm dbnm */
+//line 1841 "parse.y"
 {sqlite3Analyze(pParse, &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);}
-//line 5375 "parse.go"
+//line 5538 "parse.go"
         break
-      case 289: /* cmd ::= ALTER TABLE fullname RENAME TO nm */
-//line 1719 "parse.y"
+      case 292: /* cmd ::= ALTER TABLE fullname RENAME TO nm */
+//line 1847 "parse.y"
 {
   sqlite3AlterRenameTable(pParse,yypParser.yystack[yypParser.yytos+ -3].minor.yy157,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
 }
-//line 5382 "parse.go"
+//line 5545 "parse.go"
         break
-      case 290: /* cmd ::= ALTER TABLE add_column_fullname ADD kwcolumn_opt columnname carglist */
-//line 1723 "parse.y"
+      case 293: /* cmd ::= ALTER TABLE add_column_fullname ADD kwcolumn_opt columnname carglist */
+//line 1851 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -1].minor.yy0.n = (int)(pParse.sLastToken.z-yypParser.yystack[yypParser.yytos+ -1].minor.yy0.z) + pParse.sLastToken.n;
+  yypParser.yystack[yypParser.yytos+ -1].minor.yy0.n = uint(len(yypParser.yystack[yypParser.yytos+ -1].minor.yy0.z)-len(pParse.sLastToken.z)) + pParse.sLastToken.n;
   sqlite3AlterFinishAddColumn(pParse, &yypParser.yystack[yypParser.yytos+ -1].minor.yy0);
 }
-//line 5390 "parse.go"
+//line 5553 "parse.go"
         break
-      case 291: /* cmd ::= ALTER TABLE fullname DROP kwcolumn_opt nm */
-//line 1727 "parse.y"
+      case 294: /* cmd ::= ALTER TABLE fullname DROP kwcolumn_opt nm */
+//line 1855 "parse.y"
 {
+  sqlite3CheckVersion(pParse, 3035000, "DROP COLUMN", &yypParser.yystack[yypParser.yytos+ -2].minor.yy0, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
   sqlite3AlterDropColumn(pParse, yypParser.yystack[yypParser.yytos+ -3].minor.yy157, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
 }
-//line 5397 "parse.go"
+//line 5561 "parse.go"
         break
-      case 292: /* add_column_fullname ::= fullname */
-//line 1731 "parse.y"
+      case 295: /* add_column_fullname ::= fullname */
+//line 1860 "parse.y"
 {
   disableLookaside(pParse);
   sqlite3AlterBeginAddColumn(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy157);
 }
-//line 5405 "parse.go"
+//line 5569 "parse.go"
         break
-      case 293: /* cmd ::= ALTER TABLE fullname RENAME kwcolumn_opt nm TO nm */
-//line 1735 "parse.y"
+      case 296: /* cmd ::= ALTER TABLE fullname RENAME kwcolumn_opt nm TO nm */
+//line 1864 "parse.y"
 {
+  sqlite3CheckVersion(pParse, 3025000, "RENAME COLUMN", &yypParser.yystack[yypParser.yytos+ -4].minor.yy0, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
   sqlite3AlterRenameColumn(pParse, yypParser.yystack[yypParser.yytos+ -5].minor.yy157, &yypParser.yystack[yypParser.yytos+ -2].minor.yy0, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
 }
-//line 5412 "parse.go"
+//line 5577 "parse.go"
         break
-      case 294: /* cmd ::= create_vtab */
-//line 1747 "parse.y"
-{sqlite3VtabFinishParse(pParse,0);}
-//line 5417 "parse.go"
+      case 297: /* cmd ::= create_vtab */
+//line 1877 "parse.y"
+{sqlite3VtabFinishParse(pParse,nil);}
+//line 5582 "parse.go"
         break
-      case 295: /* cmd ::= create_vtab LP vtabarglist RP */
-//line 1748 "parse.y"
+      case 298: /* cmd ::= create_vtab LP vtabarglist RP */
+//line 1878 "parse.y"
 {sqlite3VtabFinishParse(pParse,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0);}
-//line 5422 "parse.go"
+//line 5587 "parse.go"
         break
-      case 296: /* create_vtab ::= createkw VIRTUAL TABLE ifnotexists nm dbnm USING nm */
-//line 1750 "parse.y"
+      case 299: /* create_vtab ::= createkw VIRTUAL TABLE ifnotexists nm dbnm USING nm */
+//line 1880 "parse.y"
 {
     sqlite3VtabBeginParse(pParse, &yypParser.yystack[yypParser.yytos+ -3].minor.yy0, &yypParser.yystack[yypParser.yytos+ -2].minor.yy0, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0, yypParser.yystack[yypParser.yytos+ -4].minor.yy394);
 }
-//line 5429 "parse.go"
+//line 5594 "parse.go"
         break
-      case 297: /* vtabarg ::= */
-//line 1755 "parse.y"
+      case 300: /* vtabarg ::= */
+//line 1885 "parse.y"
 {sqlite3VtabArgInit(pParse);}
-//line 5434 "parse.go"
+//line 5599 "parse.go"
         break
-      case 298: /* vtabargtoken ::= ANY */
+      case 301: /* vtabargtoken ::= ANY */
         fallthrough
-      case 299: /* vtabargtoken ::= lp anylist RP */ yytestcase(yyruleno==299);
+      case 302: /* vtabargtoken ::= lp anylist RP */ yytestcase(yyruleno==302);
         fallthrough
-      case 300: /* lp ::= LP */ yytestcase(yyruleno==300);
-//line 1757 "parse.y"
+      case 303: /* lp ::= LP */ yytestcase(yyruleno==303);
+//line 1887 "parse.y"
 {sqlite3VtabArgExtend(pParse,&yypParser.yystack[yypParser.yytos+ 0].minor.yy0);}
-//line 5443 "parse.go"
+//line 5608 "parse.go"
         break
-      case 301: /* with ::= WITH wqlist */
+      case 304: /* with ::= WITH wqlist */
         fallthrough
-      case 302: /* with ::= WITH RECURSIVE wqlist */ yytestcase(yyruleno==302);
-//line 1774 "parse.y"
+      case 305: /* with ::= WITH RECURSIVE wqlist */ yytestcase(yyruleno==305);
+//line 1904 "parse.y"
 { sqlite3WithPush(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy357, 1); }
-//line 5450 "parse.go"
+//line 5615 "parse.go"
         break
-      case 303: /* wqas ::= AS */
-//line 1778 "parse.y"
+      case 306: /* wqas ::= AS */
+//line 1908 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 0].minor.yy109 = M10d_Any;}
-//line 5455 "parse.go"
+//line 5620 "parse.go"
         break
-      case 304: /* wqas ::= AS MATERIALIZED */
-//line 1779 "parse.y"
-{yypParser.yystack[yypParser.yytos+ -1].minor.yy109 = M10d_Yes;}
-//line 5460 "parse.go"
+      case 307: /* wqas ::= AS MATERIALIZED */
+//line 1909 "parse.y"
+{
+  sqlite3CheckVersion(pParse, 3035000, "MATERIALIZED", &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
+  yylhsminor.yy109 = M10d_Yes;
+}
+//line 5628 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -1].minor.yy109 = yylhsminor.yy109;
         break
-      case 305: /* wqas ::= AS NOT MATERIALIZED */
-//line 1780 "parse.y"
-{yypParser.yystack[yypParser.yytos+ -2].minor.yy109 = M10d_No;}
-//line 5465 "parse.go"
+      case 308: /* wqas ::= AS NOT MATERIALIZED */
+//line 1913 "parse.y"
+{
+  sqlite3CheckVersion(pParse, 3035000, "NOT MATERIALIZED", &yypParser.yystack[yypParser.yytos+ -2].minor.yy0, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
+  yylhsminor.yy109 = M10d_No;
+}
+//line 5637 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -2].minor.yy109 = yylhsminor.yy109;
         break
-      case 306: /* wqitem ::= nm eidlist_opt wqas LP select RP */
-//line 1781 "parse.y"
+      case 309: /* wqitem ::= nm eidlist_opt wqas LP select RP */
+//line 1917 "parse.y"
 {
   yypParser.yystack[yypParser.yytos+ -5].minor.yy297 = sqlite3CteNew(pParse, &yypParser.yystack[yypParser.yytos+ -5].minor.yy0, yypParser.yystack[yypParser.yytos+ -4].minor.yy614, yypParser.yystack[yypParser.yytos+ -1].minor.yy361, yypParser.yystack[yypParser.yytos+ -3].minor.yy109); /*A-overwrites-X*/
 }
-//line 5472 "parse.go"
+//line 5645 "parse.go"
         break
-      case 307: /* wqlist ::= wqitem */
-//line 1784 "parse.y"
+      case 310: /* wqlist ::= wqitem */
+//line 1920 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ 0].minor.yy357 = sqlite3WithAdd(pParse, 0, yypParser.yystack[yypParser.yytos+ 0].minor.yy297); /*A-overwrites-X*/
+  yypParser.yystack[yypParser.yytos+ 0].minor.yy357 = sqlite3WithAdd(pParse, nil, yypParser.yystack[yypParser.yytos+ 0].minor.yy297); /*A-overwrites-X*/
 }
-//line 5479 "parse.go"
+//line 5652 "parse.go"
         break
-      case 308: /* wqlist ::= wqlist COMMA wqitem */
-//line 1787 "parse.y"
+      case 311: /* wqlist ::= wqlist COMMA wqitem */
+//line 1923 "parse.y"
 {
   yypParser.yystack[yypParser.yytos+ -2].minor.yy357 = sqlite3WithAdd(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy357, yypParser.yystack[yypParser.yytos+ 0].minor.yy297);
 }
-//line 5486 "parse.go"
+//line 5659 "parse.go"
         break
-      case 309: /* windowdefn_list ::= windowdefn */
-//line 1801 "parse.y"
+      case 312: /* windowdefn_list ::= windowdefn */
+//line 1937 "parse.y"
 { yylhsminor.yy179 = yypParser.yystack[yypParser.yytos+ 0].minor.yy179; }
-//line 5491 "parse.go"
+//line 5664 "parse.go"
   yypParser.yystack[yypParser.yytos+ 0].minor.yy179 = yylhsminor.yy179;
         break
-      case 310: /* windowdefn_list ::= windowdefn_list COMMA windowdefn */
-//line 1802 "parse.y"
+      case 313: /* windowdefn_list ::= windowdefn_list COMMA windowdefn */
+//line 1938 "parse.y"
 {
-  assert( yypParser.yystack[yypParser.yytos+ 0].minor.yy179!=0, "yypParser.yystack[yypParser.yytos+ 0].minor.yy179!=0");
+  assert( yypParser.yystack[yypParser.yytos+ 0].minor.yy179!=nil, "yypParser.yystack[yypParser.yytos+ 0].minor.yy179!=nil");
   sqlite3WindowChain(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy179, yypParser.yystack[yypParser.yytos+ -2].minor.yy179);
   yypParser.yystack[yypParser.yytos+ 0].minor.yy179.pNextWin = yypParser.yystack[yypParser.yytos+ -2].minor.yy179;
   yylhsminor.yy179 = yypParser.yystack[yypParser.yytos+ 0].minor.yy179;
 }
-//line 5502 "parse.go"
+//line 5675 "parse.go"
   yypParser.yystack[yypParser.yytos+ -2].minor.yy179 = yylhsminor.yy179;
         break
-      case 311: /* windowdefn ::= nm AS LP window RP */
-//line 1811 "parse.y"
+      case 314: /* windowdefn ::= nm AS LP window RP */
+//line 1947 "parse.y"
 {
-  if( ALWAYS(yypParser.yystack[yypParser.yytos+ -1].minor.yy179) ){
+  if( ALWAYS(yypParser.yystack[yypParser.yytos+ -1].minor.yy179!=nil) ){
     yypParser.yystack[yypParser.yytos+ -1].minor.yy179.zName = sqlite3DbStrNDup(pParse.db, yypParser.yystack[yypParser.yytos+ -4].minor.yy0.z, yypParser.yystack[yypParser.yytos+ -4].minor.yy0.n);
   }
   yylhsminor.yy179 = yypParser.yystack[yypParser.yytos+ -1].minor.yy179;
 }
-//line 5513 "parse.go"
+//line 5686 "parse.go"
   yypParser.yystack[yypParser.yytos+ -4].minor.yy179 = yylhsminor.yy179;
         break
-      case 312: /* window ::= PARTITION BY nexprlist orderby_opt frame_opt */
-//line 1845 "parse.y"
+      case 315: /* window ::= PARTITION BY nexprlist orderby_opt frame_opt */
+//line 1981 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -4].minor.yy179 = sqlite3WindowAssemble(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy179, yypParser.yystack[yypParser.yytos+ -2].minor.yy614, yypParser.yystack[yypParser.yytos+ -1].minor.yy614, 0);
+  yypParser.yystack[yypParser.yytos+ -4].minor.yy179 = sqlite3WindowAssemble(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy179, yypParser.yystack[yypParser.yytos+ -2].minor.yy614, yypParser.yystack[yypParser.yytos+ -1].minor.yy614, nil);
 }
-//line 5521 "parse.go"
+//line 5694 "parse.go"
         break
-      case 313: /* window ::= nm PARTITION BY nexprlist orderby_opt frame_opt */
-//line 1848 "parse.y"
+      case 316: /* window ::= nm PARTITION BY nexprlist orderby_opt frame_opt */
+//line 1984 "parse.y"
 {
   yylhsminor.yy179 = sqlite3WindowAssemble(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy179, yypParser.yystack[yypParser.yytos+ -2].minor.yy614, yypParser.yystack[yypParser.yytos+ -1].minor.yy614, &yypParser.yystack[yypParser.yytos+ -5].minor.yy0);
 }
-//line 5528 "parse.go"
+//line 5701 "parse.go"
   yypParser.yystack[yypParser.yytos+ -5].minor.yy179 = yylhsminor.yy179;
         break
-      case 314: /* window ::= ORDER BY sortlist frame_opt */
-//line 1851 "parse.y"
+      case 317: /* window ::= ORDER BY sortlist frame_opt */
+//line 1987 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -3].minor.yy179 = sqlite3WindowAssemble(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy179, 0, yypParser.yystack[yypParser.yytos+ -1].minor.yy614, 0);
+  yypParser.yystack[yypParser.yytos+ -3].minor.yy179 = sqlite3WindowAssemble(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy179, nil, yypParser.yystack[yypParser.yytos+ -1].minor.yy614, nil);
 }
-//line 5536 "parse.go"
+//line 5709 "parse.go"
         break
-      case 315: /* window ::= nm ORDER BY sortlist frame_opt */
-//line 1854 "parse.y"
+      case 318: /* window ::= nm ORDER BY sortlist frame_opt */
+//line 1990 "parse.y"
 {
-  yylhsminor.yy179 = sqlite3WindowAssemble(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy179, 0, yypParser.yystack[yypParser.yytos+ -1].minor.yy614, &yypParser.yystack[yypParser.yytos+ -4].minor.yy0);
+  yylhsminor.yy179 = sqlite3WindowAssemble(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy179, nil, yypParser.yystack[yypParser.yytos+ -1].minor.yy614, &yypParser.yystack[yypParser.yytos+ -4].minor.yy0);
 }
-//line 5543 "parse.go"
+//line 5716 "parse.go"
   yypParser.yystack[yypParser.yytos+ -4].minor.yy179 = yylhsminor.yy179;
         break
-      case 316: /* window ::= frame_opt */
+      case 319: /* window ::= frame_opt */
         fallthrough
-      case 335: /* filter_over ::= over_clause */ yytestcase(yyruleno==335);
-//line 1857 "parse.y"
+      case 338: /* filter_over ::= over_clause */ yytestcase(yyruleno==338);
+//line 1993 "parse.y"
 {
   yylhsminor.yy179 = yypParser.yystack[yypParser.yytos+ 0].minor.yy179;
 }
-//line 5553 "parse.go"
+//line 5726 "parse.go"
   yypParser.yystack[yypParser.yytos+ 0].minor.yy179 = yylhsminor.yy179;
         break
-      case 317: /* window ::= nm frame_opt */
-//line 1860 "parse.y"
+      case 320: /* window ::= nm frame_opt */
+//line 1996 "parse.y"
 {
-  yylhsminor.yy179 = sqlite3WindowAssemble(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy179, 0, 0, &yypParser.yystack[yypParser.yytos+ -1].minor.yy0);
+  yylhsminor.yy179 = sqlite3WindowAssemble(pParse, yypParser.yystack[yypParser.yytos+ 0].minor.yy179, nil, nil, &yypParser.yystack[yypParser.yytos+ -1].minor.yy0);
 }
-//line 5561 "parse.go"
+//line 5734 "parse.go"
   yypParser.yystack[yypParser.yytos+ -1].minor.yy179 = yylhsminor.yy179;
         break
-      case 318: /* frame_opt ::= */
-//line 1864 "parse.y"
+      case 321: /* frame_opt ::= */
+//line 2000 "parse.y"
 { 
-  yypParser.yystack[yypParser.yytos+ 1].minor.yy179 = sqlite3WindowAlloc(pParse, 0, TK_UNBOUNDED, 0, TK_CURRENT, 0, 0);
+  yypParser.yystack[yypParser.yytos+ 1].minor.yy179 = sqlite3WindowAlloc(pParse, 0, TK_UNBOUNDED, nil, TK_CURRENT, nil, 0);
 }
-//line 5569 "parse.go"
+//line 5742 "parse.go"
         break
-      case 319: /* frame_opt ::= range_or_rows frame_bound_s frame_exclude_opt */
-//line 1867 "parse.y"
+      case 322: /* frame_opt ::= range_or_rows frame_bound_s frame_exclude_opt */
+//line 2003 "parse.y"
 { 
-  yylhsminor.yy179 = sqlite3WindowAlloc(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy394, yypParser.yystack[yypParser.yytos+ -1].minor.yy600.eType, yypParser.yystack[yypParser.yytos+ -1].minor.yy600.pExpr, TK_CURRENT, 0, yypParser.yystack[yypParser.yytos+ 0].minor.yy109);
+  yylhsminor.yy179 = sqlite3WindowAlloc(pParse, yypParser.yystack[yypParser.yytos+ -2].minor.yy394, yypParser.yystack[yypParser.yytos+ -1].minor.yy600.eType, yypParser.yystack[yypParser.yytos+ -1].minor.yy600.pExpr, TK_CURRENT, nil, yypParser.yystack[yypParser.yytos+ 0].minor.yy109);
 }
-//line 5576 "parse.go"
+//line 5749 "parse.go"
   yypParser.yystack[yypParser.yytos+ -2].minor.yy179 = yylhsminor.yy179;
         break
-      case 320: /* frame_opt ::= range_or_rows BETWEEN frame_bound_s AND frame_bound_e frame_exclude_opt */
-//line 1871 "parse.y"
+      case 323: /* frame_opt ::= range_or_rows BETWEEN frame_bound_s AND frame_bound_e frame_exclude_opt */
+//line 2007 "parse.y"
 { 
   yylhsminor.yy179 = sqlite3WindowAlloc(pParse, yypParser.yystack[yypParser.yytos+ -5].minor.yy394, yypParser.yystack[yypParser.yytos+ -3].minor.yy600.eType, yypParser.yystack[yypParser.yytos+ -3].minor.yy600.pExpr, yypParser.yystack[yypParser.yytos+ -1].minor.yy600.eType, yypParser.yystack[yypParser.yytos+ -1].minor.yy600.pExpr, yypParser.yystack[yypParser.yytos+ 0].minor.yy109);
 }
-//line 5584 "parse.go"
+//line 5757 "parse.go"
   yypParser.yystack[yypParser.yytos+ -5].minor.yy179 = yylhsminor.yy179;
         break
-      case 321: /* range_or_rows ::= RANGE|ROWS|GROUPS */
-//line 1875 "parse.y"
-{yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = yypParser.yystack[yypParser.yytos+ 0].major; /*A-overwrites-X*/}
-//line 5590 "parse.go"
+      case 324: /* range_or_rows ::= RANGE|ROWS|GROUPS */
+//line 2011 "parse.y"
+{yypParser.yystack[yypParser.yytos+ 0].minor.yy394 = int(yypParser.yystack[yypParser.yytos+ 0].major); /*A-overwrites-X*/}
+//line 5763 "parse.go"
         break
-      case 322: /* frame_bound_s ::= frame_bound */
+      case 325: /* frame_bound_s ::= frame_bound */
         fallthrough
-      case 324: /* frame_bound_e ::= frame_bound */ yytestcase(yyruleno==324);
-//line 1877 "parse.y"
+      case 327: /* frame_bound_e ::= frame_bound */ yytestcase(yyruleno==327);
+//line 2013 "parse.y"
 {yylhsminor.yy600 = yypParser.yystack[yypParser.yytos+ 0].minor.yy600;}
-//line 5597 "parse.go"
+//line 5770 "parse.go"
   yypParser.yystack[yypParser.yytos+ 0].minor.yy600 = yylhsminor.yy600;
         break
-      case 323: /* frame_bound_s ::= UNBOUNDED PRECEDING */
+      case 326: /* frame_bound_s ::= UNBOUNDED PRECEDING */
         fallthrough
-      case 325: /* frame_bound_e ::= UNBOUNDED FOLLOWING */ yytestcase(yyruleno==325);
+      case 328: /* frame_bound_e ::= UNBOUNDED FOLLOWING */ yytestcase(yyruleno==328);
         fallthrough
-      case 327: /* frame_bound ::= CURRENT ROW */ yytestcase(yyruleno==327);
-//line 1878 "parse.y"
-{yylhsminor.yy600.eType = yypParser.yystack[yypParser.yytos+ -1].major; yylhsminor.yy600.pExpr = 0;}
-//line 5607 "parse.go"
+      case 330: /* frame_bound ::= CURRENT ROW */ yytestcase(yyruleno==330);
+//line 2014 "parse.y"
+{yylhsminor.yy600.eType = int(yypParser.yystack[yypParser.yytos+ -1].major); yylhsminor.yy600.pExpr = nil;}
+//line 5780 "parse.go"
   yypParser.yystack[yypParser.yytos+ -1].minor.yy600 = yylhsminor.yy600;
         break
-      case 326: /* frame_bound ::= expr PRECEDING|FOLLOWING */
-//line 1883 "parse.y"
-{yylhsminor.yy600.eType = yypParser.yystack[yypParser.yytos+ 0].major; yylhsminor.yy600.pExpr = yypParser.yystack[yypParser.yytos+ -1].minor.yy634;}
-//line 5613 "parse.go"
+      case 329: /* frame_bound ::= expr PRECEDING|FOLLOWING */
+//line 2019 "parse.y"
+{yylhsminor.yy600.eType = int(yypParser.yystack[yypParser.yytos+ 0].major); yylhsminor.yy600.pExpr = yypParser.yystack[yypParser.yytos+ -1].minor.yy634;}
+//line 5786 "parse.go"
   yypParser.yystack[yypParser.yytos+ -1].minor.yy600 = yylhsminor.yy600;
         break
-      case 328: /* frame_exclude_opt ::= */
-//line 1887 "parse.y"
+      case 331: /* frame_exclude_opt ::= */
+//line 2023 "parse.y"
 {yypParser.yystack[yypParser.yytos+ 1].minor.yy109 = 0;}
-//line 5619 "parse.go"
+//line 5792 "parse.go"
         break
-      case 329: /* frame_exclude_opt ::= EXCLUDE frame_exclude */
-//line 1888 "parse.y"
+      case 332: /* frame_exclude_opt ::= EXCLUDE frame_exclude */
+//line 2024 "parse.y"
 {yypParser.yystack[yypParser.yytos+ -1].minor.yy109 = yypParser.yystack[yypParser.yytos+ 0].minor.yy109;}
-//line 5624 "parse.go"
+//line 5797 "parse.go"
         break
-      case 330: /* frame_exclude ::= NO OTHERS */
+      case 333: /* frame_exclude ::= NO OTHERS */
         fallthrough
-      case 331: /* frame_exclude ::= CURRENT ROW */ yytestcase(yyruleno==331);
-//line 1891 "parse.y"
-{yypParser.yystack[yypParser.yytos+ -1].minor.yy109 = yypParser.yystack[yypParser.yytos+ -1].major; /*A-overwrites-X*/}
-//line 5631 "parse.go"
-        break
-      case 332: /* frame_exclude ::= GROUP|TIES */
-//line 1893 "parse.y"
-{yypParser.yystack[yypParser.yytos+ 0].minor.yy109 = yypParser.yystack[yypParser.yytos+ 0].major; /*A-overwrites-X*/}
-//line 5636 "parse.go"
-        break
-      case 333: /* window_clause ::= WINDOW windowdefn_list */
-//line 1898 "parse.y"
-{ yypParser.yystack[yypParser.yytos+ -1].minor.yy179 = yypParser.yystack[yypParser.yytos+ 0].minor.yy179; }
-//line 5641 "parse.go"
+      case 334: /* frame_exclude ::= CURRENT ROW */ yytestcase(yyruleno==334);
+//line 2027 "parse.y"
+{yypParser.yystack[yypParser.yytos+ -1].minor.yy109 = uint8(yypParser.yystack[yypParser.yytos+ -1].major); /*A-overwrites-X*/}
+//line 5804 "parse.go"
+        break
+      case 335: /* frame_exclude ::= GROUP|TIES */
+//line 2029 "parse.y"
+{yypParser.yystack[yypParser.yytos+ 0].minor.yy109 = uint8(yypParser.yystack[yypParser.yytos+ 0].major); /*A-overwrites-X*/}
+//line 5809 "parse.go"
+        break
+      case 336: /* window_clause ::= WINDOW windowdefn_list */
+//line 2034 "parse.y"
+{
+  sqlite3CheckVersion(pParse, 3025000, "window functions", &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, nil);
+  yylhsminor.yy179 = yypParser.yystack[yypParser.yytos+ 0].minor.yy179;
+}
+//line 5817 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -1].minor.yy179 = yylhsminor.yy179;
         break
-      case 334: /* filter_over ::= filter_clause over_clause */
-//line 1900 "parse.y"
+      case 337: /* filter_over ::= filter_clause over_clause */
+//line 2039 "parse.y"
 {
-  if( yypParser.yystack[yypParser.yytos+ 0].minor.yy179 ){
+  if( yypParser.yystack[yypParser.yytos+ 0].minor.yy179!=nil ){
     yypParser.yystack[yypParser.yytos+ 0].minor.yy179.pFilter = yypParser.yystack[yypParser.yytos+ -1].minor.yy634;
   }else{
     sqlite3ExprDelete(pParse.db, yypParser.yystack[yypParser.yytos+ -1].minor.yy634);
   }
   yylhsminor.yy179 = yypParser.yystack[yypParser.yytos+ 0].minor.yy179;
 }
-//line 5653 "parse.go"
+//line 5830 "parse.go"
   yypParser.yystack[yypParser.yytos+ -1].minor.yy179 = yylhsminor.yy179;
         break
-      case 336: /* filter_over ::= filter_clause */
-//line 1911 "parse.y"
+      case 339: /* filter_over ::= filter_clause */
+//line 2050 "parse.y"
 {
-  yylhsminor.yy179 = sqlite3DbMallocZero(pParse.db, sizeof(Window)).(*Window);
-  if( yylhsminor.yy179 ){
+  yylhsminor.yy179 = &Window{};
+  if( yylhsminor.yy179!=nil ){
     yylhsminor.yy179.eFrmType = TK_FILTER;
     yylhsminor.yy179.pFilter = yypParser.yystack[yypParser.yytos+ 0].minor.yy634;
   }else{
     sqlite3ExprDelete(pParse.db, yypParser.yystack[yypParser.yytos+ 0].minor.yy634);
   }
 }
-//line 5667 "parse.go"
+//line 5844 "parse.go"
   yypParser.yystack[yypParser.yytos+ 0].minor.yy179 = yylhsminor.yy179;
         break
-      case 337: /* over_clause ::= OVER LP window RP */
-//line 1921 "parse.y"
+      case 340: /* over_clause ::= OVER LP window RP */
+//line 2060 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -3].minor.yy179 = yypParser.yystack[yypParser.yytos+ -1].minor.yy179;
-  assert( yypParser.yystack[yypParser.yytos+ -3].minor.yy179!=0, "yypParser.yystack[yypParser.yytos+ -3].minor.yy179!=0");
+  sqlite3CheckVersion(pParse, 3025000, "window functions", &yypParser.yystack[yypParser.yytos+ -3].minor.yy0, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
+  yylhsminor.yy179 = yypParser.yystack[yypParser.yytos+ -1].minor.yy179;
+  assert( yylhsminor.yy179!=nil, "yylhsminor.yy179!=nil");
 }
-//line 5676 "parse.go"
+//line 5854 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -3].minor.yy179 = yylhsminor.yy179;
         break
-      case 338: /* over_clause ::= OVER nm */
-//line 1925 "parse.y"
+      case 341: /* over_clause ::= OVER nm */
+//line 2065 "parse.y"
 {
-  yypParser.yystack[yypParser.yytos+ -1].minor.yy179 = sqlite3DbMallocZero(pParse.db, sizeof(Window)).(*Window);
-  if( yypParser.yystack[yypParser.yytos+ -1].minor.yy179 ){
-    yypParser.yystack[yypParser.yytos+ -1].minor.yy179.zName = sqlite3DbStrNDup(pParse.db, yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z, yypParser.yystack[yypParser.yytos+ 0].minor.yy0.n);
+  sqlite3CheckVersion(pParse, 3025000, "window functions", &yypParser.yystack[yypParser.yytos+ -1].minor.yy0, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
+  yylhsminor.yy179 = &Window{};
+  if( yylhsminor.yy179!=nil ){
+    yylhsminor.yy179.zName = sqlite3DbStrNDup(pParse.db, yypParser.yystack[yypParser.yytos+ 0].minor.yy0.z, yypParser.yystack[yypParser.yytos+ 0].minor.yy0.n);
   }
 }
-//line 5686 "parse.go"
+//line 5866 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -1].minor.yy179 = yylhsminor.yy179;
         break
-      case 339: /* filter_clause ::= FILTER LP WHERE expr RP */
-//line 1932 "parse.y"
-{ yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = yypParser.yystack[yypParser.yytos+ -1].minor.yy634; }
-//line 5691 "parse.go"
+      case 342: /* filter_clause ::= FILTER LP WHERE expr RP */
+//line 2073 "parse.y"
+{
+  sqlite3CheckVersion(pParse, 3030000, "FILTER", &yypParser.yystack[yypParser.yytos+ -4].minor.yy0, &yypParser.yystack[yypParser.yytos+ 0].minor.yy0);
+  yylhsminor.yy634 = yypParser.yystack[yypParser.yytos+ -1].minor.yy634;
+}
+//line 5875 "parse.go"
+  yypParser.yystack[yypParser.yytos+ -4].minor.yy634 = yylhsminor.yy634;
         break
 	default:
-		/* (340) input ::= cmdlist */ yytestcase(yyruleno == 340)
-		/* (341) cmdlist ::= cmdlist ecmd */ yytestcase(yyruleno == 341)
-		/* (342) cmdlist ::= ecmd (OPTIMIZED OUT) */ assert(yyruleno!=342, "yyruleno!=342")
-		/* (343) ecmd ::= SEMI */ yytestcase(yyruleno == 343)
-		/* (344) ecmd ::= cmdx SEMI */ yytestcase(yyruleno == 344)
-		/* (345) ecmd ::= explain cmdx SEMI (NEVER REDUCES) */ assert(yyruleno!=345)
-		/* (346) trans_opt ::= */ yytestcase(yyruleno == 346)
-		/* (347) trans_opt ::= TRANSACTION */ yytestcase(yyruleno == 347)
-		/* (348) trans_opt ::= TRANSACTION nm */ yytestcase(yyruleno == 348)
-		/* (349) savepoint_opt ::= SAVEPOINT */ yytestcase(yyruleno == 349)
-		/* (350) savepoint_opt ::= */ yytestcase(yyruleno == 350)
-		/* (351) cmd ::= create_table create_table_args */ yytestcase(yyruleno == 351)
-		/* (352) table_option_set ::= table_option (OPTIMIZED OUT) */ assert(yyruleno!=352, "yyruleno!=352")
-		/* (353) columnlist ::= columnlist COMMA columnname carglist */ yytestcase(yyruleno == 353)
-		/* (354) columnlist ::= columnname carglist */ yytestcase(yyruleno == 354)
-		/* (355) nm ::= ID|INDEXED */ yytestcase(yyruleno == 355)
-		/* (356) nm ::= STRING */ yytestcase(yyruleno == 356)
-		/* (357) nm ::= JOIN_KW */ yytestcase(yyruleno == 357)
-		/* (358) typetoken ::= typename */ yytestcase(yyruleno == 358)
-		/* (359) typename ::= ID|STRING */ yytestcase(yyruleno == 359)
-		/* (360) signed ::= plus_num (OPTIMIZED OUT) */ assert(yyruleno!=360, "yyruleno!=360")
-		/* (361) signed ::= minus_num (OPTIMIZED OUT) */ assert(yyruleno!=361, "yyruleno!=361")
-		/* (362) carglist ::= carglist ccons */ yytestcase(yyruleno == 362)
-		/* (363) carglist ::= */ yytestcase(yyruleno == 363)
-		/* (364) ccons ::= NULL onconf */ yytestcase(yyruleno == 364)
-		/* (365) ccons ::= GENERATED ALWAYS AS generated */ yytestcase(yyruleno == 365)
-		/* (366) ccons ::= AS generated */ yytestcase(yyruleno == 366)
-		/* (367) conslist_opt ::= COMMA conslist */ yytestcase(yyruleno == 367)
-		/* (368) conslist ::= conslist tconscomma tcons */ yytestcase(yyruleno == 368)
-		/* (369) conslist ::= tcons (OPTIMIZED OUT) */ assert(yyruleno!=369, "yyruleno!=369")
-		/* (370) tconscomma ::= */ yytestcase(yyruleno == 370)
-		/* (371) defer_subclause_opt ::= defer_subclause (OPTIMIZED OUT) */ assert(yyruleno!=371, "yyruleno!=371")
-		/* (372) resolvetype ::= raisetype (OPTIMIZED OUT) */ assert(yyruleno!=372, "yyruleno!=372")
-		/* (373) selectnowith ::= oneselect (OPTIMIZED OUT) */ assert(yyruleno!=373, "yyruleno!=373")
-		/* (374) oneselect ::= values */ yytestcase(yyruleno == 374)
-		/* (375) sclp ::= selcollist COMMA */ yytestcase(yyruleno == 375)
-		/* (376) as ::= ID|STRING */ yytestcase(yyruleno == 376)
-		/* (377) indexed_opt ::= indexed_by (OPTIMIZED OUT) */ assert(yyruleno!=377, "yyruleno!=377")
-		/* (378) returning ::= */ yytestcase(yyruleno == 378)
-		/* (379) expr ::= term (OPTIMIZED OUT) */ assert(yyruleno!=379, "yyruleno!=379")
-		/* (380) likeop ::= LIKE_KW|MATCH */ yytestcase(yyruleno == 380)
-		/* (381) exprlist ::= nexprlist */ yytestcase(yyruleno == 381)
-		/* (382) nmnum ::= plus_num (OPTIMIZED OUT) */ assert(yyruleno!=382, "yyruleno!=382")
-		/* (383) nmnum ::= nm (OPTIMIZED OUT) */ assert(yyruleno!=383, "yyruleno!=383")
-		/* (384) nmnum ::= ON */ yytestcase(yyruleno == 384)
-		/* (385) nmnum ::= DELETE */ yytestcase(yyruleno == 385)
-		/* (386) nmnum ::= DEFAULT */ yytestcase(yyruleno == 386)
-		/* (387) plus_num ::= INTEGER|FLOAT */ yytestcase(yyruleno == 387)
-		/* (388) foreach_clause ::= */ yytestcase(yyruleno == 388)
-		/* (389) foreach_clause ::= FOR EACH ROW */ yytestcase(yyruleno == 389)
-		/* (390) trnm ::= nm */ yytestcase(yyruleno == 390)
-		/* (391) tridxby ::= */ yytestcase(yyruleno == 391)
-		/* (392) database_kw_opt ::= DATABASE */ yytestcase(yyruleno == 392)
-		/* (393) database_kw_opt ::= */ yytestcase(yyruleno == 393)
-		/* (394) kwcolumn_opt ::= */ yytestcase(yyruleno == 394)
-		/* (395) kwcolumn_opt ::= COLUMNKW */ yytestcase(yyruleno == 395)
-		/* (396) vtabarglist ::= vtabarg */ yytestcase(yyruleno == 396)
-		/* (397) vtabarglist ::= vtabarglist COMMA vtabarg */ yytestcase(yyruleno == 397)
-		/* (398) vtabarg ::= vtabarg vtabargtoken */ yytestcase(yyruleno == 398)
-		/* (399) anylist ::= */ yytestcase(yyruleno == 399)
-		/* (400) anylist ::= anylist LP anylist RP */ yytestcase(yyruleno == 400)
-		/* (401) anylist ::= anylist ANY */ yytestcase(yyruleno == 401)
-		/* (402) with ::= */ yytestcase(yyruleno == 402)
+		/* (343) input ::= cmdlist */ yytestcase(yyruleno == 343)
+		/* (344) cmdlist ::= cmdlist ecmd */ yytestcase(yyruleno == 344)
+		/* (345) cmdlist ::= ecmd (OPTIMIZED OUT) */ assert(yyruleno!=345, "yyruleno!=345")
+		/* (346) ecmd ::= SEMI */ yytestcase(yyruleno == 346)
+		/* (347) ecmd ::= cmdx SEMI */ yytestcase(yyruleno == 347)
+		/* (348) ecmd ::= explain cmdx SEMI (NEVER REDUCES) */ assert(yyruleno!=348, "yyruleno!=348")
+		/* (349) trans_opt ::= */ yytestcase(yyruleno == 349)
+		/* (350) trans_opt ::= TRANSACTION */ yytestcase(yyruleno == 350)
+		/* (351) trans_opt ::= TRANSACTION nm */ yytestcase(yyruleno == 351)
+		/* (352) savepoint_opt ::= SAVEPOINT */ yytestcase(yyruleno == 352)
+		/* (353) savepoint_opt ::= */ yytestcase(yyruleno == 353)
+		/* (354) cmd ::= create_table create_table_args */ yytestcase(yyruleno == 354)
+		/* (355) table_option_set ::= table_option (OPTIMIZED OUT) */ assert(yyruleno!=355, "yyruleno!=355")
+		/* (356) columnlist ::= columnlist COMMA columnname carglist */ yytestcase(yyruleno == 356)
+		/* (357) columnlist ::= columnname carglist */ yytestcase(yyruleno == 357)
+		/* (358) nm ::= ID|INDEXED */ yytestcase(yyruleno == 358)
+		/* (359) nm ::= STRING */ yytestcase(yyruleno == 359)
+		/* (360) nm ::= JOIN_KW */ yytestcase(yyruleno == 360)
+		/* (361) typetoken ::= typename */ yytestcase(yyruleno == 361)
+		/* (362) typename ::= ID|STRING */ yytestcase(yyruleno == 362)
+		/* (363) signed ::= plus_num (OPTIMIZED OUT) */ assert(yyruleno!=363, "yyruleno!=363")
+		/* (364) signed ::= minus_num (OPTIMIZED OUT) */ assert(yyruleno!=364, "yyruleno!=364")
+		/* (365) carglist ::= carglist ccons */ yytestcase(yyruleno == 365)
+		/* (366) carglist ::= */ yytestcase(yyruleno == 366)
+		/* (367) ccons ::= NULL onconf */ yytestcase(yyruleno == 367)
+		/* (368) conslist_opt ::= COMMA conslist */ yytestcase(yyruleno == 368)
+		/* (369) conslist ::= conslist tconscomma tcons */ yytestcase(yyruleno == 369)
+		/* (370) conslist ::= tcons (OPTIMIZED OUT) */ assert(yyruleno!=370, "yyruleno!=370")
+		/* (371) tconscomma ::= */ yytestcase(yyruleno == 371)
+		/* (372) defer_subclause_opt ::= defer_subclause (OPTIMIZED OUT) */ assert(yyruleno!=372, "yyruleno!=372")
+		/* (373) resolvetype ::= raisetype (OPTIMIZED OUT) */ assert(yyruleno!=373, "yyruleno!=373")
+		/* (374) selectnowith ::= oneselect (OPTIMIZED OUT) */ assert(yyruleno!=374, "yyruleno!=374")
+		/* (375) oneselect ::= values */ yytestcase(yyruleno == 375)
+		/* (376) sclp ::= selcollist COMMA */ yytestcase(yyruleno == 376)
+		/* (377) as ::= ID|STRING */ yytestcase(yyruleno == 377)
+		/* (378) indexed_opt ::= indexed_by (OPTIMIZED OUT) */ assert(yyruleno!=378, "yyruleno!=378")
+		/* (379) returning ::= */ yytestcase(yyruleno == 379)
+		/* (380) expr ::= term (OPTIMIZED OUT) */ assert(yyruleno!=380, "yyruleno!=380")
+		/* (381) likeop ::= LIKE_KW|MATCH */ yytestcase(yyruleno == 381)
+		/* (382) case_operand ::= expr */ yytestcase(yyruleno == 382)
+		/* (383) exprlist ::= nexprlist */ yytestcase(yyruleno == 383)
+		/* (384) nmnum ::= plus_num (OPTIMIZED OUT) */ assert(yyruleno!=384, "yyruleno!=384")
+		/* (385) nmnum ::= nm (OPTIMIZED OUT) */ assert(yyruleno!=385, "yyruleno!=385")
+		/* (386) nmnum ::= ON */ yytestcase(yyruleno == 386)
+		/* (387) nmnum ::= DELETE */ yytestcase(yyruleno == 387)
+		/* (388) nmnum ::= DEFAULT */ yytestcase(yyruleno == 388)
+		/* (389) plus_num ::= INTEGER|FLOAT */ yytestcase(yyruleno == 389)
+		/* (390) foreach_clause ::= */ yytestcase(yyruleno == 390)
+		/* (391) foreach_clause ::= FOR EACH ROW */ yytestcase(yyruleno == 391)
+		/* (392) trnm ::= nm */ yytestcase(yyruleno == 392)
+		/* (393) tridxby ::= */ yytestcase(yyruleno == 393)
+		/* (394) database_kw_opt ::= DATABASE */ yytestcase(yyruleno == 394)
+		/* (395) database_kw_opt ::= */ yytestcase(yyruleno == 395)
+		/* (396) kwcolumn_opt ::= */ yytestcase(yyruleno == 396)
+		/* (397) kwcolumn_opt ::= COLUMNKW */ yytestcase(yyruleno == 397)
+		/* (398) vtabarglist ::= vtabarg */ yytestcase(yyruleno == 398)
+		/* (399) vtabarglist ::= vtabarglist COMMA vtabarg */ yytestcase(yyruleno == 399)
+		/* (400) vtabarg ::= vtabarg vtabargtoken */ yytestcase(yyruleno == 400)
+		/* (401) anylist ::= */ yytestcase(yyruleno == 401)
+		/* (402) anylist ::= anylist LP anylist RP */ yytestcase(yyruleno == 402)
+		/* (403) anylist ::= anylist ANY */ yytestcase(yyruleno == 403)
+		/* (404) with ::= */ yytestcase(yyruleno == 404)
 		break
 		/********** End reduce actions ************************************************/
 	}
@@ -5785,12 +6002,12 @@ func (yypParser *yyParser) yy_syntax_error(
 //line 39 "parse.y"
 
   UNUSED_PARAMETER(yymajor);  /* Silence some compiler warnings */
-  if( TOKEN.z[0] ){
+  if( len(TOKEN.z)>0 ){
     sqlite3ErrorMsg(pParse, "near \"%T\": syntax error", &TOKEN);
   }else{
     sqlite3ErrorMsg(pParse, "incomplete input");
   }
-//line 5827 "parse.go"
+//line 6011 "parse.go"
 
 	/************ End %syntax_error code ******************************************/
 	 /* Suppress warning about unused %extra_argument variable */
@@ -5856,6 +6073,7 @@ func (yypParser *yyParser) sqlite3Parser(
 	)
 
 	pParse := yypParser.pParse; _ = pParse
+	yypParser.yyfallback = false
 
 	
 
@@ -5880,6 +6098,7 @@ func (yypParser *yyParser) sqlite3Parser(
 	for { /* Exit by "break" */
 		assert(yypParser.yytos >= 0, "yypParser.yytos >= 0")
 		assert(yyact == yypParser.yystack[yypParser.yytos].stateno, "yyact == yypParser.yystack[yypParser.yytos].stateno")
+		yystate := yyact
 		yyact = yy_find_shift_action(yymajor, yyact)
 		if yyact >= YY_MIN_REDUCE {
 			yyruleno := yyact - YY_MIN_REDUCE /* Reduce by this rule */
@@ -5915,20 +6134,20 @@ func (yypParser *yyParser) sqlite3Parser(
 						assert(yypParser.yyhwm == yypParser.yytos, "yypParser.yyhwm == yypParser.yytos")
 					}
 				}
-				if YYSTACKDEPTH > 0 {
-					if yypParser.yytos >= YYSTACKDEPTH-1 {
+				if yypParser.yystackDepth > 0 {
+					if yypParser.yytos >= yypParser.yystackDepth-1 {
 						yypParser.yyStackOverflow()
 						break
 					}
-				} else {
-					if yypParser.yytos+1 >= len(yypParser.yystack)-1 {
-						yypParser.yyGrowStack()
-					}
+				}
+				if yypParser.yytos+1 >= len(yypParser.yystack)-1 {
+					yypParser.yyGrowStack()
 				}
 			}
 			yyact = yypParser.yy_reduce(yyruleno, yymajor, yyminor,
 			pParse)
 		} else if yyact <= YY_MAX_SHIFTREDUCE {
+			yypParser.yyfallback = yy_is_fallback(yymajor, yystate)
 			yypParser.yy_shift(yyact, yymajor, yyminor)
 			if !YYNOERRORRECOVERY {
 				yypParser.yyerrcnt--
@@ -38,7 +38,7 @@
 //
 %syntax_error {
   UNUSED_PARAMETER(yymajor);  /* Silence some compiler warnings */
-  if( TOKEN.z[0] ){
+  if( len(TOKEN.z)>0 ){
     sqlite3ErrorMsg(pParse, "near \"%T\": syntax error", &TOKEN);
   }else{
     sqlite3ErrorMsg(pParse, "incomplete input");
@@ -128,26 +128,26 @@ func disableLookaside(pParse *Parse){
   // DisableLookaside;
 }
 
-// #if !defined(SQLITE_ENABLE_UPDATE_DELETE_LIMIT) \
-//  && defined(SQLITE_UDL_CAPABLE_PARSER)
-// /*
-// ** Issue an error message if an ORDER BY or LIMIT clause occurs on an
-// ** UPDATE or DELETE statement.
-// */
+/*
+** Issue an error message if an ORDER BY or LIMIT clause occurs on an
+** UPDATE or DELETE statement.  This is the behavior of SQLite builds
+** without SQLITE_ENABLE_UPDATE_DELETE_LIMIT.  The Go port decides at
+** run-time, based on Options.UpdateDeleteLimit, so the grammar is always
+** built with SQLITE_UDL_CAPABLE_PARSER.
+*/
 func updateDeleteLimitError(
   pParse *Parse,
   pOrderBy *ExprList,
   pLimit *Expr,
 ){
-  // if( pOrderBy ){
-  //   sqlite3ErrorMsg(pParse, "syntax error near \"ORDER BY\"");
-  // }else{
-  //   sqlite3ErrorMsg(pParse, "syntax error near \"LIMIT\"");
-  // }
-  // sqlite3ExprListDelete(pParse.db, pOrderBy);
-  // sqlite3ExprDelete(pParse.db, pLimit);
+  if( pOrderBy!=nil ){
+    sqlite3ErrorMsg(pParse, "syntax error near \"ORDER BY\"");
+  }else{
+    sqlite3ErrorMsg(pParse, "syntax error near \"LIMIT\"");
+  }
+  sqlite3ExprListDelete(pParse.db, pOrderBy);
+  sqlite3ExprDelete(pParse.db, pLimit);
 }
-// #endif /* SQLITE_ENABLE_UPDATE_DELETE_LIMIT */
 
 } // end %include
 
@@ -235,6 +235,7 @@ table_option(A) ::= WITHOUT nm(X). {
 }
 table_option(A) ::= nm(X). {
   if( X.n==6 && sqlite3_strnicmp(X.z,[]byte("strict"),6)==0 ){
+    sqlite3CheckVersion(pParse, 3037000, "STRICT", &X, nil);
     A = TF_Strict;
   }else{
     A = 0;
@@ -333,14 +334,14 @@ nm(A) ::= JOIN_KW(A).
 typetoken(A) ::= .   {A.n = 0; A.z = []byte{};}
 typetoken(A) ::= typename(A).
 typetoken(A) ::= typename(A) LP signed RP(Y). {
-  A.n = uint(len(Y.z) - len(A.z));
+  A.n = uint(len(A.z) - len(Y.z)) + Y.n;
 }
 typetoken(A) ::= typename(A) LP signed COMMA signed RP(Y). {
-  A.n = uint(len(Y.z) - len(A.z));
+  A.n = uint(len(A.z) - len(Y.z)) + Y.n;
 }
 %type typename {Token}
 typename(A) ::= ids(A).
-typename(A) ::= typename(A) ids(Y). {A.n=Y.n+(int)(Y.z-A.z);}
+typename(A) ::= typename(A) ids(Y). {A.n=Y.n+uint(len(A.z)-len(Y.z));}
 signed ::= plus_num.
 signed ::= minus_num.
 
@@ -374,14 +375,14 @@ carglist ::= carglist ccons.
 carglist ::= .
 ccons ::= CONSTRAINT nm(X).           {pParse.constraintName = X;}
 ccons ::= DEFAULT scantok(A) term(X).
-                            {/*sqlite3AddDefaultValue(pParse,X,A.z,&A.z[A.n]);*/}
+                            {sqlite3AddDefaultValue(pParse,X,A.z,A.z[A.n:]);}
 ccons ::= DEFAULT LP(A) expr(X) RP(Z).
-                            {/*sqlite3AddDefaultValue(pParse,X,A.z+1,Z.z);*/}
+                            {sqlite3AddDefaultValue(pParse,X,A.z[1:],Z.z);}
 ccons ::= DEFAULT PLUS(A) scantok(Z) term(X).
-                            {/*sqlite3AddDefaultValue(pParse,X,A.z,&Z.z[Z.n]);*/}
+                            {sqlite3AddDefaultValue(pParse,X,A.z,Z.z[Z.n:]);}
 ccons ::= DEFAULT MINUS(A) scantok(Z) term(X). {
   p := sqlite3PExpr(pParse, TK_UMINUS, X, nil);
-  // sqlite3AddDefaultValue(pParse,p,A.z,&Z.z[Z.n]);
+  sqlite3AddDefaultValue(pParse,p,A.z,Z.z[Z.n:]);
 }
 ccons ::= DEFAULT scantok id(X).       {
   p := tokenExpr(pParse, TK_STRING, X);
@@ -389,7 +390,7 @@ ccons ::= DEFAULT scantok id(X).       {
     sqlite3ExprIdToTrueFalse(p);
     testcase( p.op==TK_TRUEFALSE && sqlite3ExprTruthValue(p) );
   }
-  // sqlite3AddDefaultValue(pParse,p,X.z,X.z+X.n);
+  sqlite3AddDefaultValue(pParse,p,X.z,X.z[X.n:]);
 }
 
 // In addition to the type name, we also care about the primary key and
@@ -406,10 +407,16 @@ ccons ::= REFERENCES nm(T) eidlist_opt(TA) refargs(R).
                                  {sqlite3CreateForeignKey(pParse,nil,&T,TA,R);}
 ccons ::= defer_subclause(D).    {sqlite3DeferForeignKey(pParse,D);}
 ccons ::= COLLATE ids(C).        {sqlite3AddCollateType(pParse, &C);}
-ccons ::= GENERATED ALWAYS AS generated.
-ccons ::= AS generated.
-generated ::= LP expr(E) RP.          {sqlite3AddGenerated(pParse,E,nil);}
-generated ::= LP expr(E) RP ID(TYPE). {sqlite3AddGenerated(pParse,E,&TYPE);}
+ccons ::= GENERATED(G) ALWAYS AS generated(Y).
+                                 {sqlite3CheckVersion(pParse, 3031000, "generated columns", &G, &Y);}
+ccons ::= AS(A) generated(Y).    {sqlite3CheckVersion(pParse, 3031000, "generated columns", &A, &Y);}
+
+// The value of a generated nonterminal is its last token, so that the
+// span of the constraint is known.
+//
+%type generated {Token}
+generated(A) ::= LP expr(E) RP(R).          {sqlite3AddGenerated(pParse,E,nil); A = R;}
+generated(A) ::= LP expr(E) RP ID(TYPE).    {sqlite3AddGenerated(pParse,E,&TYPE); A = TYPE;}
 
 // The optional AUTOINCREMENT keyword
 %type autoinc {int}
@@ -423,7 +430,7 @@ autoinc(X) ::= AUTOINCR.  {X = 1;}
 //
 %type refargs {int}
 refargs(A) ::= .                  { A = OE_None*0x0101; /* EV: R-19803-45884 */}
-refargs(A) ::= refargs(A) refarg(Y). { /* A = (A & ~Y.mask) | Y.value; */}
+refargs(A) ::= refargs(A) refarg(Y). { A = (A &^ Y.mask) | Y.value; }
 %type refarg {struct {value int; mask int;}}
 refarg(A) ::= MATCH nm.              { A.value = 0;     A.mask = 0x000000; }
 refarg(A) ::= ON INSERT refact.      { A.value = 0;     A.mask = 0x000000; }
@@ -443,7 +450,7 @@ init_deferred_pred_opt(A) ::= .                       {A = 0;}
 init_deferred_pred_opt(A) ::= INITIALLY DEFERRED.     {A = 1;}
 init_deferred_pred_opt(A) ::= INITIALLY IMMEDIATE.    {A = 0;}
 
-conslist_opt(A) ::= .                         {A.n = 0; A.z = 0;}
+conslist_opt(A) ::= .                         {A.n = 0; A.z = nil;}
 conslist_opt(A) ::= COMMA(A) conslist.
 conslist ::= conslist tconscomma tcons.
 conslist ::= tcons.
@@ -453,7 +460,7 @@ tcons ::= CONSTRAINT nm(X).      {pParse.constraintName = X;}
 tcons ::= PRIMARY KEY LP sortlist(X) autoinc(I) RP onconf(R).
                                  {sqlite3AddPrimaryKey(pParse,X,R,I,0);}
 tcons ::= UNIQUE LP sortlist(X) RP onconf(R).
-                                 {sqlite3CreateIndex(pParse,nil,nil,nil,nil,X,R,nil,0,0,
+                                 {sqlite3CreateIndex(pParse,nil,nil,nil,X,R,nil,nil,0,0,
                                        SQLITE_IDXTYPE_UNIQUE);}
 tcons ::= CHECK LP(A) expr(E) RP(B) onconf.
                                  {sqlite3AddCheckConstraint(pParse,E,A.z,B.z);}
@@ -523,31 +530,32 @@ cmd ::= select(X).  {
   ** SQLITE_LIMIT_COMPOUND_SELECT.
   */
   func parserDoubleLinkSelect(pParse *Parse, p *Select){
-    // assert( p!=0 );
-    // if( p->pPrior ){
-    //   Select *pNext = 0, *pLoop = p;
-    //   int mxSelect, cnt = 1;
-    //   while(1){
-    //     pLoop->pNext = pNext;
-    //     pLoop->selFlags |= SF_Compound;
-    //     pNext = pLoop;
-    //     pLoop = pLoop->pPrior;
-    //     if( pLoop==0 ) break;
-    //     cnt++;        
-    //     if( pLoop->pOrderBy || pLoop->pLimit ){
-    //       sqlite3ErrorMsg(pParse,"%s clause should come after %s not before",
-    //          pLoop->pOrderBy!=0 ? "ORDER BY" : "LIMIT",
-    //          sqlite3SelectOpName(pNext->op));
-    //       break;
-    //     }
-    //   }
-    //   if( (p->selFlags & SF_MultiValue)==0 && 
-    //     (mxSelect = pParse.db->aLimit[SQLITE_LIMIT_COMPOUND_SELECT])>0 &&
-    //     cnt>mxSelect
-    //   ){
-    //     sqlite3ErrorMsg(pParse, "too many terms in compound SELECT");
-    //   }
-    // }
+    assert( p!=nil, "p!=nil" );
+    if( p.pPrior!=nil ){
+      var pNext *Select
+      pLoop := p
+      var mxSelect int
+      cnt := 1
+      for {
+        pLoop.pNext = pNext;
+        pLoop.selFlags |= SF_Compound;
+        pNext = pLoop;
+        pLoop = pLoop.pPrior;
+        if( pLoop==nil ) { break; }
+        cnt++;
+        if( pLoop.pOrderBy!=nil || pLoop.pLimit!=nil ){
+          zClause := "LIMIT"
+          if( pLoop.pOrderBy!=nil ) { zClause = "ORDER BY"; }
+          sqlite3ErrorMsg(pParse,"%s clause should come after %s not before",
+             zClause, sqlite3SelectOpName(int(pNext.op)));
+          break;
+        }
+      }
+      mxSelect = pParse.db.aLimit[SQLITE_LIMIT_COMPOUND_SELECT]
+      if( (p.selFlags & SF_MultiValue)==0 && mxSelect>0 && cnt>mxSelect ){
+        sqlite3ErrorMsg(pParse, "too many terms in compound SELECT");
+      }
+    }
   }
 
   /* Attach a With object describing the WITH clause to a Select
@@ -556,7 +564,7 @@ cmd ::= select(X).  {
   func attachWithToSelect(pParse *Parse, pSelect *Select, pWith *With) *Select{
     if pSelect != nil {
       pSelect.pWith = pWith;
-      // parserDoubleLinkSelect(pParse, pSelect);
+      parserDoubleLinkSelect(pParse, pSelect);
     }else{
       // sqlite3WithDelete(pParse.db, pWith);
     }
@@ -594,9 +602,9 @@ selectnowith(A) ::= selectnowith(A) multiselect_op(Y) oneselect(Z).  {
     pRhs.op = uint8(Y);
     pRhs.pPrior = pLhs;
     if( ALWAYS(pLhs != nil) ) {
-      // pLhs.selFlags &= ~SF_MultiValue;
+      pLhs.selFlags &^= SF_MultiValue;
     }
-    // pRhs.selFlags &= ~SF_MultiValue;
+    pRhs.selFlags &^= SF_MultiValue;
     if( Y!=TK_ALL ) {
       pParse.hasCompound = 1;
     }
@@ -606,21 +614,21 @@ selectnowith(A) ::= selectnowith(A) multiselect_op(Y) oneselect(Z).  {
   A = pRhs;
 }
 %type multiselect_op {int}
-multiselect_op(A) ::= UNION(OP).             {A = @OP; /*A-overwrites-OP*/}
+multiselect_op(A) ::= UNION(OP).             {A = int(@OP); /*A-overwrites-OP*/}
 multiselect_op(A) ::= UNION ALL.             {A = TK_ALL;}
-multiselect_op(A) ::= EXCEPT|INTERSECT(OP).  {A = @OP; /*A-overwrites-OP*/}
+multiselect_op(A) ::= EXCEPT|INTERSECT(OP).  {A = int(@OP); /*A-overwrites-OP*/}
 %endif SQLITE_OMIT_COMPOUND_SELECT
 
 oneselect(A) ::= SELECT distinct(D) selcollist(W) from(X) where_opt(Y)
                  groupby_opt(P) having_opt(Q) 
                  orderby_opt(Z) limit_opt(L). {
-  A = sqlite3SelectNew(pParse,W,X,Y,P,Q,Z,D,L);
+  A = sqlite3SelectNew(pParse,W,X,Y,P,Q,Z,uint32(D),L);
 }
 %ifndef SQLITE_OMIT_WINDOWFUNC
 oneselect(A) ::= SELECT distinct(D) selcollist(W) from(X) where_opt(Y)
                  groupby_opt(P) having_opt(Q) window_clause(R)
                  orderby_opt(Z) limit_opt(L). {
-  A = sqlite3SelectNew(pParse,W,X,Y,P,Q,Z,D,L);
+  A = sqlite3SelectNew(pParse,W,X,Y,P,Q,Z,uint32(D),L);
   if( A != nil){
     A.pWinDefn = R;
   }else{
@@ -638,10 +646,11 @@ values(A) ::= VALUES LP nexprlist(X) RP. {
   A = sqlite3SelectNew(pParse,X,nil,nil,nil,nil,nil,SF_Values,nil);
 }
 values(A) ::= values(A) COMMA LP nexprlist(Y) RP. {
-  pRight, pLeft := A;
+  var pRight *Select;
+  pLeft := A;
   pRight = sqlite3SelectNew(pParse,Y,nil,nil,nil,nil,nil,SF_Values|SF_MultiValue,nil);
   if( ALWAYS(pLeft != nil) ) {
-    // pLeft.selFlags &= ~SF_MultiValue;
+    pLeft.selFlags &^= SF_MultiValue;
   } 
   if( pRight != nil){
     pRight.op = TK_ALL;
@@ -670,7 +679,7 @@ distinct(A) ::= .           {A = 0;}
 %type sclp {*ExprList}
 %destructor sclp {sqlite3ExprListDelete(pParse.db, $$);}
 sclp(A) ::= selcollist(A) COMMA.
-sclp(A) ::= .                                {A = 0;}
+sclp(A) ::= .                                {A = nil;}
 selcollist(A) ::= sclp(A) scanpt(B) expr(X) scanpt(Z) as(Y).     {
    A = sqlite3ExprListAppend(pParse, A, X);
    if( Y.n>0 ) {
@@ -679,11 +688,11 @@ selcollist(A) ::= sclp(A) scanpt(B) expr(X) scanpt(Z) as(Y).     {
    sqlite3ExprListSetSpan(pParse,A,B,Z);
 }
 selcollist(A) ::= sclp(A) scanpt STAR. {
-  Expr *p = sqlite3Expr(pParse.db, TK_ASTERISK, 0);
+  p := sqlite3Expr(pParse.db, TK_ASTERISK, nil);
   A = sqlite3ExprListAppend(pParse, A, p);
 }
 selcollist(A) ::= sclp(A) scanpt nm(X) DOT STAR. {
-  pRight := sqlite3PExpr(pParse, TK_ASTERISK, 0, 0);
+  pRight := sqlite3PExpr(pParse, TK_ASTERISK, nil, nil);
   pLeft := tokenExpr(pParse, TK_ID, X);
   pDot := sqlite3PExpr(pParse, TK_DOT, pLeft, pRight);
   A = sqlite3ExprListAppend(pParse,A, pDot);
@@ -695,7 +704,7 @@ selcollist(A) ::= sclp(A) scanpt nm(X) DOT STAR. {
 %type as {Token}
 as(X) ::= AS nm(Y).    {X = Y;}
 as(X) ::= ids(X).
-as(X) ::= .            {X.n = 0; X.z = 0;}
+as(X) ::= .            {X.n = 0; X.z = nil;}
 
 
 %type seltablist {*SrcList}
@@ -707,9 +716,10 @@ as(X) ::= .            {X.n = 0; X.z = 0;}
 
 // A complete FROM clause.
 //
-from(A) ::= .                {A = 0;}
-from(A) ::= FROM seltablist(X). {
+from(A) ::= .                {A = nil;}
+from(A) ::= FROM(F) seltablist(X). {
   A = X;
+  if( A!=nil ){ A.sFrom = F; }
   sqlite3SrcListShiftJoinType(pParse,A);
 }
 
@@ -717,75 +727,70 @@ from(A) ::= FROM seltablist(X). {
 // in a SELECT statement.  "stl_prefix" is a prefix of this list.
 //
 stl_prefix(A) ::= seltablist(A) joinop(Y).    {
-   if( ALWAYS(A && A.nSrc>0) ) {
+   if( ALWAYS(A!=nil && A.nSrc>0) ) {
      A.a[A.nSrc-1].fg.jointype = uint8(Y);
    }
 }
-stl_prefix(A) ::= .                           {A = 0;}
+stl_prefix(A) ::= .                           {A = nil;}
 seltablist(A) ::= stl_prefix(A) nm(Y) dbnm(D) as(Z) on_using(N). {
-  A = sqlite3SrcListAppendFromTerm(pParse,A,&Y,&D,&Z,0,&N);
+  A = sqlite3SrcListAppendFromTerm(pParse,A,&Y,&D,&Z,nil,&N);
 }
 seltablist(A) ::= stl_prefix(A) nm(Y) dbnm(D) as(Z) indexed_by(I) on_using(N). {
-  A = sqlite3SrcListAppendFromTerm(pParse,A,&Y,&D,&Z,0,&N);
+  A = sqlite3SrcListAppendFromTerm(pParse,A,&Y,&D,&Z,nil,&N);
   sqlite3SrcListIndexedBy(pParse, A, &I);
 }
 seltablist(A) ::= stl_prefix(A) nm(Y) dbnm(D) LP exprlist(E) RP as(Z) on_using(N). {
-  A = sqlite3SrcListAppendFromTerm(pParse,A,&Y,&D,&Z,0,&N);
+  A = sqlite3SrcListAppendFromTerm(pParse,A,&Y,&D,&Z,nil,&N);
   sqlite3SrcListFuncArgs(pParse, A, E);
 }
 %ifndef SQLITE_OMIT_SUBQUERY
   seltablist(A) ::= stl_prefix(A) LP select(S) RP as(Z) on_using(N). {
-    A = sqlite3SrcListAppendFromTerm(pParse,A,0,0,&Z,S,&N);
+    A = sqlite3SrcListAppendFromTerm(pParse,A,nil,nil,&Z,S,&N);
   }
   seltablist(A) ::= stl_prefix(A) LP seltablist(F) RP as(Z) on_using(N). {
-    if( A==0 && Z.n==0 && N.pOn==0 && N.pUsing==0 ){
+    if( A==nil && Z.n==0 && N.pOn==nil && N.pUsing==nil ){
       A = F;
     }else if( F.nSrc==1 ){
-      A = sqlite3SrcListAppendFromTerm(pParse,A,0,0,&Z,0,&N);
-      if( A ){
+      A = sqlite3SrcListAppendFromTerm(pParse,A,nil,nil,&Z,nil,&N);
+      if( A!=nil ){
         pNew := &A.a[A.nSrc-1];
-        pOld := F.a;
+        pOld := &F.a[0];
         pNew.zName = pOld.zName;
         pNew.zDatabase = pOld.zDatabase;
         pNew.pSelect = pOld.pSelect;
-        if( pNew.pSelect && (pNew.pSelect.selFlags & SF_NestedFrom)!=0 ){
-          pNew.fg.isNestedFrom = 1;
-        }
-        if( pOld.fg.isTabFunc ){
+        if( pOld.u1.pFuncArg!=nil ){
           pNew.u1.pFuncArg = pOld.u1.pFuncArg;
-          pOld.u1.pFuncArg = 0;
-          pOld.fg.isTabFunc = 0;
-          pNew.fg.isTabFunc = 1;
+          pOld.u1.pFuncArg = nil;
         }
-        pOld.zName = 0;
-        pOld.zDatabase = 0;
-        pOld.pSelect = 0;
+        pOld.zName = nil;
+        pOld.zDatabase = nil;
+        pOld.pSelect = nil;
       }
       sqlite3SrcListDelete(pParse.db, F);
     }else{
       var pSubquery *Select;
       sqlite3SrcListShiftJoinType(pParse,F);
-      pSubquery = sqlite3SelectNew(pParse,0,F,0,0,0,0,SF_NestedFrom,0);
-      A = sqlite3SrcListAppendFromTerm(pParse,A,0,0,&Z,pSubquery,&N);
+      pSubquery = sqlite3SelectNew(pParse,nil,F,nil,nil,nil,nil,SF_NestedFrom,nil);
+      A = sqlite3SrcListAppendFromTerm(pParse,A,nil,nil,&Z,pSubquery,&N);
     }
   }
 %endif  SQLITE_OMIT_SUBQUERY
 
 %type dbnm {Token}
-dbnm(A) ::= .          {A.z=0; A.n=0;}
+dbnm(A) ::= .          {A.z=nil; A.n=0;}
 dbnm(A) ::= DOT nm(X). {A = X;}
 
 %type fullname {*SrcList}
 %destructor fullname {sqlite3SrcListDelete(pParse.db, $$);}
 fullname(A) ::= nm(X).  {
-  A = sqlite3SrcListAppend(pParse,0,&X,0);
-  if( IN_RENAME_OBJECT && A ) {
+  A = sqlite3SrcListAppend(pParse,nil,&X,nil);
+  if( pParse.eParseMode>=PARSE_MODE_RENAME && A!=nil ) {
     sqlite3RenameTokenMap(pParse, A.a[0].zName, &X);
   }
 }
 fullname(A) ::= nm(X) DOT nm(Y). {
-  A = sqlite3SrcListAppend(pParse,0,&X,&Y);
-  if( IN_RENAME_OBJECT && A ) {
+  A = sqlite3SrcListAppend(pParse,nil,&X,&Y);
+  if( pParse.eParseMode>=PARSE_MODE_RENAME && A!=nil ) {
     sqlite3RenameTokenMap(pParse, A.a[0].zName, &Y);
   }
 }
@@ -793,18 +798,18 @@ fullname(A) ::= nm(X) DOT nm(Y). {
 %type xfullname {*SrcList}
 %destructor xfullname {sqlite3SrcListDelete(pParse.db, $$);}
 xfullname(A) ::= nm(X).  
-   {A = sqlite3SrcListAppend(pParse,0,&X,0); /*A-overwrites-X*/}
+   {A = sqlite3SrcListAppend(pParse,nil,&X,nil); /*A-overwrites-X*/}
 xfullname(A) ::= nm(X) DOT nm(Y).  
-   {A = sqlite3SrcListAppend(pParse,0,&X,&Y); /*A-overwrites-X*/}
+   {A = sqlite3SrcListAppend(pParse,nil,&X,&Y); /*A-overwrites-X*/}
 xfullname(A) ::= nm(X) DOT nm(Y) AS nm(Z).  {
-   A = sqlite3SrcListAppend(pParse,0,&X,&Y); /*A-overwrites-X*/
-   if( A ) {
+   A = sqlite3SrcListAppend(pParse,nil,&X,&Y); /*A-overwrites-X*/
+   if( A!=nil ) {
      A.a[0].zAlias = sqlite3NameFromToken(pParse.db, &Z);
    }
 }
 xfullname(A) ::= nm(X) AS nm(Z). {  
-   A = sqlite3SrcListAppend(pParse,0,&X,0); /*A-overwrites-X*/
-   if( A ) {
+   A = sqlite3SrcListAppend(pParse,nil,&X,nil); /*A-overwrites-X*/
+   if( A!=nil ) {
      A.a[0].zAlias = sqlite3NameFromToken(pParse.db, &Z);
    }
 }
@@ -812,9 +817,9 @@ xfullname(A) ::= nm(X) AS nm(Z). {
 %type joinop {int}
 joinop(X) ::= COMMA|JOIN.              { X = JT_INNER; }
 joinop(X) ::= JOIN_KW(A) JOIN.
-                  {X = sqlite3JoinType(pParse,&A,0,0);  /*X-overwrites-A*/}
+                  {X = sqlite3JoinType(pParse,&A,nil,nil);  /*X-overwrites-A*/}
 joinop(X) ::= JOIN_KW(A) nm(B) JOIN.
-                  {X = sqlite3JoinType(pParse,&A,&B,0); /*X-overwrites-A*/}
+                  {X = sqlite3JoinType(pParse,&A,&B,nil); /*X-overwrites-A*/}
 joinop(X) ::= JOIN_KW(A) nm(B) nm(C) JOIN.
                   {X = sqlite3JoinType(pParse,&A,&B,&C);/*X-overwrites-A*/}
 
@@ -837,9 +842,9 @@ joinop(X) ::= JOIN_KW(A) nm(B) nm(C) JOIN.
 //
 %type on_using {OnOrUsing}
 //%destructor on_using {sqlite3ClearOnOrUsing(pParse.db, &$$);}
-on_using(N) ::= ON expr(E).            {N.pOn = E; N.pUsing = 0;}
-on_using(N) ::= USING LP idlist(L) RP. {N.pOn = 0; N.pUsing = L;}
-on_using(N) ::= .                 [OR] {N.pOn = 0; N.pUsing = 0;}
+on_using(N) ::= ON expr(E).            {N.pOn = E; N.pUsing = nil;}
+on_using(N) ::= USING LP idlist(L) RP. {N.pOn = nil; N.pUsing = L;}
+on_using(N) ::= .                 [OR] {N.pOn = nil; N.pUsing = nil;}
 
 // Note that this block abuses the Token type just a little. If there is
 // no "INDEXED BY" clause, the returned token is empty (z==0 && n==0). If
@@ -853,10 +858,10 @@ on_using(N) ::= .                 [OR] {N.pOn = 0; N.pUsing = 0;}
 //
 %type indexed_opt {Token}
 %type indexed_by  {Token}
-indexed_opt(A) ::= .                 {A.z=0; A.n=0;}
+indexed_opt(A) ::= .                 {A.z=nil; A.n=0;}
 indexed_opt(A) ::= indexed_by(A).
 indexed_by(A)  ::= INDEXED BY nm(X). {A = X;}
-indexed_by(A)  ::= NOT INDEXED.      {A.z=0; A.n=1;}
+indexed_by(A)  ::= NOT INDEXED.      {A.z=nil; A.n=1;}
 
 %type orderby_opt {*ExprList}
 %destructor orderby_opt {sqlite3ExprListDelete(pParse.db, $$);}
@@ -868,14 +873,14 @@ indexed_by(A)  ::= NOT INDEXED.      {A.z=0; A.n=1;}
 %type sortlist {*ExprList}
 %destructor sortlist {sqlite3ExprListDelete(pParse.db, $$);}
 
-orderby_opt(A) ::= .                          {A = 0;}
+orderby_opt(A) ::= .                          {A = nil;}
 orderby_opt(A) ::= ORDER BY sortlist(X).      {A = X;}
 sortlist(A) ::= sortlist(A) COMMA expr(Y) sortorder(Z) nulls(X). {
   A = sqlite3ExprListAppend(pParse,A,Y);
   sqlite3ExprListSetSortOrder(A,Z,X);
 }
 sortlist(A) ::= expr(Y) sortorder(Z) nulls(X). {
-  A = sqlite3ExprListAppend(pParse,0,Y); /*A-overwrites-Y*/
+  A = sqlite3ExprListAppend(pParse,nil,Y); /*A-overwrites-Y*/
   sqlite3ExprListSetSortOrder(A,Z,X);
 }
 
@@ -886,18 +891,24 @@ sortorder(A) ::= DESC.          {A = SQLITE_SO_DESC;}
 sortorder(A) ::= .              {A = SQLITE_SO_UNDEFINED;}
 
 %type nulls {int}
-nulls(A) ::= NULLS FIRST.       {A = SQLITE_SO_ASC;}
-nulls(A) ::= NULLS LAST.        {A = SQLITE_SO_DESC;}
+nulls(A) ::= NULLS(X) FIRST(Y).  {
+  sqlite3CheckVersion(pParse, 3030000, "NULLS FIRST", &X, &Y);
+  A = SQLITE_SO_ASC;
+}
+nulls(A) ::= NULLS(X) LAST(Y).   {
+  sqlite3CheckVersion(pParse, 3030000, "NULLS LAST", &X, &Y);
+  A = SQLITE_SO_DESC;
+}
 nulls(A) ::= .                  {A = SQLITE_SO_UNDEFINED;}
 
 %type groupby_opt {*ExprList}
 %destructor groupby_opt {sqlite3ExprListDelete(pParse.db, $$);}
-groupby_opt(A) ::= .                      {A = 0;}
+groupby_opt(A) ::= .                      {A = nil;}
 groupby_opt(A) ::= GROUP BY nexprlist(X). {A = X;}
 
 %type having_opt {*Expr}
 %destructor having_opt {sqlite3ExprDelete(pParse.db, $$);}
-having_opt(A) ::= .                {A = 0;}
+having_opt(A) ::= .                {A = nil;}
 having_opt(A) ::= HAVING expr(X).  {A = X;}
 
 %type limit_opt {*Expr}
@@ -910,9 +921,9 @@ having_opt(A) ::= HAVING expr(X).  {A = X;}
 // except as a transient.  So there is never anything to destroy.
 //
 //%destructor limit_opt {sqlite3ExprDelete(pParse.db, $$);}
-limit_opt(A) ::= .       {A = 0;}
+limit_opt(A) ::= .       {A = nil;}
 limit_opt(A) ::= LIMIT expr(X).
-                         {A = sqlite3PExpr(pParse,TK_LIMIT,X,0);}
+                         {A = sqlite3PExpr(pParse,TK_LIMIT,X,nil);}
 limit_opt(A) ::= LIMIT expr(X) OFFSET expr(Y). 
                          {A = sqlite3PExpr(pParse,TK_LIMIT,X,Y);}
 limit_opt(A) ::= LIMIT expr(X) COMMA expr(Y). 
@@ -924,19 +935,17 @@ limit_opt(A) ::= LIMIT expr(X) COMMA expr(Y).
 cmd ::= with DELETE FROM xfullname(X) indexed_opt(I) where_opt_ret(W)
         orderby_opt(O) limit_opt(L). {
   sqlite3SrcListIndexedBy(pParse, X, &I);
-#ifndef SQLITE_ENABLE_UPDATE_DELETE_LIMIT
-  if( O || L ){
+  if( (O!=nil || L!=nil) && pParse.db.bUpdateDeleteLimit==0 ){
     updateDeleteLimitError(pParse,O,L);
-    O = 0;
-    L = 0;
+    O = nil;
+    L = nil;
   }
-#endif
   sqlite3DeleteFrom(pParse,X,W,O,L);
 }
 %else
 cmd ::= with DELETE FROM xfullname(X) indexed_opt(I) where_opt_ret(W). {
   sqlite3SrcListIndexedBy(pParse, X, &I);
-  sqlite3DeleteFrom(pParse,X,W,0,0);
+  sqlite3DeleteFrom(pParse,X,W,nil,nil);
 }
 %endif
 
@@ -945,14 +954,16 @@ cmd ::= with DELETE FROM xfullname(X) indexed_opt(I) where_opt_ret(W). {
 %type where_opt_ret {*Expr}
 %destructor where_opt_ret {sqlite3ExprDelete(pParse.db, $$);}
 
-where_opt(A) ::= .                    {A = 0;}
+where_opt(A) ::= .                    {A = nil;}
 where_opt(A) ::= WHERE expr(X).       {A = X;}
-where_opt_ret(A) ::= .                                      {A = 0;}
+where_opt_ret(A) ::= .                                      {A = nil;}
 where_opt_ret(A) ::= WHERE expr(X).                         {A = X;}
-where_opt_ret(A) ::= RETURNING selcollist(X).               
-       {sqlite3AddReturning(pParse,X); A = 0;}
-where_opt_ret(A) ::= WHERE expr(X) RETURNING selcollist(Y).
-       {sqlite3AddReturning(pParse,Y); A = X;}
+where_opt_ret(A) ::= RETURNING(R) selcollist(X).
+       {sqlite3CheckVersion(pParse, 3035000, "RETURNING", &R, nil);
+        sqlite3AddReturning(pParse,X); A = nil;}
+where_opt_ret(A) ::= WHERE expr(X) RETURNING(R) selcollist(Y).
+       {sqlite3CheckVersion(pParse, 3035000, "RETURNING", &R, nil);
+        sqlite3AddReturning(pParse,Y); A = X;}
 
 ////////////////////////// The UPDATE command ////////////////////////////////
 //
@@ -960,24 +971,24 @@ where_opt_ret(A) ::= WHERE expr(X) RETURNING selcollist(Y).
 cmd ::= with UPDATE orconf(R) xfullname(X) indexed_opt(I) SET setlist(Y) from(F)
         where_opt_ret(W) orderby_opt(O) limit_opt(L).  {
   sqlite3SrcListIndexedBy(pParse, X, &I);
+  if( F!=nil ){ sqlite3CheckVersion(pParse, 3033000, "UPDATE FROM", &F.sFrom, nil); }
   X = sqlite3SrcListAppendList(pParse, X, F);
   sqlite3ExprListCheckLength(pParse,Y,"set list"); 
-#ifndef SQLITE_ENABLE_UPDATE_DELETE_LIMIT
-  if( O || L ){
+  if( (O!=nil || L!=nil) && pParse.db.bUpdateDeleteLimit==0 ){
     updateDeleteLimitError(pParse,O,L);
-    O = 0;
-    L = 0;
+    O = nil;
+    L = nil;
   }
-#endif
-  sqlite3Update(pParse,X,Y,W,R,O,L,0);
+  sqlite3Update(pParse,X,Y,W,R,O,L,nil);
 }
 %else
 cmd ::= with UPDATE orconf(R) xfullname(X) indexed_opt(I) SET setlist(Y) from(F)
         where_opt_ret(W). {
   sqlite3SrcListIndexedBy(pParse, X, &I);
   sqlite3ExprListCheckLength(pParse,Y,"set list"); 
+  if( F!=nil ){ sqlite3CheckVersion(pParse, 3033000, "UPDATE FROM", &F.sFrom, nil); }
   X = sqlite3SrcListAppendList(pParse, X, F);
-  sqlite3Update(pParse,X,Y,W,R,0,0,0);
+  sqlite3Update(pParse,X,Y,W,R,nil,nil,nil);
 }
 %endif
 
@@ -994,11 +1005,11 @@ setlist(A) ::= setlist(A) COMMA LP idlist(X) RP EQ expr(Y). {
   A = sqlite3ExprListAppendVector(pParse, A, X, Y);
 }
 setlist(A) ::= nm(X) EQ expr(Y). {
-  A = sqlite3ExprListAppend(pParse, 0, Y);
+  A = sqlite3ExprListAppend(pParse, nil, Y);
   sqlite3ExprListSetName(pParse, A, &X, 1);
 }
 setlist(A) ::= LP idlist(X) RP EQ expr(Y). {
-  A = sqlite3ExprListAppendVector(pParse, 0, X, Y);
+  A = sqlite3ExprListAppendVector(pParse, nil, X, Y);
 }
 
 ////////////////////////// The INSERT command /////////////////////////////////
@@ -1009,7 +1020,7 @@ cmd ::= with insert_cmd(R) INTO xfullname(X) idlist_opt(F) select(S)
 }
 cmd ::= with insert_cmd(R) INTO xfullname(X) idlist_opt(F) DEFAULT VALUES returning.
 {
-  sqlite3Insert(pParse, X, 0, F, R, 0);
+  sqlite3Insert(pParse, X, nil, F, R, nil);
 }
 
 %type upsert {*Upsert}
@@ -1019,19 +1030,30 @@ cmd ::= with insert_cmd(R) INTO xfullname(X) idlist_opt(F) DEFAULT VALUES return
 // be destroyed by the cmd action.  So comment-out the destructor to
 // avoid unreachable code.
 //%destructor upsert {sqlite3UpsertDelete(pParse.db,$$);}
-upsert(A) ::= . { A = 0; }
-upsert(A) ::= RETURNING selcollist(X).  { A = 0; sqlite3AddReturning(pParse,X); }
-upsert(A) ::= ON CONFLICT LP sortlist(T) RP where_opt(TW)
+upsert(A) ::= . { A = nil; }
+upsert(A) ::= RETURNING(R) selcollist(X).  {
+  A = nil;
+  sqlite3CheckVersion(pParse, 3035000, "RETURNING", &R, nil);
+  sqlite3AddReturning(pParse,X);
+}
+upsert(A) ::= ON(O) CONFLICT(C) LP sortlist(T) RP where_opt(TW)
               DO UPDATE SET setlist(Z) where_opt(W) upsert(N).
-              { A = sqlite3UpsertNew(pParse.db,T,TW,Z,W,N);}
-upsert(A) ::= ON CONFLICT LP sortlist(T) RP where_opt(TW) DO NOTHING upsert(N).
-              { A = sqlite3UpsertNew(pParse.db,T,TW,0,0,N); }
-upsert(A) ::= ON CONFLICT DO NOTHING returning.
-              { A = sqlite3UpsertNew(pParse.db,0,0,0,0,0); }
-upsert(A) ::= ON CONFLICT DO UPDATE SET setlist(Z) where_opt(W) returning.
-              { A = sqlite3UpsertNew(pParse.db,0,0,Z,W,0);}
-
-returning ::= RETURNING selcollist(X).  {sqlite3AddReturning(pParse,X);}
+              { sqlite3CheckVersion(pParse, 3024000, "UPSERT", &O, &C);
+                A = sqlite3UpsertNew(pParse.db,T,TW,Z,W,N);}
+upsert(A) ::= ON(O) CONFLICT(C) LP sortlist(T) RP where_opt(TW) DO NOTHING upsert(N).
+              { sqlite3CheckVersion(pParse, 3024000, "UPSERT", &O, &C);
+                A = sqlite3UpsertNew(pParse.db,T,TW,nil,nil,N); }
+upsert(A) ::= ON(O) CONFLICT(C) DO NOTHING returning.
+              { sqlite3CheckVersion(pParse, 3024000, "UPSERT", &O, &C);
+                A = sqlite3UpsertNew(pParse.db,nil,nil,nil,nil,nil); }
+upsert(A) ::= ON(O) CONFLICT(C) DO UPDATE SET setlist(Z) where_opt(W) returning.
+              { sqlite3CheckVersion(pParse, 3024000, "UPSERT", &O, &C);
+                A = sqlite3UpsertNew(pParse.db,nil,nil,Z,W,nil);}
+
+returning ::= RETURNING(R) selcollist(X).  {
+  sqlite3CheckVersion(pParse, 3035000, "RETURNING", &R, nil);
+  sqlite3AddReturning(pParse,X);
+}
 returning ::= .
 
 %type insert_cmd {int}
@@ -1043,12 +1065,12 @@ insert_cmd(A) ::= REPLACE.            {A = OE_Replace;}
 %type idlist {*IdList}
 %destructor idlist {sqlite3IdListDelete(pParse.db, $$);}
 
-idlist_opt(A) ::= .                       {A = 0;}
+idlist_opt(A) ::= .                       {A = nil;}
 idlist_opt(A) ::= LP idlist(X) RP.    {A = X;}
 idlist(A) ::= idlist(A) COMMA nm(Y).
     {A = sqlite3IdListAppend(pParse,A,&Y);}
 idlist(A) ::= nm(Y).
-    {A = sqlite3IdListAppend(pParse,0,&Y); /*A-overwrites-Y*/}
+    {A = sqlite3IdListAppend(pParse,nil,&Y); /*A-overwrites-Y*/}
 
 /////////////////////////// Expression Processing /////////////////////////////
 //
@@ -1062,70 +1084,84 @@ idlist(A) ::= nm(Y).
 
   /* Construct a new Expr object from a single token */
   func tokenExpr(pParse *Parse, op int, t Token) *Expr{
-    // Expr *p = sqlite3DbMallocRawNN(pParse.db, sizeof(Expr)+t.n+1);
-    // if( p ){
-    //   /* memset(p, 0, sizeof(Expr)); */
-    //   p->op = (u8)op;
-    //   p->affExpr = 0;
-    //   p->flags = EP_Leaf;
-    //   ExprClearVVAProperties(p);
-    //   /* p->iAgg = -1; // Not required */
-    //   p->pLeft = p->pRight = 0;
-    //   p->pAggInfo = 0;
-    //   memset(&p->x, 0, sizeof(p->x));
-    //   memset(&p->y, 0, sizeof(p->y));
-    //   p->op2 = 0;
-    //   p->iTable = 0;
-    //   p->iColumn = 0;
-    //   p->u.zToken = (char*)&p[1];
-    //   memcpy(p->u.zToken, t.z, t.n);
-    //   p->u.zToken[t.n] = 0;
-    //   p->w.iOfst = (int)(t.z - pParse->zTail);
-    //   if( sqlite3Isquote(p->u.zToken[0]) ){
-    //     sqlite3DequoteExpr(p);
-    //   }
+    p := &Expr{}
+    p.op = uint8(op);
+    p.affExpr = 0;
+    p.flags = EP_Leaf;
+    /* p.iAgg = -1; // Not required */
+    /* The token text refers directly to the SQL input.  It is copied
+    ** only when it must be dequoted, since that rewrites it in place */
+    p.u.zToken = t.z[:t.n:t.n];
+    p.w.iOfst = len(pParse.zTail) - len(t.z);
+    p.iSpan = p.w.iOfst;
+    p.nSpan = int(t.n);
+    if( t.n>0 && sqlite3Isquote(p.u.zToken[0]) ){
+      p.u.zToken = append([]byte(nil), p.u.zToken...);
+      sqlite3DequoteExpr(p);
+    }
     // #if SQLITE_MAX_EXPR_DEPTH>0
-    //   p->nHeight = 1;
-    // #endif  
-    //   if( IN_RENAME_OBJECT ){
-    //     return (Expr*)sqlite3RenameTokenMap(pParse, (void*)p, &t);
-    //   }
-    // }
-    // return p;
-    return nil
+    p.nHeight = 1;
+    // #endif
+    return p
+  }
+
+  /* Record the span of expression p, which is the text of the SQL input
+  ** from zStart up to the end of the last token shifted into the parser.
+  ** This is called as each rule that builds an expression is reduced,
+  ** so the span takes in any keywords or parentheses around the
+  ** operands.  Nothing is recorded if zStart is nil, which is the case
+  ** if the span of the leftmost operand is not known. */
+  func exprSpan(pParse *Parse, p *Expr, zStart []byte){
+    if( p!=nil && zStart!=nil ){
+      p.iSpan = len(pParse.zTail) - len(zStart);
+      p.nSpan = len(zStart) - len(pParse.zPrevEnd);
+    }
   }
 
+  /* Return the SQL input from the start of the span of p, or nil if the
+  ** span is not known.  Rules whose leftmost symbol is an expression call
+  ** this before the action replaces it. */
+  func exprSpanStart(pParse *Parse, p *Expr) []byte {
+    if( p==nil || p.nSpan==0 ){
+      return nil;
+    }
+    return pParse.zTail[p.iSpan:];
+  }
 }
 
 expr(A) ::= term(A).
-expr(A) ::= LP expr(X) RP. {A = X;}
+expr(A) ::= LP(B) expr(X) RP. {A = X; exprSpan(pParse, A, B.z);}
 expr(A) ::= id(X).          {A=tokenExpr(pParse,TK_ID,X); /*A-overwrites-X*/}
 expr(A) ::= JOIN_KW(X).     {A=tokenExpr(pParse,TK_ID,X); /*A-overwrites-X*/}
 expr(A) ::= nm(X) DOT nm(Y). {
-  Expr *temp1 = tokenExpr(pParse,TK_ID,X);
-  Expr *temp2 = tokenExpr(pParse,TK_ID,Y);
+  temp1 := tokenExpr(pParse,TK_ID,X);
+  temp2 := tokenExpr(pParse,TK_ID,Y);
   A = sqlite3PExpr(pParse, TK_DOT, temp1, temp2);
+  exprSpan(pParse, A, X.z);
 }
 expr(A) ::= nm(X) DOT nm(Y) DOT nm(Z). {
   temp1 := tokenExpr(pParse,TK_ID,X);
   temp2 := tokenExpr(pParse,TK_ID,Y);
   temp3 := tokenExpr(pParse,TK_ID,Z);
   temp4 := sqlite3PExpr(pParse, TK_DOT, temp2, temp3);
-  if( IN_RENAME_OBJECT ){
-    sqlite3RenameTokenRemap(pParse, 0, temp1);
+  exprSpan(pParse, temp4, Y.z);
+  if( pParse.eParseMode>=PARSE_MODE_RENAME ){
+    sqlite3RenameTokenRemap(pParse, nil, temp1);
   }
   A = sqlite3PExpr(pParse, TK_DOT, temp1, temp4);
+  exprSpan(pParse, A, X.z);
 }
-term(A) ::= NULL|FLOAT|BLOB(X). {A=tokenExpr(pParse,@X,X); /*A-overwrites-X*/}
-term(A) ::= STRING(X).          {A=tokenExpr(pParse,@X,X); /*A-overwrites-X*/}
+term(A) ::= NULL|FLOAT|BLOB(X). {A=tokenExpr(pParse,int(@X),X); /*A-overwrites-X*/}
+term(A) ::= STRING(X).          {A=tokenExpr(pParse,int(@X),X); /*A-overwrites-X*/}
 term(A) ::= INTEGER(X). {
   A = sqlite3ExprAlloc(pParse.db, TK_INTEGER, &X, 1);
-  if( A ) {
-    A.w.iOfst = (int)(X.z - pParse.zTail);
+  if( A!=nil ) {
+    A.w.iOfst = len(pParse.zTail) - len(X.z);
+    exprSpan(pParse, A, X.z);
   }
 }
 expr(A) ::= VARIABLE(X).     {
-  if( !(X.z[0]=='#' && sqlite3Isdigit(X.z[1])) ){
+  if( !(X.z[0]=='#' && X.n>1 && sqlite3Isdigit(X.z[1])) ){
     n := X.n;
     A = tokenExpr(pParse, TK_VARIABLE, X);
     sqlite3ExprAssignVarNumber(pParse, A, n);
@@ -1138,119 +1174,170 @@ expr(A) ::= VARIABLE(X).     {
     assert( t.n>=2 , "t.n>=2");
     if( pParse.nested==0 ){
       sqlite3ErrorMsg(pParse, "near \"%T\": syntax error", &t);
-      A = 0;
+      A = nil;
     }else{
-      A = sqlite3PExpr(pParse, TK_REGISTER, 0, 0);
-      if( A ) {
-        sqlite3GetInt32(&t.z[1], &A.iTable);
+      A = sqlite3PExpr(pParse, TK_REGISTER, nil, nil);
+      if( A!=nil ) {
+        sqlite3GetInt32(t.z[1:t.n], &A.iTable);
       }
     }
   }
 }
 expr(A) ::= expr(A) COLLATE ids(C). {
+  z := exprSpanStart(pParse, A);
   A = sqlite3ExprAddCollateToken(pParse, A, &C, 1);
+  exprSpan(pParse, A, z);
 }
 %ifndef SQLITE_OMIT_CAST
-expr(A) ::= CAST LP expr(E) AS typetoken(T) RP. {
+expr(A) ::= CAST(B) LP expr(E) AS typetoken(T) RP. {
   A = sqlite3ExprAlloc(pParse.db, TK_CAST, &T, 1);
-  sqlite3ExprAttachSubtrees(pParse.db, A, E, 0);
+  sqlite3ExprAttachSubtrees(pParse.db, A, E, nil);
+  exprSpan(pParse, A, B.z);
 }
 %endif  SQLITE_OMIT_CAST
 
 
 expr(A) ::= id(X) LP distinct(D) exprlist(Y) RP. {
   A = sqlite3ExprFunction(pParse, Y, &X, D);
+  exprSpan(pParse, A, X.z);
 }
 expr(A) ::= id(X) LP STAR RP. {
-  A = sqlite3ExprFunction(pParse, 0, &X, 0);
+  A = sqlite3ExprFunction(pParse, nil, &X, 0);
+  exprSpan(pParse, A, X.z);
 }
 
 %ifndef SQLITE_OMIT_WINDOWFUNC
 expr(A) ::= id(X) LP distinct(D) exprlist(Y) RP filter_over(Z). {
   A = sqlite3ExprFunction(pParse, Y, &X, D);
   sqlite3WindowAttach(pParse, A, Z);
+  exprSpan(pParse, A, X.z);
 }
 expr(A) ::= id(X) LP STAR RP filter_over(Z). {
-  A = sqlite3ExprFunction(pParse, 0, &X, 0);
+  A = sqlite3ExprFunction(pParse, nil, &X, 0);
   sqlite3WindowAttach(pParse, A, Z);
+  exprSpan(pParse, A, X.z);
 }
 %endif
 
 term(A) ::= CTIME_KW(OP). {
-  A = sqlite3ExprFunction(pParse, 0, &OP, 0);
+  A = sqlite3ExprFunction(pParse, nil, &OP, 0);
+  exprSpan(pParse, A, OP.z);
 }
 
-expr(A) ::= LP nexprlist(X) COMMA expr(Y) RP. {
-  ExprList *pList = sqlite3ExprListAppend(pParse, X, Y);
-  A = sqlite3PExpr(pParse, TK_VECTOR, 0, 0);
-  if( A ){
+expr(A) ::= LP(B) nexprlist(X) COMMA expr(Y) RP. {
+  pList := sqlite3ExprListAppend(pParse, X, Y);
+  A = sqlite3PExpr(pParse, TK_VECTOR, nil, nil);
+  if( A!=nil ){
     A.x.pList = pList;
-    if( ALWAYS(pList.nExpr) ){
+    if( ALWAYS(pList.nExpr>0) ){
       A.flags |= pList.a[0].pExpr.flags & EP_Propagate;
     }
   }else{
     sqlite3ExprListDelete(pParse.db, pList);
   }
+  exprSpan(pParse, A, B.z);
 }
 
-expr(A) ::= expr(A) AND expr(Y).        {A=sqlite3ExprAnd(pParse,A,Y);}
-expr(A) ::= expr(A) OR(OP) expr(Y).     {A=sqlite3PExpr(pParse,@OP,A,Y);}
-expr(A) ::= expr(A) LT|GT|GE|LE(OP) expr(Y).
-                                        {A=sqlite3PExpr(pParse,@OP,A,Y);}
-expr(A) ::= expr(A) EQ|NE(OP) expr(Y).  {A=sqlite3PExpr(pParse,@OP,A,Y);}
-expr(A) ::= expr(A) BITAND|BITOR|LSHIFT|RSHIFT(OP) expr(Y).
-                                        {A=sqlite3PExpr(pParse,@OP,A,Y);}
-expr(A) ::= expr(A) PLUS|MINUS(OP) expr(Y).
-                                        {A=sqlite3PExpr(pParse,@OP,A,Y);}
-expr(A) ::= expr(A) STAR|SLASH|REM(OP) expr(Y).
-                                        {A=sqlite3PExpr(pParse,@OP,A,Y);}
-expr(A) ::= expr(A) CONCAT(OP) expr(Y). {A=sqlite3PExpr(pParse,@OP,A,Y);}
+expr(A) ::= expr(A) AND expr(Y). {
+  z := exprSpanStart(pParse, A);
+  A = sqlite3ExprAnd(pParse,A,Y);
+  exprSpan(pParse, A, z);
+}
+expr(A) ::= expr(A) OR(OP) expr(Y). {
+  z := exprSpanStart(pParse, A);
+  A = sqlite3PExpr(pParse,int(@OP),A,Y);
+  exprSpan(pParse, A, z);
+}
+expr(A) ::= expr(A) LT|GT|GE|LE(OP) expr(Y). {
+  z := exprSpanStart(pParse, A);
+  A = sqlite3PExpr(pParse,int(@OP),A,Y);
+  exprSpan(pParse, A, z);
+}
+expr(A) ::= expr(A) EQ|NE(OP) expr(Y). {
+  z := exprSpanStart(pParse, A);
+  A = sqlite3PExpr(pParse,int(@OP),A,Y);
+  exprSpan(pParse, A, z);
+}
+expr(A) ::= expr(A) BITAND|BITOR|LSHIFT|RSHIFT(OP) expr(Y). {
+  z := exprSpanStart(pParse, A);
+  A = sqlite3PExpr(pParse,int(@OP),A,Y);
+  exprSpan(pParse, A, z);
+}
+expr(A) ::= expr(A) PLUS|MINUS(OP) expr(Y). {
+  z := exprSpanStart(pParse, A);
+  A = sqlite3PExpr(pParse,int(@OP),A,Y);
+  exprSpan(pParse, A, z);
+}
+expr(A) ::= expr(A) STAR|SLASH|REM(OP) expr(Y). {
+  z := exprSpanStart(pParse, A);
+  A = sqlite3PExpr(pParse,int(@OP),A,Y);
+  exprSpan(pParse, A, z);
+}
+expr(A) ::= expr(A) CONCAT(OP) expr(Y). {
+  z := exprSpanStart(pParse, A);
+  A = sqlite3PExpr(pParse,int(@OP),A,Y);
+  exprSpan(pParse, A, z);
+}
 %type likeop {Token}
 likeop(A) ::= LIKE_KW|MATCH(A).
 likeop(A) ::= NOT LIKE_KW|MATCH(X). {A=X; A.n|=0x80000000; /*A-overwrite-X*/}
 expr(A) ::= expr(A) likeop(OP) expr(Y).  [LIKE_KW]  {
   var pList *ExprList;
+  z := exprSpanStart(pParse, A);
   bNot := int(OP.n & 0x80000000);
   OP.n &= 0x7fffffff;
-  pList = sqlite3ExprListAppend(pParse,0, Y);
+  pList = sqlite3ExprListAppend(pParse,nil, Y);
   pList = sqlite3ExprListAppend(pParse,pList, A);
   A = sqlite3ExprFunction(pParse, pList, &OP, 0);
-  if( bNot ) {
-    A = sqlite3PExpr(pParse, TK_NOT, A, 0);
+  exprSpan(pParse, A, z);
+  if( bNot!=0 ) {
+    A = sqlite3PExpr(pParse, TK_NOT, A, nil);
+    exprSpan(pParse, A, z);
   }
-  if( A ) {
+  if( A!=nil ) {
     A.flags |= EP_InfixFunc;
   }
 }
 expr(A) ::= expr(A) likeop(OP) expr(Y) ESCAPE expr(E).  [LIKE_KW]  {
   var pList *ExprList;
+  z := exprSpanStart(pParse, A);
   bNot := int(OP.n & 0x80000000);
   OP.n &= 0x7fffffff;
-  pList = sqlite3ExprListAppend(pParse,0, Y);
+  pList = sqlite3ExprListAppend(pParse,nil, Y);
   pList = sqlite3ExprListAppend(pParse,pList, A);
   pList = sqlite3ExprListAppend(pParse,pList, E);
   A = sqlite3ExprFunction(pParse, pList, &OP, 0);
-  if( bNot ) {
-    A = sqlite3PExpr(pParse, TK_NOT, A, 0);
+  exprSpan(pParse, A, z);
+  if( bNot!=0 ) {
+    A = sqlite3PExpr(pParse, TK_NOT, A, nil);
+    exprSpan(pParse, A, z);
   }
-  if( A ) {
+  if( A!=nil ) {
     A.flags |= EP_InfixFunc;
   }
 }
 
-expr(A) ::= expr(A) ISNULL|NOTNULL(E).   {A = sqlite3PExpr(pParse,@E,A,0);}
-expr(A) ::= expr(A) NOT NULL.    {A = sqlite3PExpr(pParse,TK_NOTNULL,A,0);}
+expr(A) ::= expr(A) ISNULL|NOTNULL(E). {
+  z := exprSpanStart(pParse, A);
+  A = sqlite3PExpr(pParse,int(@E),A,nil);
+  exprSpan(pParse, A, z);
+}
+expr(A) ::= expr(A) NOT NULL. {
+  z := exprSpanStart(pParse, A);
+  A = sqlite3PExpr(pParse,TK_NOTNULL,A,nil);
+  exprSpan(pParse, A, z);
+}
 
 %include {
   /* A routine to convert a binary TK_IS or TK_ISNOT expression into a
   ** unary TK_ISNULL or TK_NOTNULL expression. */
   func binaryToUnaryIfNull(pParse *Parse, pY *Expr, pA *Expr, op int){
-    // sqlite3 *db = pParse.db;
-    // if( pA && pY && pY->op==TK_NULL && !IN_RENAME_OBJECT ){
-    //   pA->op = (u8)op;
-    //   sqlite3ExprDelete(db, pA->pRight);
-    //   pA->pRight = 0;
-    // }
+    db := pParse.db;
+    if( pA!=nil && pY!=nil && pY.op==TK_NULL && pParse.eParseMode<PARSE_MODE_RENAME ){
+      pA.op = uint8(op);
+      sqlite3ExprDelete(db, pA.pRight);
+      pA.pRight = nil;
+    }
   }
 }
 
@@ -1261,43 +1348,71 @@ expr(A) ::= expr(A) NOT NULL.    {A = sqlite3PExpr(pParse,TK_NOTNULL,A,0);}
 // is any other expression, code as TK_IS or TK_ISNOT.
 // 
 expr(A) ::= expr(A) IS expr(Y).     {
+  z := exprSpanStart(pParse, A);
   A = sqlite3PExpr(pParse,TK_IS,A,Y);
   binaryToUnaryIfNull(pParse, Y, A, TK_ISNULL);
+  exprSpan(pParse, A, z);
 }
 expr(A) ::= expr(A) IS NOT expr(Y). {
+  z := exprSpanStart(pParse, A);
+  A = sqlite3PExpr(pParse,TK_ISNOT,A,Y);
+  binaryToUnaryIfNull(pParse, Y, A, TK_NOTNULL);
+  exprSpan(pParse, A, z);
+}
+expr(A) ::= expr(A) IS(I) NOT DISTINCT FROM(F) expr(Y). {
+  z := exprSpanStart(pParse, A);
+  sqlite3CheckVersion(pParse, 3039000, "IS NOT DISTINCT FROM", &I, &F);
+  A = sqlite3PExpr(pParse,TK_IS,A,Y);
+  binaryToUnaryIfNull(pParse, Y, A, TK_ISNULL);
+  exprSpan(pParse, A, z);
+}
+expr(A) ::= expr(A) IS(I) DISTINCT FROM(F) expr(Y). {
+  z := exprSpanStart(pParse, A);
+  sqlite3CheckVersion(pParse, 3039000, "IS DISTINCT FROM", &I, &F);
   A = sqlite3PExpr(pParse,TK_ISNOT,A,Y);
   binaryToUnaryIfNull(pParse, Y, A, TK_NOTNULL);
+  exprSpan(pParse, A, z);
 }
 
-expr(A) ::= NOT(B) expr(X).  
-              {A = sqlite3PExpr(pParse, @B, X, 0);/*A-overwrites-B*/}
-expr(A) ::= BITNOT(B) expr(X).
-              {A = sqlite3PExpr(pParse, @B, X, 0);/*A-overwrites-B*/}
+expr(A) ::= NOT(B) expr(X). {
+  A = sqlite3PExpr(pParse, int(@B), X, nil);
+  exprSpan(pParse, A, B.z);
+}
+expr(A) ::= BITNOT(B) expr(X). {
+  A = sqlite3PExpr(pParse, int(@B), X, nil);
+  exprSpan(pParse, A, B.z);
+}
 expr(A) ::= PLUS|MINUS(B) expr(X). [BITNOT] {
-  // A = sqlite3PExpr(pParse, @B==TK_PLUS ? TK_UPLUS : TK_UMINUS, X, 0);
-  /*A-overwrites-B*/
+  op := TK_UMINUS
+  if( @B==TK_PLUS ) { op = TK_UPLUS }
+  A = sqlite3PExpr(pParse, op, X, nil);
+  exprSpan(pParse, A, B.z);
 }
 
 expr(A) ::= expr(B) PTR(C) expr(D). {
-  ExprList *pList = sqlite3ExprListAppend(pParse, 0, B);
-  pList = sqlite3ExprListAppend(pParse, pList, D);
-  A = sqlite3ExprFunction(pParse, pList, &C, 0);
+  z := exprSpanStart(pParse, B);
+  sqlite3CheckVersion(pParse, 3038000, string(C.z[:C.n]), &C, nil);
+  A = sqlite3ExprPtr(pParse, B, &C, D);
+  exprSpan(pParse, A, z);
 }
 
 %type between_op {int}
 between_op(A) ::= BETWEEN.     {A = 0;}
 between_op(A) ::= NOT BETWEEN. {A = 1;}
 expr(A) ::= expr(A) between_op(N) expr(X) AND expr(Y). [BETWEEN] {
-  pList := sqlite3ExprListAppend(pParse,0, X);
+  z := exprSpanStart(pParse, A);
+  pList := sqlite3ExprListAppend(pParse,nil, X);
   pList = sqlite3ExprListAppend(pParse,pList, Y);
-  A = sqlite3PExpr(pParse, TK_BETWEEN, A, 0);
-  if( A ){
+  A = sqlite3PExpr(pParse, TK_BETWEEN, A, nil);
+  if( A!=nil ){
     A.x.pList = pList;
   }else{
     sqlite3ExprListDelete(pParse.db, pList);
   } 
-  if( N ) {
-    A = sqlite3PExpr(pParse, TK_NOT, A, 0);
+  exprSpan(pParse, A, z);
+  if( N!=0 ) {
+    A = sqlite3PExpr(pParse, TK_NOT, A, nil);
+    exprSpan(pParse, A, z);
   }
 }
 %ifndef SQLITE_OMIT_SUBQUERY
@@ -1305,7 +1420,8 @@ expr(A) ::= expr(A) between_op(N) expr(X) AND expr(Y). [BETWEEN] {
   in_op(A) ::= IN.      {A = 0;}
   in_op(A) ::= NOT IN.  {A = 1;}
   expr(A) ::= expr(A) in_op(N) LP exprlist(Y) RP. [IN] {
-    if( Y==0 ){
+    z := exprSpanStart(pParse, A);
+    if( Y==nil ){
       /* Expressions of the form
       **
       **      expr1 IN ()
@@ -1316,25 +1432,25 @@ expr(A) ::= expr(A) between_op(N) expr(X) AND expr(Y). [BETWEEN] {
       */
       sqlite3ExprUnmapAndDelete(pParse, A);
       cnt := "0"
-      if (N) {
+      if( N!=0 ){
         cnt = "1"
       }
-      A = sqlite3Expr(pParse.db, TK_INTEGER, cnt);
+      A = sqlite3Expr(pParse.db, TK_INTEGER, []byte(cnt));
     }else{
       pRHS := Y.a[0].pExpr;
-      if( Y.nExpr==1 && sqlite3ExprIsConstant(pRHS) && A.op!=TK_VECTOR ){
-        Y.a[0].pExpr = 0;
+      if( Y.nExpr==1 && sqlite3ExprIsConstant(pRHS)!=0 && A.op!=TK_VECTOR ){
+        Y.a[0].pExpr = nil;
         sqlite3ExprListDelete(pParse.db, Y);
-        pRHS = sqlite3PExpr(pParse, TK_UPLUS, pRHS, 0);
+        pRHS = sqlite3PExpr(pParse, TK_UPLUS, pRHS, nil);
         A = sqlite3PExpr(pParse, TK_EQ, A, pRHS);
       }else{
-        A = sqlite3PExpr(pParse, TK_IN, A, 0);
-        if( A==0 ){
+        A = sqlite3PExpr(pParse, TK_IN, A, nil);
+        if( A==nil ){
           sqlite3ExprListDelete(pParse.db, Y);
         }else if( A.pLeft.op==TK_VECTOR ){
           nExpr := A.pLeft.x.pList.nExpr;
           pSelectRHS := sqlite3ExprListToValues(pParse, nExpr, Y);
-          if( pSelectRHS ){
+          if( pSelectRHS!=nil ){
             parserDoubleLinkSelect(pParse, pSelectRHS);
             sqlite3PExprAddSelect(pParse, A, pSelectRHS);
           }
@@ -1343,51 +1459,62 @@ expr(A) ::= expr(A) between_op(N) expr(X) AND expr(Y). [BETWEEN] {
           sqlite3ExprSetHeightAndFlags(pParse, A);
         }
       }
-      if( N ) {
-        A = sqlite3PExpr(pParse, TK_NOT, A, 0);
+      exprSpan(pParse, A, z);
+      if( N!=0 ) {
+        A = sqlite3PExpr(pParse, TK_NOT, A, nil);
       }
     }
+    exprSpan(pParse, A, z);
   }
-  expr(A) ::= LP select(X) RP. {
-    A = sqlite3PExpr(pParse, TK_SELECT, 0, 0);
+  expr(A) ::= LP(B) select(X) RP. {
+    A = sqlite3PExpr(pParse, TK_SELECT, nil, nil);
     sqlite3PExprAddSelect(pParse, A, X);
+    exprSpan(pParse, A, B.z);
   }
   expr(A) ::= expr(A) in_op(N) LP select(Y) RP.  [IN] {
-    A = sqlite3PExpr(pParse, TK_IN, A, 0);
+    z := exprSpanStart(pParse, A);
+    A = sqlite3PExpr(pParse, TK_IN, A, nil);
     sqlite3PExprAddSelect(pParse, A, Y);
-    if( N ) {
-      A = sqlite3PExpr(pParse, TK_NOT, A, 0);
+    exprSpan(pParse, A, z);
+    if( N!=0 ) {
+      A = sqlite3PExpr(pParse, TK_NOT, A, nil);
+      exprSpan(pParse, A, z);
     }
   }
   expr(A) ::= expr(A) in_op(N) nm(Y) dbnm(Z) paren_exprlist(E). [IN] {
-    pSrc := sqlite3SrcListAppend(pParse, 0,&Y,&Z);
-    pSelect := sqlite3SelectNew(pParse, 0,pSrc,0,0,0,0,0,0);
-    if( E ) {
+    z := exprSpanStart(pParse, A);
+    pSrc := sqlite3SrcListAppend(pParse, nil,&Y,&Z);
+    pSelect := sqlite3SelectNew(pParse, nil,pSrc,nil,nil,nil,nil,0,nil);
+    if( E!=nil ) {
       if (pSelect != nil) {
         sqlite3SrcListFuncArgs(pParse, pSrc, E);
       } else {
         sqlite3SrcListFuncArgs(pParse, nil, E);
       }
     }
-    A = sqlite3PExpr(pParse, TK_IN, A, 0);
+    A = sqlite3PExpr(pParse, TK_IN, A, nil);
     sqlite3PExprAddSelect(pParse, A, pSelect);
-    if( N ) {
-      A = sqlite3PExpr(pParse, TK_NOT, A, 0);
+    exprSpan(pParse, A, z);
+    if( N!=0 ) {
+      A = sqlite3PExpr(pParse, TK_NOT, A, nil);
+      exprSpan(pParse, A, z);
     }
   }
-  expr(A) ::= EXISTS LP select(Y) RP. {
+  expr(A) ::= EXISTS(B) LP select(Y) RP. {
     var p *Expr;
-    A = sqlite3PExpr(pParse, TK_EXISTS, 0, 0);
+    A = sqlite3PExpr(pParse, TK_EXISTS, nil, nil);
     p = A
     sqlite3PExprAddSelect(pParse, p, Y);
+    exprSpan(pParse, A, B.z);
   }
 %endif SQLITE_OMIT_SUBQUERY
 
 /* CASE expressions */
-expr(A) ::= CASE case_operand(X) case_exprlist(Y) case_else(Z) END. {
-  A = sqlite3PExpr(pParse, TK_CASE, X, 0);
-  if( A ){
-    if( Z ) {
+expr(A) ::= CASE(B) case_operand(X) case_exprlist(Y) case_else(Z) END. {
+  A = sqlite3PExpr(pParse, TK_CASE, X, nil);
+  exprSpan(pParse, A, B.z);
+  if( A!=nil ){
+    if( Z!=nil ) {
       A.x.pList = sqlite3ExprListAppend(pParse,Y,Z);
     } else {
       A.x.pList = Y;
@@ -1405,17 +1532,17 @@ case_exprlist(A) ::= case_exprlist(A) WHEN expr(Y) THEN expr(Z). {
   A = sqlite3ExprListAppend(pParse,A, Z);
 }
 case_exprlist(A) ::= WHEN expr(Y) THEN expr(Z). {
-  A = sqlite3ExprListAppend(pParse,0, Y);
+  A = sqlite3ExprListAppend(pParse,nil, Y);
   A = sqlite3ExprListAppend(pParse,A, Z);
 }
 %type case_else {*Expr}
 %destructor case_else {sqlite3ExprDelete(pParse.db, $$);}
 case_else(A) ::=  ELSE expr(X).         {A = X;}
-case_else(A) ::=  .                     {A = 0;} 
+case_else(A) ::=  .                     {A = nil;} 
 %type case_operand {*Expr}
 %destructor case_operand {sqlite3ExprDelete(pParse.db, $$);}
-case_operand(A) ::= expr(X).            {A = X; /*A-overwrites-X*/} 
-case_operand(A) ::= .                   {A = 0;} 
+case_operand(A) ::= expr(A).
+case_operand(A) ::= .                   {A = nil;} 
 
 %type exprlist {*ExprList}
 %destructor exprlist {sqlite3ExprListDelete(pParse.db, $$);}
@@ -1423,18 +1550,18 @@ case_operand(A) ::= .                   {A = 0;}
 %destructor nexprlist {sqlite3ExprListDelete(pParse.db, $$);}
 
 exprlist(A) ::= nexprlist(A).
-exprlist(A) ::= .                            {A = 0;}
+exprlist(A) ::= .                            {A = nil;}
 nexprlist(A) ::= nexprlist(A) COMMA expr(Y).
     {A = sqlite3ExprListAppend(pParse,A,Y);}
 nexprlist(A) ::= expr(Y).
-    {A = sqlite3ExprListAppend(pParse,0,Y); /*A-overwrites-Y*/}
+    {A = sqlite3ExprListAppend(pParse,nil,Y); /*A-overwrites-Y*/}
 
 %ifndef SQLITE_OMIT_SUBQUERY
 /* A paren_exprlist is an optional expression list contained inside
 ** of parenthesis */
 %type paren_exprlist {*ExprList}
 %destructor paren_exprlist {sqlite3ExprListDelete(pParse.db, $$);}
-paren_exprlist(A) ::= .   {A = 0;}
+paren_exprlist(A) ::= .   {A = nil;}
 paren_exprlist(A) ::= LP exprlist(X) RP.  {A = X;}
 %endif SQLITE_OMIT_SUBQUERY
 
@@ -1444,9 +1571,9 @@ paren_exprlist(A) ::= LP exprlist(X) RP.  {A = X;}
 cmd ::= createkw(S) uniqueflag(U) INDEX ifnotexists(NE) nm(X) dbnm(D)
         ON nm(Y) LP sortlist(Z) RP where_opt(W). {
   sqlite3CreateIndex(pParse, &X, &D, 
-                     sqlite3SrcListAppend(pParse,0,&Y,0), Z, U,
+                     sqlite3SrcListAppend(pParse,nil,&Y,nil), Z, U,
                       &S, W, SQLITE_SO_ASC, NE, SQLITE_IDXTYPE_APPDEF);
-  if( IN_RENAME_OBJECT && pParse.pNewIndex ){
+  if( pParse.eParseMode>=PARSE_MODE_RENAME && pParse.pNewIndex!=nil ){
     sqlite3RenameTokenMap(pParse, pParse.pNewIndex.zName, &Y);
   }
 }
@@ -1489,25 +1616,24 @@ uniqueflag(A) ::= .        {A = OE_None;}
     hasCollate int,
     sortOrder int,
   ) *ExprList {
-    // ExprList *p = sqlite3ExprListAppend(pParse, pPrior, 0);
-    // if( (hasCollate || sortOrder!=SQLITE_SO_UNDEFINED)
-    //     && pParse.db->init.busy==0
-    // ){
-    //   sqlite3ErrorMsg(pParse, "syntax error after column name \"%.*s\"",
-    //                      pIdToken->n, pIdToken->z);
-    // }
-    // sqlite3ExprListSetName(pParse, p, pIdToken, 1);
-    return nil;
+    p := sqlite3ExprListAppend(pParse, pPrior, nil);
+    if( (hasCollate!=0 || sortOrder!=SQLITE_SO_UNDEFINED) &&
+        pParse.db.init.busy==0 ){
+      sqlite3ErrorMsg(pParse, "syntax error after column name \"%T\"",
+                         pIdToken);
+    }
+    sqlite3ExprListSetName(pParse, p, pIdToken, 1);
+    return p;
   }
 } // end %include
 
-eidlist_opt(A) ::= .                         {A = 0;}
+eidlist_opt(A) ::= .                         {A = nil;}
 eidlist_opt(A) ::= LP eidlist(X) RP.         {A = X;}
 eidlist(A) ::= eidlist(A) COMMA nm(Y) collate(C) sortorder(Z).  {
   A = parserAddExprIdListTerm(pParse, A, &Y, C, Z);
 }
 eidlist(A) ::= nm(Y) collate(C) sortorder(Z). {
-  A = parserAddExprIdListTerm(pParse, 0, &Y, C, Z); /*A-overwrites-Y*/
+  A = parserAddExprIdListTerm(pParse, nil, &Y, C, Z); /*A-overwrites-Y*/
 }
 
 %type collate {int}
@@ -1524,16 +1650,16 @@ cmd ::= DROP INDEX ifexists(E) fullname(X).   {sqlite3DropIndex(pParse, X, E);}
 %if !SQLITE_OMIT_VACUUM && !SQLITE_OMIT_ATTACH
 %type vinto {*Expr}
 %destructor vinto {sqlite3ExprDelete(pParse.db, $$);}
-cmd ::= VACUUM vinto(Y).                {sqlite3Vacuum(pParse,0,Y);}
+cmd ::= VACUUM vinto(Y).                {sqlite3Vacuum(pParse,nil,Y);}
 cmd ::= VACUUM nm(X) vinto(Y).          {sqlite3Vacuum(pParse,&X,Y);}
 vinto(A) ::= INTO expr(X).              {A = X;}
-vinto(A) ::= .                          {A = 0;}
+vinto(A) ::= .                          {A = nil;}
 %endif
 
 ///////////////////////////// The PRAGMA command /////////////////////////////
 //
 %ifndef SQLITE_OMIT_PRAGMA
-cmd ::= PRAGMA nm(X) dbnm(Z).                {sqlite3Pragma(pParse,&X,&Z,0,0);}
+cmd ::= PRAGMA nm(X) dbnm(Z).                {sqlite3Pragma(pParse,&X,&Z,nil,0);}
 cmd ::= PRAGMA nm(X) dbnm(Z) EQ nmnum(Y).    {sqlite3Pragma(pParse,&X,&Z,&Y,0);}
 cmd ::= PRAGMA nm(X) dbnm(Z) LP nmnum(Y) RP. {sqlite3Pragma(pParse,&X,&Z,&Y,0);}
 cmd ::= PRAGMA nm(X) dbnm(Z) EQ minus_num(Y). 
@@ -1558,7 +1684,7 @@ minus_num(A) ::= MINUS number(X).     {A = X;}
 cmd ::= createkw trigger_decl(A) BEGIN trigger_cmd_list(S) END(Z). {
   var all Token;
   all.z = A.z;
-  all.n = int((Z.z - A.z) + Z.n);
+  all.n = uint(len(A.z)-len(Z.z)) + Z.n;
   sqlite3FinishTrigger(pParse, S, &all);
 }
 
@@ -1574,14 +1700,14 @@ trigger_decl(A) ::= temp(T) TRIGGER ifnotexists(NOERR) nm(B) dbnm(Z)
 }
 
 %type trigger_time {int}
-trigger_time(A) ::= BEFORE|AFTER(X).  { A = @X; /*A-overwrites-X*/ }
+trigger_time(A) ::= BEFORE|AFTER(X).  { A = int(@X); /*A-overwrites-X*/ }
 trigger_time(A) ::= INSTEAD OF.  { A = TK_INSTEAD;}
 trigger_time(A) ::= .            { A = TK_BEFORE; }
 
 %type trigger_event {TrigEvent}
 %destructor trigger_event {sqlite3IdListDelete(pParse.db, $$.b);}
-trigger_event(A) ::= DELETE|INSERT(X).   {A.a = @X; /*A-overwrites-X*/ A.b = 0;}
-trigger_event(A) ::= UPDATE(X).          {A.a = @X; /*A-overwrites-X*/ A.b = 0;}
+trigger_event(A) ::= DELETE|INSERT(X).   {A.a = int(@X); /*A-overwrites-X*/ A.b = nil;}
+trigger_event(A) ::= UPDATE(X).          {A.a = int(@X); /*A-overwrites-X*/ A.b = nil;}
 trigger_event(A) ::= UPDATE OF idlist(X).{A.a = TK_UPDATE; A.b = X;}
 
 foreach_clause ::= .
@@ -1589,18 +1715,18 @@ foreach_clause ::= FOR EACH ROW.
 
 %type when_clause {*Expr}
 %destructor when_clause {sqlite3ExprDelete(pParse.db, $$);}
-when_clause(A) ::= .             { A = 0; }
+when_clause(A) ::= .             { A = nil; }
 when_clause(A) ::= WHEN expr(X). { A = X; }
 
 %type trigger_cmd_list {*TriggerStep}
 %destructor trigger_cmd_list {sqlite3DeleteTriggerStep(pParse.db, $$);}
 trigger_cmd_list(A) ::= trigger_cmd_list(A) trigger_cmd(X) SEMI. {
-  assert( A!=0, "A!=0");
+  assert( A!=nil, "A!=nil");
   A.pLast.pNext = X;
   A.pLast = X;
 }
 trigger_cmd_list(A) ::= trigger_cmd(A) SEMI. { 
-  assert( A!=0, "A!=0");
+  assert( A!=nil, "A!=nil");
   A.pLast = A;
 }
 
@@ -1656,17 +1782,19 @@ trigger_cmd(A) ::= scanpt(B) select(X) scanpt(E).
    {A = sqlite3TriggerSelectStep(pParse.db, X, B, E); /*A-overwrites-X*/}
 
 // The special RAISE expression that may occur in trigger programs
-expr(A) ::= RAISE LP IGNORE RP.  {
-  A = sqlite3PExpr(pParse, TK_RAISE, 0, 0); 
-  if( A ){
+expr(A) ::= RAISE(B) LP IGNORE RP.  {
+  A = sqlite3PExpr(pParse, TK_RAISE, nil, nil); 
+  if( A!=nil ){
     A.affExpr = OE_Ignore;
   }
+  exprSpan(pParse, A, B.z);
 }
-expr(A) ::= RAISE LP raisetype(T) COMMA nm(Z) RP.  {
+expr(A) ::= RAISE(B) LP raisetype(T) COMMA nm(Z) RP.  {
   A = sqlite3ExprAlloc(pParse.db, TK_RAISE, &Z, 1);
-  if( A ) {
+  if( A!=nil ) {
     A.affExpr = rune(T);
   }
+  exprSpan(pParse, A, B.z);
 }
 %endif  !SQLITE_OMIT_TRIGGER
 
@@ -1694,7 +1822,7 @@ cmd ::= DETACH database_kw_opt expr(D). {
 
 %type key_opt {*Expr}
 %destructor key_opt {sqlite3ExprDelete(pParse.db, $$);}
-key_opt(A) ::= .                     { A = 0; }
+key_opt(A) ::= .                     { A = nil; }
 key_opt(A) ::= KEY expr(X).          { A = X; }
 
 database_kw_opt ::= DATABASE.
@@ -1703,13 +1831,13 @@ database_kw_opt ::= .
 
 ////////////////////////// REINDEX collation //////////////////////////////////
 %ifndef SQLITE_OMIT_REINDEX
-cmd ::= REINDEX.                {sqlite3Reindex(pParse, 0, 0);}
+cmd ::= REINDEX.                {sqlite3Reindex(pParse, nil, nil);}
 cmd ::= REINDEX nm(X) dbnm(Y).  {sqlite3Reindex(pParse, &X, &Y);}
 %endif  SQLITE_OMIT_REINDEX
 
 /////////////////////////////////// ANALYZE ///////////////////////////////////
 %ifndef SQLITE_OMIT_ANALYZE
-cmd ::= ANALYZE.                {sqlite3Analyze(pParse, 0, 0);}
+cmd ::= ANALYZE.                {sqlite3Analyze(pParse, nil, nil);}
 cmd ::= ANALYZE nm(X) dbnm(Y).  {sqlite3Analyze(pParse, &X, &Y);}
 %endif
 
@@ -1721,10 +1849,11 @@ cmd ::= ALTER TABLE fullname(X) RENAME TO nm(Z). {
 }
 cmd ::= ALTER TABLE add_column_fullname
         ADD kwcolumn_opt columnname(Y) carglist. {
-  Y.n = (int)(pParse.sLastToken.z-Y.z) + pParse.sLastToken.n;
+  Y.n = uint(len(Y.z)-len(pParse.sLastToken.z)) + pParse.sLastToken.n;
   sqlite3AlterFinishAddColumn(pParse, &Y);
 }
-cmd ::= ALTER TABLE fullname(X) DROP kwcolumn_opt nm(Y). {
+cmd ::= ALTER TABLE fullname(X) DROP(D) kwcolumn_opt nm(Y). {
+  sqlite3CheckVersion(pParse, 3035000, "DROP COLUMN", &D, &Y);
   sqlite3AlterDropColumn(pParse, X, &Y);
 }
 
@@ -1732,7 +1861,8 @@ add_column_fullname ::= fullname(X). {
   disableLookaside(pParse);
   sqlite3AlterBeginAddColumn(pParse, X);
 }
-cmd ::= ALTER TABLE fullname(X) RENAME kwcolumn_opt nm(Y) TO nm(Z). {
+cmd ::= ALTER TABLE fullname(X) RENAME(R) kwcolumn_opt nm(Y) TO nm(Z). {
+  sqlite3CheckVersion(pParse, 3025000, "RENAME COLUMN", &R, &Z);
   sqlite3AlterRenameColumn(pParse, X, &Y, &Z);
 }
 
@@ -1744,7 +1874,7 @@ kwcolumn_opt ::= COLUMNKW.
 
 //////////////////////// CREATE VIRTUAL TABLE ... /////////////////////////////
 %ifndef SQLITE_OMIT_VIRTUALTABLE
-cmd ::= create_vtab.                       {sqlite3VtabFinishParse(pParse,0);}
+cmd ::= create_vtab.                       {sqlite3VtabFinishParse(pParse,nil);}
 cmd ::= create_vtab LP vtabarglist RP(X).  {sqlite3VtabFinishParse(pParse,&X);}
 create_vtab ::= createkw VIRTUAL TABLE ifnotexists(E)
                 nm(X) dbnm(Y) USING nm(Z). {
@@ -1776,13 +1906,19 @@ with ::= WITH RECURSIVE wqlist(W).    { sqlite3WithPush(pParse, W, 1); }
 
 %type wqas {uint8}
 wqas(A)   ::= AS.                  {A = M10d_Any;}
-wqas(A)   ::= AS MATERIALIZED.     {A = M10d_Yes;}
-wqas(A)   ::= AS NOT MATERIALIZED. {A = M10d_No;}
+wqas(A)   ::= AS(X) MATERIALIZED(Y).     {
+  sqlite3CheckVersion(pParse, 3035000, "MATERIALIZED", &X, &Y);
+  A = M10d_Yes;
+}
+wqas(A)   ::= AS(X) NOT MATERIALIZED(Y). {
+  sqlite3CheckVersion(pParse, 3035000, "NOT MATERIALIZED", &X, &Y);
+  A = M10d_No;
+}
 wqitem(A) ::= nm(X) eidlist_opt(Y) wqas(M) LP select(Z) RP. {
   A = sqlite3CteNew(pParse, &X, Y, Z, M); /*A-overwrites-X*/
 }
 wqlist(A) ::= wqitem(X). {
-  A = sqlite3WithAdd(pParse, 0, X); /*A-overwrites-X*/
+  A = sqlite3WithAdd(pParse, nil, X); /*A-overwrites-X*/
 }
 wqlist(A) ::= wqlist(A) COMMA wqitem(X). {
   A = sqlite3WithAdd(pParse, A, X);
@@ -1800,7 +1936,7 @@ wqlist(A) ::= wqlist(A) COMMA wqitem(X). {
 %destructor windowdefn_list {sqlite3WindowListDelete(pParse.db, $$);}
 windowdefn_list(A) ::= windowdefn(Z). { A = Z; }
 windowdefn_list(A) ::= windowdefn_list(Y) COMMA windowdefn(Z). {
-  assert( Z!=0, "Z!=0");
+  assert( Z!=nil, "Z!=nil");
   sqlite3WindowChain(pParse, Z, Y);
   Z.pNextWin = Y;
   A = Z;
@@ -1809,7 +1945,7 @@ windowdefn_list(A) ::= windowdefn_list(Y) COMMA windowdefn(Z). {
 %type windowdefn {*Window}
 %destructor windowdefn {sqlite3WindowDelete(pParse.db, $$);}
 windowdefn(A) ::= nm(X) AS LP window(Y) RP. {
-  if( ALWAYS(Y) ){
+  if( ALWAYS(Y!=nil) ){
     Y.zName = sqlite3DbStrNDup(pParse.db, X.z, X.n);
   }
   A = Y;
@@ -1843,62 +1979,65 @@ windowdefn(A) ::= nm(X) AS LP window(Y) RP. {
 %destructor frame_bound_e {sqlite3ExprDelete(pParse.db, $$.pExpr);}
 
 window(A) ::= PARTITION BY nexprlist(X) orderby_opt(Y) frame_opt(Z). {
-  A = sqlite3WindowAssemble(pParse, Z, X, Y, 0);
+  A = sqlite3WindowAssemble(pParse, Z, X, Y, nil);
 }
 window(A) ::= nm(W) PARTITION BY nexprlist(X) orderby_opt(Y) frame_opt(Z). {
   A = sqlite3WindowAssemble(pParse, Z, X, Y, &W);
 }
 window(A) ::= ORDER BY sortlist(Y) frame_opt(Z). {
-  A = sqlite3WindowAssemble(pParse, Z, 0, Y, 0);
+  A = sqlite3WindowAssemble(pParse, Z, nil, Y, nil);
 }
 window(A) ::= nm(W) ORDER BY sortlist(Y) frame_opt(Z). {
-  A = sqlite3WindowAssemble(pParse, Z, 0, Y, &W);
+  A = sqlite3WindowAssemble(pParse, Z, nil, Y, &W);
 }
 window(A) ::= frame_opt(Z). {
   A = Z;
 }
 window(A) ::= nm(W) frame_opt(Z). {
-  A = sqlite3WindowAssemble(pParse, Z, 0, 0, &W);
+  A = sqlite3WindowAssemble(pParse, Z, nil, nil, &W);
 }
 
 frame_opt(A) ::= .                             { 
-  A = sqlite3WindowAlloc(pParse, 0, TK_UNBOUNDED, 0, TK_CURRENT, 0, 0);
+  A = sqlite3WindowAlloc(pParse, 0, TK_UNBOUNDED, nil, TK_CURRENT, nil, 0);
 }
 frame_opt(A) ::= range_or_rows(X) frame_bound_s(Y) frame_exclude_opt(Z). { 
-  A = sqlite3WindowAlloc(pParse, X, Y.eType, Y.pExpr, TK_CURRENT, 0, Z);
+  A = sqlite3WindowAlloc(pParse, X, Y.eType, Y.pExpr, TK_CURRENT, nil, Z);
 }
 frame_opt(A) ::= range_or_rows(X) BETWEEN frame_bound_s(Y) AND
                           frame_bound_e(Z) frame_exclude_opt(W). { 
   A = sqlite3WindowAlloc(pParse, X, Y.eType, Y.pExpr, Z.eType, Z.pExpr, W);
 }
 
-range_or_rows(A) ::= RANGE|ROWS|GROUPS(X).   {A = @X; /*A-overwrites-X*/}
+range_or_rows(A) ::= RANGE|ROWS|GROUPS(X).   {A = int(@X); /*A-overwrites-X*/}
 
 frame_bound_s(A) ::= frame_bound(X).         {A = X;}
-frame_bound_s(A) ::= UNBOUNDED(X) PRECEDING. {A.eType = @X; A.pExpr = 0;}
+frame_bound_s(A) ::= UNBOUNDED(X) PRECEDING. {A.eType = int(@X); A.pExpr = nil;}
 frame_bound_e(A) ::= frame_bound(X).         {A = X;}
-frame_bound_e(A) ::= UNBOUNDED(X) FOLLOWING. {A.eType = @X; A.pExpr = 0;}
+frame_bound_e(A) ::= UNBOUNDED(X) FOLLOWING. {A.eType = int(@X); A.pExpr = nil;}
 
 frame_bound(A) ::= expr(X) PRECEDING|FOLLOWING(Y).
-                                             {A.eType = @Y; A.pExpr = X;}
-frame_bound(A) ::= CURRENT(X) ROW.           {A.eType = @X; A.pExpr = 0;}
+                                             {A.eType = int(@Y); A.pExpr = X;}
+frame_bound(A) ::= CURRENT(X) ROW.           {A.eType = int(@X); A.pExpr = nil;}
 
 %type frame_exclude_opt {uint8}
 frame_exclude_opt(A) ::= . {A = 0;}
 frame_exclude_opt(A) ::= EXCLUDE frame_exclude(X). {A = X;}
 
 %type frame_exclude {uint8}
-frame_exclude(A) ::= NO(X) OTHERS.   {A = @X; /*A-overwrites-X*/}
-frame_exclude(A) ::= CURRENT(X) ROW. {A = @X; /*A-overwrites-X*/}
-frame_exclude(A) ::= GROUP|TIES(X).  {A = @X; /*A-overwrites-X*/}
+frame_exclude(A) ::= NO(X) OTHERS.   {A = uint8(@X); /*A-overwrites-X*/}
+frame_exclude(A) ::= CURRENT(X) ROW. {A = uint8(@X); /*A-overwrites-X*/}
+frame_exclude(A) ::= GROUP|TIES(X).  {A = uint8(@X); /*A-overwrites-X*/}
 
 
 %type window_clause {*Window}
 %destructor window_clause {sqlite3WindowListDelete(pParse.db, $$);}
-window_clause(A) ::= WINDOW windowdefn_list(B). { A = B; }
+window_clause(A) ::= WINDOW(W) windowdefn_list(B). {
+  sqlite3CheckVersion(pParse, 3025000, "window functions", &W, nil);
+  A = B;
+}
 
 filter_over(A) ::= filter_clause(F) over_clause(O). {
-  if( O ){
+  if( O!=nil ){
     O.pFilter = F;
   }else{
     sqlite3ExprDelete(pParse.db, F);
@@ -1909,8 +2048,8 @@ filter_over(A) ::= over_clause(O). {
   A = O;
 }
 filter_over(A) ::= filter_clause(F). {
-  A = sqlite3DbMallocZero(pParse.db, sizeof(Window)).(*Window);
-  if( A ){
+  A = &Window{};
+  if( A!=nil ){
     A.eFrmType = TK_FILTER;
     A.pFilter = F;
   }else{
@@ -1918,18 +2057,23 @@ filter_over(A) ::= filter_clause(F). {
   }
 }
 
-over_clause(A) ::= OVER LP window(Z) RP. {
+over_clause(A) ::= OVER(O) LP window(Z) RP(R). {
+  sqlite3CheckVersion(pParse, 3025000, "window functions", &O, &R);
   A = Z;
-  assert( A!=0, "A!=0");
+  assert( A!=nil, "A!=nil");
 }
-over_clause(A) ::= OVER nm(Z). {
-  A = sqlite3DbMallocZero(pParse.db, sizeof(Window)).(*Window);
-  if( A ){
+over_clause(A) ::= OVER(O) nm(Z). {
+  sqlite3CheckVersion(pParse, 3025000, "window functions", &O, &Z);
+  A = &Window{};
+  if( A!=nil ){
     A.zName = sqlite3DbStrNDup(pParse.db, Z.z, Z.n);
   }
 }
 
-filter_clause(A) ::= FILTER LP WHERE expr(X) RP.  { A = X; }
+filter_clause(A) ::= FILTER(F) LP WHERE expr(X) RP(R).  {
+  sqlite3CheckVersion(pParse, 3030000, "FILTER", &F, &R);
+  A = X;
+}
 %endif /* SQLITE_OMIT_WINDOWFUNC */
 
 /*
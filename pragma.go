@@ -0,0 +1,84 @@
+/*
+** 2003 April 6
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains code used to implement the PRAGMA command.
+ */
+package internal
+
+/*
+** The Go port has no pragmas to run.  Instead the parse tree of a PRAGMA
+** statement is recorded in one of these objects and attached to the
+** Stmt.
+ */
+type Pragma struct {
+	zDb    []byte /* Name of the schema, or NULL */
+	zLeft  []byte /* Name of the pragma */
+	zRight []byte /* Value of the pragma, or NULL */
+}
+
+/*
+** Process a pragma statement.
+**
+** Pragmas are of this form:
+**
+**      PRAGMA [schema.]id [= value]
+**
+** The identifier might also be a string.  The value is a string, and
+** identifier, or a number.  If minusFlag is true, then the value is
+** a number that was preceded by a minus sign.
+**
+** If the left side is "database.id" then pId1 is the database name
+** and pId2 is the id.  If the left side is just "id" then pId1 is the
+** id and pId2 is any empty string.
+ */
+func sqlite3Pragma(
+	pParse *Parse,
+	pId1 *Token, /* First part of [schema.]id field */
+	pId2 *Token, /* Second part of [schema.]id field, or NULL */
+	pValue *Token, /* Token for <value>, or NULL */
+	minusFlag int, /* True if a '-' sign preceded <value> */
+) {
+	var p *Pragma
+	var zLeft []byte  /* Nul-terminated UTF-8 string <id> */
+	var zRight []byte /* Nul-terminated UTF-8 string <value>, or NULL */
+	var zDb []byte    /* The database name */
+	var pId *Token    /* Pointer to <id> token */
+	db := pParse.db
+
+	if sqlite3IsOmitted(pParse, OmitPragma, "PRAGMA") {
+		return
+	}
+
+	/* Interpret the [schema.] part of the pragma statement.  The port has
+	 ** no attached databases, so any schema name is accepted.
+	 */
+	if pId2 != nil && pId2.n > 0 {
+		zDb = sqlite3NameFromToken(db, pId1)
+		pId = pId2
+	} else {
+		pId = pId1
+	}
+	zLeft = sqlite3NameFromToken(db, pId)
+	if zLeft == nil {
+		return
+	}
+	if minusFlag != 0 {
+		zRight = sqlite3MPrintf(db, "-%T", pValue)
+	} else {
+		zRight = sqlite3NameFromToken(db, pValue)
+	}
+
+	p = &Pragma{}
+	p.zDb = zDb
+	p.zLeft = zLeft
+	p.zRight = zRight
+	pParse.pPragma = p
+}
@@ -0,0 +1,329 @@
+/*
+** 2005 May 25
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains the implementation of the sqlite3_prepare()
+** interface, and routines that contribute to loading the database schema
+** from disk.
+ */
+package internal
+
+import (
+	"strconv"
+	"sync"
+	"unsafe"
+)
+
+/*
+** Error is returned by ParseSQL when the SQL text cannot be parsed.
+** Code is one of the SQLITE_* result codes.  Offset is the byte offset
+** within the complete SQL text of the token that caused the error, or
+** -1 if the error is not associated with a particular token.
+ */
+type Error struct {
+	Code   int
+	Msg    string
+	Offset int
+}
+
+func (e *Error) Error() string {
+	if e.Offset >= 0 {
+		return e.Msg + " (offset " + strconv.Itoa(e.Offset) + ")"
+	}
+	return e.Msg
+}
+
+/*
+** Stmt describes one SQL statement found by ParseSQL.
+ */
+type Stmt struct {
+	zSql     []byte         /* Text of the statement, without the trailing ";" */
+	iOfst    int            /* Byte offset of zSql within the complete SQL text */
+	iBase    int            /* Offset that the offsets in Expr objects are from */
+	explain  uint8          /* 1 for EXPLAIN, 2 for EXPLAIN QUERY PLAN */
+	pSelect  *Select        /* The SELECT statement, if this is one */
+	pDelete  *Delete        /* The DELETE statement, if this is one */
+	pUpdate  *Update        /* The UPDATE statement, if this is one */
+	pInsert  *Insert        /* The INSERT statement, if this is one */
+	pTable   *Table         /* The table or view, if this is a CREATE */
+	pIndex   *Index         /* The index, if this is a CREATE INDEX */
+	pTrigger *Trigger       /* The trigger, if this is a CREATE TRIGGER */
+	pDrop    *Drop          /* The object dropped, if this is a DROP */
+	pAlter   *Alter         /* The ALTER TABLE statement, if this is one */
+	pPragma  *Pragma        /* The PRAGMA statement, if this is one */
+	pAttach  *Attach        /* The ATTACH or DETACH, if this is one */
+	aVersion []VersionIssue /* Constructs newer than the target version */
+}
+
+/* SQL returns the text of the statement */
+func (p *Stmt) SQL() string { return string(p.zSql) }
+
+/* Offset returns the byte offset of the statement in the parsed text */
+func (p *Stmt) Offset() int { return p.iOfst }
+
+/*
+** Select returns the parse tree of a SELECT statement, or nil if the
+** statement is not a SELECT.  The tree may be inspected, rendered with
+** its SQL method, or extended with the methods in builder.go.
+ */
+func (p *Stmt) Select() *Select { return p.pSelect }
+
+/* Insert returns the parse tree of an INSERT statement, or nil */
+func (p *Stmt) Insert() *Insert { return p.pInsert }
+
+/* Update returns the parse tree of an UPDATE statement, or nil */
+func (p *Stmt) Update() *Update { return p.pUpdate }
+
+/* Delete returns the parse tree of a DELETE statement, or nil */
+func (p *Stmt) Delete() *Delete { return p.pDelete }
+
+/* Table returns the table or view built by a CREATE TABLE or CREATE VIEW */
+func (p *Stmt) Table() *Table { return p.pTable }
+
+/* Index returns the index built by a CREATE INDEX statement, or nil */
+func (p *Stmt) Index() *Index { return p.pIndex }
+
+/* Trigger returns the trigger built by a CREATE TRIGGER statement, or nil */
+func (p *Stmt) Trigger() *Trigger { return p.pTrigger }
+
+/* Drop returns the object named by a DROP statement, or nil */
+func (p *Stmt) Drop() *Drop { return p.pDrop }
+
+/* Alter returns the parse tree of an ALTER TABLE statement, or nil */
+func (p *Stmt) Alter() *Alter { return p.pAlter }
+
+/* Pragma returns the parse tree of a PRAGMA statement, or nil */
+func (p *Stmt) Pragma() *Pragma { return p.pPragma }
+
+/* Attach returns the parse tree of an ATTACH or DETACH statement, or nil */
+func (p *Stmt) Attach() *Attach { return p.pAttach }
+
+/*
+** VersionIssues returns the constructs in the statement that are newer
+** than Options.TargetVersion, in the order they appear.
+ */
+func (p *Stmt) VersionIssues() []VersionIssue { return p.aVersion }
+
+/*
+** Compile the UTF-8 encoded SQL statement zSql into a statement handle.
+**
+** The Go port has no virtual machine, so "compiling" stops once the
+** parser has accepted a single statement.  On success the new Stmt is
+** written to *ppStmt and *pzTail is set to the unparsed remainder.
+**
+** The C version keeps its Parse object on the stack.  Here the caller
+** supplies it so that a Parser can reuse one Parse object for every
+** statement it compiles.  *pParse must be zeroed on entry and is zeroed
+** again before returning, so that it does not keep the parse tree alive.
+ */
+func sqlite3Prepare(db *sqlite3, pParse *Parse, zSql []byte, ppStmt **Stmt, pzTail *[]byte) int {
+	var rc int
+	*ppStmt = nil
+
+	db.errByteOffset = -1
+	/* Go strings carry their length, so this is the nBytes>=0 case of
+	** sqlite3Prepare(), which rejects overlong text before tokenizing it */
+	if len(zSql) > db.aLimit[SQLITE_LIMIT_SQL_LENGTH] {
+		db.zErrMsg = []byte("statement too long")
+		db.errCode = SQLITE_TOOBIG
+		*pzTail = zSql
+		return SQLITE_TOOBIG
+	}
+	pParse.db = db
+	sqlite3RunParser(pParse, zSql)
+
+	*pzTail = pParse.zTail
+	rc = pParse.rc
+	if rc == SQLITE_DONE {
+		rc = SQLITE_OK
+	}
+	if pParse.nErr > 0 && rc == SQLITE_OK {
+		rc = SQLITE_ERROR
+	}
+	if rc == SQLITE_OK {
+		*ppStmt = pParse.pStmt
+	} else if pParse.zErrMsg != nil {
+		db.zErrMsg = pParse.zErrMsg
+	} else {
+		db.zErrMsg = []byte(sqlite3ErrStr(rc))
+	}
+	db.errCode = rc
+	*pParse = Parse{}
+	return rc
+}
+
+/*
+** Parser is a reusable handle for parsing SQL text.  It keeps its
+** settings and working storage between calls to Parse, so a program that
+** parses many statements should create one Parser and reuse it.  A
+** Parser must not be used by more than one goroutine at a time.
+ */
+type Parser struct {
+	db     *sqlite3 /* Connection settings: limits and error state */
+	err    error    /* Error in the Options, reported by every Parse */
+	sParse Parse    /* Parse context reused for every statement */
+}
+
+/*
+** NewParser returns a Parser configured by opts.  A nil opts selects
+** the defaults.
+ */
+func NewParser(opts *Options) *Parser {
+	p := &Parser{db: openDatabase()}
+	p.err = opts.apply(p.db)
+	return p
+}
+
+/*
+** Parse parses every statement in zSql and returns them in order.
+** Parsing stops at the first error, which is returned as an *Error.
+** Statements that consist only of whitespace, comments or ";" are
+** skipped.
+ */
+func (p *Parser) Parse(zSql string) ([]*Stmt, error) {
+	var aStmt []*Stmt
+	db := p.db
+
+	if p.err != nil {
+		return nil, p.err
+	}
+	zText := sqlite3StringBytes(zSql)
+	zTail := zText
+	for len(zTail) > 0 {
+		var pStmt *Stmt
+		var zLeft []byte
+		iOfst := len(zText) - len(zTail)
+		rc := sqlite3Prepare(db, &p.sParse, zTail, &pStmt, &zLeft)
+		if rc != SQLITE_OK {
+			iErr := -1
+			if db.errByteOffset >= 0 {
+				iErr = iOfst + db.errByteOffset
+			}
+			return aStmt, &Error{Code: rc, Msg: string(db.zErrMsg), Offset: iErr}
+		}
+		if pStmt != nil {
+			pStmt.iOfst += iOfst
+			pStmt.iBase = iOfst
+			for i := range pStmt.aVersion {
+				pStmt.aVersion[i].Offset += iOfst
+			}
+			aStmt = append(aStmt, pStmt)
+		}
+		if len(zLeft) == len(zTail) {
+			break
+		}
+		zTail = zLeft
+	}
+	return aStmt, nil
+}
+
+/*
+** Return the bytes of zSql without copying them.  The parser never
+** writes to its input: token text is copied before it is dequoted.
+ */
+func sqlite3StringBytes(zSql string) []byte {
+	return *(*[]byte)(unsafe.Pointer(&struct {
+		string
+		int
+	}{zSql, len(zSql)}))
+}
+
+/*
+** The largest number of distinct Options for which ParseSQL keeps
+** Parsers.
+ */
+const SQLITE_PARSER_CACHE_SIZE = 16
+
+/*
+** The settings of a database connection that affect parsing.  Options
+** that have the same effect, such as TargetVersions "3.31" and "3.31.0"
+** or limits that are both above the hard limit, give equal keys.
+ */
+type parserKey struct {
+	aLimit             [SQLITE_N_LIMIT]int
+	omitFlags          uint32
+	bUpdateDeleteLimit uint8
+	iTargetVersion     int
+}
+
+/*
+** Parsers kept for reuse by ParseSQL.  aPool holds a sync.Pool of
+** Parsers for each parserKey.  aOpts maps each Options value that
+** ParseSQL has seen, with nil treated as the zero Options, to the pool
+** for its settings, so that the common case needs no lock.  aOpts holds
+** at most SQLITE_PARSER_CACHE_SIZE entries, and so aPool holds no more.
+** Once aOpts is full, ParseSQL makes a new Parser for other Options.
+ */
+var sqlite3ParserCache struct {
+	aOpts sync.Map                 /* Options to *sync.Pool */
+	mu    sync.Mutex               /* Protects the fields below */
+	nOpts int                      /* Number of entries in aOpts */
+	aPool map[parserKey]*sync.Pool /* Pool for each set of settings */
+}
+
+/*
+** Add opts, whose Parser p has been configured without error, to the
+** ParseSQL cache.  Return the pool that p belongs in, or NULL if the
+** cache is full.
+ */
+func sqlite3ParserCacheAdd(opts Options, p *Parser) *sync.Pool {
+	pCache := &sqlite3ParserCache
+	db := p.db
+	key := parserKey{db.aLimit, db.omitFlags, db.bUpdateDeleteLimit, db.iTargetVersion}
+
+	pCache.mu.Lock()
+	defer pCache.mu.Unlock()
+	if v, ok := pCache.aOpts.Load(opts); ok {
+		return v.(*sync.Pool)
+	}
+	if pCache.nOpts >= SQLITE_PARSER_CACHE_SIZE {
+		return nil
+	}
+	pPool := pCache.aPool[key]
+	if pPool == nil {
+		pPool = &sync.Pool{
+			New: func() interface{} { return NewParser(&opts) },
+		}
+		if pCache.aPool == nil {
+			pCache.aPool = make(map[parserKey]*sync.Pool)
+		}
+		pCache.aPool[key] = pPool
+	}
+	pCache.aOpts.Store(opts, pPool)
+	pCache.nOpts++
+	return pPool
+}
+
+/*
+** ParseSQL parses zSql with a Parser configured by opts.  The Parser is
+** taken from a pool shared by all callers whose Options have the same
+** effect, so that its working storage is reused.  See Parser.Parse.
+ */
+func ParseSQL(zSql string, opts *Options) ([]*Stmt, error) {
+	var key Options
+	var pPool *sync.Pool
+	var p *Parser
+	if opts != nil {
+		key = *opts
+	}
+	if v, ok := sqlite3ParserCache.aOpts.Load(key); ok {
+		pPool = v.(*sync.Pool)
+		p = pPool.Get().(*Parser)
+	} else {
+		p = NewParser(&key)
+		if p.err == nil {
+			pPool = sqlite3ParserCacheAdd(key, p)
+		}
+	}
+	if pPool != nil {
+		defer pPool.Put(p)
+	}
+	return p.Parse(zSql)
+}
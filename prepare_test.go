@@ -0,0 +1,198 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Tests for splitting SQL text into statements and reporting errors,
+** and tests and benchmarks for the Parser handle and the ParseSQL cache.
+ */
+package internal
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+/*
+** ParseSQL must return one Stmt for each statement, skipping empty
+** ones, with the byte offset of the statement in the text.
+ */
+func TestParseSQL(t *testing.T) {
+	aTest := []struct {
+		zSql  string
+		aOfst []int
+	}{
+		{"", nil},
+		{" ; -- comment\n;", nil},
+		{"SELECT 1", []int{0}},
+		{"SELECT 1;  ; SELECT 2", []int{0, 13}},
+		{"/* x */ SELECT 1; SELECT 2;", []int{8, 18}},
+	}
+	for _, tc := range aTest {
+		aStmt, err := ParseSQL(tc.zSql, nil)
+		if err != nil {
+			t.Errorf("%q: %v", tc.zSql, err)
+			continue
+		}
+		if len(aStmt) != len(tc.aOfst) {
+			t.Errorf("%q: %d statements, want %d", tc.zSql, len(aStmt), len(tc.aOfst))
+			continue
+		}
+		for i, pStmt := range aStmt {
+			if pStmt.Offset() != tc.aOfst[i] {
+				t.Errorf("%q: statement %d at offset %d, want %d",
+					tc.zSql, i, pStmt.Offset(), tc.aOfst[i])
+			}
+		}
+	}
+}
+
+/*
+** ParseSQL must report SQLite's error message and the byte offset that
+** sqlite3_error_offset() would return, or -1 if there is none.
+ */
+func TestParseSQLError(t *testing.T) {
+	aTest := []struct {
+		zSql  string
+		zErr  string
+		iOfst int
+	}{
+		{"SELEC 1", `near "SELEC": syntax error`, 0},
+		{"SELECT 1; SELECT ,", `near ",": syntax error`, 17},
+		{"SELECT * FROM", "incomplete input", -1},
+		{"SELECT ?0", "variable number must be between ?1 and ?32766", 7},
+		{"SELECT x'0g'", `unrecognized token: "x'0g'"`, 7},
+		{"SELECT 'abc", `unrecognized token: "'abc"`, 7},
+	}
+	for _, tc := range aTest {
+		_, err := ParseSQL(tc.zSql, nil)
+		pErr, ok := err.(*Error)
+		if !ok {
+			t.Errorf("%q: got %v, want %q", tc.zSql, err, tc.zErr)
+			continue
+		}
+		if pErr.Msg != tc.zErr || pErr.Offset != tc.iOfst {
+			t.Errorf("%q: got %q at %d, want %q at %d",
+				tc.zSql, pErr.Msg, pErr.Offset, tc.zErr, tc.iOfst)
+		}
+	}
+}
+
+/*
+** A parse of a simple SELECT through the ParseSQL cache must allocate
+** little more than the parse tree, as a reused Parser does.  The bound
+** has some room above the 28 allocations that the statement takes
+** today, since the race detector makes a sync.Pool drop some of the
+** Parsers put back into it.
+ */
+func TestParseSQLAllocs(t *testing.T) {
+	zSql := "SELECT a, b+1 FROM t1 WHERE c>10 ORDER BY a LIMIT 5"
+	if _, err := ParseSQL(zSql, nil); err != nil {
+		t.Fatal(err)
+	}
+	nCached := testing.AllocsPerRun(100, func() { ParseSQL(zSql, nil) })
+	p := NewParser(nil)
+	nParser := testing.AllocsPerRun(100, func() { p.Parse(zSql) })
+	if nCached > 32 {
+		t.Errorf("ParseSQL made %v allocations, a Parser %v, want at most 32", nCached, nParser)
+	}
+}
+
+/*
+** Goroutines that share the pooled Parsers of ParseSQL must each get
+** their own statements.  Run with -race to check the pool.
+ */
+func TestParseSQLConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	aErr := make([]error, 8)
+	for i := range aErr {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			opts := &Options{Limits: Limits{ExprDepth: 100 + i%2}}
+			for j := 0; j < 200 && aErr[i] == nil; j++ {
+				zSql := fmt.Sprintf("SELECT a%d FROM t%d WHERE b = %d", i, j, i*j)
+				aStmt, err := ParseSQL(zSql, opts)
+				if err != nil {
+					aErr[i] = err
+				} else if zGot := aStmt[0].pSelect.SQL(); zGot != zSql {
+					aErr[i] = fmt.Errorf("parsed %q as %q", zSql, zGot)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range aErr {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+/*
+** The ParseSQL cache must share Parsers between Options that have the
+** same effect and must not grow beyond SQLITE_PARSER_CACHE_SIZE.
+ */
+func TestParseSQLCache(t *testing.T) {
+	pCache := &sqlite3ParserCache
+	pCache.mu.Lock()
+	pCache.aOpts.Range(func(k, v interface{}) bool {
+		pCache.aOpts.Delete(k)
+		return true
+	})
+	pCache.nOpts = 0
+	pCache.aPool = nil
+	pCache.mu.Unlock()
+
+	aOpts := []Options{
+		{TargetVersion: "3.31"},
+		{TargetVersion: "3.31.0"},
+		{Limits: Limits{ExprDepth: 1 << 30}},
+		{Limits: Limits{ExprDepth: 1 << 31}},
+	}
+	for _, opts := range aOpts {
+		if _, err := ParseSQL("SELECT 1", &opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if pCache.nOpts != 4 || len(pCache.aPool) != 2 {
+		t.Errorf("%d Options share %d pools, want 4 and 2", pCache.nOpts, len(pCache.aPool))
+	}
+	for i := 0; i < 2*SQLITE_PARSER_CACHE_SIZE; i++ {
+		opts := &Options{Limits: Limits{VariableNumber: 100 + i}}
+		aStmt, err := ParseSQL(fmt.Sprintf("SELECT ?%d", 100+i), opts)
+		if err != nil || len(aStmt) != 1 {
+			t.Fatalf("%d: %v", i, err)
+		}
+	}
+	if pCache.nOpts != SQLITE_PARSER_CACHE_SIZE || len(pCache.aPool) > SQLITE_PARSER_CACHE_SIZE {
+		t.Errorf("cache holds %d Options and %d pools, want at most %d",
+			pCache.nOpts, len(pCache.aPool), SQLITE_PARSER_CACHE_SIZE)
+	}
+	if _, err := ParseSQL("SELECT ?1000", &Options{Limits: Limits{VariableNumber: 999}}); err == nil {
+		t.Error("the limits of an uncached Parser were not applied")
+	}
+}
+
+/*
+** Parse a simple SELECT over and over with one Parser, so that the
+** reported allocations are those of building the parse tree.
+ */
+func BenchmarkParse(b *testing.B) {
+	zSql := "SELECT a, b+1 FROM t1 WHERE c>10 ORDER BY a LIMIT 5"
+	p := NewParser(nil)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(zSql)))
+	for i := 0; i < b.N; i++ {
+		if _, err := p.Parse(zSql); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+/*
+** The "printf" code that follows dates from the 1980's.  It is in
+** the public domain.
+**
+**************************************************************************
+**
+** This file contains code for a set of "printf"-like routines.  These
+** routines format strings much like the printf() from the standard C
+** library, though the implementation here has enhancements to support
+** SQLite.
+ */
+package internal
+
+import (
+	"fmt"
+	"strconv"
+)
+
+/*
+** The Go port formats into a byte slice using only the conversions that
+** the parser actually needs.  In addition to the standard %s, %d, %c
+** and %%, the following SQLite extensions are recognized:
+**
+**   %T    The argument is a *Token.  The text of the token is inserted.
+**         If the database connection is currently parsing, the byte
+**         offset of the token is recorded as the error offset.
+**   %Q    Like %q but the result is enclosed in '...' and NULL is
+**         rendered as the keyword NULL.
+**   %q    Single quotes in the argument are doubled.
+**   %r    The integer argument is written as an English ordinal:
+**         "1st", "2nd", "3rd", "4th" and so on.
+**   %w    Double quotes in the argument are doubled.
+**   %z    Same as %s.  There is no memory to release in Go.
+**
+** The "%.*s" form takes the length as a separate int argument.
+ */
+
+/*
+** Record the byte offset of the token z within the SQL text currently
+** being parsed, provided that sqlite3ErrorMsg() asked for it by setting
+** db->errByteOffset to -2.
+ */
+func sqlite3RecordErrorByteOffset(db *sqlite3, z []byte) {
+	var pParse *Parse
+	var zText []byte
+	if db == nil {
+		return
+	}
+	if db.errByteOffset != -2 {
+		return
+	}
+	pParse = db.pParse
+	if pParse == nil {
+		return
+	}
+	zText = pParse.zTail
+	if len(z) == 0 || len(zText) == 0 || len(z) > len(zText) {
+		return
+	}
+	/* z and zText both run to the end of the same input, so z lies within
+	 ** zText exactly when their final bytes are the same byte. */
+	if &z[len(z)-1] != &zText[len(zText)-1] {
+		return
+	}
+	db.errByteOffset = len(zText) - len(z)
+}
+
+/*
+** Return the text of a %s-style argument.  Strings, byte slices and
+** tokens are accepted.  A nil argument yields no text.  A byte slice
+** ends at its first 0x00 byte, as a C string does.
+ */
+func argText(a interface{}) []byte {
+	switch v := a.(type) {
+	case nil:
+		return nil
+	case string:
+		return []byte(v)
+	case []byte:
+		return v[:sqlite3Strlen30(v)]
+	case *Token:
+		if v == nil {
+			return nil
+		}
+		return v.z[:v.n]
+	default:
+		return []byte(fmt.Sprint(v))
+	}
+}
+
+/*
+** Return the value of a %d-style argument as a 64-bit integer.
+ */
+func argInt(a interface{}) int64 {
+	switch v := a.(type) {
+	case int:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case uint:
+		return int64(v)
+	case uint8:
+		return int64(v)
+	case uint16:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case uint64:
+		return int64(v)
+	case ynVar:
+		return int64(v)
+	}
+	return 0
+}
+
+/*
+** Print into memory obtained from sqliteMalloc().  Use the internal
+** %-conversion extensions.
+ */
+func sqlite3MPrintf(db *sqlite3, zFormat string, args ...interface{}) []byte {
+	var z []byte
+	iArg := 0
+	next := func() interface{} {
+		if iArg < len(args) {
+			iArg++
+			return args[iArg-1]
+		}
+		return nil
+	}
+	for i := 0; i < len(zFormat); i++ {
+		c := zFormat[i]
+		if c != '%' || i+1 >= len(zFormat) {
+			z = append(z, c)
+			continue
+		}
+		i++
+		precision := -1
+		if zFormat[i] == '.' && i+1 < len(zFormat) && zFormat[i+1] == '*' {
+			precision = int(argInt(next()))
+			i += 2
+			if i >= len(zFormat) {
+				break
+			}
+		}
+		switch zFormat[i] {
+		case '%':
+			z = append(z, '%')
+		case 'd':
+			z = strconv.AppendInt(z, argInt(next()), 10)
+		case 'c':
+			z = append(z, byte(argInt(next())))
+		case 'r':
+			const zOrd = "thstndrd"
+			longvalue := argInt(next())
+			x := int(longvalue % 10)
+			if x >= 4 || (longvalue/10)%10 == 1 {
+				x = 0
+			}
+			z = strconv.AppendInt(z, longvalue, 10)
+			z = append(z, zOrd[x*2], zOrd[x*2+1])
+		case 's', 'z':
+			zArg := argText(next())
+			if precision >= 0 && precision < len(zArg) {
+				zArg = zArg[:precision]
+			}
+			z = append(z, zArg...)
+		case 'T':
+			pToken, _ := next().(*Token)
+			if pToken != nil && pToken.n > 0 {
+				z = append(z, pToken.z[:pToken.n]...)
+				sqlite3RecordErrorByteOffset(db, pToken.z)
+			}
+		case 'q', 'Q', 'w':
+			a := next()
+			q := byte('\'')
+			if zFormat[i] == 'w' {
+				q = '"'
+			}
+			if a == nil && zFormat[i] == 'Q' {
+				z = append(z, "NULL"...)
+				break
+			}
+			zArg := argText(a)
+			if zFormat[i] == 'Q' {
+				z = append(z, q)
+			}
+			for _, ch := range zArg {
+				z = append(z, ch)
+				if ch == q {
+					z = append(z, q)
+				}
+			}
+			if zFormat[i] == 'Q' {
+				z = append(z, q)
+			}
+		default:
+			z = append(z, '%', zFormat[i])
+		}
+	}
+	return z
+}
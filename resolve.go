@@ -0,0 +1,653 @@
+/*
+** 2008 August 18
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+**
+** This file contains routines used for walking the parser tree and
+** resolve all identifiers by associating them with a particular
+** table and column.
+**
+** The Go port has no schema, so identifiers are not resolved.  What
+** remains are the checks that do not depend on the schema: the number
+** of result columns of each term of a compound SELECT, the range of
+** integer ORDER BY and GROUP BY terms, the use of the NEW and OLD
+** pseudo-tables in a trigger program, and RAISE() outside of one.  A
+** result set that contains "*" or "TABLE.*" cannot be expanded, so the
+** checks on the number of result columns are skipped when they involve
+** one.  The expressions of an index are checked against the columns of
+** its table, when those are known.
+ */
+package internal
+
+/*
+** Generate an ORDER BY or GROUP BY term out-of-range error.
+ */
+func resolveOutOfRangeError(
+	pParse *Parse, /* The error context into which to write the error */
+	zType string, /* "ORDER" or "GROUP" */
+	i int, /* The index (1-based) of the term out of range */
+	mx int, /* Largest permissible value of i */
+	pError *Expr, /* Associate the error with the expression */
+) {
+	sqlite3ErrorMsg(pParse,
+		"%r %s BY term out of range - should be "+
+			"between 1 and %d", i, zType, mx)
+	sqlite3RecordErrorOffsetOfExpr(pParse.db, pError)
+}
+
+/*
+** Analyze the ORDER BY clause in a compound SELECT statement.  pSelect
+** is the right-most term of the compound, which holds the ORDER BY.
+** Check that every term of the ORDER BY that is an integer refers to
+** a column of the result set, and record the column number in
+** iOrderByCol.
+**
+** The C version also matches terms that are not integers against the
+** result columns of each SELECT.  That requires name resolution and is
+** not done here.
+**
+** Return the number of errors seen.
+ */
+func resolveCompoundOrderBy(
+	pParse *Parse, /* Parsing context.  Leave error messages here */
+	pSelect *Select, /* The SELECT statement containing the ORDER BY */
+) int {
+	var pEList *ExprList
+	var pOrderBy *ExprList
+
+	pOrderBy = pSelect.pOrderBy
+	if pOrderBy == nil {
+		return 0
+	}
+	if pOrderBy.nExpr > pParse.db.aLimit[SQLITE_LIMIT_COLUMN] {
+		sqlite3ErrorMsg(pParse, "too many terms in ORDER BY clause")
+		return 1
+	}
+	pEList = pSelect.pEList
+	if selectHasStar(pEList) {
+		return 0
+	}
+	for i := 0; i < pOrderBy.nExpr; i++ {
+		pItem := &pOrderBy.a[i]
+		iCol := -1
+		pE := sqlite3ExprSkipCollateAndLikely(pItem.pExpr)
+		if NEVER(pE == nil) {
+			continue
+		}
+		if sqlite3ExprIsInteger(pE, &iCol) != 0 {
+			if iCol <= 0 || iCol > pEList.nExpr {
+				resolveOutOfRangeError(pParse, "ORDER", i+1, pEList.nExpr, pE)
+				return 1
+			}
+			pItem.u.x.iOrderByCol = uint16(iCol)
+		}
+	}
+	return 0
+}
+
+/*
+** pOrderBy is an ORDER BY or GROUP BY clause in SELECT statement pSelect.
+** The Name context of the SELECT statement is pNC.  zType is either
+** "ORDER" or "GROUP" depending on which type of clause pOrderBy is.
+**
+** A term that is an integer K is a reference to the K-th column of the
+** result set.  Check that K is in range and record it in iOrderByCol.
+** Other terms are expressions, which this port does not resolve.
+**
+** Return the number of errors seen.
+ */
+func resolveOrderGroupBy(
+	pParse *Parse, /* Parsing context */
+	pSelect *Select, /* The SELECT statement holding pOrderBy */
+	pOrderBy *ExprList, /* An ORDER BY or GROUP BY clause to resolve */
+	zType string, /* Either "ORDER" or "GROUP", as appropriate */
+) int {
+	var pEList *ExprList
+
+	if pOrderBy == nil {
+		return 0
+	}
+	if pOrderBy.nExpr > pParse.db.aLimit[SQLITE_LIMIT_COLUMN] {
+		sqlite3ErrorMsg(pParse, "too many terms in %s BY clause", zType)
+		return 1
+	}
+	pEList = pSelect.pEList
+	for i := 0; i < pOrderBy.nExpr; i++ {
+		var iCol int
+		pItem := &pOrderBy.a[i]
+		pE2 := sqlite3ExprSkipCollateAndLikely(pItem.pExpr)
+		if NEVER(pE2 == nil) {
+			continue
+		}
+		if sqlite3ExprIsInteger(pE2, &iCol) != 0 {
+			/* The ORDER BY term is an integer constant.  Again pattern match
+			 ** the column number against the result set.
+			 */
+			if iCol < 1 || iCol > 0xffff {
+				resolveOutOfRangeError(pParse, zType, i+1, pEList.nExpr, pE2)
+				return 1
+			}
+			if iCol > pEList.nExpr && !selectHasStar(pEList) {
+				resolveOutOfRangeError(pParse, zType, i+1, pEList.nExpr, pE2)
+				return 1
+			}
+			pItem.u.x.iOrderByCol = uint16(iCol)
+		}
+	}
+	return 0
+}
+
+/*
+** Return true if zTab is the name or alias of a table in the FROM clause
+** of pNC or of any of its outer name contexts.
+ */
+func resolveTableVisible(pNC *NameContext, zTab []byte) bool {
+	for ; pNC != nil; pNC = pNC.pNext {
+		pSrcList := pNC.pSrcList
+		if pSrcList == nil {
+			continue
+		}
+		for i := 0; i < pSrcList.nSrc; i++ {
+			pItem := &pSrcList.a[i]
+			zName := pItem.zAlias
+			if zName == nil {
+				zName = pItem.zName
+			}
+			if zName != nil && sqlite3StrICmp(zName, zTab) == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+/*
+** pExpr is a column reference of the form "TABLE.COLUMN".  If it appears
+** within the program or WHEN clause of the trigger being built, check
+** that it does not use a pseudo-table the trigger does not have: an
+** INSERT trigger has no OLD row and a DELETE trigger has no NEW row.
+** A table of the FROM clause with the same name hides the pseudo-table.
+**
+** Return the number of errors seen.
+ */
+func resolveTriggerRef(pNC *NameContext, pExpr *Expr) int {
+	pParse := pNC.pParse
+	pTrig := pParse.pNewTrigger
+	zTab := pExpr.pLeft.u.zToken
+	zCol := pExpr.pRight.u.zToken
+
+	if pTrig == nil || resolveTableVisible(pNC, zTab) {
+		return 0
+	}
+	if (pTrig.op == TK_INSERT && sqlite3StrICmp(zTab, []byte("old")) == 0) ||
+		(pTrig.op == TK_DELETE && sqlite3StrICmp(zTab, []byte("new")) == 0) {
+		sqlite3ErrorMsg(pParse, "no such column: %s.%s", zTab, zCol)
+		sqlite3RecordErrorOffsetOfExpr(pParse.db, pExpr)
+		return 1
+	}
+	return 0
+}
+
+/*
+** pExpr is a column reference of the form "TABLE.COLUMN".  The EXCLUDED
+** pseudo-table holds the row that failed to insert, and only the SET and
+** WHERE terms of an ON CONFLICT DO UPDATE can see it.  Report an error
+** if pExpr uses it anywhere else.  A table of the FROM clause with the
+** same name hides the pseudo-table.
+**
+** Return the number of errors seen.
+ */
+func resolveExcludedRef(pNC *NameContext, pExpr *Expr) int {
+	zTab := pExpr.pLeft.u.zToken
+	zCol := pExpr.pRight.u.zToken
+
+	if sqlite3StrICmp(zTab, []byte("excluded")) != 0 || resolveTableVisible(pNC, zTab) {
+		return 0
+	}
+	for p := pNC; p != nil; p = p.pNext {
+		if (p.ncFlags&NC_UUpsert) != 0 && p.uNC.pUpsert != nil {
+			return 0
+		}
+	}
+	sqlite3ErrorMsg(pNC.pParse, "no such column: %s.%s", zTab, zCol)
+	sqlite3RecordErrorOffsetOfExpr(pNC.pParse.db, pExpr)
+	return 1
+}
+
+/*
+** The port has no function registry.  These are the built-in SQL
+** functions that are neither SQLITE_FUNC_CONSTANT nor SQLITE_FUNC_SLOCHNG,
+** and so cannot be used where the result must be deterministic.
+ */
+var azNonDeterministic = []string{
+	"changes",
+	"last_insert_rowid",
+	"load_extension",
+	"random",
+	"randomblob",
+	"total_changes",
+}
+
+/*
+** Return true if zName is one of the functions in azNonDeterministic[].
+ */
+func resolveIsNonDeterministic(zName []byte) bool {
+	for _, z := range azNonDeterministic {
+		if sqlite3StrICmp(zName, []byte(z)) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+** Report an error that an expression is not valid for some set of
+** pNC->ncFlags values determined by validMask.
+**
+** static void notValidImpl(
+**    Parse *pParse,       // Leave error message here
+**    NameContext *pNC,    // The name context
+**    const char *zMsg,    // Type of error
+**    Expr *pExpr,         // Invalidate this expression on error
+**    Expr *pError         // Associate error with this expression
+** );
+ */
+func notValidImpl(pParse *Parse, pNC *NameContext, zMsg string, pError *Expr) {
+	zIn := "partial index WHERE clauses"
+	if (pNC.ncFlags & NC_IdxExpr) != 0 {
+		zIn = "index expressions"
+	} else if (pNC.ncFlags & NC_IsCheck) != 0 {
+		zIn = "CHECK constraints"
+	} else if (pNC.ncFlags & NC_GenCol) != 0 {
+		zIn = "generated columns"
+	}
+	sqlite3ErrorMsg(pParse, "%s prohibited in %s", zMsg, zIn)
+	sqlite3RecordErrorOffsetOfExpr(pParse.db, pError)
+}
+
+/*
+** Report an error and return true if pNC->ncFlags has any of the bits
+** in validMask set.  This is the sqlite3ResolveNotValid() macro of the
+** C version.
+ */
+func sqlite3ResolveNotValid(pParse *Parse, pNC *NameContext, zMsg string, validMask int, pError *Expr) bool {
+	assert((validMask&^(NC_IsCheck|NC_PartIdx|NC_IdxExpr|NC_GenCol)) == 0,
+		"(validMask&^(NC_IsCheck|NC_PartIdx|NC_IdxExpr|NC_GenCol)) == 0")
+	if (pNC.ncFlags & validMask) != 0 {
+		notValidImpl(pParse, pNC, zMsg, pError)
+		return true
+	}
+	return false
+}
+
+/*
+** pExpr is a column name, or a TABLE.COLUMN reference, within a CHECK
+** constraint, index or generated column of the table of pNC.  If the
+** columns of that table are known, check that pExpr names one of them
+** or the rowid.
+**
+** Return the number of errors seen.
+ */
+func resolveSelfRef(pNC *NameContext, pExpr *Expr) int {
+	var zTab []byte /* Name of the table, or NULL */
+	var zCol []byte /* Name of the column */
+	pParse := pNC.pParse
+	pTab := pNC.pSrcList.a[0].pTab
+
+	if pTab == nil || pTab.nCol == 0 {
+		return 0
+	}
+	if pExpr.op == TK_DOT {
+		zTab = pExpr.pLeft.u.zToken
+		zCol = pExpr.pRight.u.zToken
+	} else {
+		zCol = pExpr.u.zToken
+	}
+	if zTab == nil || sqlite3StrICmp(zTab, pTab.zName) == 0 {
+		for i := 0; i < int(pTab.nCol); i++ {
+			if sqlite3StrICmp(zCol, pTab.aCol[i].zCnName) == 0 {
+				return 0
+			}
+		}
+		if HasRowid(pTab) && sqlite3IsRowid(zCol) {
+			return 0
+		}
+	}
+	if zTab == nil && !ExprHasProperty(pExpr, EP_Quoted) && sqlite3IsTrueOrFalse(zCol) != 0 {
+		/* An unquoted TRUE or FALSE that is not a column is a constant.
+		 ** See sqlite3ExprIdToTrueFalse() */
+		return 0
+	}
+	if zTab != nil {
+		sqlite3ErrorMsg(pParse, "no such column: %s.%s", zTab, zCol)
+	} else {
+		sqlite3ErrorMsg(pParse, "no such column: %s", zCol)
+	}
+	sqlite3RecordErrorOffsetOfExpr(pParse.db, pExpr)
+	return 1
+}
+
+/*
+** This routine is callback for sqlite3WalkExpr().
+**
+** Check the current node in the expression tree.  Return 0 to continue
+** the search down the tree, 1 to skip its children, or 2 to abort the
+** tree walk.
+ */
+func resolveExprStep(pWalker *Walker, pExpr *Expr) int {
+	pNC := pWalker.u.pNC
+	assert(pNC != nil, "pNC != nil")
+	pParse := pNC.pParse
+	assert(pParse == pWalker.pParse, "pParse == pWalker.pParse")
+
+	switch pExpr.op {
+	/* A column name:                    ID
+	 ** Or table name and column name:    ID.ID
+	 ** Or a database, table and column:  ID.ID.ID
+	 **
+	 ** Only the second form can refer to the NEW, OLD or EXCLUDED
+	 ** pseudo-tables.
+	 */
+	case TK_ID:
+		if (pNC.ncFlags&NC_SelfRef) != 0 && resolveSelfRef(pNC, pExpr) != 0 {
+			return WRC_Abort
+		}
+
+	case TK_DOT:
+		if pExpr.pRight.op != TK_ID {
+			return WRC_Prune
+		}
+		if (pNC.ncFlags & NC_SelfRef) != 0 {
+			if resolveSelfRef(pNC, pExpr) != 0 {
+				return WRC_Abort
+			}
+		} else if resolveTriggerRef(pNC, pExpr) != 0 || resolveExcludedRef(pNC, pExpr) != 0 {
+			return WRC_Abort
+		}
+		return WRC_Prune
+
+	/* Clearly non-deterministic functions like random() cannot be used
+	 ** in an index or generated column.  Curiously, they can be used
+	 ** in a CHECK constraint.
+	 */
+	case TK_FUNCTION:
+		if resolveIsNonDeterministic(pExpr.u.zToken) &&
+			sqlite3ResolveNotValid(pParse, pNC, "non-deterministic functions",
+				NC_IdxExpr|NC_PartIdx|NC_GenCol, pExpr) {
+			return WRC_Abort
+		}
+
+	case TK_IN, TK_EXISTS, TK_SELECT:
+		if ExprUseXSelect(pExpr) &&
+			sqlite3ResolveNotValid(pParse, pNC, "subqueries", NC_SelfRef, pExpr) {
+			return WRC_Abort
+		}
+
+	case TK_VARIABLE:
+		if sqlite3ResolveNotValid(pParse, pNC, "parameters", NC_SelfRef, pExpr) {
+			return WRC_Abort
+		}
+
+	/* RAISE() is only meaningful within a trigger program.  The C version
+	 ** reports its misuse while generating code.
+	 */
+	case TK_RAISE:
+		if pParse.pNewTrigger == nil {
+			sqlite3ErrorMsg(pParse, "RAISE() may only be used within a trigger-program")
+			sqlite3RecordErrorOffsetOfExpr(pParse.db, pExpr)
+			return WRC_Abort
+		}
+	}
+	return WRC_Continue
+}
+
+/*
+** Resolve names in the SELECT statement p and all of its descendants.
+** This is the xSelectCallback of the walker used by
+** sqlite3ResolveSelectNames().
+**
+** The walker calls this routine for the right-most term of a compound
+** SELECT, and the routine handles every term of the compound itself.
+ */
+func resolveSelectStep(pWalker *Walker, p *Select) int {
+	var pOuterNC *NameContext /* Context that contains this SELECT */
+	var isCompound bool       /* True if p is a compound select */
+	var pLeftmost *Select     /* Left-most of SELECT of a compound */
+	var w Walker              /* Walks the expressions of each term */
+
+	pParse := pWalker.pParse
+	pOuterNC = pWalker.u.pNC
+	isCompound = p.pPrior != nil
+	pLeftmost = p
+	for p != nil {
+		var sNC NameContext /* Name context of this SELECT */
+
+		/* Recursively resolve names in all subqueries in the FROM clause
+		 */
+		for i := 0; i < p.pSrc.nSrc; i++ {
+			pItem := &p.pSrc.a[i]
+			if pItem.pSelect != nil {
+				sqlite3ResolveSelectNames(pParse, pItem.pSelect, pOuterNC)
+				if pParse.nErr != 0 {
+					return WRC_Abort
+				}
+			}
+		}
+
+		/* Set up the local name-context to pass to sqlite3ResolveExprNames() to
+		 ** resolve the result-set expression list.
+		 */
+		sNC.pParse = pParse
+		sNC.pSrcList = p.pSrc
+		sNC.pNext = pOuterNC
+
+		/* Resolve names in table-valued-function arguments */
+		for i := 0; i < p.pSrc.nSrc; i++ {
+			pItem := &p.pSrc.a[i]
+			if pItem.u1.pFuncArg != nil &&
+				sqlite3ResolveExprListNames(&sNC, pItem.u1.pFuncArg) != 0 {
+				return WRC_Abort
+			}
+		}
+
+		/* An ORDER BY on a simple SELECT and a GROUP BY may refer to the
+		 ** result set by number.  The ORDER BY of a compound is checked
+		 ** once every term has been seen.
+		 */
+		if !isCompound && resolveOrderGroupBy(pParse, p, p.pOrderBy, "ORDER") != 0 {
+			return WRC_Abort
+		}
+		if resolveOrderGroupBy(pParse, p, p.pGroupBy, "GROUP") != 0 {
+			return WRC_Abort
+		}
+
+		/* Resolve names in the expressions of this SELECT and in the
+		 ** subqueries they contain.
+		 */
+		w = Walker{}
+		w.xExprCallback = resolveExprStep
+		w.xSelectCallback = resolveSelectStep
+		w.pParse = pParse
+		w.bWinDefn = true
+		w.u.pNC = &sNC
+		if sqlite3WalkSelectExpr(&w, p) != 0 {
+			return WRC_Abort
+		}
+
+		/* Resolve the OVER clause of each window function of this SELECT
+		 ** against its WINDOW clause.  In C this is done by
+		 ** sqlite3WindowUpdate() as each function is resolved. */
+		if windowResolveSelect(&w, p) != WRC_Continue {
+			return WRC_Abort
+		}
+
+		/* The C version resolves a CTE each time it is used, as a subquery
+		 ** of the FROM clause.  Here the CTEs that were used are resolved
+		 ** where they are defined. */
+		if p.pWith != nil {
+			for i := 0; i < p.pWith.nCte; i++ {
+				pCte := &p.pWith.a[i]
+				if pCte.pUse != nil {
+					sqlite3ResolveSelectNames(pParse, pCte.pSelect, pOuterNC)
+					if pParse.nErr != 0 {
+						return WRC_Abort
+					}
+				}
+			}
+		}
+
+		/* If this is part of a compound SELECT, check that it has the right
+		 ** number of expressions in the select list. */
+		if p.pNext != nil && p.pEList.nExpr != p.pNext.pEList.nExpr &&
+			!selectHasStar(p.pEList) && !selectHasStar(p.pNext.pEList) {
+			sqlite3SelectWrongNumTermsError(pParse, p.pNext)
+			return WRC_Abort
+		}
+
+		/* Advance to the next term of the compound
+		 */
+		p = p.pPrior
+	}
+
+	/* Resolve the ORDER BY on a compound SELECT after all terms of
+	 ** the compound have been resolved.
+	 */
+	if isCompound && resolveCompoundOrderBy(pParse, pLeftmost) != 0 {
+		return WRC_Abort
+	}
+
+	return WRC_Prune
+}
+
+/*
+** This routine walks an expression tree and resolves references to
+** table columns and result-set columns.
+**
+** Return 1 if any errors are seen.  Return 0 if no errors.
+ */
+func sqlite3ResolveExprNames(
+	pNC *NameContext, /* Namespace to resolve expressions in. */
+	pExpr *Expr, /* The expression to be analyzed. */
+) int {
+	var w Walker
+
+	if pExpr == nil {
+		return SQLITE_OK
+	}
+	w.pParse = pNC.pParse
+	w.xExprCallback = resolveExprStep
+	w.xSelectCallback = resolveSelectStep
+	w.xSelectCallback2 = nil
+	w.u.pNC = pNC
+	sqlite3WalkExpr(&w, pExpr)
+	if w.pParse.nErr > 0 {
+		return 1
+	}
+	return 0
+}
+
+/*
+** Resolve all names for all expression in an expression list.  This is
+** just like sqlite3ResolveExprNames() except that it works for an expression
+** list rather than a single expression.
+ */
+func sqlite3ResolveExprListNames(
+	pNC *NameContext, /* Namespace to resolve expressions in. */
+	pList *ExprList, /* The expression list to be analyzed. */
+) int {
+	if pList == nil {
+		return SQLITE_OK
+	}
+	for i := 0; i < pList.nExpr; i++ {
+		if sqlite3ResolveExprNames(pNC, pList.a[i].pExpr) != 0 {
+			return WRC_Abort
+		}
+	}
+	return WRC_Continue
+}
+
+/*
+** Resolve all names in all expressions of a SELECT and in all
+** descendants of the SELECT, including compounds off of p->pPrior,
+** subqueries in expressions, and subqueries used as FROM clause
+** terms.
+**
+** See sqlite3ResolveExprNames() for a description of the kinds of
+** transformations that occur.
+**
+** All SELECT statements should have been expanded using
+** sqlite3SelectExpand() prior to invoking this routine.
+ */
+func sqlite3ResolveSelectNames(
+	pParse *Parse, /* The parser context */
+	p *Select, /* The SELECT statement being coded. */
+	pOuterNC *NameContext, /* Name context for parent SELECT statement */
+) {
+	var w Walker
+
+	assert(p != nil, "p != nil")
+	w.xExprCallback = resolveExprStep
+	w.xSelectCallback = resolveSelectStep
+	w.xSelectCallback2 = nil
+	w.pParse = pParse
+	w.u.pNC = pOuterNC
+	sqlite3WalkSelect(&w, p)
+}
+
+/*
+** Resolve names in expressions that can only reference a single table
+** or which cannot reference any tables at all.  Examples:
+**
+**                                                    "type" flag
+**                                                    ------------
+**    (1)   CHECK constraints                         NC_IsCheck
+**    (2)   WHERE clauses on partial indices          NC_PartIdx
+**    (3)   Expressions in indexes on expressions     NC_IdxExpr
+**    (4)   Expression arguments to VACUUM INTO.      0
+**    (5)   GENERATED ALWAYS as expressions           NC_GenCol
+**
+** In all cases except (4), the Expr.iTable value for Expr.op==TK_COLUMN
+** nodes of the expression is set to -1 and the Expr.iColumn value is
+** set to the column number.  In case (4), TK_COLUMN nodes cause an error.
+**
+** The port leaves column names as they are.  Only the checks are made.
+**
+** Any errors cause an error message to be set in pParse.
+ */
+func sqlite3ResolveSelfReference(
+	pParse *Parse, /* Parsing context */
+	pTab *Table, /* The table being referenced, or NULL */
+	typ int, /* NC_IsCheck, NC_PartIdx, NC_IdxExpr, NC_GenCol, or 0 */
+	pExpr *Expr, /* Expression to resolve.  May be NULL. */
+	pList *ExprList, /* Expression list to resolve.  May be NULL. */
+) int {
+	var sSrc SrcList    /* Fake SrcList for pParse->pNewTable */
+	var sNC NameContext /* Name context for pParse->pNewTable */
+	var rc int
+
+	assert(typ == 0 || pTab != nil, "typ == 0 || pTab != nil")
+	assert(typ == NC_IsCheck || typ == NC_PartIdx || typ == NC_IdxExpr ||
+		typ == NC_GenCol || pTab == nil,
+		"typ == NC_IsCheck || typ == NC_PartIdx || typ == NC_IdxExpr || typ == NC_GenCol || pTab == nil")
+	if pTab != nil {
+		sSrc.nSrc = 1
+		sSrc.a = []SrcItem{{zName: pTab.zName, pTab: pTab}}
+	}
+	sNC.pParse = pParse
+	sNC.pSrcList = &sSrc
+	sNC.ncFlags = typ
+	if rc = sqlite3ResolveExprNames(&sNC, pExpr); rc != SQLITE_OK {
+		return rc
+	}
+	if pList != nil {
+		rc = sqlite3ResolveExprListNames(&sNC, pList)
+	}
+	return rc
+}
@@ -0,0 +1,65 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Tests for the schema-independent parts of name resolution.
+ */
+package internal
+
+import "testing"
+
+/*
+** Compound SELECTs and multi-row VALUES whose terms have different
+** numbers of columns, and integer ORDER BY or GROUP BY terms that are
+** out of range, must be reported the way SQLite reports them.
+ */
+func TestResolveError(t *testing.T) {
+	aTest := []struct {
+		zSql  string
+		zErr  string
+		iOfst int
+	}{
+		{"SELECT 1, 2 UNION SELECT 3",
+			"SELECTs to the left and right of UNION do not have the same number of result columns", -1},
+		{"SELECT 1 UNION ALL SELECT 2, 3 EXCEPT SELECT 4",
+			"SELECTs to the left and right of EXCEPT do not have the same number of result columns", -1},
+		{"VALUES(1,2),(3)",
+			"all VALUES must have the same number of terms", -1},
+		{"VALUES(1) INTERSECT VALUES(1,2)",
+			"all VALUES must have the same number of terms", -1},
+		{"SELECT 1 UNION SELECT 2 ORDER BY 2",
+			"1st ORDER BY term out of range - should be between 1 and 1", 33},
+		{"SELECT a, b FROM t GROUP BY 2 ORDER BY 3",
+			"1st ORDER BY term out of range - should be between 1 and 2", 39},
+		{"SELECT a FROM t ORDER BY 1, -1",
+			"2nd ORDER BY term out of range - should be between 1 and 1", 29},
+		{"SELECT a FROM t GROUP BY 2",
+			"1st GROUP BY term out of range - should be between 1 and 1", 25},
+	}
+	for _, tc := range aTest {
+		_, err := ParseSQL(tc.zSql, nil)
+		pErr, ok := err.(*Error)
+		if !ok || pErr.Msg != tc.zErr {
+			t.Errorf("%s: got %v, want %q", tc.zSql, err, tc.zErr)
+		} else if tc.iOfst >= 0 && pErr.Offset != tc.iOfst {
+			t.Errorf("%s: error at offset %d, want %d", tc.zSql, pErr.Offset, tc.iOfst)
+		}
+	}
+
+	for _, zSql := range []string{
+		"VALUES(1),(2) UNION VALUES(3)",
+		"SELECT a, b FROM t UNION SELECT c, d FROM u ORDER BY 2",
+		"SELECT a FROM t GROUP BY 1 ORDER BY 1",
+	} {
+		if _, err := ParseSQL(zSql, nil); err != nil {
+			t.Errorf("%s: %v", zSql, err)
+		}
+	}
+}
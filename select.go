@@ -0,0 +1,833 @@
+/*
+** 2001 September 15
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains C code routines that are called by the parser
+** to handle SELECT statements in SQLite.
+ */
+package internal
+
+/*
+** Allocate a new Select structure and return a pointer to that
+** structure.
+ */
+func sqlite3SelectNew(
+	pParse *Parse, /* Parsing context */
+	pEList *ExprList, /* which columns to include in the result */
+	pSrc *SrcList, /* the FROM clause -- which tables to scan */
+	pWhere *Expr, /* the WHERE clause */
+	pGroupBy *ExprList, /* the GROUP BY clause */
+	pHaving *Expr, /* the HAVING clause */
+	pOrderBy *ExprList, /* the ORDER BY clause */
+	selFlags uint32, /* Flag parameters, such as SF_Distinct */
+	pLimit *Expr, /* LIMIT value.  NULL means not used */
+) *Select {
+	pNew := &Select{}
+	if pEList == nil {
+		pEList = sqlite3ExprListAppend(pParse, nil,
+			sqlite3Expr(pParse.db, TK_ASTERISK, nil))
+	}
+	pNew.pEList = pEList
+	pNew.op = TK_SELECT
+	pNew.selFlags = selFlags
+	pNew.iLimit = 0
+	pNew.iOffset = 0
+	pParse.nSelect++
+	pNew.selId = uint32(pParse.nSelect)
+	pNew.addrOpenEphm[0] = -1
+	pNew.addrOpenEphm[1] = -1
+	pNew.nSelectRow = 0
+	if pSrc == nil {
+		pSrc = &SrcList{}
+	}
+	pNew.pSrc = pSrc
+	pNew.pWhere = pWhere
+	pNew.pGroupBy = pGroupBy
+	pNew.pHaving = pHaving
+	pNew.pOrderBy = pOrderBy
+	pNew.pPrior = nil
+	pNew.pNext = nil
+	pNew.pLimit = pLimit
+	pNew.pWith = nil
+	// #ifndef SQLITE_OMIT_WINDOWFUNC
+	pNew.pWin = nil
+	pNew.pWinDefn = nil
+	// #endif
+	return pNew
+}
+
+/*
+** Name of the connection operator, used for error messages.
+ */
+func sqlite3SelectOpName(id int) string {
+	var z string
+	switch id {
+	case TK_ALL:
+		z = "UNION ALL"
+	case TK_INTERSECT:
+		z = "INTERSECT"
+	case TK_EXCEPT:
+		z = "EXCEPT"
+	default:
+		z = "UNION"
+	}
+	return z
+}
+
+/*
+** Error message for when two or more terms of a compound select have different
+** size result sets.
+ */
+func sqlite3SelectWrongNumTermsError(pParse *Parse, p *Select) {
+	if (p.selFlags & SF_Values) != 0 {
+		sqlite3ErrorMsg(pParse, "all VALUES must have the same number of terms")
+	} else {
+		sqlite3ErrorMsg(pParse, "SELECTs to the left and right of %s"+
+			" do not have the same number of result columns",
+			sqlite3SelectOpName(int(p.op)))
+	}
+}
+
+/*
+** Given 1 to 3 identifiers preceding the JOIN keyword, determine the
+** type of join.  Return an integer constant that expresses that type
+** in terms of the following bit values:
+**
+**     JT_INNER
+**     JT_CROSS
+**     JT_OUTER
+**     JT_NATURAL
+**     JT_LEFT
+**     JT_RIGHT
+**
+** A full outer join is the combination of JT_LEFT and JT_RIGHT.
+**
+** If an illegal or unsupported join type is seen, then still return
+** a join type, but put an error in the pParse structure.
+**
+** These are the valid join types:
+**
+**
+**      pA       pB       pC               Return Value
+**     -------  -----    -----             ------------
+**     CROSS      -        -                 JT_CROSS
+**     INNER      -        -                 JT_INNER
+**     LEFT       -        -                 JT_LEFT|JT_OUTER
+**     LEFT     OUTER      -                 JT_LEFT|JT_OUTER
+**     RIGHT      -        -                 JT_RIGHT|JT_OUTER
+**     RIGHT    OUTER      -                 JT_RIGHT|JT_OUTER
+**     FULL       -        -                 JT_LEFT|JT_RIGHT|JT_OUTER
+**     FULL     OUTER      -                 JT_LEFT|JT_RIGHT|JT_OUTER
+**     NATURAL  INNER      -                 JT_NATURAL|JT_INNER
+**     NATURAL  LEFT       -                 JT_NATURAL|JT_LEFT|JT_OUTER
+**     NATURAL  LEFT     OUTER               JT_NATURAL|JT_LEFT|JT_OUTER
+**     NATURAL  RIGHT      -                 JT_NATURAL|JT_RIGHT|JT_OUTER
+**     NATURAL  RIGHT    OUTER               JT_NATURAL|JT_RIGHT|JT_OUTER
+**     NATURAL  FULL       -                 JT_NATURAL|JT_LEFT|JT_RIGHT
+**     NATURAL  FULL     OUTER               JT_NATRUAL|JT_LEFT|JT_RIGHT
+**
+** To preserve historical compatibly, SQLite also accepts a variety
+** of other non-standard and in many cases non-sensical join types.
+** This routine makes as much sense at it can from the nonsense join
+** type and returns a result.  Examples of accepted nonsense join types
+** include but are not limited to:
+**
+**          INNER CROSS JOIN        ->   same as JOIN
+**          NATURAL CROSS JOIN      ->   same as NATURAL JOIN
+**          OUTER LEFT JOIN         ->   same as LEFT JOIN
+**          LEFT NATURAL JOIN       ->   same as NATURAL LEFT JOIN
+**          LEFT RIGHT JOIN         ->   same as FULL JOIN
+**          RIGHT OUTER FULL JOIN   ->   same as FULL JOIN
+**          CROSS CROSS CROSS JOIN  ->   same as JOIN
+**
+** The only restrictions on the join type name are:
+**
+**    *   "INNER" cannot appear together with "OUTER", "LEFT", "RIGHT",
+**        or "FULL".
+**
+**    *   "CROSS" cannot appear together with "OUTER", "LEFT", "RIGHT,
+**        or "FULL".
+**
+**    *   If "OUTER" is present then there must also be one of
+**        "LEFT", "RIGHT", or "FULL"
+ */
+func sqlite3JoinType(pParse *Parse, pA *Token, pB *Token, pC *Token) int {
+	jointype := 0
+	var apAll [3]*Token
+	var p *Token
+	/*   0123456789 123456789 123456789 123 */
+	zKeyText := "naturaleftouterightfullinnercross"
+	aKeyword := [...]struct {
+		i     uint8 /* Beginning of keyword text in zKeyText[] */
+		nChar uint8 /* Length of the keyword in characters */
+		code  uint8 /* Join type mask */
+	}{
+		/* (0) natural */ {0, 7, JT_NATURAL},
+		/* (1) left    */ {6, 4, JT_LEFT | JT_OUTER},
+		/* (2) outer   */ {10, 5, JT_OUTER},
+		/* (3) right   */ {14, 5, JT_RIGHT | JT_OUTER},
+		/* (4) full    */ {19, 4, JT_LEFT | JT_RIGHT | JT_OUTER},
+		/* (5) inner   */ {23, 5, JT_INNER},
+		/* (6) cross   */ {28, 5, JT_INNER | JT_CROSS},
+	}
+	var i, j int
+	apAll[0] = pA
+	apAll[1] = pB
+	apAll[2] = pC
+	for i = 0; i < 3 && apAll[i] != nil; i++ {
+		p = apAll[i]
+		for j = 0; j < len(aKeyword); j++ {
+			k := aKeyword[j]
+			if p.n == uint(k.nChar) &&
+				sqlite3_strnicmp(p.z, []byte(zKeyText[k.i:]), int(p.n)) == 0 {
+				jointype |= int(k.code)
+				break
+			}
+		}
+		testcase(j == 0 || j == 1 || j == 2 || j == 3 || j == 4 || j == 5 || j == 6)
+		if j >= len(aKeyword) {
+			jointype |= JT_ERROR
+			break
+		}
+	}
+	if (jointype&(JT_INNER|JT_OUTER)) == (JT_INNER|JT_OUTER) ||
+		(jointype&JT_ERROR) != 0 ||
+		(jointype&(JT_OUTER|JT_LEFT|JT_RIGHT)) == JT_OUTER {
+		zSp1 := " "
+		zSp2 := " "
+		if pB == nil {
+			zSp1 = ""
+		}
+		if pC == nil {
+			zSp2 = ""
+		}
+		sqlite3ErrorMsg(pParse, "unknown join type: "+
+			"%T%s%T%s%T", pA, zSp1, pB, zSp2, pC)
+		jointype = JT_INNER
+	} else if (jointype & JT_RIGHT) != 0 {
+		/* RIGHT and FULL JOIN first appeared in SQLite 3.39.0 */
+		pLast := pA
+		for i = 1; i < 3 && apAll[i] != nil; i++ {
+			pLast = apAll[i]
+		}
+		zName := "RIGHT JOIN"
+		if (jointype & JT_LEFT) != 0 {
+			zName = "FULL JOIN"
+		}
+		sqlite3CheckVersion(pParse, 3039000, zName, pA, pLast)
+	}
+	return jointype
+}
+
+/*
+** Return the right-most SELECT of the compound SELECT that p is part of.
+ */
+func findRightmost(p *Select) *Select {
+	for p.pNext != nil {
+		p = p.pNext
+	}
+	return p
+}
+
+/*
+** Argument pWith (which may be NULL) points to a linked list of nested
+** WITH contexts, from inner to outermost. If the table identified by
+** FROM clause element pItem is really a common-table-expression (CTE)
+** then return a pointer to the CTE definition for that table. Otherwise
+** return NULL.
+**
+** If a non-NULL value is returned, set *ppContext to point to the With
+** object that the returned CTE belongs to.
+ */
+func searchWith(
+	pWith *With, /* Current innermost WITH clause */
+	pItem *SrcItem, /* FROM clause element to resolve */
+	ppContext **With, /* OUT: WITH clause return value belongs to */
+) *Cte {
+	zName := pItem.zName
+	assert(pItem.zDatabase == nil, "pItem.zDatabase == nil")
+	assert(zName != nil, "zName != nil")
+	for p := pWith; p != nil; p = p.pOuter {
+		for i := 0; i < p.nCte; i++ {
+			if sqlite3StrICmp(zName, p.a[i].zName) == 0 {
+				*ppContext = p
+				return &p.a[i]
+			}
+		}
+		if p.bView != 0 {
+			break
+		}
+	}
+	return nil
+}
+
+/* The code generator maintains a stack of active WITH clauses
+** with the inner-most WITH clause being at the top of the stack.
+**
+** This routine pushes the WITH clause passed as the second argument
+** onto the top of the stack. If argument bFree is true, then this
+** WITH clause will never be popped from the stack but should instead
+** be freed along with the Parse object. In other cases, when
+** bFree==0, the With object will be freed along with the SELECT
+** statement with which it is associated.
+ */
+func sqlite3WithPush(pParse *Parse, pWith *With, bFree uint8) *With {
+	UNUSED_PARAMETER(bFree)
+	if pWith != nil {
+		if pParse.nErr == 0 {
+			assert(pParse.pWith != pWith, "pParse.pWith != pWith")
+			pWith.pOuter = pParse.pWith
+			pParse.pWith = pWith
+		}
+	}
+	return pWith
+}
+
+/*
+** Check to see if the FROM clause term pFrom has table-valued function
+** arguments.  If it does, leave an error message in pParse and return
+** non-zero, since pFrom is not allowed to be a table-valued function.
+ */
+func cannotBeFunction(pParse *Parse, pFrom *SrcItem) int {
+	if pFrom.u1.pFuncArg != nil {
+		sqlite3ErrorMsg(pParse, "'%s' is not a function", pFrom.zName)
+		return 1
+	}
+	return 0
+}
+
+/*
+** This routine is a Walker callback used by resolveFromTermToCte() to
+** look for recursive references in the definition of the CTE in
+** pWalker->u.pCte that are not allowed.  Leave the error in
+** pCte->zCteErr in pParse if a term of the FROM clause of p, other than
+** the recursive reference itself, refers to the CTE.  The definitions of
+** other CTEs named in the FROM clause are checked too, since in C they
+** are expanded as part of the walk.
+**
+** Unlike selectExpander(), this routine changes nothing in the tree and
+** does not stop at a SELECT that has already been expanded, so that the
+** whole CTE definition is checked.
+ */
+func cteRecursiveRefStep(pWalker *Walker, p *Select) int {
+	pParse := pWalker.pParse
+	pCte := pWalker.u.pCte
+	sqlite3WithPush(pParse, p.pWith, 0)
+	for i := 0; i < p.pSrc.nSrc; i++ {
+		var pWith *With
+		pFrom := &p.pSrc.a[i]
+		if pFrom.fg.isRecursive != 0 || pFrom.zName == nil || pFrom.zDatabase != nil {
+			continue
+		}
+		pRef := searchWith(pParse.pWith, pFrom, &pWith)
+		if pRef == pCte {
+			sqlite3ErrorMsg(pParse, string(pCte.zCteErr), pCte.zName)
+			return WRC_Abort
+		}
+		if pRef != nil && pRef.zCteErr == nil {
+			pSavedWith := pParse.pWith
+			pParse.pWith = pWith
+			pRef.zCteErr = []byte("circular reference: %s")
+			rc := sqlite3WalkSelect(pWalker, pRef.pSelect)
+			pRef.zCteErr = nil
+			pParse.pWith = pSavedWith
+			if rc != 0 {
+				return WRC_Abort
+			}
+		}
+	}
+	return WRC_Continue
+}
+
+/*
+** Check to see if the FROM clause term pFrom is a reference to a common
+** table expression of a WITH clause in scope.  If it is, analyze the
+** CTE and return 1.  If it is not, return 0.  If an error occurs, leave
+** an error in pParse and return 2.
+**
+** The C version attaches a copy of the CTE definition to pFrom and
+** expands the copy once for each reference.  This port leaves the tree
+** as written.  The CTE definition itself is analyzed, once, when the
+** first reference to it is seen.  The analysis marks the recursive terms
+** of the CTE with SF_Recursive and the recursive references in their
+** FROM clauses with fg.isRecursive.  Each reference is linked to the
+** CteUse object of the CTE through u2.pCteUse.
+ */
+func resolveFromTermToCte(
+	pParse *Parse, /* The parsing context */
+	pWalker *Walker, /* Current tree walker */
+	pFrom *SrcItem, /* The FROM clause term to check */
+) int {
+	var pCte *Cte   /* Matched CTE (or NULL if no match) */
+	var pWith *With /* The matching WITH */
+	var pCteUse *CteUse
+
+	assert(pFrom.pTab == nil, "pFrom.pTab == nil")
+	if pParse.pWith == nil {
+		/* There are no WITH clauses in the stack.  No match is possible */
+		return 0
+	}
+	if pParse.nErr != 0 {
+		/* Prior errors might have left pParse->pWith in a goofy state, so
+		 ** go no further. */
+		return 0
+	}
+	if pFrom.zDatabase != nil {
+		/* The FROM term contains a schema qualifier (ex: main.t1) and so
+		 ** it cannot possibly be a CTE reference. */
+		return 0
+	}
+	pCte = searchWith(pParse.pWith, pFrom, &pWith)
+	if pCte == nil {
+		return 0
+	}
+
+	/* If pCte->zCteErr is non-NULL at this point, then this is an illegal
+	 ** recursive reference to CTE pCte. Leave an error in pParse and return
+	 ** early. If pCte->zCteErr is NULL, then this is not a recursive reference.
+	 ** In this case, proceed.  */
+	if pCte.zCteErr != nil {
+		sqlite3ErrorMsg(pParse, string(pCte.zCteErr), pCte.zName)
+		return 2
+	}
+	if cannotBeFunction(pParse, pFrom) != 0 {
+		return 2
+	}
+
+	pFrom.fg.isCte = 1
+	if pCteUse = pCte.pUse; pCteUse != nil {
+		/* The CTE was analyzed when an earlier reference was resolved */
+		pFrom.u2.pCteUse = pCteUse
+		pCteUse.nUse++
+		return 1
+	}
+	pCteUse = &CteUse{}
+	pCteUse.eM10d = pCte.eM10d
+	pCte.pUse = pCteUse
+	pFrom.u2.pCteUse = pCteUse
+	pCteUse.nUse++
+
+	/* Check if this is a recursive CTE. */
+	pSel := pCte.pSelect
+	pRecTerm := pSel
+	bMayRecursive := pSel.op == TK_ALL || pSel.op == TK_UNION
+	for bMayRecursive && pRecTerm.op == pSel.op {
+		pSrc := pRecTerm.pSrc
+		assert(pRecTerm.pPrior != nil, "pRecTerm.pPrior != nil")
+		for i := 0; i < pSrc.nSrc; i++ {
+			pItem := &pSrc.a[i]
+			if pItem.zDatabase == nil &&
+				pItem.zName != nil &&
+				sqlite3StrICmp(pItem.zName, pCte.zName) == 0 {
+				pItem.fg.isRecursive = 1
+				if (pRecTerm.selFlags & SF_Recursive) != 0 {
+					sqlite3ErrorMsg(pParse,
+						"multiple references to recursive table: %s", pCte.zName)
+					return 2
+				}
+				pRecTerm.selFlags |= SF_Recursive
+			}
+		}
+		if (pRecTerm.selFlags & SF_Recursive) == 0 {
+			break
+		}
+		pRecTerm = pRecTerm.pPrior
+	}
+
+	pCte.zCteErr = []byte("circular reference: %s")
+	pSavedWith := pParse.pWith
+	pParse.pWith = pWith
+	if (pSel.selFlags & SF_Recursive) != 0 {
+		assert(pRecTerm != nil, "pRecTerm != nil")
+		assert((pRecTerm.selFlags&SF_Recursive) == 0, "(pRecTerm.selFlags&SF_Recursive) == 0")
+		assert(pRecTerm.pNext != nil, "pRecTerm.pNext != nil")
+		assert((pRecTerm.pNext.selFlags&SF_Recursive) != 0, "(pRecTerm.pNext.selFlags&SF_Recursive) != 0")
+		assert(pRecTerm.pWith == nil, "pRecTerm.pWith == nil")
+		pRecTerm.pWith = pSel.pWith
+		rc := sqlite3WalkSelect(pWalker, pRecTerm)
+		pRecTerm.pWith = nil
+		if rc != 0 {
+			pParse.pWith = pSavedWith
+			return 2
+		}
+	} else {
+		if sqlite3WalkSelect(pWalker, pSel) != 0 {
+			pParse.pWith = pSavedWith
+			return 2
+		}
+	}
+	pParse.pWith = pWith
+
+	pLeft := pSel
+	for pLeft.pPrior != nil {
+		pLeft = pLeft.pPrior
+	}
+	pEList := pLeft.pEList
+	if pCte.pCols != nil && pEList != nil && !selectHasStar(pEList) &&
+		pEList.nExpr != pCte.pCols.nExpr {
+		sqlite3ErrorMsg(pParse, "table %s has %d values for %d columns",
+			pCte.zName, pEList.nExpr, pCte.pCols.nExpr)
+		pParse.pWith = pSavedWith
+		return 2
+	}
+
+	if bMayRecursive {
+		if (pSel.selFlags & SF_Recursive) != 0 {
+			pCte.zCteErr = []byte("multiple recursive references: %s")
+		} else {
+			pCte.zCteErr = []byte("recursive reference in a subquery: %s")
+		}
+		/* The recursive terms are expanded by the second walk.  Parts of
+		 ** the definition were already expanded by the first one and would
+		 ** be skipped, so the check is made on the whole definition first.
+		 */
+		var w Walker
+		w.pParse = pParse
+		w.xExprCallback = sqlite3ExprWalkNoop
+		w.xSelectCallback = cteRecursiveRefStep
+		w.xSelectCallback2 = sqlite3SelectPopWith
+		w.u.pCte = pCte
+		if sqlite3WalkSelect(&w, pSel) == 0 {
+			sqlite3WalkSelect(pWalker, pSel)
+		}
+	}
+	pCte.zCteErr = nil
+	pParse.pWith = pSavedWith
+	return 1 /* Success */
+}
+
+/*
+** Given an expression list (which is really the list of expressions
+** that form the result set of a SELECT statement) compute appropriate
+** column names for a table that would hold the expression list.
+**
+** All column names will be unique.
+**
+** Only the column names are computed.  Column.zType, Column.zColl,
+** and other fields of Column are zeroed.
+**
+** The port does not resolve column references to TK_COLUMN nodes, so a
+** column reference is named by its identifier.  The C version makes up
+** a random suffix once a name has collided more than three times.  Here
+** the suffix keeps counting, so that the names are reproducible.
+ */
+func sqlite3ColumnsFromExprList(
+	pParse *Parse, /* Parsing context */
+	pEList *ExprList, /* Expr list from which to derive column names */
+	pnCol *int16, /* Write the number of columns here */
+	paCol *[]Column, /* Write the new column list here */
+) int {
+	var nCol int      /* Number of columns in the result set */
+	var aCol []Column /* For looping over result columns */
+	var zName []byte  /* Column name */
+	var nName int     /* Size of name in zName[] */
+	var cnt int       /* Index added to make the name unique */
+	db := pParse.db
+
+	if pEList != nil {
+		nCol = pEList.nExpr
+		aCol = make([]Column, nCol)
+	} else {
+		nCol = 0
+		aCol = nil
+	}
+	assert(nCol == int(int16(nCol)), "nCol == int(int16(nCol))")
+	*pnCol = int16(nCol)
+	*paCol = aCol
+
+	for i := 0; i < nCol; i++ {
+		pX := &pEList.a[i]
+		pCol := &aCol[i]
+
+		/* Get an appropriate name for the column
+		 */
+		zName = nil
+		if pX.zEName != nil && pX.eEName == ENAME_NAME {
+			/* If the column contains an "AS <name>" phrase, use <name> as the name */
+			zName = pX.zEName
+		} else {
+			pColExpr := sqlite3ExprSkipCollateAndLikely(pX.pExpr)
+			for pColExpr != nil && pColExpr.op == TK_DOT {
+				pColExpr = pColExpr.pRight
+				assert(pColExpr != nil, "pColExpr != nil")
+			}
+			if pColExpr != nil && pColExpr.op == TK_ID {
+				/* For columns use the column name name */
+				assert(!ExprHasProperty(pColExpr, EP_IntValue), "!ExprHasProperty(pColExpr, EP_IntValue)")
+				zName = pColExpr.u.zToken
+			} else {
+				/* Use the original text of the column expression as its name */
+				zName = pX.zEName
+			}
+		}
+		if zName != nil && sqlite3IsTrueOrFalse(zName) == 0 {
+			zName = sqlite3DbStrDup(db, zName)
+		} else {
+			zName = sqlite3MPrintf(db, "column%d", i+1)
+		}
+
+		/* Make sure the column name is unique.  If the name is not unique,
+		 ** append an integer to the name so that it becomes unique.
+		 */
+		cnt = 0
+		for j := 0; j < i; j++ {
+			if sqlite3StrICmp(aCol[j].zCnName, zName) != 0 {
+				continue
+			}
+			nName = len(zName)
+			if nName > 0 {
+				k := nName - 1
+				for k > 0 && sqlite3Isdigit(zName[k]) {
+					k--
+				}
+				if zName[k] == ':' {
+					nName = k
+				}
+			}
+			cnt++
+			zName = sqlite3MPrintf(db, "%s:%d", zName[:nName], cnt)
+			j = -1
+		}
+		pCol.zCnName = zName
+	}
+	return SQLITE_OK
+}
+
+/*
+** pTab is a transient Table object that represents a subquery of some
+** kind (maybe a parenthesized subquery in the FROM clause of a larger
+** query, or a VIEW, or a CTE).  This routine computes type information
+** for that Table object based on the expressions in the result set of
+** pSelect.
+**
+** Only the affinity of each column is computed.  It is that of the
+** expression in the leftmost SELECT, or aff if the expression has none.
+** The declared types and collating sequences of the C version come from
+** the table columns that the expressions refer to, which the port does
+** not know.
+ */
+func sqlite3SubqueryColumnTypes(
+	pParse *Parse, /* Parsing contexts */
+	pTab *Table, /* Add column type information to this table */
+	pSelect *Select, /* SELECT used to determine types and collations */
+	aff rune, /* Default affinity. */
+) {
+	UNUSED_PARAMETER(pParse)
+	for pSelect.pPrior != nil {
+		pSelect = pSelect.pPrior
+	}
+	a := pSelect.pEList.a
+	for i := 0; i < int(pTab.nCol); i++ {
+		pCol := &pTab.aCol[i]
+		p := a[i].pExpr
+		pCol.affinity = sqlite3ExprAffinity(p)
+		if pCol.affinity <= SQLITE_AFF_NONE {
+			pCol.affinity = aff
+		}
+	}
+}
+
+/*
+** Return true if result column list pEList contains a "*" or "TABLE.*"
+** term.  The C version expands these before counting the columns of a
+** CTE.  Without a schema they cannot be expanded, so the count is not
+** checked.
+ */
+func selectHasStar(pEList *ExprList) bool {
+	for k := 0; k < pEList.nExpr; k++ {
+		pE := pEList.a[k].pExpr
+		if pE.op == TK_ASTERISK {
+			return true
+		}
+		if pE.op == TK_DOT && pE.pRight.op == TK_ASTERISK {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+** The SELECT statement passed as the second parameter is a compound SELECT
+** with an associated WITH clause. This function pops the WITH clause from
+** the stack of in-scope WITH clauses.  It is invoked as the
+** xSelectCallback2() of the walker used by sqlite3SelectExpand().
+ */
+func sqlite3SelectPopWith(pWalker *Walker, p *Select) {
+	pParse := pWalker.pParse
+	if pParse.pWith != nil && p.pPrior == nil {
+		pWith := findRightmost(p).pWith
+		if pWith != nil {
+			assert(pParse.pWith == pWith || pParse.nErr != 0, "pParse.pWith == pWith || pParse.nErr != 0")
+			pParse.pWith = pWith.pOuter
+		}
+	}
+}
+
+/*
+** This routine is a Walker callback for "expanding" a SELECT statement.
+**
+** In C this routine looks up every table of the FROM clause in the
+** schema, expands "*" in the result set and turns CTE references into
+** subqueries.  This port has no schema, so only the WITH clauses are
+** processed: p->pWith is pushed onto the stack of in-scope WITH clauses
+** and each FROM clause term that names a CTE is checked by
+** resolveFromTermToCte().
+ */
+func selectExpander(pWalker *Walker, p *Select) int {
+	pParse := pWalker.pParse
+	if (p.selFlags & SF_Expanded) != 0 {
+		return WRC_Prune
+	}
+	pTabList := p.pSrc
+	p.selFlags |= SF_Expanded
+	sqlite3WithPush(pParse, p.pWith, 0)
+
+	/* Look up every table named in the FROM clause of the select.  If
+	 ** an entry of the FROM clause is a subquery instead of a table or view,
+	 ** then expand the subquery first.
+	 */
+	for i := 0; i < pTabList.nSrc; i++ {
+		pFrom := &pTabList.a[i]
+		if pFrom.fg.isRecursive != 0 {
+			continue
+		}
+		if pFrom.zName == nil {
+			pSel := pFrom.pSelect
+			/* A sub-query in the FROM clause of a SELECT */
+			assert(pSel != nil, "pSel != nil")
+			if sqlite3WalkSelect(pWalker, pSel) != 0 {
+				return WRC_Abort
+			}
+		} else if rc := resolveFromTermToCte(pParse, pWalker, pFrom); rc > 1 {
+			return WRC_Abort
+		}
+	}
+	return WRC_Continue
+}
+
+/*
+** This routine "expands" a SELECT statement and all of its subqueries.
+** In this port that means resolving references to common table
+** expressions.  Errors, such as a circular reference between CTEs, are
+** left in pParse.
+ */
+func sqlite3SelectExpand(pParse *Parse, pSelect *Select) {
+	var w Walker
+	w.xExprCallback = sqlite3ExprWalkNoop
+	w.pParse = pParse
+	w.xSelectCallback = selectExpander
+	w.xSelectCallback2 = sqlite3SelectPopWith
+	w.eCode = 0
+	sqlite3WalkSelect(&w, pSelect)
+}
+
+/*
+** This routine sets up a SELECT statement for processing.  The
+** following is accomplished:
+**
+**     *  VDBE Cursor numbers are assigned to all FROM-clause terms.
+**     *  Ephemeral Table objects are created for all FROM-clause subqueries.
+**     *  ON and USING clauses are shifted into WHERE statements
+**     *  Wildcards "*" and "TABLE.*" in result sets are expanded.
+**     *  Identifiers in expression are matched to tables.
+**
+** The port has no schema, so only the common table expressions are
+** expanded and the identifiers are checked as described in resolve.go.
+** The window functions of each SELECT are resolved against its WINDOW
+** clause at the same time.  Errors are left in pParse.
+ */
+func sqlite3SelectPrep(
+	pParse *Parse, /* The parser context */
+	p *Select, /* The SELECT statement being coded. */
+	pOuterNC *NameContext, /* Name context for container */
+) {
+	if NEVER(p == nil) {
+		return
+	}
+	sqlite3SelectExpand(pParse, p)
+	if pParse.nErr != 0 {
+		return
+	}
+	sqlite3ResolveSelectNames(pParse, p, pOuterNC)
+}
+
+/*
+** Generate code for the SELECT statement given in the p argument.
+**
+** The Go port generates no code.  The parse tree is recorded in
+** pParse->pSelect, where sqlite3FinishCoding() finds it.  The return
+** value is the number of errors seen, as in C.
+ */
+func sqlite3Select(
+	pParse *Parse, /* The parser context */
+	p *Select, /* The SELECT statement being coded. */
+	pDest *SelectDest, /* What to do with the query results */
+) int {
+	if pParse.nErr != 0 {
+		return 1
+	}
+	assert(pDest.eDest == SRT_Output, "pDest.eDest == SRT_Output")
+	sqlite3SelectPrep(pParse, p, nil)
+	if pParse.nErr != 0 {
+		return 1
+	}
+	pParse.pSelect = p
+	return 0
+}
+
+/*
+** CteInfo describes one common table expression of a WITH clause.
+** Materialized is "MATERIALIZED" or "NOT MATERIALIZED" if the CTE
+** carries that hint, or "" if it does not.  Recursive is true if the
+** CTE refers to itself through a UNION or UNION ALL, and Uses counts the
+** references to the CTE.  As in SQLite, a CTE is analyzed only when the
+** statement refers to it, so Recursive is false for an unused CTE.
+ */
+type CteInfo struct {
+	Name         string   /* Name of the CTE */
+	Columns      []string /* Declared column names, or nil */
+	Select       *Select  /* The definition of the CTE */
+	Materialized string   /* The MATERIALIZED hint */
+	Recursive    bool     /* True for a recursive CTE */
+	Uses         int      /* Number of references to the CTE */
+}
+
+/*
+** With returns the common table expressions of the WITH clause of p, in
+** the order they were written.  It returns nil if p has no WITH clause.
+ */
+func (p *Select) With() []CteInfo {
+	pWith := findRightmost(p).pWith
+	if pWith == nil {
+		return nil
+	}
+	aCte := make([]CteInfo, pWith.nCte)
+	for i := range aCte {
+		pCte := &pWith.a[i]
+		aCte[i].Name = string(pCte.zName)
+		if pCte.pCols != nil {
+			for j := 0; j < pCte.pCols.nExpr; j++ {
+				aCte[i].Columns = append(aCte[i].Columns, string(pCte.pCols.a[j].zEName))
+			}
+		}
+		aCte[i].Select = pCte.pSelect
+		switch pCte.eM10d {
+		case M10d_Yes:
+			aCte[i].Materialized = "MATERIALIZED"
+		case M10d_No:
+			aCte[i].Materialized = "NOT MATERIALIZED"
+		}
+		aCte[i].Recursive = (pCte.pSelect.selFlags & SF_Recursive) != 0
+		if pCte.pUse != nil {
+			aCte[i].Uses = pCte.pUse.nUse
+		}
+	}
+	return aCte
+}
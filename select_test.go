@@ -0,0 +1,72 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Tests for analyzing common table expressions.
+ */
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+/*
+** With must report each CTE as written, and count the references to it.
+ */
+func TestWith(t *testing.T) {
+	p := testSelect(t, "WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x+1 FROM c WHERE x<10), "+
+		"d AS MATERIALIZED (SELECT 2) SELECT * FROM c, d, d AS e")
+	aCte := p.With()
+	if len(aCte) != 2 {
+		t.Fatalf("%d CTEs, want 2", len(aCte))
+	}
+	if aCte[0].Name != "c" || !reflect.DeepEqual(aCte[0].Columns, []string{"x"}) ||
+		!aCte[0].Recursive || aCte[0].Select == nil {
+		t.Errorf("got %+v for c", aCte[0])
+	}
+	if aCte[1].Name != "d" || aCte[1].Columns != nil || aCte[1].Recursive ||
+		aCte[1].Materialized != "MATERIALIZED" || aCte[1].Uses != 2 {
+		t.Errorf("got %+v for d", aCte[1])
+	}
+	if testSelect(t, "SELECT 1").With() != nil {
+		t.Error("With() is not nil without a WITH clause")
+	}
+}
+
+/*
+** Circular, duplicate and misused CTEs must be reported the way SQLite
+** reports them.
+ */
+func TestCteError(t *testing.T) {
+	aTest := []struct {
+		zSql string
+		zErr string
+	}{
+		{"WITH a AS (SELECT * FROM b), b AS (SELECT * FROM a) SELECT * FROM a",
+			"circular reference: a"},
+		{"WITH RECURSIVE c(x) AS (SELECT x FROM c) SELECT * FROM c",
+			"circular reference: c"},
+		{"WITH a AS (SELECT 1), a AS (SELECT 2) SELECT * FROM a",
+			"duplicate WITH table name: a"},
+		{"WITH a(x, y) AS (SELECT 1) SELECT * FROM a",
+			"table a has 1 values for 2 columns"},
+		{"WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x+1 FROM c, c) SELECT * FROM c",
+			"multiple references to recursive table: c"},
+		{"WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT (SELECT x FROM c) FROM c) SELECT * FROM c",
+			"multiple recursive references: c"},
+	}
+	for _, tc := range aTest {
+		_, err := ParseSQL(tc.zSql, nil)
+		if pErr, ok := err.(*Error); !ok || pErr.Msg != tc.zErr {
+			t.Errorf("%s: got %v, want %q", tc.zSql, err, tc.zErr)
+		}
+	}
+}
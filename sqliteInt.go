@@ -54,10 +54,92 @@ const (
 	TABTYP_VIEW = 2 /* A view */
 )
 
+/*
+** Macros to determine if a Table object is a view, a virtual table or
+** an ordinary table.
+ */
+func IsView(X *Table) bool          { return X.eTabType == TABTYP_VIEW }
+func IsVirtual(X *Table) bool       { return X.eTabType == TABTYP_VTAB }
+func IsOrdinaryTable(X *Table) bool { return X.eTabType == TABTYP_NORM }
+
+/* Does the table have a rowid */
+func HasRowid(X *Table) bool { return (X.tabFlags & TF_WithoutRowid) == 0 }
+
 // TODO: I made these up
 type Hash uint64
 type Pgno uint64
 
+/*
+** Result codes.  Only the codes that the parser can produce are
+** defined here.
+ */
+const (
+	SQLITE_OK     = 0   /* Successful result */
+	SQLITE_ERROR  = 1   /* Generic error */
+	SQLITE_NOMEM  = 7   /* A malloc() failed */
+	SQLITE_TOOBIG = 18  /* String or BLOB exceeds size limit */
+	SQLITE_MISUSE = 21  /* Library used incorrectly */
+	SQLITE_RANGE  = 25  /* 2nd parameter to sqlite3_bind out of range */
+	SQLITE_DONE   = 101 /* sqlite3_step() has finished executing */
+)
+
+/*
+** Run-time limit categories.  These are the values of the first
+** argument to sqlite3_limit().
+**
+** SQLITE_LIMIT_PARSER_DEPTH is not part of SQLite.  It bounds the depth
+** of the LALR(1) parser stack, which SQLite fixes at compile time with
+** YYSTACKDEPTH.
+ */
+const (
+	SQLITE_LIMIT_LENGTH              = 0
+	SQLITE_LIMIT_SQL_LENGTH          = 1
+	SQLITE_LIMIT_COLUMN              = 2
+	SQLITE_LIMIT_EXPR_DEPTH          = 3
+	SQLITE_LIMIT_COMPOUND_SELECT     = 4
+	SQLITE_LIMIT_VDBE_OP             = 5
+	SQLITE_LIMIT_FUNCTION_ARG        = 6
+	SQLITE_LIMIT_ATTACHED            = 7
+	SQLITE_LIMIT_LIKE_PATTERN_LENGTH = 8
+	SQLITE_LIMIT_VARIABLE_NUMBER     = 9
+	SQLITE_LIMIT_TRIGGER_DEPTH       = 10
+	SQLITE_LIMIT_WORKER_THREADS      = 11
+	SQLITE_LIMIT_PARSER_DEPTH        = 12
+)
+
+/*
+** The number of different kinds of things that can be limited
+** using the sqlite3_limit() interface.
+ */
+const SQLITE_N_LIMIT = SQLITE_LIMIT_PARSER_DEPTH + 1
+
+/*
+** The hard upper bounds of the run-time limits.  These are the values
+** from sqliteLimit.h.  A limit may be lowered at run-time but never
+** raised above these values.
+ */
+const (
+	SQLITE_MAX_LENGTH              = 1000000000
+	SQLITE_MAX_COLUMN              = 2000
+	SQLITE_MAX_SQL_LENGTH          = 1000000000
+	SQLITE_MAX_EXPR_DEPTH          = 1000
+	SQLITE_MAX_COMPOUND_SELECT     = 500
+	SQLITE_MAX_VDBE_OP             = 250000000
+	SQLITE_MAX_FUNCTION_ARG        = 127
+	SQLITE_MAX_ATTACHED            = 10
+	SQLITE_MAX_LIKE_PATTERN_LENGTH = 50000
+	SQLITE_MAX_VARIABLE_NUMBER     = 32766
+	SQLITE_MAX_TRIGGER_DEPTH       = 1000
+	SQLITE_MAX_WORKER_THREADS      = 8
+	SQLITE_MAX_PARSER_DEPTH        = 10000
+)
+
+/*
+** The default depth of the parser stack.  This is the YYSTACKDEPTH that
+** lemon writes into parse.go.
+ */
+const SQLITE_DEFAULT_PARSER_DEPTH = YYSTACKDEPTH
+
 /*
 ** The bitmask datatype defined below is used for various optimizations.
 **
@@ -184,9 +266,9 @@ type AggInfo struct {
 ** set.
  */
 type Column struct {
-	zCnName []byte /* Name of this column */
-	// unsigned notNull :4;  /* An OE_ code for handling a NOT NULL constraint */
-	// unsigned eCType :4;   /* One of the standard types */
+	zCnName  []byte /* Name of this column */
+	notNull  uint8  /* An OE_ code for handling a NOT NULL constraint */
+	eCType   uint8  /* One of the standard types */
 	affinity rune   /* One of the SQLITE_AFF_... values */
 	szEst    uint8  /* Est size of value in this column. sizeof(INT)==1 */
 	hName    uint8  /* Column name hash for faster lookup */
@@ -194,6 +276,77 @@ type Column struct {
 	colFlags uint16 /* Boolean properties.  See COLFLAG_ defines below */
 }
 
+/* Allowed values for Column.eCType.
+**
+** Values must match entries in the global constant arrays
+** sqlite3StdTypeLen[] and sqlite3StdType[].  Each value is one more
+** than the offset into these arrays for the corresponding name.
+** Adjust the SQLITE_N_STDTYPE value if adding or removing entries.
+ */
+const (
+	COLTYPE_CUSTOM   = 0 /* Type appended to zName */
+	COLTYPE_ANY      = 1
+	COLTYPE_BLOB     = 2
+	COLTYPE_INT      = 3
+	COLTYPE_INTEGER  = 4
+	COLTYPE_REAL     = 5
+	COLTYPE_TEXT     = 6
+	SQLITE_N_STDTYPE = 6 /* Number of standard types */
+)
+
+/* Allowed values for Column.colFlags.
+**
+** Constraints:
+**         TF_HasVirtual == COLFLAG_VIRTUAL
+**         TF_HasStored  == COLFLAG_STORED
+**         TF_HasHidden  == COLFLAG_HIDDEN
+ */
+const (
+	COLFLAG_PRIMKEY   = 0x0001 /* Column is part of the primary key */
+	COLFLAG_HIDDEN    = 0x0002 /* A hidden column in a virtual table */
+	COLFLAG_HASTYPE   = 0x0004 /* Type name follows column name */
+	COLFLAG_UNIQUE    = 0x0008 /* Column def contains "UNIQUE" or "PK" */
+	COLFLAG_SORTERREF = 0x0010 /* Use sorter-refs with this column */
+	COLFLAG_VIRTUAL   = 0x0020 /* GENERATED ALWAYS AS ... VIRTUAL */
+	COLFLAG_STORED    = 0x0040 /* GENERATED ALWAYS AS ... STORED */
+	COLFLAG_NOTAVAIL  = 0x0080 /* STORED column not yet calculated */
+	COLFLAG_BUSY      = 0x0100 /* Blocks recursion on GENERATED columns */
+	COLFLAG_HASCOLL   = 0x0200 /* Has collating sequence name in zCnName */
+	COLFLAG_NOEXPAND  = 0x0400 /* Omit this column when expanding "*" */
+	COLFLAG_GENERATED = 0x0060 /* Combo: _STORED, _VIRTUAL */
+	COLFLAG_NOINSERT  = 0x0062 /* Combo: _HIDDEN, _STORED, _VIRTUAL */
+)
+
+/*
+** Test to see whether or not a column is hidden.
+ */
+func IsHiddenColumn(X *Column) bool { return (X.colFlags & COLFLAG_HIDDEN) != 0 }
+
+/*
+** Column affinity types.
+**
+** These used to have mnemonic name like 'i' for SQLITE_AFF_INTEGER and
+** 't' for SQLITE_AFF_TEXT.  But we can save a little space and improve
+** the speed a little by numbering the values consecutively.
+**
+** But rather than start with 0 or 1, we begin with 'A'.  That way,
+** when multiple affinity types are concatenated into a string and
+** used as the P4 operand, they will be more readable.
+**
+** Note also that the numeric types are grouped together so that testing
+** for a numeric type is a single comparison.  And the BLOB type is first.
+ */
+const (
+	SQLITE_AFF_NONE    = 0x40 /* '@' */
+	SQLITE_AFF_BLOB    = 0x41 /* 'A' */
+	SQLITE_AFF_TEXT    = 0x42 /* 'B' */
+	SQLITE_AFF_NUMERIC = 0x43 /* 'C' */
+	SQLITE_AFF_INTEGER = 0x44 /* 'D' */
+	SQLITE_AFF_REAL    = 0x45 /* 'E' */
+)
+
+func sqlite3IsNumericAffinity(X rune) bool { return X >= SQLITE_AFF_NUMERIC }
+
 /*
 ** A single common table expression
  */
@@ -226,6 +379,15 @@ type CteUse struct {
 	eM10d   uint8  /* The MATERIALIZED flag */
 }
 
+/*
+** Allowed values for the Cte.eM10d field
+ */
+const (
+	M10d_Yes = 0 /* AS MATERIALIZED */
+	M10d_Any = 1 /* Not specified.  Query planner's choice */
+	M10d_No  = 2 /* AS NOT MATERIALIZED */
+)
+
 /*
 ** Each node of an expression in the parse tree is an instance
 ** of this structure.
@@ -299,8 +461,8 @@ type Expr struct {
 	vvaFlags uint8  /* Verification flags. */
 	flags    uint32 /* Various flags.  EP_* See below */
 	u        struct {
-		zToken *rune /* Token value. Zero terminated and dequoted */
-		iValue int   /* Non-negative integer value if EP_IntValue */
+		zToken []byte /* Token value. Zero terminated and dequoted */
+		iValue int    /* Non-negative integer value if EP_IntValue */
 	}
 
 	/* If the EP_TokenOnly flag is set in the Expr.flags mask, then no
@@ -345,8 +507,84 @@ type Expr struct {
 			regReturn int /* Register used to hold return address */
 		}
 	}
+	iSpan int /* Start of the text of this expression, as for w.iOfst */
+	nSpan int /* Length of that text.  0 if it was not recorded */
 }
 
+/*
+** The following are the meanings of bits in the Expr.flags field.
+** Value restrictions:
+**
+**          EP_Agg == NC_HasAgg == SF_HasAgg
+**          EP_Win == NC_HasWin
+ */
+const (
+	EP_FromJoin  = 0x000001   /* Originates in ON/USING clause of outer join */
+	EP_Distinct  = 0x000002   /* Aggregate function with DISTINCT keyword */
+	EP_HasFunc   = 0x000004   /* Contains one or more functions of any kind */
+	EP_FixedCol  = 0x000008   /* TK_Column with a known fixed value */
+	EP_Agg       = 0x000010   /* Contains one or more aggregate functions */
+	EP_VarSelect = 0x000020   /* pSelect is correlated, not constant */
+	EP_DblQuoted = 0x000040   /* token.z was originally in "..." */
+	EP_InfixFunc = 0x000080   /* True for an infix function: LIKE, GLOB, etc */
+	EP_Collate   = 0x000100   /* Tree contains a TK_COLLATE operator */
+	EP_Commuted  = 0x000200   /* Comparison operator has been commuted */
+	EP_IntValue  = 0x000400   /* Integer value contained in u.iValue */
+	EP_xIsSelect = 0x000800   /* x.pSelect is valid (otherwise x.pList is) */
+	EP_Skip      = 0x001000   /* Operator does not contribute to affinity */
+	EP_Reduced   = 0x002000   /* Expr struct EXPR_REDUCEDSIZE bytes only */
+	EP_TokenOnly = 0x004000   /* Expr struct EXPR_TOKENONLYSIZE bytes only */
+	EP_Win       = 0x008000   /* Contains window functions */
+	EP_MemToken  = 0x010000   /* Need to sqlite3DbFree() Expr.zToken */
+	EP_IfNullRow = 0x020000   /* The TK_IF_NULL_ROW opcode */
+	EP_Unlikely  = 0x040000   /* unlikely() or likelihood() function */
+	EP_ConstFunc = 0x080000   /* A SQLITE_FUNC_CONSTANT or _SLOCHNG function */
+	EP_CanBeNull = 0x100000   /* Can be null despite NOT NULL constraint */
+	EP_Subquery  = 0x200000   /* Tree contains a TK_SELECT operator */
+	EP_InnerJoin = 0x400000   /* Originates in ON/USING of an inner join */
+	EP_Leaf      = 0x800000   /* Expr.pLeft, .pRight, .u.pSelect all NULL */
+	EP_WinFunc   = 0x1000000  /* TK_FUNCTION with Expr.y.pWin set */
+	EP_Subrtn    = 0x2000000  /* Uses Expr.y.sub. TK_IN, _SELECT, or _EXISTS */
+	EP_Quoted    = 0x4000000  /* TK_ID was originally quoted */
+	EP_Static    = 0x8000000  /* Held in memory not obtained from malloc() */
+	EP_IsTrue    = 0x10000000 /* Always has boolean value of TRUE */
+	EP_IsFalse   = 0x20000000 /* Always has boolean value of FALSE */
+	EP_FromDDL   = 0x40000000 /* Originates from sqlite_schema */
+	/*             0x80000000 // Available */
+)
+
+/* The EP_Propagate mask is a set of properties that automatically propagate
+** upwards into parent nodes.
+ */
+const EP_Propagate = EP_Collate | EP_Subquery | EP_HasFunc
+
+/* Macros can be used to test, set, or clear bits in the
+** Expr.flags field.
+ */
+func ExprHasProperty(E *Expr, P uint32) bool    { return (E.flags & P) != 0 }
+func ExprHasAllProperty(E *Expr, P uint32) bool { return (E.flags & P) == P }
+func ExprSetProperty(E *Expr, P uint32)         { E.flags |= P }
+func ExprClearProperty(E *Expr, P uint32)       { E.flags &^= P }
+func ExprAlwaysTrue(E *Expr) bool               { return (E.flags & (EP_FromJoin | EP_IsTrue)) == EP_IsTrue }
+func ExprAlwaysFalse(E *Expr) bool              { return (E.flags & (EP_FromJoin | EP_IsFalse)) == EP_IsFalse }
+
+/* Macros used to ensure that the correct members of unions are accessed
+** in Expr.
+ */
+func ExprUseUToken(E *Expr) bool  { return (E.flags & EP_IntValue) == 0 }
+func ExprUseUValue(E *Expr) bool  { return (E.flags & EP_IntValue) != 0 }
+func ExprUseXList(E *Expr) bool   { return (E.flags & EP_xIsSelect) == 0 }
+func ExprUseXSelect(E *Expr) bool { return (E.flags & EP_xIsSelect) != 0 }
+func ExprUseYTab(E *Expr) bool    { return (E.flags & (EP_WinFunc | EP_Subrtn)) == 0 }
+func ExprUseYWin(E *Expr) bool    { return (E.flags & EP_WinFunc) != 0 }
+func ExprUseYSub(E *Expr) bool    { return (E.flags & EP_Subrtn) != 0 }
+
+/*
+** Flags passed to the sqlite3ExprDup() function. See the header comment
+** above sqlite3ExprDup() for details.
+ */
+const EXPRDUP_REDUCE = 0x0001 /* Used reduced-size Expr nodes */
+
 /*
 ** A list of expressions.  Each expression may optionally have a
 ** name.  An expr/name combination can be used in several ways, such
@@ -370,29 +608,58 @@ type Expr struct {
 **                     expression.
  */
 type ExprList struct {
-	nExpr  int        /* Number of expressions on the list */
-	nAlloc int        /* Number of a[] slots allocated */
-	a      []struct { /* For each expression in the list */
-		pExpr     *Expr  /* The parse tree for this expression */
-		zEName    []byte /* Token associated with this expression */
-		sortFlags uint8  /* Mask of KEYINFO_ORDER_* flags */
-		// unsigned eEName :2;     /* Meaning of zEName */
-		// unsigned done :1;       /* A flag to indicate when processing is finished */
-		// unsigned reusable :1;   /* Constant expression is reusable */
-		// unsigned bSorterRef :1; /* Defer evaluation until after sorting */
-		// unsigned bNulls: 1;     /* True if explicit "NULLS FIRST/LAST" */
-		// unsigned bUsed: 1;      /* This column used in a SF_NestedFrom subquery */
-		u struct {
-			x struct { /* Used by any ExprList other than Parse.pConsExpr */
-				iOrderByCol uint16 /* For ORDER BY, column number in result set */
-				iAlias      uint16 /* Index into Parse.aAlias[] for zName */
-			}
-			iConstExprReg int /* Register in which Expr value is cached. Used only
-			 ** by Parse.pConstExpr */
+	nExpr  int             /* Number of expressions on the list */
+	nAlloc int             /* Number of a[] slots allocated */
+	a      []ExprList_item /* One slot for each expression in the list */
+}
+
+/* For each expression in the list */
+type ExprList_item struct {
+	pExpr     *Expr  /* The parse tree for this expression */
+	zEName    []byte /* Token associated with this expression */
+	sortFlags uint8  /* Mask of KEYINFO_ORDER_* flags */
+	eEName    uint8  /* Meaning of zEName */
+	// unsigned done :1;       /* A flag to indicate when processing is finished */
+	// unsigned reusable :1;   /* Constant expression is reusable */
+	// unsigned bSorterRef :1; /* Defer evaluation until after sorting */
+	bNulls uint8 /* True if explicit "NULLS FIRST/LAST" */
+	// unsigned bUsed: 1;      /* This column used in a SF_NestedFrom subquery */
+	u struct {
+		x struct { /* Used by any ExprList other than Parse.pConsExpr */
+			iOrderByCol uint16 /* For ORDER BY, column number in result set */
+			iAlias      uint16 /* Index into Parse.aAlias[] for zName */
 		}
-	} /* One slot for each expression in the list */
+		iConstExprReg int /* Register in which Expr value is cached. Used only
+		 ** by Parse.pConstExpr */
+	}
 }
 
+/*
+** Allowed values for Expr.a.eEName
+ */
+const (
+	ENAME_NAME = 0 /* The AS clause of a result set */
+	ENAME_SPAN = 1 /* Complete text of the result set expression */
+	ENAME_TAB  = 2 /* "DB.TABLE.NAME" for the result set */
+)
+
+/*
+** Allowed bit values for ExprList.a.sortFlags.
+ */
+const (
+	KEYINFO_ORDER_DESC    = 0x01 /* DESC sort order */
+	KEYINFO_ORDER_BIGNULL = 0x02 /* NULL is larger than any other value */
+)
+
+/*
+** A sort order can be either ASC or DESC.
+ */
+const (
+	SQLITE_SO_ASC       = 0  /* Sort in ascending order */
+	SQLITE_SO_DESC      = 1  /* Sort in descending order */
+	SQLITE_SO_UNDEFINED = -1 /* No sort order specified */
+)
+
 /*
 ** Each foreign key constraint is an instance of the following structure.
 **
@@ -591,14 +858,14 @@ type IdList struct {
  */
 type Index struct {
 	zName         []byte    /* Name of this index */
-	aiColumn      *int16    /* Which columns are used by this index.  1st is 0 */
+	aiColumn      []int16   /* Which columns are used by this index.  1st is 0 */
 	aiRowLogEst   *LogEst   /* From ANALYZE: Est. rows selected by each column */
 	pTable        *Table    /* The SQL table being indexed */
 	zColAff       []byte    /* String defining the affinity of each column */
 	pNext         *Index    /* The next index associated with the same table */
 	pSchema       *Schema   /* Schema containing this index */
-	aSortOrder    *uint8    /* for each column: True==DESC, False==ASC */
-	azColl        []byte    /* Array of collation sequence names for index */
+	aSortOrder    []uint8   /* for each column: True==DESC, False==ASC */
+	azColl        [][]byte  /* Array of collation sequence names for index */
 	pPartIdxWhere *Expr     /* WHERE clause for partial indices */
 	aColExpr      *ExprList /* Column expressions */
 	tnum          Pgno      /* DB Page containing root of this index */
@@ -606,9 +873,9 @@ type Index struct {
 	nKeyCol       uint16    /* Number of columns forming the key */
 	nColumn       uint16    /* Number of columns stored in the index */
 	onError       uint8     /* OE_Abort, OE_Ignore, OE_Replace, or OE_None */
-	// unsigned idxType:2;      /* 0:Normal 1:UNIQUE, 2:PRIMARY KEY, 3:IPK */
+	idxType       uint8     /* 0:Normal 1:UNIQUE, 2:PRIMARY KEY, 3:IPK */
+	uniqNotNull   uint8     /* True if UNIQUE and NOT NULL for all columns */
 	// unsigned bUnordered:1;   /* Use this index for == or IN queries only */
-	// unsigned uniqNotNull:1;  /* True if UNIQUE and NOT NULL for all columns */
 	// unsigned isResized:1;    /* True if resizeIndexObject() has been called */
 	// unsigned isCovering:1;   /* True if this is a covering index */
 	// unsigned noSkipScan:1;   /* Do not try to use skip-scan if true */
@@ -635,6 +902,20 @@ const (
 	SQLITE_IDXTYPE_IPK        = 3 /* INTEGER PRIMARY KEY index */
 )
 
+/* Return true if index X is a PRIMARY KEY index */
+func IsPrimaryKeyIndex(X *Index) bool { return X.idxType == SQLITE_IDXTYPE_PRIMARYKEY }
+
+/* Return true if index X is a UNIQUE index */
+func IsUniqueIndex(X *Index) bool { return X.onError != OE_None }
+
+/* The Index.aiColumn[] values are normally positive integer.  But
+** there are some negative values that have special meaning:
+ */
+const (
+	XN_ROWID = -1 /* Indexed column is the rowid */
+	XN_EXPR  = -2 /* Indexed column is an expression */
+)
+
 /*
 ** Each sample stored in the sqlite_stat4 table is represented in memory
 ** using a structure of this type.  See documentation at the top of the
@@ -721,10 +1002,10 @@ type Parse struct {
 	//   Table *pTriggerTab;  /* Table triggers are being coded for */
 	//   TriggerPrg *pTriggerPrg;  /* Linked list of coded triggers */
 	//   ParseCleanup *pCleanup;   /* List of cleanup operations to run after parse */
-	//   union {
-	//     int addrCrTab;         /* Address of OP_CreateBtree on CREATE TABLE */
-	//     Returning *pReturning; /* The RETURNING clause */
-	//   } u1;
+	u1 struct {
+		addrCrTab  int        /* Address of OP_CreateBtree on CREATE TABLE */
+		pReturning *Returning /* The RETURNING clause */
+	}
 	nQueryLoop      uint32 /* Est number of iterations of a query (10*log2(N)) */
 	oldmask         uint32 /* Mask of old.* columns referenced */
 	newmask         uint32 /* Mask of new.* columns referenced */
@@ -763,11 +1044,27 @@ type Parse struct {
 	// // #ifndef SQLITE_OMIT_EXPLAIN
 	addrExplain int /* Address of current OP_Explain opcode */
 	// // j#endif
-	//   VList *pVList;            /* Mapping between variable names and numbers */
+	pVList *VList /* Mapping between variable names and numbers */
 	//   Vdbe *pReprepare;         /* VM being reprepared (sqlite3Reprepare()) */
-	zTail     []byte /* All SQL text past the last semicolon parsed */
-	pNewTable *Table /* A table being constructed by CREATE TABLE */
-	pNewIndex *Index /* An index being constructed by CREATE INDEX.
+	zTail     []byte         /* All SQL text past the last semicolon parsed */
+	zStmt     []byte         /* Start of the statement currently being parsed */
+	zPrevEnd  []byte         /* Text after the token before sLastToken */
+	pStmt     *Stmt          /* The statement built by sqlite3FinishCoding() */
+	pSelect   *Select        /* Parse tree of a SELECT statement */
+	pDelete   *Delete        /* Parse tree of a DELETE statement */
+	pUpdate   *Update        /* Parse tree of an UPDATE statement */
+	pInsert   *Insert        /* Parse tree of an INSERT statement */
+	pTable    *Table         /* Table or view built by a CREATE statement */
+	pIndex    *Index         /* Index built by a CREATE INDEX statement */
+	pTrigger  *Trigger       /* Trigger built by a CREATE TRIGGER statement */
+	pDrop     *Drop          /* Object named by a DROP statement */
+	pAlter    *Alter         /* Parse tree of an ALTER TABLE statement */
+	pPragma   *Pragma        /* Parse tree of a PRAGMA statement */
+	pAttach   *Attach        /* Parse tree of an ATTACH or DETACH */
+	aVersion  []VersionIssue /* Constructs newer than db->iTargetVersion */
+	aFallback []int          /* Offsets of keywords parsed as identifiers */
+	pNewTable *Table         /* A table being constructed by CREATE TABLE */
+	pNewIndex *Index         /* An index being constructed by CREATE INDEX.
 	//                             ** Also used to hold redundant UNIQUE constraints
 	//                             ** during a RENAME COLUMN */
 	pNewTrigger  *Trigger /* Trigger under construct by a CREATE TRIGGER */
@@ -776,12 +1073,21 @@ type Parse struct {
 	sArg Token /* Complete text of a module argument */
 	//   Table **apVtabLock;       /* Pointer to virtual tables needing locking */
 	// #endif
-	//   With *pWith;              /* Current WITH clause, or NULL */
+	pWith *With /* Current WITH clause, or NULL */
 	// #ifndef SQLITE_OMIT_ALTERTABLE
-	//   RenameToken *pRename;     /* Tokens subject to renaming by ALTER TABLE */
+	pRename *RenameToken /* Tokens subject to renaming by ALTER TABLE */
 	// #endif
 }
 
+/* Allowed values for Parse.eParseMode
+ */
+const (
+	PARSE_MODE_NORMAL       = 0
+	PARSE_MODE_DECLARE_VTAB = 1
+	PARSE_MODE_RENAME       = 2
+	PARSE_MODE_UNMAP        = 3
+)
+
 /*
 ** All current savepoints are stored in a linked list starting at
 ** sqlite3.pSavepoint. The first element in the list is the most recently
@@ -1051,15 +1357,15 @@ type SrcItem struct {
 	regReturn   int     /* Register holding return address of addrFillSub */
 	regResult   int     /* Registers holding results of a co-routine */
 	fg          struct {
-		jointype uint8 /* Type of join between this table and the previous */
-		// unsigned notIndexed :1;    /* True if there is a NOT INDEXED clause */
+		jointype   uint8 /* Type of join between this table and the previous */
+		notIndexed uint8 /* True if there is a NOT INDEXED clause */
 		// unsigned isIndexedBy :1;   /* True if there is an INDEXED BY clause */
 		// unsigned isTabFunc :1;     /* True if table-valued-function syntax */
 		// unsigned isCorrelated :1;  /* True if sub-query is correlated */
 		// unsigned viaCoroutine :1;  /* Implemented as a co-routine */
-		// unsigned isRecursive :1;   /* True for recursive reference in WITH */
+		isRecursive uint8 /* True for recursive reference in WITH */
 		// unsigned fromDDL :1;       /* Comes from sqlite_schema */
-		// unsigned isCte :1;         /* This is a CTE */
+		isCte uint8 /* This is a CTE */
 		// unsigned notCte :1;        /* This item may not match a CTE */
 		// unsigned isUsing :1;       /* u3.pUsing is valid */
 		// unsigned isSynthUsing :1;  /* u3.pUsing is synthensized from NATURAL */
@@ -1104,15 +1410,68 @@ type SrcList struct {
 	nSrc   int       /* Number of tables or subqueries in the FROM clause */
 	nAlloc uint32    /* Number of entries allocated in a[] below */
 	a      []SrcItem /* One entry for each identifier on the list */
+	sFrom  Token     /* The FROM keyword, if this list is a FROM clause */
 }
 
+/*
+** A NameContext defines a context in which to resolve table and column
+** names.  The pSrcList corresponds to the FROM clause of a SELECT or
+** to the table being operated on by INSERT, UPDATE, or DELETE.
+**
+** The Go port has no schema, so a NameContext only records which names
+** the FROM clause makes visible.  Those hide the NEW and OLD pseudo-tables
+** of a trigger program and the EXCLUDED pseudo-table of an upsert.
+** The exception is the table of an index, whose columns are checked when
+** they are known.  See sqlite3ResolveSelfReference().
+ */
+type NameContext struct {
+	pParse   *Parse   /* The parser */
+	pSrcList *SrcList /* One or more tables used to resolve names */
+	uNC      struct {
+		pUpsert *Upsert /* ON CONFLICT clause information from an upsert */
+	}
+	pNext   *NameContext /* Next outer name context.  NULL for outermost */
+	ncFlags int          /* Zero or more NC_* flags defined below */
+}
+
+/*
+** Allowed values for the NameContext, ncFlags field.
+ */
+const (
+	NC_PartIdx = 0x000002 /* True if resolving a partial index WHERE */
+	NC_IsCheck = 0x000004 /* True if resolving a CHECK constraint */
+	NC_GenCol  = 0x000008 /* True for a GENERATED ALWAYS AS clause */
+	NC_IdxExpr = 0x000020 /* True if resolving columns of CREATE INDEX */
+	NC_SelfRef = 0x00002e /* Combo: PartIdx, isCheck, GenCol, and IdxExpr */
+	NC_UUpsert = 0x000200 /* True if uNC.pUpsert is used */
+)
+
+/*
+** Maximum number of terms in a FROM clause.
+ */
+const SQLITE_MAX_SRCLIST = 200
+
+/*
+** Permitted values of the SrcList.a.jointype field
+ */
+const (
+	JT_INNER   = 0x01 /* Any kind of inner or cross join */
+	JT_CROSS   = 0x02 /* Explicit use of the CROSS keyword */
+	JT_NATURAL = 0x04 /* True for a "natural" join */
+	JT_LEFT    = 0x08 /* Left outer join */
+	JT_RIGHT   = 0x10 /* Right outer join */
+	JT_OUTER   = 0x20 /* The "OUTER" keyword is present */
+	JT_LTORJ   = 0x40 /* One of the LEFT operands of a RIGHT JOIN */
+	JT_ERROR   = 0x80 /* unknown or unsupported join type */
+)
+
 /*
 ** The schema for each SQL table, virtual table, and view is represented
 ** in memory by an instance of the following structure.
  */
 type Table struct {
 	zName   []byte    /* Name of the table or view */
-	aCol    *Column   /* Information about each column */
+	aCol    []Column  /* Information about each column */
 	pIndex  *Index    /* List of SQL indexes on this table. */
 	zColAff []byte    /* String defining the affinity of each column */
 	pCheck  *ExprList /* All CHECK constraints */
@@ -1191,8 +1550,21 @@ type Trigger struct {
 	pTabSchema *Schema      /* Schema containing the table */
 	step_list  *TriggerStep /* Link list of trigger program steps             */
 	pNext      *Trigger     /* Next trigger associated with the table */
+	bInstead   uint8        /* INSTEAD OF trigger.  tr_tm is TRIGGER_BEFORE */
 }
 
+/*
+** A trigger is either a BEFORE or an AFTER trigger.  The following constants
+** determine which.
+**
+** If there are multiple triggers, you might of some BEFORE and some AFTER.
+** In that cases, the constants below can be ORed together.
+ */
+const (
+	TRIGGER_BEFORE = 1
+	TRIGGER_AFTER  = 2
+)
+
 /*
 ** An instance of struct TriggerStep is used to store a single SQL statement
 ** that is a part of a trigger-program.
@@ -1253,6 +1625,18 @@ type TriggerStep struct {
 	pLast     *TriggerStep /* Last element in link-list. Valid for 1st elem only */
 }
 
+/*
+** Information about a RETURNING clause
+**
+** The C version implements RETURNING as a transient AFTER trigger and
+** keeps the trigger, its step and the registers used to code it here.
+** The Go port only records the parse tree.
+ */
+type Returning struct {
+	pParse    *Parse    /* The parse that includes the RETURNING clause */
+	pReturnEL *ExprList /* List of expressions to return */
+}
+
 /*
 ** An instance of the following object describes a single ON CONFLICT
 ** clause in an upsert.
@@ -1377,7 +1761,7 @@ type Window struct {
 	eExclude       uint8     /* TK_NO, TK_CURRENT, TK_TIES, TK_GROUP, or 0 */
 	pStart         *Expr     /* Expression for "<expr> PRECEDING" */
 	pEnd           *Expr     /* Expression for "<expr> FOLLOWING" */
-	ppThis         *Window   /* Pointer to this object in Select.pWin list */
+	ppThis         **Window  /* Pointer to this object in Select.pWin list */
 	pNextWin       *Window   /* Next window function belonging to this SELECT */
 	pFilter        *Expr     /* The FILTER expression */
 	pWFunc         *FuncDef  /* The function */
@@ -1397,6 +1781,40 @@ type Window struct {
 	 ** due to the SQLITE_SUBTYPE flag */
 }
 
+/*
+** Context pointer passed down through the tree-walk.
+ */
+type Walker struct {
+	pParse           *Parse                     /* Parser context.  */
+	xExprCallback    func(*Walker, *Expr) int   /* Callback for expressions */
+	xSelectCallback  func(*Walker, *Select) int /* Callback for SELECTs */
+	xSelectCallback2 func(*Walker, *Select)     /* Second callback for SELECTs */
+	walkerDepth      int                        /* Number of subqueries */
+	eCode            uint16                     /* A small processing code */
+	bWinDefn         bool                       /* Also walk Select.pWinDefn */
+	u                struct {                   /* Extra data for callback */
+		n        int           /* A counter */
+		iCur     int           /* A cursor number */
+		pSrcList *SrcList      /* FROM clause */
+		pGroupBy *ExprList     /* GROUP BY clause */
+		pSelect  *Select       /* HAVING to WHERE clause ctx */
+		pTab     *Table        /* Table of generated column */
+		pNC      *NameContext  /* Naming context */
+		pCte     *Cte          /* CTE checked by cteRecursiveRefStep() */
+		pCheck   *catalogCheck /* State of Catalog.checkColumns() */
+	}
+}
+
+/*
+** Return code from the parse-tree walking primitives and their
+** callbacks.
+ */
+const (
+	WRC_Continue = 0 /* Continue down into children */
+	WRC_Prune    = 1 /* Omit children but continue walking siblings */
+	WRC_Abort    = 2 /* Abandon the tree walk */
+)
+
 /*
 ** An instance of the With object represents a WITH clause containing
 ** one or more CTEs (common table expressions).
@@ -1424,11 +1842,14 @@ type sqlite3 struct {
 	//   i64 szMmap;                   /* Default mmap_size setting */
 	//   u32 nSchemaLock;              /* Do not reset the schema when non-zero */
 	//   unsigned int openFlags;       /* Flags passed to sqlite3_vfs.xOpen() */
-	//   int errCode;                  /* Most recent error code (SQLITE_*) */
-	//   int errByteOffset;            /* Byte offset of error in SQL statement */
+	errCode       int /* Most recent error code (SQLITE_*) */
+	errByteOffset int /* Byte offset of error in SQL statement */
 	//   int errMask;                  /* & result codes with this before returning */
 	//   int iSysErrno;                /* Errno value from last system error */
 	//   u32 dbOptFlags;               /* Flags to enable/disable optimizations */
+	omitFlags          uint32 /* Language features disabled at run-time. See Feature */
+	bUpdateDeleteLimit uint8  /* SQLITE_ENABLE_UPDATE_DELETE_LIMIT is in effect */
+	iTargetVersion     int    /* Oldest SQLite release to accept SQL from, or 0 */
 	//   u8 enc;                       /* Text encoding */
 	//   u8 autoCommit;                /* The auto-commit flag. */
 	//   u8 temp_store;                /* 1: file 2: memory 0: default */
@@ -1446,7 +1867,7 @@ type sqlite3 struct {
 	//   int nextPagesize;             /* Pagesize after VACUUM if >0 */
 	//   i64 nChange;                  /* Value returned by sqlite3_changes() */
 	//   i64 nTotalChange;             /* Value returned by sqlite3_total_changes() */
-	//   int aLimit[SQLITE_N_LIMIT];   /* Limits */
+	aLimit [SQLITE_N_LIMIT]int /* Limits */
 	//   int nMaxSorterMmap;           /* Maximum size of regions mapped by sorter */
 	init struct { /* Information used during initialization */
 		newTnum Pgno  /* Rootpage of table being initialized */
@@ -1482,7 +1903,7 @@ type sqlite3 struct {
 	//   void *pAutovacPagesArg;           /* Client argument to autovac_pages */
 	//   void (*xAutovacDestr)(void*);     /* Destructor for pAutovacPAgesArg */
 	//   unsigned int (*xAutovacPages)(void*,const char*,u32,u32,u32);
-	//   Parse *pParse;                /* Current parse */
+	pParse *Parse /* Current parse */
 	// #ifdef SQLITE_ENABLE_PREUPDATE_HOOK
 	//   void *pPreUpdateArg;          /* First argument to xPreUpdateCallback */
 	//   void (*xPreUpdateCallback)(   /* Registered using sqlite3_preupdate_hook() */
@@ -1497,7 +1918,7 @@ type sqlite3 struct {
 	//   void(*xCollNeeded)(void*,sqlite3*,int eTextRep,const char*);
 	//   void(*xCollNeeded16)(void*,sqlite3*,int eTextRep,const void*);
 	//   void *pCollNeededArg;
-	//   sqlite3_value *pErr;          /* Most recent error message */
+	zErrMsg []byte /* Most recent error message */
 	//   union {
 	//     volatile int isInterrupted; /* True if sqlite3_interrupt has been called */
 	//     double notUsed1;            /* Spacer */
@@ -1552,3 +1973,9 @@ type sqlite3 struct {
 	//   sqlite3_userauth auth;        /* User authentication information */
 	// #endif
 }
+
+/*
+** The following macro is used within testcase() and other places
+** to silence compiler warnings about unused parameters.
+ */
+func UNUSED_PARAMETER(x interface{}) {}
@@ -17,6 +17,8 @@
  */
 package internal
 
+import "sync"
+
 /* Character classes for tokenizing
 **
 ** In the sqlite3GetToken() function, a switch() on aiClass[c] is implemented
@@ -183,89 +185,693 @@ var sqlite3IsEbcdicIdChar = []rune{
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 0, 1, 1, 1, 1, 0, /* Fx */
 }
 
-//TODO: #define IdChar(C)  (((c=C)>=0x42 && sqlite3IsEbcdicIdChar[c-0x40]))
-//TODO: Placeholder function to compile
-func IdChar(c uint8) int {
-	return 0
+// TODO: #define IdChar(C)  (((c=C)>=0x42 && sqlite3IsEbcdicIdChar[c-0x40]))
+func IdChar(C uint8) bool {
+	return (sqlite3CtypeMap[C] & 0x46) != 0
 }
 
 /* Make the IdChar function accessible from ctime.c and alter.c */
-func sqlite3IsIdChar(c uint8) int { return IdChar(c) }
+func sqlite3IsIdChar(c uint8) int {
+	if IdChar(c) {
+		return 1
+	}
+	return 0
+}
 
-// TODO:
 // #ifndef SQLITE_OMIT_WINDOWFUNC
-// /*
-// ** Return the id of the next token in string (*pz). Before returning, set
-// ** (*pz) to point to the byte following the parsed token.
-// */
-// static int getToken(const unsigned char **pz){
-//   const unsigned char *z = *pz;
-//   int t;                          /* Token type to return */
-//   do {
-//     z += sqlite3GetToken(z, &t);
-//   }while( t==TK_SPACE );
-//   if( t==TK_ID
-//    || t==TK_STRING
-//    || t==TK_JOIN_KW
-//    || t==TK_WINDOW
-//    || t==TK_OVER
-//    || sqlite3ParserFallback(t)==TK_ID
-//   ){
-//     t = TK_ID;
-//   }
-//   *pz = z;
-//   return t;
-// }
-//
-// /*
-// ** The following three functions are called immediately after the tokenizer
-// ** reads the keywords WINDOW, OVER and FILTER, respectively, to determine
-// ** whether the token should be treated as a keyword or an SQL identifier.
-// ** This cannot be handled by the usual lemon %fallback method, due to
-// ** the ambiguity in some constructions. e.g.
-// **
-// **   SELECT sum(x) OVER ...
-// **
-// ** In the above, "OVER" might be a keyword, or it might be an alias for the
-// ** sum(x) expression. If a "%fallback ID OVER" directive were added to
-// ** grammar, then SQLite would always treat "OVER" as an alias, making it
-// ** impossible to call a window-function without a FILTER clause.
-// **
-// ** WINDOW is treated as a keyword if:
-// **
-// **   * the following token is an identifier, or a keyword that can fallback
-// **     to being an identifier, and
-// **   * the token after than one is TK_AS.
-// **
-// ** OVER is a keyword if:
-// **
-// **   * the previous token was TK_RP, and
-// **   * the next token is either TK_LP or an identifier.
-// **
-// ** FILTER is a keyword if:
-// **
-// **   * the previous token was TK_RP, and
-// **   * the next token is TK_LP.
-// */
-// static int analyzeWindowKeyword(const unsigned char *z){
-//   int t;
-//   t = getToken(&z);
-//   if( t!=TK_ID ) return TK_ID;
-//   t = getToken(&z);
-//   if( t!=TK_AS ) return TK_ID;
-//   return TK_WINDOW;
-// }
-// static int analyzeOverKeyword(const unsigned char *z, int lastToken){
-//   if( lastToken==TK_RP ){
-//     int t = getToken(&z);
-//     if( t==TK_LP || t==TK_ID ) return TK_OVER;
-//   }
-//   return TK_ID;
-// }
-// static int analyzeFilterKeyword(const unsigned char *z, int lastToken){
-//   if( lastToken==TK_RP && getToken(&z)==TK_LP ){
-//     return TK_FILTER;
-//   }
-//   return TK_ID;
-// }
+/*
+** Return the id of the next token in string (*pz). Before returning, set
+** (*pz) to point to the byte following the parsed token.
+ */
+func getToken(pz *[]byte) int {
+	z := *pz
+	var t int /* Token type to return */
+	for {
+		z = z[sqlite3GetToken(z, &t):]
+		if t != TK_SPACE {
+			break
+		}
+	}
+	if t == TK_ID ||
+		t == TK_STRING ||
+		t == TK_JOIN_KW ||
+		t == TK_WINDOW ||
+		t == TK_OVER ||
+		sqlite3ParserFallback(t) == TK_ID {
+		t = TK_ID
+	}
+	*pz = z
+	return t
+}
+
+/*
+** The following three functions are called immediately after the tokenizer
+** reads the keywords WINDOW, OVER and FILTER, respectively, to determine
+** whether the token should be treated as a keyword or an SQL identifier.
+** This cannot be handled by the usual lemon %fallback method, due to
+** the ambiguity in some constructions. e.g.
+**
+**   SELECT sum(x) OVER ...
+**
+** In the above, "OVER" might be a keyword, or it might be an alias for the
+** sum(x) expression. If a "%fallback ID OVER" directive were added to
+** grammar, then SQLite would always treat "OVER" as an alias, making it
+** impossible to call a window-function without a FILTER clause.
+**
+** WINDOW is treated as a keyword if:
+**
+**   * the following token is an identifier, or a keyword that can fallback
+**     to being an identifier, and
+**   * the token after than one is TK_AS.
+**
+** OVER is a keyword if:
+**
+**   * the previous token was TK_RP, and
+**   * the next token is either TK_LP or an identifier.
+**
+** FILTER is a keyword if:
+**
+**   * the previous token was TK_RP, and
+**   * the next token is TK_LP.
+ */
+func analyzeWindowKeyword(z []byte) int {
+	var t int
+	t = getToken(&z)
+	if t != TK_ID {
+		return TK_ID
+	}
+	t = getToken(&z)
+	if t != TK_AS {
+		return TK_ID
+	}
+	return TK_WINDOW
+}
+func analyzeOverKeyword(z []byte, lastToken int) int {
+	if lastToken == TK_RP {
+		t := getToken(&z)
+		if t == TK_LP || t == TK_ID {
+			return TK_OVER
+		}
+	}
+	return TK_ID
+}
+func analyzeFilterKeyword(z []byte, lastToken int) int {
+	if lastToken == TK_RP && getToken(&z) == TK_LP {
+		return TK_FILTER
+	}
+	return TK_ID
+}
+
 // #endif /* SQLITE_OMIT_WINDOWFUNC */
+
+/*
+** Return the length (in bytes) of the token that begins at z[0].
+** Store the token type in *tokenType before returning.
+**
+** The C version relies on z[] being zero-terminated.  Here the end of
+** the slice plays that role: charAt() returns 0 for any offset past the
+** end of z.
+ */
+func sqlite3GetToken(z []byte, tokenType *int) int {
+	var i int
+	var c byte
+	switch aiClass[charAt(z, 0)] { /* Switch on the character-class of the first byte
+	 ** of the token. See the comment on the CC_ defines
+	 ** above. */
+	case CC_SPACE:
+		for i = 1; sqlite3Isspace(charAt(z, i)); i++ {
+		}
+		*tokenType = TK_SPACE
+		return i
+	case CC_MINUS:
+		if charAt(z, 1) == '-' {
+			for i = 2; ; i++ {
+				c = charAt(z, i)
+				if c == 0 || c == '\n' {
+					break
+				}
+			}
+			*tokenType = TK_SPACE /* IMP: R-22934-25134 */
+			return i
+		} else if charAt(z, 1) == '>' {
+			*tokenType = TK_PTR
+			if charAt(z, 2) == '>' {
+				return 3
+			}
+			return 2
+		}
+		*tokenType = TK_MINUS
+		return 1
+	case CC_LP:
+		*tokenType = TK_LP
+		return 1
+	case CC_RP:
+		*tokenType = TK_RP
+		return 1
+	case CC_SEMI:
+		*tokenType = TK_SEMI
+		return 1
+	case CC_PLUS:
+		*tokenType = TK_PLUS
+		return 1
+	case CC_STAR:
+		*tokenType = TK_STAR
+		return 1
+	case CC_SLASH:
+		if charAt(z, 1) != '*' || charAt(z, 2) == 0 {
+			*tokenType = TK_SLASH
+			return 1
+		}
+		for i, c = 3, charAt(z, 2); c != '*' || charAt(z, i) != '/'; i++ {
+			c = charAt(z, i)
+			if c == 0 {
+				break
+			}
+		}
+		if c != 0 {
+			i++
+		}
+		*tokenType = TK_SPACE /* IMP: R-22934-25134 */
+		return i
+	case CC_PERCENT:
+		*tokenType = TK_REM
+		return 1
+	case CC_EQ:
+		*tokenType = TK_EQ
+		if charAt(z, 1) == '=' {
+			return 2
+		}
+		return 1
+	case CC_LT:
+		if c = charAt(z, 1); c == '=' {
+			*tokenType = TK_LE
+			return 2
+		} else if c == '>' {
+			*tokenType = TK_NE
+			return 2
+		} else if c == '<' {
+			*tokenType = TK_LSHIFT
+			return 2
+		} else {
+			*tokenType = TK_LT
+			return 1
+		}
+	case CC_GT:
+		if c = charAt(z, 1); c == '=' {
+			*tokenType = TK_GE
+			return 2
+		} else if c == '>' {
+			*tokenType = TK_RSHIFT
+			return 2
+		} else {
+			*tokenType = TK_GT
+			return 1
+		}
+	case CC_BANG:
+		if charAt(z, 1) != '=' {
+			*tokenType = TK_ILLEGAL
+			return 1
+		} else {
+			*tokenType = TK_NE
+			return 2
+		}
+	case CC_PIPE:
+		if charAt(z, 1) != '|' {
+			*tokenType = TK_BITOR
+			return 1
+		} else {
+			*tokenType = TK_CONCAT
+			return 2
+		}
+	case CC_COMMA:
+		*tokenType = TK_COMMA
+		return 1
+	case CC_AND:
+		*tokenType = TK_BITAND
+		return 1
+	case CC_TILDA:
+		*tokenType = TK_BITNOT
+		return 1
+	case CC_QUOTE:
+		delim := z[0]
+		for i = 1; ; i++ {
+			c = charAt(z, i)
+			if c == 0 {
+				break
+			}
+			if c == delim {
+				if charAt(z, i+1) == delim {
+					i++
+				} else {
+					break
+				}
+			}
+		}
+		if c == '\'' {
+			*tokenType = TK_STRING
+			return i + 1
+		} else if c != 0 {
+			*tokenType = TK_ID
+			return i + 1
+		} else {
+			*tokenType = TK_ILLEGAL
+			return i
+		}
+	case CC_DOT, CC_DIGIT:
+		if z[0] == '.' && !sqlite3Isdigit(charAt(z, 1)) {
+			*tokenType = TK_DOT
+			return 1
+		}
+		/* If the next character is a digit, this is a floating point
+		 ** number that begins with ".".  Fall thru into the next case */
+		*tokenType = TK_INTEGER
+		if z[0] == '0' && (charAt(z, 1) == 'x' || charAt(z, 1) == 'X') && sqlite3Isxdigit(charAt(z, 2)) {
+			for i = 3; sqlite3Isxdigit(charAt(z, i)); i++ {
+			}
+			return i
+		}
+		for i = 0; sqlite3Isdigit(charAt(z, i)); i++ {
+		}
+		if charAt(z, i) == '.' {
+			i++
+			for sqlite3Isdigit(charAt(z, i)) {
+				i++
+			}
+			*tokenType = TK_FLOAT
+		}
+		if (charAt(z, i) == 'e' || charAt(z, i) == 'E') &&
+			(sqlite3Isdigit(charAt(z, i+1)) ||
+				((charAt(z, i+1) == '+' || charAt(z, i+1) == '-') && sqlite3Isdigit(charAt(z, i+2)))) {
+			i += 2
+			for sqlite3Isdigit(charAt(z, i)) {
+				i++
+			}
+			*tokenType = TK_FLOAT
+		}
+		for IdChar(charAt(z, i)) {
+			*tokenType = TK_ILLEGAL
+			i++
+		}
+		return i
+	case CC_QUOTE2:
+		for i, c = 1, z[0]; c != ']'; i++ {
+			c = charAt(z, i)
+			if c == 0 {
+				break
+			}
+		}
+		if c == ']' {
+			*tokenType = TK_ID
+		} else {
+			*tokenType = TK_ILLEGAL
+		}
+		return i
+	case CC_VARNUM:
+		*tokenType = TK_VARIABLE
+		for i = 1; sqlite3Isdigit(charAt(z, i)); i++ {
+		}
+		return i
+	case CC_DOLLAR, CC_VARALPHA:
+		n := 0
+		*tokenType = TK_VARIABLE
+		for i = 1; ; i++ {
+			c = charAt(z, i)
+			if c == 0 {
+				break
+			}
+			if IdChar(c) {
+				n++
+			} else if c == '(' && n > 0 {
+				for {
+					i++
+					c = charAt(z, i)
+					if c == 0 || sqlite3Isspace(c) || c == ')' {
+						break
+					}
+				}
+				if c == ')' {
+					i++
+				} else {
+					*tokenType = TK_ILLEGAL
+				}
+				break
+			} else if c == ':' && charAt(z, i+1) == ':' {
+				i++
+			} else {
+				break
+			}
+		}
+		if n == 0 {
+			*tokenType = TK_ILLEGAL
+		}
+		return i
+	case CC_KYWD0:
+		for i = 1; aiClass[charAt(z, i)] <= CC_KYWD; i++ {
+		}
+		if IdChar(charAt(z, i)) {
+			/* This token started out using characters that can appear in keywords,
+			 ** but z[i] is a character not allowed within keywords, so this must
+			 ** be an identifier instead */
+			i++
+			break
+		}
+		*tokenType = TK_ID
+		return keywordCode(z, i, tokenType)
+	case CC_X:
+		if charAt(z, 1) == '\'' {
+			*tokenType = TK_BLOB
+			for i = 2; sqlite3Isxdigit(charAt(z, i)); i++ {
+			}
+			if charAt(z, i) != '\'' || i%2 != 0 {
+				*tokenType = TK_ILLEGAL
+				for charAt(z, i) != 0 && charAt(z, i) != '\'' {
+					i++
+				}
+			}
+			if charAt(z, i) != 0 {
+				i++
+			}
+			return i
+		}
+		/* If it is not a BLOB literal, then it must be an ID, since no
+		 ** SQL keywords start with the letter 'x'.  Fall through */
+		i = 1
+	case CC_KYWD, CC_ID:
+		i = 1
+	case CC_BOM:
+		if charAt(z, 1) == 0xbb && charAt(z, 2) == 0xbf {
+			*tokenType = TK_SPACE
+			return 3
+		}
+		i = 1
+	case CC_NUL:
+		*tokenType = TK_ILLEGAL
+		return 0
+	default:
+		*tokenType = TK_ILLEGAL
+		return 1
+	}
+	for IdChar(charAt(z, i)) {
+		i++
+	}
+	*tokenType = TK_ID
+	return i
+}
+
+/*
+** SQLite can keep the parser object on the C stack of sqlite3RunParser()
+** when it is compiled with sqlite3Parser_ENGINEALWAYSONSTACK.  The Go
+** equivalent is a pool of parser objects.  Each one is initialized with
+** sqlite3ParserInit() before use and returned to the pool after
+** sqlite3ParserFinalize(), so the parser stack is allocated only once.
+ */
+var sqlite3ParserPool = sync.Pool{
+	New: func() interface{} { return &yyParser{} },
+}
+
+/*
+** Run the parser on the given SQL string.
+**
+** Exactly one statement is parsed per call.  When the statement is
+** complete, sqlite3FinishCoding() sets pParse->rc to SQLITE_DONE which
+** stops the loop below, and pParse->zTail is left pointing at the
+** remainder of the input.
+ */
+func sqlite3RunParser(pParse *Parse, zSql []byte) int {
+	nErr := 0               /* Number of errors encountered */
+	var pEngine *yyParser   /* The LEMON-generated LALR(1) parser */
+	n := 0                  /* Length of the next token token */
+	var tokenType int       /* type of the next token */
+	lastTokenParsed := -1   /* type of the previous token */
+	db := pParse.db         /* The database connection */
+	var mxSqlLen int        /* Max length of an SQL string */
+	var pParentParse *Parse /* Outer parse context, if any */
+
+	mxSqlLen = db.aLimit[SQLITE_LIMIT_SQL_LENGTH]
+	pParse.rc = SQLITE_OK
+	pParse.zTail = zSql
+	// #ifdef sqlite3Parser_ENGINEALWAYSONSTACK
+	pEngine = sqlite3ParserPool.Get().(*yyParser)
+	pEngine.sqlite3ParserInit(pParse)
+	// #endif
+	pEngine.yystackDepth = db.aLimit[SQLITE_LIMIT_PARSER_DEPTH]
+	assert(pParse.pNewTable == nil, "pParse.pNewTable == nil")
+	assert(pParse.pNewTrigger == nil, "pParse.pNewTrigger == nil")
+	assert(pParse.nVar == 0, "pParse.nVar == 0")
+	assert(pParse.pVList == nil, "pParse.pVList == nil")
+	pParentParse = db.pParse
+	db.pParse = pParse
+	for {
+		n = sqlite3GetToken(zSql, &tokenType)
+		mxSqlLen -= n
+		if mxSqlLen < 0 {
+			pParse.rc = SQLITE_TOOBIG
+			break
+		}
+		// #ifndef SQLITE_OMIT_WINDOWFUNC
+		if tokenType >= TK_WINDOW {
+			assert(tokenType == TK_SPACE || tokenType == TK_OVER || tokenType == TK_FILTER ||
+				tokenType == TK_ILLEGAL || tokenType == TK_WINDOW,
+				"tokenType == TK_SPACE || tokenType == TK_OVER || tokenType == TK_FILTER || tokenType == TK_ILLEGAL || tokenType == TK_WINDOW")
+			if tokenType == TK_SPACE {
+				zSql = zSql[n:]
+				continue
+			}
+			if len(zSql) == 0 {
+				/* Upon reaching the end of input, call the parser two more times
+				 ** with tokens TK_SEMI and 0, in that order. */
+				if lastTokenParsed == TK_SEMI {
+					tokenType = 0
+				} else if lastTokenParsed == 0 {
+					break
+				} else {
+					tokenType = TK_SEMI
+				}
+				n = 0
+			} else if tokenType == TK_WINDOW {
+				assert(n == 6, "n == 6")
+				tokenType = analyzeWindowKeyword(zSql[6:])
+			} else if tokenType == TK_OVER {
+				assert(n == 4, "n == 4")
+				tokenType = analyzeOverKeyword(zSql[4:], lastTokenParsed)
+			} else if tokenType == TK_FILTER {
+				assert(n == 6, "n == 6")
+				tokenType = analyzeFilterKeyword(zSql[6:], lastTokenParsed)
+			} else {
+				var x Token
+				x.z = zSql
+				x.n = uint(n)
+				sqlite3ErrorMsg(pParse, "unrecognized token: \"%T\"", &x)
+				break
+			}
+		}
+		if (tokenType == TK_WINDOW || tokenType == TK_OVER || tokenType == TK_FILTER) &&
+			sqlite3IsOmitted(pParse, OmitWindowFunc, "window functions") {
+			break
+		}
+		if pParse.zStmt == nil && tokenType != TK_SEMI && tokenType != 0 {
+			pParse.zStmt = zSql
+		}
+		pParse.sLastToken.z = zSql
+		pParse.sLastToken.n = uint(n)
+		pEngine.sqlite3Parser(YYCODETYPE(tokenType), pParse.sLastToken)
+		if pEngine.yyfallback {
+			pParse.aFallback = append(pParse.aFallback, len(pParse.zTail)-len(zSql))
+		}
+		lastTokenParsed = tokenType
+		zSql = zSql[n:]
+		pParse.zPrevEnd = zSql
+		if pParse.rc != SQLITE_OK {
+			break
+		}
+	}
+	assert(nErr == 0, "nErr == 0")
+	// #ifdef sqlite3Parser_ENGINEALWAYSONSTACK
+	pEngine.sqlite3ParserFinalize()
+	sqlite3ParserPool.Put(pEngine)
+	// #endif
+	if pParse.zErrMsg != nil || (pParse.rc != SQLITE_OK && pParse.rc != SQLITE_DONE) {
+		if pParse.zErrMsg == nil {
+			pParse.zErrMsg = sqlite3MPrintf(db, "%s", sqlite3ErrStr(pParse.rc))
+		}
+		nErr++
+	}
+	pParse.zTail = zSql
+	pParse.pVList = nil
+	db.pParse = pParentParse
+	assert(nErr == 0 || pParse.rc != SQLITE_OK, "nErr == 0 || pParse.rc != SQLITE_OK")
+	return nErr
+}
+
+/*
+** TokenClass is the broad category of a token returned by a Scanner.
+ */
+type TokenClass int
+
+const (
+	ClassOperator   TokenClass = iota /* Punctuation and operators */
+	ClassKeyword                      /* A keyword such as SELECT or JOIN */
+	ClassIdentifier                   /* A name, quoted or not */
+	ClassLiteral                      /* A string, number or blob literal */
+	ClassVariable                     /* A parameter: ?NNN, :AAA, @AAA or $AAA */
+	ClassSpace                        /* Whitespace */
+	ClassComment                      /* An SQL or C-style comment */
+	ClassIllegal                      /* Text that is not a valid token */
+)
+
+/*
+** TokenKind is one of the TK_* token codes used by the parser.
+ */
+type TokenKind int
+
+/* String returns the name the grammar uses for the token kind */
+func (k TokenKind) String() string {
+	if k >= 0 && int(k) < len(yyTokenName) {
+		return yyTokenName[k]
+	}
+	return "UNKNOWN"
+}
+
+/*
+** ScanToken is a single token returned by a Scanner.  Offset is the
+** byte offset of the token within the scanned text.  Line and Column
+** are 1-based; Column counts bytes from the start of the line.
+ */
+type ScanToken struct {
+	Kind   TokenKind
+	Class  TokenClass
+	Text   string
+	Offset int
+	Line   int
+	Column int
+}
+
+/* IsKeyword is true for keywords */
+func (t *ScanToken) IsKeyword() bool { return t.Class == ClassKeyword }
+
+/* IsIdentifier is true for names, including quoted names */
+func (t *ScanToken) IsIdentifier() bool { return t.Class == ClassIdentifier }
+
+/* IsLiteral is true for string, numeric and blob literals */
+func (t *ScanToken) IsLiteral() bool { return t.Class == ClassLiteral }
+
+/*
+** A Scanner splits SQL text into tokens using the same rules as the
+** parser, without parsing it.  Text that is not a valid token is
+** returned as a token of class ClassIllegal and scanning continues after
+** it.  Typical use is:
+**
+**     s := NewScanner(zSql)
+**     for s.Next() {
+**       t := s.Token()
+**       ...
+**     }
+ */
+type Scanner struct {
+	Space bool /* If true, also return whitespace and comments */
+
+	zSql      []byte    /* Complete text being scanned */
+	iOfst     int       /* Offset of the next token */
+	iLine     int       /* Line number of zSql[iOfst] */
+	iLineOfst int       /* Offset of the first byte of line iLine */
+	lastToken int       /* Kind of the last non-space token */
+	tok       ScanToken /* The current token */
+}
+
+/* NewScanner returns a Scanner that reads tokens from zSql */
+func NewScanner(zSql string) *Scanner {
+	return &Scanner{zSql: []byte(zSql), iLine: 1}
+}
+
+/*
+** Next advances to the next token, which is then available from Token.
+** It returns false at the end of the input.
+ */
+func (s *Scanner) Next() bool {
+	for s.iOfst < len(s.zSql) {
+		var tokenType int
+		z := s.zSql[s.iOfst:]
+		n := sqlite3GetToken(z, &tokenType)
+		if n == 0 {
+			n = 1
+		}
+		switch tokenType {
+		case TK_WINDOW:
+			tokenType = analyzeWindowKeyword(z[n:])
+		case TK_OVER:
+			tokenType = analyzeOverKeyword(z[n:], s.lastToken)
+		case TK_FILTER:
+			tokenType = analyzeFilterKeyword(z[n:], s.lastToken)
+		}
+		s.tok = ScanToken{
+			Kind:   TokenKind(tokenType),
+			Class:  scanTokenClass(tokenType, z[:n]),
+			Text:   string(z[:n]),
+			Offset: s.iOfst,
+			Line:   s.iLine,
+			Column: s.iOfst - s.iLineOfst + 1,
+		}
+		for i := 0; i < n; i++ {
+			if z[i] == '\n' {
+				s.iLine++
+				s.iLineOfst = s.iOfst + i + 1
+			}
+		}
+		s.iOfst += n
+		if tokenType == TK_SPACE {
+			if !s.Space {
+				continue
+			}
+		} else {
+			s.lastToken = tokenType
+		}
+		return true
+	}
+	return false
+}
+
+/* Token returns the token found by the most recent call to Next */
+func (s *Scanner) Token() ScanToken { return s.tok }
+
+/*
+** Return the TokenClass of the token z of type tokenType.
+ */
+func scanTokenClass(tokenType int, z []byte) TokenClass {
+	switch tokenType {
+	case TK_SPACE:
+		if len(z) >= 2 && ((z[0] == '-' && z[1] == '-') || (z[0] == '/' && z[1] == '*')) {
+			return ClassComment
+		}
+		return ClassSpace
+	case TK_ILLEGAL:
+		return ClassIllegal
+	case TK_ID:
+		return ClassIdentifier
+	case TK_STRING, TK_INTEGER, TK_FLOAT, TK_BLOB:
+		return ClassLiteral
+	case TK_VARIABLE:
+		return ClassVariable
+	}
+	if IdChar(z[0]) && !sqlite3Isdigit(z[0]) {
+		return ClassKeyword
+	}
+	return ClassOperator
+}
+
+/*
+** QuoteIdent returns zName in a form that can be used as an identifier
+** in SQL text.  The name is returned unchanged if the tokenizer would
+** read it as a single TK_ID: it is not empty, starts with neither a
+** digit nor "$", contains only identifier characters, and is not a
+** keyword.  Otherwise it is enclosed in double quotes and any double
+** quotes within it are doubled.
+ */
+func QuoteIdent(zName string) string {
+	var tokenType int
+	z := []byte(zName)
+	if len(z) > 0 && IdChar(z[0]) && !sqlite3Isdigit(z[0]) && z[0] != '$' &&
+		sqlite3GetToken(z, &tokenType) == len(z) && tokenType == TK_ID {
+		return zName
+	}
+	return string(sqlite3MPrintf(nil, "\"%w\"", zName))
+}
@@ -0,0 +1,117 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Tests for the exported tokenizer interfaces.
+ */
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+/*
+** Scan zSql and return its tokens.
+ */
+func testScan(zSql string, bSpace bool) []ScanToken {
+	var aTok []ScanToken
+	s := NewScanner(zSql)
+	s.Space = bSpace
+	for s.Next() {
+		aTok = append(aTok, s.Token())
+	}
+	return aTok
+}
+
+/*
+** The Scanner must report the kind, class, text and position of each
+** token, resolve WINDOW the way the parser does, and return whitespace
+** and comments only if Scanner.Space is set.
+ */
+func TestScanner(t *testing.T) {
+	zSql := "SELECT \"a\", window -- c\n  FROM t WINDOW w AS (), x'01' ?1 #"
+	aWant := []ScanToken{
+		{TK_SELECT, ClassKeyword, "SELECT", 0, 1, 1},
+		{TK_ID, ClassIdentifier, `"a"`, 7, 1, 8},
+		{TK_COMMA, ClassOperator, ",", 10, 1, 11},
+		{TK_ID, ClassIdentifier, "window", 12, 1, 13},
+		{TK_FROM, ClassKeyword, "FROM", 26, 2, 3},
+		{TK_ID, ClassIdentifier, "t", 31, 2, 8},
+		{TK_WINDOW, ClassKeyword, "WINDOW", 33, 2, 10},
+		{TK_ID, ClassIdentifier, "w", 40, 2, 17},
+		{TK_AS, ClassKeyword, "AS", 42, 2, 19},
+		{TK_LP, ClassOperator, "(", 45, 2, 22},
+		{TK_RP, ClassOperator, ")", 46, 2, 23},
+		{TK_COMMA, ClassOperator, ",", 47, 2, 24},
+		{TK_BLOB, ClassLiteral, "x'01'", 49, 2, 26},
+		{TK_VARIABLE, ClassVariable, "?1", 55, 2, 32},
+		{TK_ILLEGAL, ClassIllegal, "#", 58, 2, 35},
+	}
+	if aTok := testScan(zSql, false); !reflect.DeepEqual(aTok, aWant) {
+		t.Errorf("got  %+v\nwant %+v", aTok, aWant)
+	}
+
+	aTok := testScan(zSql, true)
+	zText := ""
+	nComment := 0
+	for _, tok := range aTok {
+		zText += tok.Text
+		if tok.Class == ClassComment {
+			nComment++
+			if tok.Text != "-- c" || tok.Kind != TK_SPACE {
+				t.Errorf("comment token %+v", tok)
+			}
+		}
+	}
+	if zText != zSql {
+		t.Errorf("tokens with Space set do not cover the text: %q", zText)
+	}
+	if nComment != 1 {
+		t.Errorf("%d comments, want 1", nComment)
+	}
+	if s := TokenKind(TK_SELECT).String(); s != "SELECT" {
+		t.Errorf("TokenKind(TK_SELECT) is %q", s)
+	}
+}
+
+/*
+** QuoteIdent must leave plain names alone and quote everything else,
+** and a quoted name must read back as a single identifier.
+ */
+func TestQuoteIdent(t *testing.T) {
+	aTest := []struct {
+		zName string
+		zWant string
+	}{
+		{"abc", "abc"},
+		{"_x1", "_x1"},
+		{"tést", "tést"},
+		{"", `""`},
+		{"select", `"select"`},
+		{"Key", `"Key"`},
+		{"1abc", `"1abc"`},
+		{"$a", `"$a"`},
+		{"a b", `"a b"`},
+		{"a-b", `"a-b"`},
+		{`a"b`, `"a""b"`},
+	}
+	for _, tc := range aTest {
+		zGot := QuoteIdent(tc.zName)
+		if zGot != tc.zWant {
+			t.Errorf("QuoteIdent(%q) = %q, want %q", tc.zName, zGot, tc.zWant)
+			continue
+		}
+		aTok := testScan(zGot, true)
+		if len(aTok) != 1 || aTok[0].Kind != TK_ID {
+			t.Errorf("%s is not read as one identifier: %+v", zGot, aTok)
+		}
+	}
+}
@@ -0,0 +1,3933 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains a Go port of SQLite's LEMON parser generator.  It
+** reads an LALR(1) grammar such as parse.y and writes a Go parser built
+** from the driver template in lempar.go.tmpl.
+**
+** The grammar analysis is a line-for-line translation of tool/lemon.c so
+** that the tables it writes are identical to the ones lemon would write.
+** Only the output routines differ: they emit Go instead of C.
+ */
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+/*
+** The default driver template.  The -T option names a different one.
+ */
+//go:embed lempar.go.tmpl
+var lempar string
+
+const MAXRHS = 1000
+
+const NO_OFFSET = -2147483647
+
+/********** From the file "set.h" ****************************************/
+/*
+** A set is an array of booleans, one for each terminal symbol plus
+** one extra.
+ */
+type set []bool
+
+var size int = 0
+
+/* Set the set size */
+func SetSize(n int) {
+	size = n + 1
+}
+
+/* Allocate a new set */
+func SetNew() set {
+	return make(set, size)
+}
+
+/* Add a new element to the set.  Return true if the element was added
+** and false if it was already there. */
+func SetAdd(s set, e int) bool {
+	rv := s[e]
+	s[e] = true
+	return !rv
+}
+
+/* Add every element of s2 to s1.  Return true if s1 changes. */
+func SetUnion(s1 set, s2 set) bool {
+	progress := false
+	for i := 0; i < size; i++ {
+		if !s2[i] {
+			continue
+		}
+		if !s1[i] {
+			progress = true
+			s1[i] = true
+		}
+	}
+	return progress
+}
+
+func SetFind(s set, e int) bool {
+	return s[e]
+}
+
+/********** From the file "struct.h" *************************************/
+/*
+** Principal data structures for the LEMON parser generator.
+ */
+
+/* Symbols (terminals and nonterminals) of the grammar are stored
+** in the following: */
+type symbol_type int
+
+const (
+	TERMINAL symbol_type = iota
+	NONTERMINAL
+	MULTITERMINAL
+)
+
+type e_assoc int
+
+const (
+	LEFT e_assoc = iota
+	RIGHT
+	NONE
+	UNK
+)
+
+type symbol struct {
+	name       string      /* Name of the symbol */
+	index      int         /* Index number for this symbol */
+	typ        symbol_type /* Symbols are all either TERMINALS or NTs */
+	rule       *rule       /* Linked list of rules of this (if an NT) */
+	fallback   *symbol     /* fallback token in case this token doesn't parse */
+	prec       int         /* Precedence if defined (-1 otherwise) */
+	assoc      e_assoc     /* Associativity if precedence is defined */
+	firstset   set         /* First-set for all rules of this symbol */
+	lambda     bool        /* True if NT and can generate an empty string */
+	useCnt     int         /* Number of times used */
+	destructor string      /* Code which executes whenever this symbol is
+	 ** popped from the stack during error processing */
+	destLineno int /* Line number for start of destructor.  Set to
+	 ** -1 for duplicate destructors. */
+	datatype string /* The data type of information held by this
+	 ** object. Only used if type==NONTERMINAL */
+	dtnum int /* The data type number.  In the parser, the value
+	 ** stack is a union.  The .yy%d element of this
+	 ** union is the correct data type for this object */
+	bContent bool /* True if this symbol ever carries content - if
+	 ** it is ever more than just syntax */
+	/* The following fields are used by MULTITERMINALs only */
+	subsym []*symbol /* Array of constituent symbols */
+}
+
+/* Each production rule in the grammar is stored in the following
+** structure.  */
+type rule struct {
+	lhs         *symbol   /* Left-hand side of the rule */
+	lhsalias    string    /* Alias for the LHS (NULL if none) */
+	lhsStart    bool      /* True if left-hand side is the start symbol */
+	ruleline    int       /* Line number for the rule */
+	rhs         []*symbol /* The RHS symbols */
+	rhsalias    []string  /* An alias for each RHS symbol (NULL if none) */
+	line        int       /* Line number at which code begins */
+	code        string    /* The code executed when this rule is reduced */
+	codePrefix  string    /* Setup code before code[] above */
+	codeSuffix  string    /* Breakdown code after code[] above */
+	precsym     *symbol   /* Precedence symbol for this rule */
+	index       int       /* An index number for this rule */
+	iRule       int       /* Rule number as used in the generated tables */
+	noCode      bool      /* True if this rule has no associated C code */
+	codeEmitted bool      /* True if the code has been emitted already */
+	canReduce   bool      /* True if this rule is ever reduced */
+	doesReduce  bool      /* Reduce actions occur after optimization */
+	neverReduce bool      /* Reduce is theoretically possible, but prevented
+	 ** by actions or other outside implementation */
+	nextlhs *rule /* Next rule with the same LHS */
+	next    *rule /* Next rule in the global list */
+}
+
+/* A configuration is a production rule of the grammar together with
+** a mark (dot) showing how much of that rule has been processed so far.
+** Configurations also contain a follow-set which is a list of terminal
+** symbols which are allowed to immediately follow the end of the rule.
+** Every configuration is recorded as an instance of the following: */
+type cfgstatus int
+
+const (
+	COMPLETE cfgstatus = iota
+	INCOMPLETE
+)
+
+type config struct {
+	rp     *rule     /* The rule upon which the configuration is based */
+	dot    int       /* The parse point */
+	fws    set       /* Follow-set for this configuration only */
+	fplp   *plink    /* Follow-set forward propagation links */
+	bplp   *plink    /* Follow-set backwards propagation links */
+	stp    *state    /* Pointer to state which contains this */
+	status cfgstatus /* used during followset and shift computations */
+	next   *config   /* Next configuration in the state */
+	bp     *config   /* The next basis configuration */
+}
+
+type e_action int
+
+const (
+	SHIFT e_action = iota
+	ACCEPT
+	REDUCE
+	ERROR
+	SSCONFLICT  /* A shift/shift conflict */
+	SRCONFLICT  /* Was a reduce, but part of a conflict */
+	RRCONFLICT  /* Was a reduce, but part of a conflict */
+	SH_RESOLVED /* Was a shift.  Precedence resolved conflict */
+	RD_RESOLVED /* Was reduce.  Precedence resolved conflict */
+	NOT_USED    /* Deleted by compression */
+	SHIFTREDUCE /* Shift first, then reduce */
+)
+
+/* Every shift or reduce operation is stored as one of the following */
+type action struct {
+	sp    *symbol  /* The look-ahead symbol */
+	typ   e_action /* The type of action */
+	stp   *state   /* The new state, if a shift */
+	rp    *rule    /* The rule, if a reduce */
+	spOpt *symbol  /* SHIFTREDUCE optimization to this symbol */
+	next  *action  /* Next action for this state */
+	seq   int      /* Allocation order.  Stands in for the address
+	 ** comparison lemon uses to break ties */
+}
+
+/* Each state of the generated parser's finite state machine
+** is encoded as an instance of the following structure. */
+type state struct {
+	bp          *config /* The basis configurations for this state */
+	cfp         *config /* All configurations in this set */
+	statenum    int     /* Sequential number for this state */
+	ap          *action /* List of actions for this state */
+	nTknAct     int     /* Number of actions on terminals and nonterminals */
+	nNtAct      int
+	iTknOfst    int /* yy_action[] offset for terminals and nonterms */
+	iNtOfst     int
+	iDfltReduce int   /* Default action is to REDUCE by this rule */
+	pDfltReduce *rule /* The default REDUCE rule. */
+	autoReduce  bool  /* True if this is an auto-reduce state */
+}
+
+/* A followset propagation link indicates that the contents of one
+** configuration followset should be propagated to another whenever
+** the first changes. */
+type plink struct {
+	cfp  *config /* The configuration to which linked */
+	next *plink  /* The next propagate link */
+}
+
+/* The state vector for the entire parser generator is recorded as
+** follows.  (LEMON uses no global variables and makes little use of
+** static variables.  Fields in the following structure can be thought
+** of as begin global variables in the program.) */
+type lemon struct {
+	sorted          []*state  /* Table of states sorted by state number */
+	rule            *rule     /* List of all rules */
+	startRule       *rule     /* First rule */
+	nstate          int       /* Number of states */
+	nxstate         int       /* nstate with tail degenerate states removed */
+	nrule           int       /* Number of rules */
+	nruleWithAction int       /* Number of rules with actions */
+	nsymbol         int       /* Number of terminal and nonterminal symbols */
+	nterminal       int       /* Number of terminal symbols */
+	minShiftReduce  int       /* Minimum shift-reduce action value */
+	errAction       int       /* Error action value */
+	accAction       int       /* Accept action value */
+	noAction        int       /* No-op action value */
+	minReduce       int       /* Minimum reduce action */
+	maxAction       int       /* Maximum action value of any kind */
+	symbols         []*symbol /* Sorted array of pointers to symbols */
+	errorcnt        int       /* Number of errors */
+	errsym          *symbol   /* The error symbol */
+	wildcard        *symbol   /* Token that matches anything */
+	name            string    /* Name of the generated parser */
+	arg             string    /* Declaration of the 3rd argument to parser */
+	ctx             string    /* Declaration of 2nd argument to constructor */
+	tokentype       string    /* Type of terminal symbols in the parser stack */
+	vartype         string    /* The default type of non-terminal symbols */
+	start           string    /* Name of the start symbol for the grammar */
+	stacksize       string    /* Size of the parser stack */
+	include         string    /* Code to put at the start of the parser file */
+	error           string    /* Code to execute when an error is seen */
+	overflow        string    /* Code to execute on a stack overflow */
+	failure         string    /* Code to execute on parser failure */
+	accept          string    /* Code to execute when the parser excepts */
+	extracode       string    /* Code appended to the generated file */
+	tokendest       string    /* Code to execute to destroy token data */
+	vardest         string    /* Code for the default non-terminal destructor */
+	filename        string    /* Name of the input file */
+	outname         string    /* Name of the current output file */
+	tokenprefix     string    /* A prefix added to token names in the .h file */
+	nconflict       int       /* Number of parsing conflicts */
+	nactiontab      int       /* Number of entries in the yy_action[] table */
+	nlookaheadtab   int       /* Number of entries in yy_lookahead[] */
+	tablesize       int       /* Total table size of all tables in bytes */
+	has_fallback    bool      /* True if any %fallback is seen in the grammar */
+	nolinenosflag   bool      /* True if #line statements should not be printed */
+}
+
+/**************** From the file "msort.c" *******************************/
+/*
+** Sort the linked list of actions.  Lemon uses a stable merge sort on
+** the "next" pointers; sort.SliceStable over a slice gives the same
+** order.
+ */
+func Action_sort(ap *action) *action {
+	var a []*action
+	for ; ap != nil; ap = ap.next {
+		a = append(a, ap)
+	}
+	sort.SliceStable(a, func(i, j int) bool { return actioncmp(a[i], a[j]) < 0 })
+	return linkActions(a)
+}
+
+func linkActions(a []*action) *action {
+	var head *action
+	for i := len(a) - 1; i >= 0; i-- {
+		a[i].next = head
+		head = a[i]
+	}
+	return head
+}
+
+/********************** From the file "action.c" *************************/
+/*
+** Routines processing parser actions in the LEMON parser generator.
+ */
+
+var nActionSeq int
+
+/* Allocate a new parser action */
+func Action_new() *action {
+	nActionSeq++
+	return &action{seq: nActionSeq}
+}
+
+/* Compare two actions for sorting purposes.  Return negative, zero, or
+** positive if the first action is less than, equal to, or greater than
+** the first
+ */
+func actioncmp(ap1 *action, ap2 *action) int {
+	rc := ap1.sp.index - ap2.sp.index
+	if rc == 0 {
+		rc = int(ap1.typ) - int(ap2.typ)
+	}
+	if rc == 0 && (ap1.typ == REDUCE || ap1.typ == SHIFTREDUCE) {
+		rc = ap1.rp.index - ap2.rp.index
+	}
+	if rc == 0 {
+		rc = ap2.seq - ap1.seq
+	}
+	return rc
+}
+
+func Action_add(app **action, typ e_action, sp *symbol, stp *state, rp *rule) {
+	newaction := Action_new()
+	newaction.next = *app
+	*app = newaction
+	newaction.typ = typ
+	newaction.sp = sp
+	newaction.spOpt = nil
+	newaction.stp = stp
+	newaction.rp = rp
+}
+
+/********************** New code to implement the "acttab" module ***********/
+/*
+** This module implements routines use to construct the yy_action[] table.
+ */
+
+/*
+** The state of the yy_action table under construction is an instance of
+** the following structure.
+**
+** The yy_action table maps the pair (state_number, lookahead) into an
+** action_number.  The table is an array of integers pairs.  The state_number
+** determines an initial offset into the yy_action array.  The lookahead
+** value is then added to this initial offset to get an index X into the
+** yy_action array. If the aAction[X].lookahead equals the value of the
+** of the lookahead input, then the value of the action_number output is
+** aAction[X].action.  If the lookaheads do not match then the
+** default action for the state_number is returned.
+**
+** All actions associated with a single state_number are first entered
+** into aLookahead[] using multiple calls to acttab_action().  Then the
+** actions for that single state_number are placed into the aAction[]
+** array with a single call to acttab_insert().  The acttab_insert() call
+** also resets the aLookahead[] array in preparation for the next
+** state number.
+ */
+type lookahead_action struct {
+	lookahead int /* Value of the lookahead token */
+	action    int /* Action to take on the given lookahead */
+}
+
+type acttab struct {
+	nAction      int                /* Number of used slots in aAction[] */
+	nActionAlloc int                /* Slots allocated for aAction[] */
+	aAction      []lookahead_action /* The yy_action[] table under construction */
+	aLookahead   []lookahead_action /* A single new transaction set */
+	mnLookahead  int                /* Minimum aLookahead[].lookahead */
+	mnAction     int                /* Action associated with mnLookahead */
+	mxLookahead  int                /* Maximum aLookahead[].lookahead */
+	nterminal    int                /* Number of terminal symbols */
+	nsymbol      int                /* total number of symbols */
+}
+
+/* Allocate a new acttab structure */
+func acttab_alloc(nsymbol int, nterminal int) *acttab {
+	return &acttab{nsymbol: nsymbol, nterminal: nterminal}
+}
+
+/*
+** Add a new action to the current transaction set.
+**
+** This routine is called once for each lookahead for a particular
+** state.
+ */
+func acttab_action(p *acttab, lookahead int, action int) {
+	if len(p.aLookahead) == 0 {
+		p.mxLookahead = lookahead
+		p.mnLookahead = lookahead
+		p.mnAction = action
+	} else {
+		if p.mxLookahead < lookahead {
+			p.mxLookahead = lookahead
+		}
+		if p.mnLookahead > lookahead {
+			p.mnLookahead = lookahead
+			p.mnAction = action
+		}
+	}
+	p.aLookahead = append(p.aLookahead, lookahead_action{lookahead, action})
+}
+
+/*
+** Add the transaction set built up with prior calls to acttab_action()
+** into the current action table.  Then reset the transaction set back
+** to an empty set in preparation for a new round of acttab_action() calls.
+**
+** Return the offset into the action table of the new transaction.
+**
+** If the makeItSafe parameter is true, then the offset is chosen so that
+** it is impossible to overread the yy_lookaside[] table regardless of
+** the lookaside token.  This is done for the terminal symbols, as they
+** come from external inputs and can contain syntax errors.  When makeItSafe
+** is false, there is more flexibility in selecting offsets, resulting in
+** a smaller table.  For non-terminal symbols, which are never syntax errors,
+** makeItSafe can be false.
+ */
+func acttab_insert(p *acttab, makeItSafe bool) int {
+	var i, j, k, n, end int
+	nLookahead := len(p.aLookahead)
+	assert(nLookahead > 0)
+
+	/* Make sure we have enough space to hold the expanded action table
+	** in the worst case.  The worst case occurs if the transaction set
+	** must be appended to the current action table
+	 */
+	n = p.nsymbol + 1
+	if p.nAction+n >= p.nActionAlloc {
+		oldAlloc := p.nActionAlloc
+		p.nActionAlloc = p.nAction + n + p.nActionAlloc + 20
+		for i = oldAlloc; i < p.nActionAlloc; i++ {
+			p.aAction = append(p.aAction, lookahead_action{-1, -1})
+		}
+	}
+
+	/* Scan the existing action table looking for an offset that is a
+	** duplicate of the current transaction set.  Fall out of the loop
+	** if and when the duplicate is found.
+	**
+	** i is the index in p->aAction[] where p->mnLookahead is inserted.
+	 */
+	if makeItSafe {
+		end = p.mnLookahead
+	}
+	for i = p.nAction - 1; i >= end; i-- {
+		if p.aAction[i].lookahead == p.mnLookahead {
+			/* All lookaheads and actions in the aLookahead[] transaction
+			** must match against the candidate aAction[i] entry. */
+			if p.aAction[i].action != p.mnAction {
+				continue
+			}
+			for j = 0; j < nLookahead; j++ {
+				k = p.aLookahead[j].lookahead - p.mnLookahead + i
+				if k < 0 || k >= p.nAction {
+					break
+				}
+				if p.aLookahead[j].lookahead != p.aAction[k].lookahead {
+					break
+				}
+				if p.aLookahead[j].action != p.aAction[k].action {
+					break
+				}
+			}
+			if j < nLookahead {
+				continue
+			}
+
+			/* No possible lookahead value that is not in the aLookahead[]
+			** transaction is allowed to match aAction[i] */
+			n = 0
+			for j = 0; j < p.nAction; j++ {
+				if p.aAction[j].lookahead < 0 {
+					continue
+				}
+				if p.aAction[j].lookahead == j+p.mnLookahead-i {
+					n++
+				}
+			}
+			if n == nLookahead {
+				break /* An exact match is found at offset i */
+			}
+		}
+	}
+
+	/* If no existing offsets exactly match the current transaction, find an
+	** an empty offset in the aAction[] table in which we can add the
+	** aLookahead[] transaction.
+	 */
+	if i < end {
+		/* Look for holes in the aAction[] table that fit the current
+		** aLookahead[] transaction.  Leave i set to the offset of the hole.
+		** If no holes are found, i is left at p->nAction, which means the
+		** transaction will be appended. */
+		if makeItSafe {
+			i = p.mnLookahead
+		} else {
+			i = 0
+		}
+		for ; i < p.nActionAlloc-p.mxLookahead; i++ {
+			if p.aAction[i].lookahead < 0 {
+				for j = 0; j < nLookahead; j++ {
+					k = p.aLookahead[j].lookahead - p.mnLookahead + i
+					if k < 0 {
+						break
+					}
+					if p.aAction[k].lookahead >= 0 {
+						break
+					}
+				}
+				if j < nLookahead {
+					continue
+				}
+				for j = 0; j < p.nAction; j++ {
+					if p.aAction[j].lookahead == j+p.mnLookahead-i {
+						break
+					}
+				}
+				if j == p.nAction {
+					break /* Fits in empty slots */
+				}
+			}
+		}
+	}
+	/* Insert transaction set at index i. */
+	for j = 0; j < nLookahead; j++ {
+		k = p.aLookahead[j].lookahead - p.mnLookahead + i
+		p.aAction[k] = p.aLookahead[j]
+		if k >= p.nAction {
+			p.nAction = k + 1
+		}
+	}
+	if makeItSafe && i+p.nterminal >= p.nAction {
+		p.nAction = i + p.nterminal + 1
+	}
+	p.aLookahead = p.aLookahead[:0]
+
+	/* Return the offset that is added to the lookahead in order to get the
+	** index into yy_action of the action */
+	return i - p.mnLookahead
+}
+
+/*
+** Return the size of the action table without the trailing syntax error
+** entries.
+ */
+func acttab_action_size(p *acttab) int {
+	n := p.nAction
+	for n > 0 && p.aAction[n-1].lookahead < 0 {
+		n--
+	}
+	return n
+}
+
+/********************** From the file "build.c" *****************************/
+/*
+** Routines to construction the finite state machine for the LEMON
+** parser generator.
+ */
+
+/* Find a precedence symbol of every rule in the grammar.
+**
+** Those rules which have a precedence symbol coded in the input
+** grammar using the "[symbol]" construct will already have the
+** rp->precsym field filled.  Other rules take as their precedence
+** symbol the first RHS symbol with a defined precedence.  If there
+** are not RHS symbols with a defined precedence, the precedence
+** symbol field is left blank.
+ */
+func FindRulePrecedences(xp *lemon) {
+	for rp := xp.rule; rp != nil; rp = rp.next {
+		if rp.precsym == nil {
+			for i := 0; i < len(rp.rhs) && rp.precsym == nil; i++ {
+				sp := rp.rhs[i]
+				if sp.typ == MULTITERMINAL {
+					for j := 0; j < len(sp.subsym); j++ {
+						if sp.subsym[j].prec >= 0 {
+							rp.precsym = sp.subsym[j]
+							break
+						}
+					}
+				} else if sp.prec >= 0 {
+					rp.precsym = rp.rhs[i]
+				}
+			}
+		}
+	}
+}
+
+/* Find all nonterminals which will generate the empty string.
+** Then go back and compute the first sets of every nonterminal.
+** The first set is the set of all terminal symbols which can begin
+** a string generated by that nonterminal.
+ */
+func FindFirstSets(lemp *lemon) {
+	var progress bool
+
+	for i := 0; i < lemp.nsymbol; i++ {
+		lemp.symbols[i].lambda = false
+	}
+	for i := lemp.nterminal; i < lemp.nsymbol; i++ {
+		lemp.symbols[i].firstset = SetNew()
+	}
+
+	/* First compute all lambdas */
+	for {
+		progress = false
+		for rp := lemp.rule; rp != nil; rp = rp.next {
+			if rp.lhs.lambda {
+				continue
+			}
+			i := 0
+			for ; i < len(rp.rhs); i++ {
+				sp := rp.rhs[i]
+				assert(sp.typ == NONTERMINAL || !sp.lambda)
+				if !sp.lambda {
+					break
+				}
+			}
+			if i == len(rp.rhs) {
+				rp.lhs.lambda = true
+				progress = true
+			}
+		}
+		if !progress {
+			break
+		}
+	}
+
+	/* Now compute all first sets */
+	for {
+		progress = false
+		for rp := lemp.rule; rp != nil; rp = rp.next {
+			s1 := rp.lhs
+			for i := 0; i < len(rp.rhs); i++ {
+				s2 := rp.rhs[i]
+				if s2.typ == TERMINAL {
+					if SetAdd(s1.firstset, s2.index) {
+						progress = true
+					}
+					break
+				} else if s2.typ == MULTITERMINAL {
+					for j := 0; j < len(s2.subsym); j++ {
+						if SetAdd(s1.firstset, s2.subsym[j].index) {
+							progress = true
+						}
+					}
+					break
+				} else if s1 == s2 {
+					if !s1.lambda {
+						break
+					}
+				} else {
+					if SetUnion(s1.firstset, s2.firstset) {
+						progress = true
+					}
+					if !s2.lambda {
+						break
+					}
+				}
+			}
+		}
+		if !progress {
+			break
+		}
+	}
+}
+
+/* Compute all LR(0) states for the grammar.  Links
+** are added to between some states so that the LR(1) follow sets
+** can be computed later.
+ */
+func FindStates(lemp *lemon) {
+	var sp *symbol
+
+	Configlist_init()
+
+	/* Find the start symbol */
+	if lemp.start != "" {
+		sp = Symbol_find(lemp.start)
+		if sp == nil {
+			ErrorMsg(lemp.filename, 0,
+				"The specified start symbol \"%s\" is not "+
+					"in a nonterminal of the grammar.  \"%s\" will be used as the start "+
+					"symbol instead.", lemp.start, lemp.startRule.lhs.name)
+			lemp.errorcnt++
+			sp = lemp.startRule.lhs
+		}
+	} else if lemp.startRule != nil {
+		sp = lemp.startRule.lhs
+	} else {
+		ErrorMsg(lemp.filename, 0, "Internal error - no start rule\n")
+		os.Exit(1)
+	}
+
+	/* Make sure the start symbol doesn't occur on the right-hand side of
+	** any rule.  Report an error if it does.  (YACC would generate a new
+	** start symbol in this case.) */
+	for rp := lemp.rule; rp != nil; rp = rp.next {
+		for i := 0; i < len(rp.rhs); i++ {
+			if rp.rhs[i] == sp { /* FIX ME:  Deal with multiterminals */
+				ErrorMsg(lemp.filename, 0,
+					"The start symbol \"%s\" occurs on the "+
+						"right-hand side of a rule. This will result in a parser which "+
+						"does not work properly.", sp.name)
+				lemp.errorcnt++
+			}
+		}
+	}
+
+	/* The basis configuration set for the first state
+	** is all rules which have the start symbol as their
+	** left-hand side */
+	for rp := sp.rule; rp != nil; rp = rp.nextlhs {
+		rp.lhsStart = true
+		newcfp := Configlist_addbasis(rp, 0)
+		SetAdd(newcfp.fws, 0)
+	}
+
+	/* Compute the first state.  All other states will be
+	** computed automatically during the computation of the first one.
+	** The returned pointer to the first state is not used. */
+	getstate(lemp)
+}
+
+/* Return a pointer to a state which is described by the configuration
+** list which has been built from calls to Configlist_add.
+ */
+func getstate(lemp *lemon) *state {
+	/* Extract the sorted basis of the new state.  The basis was constructed
+	** by prior calls to "Configlist_addbasis()". */
+	Configlist_sortbasis()
+	bp := Configlist_basis()
+
+	/* Get a state with the same basis */
+	stp := State_find(bp)
+	if stp != nil {
+		/* A state with the same basis already exists!  Copy all the follow-set
+		** propagation links from the state under construction into the
+		** preexisting state, then return a pointer to the preexisting state */
+		x, y := bp, stp.bp
+		for ; x != nil && y != nil; x, y = x.bp, y.bp {
+			Plink_copy(&y.bplp, x.bplp)
+			x.fplp = nil
+			x.bplp = nil
+		}
+		Configlist_return()
+	} else {
+		/* This really is a new state.  Construct all the details */
+		Configlist_closure(lemp)   /* Compute the configuration closure */
+		Configlist_sort()          /* Sort the configuration closure */
+		cfp := Configlist_return() /* Get a pointer to the config list */
+		stp = &state{}
+		stp.bp = bp                /* Remember the configuration basis */
+		stp.cfp = cfp              /* Remember the configuration closure */
+		stp.statenum = lemp.nstate /* Every state gets a sequence number */
+		lemp.nstate++
+		stp.ap = nil              /* No actions, yet. */
+		State_insert(stp, stp.bp) /* Add to the state table */
+		buildshifts(lemp, stp)    /* Recursively compute successor states */
+	}
+	return stp
+}
+
+/*
+** Return true if two symbols are the same.
+ */
+func same_symbol(a *symbol, b *symbol) bool {
+	if a == b {
+		return true
+	}
+	if a.typ != MULTITERMINAL {
+		return false
+	}
+	if b.typ != MULTITERMINAL {
+		return false
+	}
+	if len(a.subsym) != len(b.subsym) {
+		return false
+	}
+	for i := range a.subsym {
+		if a.subsym[i] != b.subsym[i] {
+			return false
+		}
+	}
+	return true
+}
+
+/* Construct all successor states to the given state.  A "successor"
+** state is any state which can be reached by a shift action.
+ */
+func buildshifts(lemp *lemon, stp *state) {
+	/* Each configuration becomes complete after it contributes to a successor
+	** state.  Initially, all configurations are incomplete */
+	for cfp := stp.cfp; cfp != nil; cfp = cfp.next {
+		cfp.status = INCOMPLETE
+	}
+
+	/* Loop through all configurations of the state "stp" */
+	for cfp := stp.cfp; cfp != nil; cfp = cfp.next {
+		if cfp.status == COMPLETE {
+			continue /* Already used by inner loop */
+		}
+		if cfp.dot >= len(cfp.rp.rhs) {
+			continue /* Can't shift this config */
+		}
+		Configlist_reset()        /* Reset the new config set */
+		sp := cfp.rp.rhs[cfp.dot] /* Symbol after the dot */
+
+		/* For every configuration in the state "stp" which has the symbol "sp"
+		** following its dot, add the same configuration to the basis set under
+		** construction but with the dot shifted one symbol to the right. */
+		for bcfp := cfp; bcfp != nil; bcfp = bcfp.next {
+			if bcfp.status == COMPLETE {
+				continue /* Already used */
+			}
+			if bcfp.dot >= len(bcfp.rp.rhs) {
+				continue /* Can't shift this one */
+			}
+			bsp := bcfp.rp.rhs[bcfp.dot] /* Get symbol after dot */
+			if !same_symbol(bsp, sp) {
+				continue /* Must be same as for "cfp" */
+			}
+			bcfp.status = COMPLETE /* Mark this config as used */
+			newcfg := Configlist_addbasis(bcfp.rp, bcfp.dot+1)
+			Plink_add(&newcfg.bplp, bcfp)
+		}
+
+		/* Get a pointer to the state described by the basis configuration set
+		** constructed in the preceding loop */
+		newstp := getstate(lemp)
+
+		/* The state "newstp" is reached from the state "stp" by a shift action
+		** on the symbol "sp" */
+		if sp.typ == MULTITERMINAL {
+			for i := 0; i < len(sp.subsym); i++ {
+				Action_add(&stp.ap, SHIFT, sp.subsym[i], newstp, nil)
+			}
+		} else {
+			Action_add(&stp.ap, SHIFT, sp, newstp, nil)
+		}
+	}
+}
+
+/*
+** Construct the propagation links
+ */
+func FindLinks(lemp *lemon) {
+	/* Housekeeping detail:
+	** Add to every propagate link a pointer back to the state to
+	** which the link is attached. */
+	for i := 0; i < lemp.nstate; i++ {
+		stp := lemp.sorted[i]
+		for cfp := stp.cfp; cfp != nil; cfp = cfp.next {
+			cfp.stp = stp
+		}
+	}
+
+	/* Convert all backlinks into forward links.  Only the forward
+	** links are used in the follow-set computation. */
+	for i := 0; i < lemp.nstate; i++ {
+		stp := lemp.sorted[i]
+		for cfp := stp.cfp; cfp != nil; cfp = cfp.next {
+			for plp := cfp.bplp; plp != nil; plp = plp.next {
+				other := plp.cfp
+				Plink_add(&other.fplp, cfp)
+			}
+		}
+	}
+}
+
+/* Compute all followsets.
+**
+** A followset is the set of all symbols which can come immediately
+** after a configuration.
+ */
+func FindFollowSets(lemp *lemon) {
+	for i := 0; i < lemp.nstate; i++ {
+		for cfp := lemp.sorted[i].cfp; cfp != nil; cfp = cfp.next {
+			cfp.status = INCOMPLETE
+		}
+	}
+
+	for {
+		progress := false
+		for i := 0; i < lemp.nstate; i++ {
+			for cfp := lemp.sorted[i].cfp; cfp != nil; cfp = cfp.next {
+				if cfp.status == COMPLETE {
+					continue
+				}
+				for plp := cfp.fplp; plp != nil; plp = plp.next {
+					if SetUnion(plp.cfp.fws, cfp.fws) {
+						plp.cfp.status = INCOMPLETE
+						progress = true
+					}
+				}
+				cfp.status = COMPLETE
+			}
+		}
+		if !progress {
+			break
+		}
+	}
+}
+
+/* Compute the reduce actions, and resolve conflicts.
+ */
+func FindActions(lemp *lemon) {
+	var sp *symbol
+
+	/* Add all of the reduce actions
+	** A reduce action is added for each element of the followset of
+	** a configuration which has its dot at the extreme right.
+	 */
+	for i := 0; i < lemp.nstate; i++ { /* Loop over all states */
+		stp := lemp.sorted[i]
+		for cfp := stp.cfp; cfp != nil; cfp = cfp.next { /* Loop over all configurations */
+			if len(cfp.rp.rhs) == cfp.dot { /* Is dot at extreme right? */
+				for j := 0; j < lemp.nterminal; j++ {
+					if SetFind(cfp.fws, j) {
+						/* Add a reduce action to the state "stp" which will reduce by the
+						** rule "cfp->rp" if the lookahead symbol is "lemp->symbols[j]" */
+						Action_add(&stp.ap, REDUCE, lemp.symbols[j], nil, cfp.rp)
+					}
+				}
+			}
+		}
+	}
+
+	/* Add the accepting token */
+	if lemp.start != "" {
+		sp = Symbol_find(lemp.start)
+		if sp == nil {
+			sp = lemp.startRule.lhs
+		}
+	} else {
+		sp = lemp.startRule.lhs
+	}
+	/* Add to the first state (which is always the starting state of the
+	** finite state machine) an action to ACCEPT if the lookahead is the
+	** start nonterminal.  */
+	Action_add(&lemp.sorted[0].ap, ACCEPT, sp, nil, nil)
+
+	/* Resolve conflicts */
+	for i := 0; i < lemp.nstate; i++ {
+		stp := lemp.sorted[i]
+		stp.ap = Action_sort(stp.ap)
+		for ap := stp.ap; ap != nil && ap.next != nil; ap = ap.next {
+			for nap := ap.next; nap != nil && nap.sp == ap.sp; nap = nap.next {
+				/* The two actions "ap" and "nap" have the same lookahead.
+				** Figure out which one should be used */
+				lemp.nconflict += resolve_conflict(ap, nap)
+			}
+		}
+	}
+
+	/* Report an error for each rule that can never be reduced. */
+	for rp := lemp.rule; rp != nil; rp = rp.next {
+		rp.canReduce = false
+	}
+	for i := 0; i < lemp.nstate; i++ {
+		for ap := lemp.sorted[i].ap; ap != nil; ap = ap.next {
+			if ap.typ == REDUCE {
+				ap.rp.canReduce = true
+			}
+		}
+	}
+	for rp := lemp.rule; rp != nil; rp = rp.next {
+		if rp.canReduce {
+			continue
+		}
+		ErrorMsg(lemp.filename, rp.ruleline, "This rule can not be reduced.\n")
+		lemp.errorcnt++
+	}
+}
+
+/* Resolve a conflict between the two given actions.  If the
+** conflict can't be resolved, return non-zero.
+**
+** NO LONGER TRUE:
+**   To resolve a conflict, first look to see if either action
+**   is on an error rule.  In that case, take the action which
+**   is not associated with the error rule.  If neither or both
+**   actions are associated with an error rule, then try to
+**   use precedence to resolve the conflict.
+**
+** If either action is a SHIFT, then it must be apx.  This
+** function won't work if apx->type==REDUCE and apy->type==SHIFT.
+ */
+func resolve_conflict(apx *action, apy *action) int {
+	var spx, spy *symbol
+	errcnt := 0
+	assert(apx.sp == apy.sp) /* Otherwise there would be no conflict */
+	if apx.typ == SHIFT && apy.typ == SHIFT {
+		apy.typ = SSCONFLICT
+		errcnt++
+	}
+	if apx.typ == SHIFT && apy.typ == REDUCE {
+		spx = apx.sp
+		spy = apy.rp.precsym
+		if spy == nil || spx.prec < 0 || spy.prec < 0 {
+			/* Not enough precedence information. */
+			apy.typ = SRCONFLICT
+			errcnt++
+		} else if spx.prec > spy.prec { /* higher precedence wins */
+			apy.typ = RD_RESOLVED
+		} else if spx.prec < spy.prec {
+			apx.typ = SH_RESOLVED
+		} else if spx.prec == spy.prec && spx.assoc == RIGHT { /* Use operator */
+			apy.typ = RD_RESOLVED /* associativity */
+		} else if spx.prec == spy.prec && spx.assoc == LEFT { /* to break tie */
+			apx.typ = SH_RESOLVED
+		} else {
+			assert(spx.prec == spy.prec && spx.assoc == NONE)
+			apx.typ = ERROR
+		}
+	} else if apx.typ == REDUCE && apy.typ == REDUCE {
+		spx = apx.rp.precsym
+		spy = apy.rp.precsym
+		if spx == nil || spy == nil || spx.prec < 0 ||
+			spy.prec < 0 || spx.prec == spy.prec {
+			apy.typ = RRCONFLICT
+			errcnt++
+		} else if spx.prec > spy.prec {
+			apy.typ = RD_RESOLVED
+		} else if spx.prec < spy.prec {
+			apx.typ = RD_RESOLVED
+		}
+	} else {
+		/* The REDUCE/SHIFT case cannot happen because SHIFTs come before
+		** REDUCEs on the list.  If we reach this point it must be because
+		** the parser conflict had already been resolved. */
+	}
+	return errcnt
+}
+
+/********************* From the file "configlist.c" *************************/
+/*
+** Routines to processing a configuration list and building a state
+** in the LEMON parser generator.
+ */
+
+var current *config     /* Top of list of configurations */
+var currentend **config /* Last on list of configs */
+var basis *config       /* Top of list of basis configs */
+var basisend **config   /* End of list of basis configs */
+
+type configKey struct {
+	rp  *rule
+	dot int
+}
+
+var configTable map[configKey]*config
+
+/* Initialized the configuration list builder */
+func Configlist_init() {
+	current = nil
+	currentend = &current
+	basis = nil
+	basisend = &basis
+	configTable = map[configKey]*config{}
+}
+
+/* Initialized the configuration list builder */
+func Configlist_reset() {
+	current = nil
+	currentend = &current
+	basis = nil
+	basisend = &basis
+	configTable = map[configKey]*config{}
+}
+
+/* Add another configuration to the configuration list */
+func Configlist_add(rp *rule, dot int) *config {
+	assert(currentend != nil)
+	cfp := configTable[configKey{rp, dot}]
+	if cfp == nil {
+		cfp = &config{rp: rp, dot: dot, fws: SetNew()}
+		*currentend = cfp
+		currentend = &cfp.next
+		configTable[configKey{rp, dot}] = cfp
+	}
+	return cfp
+}
+
+/* Add a basis configuration to the configuration list */
+func Configlist_addbasis(rp *rule, dot int) *config {
+	assert(basisend != nil)
+	assert(currentend != nil)
+	cfp := configTable[configKey{rp, dot}]
+	if cfp == nil {
+		cfp = &config{rp: rp, dot: dot, fws: SetNew()}
+		*currentend = cfp
+		currentend = &cfp.next
+		*basisend = cfp
+		basisend = &cfp.bp
+		configTable[configKey{rp, dot}] = cfp
+	}
+	return cfp
+}
+
+/* Compute the closure of the configuration list */
+func Configlist_closure(lemp *lemon) {
+	assert(currentend != nil)
+	for cfp := current; cfp != nil; cfp = cfp.next {
+		rp := cfp.rp
+		dot := cfp.dot
+		if dot >= len(rp.rhs) {
+			continue
+		}
+		sp := rp.rhs[dot]
+		if sp.typ == NONTERMINAL {
+			if sp.rule == nil && sp != lemp.errsym {
+				ErrorMsg(lemp.filename, rp.line, "Nonterminal \"%s\" has no rules.",
+					sp.name)
+				lemp.errorcnt++
+			}
+			for newrp := sp.rule; newrp != nil; newrp = newrp.nextlhs {
+				newcfp := Configlist_add(newrp, 0)
+				i := dot + 1
+				for ; i < len(rp.rhs); i++ {
+					xsp := rp.rhs[i]
+					if xsp.typ == TERMINAL {
+						SetAdd(newcfp.fws, xsp.index)
+						break
+					} else if xsp.typ == MULTITERMINAL {
+						for k := 0; k < len(xsp.subsym); k++ {
+							SetAdd(newcfp.fws, xsp.subsym[k].index)
+						}
+						break
+					} else {
+						SetUnion(newcfp.fws, xsp.firstset)
+						if !xsp.lambda {
+							break
+						}
+					}
+				}
+				if i == len(rp.rhs) {
+					Plink_add(&cfp.fplp, newcfp)
+				}
+			}
+		}
+	}
+}
+
+/* Compare two configurations */
+func Configcmp(a *config, b *config) int {
+	x := a.rp.index - b.rp.index
+	if x == 0 {
+		x = a.dot - b.dot
+	}
+	return x
+}
+
+/* Sort the configuration list */
+func Configlist_sort() {
+	var a []*config
+	for cfp := current; cfp != nil; cfp = cfp.next {
+		a = append(a, cfp)
+	}
+	sort.SliceStable(a, func(i, j int) bool { return Configcmp(a[i], a[j]) < 0 })
+	current = nil
+	for i := len(a) - 1; i >= 0; i-- {
+		a[i].next = current
+		current = a[i]
+	}
+	currentend = nil
+}
+
+/* Sort the basis configuration list */
+func Configlist_sortbasis() {
+	var a []*config
+	for cfp := current; cfp != nil; cfp = cfp.bp {
+		a = append(a, cfp)
+	}
+	sort.SliceStable(a, func(i, j int) bool { return Configcmp(a[i], a[j]) < 0 })
+	basis = nil
+	for i := len(a) - 1; i >= 0; i-- {
+		a[i].bp = basis
+		basis = a[i]
+	}
+	basisend = nil
+}
+
+/* Return a pointer to the head of the configuration list and
+** reset the list */
+func Configlist_return() *config {
+	old := current
+	current = nil
+	currentend = nil
+	return old
+}
+
+/* Return a pointer to the head of the configuration list and
+** reset the list */
+func Configlist_basis() *config {
+	old := basis
+	basis = nil
+	basisend = nil
+	return old
+}
+
+/***************** From the file "plink.c" *********************************/
+/*
+** Routines processing configuration follow-set propagation links
+** in the LEMON parser generator.
+ */
+
+/* Add a plink to a plink list */
+func Plink_add(plpp **plink, cfp *config) {
+	*plpp = &plink{cfp: cfp, next: *plpp}
+}
+
+/* Transfer every plink on the list "from" to the list "to" */
+func Plink_copy(to **plink, from *plink) {
+	for from != nil {
+		nextpl := from.next
+		from.next = *to
+		*to = from
+		from = nextpl
+	}
+}
+
+/*************************** From the file "table.c" ***********************/
+/*
+** The symbol and state tables.  Lemon keeps them in hand-built hash
+** tables; here a map does the lookups and a slice remembers the order
+** in which entries were inserted, which is what Symbol_arrayof() and
+** State_arrayof() return.
+ */
+
+var symbolTable map[string]*symbol
+var symbolArray []*symbol
+
+/* Allocate a new associative array */
+func Symbol_init() {
+	symbolTable = map[string]*symbol{}
+	symbolArray = nil
+}
+
+/* Return a pointer to the (terminal or nonterminal) symbol "x".
+** Create a new symbol if this is the first time "x" has been seen.
+ */
+func Symbol_new(x string) *symbol {
+	sp := Symbol_find(x)
+	if sp == nil {
+		sp = &symbol{name: x, prec: -1, assoc: UNK}
+		if ISUPPER(x[0]) {
+			sp.typ = TERMINAL
+		} else {
+			sp.typ = NONTERMINAL
+		}
+		Symbol_insert(sp, x)
+	}
+	sp.useCnt++
+	return sp
+}
+
+/* Insert a new record into the array. */
+func Symbol_insert(sp *symbol, key string) {
+	symbolTable[key] = sp
+	symbolArray = append(symbolArray, sp)
+}
+
+/* Return a pointer to data assigned to the given key.  Return NULL
+** if no such key. */
+func Symbol_find(key string) *symbol {
+	return symbolTable[key]
+}
+
+/* Return the size of the array */
+func Symbol_count() int {
+	return len(symbolArray)
+}
+
+/* Return an array of pointers to all data in the table.
+** The array is obtained from malloc.  Return NULL if memory allocation
+** problems, or if the array is empty. */
+func Symbol_arrayof() []*symbol {
+	return append([]*symbol(nil), symbolArray...)
+}
+
+/* Compare two symbols for sorting purposes.  Return negative,
+** zero, or positive if a is less then, equal to, or greater
+** than b.
+**
+** Symbols that begin with upper case letters (terminals or tokens)
+** must sort before symbols that begin with lower case letters
+** (non-terminals).  And MULTITERMINAL symbols (created using the
+** %token_class directive) must sort at the very end. Other than
+** that, the order does not matter.
+**
+** We find experimentally that leaving the symbols in their original
+** order (the order they appeared in the grammar file) gives the
+** smallest parser tables in SQLite.
+ */
+func Symbolcmpp(a *symbol, b *symbol) int {
+	i1 := 1
+	if a.typ == MULTITERMINAL {
+		i1 = 3
+	} else if a.name[0] > 'Z' {
+		i1 = 2
+	}
+	i2 := 1
+	if b.typ == MULTITERMINAL {
+		i2 = 3
+	} else if b.name[0] > 'Z' {
+		i2 = 2
+	}
+	if i1 == i2 {
+		return a.index - b.index
+	}
+	return i1 - i2
+}
+
+var stateTable map[string]*state
+var stateArray []*state
+
+/* Allocate a new associative array */
+func State_init() {
+	stateTable = map[string]*state{}
+	stateArray = nil
+}
+
+/* The key of a state is its sorted basis. */
+func statekey(bp *config) string {
+	var b strings.Builder
+	for ; bp != nil; bp = bp.bp {
+		fmt.Fprintf(&b, "%d.%d ", bp.rp.index, bp.dot)
+	}
+	return b.String()
+}
+
+/* Insert a new record into the array. */
+func State_insert(stp *state, key *config) {
+	stateTable[statekey(key)] = stp
+	stateArray = append(stateArray, stp)
+}
+
+/* Return a pointer to data assigned to the given key.  Return NULL
+** if no such key. */
+func State_find(key *config) *state {
+	return stateTable[statekey(key)]
+}
+
+/* Return an array of pointers to all data in the table.
+** The array is obtained from malloc.  Return NULL if memory allocation
+** problems, or if the array is empty. */
+func State_arrayof() []*state {
+	return append([]*state(nil), stateArray...)
+}
+
+/****************** From the file "error.c" *********************************/
+/*
+** Code for printing error message.
+ */
+
+func ErrorMsg(filename string, lineno int, format string, a ...interface{}) {
+	fmt.Fprintf(os.Stderr, "%s:%d: ", filename, lineno)
+	fmt.Fprintf(os.Stderr, format, a...)
+	fmt.Fprintf(os.Stderr, "\n")
+}
+
+func assert(condition bool) {
+	if !condition {
+		panic("assertion failed")
+	}
+}
+
+func ISSPACE(c byte) bool { return c == ' ' || (c >= '\t' && c <= '\r') }
+func ISDIGIT(c byte) bool { return c >= '0' && c <= '9' }
+func ISALPHA(c byte) bool { return ISUPPER(c) || ISLOWER(c) }
+func ISALNUM(c byte) bool { return ISALPHA(c) || ISDIGIT(c) }
+func ISUPPER(c byte) bool { return c >= 'A' && c <= 'Z' }
+func ISLOWER(c byte) bool { return c >= 'a' && c <= 'z' }
+
+/**************** From the file "main.c" ************************************/
+/*
+** Main program file for the LEMON parser generator.
+ */
+
+/* The -D option defines a preprocessor symbol for %ifdef, %ifndef
+** and %if.  bDefineUsed records which of them the grammar tested. */
+var azDefine []string
+var bDefineUsed []bool
+var nDefineUsed int
+
+/* Rember the name of the output directory
+ */
+var outputDir string
+var user_templatename string
+
+/* The main program.  Parse the command line and do it... */
+func main() {
+	var version, rpflag, basisflag, compress, quiet, statistics, noResort bool
+	var filename string
+	var lem lemon
+
+	for _, arg := range os.Args[1:] {
+		switch {
+		case strings.HasPrefix(arg, "-D"):
+			z := arg[2:]
+			if i := strings.IndexByte(z, '='); i >= 0 {
+				z = z[:i]
+			}
+			azDefine = append(azDefine, z)
+			bDefineUsed = append(bDefineUsed, false)
+		case strings.HasPrefix(arg, "-U"):
+			z := arg[2:]
+			for i := 0; i < len(azDefine); i++ {
+				if azDefine[i] == z {
+					azDefine = append(azDefine[:i], azDefine[i+1:]...)
+					bDefineUsed = append(bDefineUsed[:i], bDefineUsed[i+1:]...)
+					i--
+				}
+			}
+		case strings.HasPrefix(arg, "-T"):
+			user_templatename = arg[2:]
+		case strings.HasPrefix(arg, "-d"):
+			outputDir = arg[2:]
+		case arg == "-b":
+			basisflag = true
+		case arg == "-c":
+			compress = true
+		case arg == "-g":
+			rpflag = true
+		case arg == "-l":
+			lem.nolinenosflag = true
+		case arg == "-q":
+			quiet = true
+		case arg == "-r":
+			noResort = true
+		case arg == "-s":
+			statistics = true
+		case arg == "-x":
+			version = true
+		case strings.HasPrefix(arg, "-"):
+			usage()
+		default:
+			if filename != "" {
+				usage()
+			}
+			filename = arg
+		}
+	}
+	_ = basisflag
+	_ = quiet
+	if version {
+		fmt.Printf("Lemon version 1.0\n")
+		os.Exit(0)
+	}
+	if filename == "" {
+		usage()
+	}
+
+	Symbol_init()
+	State_init()
+	lem.filename = filename
+	lem.errorcnt = 0
+
+	/* Parse the input file */
+	Symbol_new("$")
+	Parse(&lem)
+	if lem.errorcnt != 0 {
+		os.Exit(lem.errorcnt)
+	}
+	if lem.nrule == 0 {
+		fmt.Fprintf(os.Stderr, "Empty grammar.\n")
+		os.Exit(1)
+	}
+	lem.errsym = Symbol_find("error")
+
+	/* Count and index the symbols of the grammar */
+	Symbol_new("{default}")
+	lem.nsymbol = Symbol_count()
+	lem.symbols = Symbol_arrayof()
+	for i := 0; i < lem.nsymbol; i++ {
+		lem.symbols[i].index = i
+	}
+	sort.Slice(lem.symbols, func(i, j int) bool {
+		return Symbolcmpp(lem.symbols[i], lem.symbols[j]) < 0
+	})
+	i := 0
+	for ; i < lem.nsymbol; i++ {
+		lem.symbols[i].index = i
+	}
+	for lem.symbols[i-1].typ == MULTITERMINAL {
+		i--
+	}
+	assert(lem.symbols[i-1].name == "{default}")
+	lem.nsymbol = i - 1
+	for i = 1; ISUPPER(lem.symbols[i].name[0]); i++ {
+	}
+	lem.nterminal = i
+
+	/* Assign sequential rule numbers.  Start with 0.  Put rules that have no
+	** reduce action C-code associated with them last, so that the switch()
+	** statement that selects reduction actions will have a smaller jump table.
+	 */
+	i = 0
+	for rp := lem.rule; rp != nil; rp = rp.next {
+		if !rp.noCode {
+			rp.iRule = i
+			i++
+		} else {
+			rp.iRule = -1
+		}
+	}
+	lem.nruleWithAction = i
+	for rp := lem.rule; rp != nil; rp = rp.next {
+		if rp.iRule < 0 {
+			rp.iRule = i
+			i++
+		}
+	}
+	lem.startRule = lem.rule
+	lem.rule = Rule_sort(lem.rule)
+
+	/* Generate a reprint of the grammar, if requested on the command line */
+	if rpflag {
+		Reprint(&lem)
+	} else {
+		/* Initialize the size for all follow and first sets */
+		SetSize(lem.nterminal + 1)
+
+		/* Find the precedence for every production rule (that has one) */
+		FindRulePrecedences(&lem)
+
+		/* Compute the lambda-nonterminals and the first-sets for every
+		** nonterminal */
+		FindFirstSets(&lem)
+
+		/* Compute all LR(0) states.  Also record follow-set propagation
+		** links so that the follow-set can be computed later */
+		lem.nstate = 0
+		FindStates(&lem)
+		lem.sorted = State_arrayof()
+
+		/* Tie up loose ends on the propagation links */
+		FindLinks(&lem)
+
+		/* Compute the follow set of every reducible configuration */
+		FindFollowSets(&lem)
+
+		/* Compute the action tables */
+		FindActions(&lem)
+
+		/* Compress the action tables */
+		if !compress {
+			CompressTables(&lem)
+		}
+
+		/* Reorder and renumber the states so that states with fewer choices
+		** occur at the end.  This is an optimization that helps make the
+		** generated parser tables smaller. */
+		if !noResort {
+			ResortStates(&lem)
+		}
+
+		/* Generate the source code for the parser */
+		ReportTable(&lem)
+	}
+	if statistics {
+		fmt.Printf("Parser statistics:\n")
+		stats_line("terminal symbols", lem.nterminal)
+		stats_line("non-terminal symbols", lem.nsymbol-lem.nterminal)
+		stats_line("total symbols", lem.nsymbol)
+		stats_line("rules", lem.nrule)
+		stats_line("states", lem.nxstate)
+		stats_line("conflicts", lem.nconflict)
+		stats_line("action table entries", lem.nactiontab)
+		stats_line("lookahead table entries", lem.nlookaheadtab)
+	}
+	if lem.nconflict > 0 {
+		fmt.Fprintf(os.Stderr, "%d parsing conflicts.\n", lem.nconflict)
+	}
+	if lem.errorcnt > 0 || lem.nconflict > 0 {
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s [-bcglqrsx] [-Dname] [-Uname] [-Tfile] [-ddir] grammar.y\n", os.Args[0])
+	os.Exit(1)
+}
+
+/* Print a single line of the "Parser Stats" output
+ */
+func stats_line(zLabel string, iValue int) {
+	nLabel := len(zLabel)
+	fmt.Printf("  %s%s %5d\n", zLabel, strings.Repeat(".", 35-nLabel), iValue)
+}
+
+/*
+** Sort the rules by their iRule number, as lemon's Rule_sort() does.
+ */
+func Rule_sort(rp *rule) *rule {
+	var a []*rule
+	for ; rp != nil; rp = rp.next {
+		a = append(a, rp)
+	}
+	sort.SliceStable(a, func(i, j int) bool { return a[i].iRule < a[j].iRule })
+	var head *rule
+	for i := len(a) - 1; i >= 0; i-- {
+		a[i].next = head
+		head = a[i]
+	}
+	return head
+}
+
+/*********************** From the file "parse.c" ****************************/
+/*
+** Input file parser for the LEMON parser generator.
+ */
+
+/* The state of the parser */
+type e_state int
+
+const (
+	INITIALIZE e_state = iota
+	WAITING_FOR_DECL_OR_RULE
+	WAITING_FOR_DECL_KEYWORD
+	WAITING_FOR_DECL_ARG
+	WAITING_FOR_PRECEDENCE_SYMBOL
+	WAITING_FOR_ARROW
+	IN_RHS
+	LHS_ALIAS_1
+	LHS_ALIAS_2
+	LHS_ALIAS_3
+	RHS_ALIAS_1
+	RHS_ALIAS_2
+	PRECEDENCE_MARK_1
+	PRECEDENCE_MARK_2
+	RESYNC_AFTER_RULE_ERROR
+	RESYNC_AFTER_DECL_ERROR
+	WAITING_FOR_DESTRUCTOR_SYMBOL
+	WAITING_FOR_DATATYPE_SYMBOL
+	WAITING_FOR_FALLBACK_ID
+	WAITING_FOR_WILDCARD_ID
+	WAITING_FOR_CLASS_ID
+	WAITING_FOR_CLASS_TOKEN
+	WAITING_FOR_TOKEN_NAME
+)
+
+type pstate struct {
+	filename        string    /* Name of the input file */
+	tokenlineno     int       /* Linenumber at which current token starts */
+	errorcnt        int       /* Number of errors so far */
+	token           string    /* The current token */
+	gp              *lemon    /* Global state vector */
+	state           e_state   /* The state of the parser */
+	fallback        *symbol   /* The fallback token */
+	tkclass         *symbol   /* Token class symbol */
+	lhs             *symbol   /* Left-hand side of current rule */
+	lhsalias        string    /* Alias for the LHS */
+	rhs             []*symbol /* RHS symbols */
+	alias           []string  /* Aliases for each RHS symbol (or NULL) */
+	prevrule        *rule     /* Previous rule parsed */
+	declkeyword     string    /* Keyword of a declaration */
+	declargslot     *string   /* Where the declaration argument should be put */
+	insertLineMacro bool      /* Add #line before declaration insert */
+	decllinenoslot  *int      /* Where to write declaration line number */
+	declassoc       e_assoc   /* Assign this association to decl arguments */
+	preccounter     int       /* Assign this precedence to decl arguments */
+	firstrule       *rule     /* Pointer to first rule in the grammar */
+	lastrule        *rule     /* Pointer to the most recently parsed rule */
+}
+
+/* Parse a single token */
+func parseonetoken(psp *pstate) {
+	x := psp.token
+	switch psp.state {
+	case INITIALIZE, WAITING_FOR_DECL_OR_RULE:
+		if psp.state == INITIALIZE {
+			psp.prevrule = nil
+			psp.preccounter = 0
+			psp.firstrule = nil
+			psp.lastrule = nil
+			psp.gp.nrule = 0
+		}
+		if x[0] == '%' {
+			psp.state = WAITING_FOR_DECL_KEYWORD
+		} else if ISLOWER(x[0]) {
+			psp.lhs = Symbol_new(x)
+			psp.rhs = nil
+			psp.alias = nil
+			psp.lhsalias = ""
+			psp.state = WAITING_FOR_ARROW
+		} else if x[0] == '{' {
+			if psp.prevrule == nil {
+				ErrorMsg(psp.filename, psp.tokenlineno,
+					"There is no prior rule upon which to attach the code "+
+						"fragment which begins on this line.")
+				psp.errorcnt++
+			} else if !psp.prevrule.noCode {
+				ErrorMsg(psp.filename, psp.tokenlineno,
+					"Code fragment beginning on this line is not the first "+
+						"to follow the previous rule.")
+				psp.errorcnt++
+			} else if x == "{NEVER-REDUCE" {
+				psp.prevrule.neverReduce = true
+			} else {
+				psp.prevrule.line = psp.tokenlineno
+				psp.prevrule.code = x[1:]
+				psp.prevrule.noCode = false
+			}
+		} else if x[0] == '[' {
+			psp.state = PRECEDENCE_MARK_1
+		} else {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"Token \"%s\" should be either \"%%\" or a nonterminal name.",
+				x)
+			psp.errorcnt++
+		}
+	case PRECEDENCE_MARK_1:
+		if !ISUPPER(x[0]) {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"The precedence symbol must be a terminal.")
+			psp.errorcnt++
+		} else if psp.prevrule == nil {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"There is no prior rule to assign precedence \"[%s]\".", x)
+			psp.errorcnt++
+		} else if psp.prevrule.precsym != nil {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"Precedence mark on this line is not the first "+
+					"to follow the previous rule.")
+			psp.errorcnt++
+		} else {
+			psp.prevrule.precsym = Symbol_new(x)
+		}
+		psp.state = PRECEDENCE_MARK_2
+	case PRECEDENCE_MARK_2:
+		if x[0] != ']' {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"Missing \"]\" on precedence mark.")
+			psp.errorcnt++
+		}
+		psp.state = WAITING_FOR_DECL_OR_RULE
+	case WAITING_FOR_ARROW:
+		if strings.HasPrefix(x, "::=") {
+			psp.state = IN_RHS
+		} else if x[0] == '(' {
+			psp.state = LHS_ALIAS_1
+		} else {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"Expected to see a \":\" following the LHS symbol \"%s\".",
+				psp.lhs.name)
+			psp.errorcnt++
+			psp.state = RESYNC_AFTER_RULE_ERROR
+		}
+	case LHS_ALIAS_1:
+		if ISALPHA(x[0]) {
+			psp.lhsalias = x
+			psp.state = LHS_ALIAS_2
+		} else {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"\"%s\" is not a valid alias for the LHS \"%s\"\n",
+				x, psp.lhs.name)
+			psp.errorcnt++
+			psp.state = RESYNC_AFTER_RULE_ERROR
+		}
+	case LHS_ALIAS_2:
+		if x[0] == ')' {
+			psp.state = LHS_ALIAS_3
+		} else {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"Missing \")\" following LHS alias name \"%s\".", psp.lhsalias)
+			psp.errorcnt++
+			psp.state = RESYNC_AFTER_RULE_ERROR
+		}
+	case LHS_ALIAS_3:
+		if strings.HasPrefix(x, "::=") {
+			psp.state = IN_RHS
+		} else {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"Missing \"->\" following: \"%s(%s)\".",
+				psp.lhs.name, psp.lhsalias)
+			psp.errorcnt++
+			psp.state = RESYNC_AFTER_RULE_ERROR
+		}
+	case IN_RHS:
+		if x[0] == '.' {
+			rp := &rule{}
+			rp.ruleline = psp.tokenlineno
+			rp.rhs = psp.rhs
+			rp.rhsalias = psp.alias
+			for i := range rp.rhs {
+				if rp.rhsalias[i] != "" {
+					rp.rhs[i].bContent = true
+				}
+			}
+			rp.lhs = psp.lhs
+			rp.lhsalias = psp.lhsalias
+			rp.noCode = true
+			rp.precsym = nil
+			rp.index = psp.gp.nrule
+			psp.gp.nrule++
+			rp.nextlhs = rp.lhs.rule
+			rp.lhs.rule = rp
+			rp.next = nil
+			if psp.firstrule == nil {
+				psp.firstrule = rp
+				psp.lastrule = rp
+			} else {
+				psp.lastrule.next = rp
+				psp.lastrule = rp
+			}
+			psp.prevrule = rp
+			psp.rhs = nil
+			psp.alias = nil
+			psp.state = WAITING_FOR_DECL_OR_RULE
+		} else if ISALPHA(x[0]) {
+			if len(psp.rhs) >= MAXRHS {
+				ErrorMsg(psp.filename, psp.tokenlineno,
+					"Too many symbols on RHS of rule beginning at \"%s\".",
+					x)
+				psp.errorcnt++
+				psp.state = RESYNC_AFTER_RULE_ERROR
+			} else {
+				psp.rhs = append(psp.rhs, Symbol_new(x))
+				psp.alias = append(psp.alias, "")
+			}
+		} else if (x[0] == '|' || x[0] == '/') && len(psp.rhs) > 0 && len(x) > 1 && ISUPPER(x[1]) {
+			msp := psp.rhs[len(psp.rhs)-1]
+			if msp.typ != MULTITERMINAL {
+				origsp := msp
+				msp = &symbol{}
+				msp.typ = MULTITERMINAL
+				msp.subsym = []*symbol{origsp}
+				msp.name = origsp.name
+				psp.rhs[len(psp.rhs)-1] = msp
+			}
+			msp.subsym = append(msp.subsym, Symbol_new(x[1:]))
+			if ISLOWER(x[1]) || ISLOWER(msp.subsym[0].name[0]) {
+				ErrorMsg(psp.filename, psp.tokenlineno,
+					"Cannot form a compound containing a non-terminal")
+				psp.errorcnt++
+			}
+		} else if x[0] == '(' && len(psp.rhs) > 0 {
+			psp.state = RHS_ALIAS_1
+		} else {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"Illegal character on RHS of rule: \"%s\".", x)
+			psp.errorcnt++
+			psp.state = RESYNC_AFTER_RULE_ERROR
+		}
+	case RHS_ALIAS_1:
+		if ISALPHA(x[0]) {
+			psp.alias[len(psp.alias)-1] = x
+			psp.state = RHS_ALIAS_2
+		} else {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"\"%s\" is not a valid alias for the RHS symbol \"%s\"\n",
+				x, psp.rhs[len(psp.rhs)-1].name)
+			psp.errorcnt++
+			psp.state = RESYNC_AFTER_RULE_ERROR
+		}
+	case RHS_ALIAS_2:
+		if x[0] == ')' {
+			psp.state = IN_RHS
+		} else {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"Missing \")\" following LHS alias name \"%s\".", psp.lhsalias)
+			psp.errorcnt++
+			psp.state = RESYNC_AFTER_RULE_ERROR
+		}
+	case WAITING_FOR_DECL_KEYWORD:
+		if ISALPHA(x[0]) {
+			psp.declkeyword = x
+			psp.declargslot = nil
+			psp.decllinenoslot = nil
+			psp.insertLineMacro = true
+			psp.state = WAITING_FOR_DECL_ARG
+			switch x {
+			case "name":
+				psp.declargslot = &psp.gp.name
+				psp.insertLineMacro = false
+			case "include":
+				psp.declargslot = &psp.gp.include
+			case "code":
+				psp.declargslot = &psp.gp.extracode
+			case "token_destructor":
+				psp.declargslot = &psp.gp.tokendest
+			case "default_destructor":
+				psp.declargslot = &psp.gp.vardest
+			case "token_prefix":
+				psp.declargslot = &psp.gp.tokenprefix
+				psp.insertLineMacro = false
+			case "syntax_error":
+				psp.declargslot = &psp.gp.error
+			case "parse_accept":
+				psp.declargslot = &psp.gp.accept
+			case "parse_failure":
+				psp.declargslot = &psp.gp.failure
+			case "stack_overflow":
+				psp.declargslot = &psp.gp.overflow
+			case "extra_argument":
+				psp.declargslot = &psp.gp.arg
+				psp.insertLineMacro = false
+			case "extra_context":
+				psp.declargslot = &psp.gp.ctx
+				psp.insertLineMacro = false
+			case "token_type":
+				psp.declargslot = &psp.gp.tokentype
+				psp.insertLineMacro = false
+			case "default_type":
+				psp.declargslot = &psp.gp.vartype
+				psp.insertLineMacro = false
+			case "stack_size":
+				psp.declargslot = &psp.gp.stacksize
+				psp.insertLineMacro = false
+			case "start_symbol":
+				psp.declargslot = &psp.gp.start
+				psp.insertLineMacro = false
+			case "left":
+				psp.preccounter++
+				psp.declassoc = LEFT
+				psp.state = WAITING_FOR_PRECEDENCE_SYMBOL
+			case "right":
+				psp.preccounter++
+				psp.declassoc = RIGHT
+				psp.state = WAITING_FOR_PRECEDENCE_SYMBOL
+			case "nonassoc":
+				psp.preccounter++
+				psp.declassoc = NONE
+				psp.state = WAITING_FOR_PRECEDENCE_SYMBOL
+			case "destructor":
+				psp.state = WAITING_FOR_DESTRUCTOR_SYMBOL
+			case "type":
+				psp.state = WAITING_FOR_DATATYPE_SYMBOL
+			case "fallback":
+				psp.fallback = nil
+				psp.state = WAITING_FOR_FALLBACK_ID
+			case "token":
+				psp.state = WAITING_FOR_TOKEN_NAME
+			case "wildcard":
+				psp.state = WAITING_FOR_WILDCARD_ID
+			case "token_class":
+				psp.state = WAITING_FOR_CLASS_ID
+			default:
+				ErrorMsg(psp.filename, psp.tokenlineno,
+					"Unknown declaration keyword: \"%%%s\".", x)
+				psp.errorcnt++
+				psp.state = RESYNC_AFTER_DECL_ERROR
+			}
+		} else {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"Illegal declaration keyword: \"%s\".", x)
+			psp.errorcnt++
+			psp.state = RESYNC_AFTER_DECL_ERROR
+		}
+	case WAITING_FOR_DESTRUCTOR_SYMBOL:
+		if !ISALPHA(x[0]) {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"Symbol name missing after %%destructor keyword")
+			psp.errorcnt++
+			psp.state = RESYNC_AFTER_DECL_ERROR
+		} else {
+			sp := Symbol_new(x)
+			psp.declargslot = &sp.destructor
+			psp.decllinenoslot = &sp.destLineno
+			psp.insertLineMacro = true
+			psp.state = WAITING_FOR_DECL_ARG
+		}
+	case WAITING_FOR_DATATYPE_SYMBOL:
+		if !ISALPHA(x[0]) {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"Symbol name missing after %%type keyword")
+			psp.errorcnt++
+			psp.state = RESYNC_AFTER_DECL_ERROR
+		} else {
+			sp := Symbol_find(x)
+			if sp != nil && sp.datatype != "" {
+				ErrorMsg(psp.filename, psp.tokenlineno,
+					"Symbol %%type \"%s\" already defined", x)
+				psp.errorcnt++
+				psp.state = RESYNC_AFTER_DECL_ERROR
+			} else {
+				if sp == nil {
+					sp = Symbol_new(x)
+				}
+				psp.declargslot = &sp.datatype
+				psp.insertLineMacro = false
+				psp.state = WAITING_FOR_DECL_ARG
+			}
+		}
+	case WAITING_FOR_PRECEDENCE_SYMBOL:
+		if x[0] == '.' {
+			psp.state = WAITING_FOR_DECL_OR_RULE
+		} else if ISUPPER(x[0]) {
+			sp := Symbol_new(x)
+			if sp.prec >= 0 {
+				ErrorMsg(psp.filename, psp.tokenlineno,
+					"Symbol \"%s\" has already be given a precedence.", x)
+				psp.errorcnt++
+			} else {
+				sp.prec = psp.preccounter
+				sp.assoc = psp.declassoc
+			}
+		} else {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"Can't assign a precedence to \"%s\".", x)
+			psp.errorcnt++
+		}
+	case WAITING_FOR_DECL_ARG:
+		if x[0] == '{' || x[0] == '"' || ISALNUM(x[0]) {
+			zNew := x
+			if zNew[0] == '"' || zNew[0] == '{' {
+				zNew = zNew[1:]
+			}
+			zOld := *psp.declargslot
+			addLineMacro := !psp.gp.nolinenosflag &&
+				psp.insertLineMacro &&
+				psp.tokenlineno > 1 &&
+				(psp.decllinenoslot == nil || *psp.decllinenoslot != 0)
+			var zBuf strings.Builder
+			zBuf.WriteString(zOld)
+			if addLineMacro {
+				if zOld != "" && zOld[len(zOld)-1] != '\n' {
+					zBuf.WriteByte('\n')
+				}
+				tplt_linedir(&zBuf, psp.tokenlineno, psp.filename)
+			}
+			if psp.decllinenoslot != nil && *psp.decllinenoslot == 0 {
+				*psp.decllinenoslot = psp.tokenlineno
+			}
+			zBuf.WriteString(zNew)
+			*psp.declargslot = zBuf.String()
+			psp.state = WAITING_FOR_DECL_OR_RULE
+		} else {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"Illegal argument to %%%s: %s", psp.declkeyword, x)
+			psp.errorcnt++
+			psp.state = RESYNC_AFTER_DECL_ERROR
+		}
+	case WAITING_FOR_FALLBACK_ID:
+		if x[0] == '.' {
+			psp.state = WAITING_FOR_DECL_OR_RULE
+		} else if !ISUPPER(x[0]) {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"%%fallback argument \"%s\" should be a token", x)
+			psp.errorcnt++
+		} else {
+			sp := Symbol_new(x)
+			if psp.fallback == nil {
+				psp.fallback = sp
+			} else if sp.fallback != nil {
+				ErrorMsg(psp.filename, psp.tokenlineno,
+					"More than one fallback assigned to token %s", x)
+				psp.errorcnt++
+			} else {
+				sp.fallback = psp.fallback
+				psp.gp.has_fallback = true
+			}
+		}
+	case WAITING_FOR_TOKEN_NAME:
+		/* Tokens do not have to be declared before use.  But they can be
+		** in order to control their assigned integer number.  The number for
+		** each token is assigned when it is first seen.  So by including
+		**
+		**     %token ONE TWO THREE.
+		**
+		** early in the grammar file, that assigns small consecutive values
+		** to each of the tokens ONE TWO and THREE.
+		 */
+		if x[0] == '.' {
+			psp.state = WAITING_FOR_DECL_OR_RULE
+		} else if !ISUPPER(x[0]) {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"%%token argument \"%s\" should be a token", x)
+			psp.errorcnt++
+		} else {
+			Symbol_new(x)
+		}
+	case WAITING_FOR_WILDCARD_ID:
+		if x[0] == '.' {
+			psp.state = WAITING_FOR_DECL_OR_RULE
+		} else if !ISUPPER(x[0]) {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"%%wildcard argument \"%s\" should be a token", x)
+			psp.errorcnt++
+		} else {
+			sp := Symbol_new(x)
+			if psp.gp.wildcard == nil {
+				psp.gp.wildcard = sp
+			} else {
+				ErrorMsg(psp.filename, psp.tokenlineno,
+					"Extra wildcard to token: %s", x)
+				psp.errorcnt++
+			}
+		}
+	case WAITING_FOR_CLASS_ID:
+		if !ISLOWER(x[0]) {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"%%token_class must be followed by an identifier: %s", x)
+			psp.errorcnt++
+			psp.state = RESYNC_AFTER_DECL_ERROR
+		} else if Symbol_find(x) != nil {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"Symbol \"%s\" already used", x)
+			psp.errorcnt++
+			psp.state = RESYNC_AFTER_DECL_ERROR
+		} else {
+			psp.tkclass = Symbol_new(x)
+			psp.tkclass.typ = MULTITERMINAL
+			psp.state = WAITING_FOR_CLASS_TOKEN
+		}
+	case WAITING_FOR_CLASS_TOKEN:
+		if x[0] == '.' {
+			psp.state = WAITING_FOR_DECL_OR_RULE
+		} else if ISUPPER(x[0]) || ((x[0] == '|' || x[0] == '/') && len(x) > 1 && ISUPPER(x[1])) {
+			msp := psp.tkclass
+			if !ISUPPER(x[0]) {
+				x = x[1:]
+			}
+			msp.subsym = append(msp.subsym, Symbol_new(x))
+		} else {
+			ErrorMsg(psp.filename, psp.tokenlineno,
+				"%%token_class argument \"%s\" should be a token", x)
+			psp.errorcnt++
+			psp.state = RESYNC_AFTER_DECL_ERROR
+		}
+	case RESYNC_AFTER_RULE_ERROR, RESYNC_AFTER_DECL_ERROR:
+		if x[0] == '.' {
+			psp.state = WAITING_FOR_DECL_OR_RULE
+		}
+		if x[0] == '%' {
+			psp.state = WAITING_FOR_DECL_KEYWORD
+		}
+	}
+}
+
+/* The text in the input is part of the argument to an %ifdef or %ifndef.
+** Evaluate the text as a boolean expression.  Return true or false.
+ */
+func eval_preprocessor_boolean(z []byte, lineno int) int {
+	neg := false
+	res := 0
+	okTerm := true
+	var i int
+	for i = 0; i < len(z); i++ {
+		if ISSPACE(z[i]) {
+			continue
+		}
+		if z[i] == '!' {
+			if !okTerm {
+				goto pp_syntax_error
+			}
+			neg = !neg
+			continue
+		}
+		if z[i] == '|' && i+1 < len(z) && z[i+1] == '|' {
+			if okTerm {
+				goto pp_syntax_error
+			}
+			if res != 0 {
+				return 1
+			}
+			i++
+			okTerm = true
+			continue
+		}
+		if z[i] == '&' && i+1 < len(z) && z[i+1] == '&' {
+			if okTerm {
+				goto pp_syntax_error
+			}
+			if res == 0 {
+				return 0
+			}
+			i++
+			okTerm = true
+			continue
+		}
+		if z[i] == '(' {
+			n := 1
+			if !okTerm {
+				goto pp_syntax_error
+			}
+			k := i + 1
+			for ; k < len(z); k++ {
+				if z[k] == ')' {
+					n--
+					if n == 0 {
+						res = eval_preprocessor_boolean(z[i+1:k], -1)
+						if res < 0 {
+							i = i - res
+							goto pp_syntax_error
+						}
+						i = k
+						break
+					}
+				} else if z[k] == '(' {
+					n++
+				}
+			}
+			if k == len(z) {
+				i = k
+				goto pp_syntax_error
+			}
+			if neg {
+				res = 1 - res
+				neg = false
+			}
+			okTerm = false
+			continue
+		}
+		if ISALPHA(z[i]) {
+			if !okTerm {
+				goto pp_syntax_error
+			}
+			k := i + 1
+			for k < len(z) && (ISALNUM(z[k]) || z[k] == '_') {
+				k++
+			}
+			res = 0
+			for j := range azDefine {
+				if azDefine[j] == string(z[i:k]) {
+					if !bDefineUsed[j] {
+						bDefineUsed[j] = true
+						nDefineUsed++
+					}
+					res = 1
+					break
+				}
+			}
+			i = k - 1
+			if neg {
+				res = 1 - res
+				neg = false
+			}
+			okTerm = false
+			continue
+		}
+		goto pp_syntax_error
+	}
+	return res
+
+pp_syntax_error:
+	if lineno > 0 {
+		fmt.Fprintf(os.Stderr, "%%if syntax error on line %d.\n", lineno)
+		if i >= len(z) {
+			i = len(z) - 1
+		}
+		fmt.Fprintf(os.Stderr, "  %s <-- syntax error here\n", z[:i+1])
+		os.Exit(1)
+	}
+	return -(i + 1)
+}
+
+/* Run the preprocessor over the input file text.  The global variables
+** azDefine[0] through azDefine[nDefine-1] contains the names of all defined
+** macros.  This routine looks for "%ifdef" and "%ifndef" and "%endif" and
+** comments them out.  Text in between is also commented out as appropriate.
+ */
+func preprocess_input(z []byte) {
+	exclude := 0
+	start := 0
+	lineno := 1
+	start_lineno := 1
+	blank := func(from int) {
+		for j := from; j < len(z) && z[j] != '\n'; j++ {
+			z[j] = ' '
+		}
+	}
+	for i := 0; i < len(z); i++ {
+		if z[i] == '\n' {
+			lineno++
+		}
+		if z[i] != '%' || (i > 0 && z[i-1] != '\n') {
+			continue
+		}
+		rest := z[i:]
+		if hasDirective(rest, "%endif") {
+			if exclude != 0 {
+				exclude--
+				if exclude == 0 {
+					for j := start; j < i; j++ {
+						if z[j] != '\n' {
+							z[j] = ' '
+						}
+					}
+				}
+			}
+			blank(i)
+		} else if hasDirective(rest, "%else") {
+			if exclude == 1 {
+				exclude = 0
+				for j := start; j < i; j++ {
+					if z[j] != '\n' {
+						z[j] = ' '
+					}
+				}
+			} else if exclude == 0 {
+				exclude = 1
+				start = i
+				start_lineno = lineno
+			}
+			blank(i)
+		} else if strings.HasPrefix(string(rest), "%ifdef ") ||
+			strings.HasPrefix(string(rest), "%if ") ||
+			strings.HasPrefix(string(rest), "%ifndef ") {
+			if exclude != 0 {
+				exclude++
+			} else {
+				j := i
+				for j < len(z) && !ISSPACE(z[j]) {
+					j++
+				}
+				iBool := j
+				isNot := j == i+7
+				for j < len(z) && z[j] != '\n' {
+					j++
+				}
+				exclude = eval_preprocessor_boolean(z[iBool:j], lineno)
+				if !isNot {
+					exclude = 1 - exclude
+				}
+				if exclude != 0 {
+					start = i
+					start_lineno = lineno
+				}
+			}
+			blank(i)
+		}
+	}
+	if exclude != 0 {
+		fmt.Fprintf(os.Stderr, "unterminated %%if starting on line %d\n", start_lineno)
+		os.Exit(1)
+	}
+}
+
+/* Return true if z begins with the directive zDir followed by a space. */
+func hasDirective(z []byte, zDir string) bool {
+	n := len(zDir)
+	return len(z) > n && string(z[:n]) == zDir && ISSPACE(z[n])
+}
+
+/* In spite of its name, this function is really a scanner.  It read
+** in the entire input file (all at once) then tokenizes it.  Each
+** token is passed to the function "parseonetoken" which builds all
+** the appropriate data structures in the global state vector "gp".
+ */
+func Parse(gp *lemon) {
+	var ps pstate
+	var nextcp int
+
+	ps.gp = gp
+	ps.filename = gp.filename
+	ps.errorcnt = 0
+	ps.state = INITIALIZE
+
+	/* Begin by reading the input file */
+	filebuf, err := os.ReadFile(ps.filename)
+	if err != nil {
+		ErrorMsg(ps.filename, 0, "Can't open this file for reading.")
+		gp.errorcnt++
+		return
+	}
+
+	/* Make an initial pass through the file to handle %ifdef and %ifndef */
+	preprocess_input(filebuf)
+
+	/* Now scan the text of the input file */
+	at := func(i int) byte {
+		if i < len(filebuf) {
+			return filebuf[i]
+		}
+		return 0
+	}
+	lineno := 1
+	for cp := 0; cp < len(filebuf); {
+		c := filebuf[cp]
+		if c == '\n' {
+			lineno++ /* Keep track of the line number */
+		}
+		if ISSPACE(c) {
+			cp++
+			continue
+		} /* Skip all white space */
+		if c == '/' && at(cp+1) == '/' { /* Skip C++ style comments */
+			cp += 2
+			for cp < len(filebuf) && filebuf[cp] != '\n' {
+				cp++
+			}
+			continue
+		}
+		if c == '/' && at(cp+1) == '*' { /* Skip C style comments */
+			cp += 2
+			if at(cp) == '/' {
+				cp++
+			}
+			for cp < len(filebuf) && (filebuf[cp] != '/' || filebuf[cp-1] != '*') {
+				if filebuf[cp] == '\n' {
+					lineno++
+				}
+				cp++
+			}
+			if cp < len(filebuf) {
+				cp++
+			}
+			continue
+		}
+		tokenstart := cp        /* Mark the beginning of the token */
+		ps.tokenlineno = lineno /* Linenumber on which token begins */
+		if c == '"' {           /* String literals */
+			cp++
+			for cp < len(filebuf) && filebuf[cp] != '"' {
+				if filebuf[cp] == '\n' {
+					lineno++
+				}
+				cp++
+			}
+			if cp == len(filebuf) {
+				ErrorMsg(ps.filename, ps.tokenlineno,
+					"String starting on this line is not terminated before "+
+						"the end of the file.")
+				ps.errorcnt++
+				nextcp = cp
+			} else {
+				nextcp = cp + 1
+			}
+		} else if c == '{' { /* A block of C code */
+			cp++
+			level := 1
+			for ; cp < len(filebuf) && (level > 1 || filebuf[cp] != '}'); cp++ {
+				c = filebuf[cp]
+				if c == '\n' {
+					lineno++
+				} else if c == '{' {
+					level++
+				} else if c == '}' {
+					level--
+				} else if c == '/' && at(cp+1) == '*' { /* Skip comments */
+					var prevc byte
+					cp += 2
+					for cp < len(filebuf) && (filebuf[cp] != '/' || prevc != '*') {
+						if filebuf[cp] == '\n' {
+							lineno++
+						}
+						prevc = filebuf[cp]
+						cp++
+					}
+					if cp == len(filebuf) {
+						break
+					}
+				} else if c == '/' && at(cp+1) == '/' { /* Skip C++ style comments too */
+					cp += 2
+					for cp < len(filebuf) && filebuf[cp] != '\n' {
+						cp++
+					}
+					if cp == len(filebuf) {
+						break
+					}
+					lineno++
+				} else if c == '\'' || c == '"' || c == '`' { /* String a character literals */
+					startchar := c
+					var prevc byte
+					for cp++; cp < len(filebuf) && (filebuf[cp] != startchar || prevc == '\\'); cp++ {
+						if filebuf[cp] == '\n' {
+							lineno++
+						}
+						if prevc == '\\' || startchar == '`' {
+							prevc = 0
+						} else {
+							prevc = filebuf[cp]
+						}
+					}
+					if cp == len(filebuf) {
+						break
+					}
+				}
+			}
+			if cp >= len(filebuf) {
+				ErrorMsg(ps.filename, ps.tokenlineno,
+					"C code starting on this line is not terminated before "+
+						"the end of the file.")
+				ps.errorcnt++
+				cp = len(filebuf)
+				nextcp = cp
+			} else {
+				nextcp = cp + 1
+			}
+		} else if ISALNUM(c) { /* Identifiers */
+			for cp < len(filebuf) && (ISALNUM(filebuf[cp]) || filebuf[cp] == '_') {
+				cp++
+			}
+			nextcp = cp
+		} else if c == ':' && at(cp+1) == ':' && at(cp+2) == '=' { /* The operator "::=" */
+			cp += 3
+			nextcp = cp
+		} else if (c == '/' || c == '|') && ISALPHA(at(cp+1)) {
+			cp += 2
+			for cp < len(filebuf) && (ISALNUM(filebuf[cp]) || filebuf[cp] == '_') {
+				cp++
+			}
+			nextcp = cp
+		} else { /* All other (one character) operators */
+			cp++
+			nextcp = cp
+		}
+		ps.token = string(filebuf[tokenstart:cp])
+		parseonetoken(&ps) /* Parse the token */
+		cp = nextcp
+	}
+	gp.rule = ps.firstrule
+	gp.errorcnt = ps.errorcnt
+}
+
+/*
+** Print the grammar with the -g option, as lemon's Reprint() does.
+ */
+func Reprint(lemp *lemon) {
+	fmt.Printf("// Reprint of input file \"%s\".\n// Symbols:\n", lemp.filename)
+	maxlen := 10
+	for i := 0; i < lemp.nsymbol; i++ {
+		sp := lemp.symbols[i]
+		if len(sp.name) > maxlen {
+			maxlen = len(sp.name)
+		}
+	}
+	ncolumns := 76 / (maxlen + 5)
+	if ncolumns < 1 {
+		ncolumns = 1
+	}
+	skip := (lemp.nsymbol + ncolumns - 1) / ncolumns
+	for i := 0; i < skip; i++ {
+		fmt.Printf("//")
+		for j := i; j < lemp.nsymbol; j += skip {
+			sp := lemp.symbols[j]
+			assert(sp.index == j)
+			fmt.Printf(" %3d %-*.*s", j, maxlen, maxlen, sp.name)
+		}
+		fmt.Printf("\n")
+	}
+	for rp := lemp.rule; rp != nil; rp = rp.next {
+		fmt.Printf("%s", ruleText(rp))
+		fmt.Printf(".")
+		if rp.precsym != nil {
+			fmt.Printf(" [%s]", rp.precsym.name)
+		}
+		fmt.Printf("\n")
+	}
+}
+
+/*
+** Return the text of a rule: "lhs ::= rhs1 rhs2|rhs3".
+ */
+func ruleText(rp *rule) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s ::=", rp.lhs.name)
+	for _, sp := range rp.rhs {
+		if sp.typ != MULTITERMINAL {
+			fmt.Fprintf(&b, " %s", sp.name)
+		} else {
+			fmt.Fprintf(&b, " %s", sp.subsym[0].name)
+			for k := 1; k < len(sp.subsym); k++ {
+				fmt.Fprintf(&b, "|%s", sp.subsym[k].name)
+			}
+		}
+	}
+	return b.String()
+}
+
+/*
+** The output file.  lemon counts the lines it writes by hand so that it
+** can emit "#line" directives; here every write counts its own newlines.
+ */
+type outfile struct {
+	strings.Builder
+	lineno int
+}
+
+func (out *outfile) printf(format string, a ...interface{}) {
+	s := fmt.Sprintf(format, a...)
+	out.WriteString(s)
+	out.lineno += strings.Count(s, "\n")
+}
+
+/* The text of the driver template and the read position within it. */
+type template struct {
+	lines []string
+	i     int
+}
+
+/* Given an action, compute the integer value for that action
+** which is to be put in the action table of the generated machine.
+** Return negative if no action should be generated.
+ */
+func compute_action(lemp *lemon, ap *action) int {
+	var act int
+	switch ap.typ {
+	case SHIFT:
+		act = ap.stp.statenum
+	case SHIFTREDUCE:
+		/* Since a SHIFT is inherient after a prior REDUCE, convert any
+		** SHIFTREDUCE action with a nonterminal on the LHS into a simple
+		** REDUCE action: */
+		if ap.sp.index >= lemp.nterminal &&
+			(lemp.errsym == nil || ap.sp.index != lemp.errsym.index) {
+			act = lemp.minReduce + ap.rp.iRule
+		} else {
+			act = lemp.minShiftReduce + ap.rp.iRule
+		}
+	case REDUCE:
+		act = lemp.minReduce + ap.rp.iRule
+	case ERROR:
+		act = lemp.errAction
+	case ACCEPT:
+		act = lemp.accAction
+	default:
+		act = -1
+	}
+	return act
+}
+
+/* Replace the %ARG_ and %CTX_ markers of a template line with the code
+** for the %extra_argument and %extra_context declarations. */
+func tplt_macros(lemp *lemon, line string) string {
+	if !strings.Contains(line, "%ARG_") && !strings.Contains(line, "%CTX_") {
+		return line
+	}
+	for _, m := range []struct {
+		prefix string
+		decl   string
+	}{{"%ARG_", lemp.arg}, {"%CTX_", lemp.ctx}} {
+		var sdecl, pdecl, param, fetch, store string
+		decl := strings.TrimSpace(m.decl)
+		if decl != "" {
+			/* In Go the name comes first: "pParse *Parse" */
+			name := decl
+			if i := strings.IndexFunc(decl, func(r rune) bool {
+				return !(r < 0x80 && (ISALNUM(byte(r)) || r == '_'))
+			}); i >= 0 {
+				name = decl[:i]
+			}
+			sdecl = decl
+			fetch = fmt.Sprintf("%s := yypParser.%s; _ = %s", name, name, name)
+			store = fmt.Sprintf("yypParser.%s=%s", name, name)
+			if m.prefix == "%ARG_" {
+				pdecl = decl + ","
+				param = "," + name
+			} else {
+				pdecl = decl
+				param = name
+			}
+		}
+		line = strings.NewReplacer(
+			m.prefix+"SDECL", sdecl,
+			m.prefix+"PDECL", pdecl,
+			m.prefix+"PARAM", param,
+			m.prefix+"FETCH", fetch,
+			m.prefix+"STORE", store,
+		).Replace(line)
+	}
+	return line
+}
+
+/* The next function finds the template file and opens it, returning
+** the template.  Return nil if the file named with -T can not be read.
+ */
+func tplt_open(lemp *lemon) *template {
+	text := lempar
+	if user_templatename != "" {
+		b, err := os.ReadFile(user_templatename)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Can't open the template file \"%s\".\n",
+				user_templatename)
+			lemp.errorcnt++
+			return nil
+		}
+		text = string(b)
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return &template{lines: lines}
+}
+
+/* Transfer data from "in" to "out" until a line is seen which begins
+** with "%%".  The line number is tracked.
+**
+** if name!=0, then any word that begin with "Parse" is changed to
+** begin with *name instead.
+ */
+func tplt_xfer(lemp *lemon, in *template, out *outfile) {
+	name := lemp.name
+	for in.i < len(in.lines) {
+		line := in.lines[in.i]
+		in.i++
+		if strings.HasPrefix(line, "%%") {
+			break
+		}
+		line = tplt_macros(lemp, line)
+		iStart := 0
+		if name != "" {
+			for i := 0; i < len(line); i++ {
+				if line[i] == 'P' && strings.HasPrefix(line[i:], "Parse") &&
+					(i == 0 || !ISALPHA(line[i-1])) {
+					if i > iStart {
+						out.printf("%s", line[iStart:i])
+					}
+					out.printf("%s", name)
+					i += 4
+					iStart = i + 1
+				}
+			}
+		}
+		out.printf("%s", line[iStart:])
+	}
+}
+
+/* Skip forward past the header of the template file to the first "%%".
+** Unlike lemon, the skipped lines are not counted, since they never
+** reach the output file.
+ */
+func tplt_skip_header(in *template) {
+	for in.i < len(in.lines) {
+		line := in.lines[in.i]
+		in.i++
+		if strings.HasPrefix(line, "%%") {
+			break
+		}
+	}
+}
+
+/* Print a //line directive line to the output file. */
+func tplt_linedir(out interface{ WriteString(string) (int, error) }, lineno int, filename string) {
+	out.WriteString(fmt.Sprintf("//line %d \"%s\"\n", lineno, filename))
+}
+
+/* Print a string to the file and keep the linenumber up to date */
+func tplt_print(out *outfile, lemp *lemon, str string) {
+	if str == "" {
+		return
+	}
+	out.printf("%s", str)
+	if str[len(str)-1] != '\n' {
+		out.printf("\n")
+	}
+	if !lemp.nolinenosflag {
+		out.lineno++
+		tplt_linedir(out, out.lineno, lemp.outname)
+	}
+}
+
+/*
+** The following routine emits code for the destructor for the
+** symbol sp
+ */
+func emit_destructor_code(out *outfile, sp *symbol, lemp *lemon) {
+	var cp string
+
+	if sp.typ == TERMINAL {
+		cp = lemp.tokendest
+		if cp == "" {
+			return
+		}
+		out.printf("{\n")
+	} else if sp.destructor != "" {
+		cp = sp.destructor
+		out.printf("{\n")
+		if !lemp.nolinenosflag {
+			out.lineno++
+			tplt_linedir(out, sp.destLineno, lemp.filename)
+		}
+	} else if lemp.vardest != "" {
+		cp = lemp.vardest
+		out.printf("{\n")
+	} else {
+		assert(false) /* Cannot happen */
+	}
+	out.printf("%s", strings.ReplaceAll(cp, "$$", fmt.Sprintf("(yypminor.yy%d)", sp.dtnum)))
+	out.printf("\n")
+	if !lemp.nolinenosflag {
+		out.lineno++
+		tplt_linedir(out, out.lineno, lemp.outname)
+	}
+	out.printf("}\n")
+}
+
+/*
+** Return TRUE (non-zero) if the given symbol has a destructor.
+ */
+func has_destructor(sp *symbol, lemp *lemon) bool {
+	if sp.typ == TERMINAL {
+		return lemp.tokendest != ""
+	}
+	return lemp.vardest != "" || sp.destructor != ""
+}
+
+/*
+** Append text to a dynamically allocated string.  If zText is 0 then
+** reset the string to be empty again.  Always return the complete text
+** of the string (which is overwritten with each call).
+**
+** n bytes of zText are stored.  If n==0 then all of zText up to the first
+** \000 terminator is stored.  zText can contain up to two instances of
+** %d.  The values of p1 and p2 are written into the first and second
+** %d.
+**
+** If n==-1, then the previous character is overwritten.
+ */
+type appendBuf struct {
+	z []byte
+}
+
+func (a *appendBuf) reset() string {
+	s := string(a.z)
+	a.z = a.z[:0]
+	return s
+}
+
+func (a *appendBuf) append(zText string, n int, p1 int, p2 int) {
+	if n <= 0 {
+		if n < 0 {
+			a.z = a.z[:len(a.z)+n]
+		}
+		n = len(zText)
+	}
+	for i := 0; i < n; i++ {
+		c := zText[i]
+		if c == '%' && i+1 < n && zText[i+1] == 'd' {
+			a.z = append(a.z, fmt.Sprintf("%d", p1)...)
+			p1 = p2
+			i++
+		} else {
+			a.z = append(a.z, c)
+		}
+	}
+}
+
+/*
+** Write and transform the rp->code string so that symbols are expanded.
+** Populate the rp->codePrefix and rp->codeSuffix strings, as appropriate.
+**
+** Return 1 if the expanded code requires that "yylhsminor" local variable
+** to be defined.
+ */
+func translate_code(lemp *lemon, rp *rule) int {
+	var buf appendBuf
+	rc := 0
+	dontUseRhs0 := false /* If true, use of left-most RHS label is illegal */
+	zSkip := -1          /* The zOvwrt comment within rp->code, or -1 */
+	lhsused := false     /* True if the LHS element has been used */
+	var lhsdirect bool   /* True if LHS writes directly into stack */
+	nrhs := len(rp.rhs)
+	used := make([]bool, nrhs) /* True for each RHS element which is used */
+	var zLhs string            /* Convert the LHS symbol into this string */
+	var zOvwrt string          /* Comment that to allow LHS to overwrite RHS */
+
+	if rp.noCode {
+		rp.code = "\n"
+		rp.line = rp.ruleline
+		rp.noCode = true
+	} else {
+		rp.noCode = false
+	}
+
+	if nrhs == 0 {
+		/* If there are no RHS symbols, then writing directly to the LHS is ok */
+		lhsdirect = true
+	} else if rp.rhsalias[0] == "" {
+		/* The left-most RHS symbol has no value.  LHS direct is ok.  But
+		** we have to call the destructor on the RHS symbol first. */
+		lhsdirect = true
+		if has_destructor(rp.rhs[0], lemp) {
+			buf.append("  yypParser.yy_destructor(%d,&yypParser.yystack[yypParser.yytos+ %d].minor);\n", 0,
+				rp.rhs[0].index, 1-nrhs)
+			rp.codePrefix = buf.reset()
+			rp.noCode = false
+		}
+	} else if rp.lhsalias == "" {
+		/* There is no LHS value symbol. */
+		lhsdirect = true
+	} else if rp.lhsalias == rp.rhsalias[0] {
+		/* The LHS symbol and the left-most RHS symbol are the same, so
+		** direct writing is allowed */
+		lhsdirect = true
+		lhsused = true
+		used[0] = true
+		if rp.lhs.dtnum != rp.rhs[0].dtnum {
+			ErrorMsg(lemp.filename, rp.ruleline,
+				"%s(%s) and %s(%s) share the same label but have "+
+					"different datatypes.",
+				rp.lhs.name, rp.lhsalias, rp.rhs[0].name, rp.rhsalias[0])
+			lemp.errorcnt++
+		}
+	} else {
+		zOvwrt = fmt.Sprintf("/*%s-overwrites-%s*/", rp.lhsalias, rp.rhsalias[0])
+		zSkip = strings.Index(rp.code, zOvwrt)
+		/* The code contains a special comment that indicates that it is safe
+		** for the LHS label to overwrite left-most RHS label. */
+		lhsdirect = zSkip >= 0
+	}
+	if lhsdirect {
+		zLhs = fmt.Sprintf("yypParser.yystack[yypParser.yytos+ %d].minor.yy%d", 1-nrhs, rp.lhs.dtnum)
+	} else {
+		rc = 1
+		zLhs = fmt.Sprintf("yylhsminor.yy%d", rp.lhs.dtnum)
+	}
+
+	code := rp.code
+	for cp := 0; cp < len(code); cp++ {
+		if cp == zSkip {
+			buf.append(zOvwrt, 0, 0, 0)
+			cp += len(zOvwrt) - 1
+			dontUseRhs0 = true
+			continue
+		}
+		if ISALPHA(code[cp]) && (cp == 0 || (!ISALNUM(code[cp-1]) && code[cp-1] != '_')) {
+			xp := cp + 1
+			for xp < len(code) && (ISALNUM(code[xp]) || code[xp] == '_') {
+				xp++
+			}
+			word := code[cp:xp]
+			if rp.lhsalias != "" && word == rp.lhsalias {
+				buf.append(zLhs, 0, 0, 0)
+				cp = xp
+				lhsused = true
+			} else {
+				for i := 0; i < nrhs; i++ {
+					if rp.rhsalias[i] != "" && word == rp.rhsalias[i] {
+						if i == 0 && dontUseRhs0 {
+							ErrorMsg(lemp.filename, rp.ruleline,
+								"Label %s used after '%s'.",
+								rp.rhsalias[0], zOvwrt)
+							lemp.errorcnt++
+						} else if cp != 0 && code[cp-1] == '@' {
+							/* If the argument is of the form @X then substituted
+							** the token number of X, not the value of X */
+							buf.append("yypParser.yystack[yypParser.yytos+ %d].major", -1, i-nrhs+1, 0)
+						} else {
+							sp := rp.rhs[i]
+							var dtnum int
+							if sp.typ == MULTITERMINAL {
+								dtnum = sp.subsym[0].dtnum
+							} else {
+								dtnum = sp.dtnum
+							}
+							buf.append("yypParser.yystack[yypParser.yytos+ %d].minor.yy%d", 0, i-nrhs+1, dtnum)
+						}
+						cp = xp
+						used[i] = true
+						break
+					}
+				}
+			}
+		}
+		if cp < len(code) {
+			buf.append(code[cp:cp+1], 1, 0, 0)
+		}
+	} /* End loop */
+
+	/* Main code generation completed */
+	if cp := buf.reset(); cp != "" {
+		rp.code = cp
+	}
+
+	/* Check to make sure the LHS has been used */
+	if rp.lhsalias != "" && !lhsused {
+		ErrorMsg(lemp.filename, rp.ruleline,
+			"Label \"%s\" for \"%s(%s)\" is never used.",
+			rp.lhsalias, rp.lhs.name, rp.lhsalias)
+		lemp.errorcnt++
+	}
+
+	/* Generate destructor code for RHS minor values which are not referenced.
+	** Generate error messages for unused labels and duplicate labels.
+	 */
+	for i := 0; i < nrhs; i++ {
+		if rp.rhsalias[i] != "" {
+			if i > 0 {
+				if rp.lhsalias != "" && rp.lhsalias == rp.rhsalias[i] {
+					ErrorMsg(lemp.filename, rp.ruleline,
+						"%s(%s) has the same label as the LHS but is not the left-most "+
+							"symbol on the RHS.",
+						rp.rhs[i].name, rp.rhsalias[i])
+					lemp.errorcnt++
+				}
+				for j := 0; j < i; j++ {
+					if rp.rhsalias[j] != "" && rp.rhsalias[j] == rp.rhsalias[i] {
+						ErrorMsg(lemp.filename, rp.ruleline,
+							"Label %s used for multiple symbols on the RHS of a rule.",
+							rp.rhsalias[i])
+						lemp.errorcnt++
+						break
+					}
+				}
+			}
+			if !used[i] {
+				ErrorMsg(lemp.filename, rp.ruleline,
+					"Label %s for \"%s(%s)\" is never used.",
+					rp.rhsalias[i], rp.rhs[i].name, rp.rhsalias[i])
+				lemp.errorcnt++
+			}
+		} else if i > 0 && has_destructor(rp.rhs[i], lemp) {
+			buf.append("  yypParser.yy_destructor(%d,&yypParser.yystack[yypParser.yytos+ %d].minor);\n", 0,
+				rp.rhs[i].index, i-nrhs+1)
+		}
+	}
+
+	/* If unable to write LHS values directly into the stack, write the
+	** saved LHS value now. */
+	if !lhsdirect {
+		buf.append("  yypParser.yystack[yypParser.yytos+ %d].minor.yy%d = ", 0, 1-nrhs, rp.lhs.dtnum)
+		buf.append(zLhs, 0, 0, 0)
+		buf.append(";\n", 0, 0, 0)
+	}
+
+	/* Suffix code generation complete */
+	if cp := buf.reset(); cp != "" {
+		rp.codeSuffix = cp
+		rp.noCode = false
+	}
+
+	return rc
+}
+
+/*
+** Generate code which executes when the rule "rp" is reduced.  Write
+** the code to "out".  Make sure lineno stays up-to-date.
+ */
+func emit_code(out *outfile, rp *rule, lemp *lemon) {
+	/* Setup code prior to the #line directive */
+	if rp.codePrefix != "" {
+		out.printf("{%s", rp.codePrefix)
+	}
+
+	/* Generate code to do the reduce action */
+	if rp.code != "" {
+		if !lemp.nolinenosflag {
+			out.lineno++
+			tplt_linedir(out, rp.line, lemp.filename)
+		}
+		out.printf("{%s", rp.code)
+		out.printf("}\n")
+		if !lemp.nolinenosflag {
+			out.lineno++
+			tplt_linedir(out, out.lineno, lemp.outname)
+		}
+	}
+
+	/* Generate breakdown code that occurs after the #line directive */
+	if rp.codeSuffix != "" {
+		out.printf("%s", rp.codeSuffix)
+	}
+
+	if rp.codePrefix != "" {
+		out.printf("}\n")
+	}
+}
+
+/*
+** Print the definition of the union used for the parser's data stack.
+** This union contains fields for every possible data type for tokens
+** and nonterminals.  In the process of computing and printing this
+** union, also set the ".dtnum" field of every terminal and nonterminal
+** symbol.
+ */
+func print_stack_union(out *outfile, lemp *lemon) {
+	/* Allocate and initialize types[] and allocate stddt[] */
+	arraysize := lemp.nsymbol * 2
+	types := make([]string, arraysize)
+
+	/* Build a hash table of datatypes. The ".dtnum" field of each symbol
+	** is filled in with the hash index plus 1.  A ".dtnum" value of 0 is
+	** used for terminal symbols.  If there is no %default_type defined then
+	** 0 is also used as the .dtnum value for nonterminals which do not specify
+	** a datatype using the %type directive.
+	 */
+	for i := 0; i < lemp.nsymbol; i++ {
+		sp := lemp.symbols[i]
+		if sp == lemp.errsym {
+			sp.dtnum = arraysize + 1
+			continue
+		}
+		if sp.typ != NONTERMINAL || (sp.datatype == "" && lemp.vartype == "") {
+			sp.dtnum = 0
+			continue
+		}
+		cp := sp.datatype
+		if cp == "" {
+			cp = lemp.vartype
+		}
+		stddt := strings.TrimRight(strings.TrimLeft(cp, " \t\n\v\f\r"), " \t\n\v\f\r")
+		if lemp.tokentype != "" && stddt == lemp.tokentype {
+			sp.dtnum = 0
+			continue
+		}
+		var hash uint32
+		for j := 0; j < len(stddt); j++ {
+			hash = hash*53 + uint32(stddt[j])
+		}
+		hash = (hash & 0x7fffffff) % uint32(arraysize)
+		for types[hash] != "" {
+			if types[hash] == stddt {
+				sp.dtnum = int(hash) + 1
+				break
+			}
+			hash++
+			if hash >= uint32(arraysize) {
+				hash = 0
+			}
+		}
+		if types[hash] == "" {
+			sp.dtnum = int(hash) + 1
+			types[hash] = stddt
+		}
+	}
+
+	/* Print out the definition of YYTOKENTYPE and YYMINORTYPE */
+	name := lemp.name
+	if name == "" {
+		name = "Parse"
+	}
+	tokentype := lemp.tokentype
+	if tokentype == "" {
+		tokentype = "interface{}"
+	}
+	out.printf("type %sTOKENTYPE = %s\n", name, tokentype)
+	out.printf("type YYMINORTYPE struct {\n")
+	out.printf("\tyyinit int\n")
+	out.printf("\tyy0    %sTOKENTYPE\n", name)
+	for i := 0; i < arraysize; i++ {
+		if types[i] == "" {
+			continue
+		}
+		out.printf("\tyy%d %s\n", i+1, types[i])
+	}
+	if lemp.errsym != nil && lemp.errsym.useCnt != 0 {
+		out.printf("\tyy%d int\n", lemp.errsym.dtnum)
+	}
+	out.printf("}\n")
+}
+
+/*
+** Return the name of a Go datatype able to represent values between
+** lwr and upr, inclusive.
+ */
+func minimum_size_type(lwr int, upr int) string {
+	if lwr >= 0 {
+		if upr <= 255 {
+			return "uint8"
+		} else if upr < 65535 {
+			return "uint16"
+		}
+		return "uint32"
+	} else if lwr >= -127 && upr <= 127 {
+		return "int8"
+	} else if lwr >= -32767 && upr < 32767 {
+		return "int16"
+	}
+	return "int32"
+}
+
+/*
+** Each state contains a set of token transaction and a set of
+** nonterminal transactions.  Each of these sets makes an instance
+** of the following structure.  An array of these structures is used
+** to order the creation of entries in the yy_action[] table.
+ */
+type axset struct {
+	stp     *state /* A pointer to a state */
+	isTkn   bool   /* True to use tokens.  False for non-terminals */
+	nAction int    /* Number of actions */
+	iOrder  int    /* Original order of action sets */
+}
+
+/*
+** Compare to axset structures for sorting purposes
+ */
+func axset_compare(p1 *axset, p2 *axset) int {
+	c := p2.nAction - p1.nAction
+	if c == 0 {
+		c = p1.iOrder - p2.iOrder
+	}
+	assert(c != 0 || p1 == p2)
+	return c
+}
+
+/*
+** Write text on "out" that describes the rule "rp".
+ */
+func writeRuleText(out *outfile, rp *rule) {
+	out.printf("%s", ruleText(rp))
+}
+
+/*
+** Write a table of integers, ten to a row, each row prefixed with the
+** index of its first entry.
+ */
+func writeRows(out *outfile, a []int) {
+	for i := 0; i < len(a); i += 10 {
+		out.printf("\t/* %d */", i)
+		for j := i; j < i+10 && j < len(a); j++ {
+			out.printf(" %d,", a[j])
+		}
+		out.printf("\n")
+	}
+}
+
+/* Generate Go source code for the parser */
+func ReportTable(lemp *lemon) {
+	var mnTknOfst, mxTknOfst, mnNtOfst, mxNtOfst int
+
+	lemp.minShiftReduce = lemp.nstate
+	lemp.errAction = lemp.minShiftReduce + lemp.nrule
+	lemp.accAction = lemp.errAction + 1
+	lemp.noAction = lemp.accAction + 1
+	lemp.minReduce = lemp.noAction + 1
+	lemp.maxAction = lemp.minReduce + lemp.nrule
+
+	in := tplt_open(lemp)
+	if in == nil {
+		return
+	}
+	lemp.outname = file_makename(lemp, ".go")
+	out := &outfile{lineno: 1}
+
+	/* Make the output file header */
+	out.printf("/* This file is automatically generated by Lemon from input grammar\n"+
+		"** source file \"%s\"", lemp.filename)
+	if nDefineUsed == 0 {
+		out.printf(". */\n")
+	} else {
+		out.printf(" with the following\n** preprocessor symbols defined:\n**\n")
+		for i := range azDefine {
+			if !bDefineUsed[i] {
+				continue
+			}
+			out.printf("**    -D%s\n", azDefine[i])
+		}
+		out.printf("*/\n")
+	}
+
+	/* The first %include directive begins with a C-language comment,
+	** then skip over the header comment of the template file
+	 */
+	include := lemp.include
+	for i := 0; i < len(include) && ISSPACE(include[i]); i++ {
+		if include[i] == '\n' {
+			include = include[i+1:]
+			i = -1
+		}
+	}
+	if strings.HasPrefix(include, "/") {
+		tplt_skip_header(in)
+	} else {
+		tplt_xfer(lemp, in, out)
+	}
+
+	/* Generate the include code, if any */
+	tplt_print(out, lemp, include)
+	tplt_xfer(lemp, in, out)
+
+	/* Generate constants for all tokens */
+	prefix := lemp.tokenprefix
+	out.printf("const (\n")
+	for i := 1; i < lemp.nterminal; i++ {
+		out.printf("\t%s%s = %d\n", prefix, lemp.symbols[i].name, i)
+	}
+	out.printf(")\n")
+	tplt_xfer(lemp, in, out)
+
+	/* Generate the defines */
+	out.printf("const YYNOCODE = %d\n\n", lemp.nsymbol)
+	out.printf("type YYCODETYPE = %s\n", minimum_size_type(0, lemp.nsymbol))
+	out.printf("type YYACTIONTYPE = %s\n", minimum_size_type(0, lemp.maxAction))
+	print_stack_union(out, lemp)
+	out.printf("\n")
+	if lemp.wildcard != nil {
+		out.printf("const YYWILDCARD = %d\n", lemp.wildcard.index)
+	} else {
+		out.printf("const YYWILDCARD = 0\n")
+	}
+	if lemp.stacksize != "" {
+		out.printf("const YYSTACKDEPTH = %s\n", lemp.stacksize)
+	} else {
+		out.printf("const YYSTACKDEPTH = 100\n")
+	}
+	out.printf("const YYNOERRORRECOVERY = false\n")
+	out.printf("const YYCOVERAGE = false\n")
+	out.printf("const YYTRACKMAXSTACKDEPTH = false\n")
+	out.printf("const NDEBUG = false\n")
+	if lemp.errsym != nil && lemp.errsym.useCnt != 0 {
+		out.printf("const YYERRORSYMBOL = %d\n", lemp.errsym.index)
+	} else {
+		out.printf("const YYERRORSYMBOL = 0\n")
+	}
+	out.printf("const YYFALLBACK = %v\n", lemp.has_fallback)
+
+	/* Compute the action table, but do not output it yet.  The action
+	** table must be computed before generating the YYNSTATE macro because
+	** we need to know how many states can be eliminated.
+	 */
+	ax := make([]axset, lemp.nxstate*2)
+	for i := 0; i < lemp.nxstate; i++ {
+		stp := lemp.sorted[i]
+		ax[i*2] = axset{stp: stp, isTkn: true, nAction: stp.nTknAct}
+		ax[i*2+1] = axset{stp: stp, isTkn: false, nAction: stp.nNtAct}
+	}
+	/* In an effort to minimize the action table size, use the heuristic
+	** of placing the largest action sets first */
+	for i := range ax {
+		ax[i].iOrder = i
+	}
+	sort.Slice(ax, func(i, j int) bool { return axset_compare(&ax[i], &ax[j]) < 0 })
+	pActtab := acttab_alloc(lemp.nsymbol, lemp.nterminal)
+	for i := 0; i < len(ax) && ax[i].nAction > 0; i++ {
+		stp := ax[i].stp
+		if ax[i].isTkn {
+			for ap := stp.ap; ap != nil; ap = ap.next {
+				if ap.sp.index >= lemp.nterminal {
+					continue
+				}
+				action := compute_action(lemp, ap)
+				if action < 0 {
+					continue
+				}
+				acttab_action(pActtab, ap.sp.index, action)
+			}
+			stp.iTknOfst = acttab_insert(pActtab, true)
+			if stp.iTknOfst < mnTknOfst {
+				mnTknOfst = stp.iTknOfst
+			}
+			if stp.iTknOfst > mxTknOfst {
+				mxTknOfst = stp.iTknOfst
+			}
+		} else {
+			for ap := stp.ap; ap != nil; ap = ap.next {
+				if ap.sp.index < lemp.nterminal {
+					continue
+				}
+				if ap.sp.index == lemp.nsymbol {
+					continue
+				}
+				action := compute_action(lemp, ap)
+				if action < 0 {
+					continue
+				}
+				acttab_action(pActtab, ap.sp.index, action)
+			}
+			stp.iNtOfst = acttab_insert(pActtab, false)
+			if stp.iNtOfst < mnNtOfst {
+				mnNtOfst = stp.iNtOfst
+			}
+			if stp.iNtOfst > mxNtOfst {
+				mxNtOfst = stp.iNtOfst
+			}
+		}
+	}
+
+	/* Mark rules that are actually used for reduce actions after all
+	** optimizations have been applied
+	 */
+	for rp := lemp.rule; rp != nil; rp = rp.next {
+		rp.doesReduce = false
+	}
+	for i := 0; i < lemp.nxstate; i++ {
+		for ap := lemp.sorted[i].ap; ap != nil; ap = ap.next {
+			if ap.typ == REDUCE || ap.typ == SHIFTREDUCE {
+				ap.rp.doesReduce = true
+			}
+		}
+	}
+
+	/* Finish rendering the constants now that the action table has
+	** been computed */
+	out.printf("const YYNSTATE = %d\n", lemp.nxstate)
+	out.printf("const YYNRULE = %d\n", lemp.nrule)
+	out.printf("const YYNRULE_WITH_ACTION = %d\n", lemp.nruleWithAction)
+	out.printf("const YYNTOKEN = %d\n", lemp.nterminal)
+	out.printf("const YY_MAX_SHIFT = %d\n", lemp.nxstate-1)
+	out.printf("const YY_MIN_SHIFTREDUCE = %d\n", lemp.minShiftReduce)
+	out.printf("const YY_MAX_SHIFTREDUCE = %d\n", lemp.minShiftReduce+lemp.nrule-1)
+	out.printf("const YY_ERROR_ACTION = %d\n", lemp.errAction)
+	out.printf("const YY_ACCEPT_ACTION = %d\n", lemp.accAction)
+	out.printf("const YY_NO_ACTION = %d\n", lemp.noAction)
+	out.printf("const YY_MIN_REDUCE = %d\n", lemp.minReduce)
+	out.printf("const YY_MAX_REDUCE = %d\n", lemp.minReduce+lemp.nrule-1)
+	tplt_xfer(lemp, in, out)
+
+	/* Now output the action table and its associates:
+	**
+	**  yy_action[]        A single table containing all actions.
+	**  yy_lookahead[]     A table containing the lookahead for each entry in
+	**                     yy_action.  Used to detect hash collisions.
+	**  yy_shift_ofst[]    For each state, the offset into yy_action for
+	**                     shifting terminals.
+	**  yy_reduce_ofst[]   For each state, the offset into yy_action for
+	**                     shifting non-terminals after a reduce.
+	**  yy_default[]       Default action for each state.
+	 */
+
+	/* Output the yy_action table */
+	n := acttab_action_size(pActtab)
+	lemp.nactiontab = n
+	out.printf("const YY_ACTTAB_COUNT = %d\n\n", n)
+	out.printf("var yy_action = []YYACTIONTYPE{\n")
+	a := make([]int, n)
+	for i := 0; i < n; i++ {
+		a[i] = pActtab.aAction[i].action
+		if a[i] < 0 {
+			a[i] = lemp.noAction
+		}
+	}
+	writeRows(out, a)
+	out.printf("}\n")
+
+	/* Output the yy_lookahead table */
+	n = pActtab.nAction
+	lemp.nlookaheadtab = n
+	out.printf("var yy_lookahead = []YYCODETYPE{\n")
+	a = a[:0]
+	for i := 0; i < n; i++ {
+		la := pActtab.aAction[i].lookahead
+		if la < 0 {
+			la = lemp.nsymbol
+		}
+		a = append(a, la)
+	}
+	/* Add extra entries to the end of the yy_lookahead[] table so that
+	** yy_shift_ofst[]+iToken will always be a valid index into the array,
+	** even for the largest possible value of yy_shift_ofst[] and iToken. */
+	nLookAhead := lemp.nterminal + lemp.nactiontab
+	for len(a) < nLookAhead {
+		a = append(a, lemp.nsymbol)
+	}
+	writeRows(out, a)
+	out.printf("}\n\n")
+
+	/* Output the yy_shift_ofst[] table */
+	n = lemp.nxstate
+	for n > 0 && lemp.sorted[n-1].iTknOfst == NO_OFFSET {
+		n--
+	}
+	out.printf("const YY_SHIFT_COUNT = %d\n", n-1)
+	out.printf("const YY_SHIFT_MIN = %d\n", mnTknOfst)
+	out.printf("const YY_SHIFT_MAX = %d\n\n", mxTknOfst)
+	out.printf("var yy_shift_ofst = []%s{\n",
+		minimum_size_type(mnTknOfst, lemp.nterminal+lemp.nactiontab))
+	a = a[:0]
+	for i := 0; i < n; i++ {
+		ofst := lemp.sorted[i].iTknOfst
+		if ofst == NO_OFFSET {
+			ofst = lemp.nactiontab
+		}
+		a = append(a, ofst)
+	}
+	writeRows(out, a)
+	out.printf("}\n\n")
+
+	/* Output the yy_reduce_ofst[] table */
+	n = lemp.nxstate
+	for n > 0 && lemp.sorted[n-1].iNtOfst == NO_OFFSET {
+		n--
+	}
+	out.printf("const YY_REDUCE_COUNT = %d\n", n-1)
+	out.printf("const YY_REDUCE_MIN = %d\n", mnNtOfst)
+	out.printf("const YY_REDUCE_MAX = %d\n\n", mxNtOfst)
+	out.printf("var yy_reduce_ofst = []%s{\n", minimum_size_type(mnNtOfst-1, mxNtOfst))
+	a = a[:0]
+	for i := 0; i < n; i++ {
+		ofst := lemp.sorted[i].iNtOfst
+		if ofst == NO_OFFSET {
+			ofst = mnNtOfst - 1
+		}
+		a = append(a, ofst)
+	}
+	writeRows(out, a)
+	out.printf("}\n")
+
+	/* Output the default action table */
+	out.printf("var yy_default = []YYACTIONTYPE{\n")
+	a = a[:0]
+	for i := 0; i < lemp.nxstate; i++ {
+		stp := lemp.sorted[i]
+		if stp.iDfltReduce < 0 {
+			a = append(a, lemp.errAction)
+		} else {
+			a = append(a, stp.iDfltReduce+lemp.minReduce)
+		}
+	}
+	writeRows(out, a)
+	out.printf("}\n")
+	tplt_xfer(lemp, in, out)
+
+	/* Generate the table of fallback tokens.
+	 */
+	if lemp.has_fallback {
+		/* 2019-08-28:  Generate fallback entries for every token to avoid
+		** having to do a range check on the index */
+		for i := 0; i < lemp.nterminal; i++ {
+			p := lemp.symbols[i]
+			if p.fallback == nil {
+				out.printf("\t0,  /* %10s => nothing */\n", p.name)
+			} else {
+				out.printf("\t%d,  /* %10s => %s */\n", p.fallback.index,
+					p.name, p.fallback.name)
+			}
+		}
+	}
+	tplt_xfer(lemp, in, out)
+
+	/* Generate a table containing the symbolic name of every symbol
+	 */
+	for i := 0; i < lemp.nsymbol; i++ {
+		out.printf("\t/* %4d */ \"%s\",\n", i, lemp.symbols[i].name)
+	}
+	tplt_xfer(lemp, in, out)
+
+	/* Generate a table containing a text string that describes every
+	** rule in the rule set of the grammar.  This information is used
+	** when tracing REDUCE and SHIFT actions, or when debugging.
+	 */
+	i := 0
+	for rp := lemp.rule; rp != nil; rp = rp.next {
+		assert(rp.iRule == i)
+		out.printf("\t/* %3d */ \"", i)
+		writeRuleText(out, rp)
+		out.printf("\",\n")
+		i++
+	}
+	tplt_xfer(lemp, in, out)
+
+	/* Generate code which executes every time a symbol is popped from
+	** the stack while processing errors or while destroying the parser.
+	** (In other words, generate the %destructor actions)
+	 */
+	if lemp.tokendest != "" {
+		once := true
+		for i := 0; i < lemp.nsymbol; i++ {
+			sp := lemp.symbols[i]
+			if sp == nil || sp.typ != TERMINAL {
+				continue
+			}
+			if once {
+				out.printf("      /* TERMINAL Destructor */\n")
+				once = false
+			}
+			out.printf("    case %d: /* %s */\n", sp.index, sp.name)
+		}
+		i := 0
+		for ; i < lemp.nsymbol && lemp.symbols[i].typ != TERMINAL; i++ {
+		}
+		if i < lemp.nsymbol {
+			emit_destructor_code(out, lemp.symbols[i], lemp)
+			out.printf("      break\n")
+		}
+	}
+	if lemp.vardest != "" {
+		var dflt_sp *symbol
+		once := true
+		for i := 0; i < lemp.nsymbol; i++ {
+			sp := lemp.symbols[i]
+			if sp == nil || sp.typ == TERMINAL ||
+				sp.index <= 0 || sp.destructor != "" {
+				continue
+			}
+			if once {
+				out.printf("      /* Default NON-TERMINAL Destructor */\n")
+				once = false
+			}
+			out.printf("    case %d: /* %s */\n", sp.index, sp.name)
+			dflt_sp = sp
+		}
+		if dflt_sp != nil {
+			emit_destructor_code(out, dflt_sp, lemp)
+		}
+		out.printf("      break\n")
+	}
+	for i := 0; i < lemp.nsymbol; i++ {
+		sp := lemp.symbols[i]
+		if sp == nil || sp.typ == TERMINAL || sp.destructor == "" {
+			continue
+		}
+		if sp.destLineno < 0 {
+			continue /* Already emitted */
+		}
+		out.printf("    case %d: /* %s */\n", sp.index, sp.name)
+
+		/* Combine duplicate destructors into a single case */
+		for j := i + 1; j < lemp.nsymbol; j++ {
+			sp2 := lemp.symbols[j]
+			if sp2 != nil && sp2.typ != TERMINAL && sp2.destructor != "" &&
+				sp2.dtnum == sp.dtnum &&
+				sp.destructor == sp2.destructor {
+				out.printf("    case %d: /* %s */\n", sp2.index, sp2.name)
+				sp2.destLineno = -1 /* Avoid emitting this destructor again */
+			}
+		}
+
+		emit_destructor_code(out, lemp.symbols[i], lemp)
+		out.printf("      break\n")
+	}
+	tplt_xfer(lemp, in, out)
+
+	/* Generate code which executes whenever the parser stack overflows */
+	tplt_print(out, lemp, lemp.overflow)
+	tplt_xfer(lemp, in, out)
+
+	/* Generate the tables of rule information.  yyRuleInfoLhs[] and
+	** yyRuleInfoNRhs[].
+	**
+	** Note: This code depends on the fact that rules are number
+	** sequentually beginning with 0.
+	 */
+	i = 0
+	for rp := lemp.rule; rp != nil; rp = rp.next {
+		out.printf("\t%d, /* (%d) %s */\n", rp.lhs.index, i, ruleText(rp))
+		i++
+	}
+	tplt_xfer(lemp, in, out)
+	i = 0
+	for rp := lemp.rule; rp != nil; rp = rp.next {
+		out.printf("\t%d, /* (%d) %s */\n", -len(rp.rhs), i, ruleText(rp))
+		i++
+	}
+	tplt_xfer(lemp, in, out)
+
+	/* Generate code which execution during each REDUCE action */
+	for rp := lemp.rule; rp != nil; rp = rp.next {
+		translate_code(lemp, rp)
+	}
+	/* First output rules other than the default: rule */
+	for rp := lemp.rule; rp != nil; rp = rp.next {
+		if rp.codeEmitted {
+			continue
+		}
+		if rp.noCode {
+			/* No C code actions, so this will be part of the "default:" rule */
+			continue
+		}
+		out.printf("      case %d: /* ", rp.iRule)
+		writeRuleText(out, rp)
+		out.printf(" */\n")
+		for rp2 := rp.next; rp2 != nil; rp2 = rp2.next {
+			if rp2.code == rp.code && rp2.codePrefix == rp.codePrefix &&
+				rp2.codeSuffix == rp.codeSuffix {
+				out.printf("        fallthrough\n")
+				out.printf("      case %d: /* ", rp2.iRule)
+				writeRuleText(out, rp2)
+				out.printf(" */ yytestcase(yyruleno==%d);\n", rp2.iRule)
+				rp2.codeEmitted = true
+			}
+		}
+		emit_code(out, rp, lemp)
+		out.printf("        break\n")
+		rp.codeEmitted = true
+	}
+	/* Finally, output the default: rule.  We choose as the default: all
+	** empty actions. */
+	out.printf("\tdefault:\n")
+	for rp := lemp.rule; rp != nil; rp = rp.next {
+		if rp.codeEmitted {
+			continue
+		}
+		assert(rp.noCode)
+		out.printf("\t\t/* (%d) ", rp.iRule)
+		writeRuleText(out, rp)
+		if rp.neverReduce {
+			out.printf(" (NEVER REDUCES) */ assert(yyruleno!=%d, \"yyruleno!=%d\")\n",
+				rp.iRule, rp.iRule)
+		} else if rp.doesReduce {
+			out.printf(" */ yytestcase(yyruleno == %d)\n", rp.iRule)
+		} else {
+			out.printf(" (OPTIMIZED OUT) */ assert(yyruleno!=%d, \"yyruleno!=%d\")\n",
+				rp.iRule, rp.iRule)
+		}
+	}
+	out.printf("\t\tbreak\n")
+	tplt_xfer(lemp, in, out)
+
+	/* Generate code which executes if a parse fails */
+	tplt_print(out, lemp, lemp.failure)
+	tplt_xfer(lemp, in, out)
+
+	/* Generate code which executes when a syntax error occurs */
+	tplt_print(out, lemp, lemp.error)
+	tplt_xfer(lemp, in, out)
+
+	/* Generate code which executes when the parser accepts its input */
+	tplt_print(out, lemp, lemp.accept)
+	tplt_xfer(lemp, in, out)
+
+	/* Append any addition code the user desires */
+	tplt_print(out, lemp, lemp.extracode)
+
+	if err := os.WriteFile(lemp.outname, []byte(out.String()), 0666); err != nil {
+		fmt.Fprintf(os.Stderr, "Can't open file \"%s\".\n", lemp.outname)
+		lemp.errorcnt++
+	}
+}
+
+/*
+** Reduce the size of the action tables, if possible, by making use
+** of defaults.
+**
+** In this version, we take the most frequent REDUCE action and make
+** it the default.  Except, there is no default if the wildcard token
+** is a possible look-ahead.
+ */
+func CompressTables(lemp *lemon) {
+	for i := 0; i < lemp.nstate; i++ {
+		stp := lemp.sorted[i]
+		nbest := 0
+		var rbest *rule
+		usesWildcard := false
+
+		for ap := stp.ap; ap != nil; ap = ap.next {
+			if ap.typ == SHIFT && ap.sp == lemp.wildcard {
+				usesWildcard = true
+			}
+			if ap.typ != REDUCE {
+				continue
+			}
+			rp := ap.rp
+			if rp.lhsStart {
+				continue
+			}
+			if rp == rbest {
+				continue
+			}
+			n := 1
+			for ap2 := ap.next; ap2 != nil; ap2 = ap2.next {
+				if ap2.typ != REDUCE {
+					continue
+				}
+				rp2 := ap2.rp
+				if rp2 == rbest {
+					continue
+				}
+				if rp2 == rp {
+					n++
+				}
+			}
+			if n > nbest {
+				nbest = n
+				rbest = rp
+			}
+		}
+
+		/* Do not make a default if the number of rules to default
+		** is not at least 1 or if the wildcard token is a possible
+		** lookahead.
+		 */
+		if nbest < 1 || usesWildcard {
+			continue
+		}
+
+		/* Combine matching REDUCE actions into a single default */
+		var ap *action
+		for ap = stp.ap; ap != nil; ap = ap.next {
+			if ap.typ == REDUCE && ap.rp == rbest {
+				break
+			}
+		}
+		assert(ap != nil)
+		ap.sp = Symbol_new("{default}")
+		for ap = ap.next; ap != nil; ap = ap.next {
+			if ap.typ == REDUCE && ap.rp == rbest {
+				ap.typ = NOT_USED
+			}
+		}
+		stp.ap = Action_sort(stp.ap)
+
+		for ap = stp.ap; ap != nil; ap = ap.next {
+			if ap.typ == SHIFT {
+				break
+			}
+			if ap.typ == REDUCE && ap.rp != rbest {
+				break
+			}
+		}
+		if ap == nil {
+			stp.autoReduce = true
+			stp.pDfltReduce = rbest
+		}
+	}
+
+	/* Make a second pass over all states and actions.  Convert
+	** every action that is a SHIFT to an autoReduce state into
+	** a SHIFTREDUCE action.
+	 */
+	for i := 0; i < lemp.nstate; i++ {
+		stp := lemp.sorted[i]
+		for ap := stp.ap; ap != nil; ap = ap.next {
+			if ap.typ != SHIFT {
+				continue
+			}
+			pNextState := ap.stp
+			if pNextState.autoReduce && pNextState.pDfltReduce != nil {
+				ap.typ = SHIFTREDUCE
+				ap.rp = pNextState.pDfltReduce
+			}
+		}
+	}
+
+	/* If a SHIFTREDUCE action specifies a rule that has a single RHS term
+	** (meaning that the SHIFTREDUCE will land back in the state where it
+	** started) and if there is no C-code associated with the reduce action,
+	** then we can go ahead and convert the action to be the same as the
+	** action for the RHS of the rule.
+	 */
+	for i := 0; i < lemp.nstate; i++ {
+		stp := lemp.sorted[i]
+		var nextap *action
+		for ap := stp.ap; ap != nil; ap = nextap {
+			nextap = ap.next
+			if ap.typ != SHIFTREDUCE {
+				continue
+			}
+			rp := ap.rp
+			if !rp.noCode {
+				continue
+			}
+			if len(rp.rhs) != 1 {
+				continue
+			}
+			/* Only apply this optimization to non-terminals.  It would be OK to
+			** apply it to terminal symbols too, but that makes the parser tables
+			** larger. */
+			if ap.sp.index < lemp.nterminal {
+				continue
+			}
+			/* If we reach this point, it means the optimization can be applied */
+			nextap = ap
+			ap2 := stp.ap
+			for ap2 != nil && (ap2 == ap || ap2.sp != rp.lhs) {
+				ap2 = ap2.next
+			}
+			assert(ap2 != nil)
+			ap.spOpt = ap2.sp
+			ap.typ = ap2.typ
+			ap.stp = ap2.stp
+			ap.rp = ap2.rp
+		}
+	}
+}
+
+/*
+** Compare two states for sorting purposes.  The smaller state is the
+** one with the most non-terminal actions.  If they have the same number
+** of non-terminal actions, then the smaller is the one with the most
+** token actions.
+ */
+func stateResortCompare(pA *state, pB *state) int {
+	n := pB.nNtAct - pA.nNtAct
+	if n == 0 {
+		n = pB.nTknAct - pA.nTknAct
+		if n == 0 {
+			n = pB.statenum - pA.statenum
+		}
+	}
+	assert(n != 0)
+	return n
+}
+
+/*
+** Renumber and resort states so that states with fewer choices
+** occur at the end.  Except, keep state 0 as the first state.
+ */
+func ResortStates(lemp *lemon) {
+	for i := 0; i < lemp.nstate; i++ {
+		stp := lemp.sorted[i]
+		stp.nTknAct = 0
+		stp.nNtAct = 0
+		stp.iDfltReduce = -1 /* Init dflt action to "syntax error" */
+		stp.iTknOfst = NO_OFFSET
+		stp.iNtOfst = NO_OFFSET
+		for ap := stp.ap; ap != nil; ap = ap.next {
+			iAction := compute_action(lemp, ap)
+			if iAction >= 0 {
+				if ap.sp.index < lemp.nterminal {
+					stp.nTknAct++
+				} else if ap.sp.index < lemp.nsymbol {
+					stp.nNtAct++
+				} else {
+					assert(!stp.autoReduce || stp.pDfltReduce == ap.rp)
+					stp.iDfltReduce = iAction
+				}
+			}
+		}
+	}
+	rest := lemp.sorted[1:lemp.nstate]
+	sort.Slice(rest, func(i, j int) bool { return stateResortCompare(rest[i], rest[j]) < 0 })
+	for i := 0; i < lemp.nstate; i++ {
+		lemp.sorted[i].statenum = i
+	}
+	lemp.nxstate = lemp.nstate
+	for lemp.nxstate > 1 && lemp.sorted[lemp.nxstate-1].autoReduce {
+		lemp.nxstate--
+	}
+}
+
+/*
+** Return the name of an output file: the input file name with its
+** suffix replaced by "suffix", placed in the -d directory if one was
+** given.
+ */
+func file_makename(lemp *lemon, suffix string) string {
+	name := lemp.filename
+	if outputDir != "" {
+		name = filepath.Join(outputDir, filepath.Base(name))
+	}
+	if i := strings.LastIndexByte(name, '.'); i > strings.LastIndexByte(name, '/') {
+		name = name[:i]
+	}
+	return name + suffix
+}
@@ -0,0 +1,1851 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+**
+** This file contains routines that convert parse trees to and from JSON,
+** so that programs not written in Go can consume the output of the
+** parser.  The format is described by the JSON Schema in
+** schema/ast.v1.schema.json.  In outline:
+**
+**    {"version":1, "statements":[ STMT, ... ]}
+**
+** Each STMT has the text of the statement ("sql"), its span within the
+** parsed text ("offset" and "length", in bytes) and one member for the
+** parse tree of the statement, such as "select", "table" or "drop".
+**
+** Expression nodes are objects with an "op" member holding the name of
+** the TK_* code of the node as used by the grammar ("ID", "PLUS",
+** "FUNCTION", ...).  The text of an expression written in the SQL runs
+** from its "start" to its "end" offset, which take in any parentheses or
+** keywords around the operands.  The "offset" member, where present, is
+** the point that errors about the node are reported at.  All offsets are
+** relative to the start of the complete text passed to Parser.Parse, not
+** to the statement.
+**
+** Only what was written in the SQL is encoded.  Flags that the parser
+** derives from the shape of the tree are computed again when it is
+** decoded.
+**
+** The format is versioned by JSONFormatVersion.  Members may be added
+** to any object without a change of version.  A change that removes a
+** member or alters its meaning increments the version.
+ */
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+/*
+** JSONFormatVersion is the version of the JSON format written by
+** EncodeJSON.  DecodeJSON accepts only this version.
+ */
+const JSONFormatVersion = 1
+
+/*
+** The following types mirror the parse tree objects in a form that the
+** encoding/json package can read and write.
+ */
+type jsonDoc struct {
+	Version    int         `json:"version"`
+	Statements []*jsonStmt `json:"statements"`
+}
+
+type jsonStmt struct {
+	SQL           string              `json:"sql"`
+	Offset        int                 `json:"offset"`
+	Length        int                 `json:"length"`
+	Explain       int                 `json:"explain,omitempty"`
+	Select        *jsonSelect         `json:"select,omitempty"`
+	Delete        *jsonDelete         `json:"delete,omitempty"`
+	Update        *jsonUpdate         `json:"update,omitempty"`
+	Insert        *jsonInsert         `json:"insert,omitempty"`
+	Trigger       *jsonTrigger        `json:"trigger,omitempty"`
+	Table         *jsonTable          `json:"table,omitempty"`
+	View          *jsonView           `json:"view,omitempty"`
+	Index         *jsonIndex          `json:"index,omitempty"`
+	Drop          *jsonDrop           `json:"drop,omitempty"`
+	Alter         *jsonAlter          `json:"alter,omitempty"`
+	Pragma        *jsonPragma         `json:"pragma,omitempty"`
+	Attach        *jsonAttach         `json:"attach,omitempty"`
+	VersionIssues []*jsonVersionIssue `json:"versionIssues,omitempty"`
+}
+
+type jsonVersionIssue struct {
+	Feature string `json:"feature"`
+	Version string `json:"version"`
+	Offset  int    `json:"offset"`
+	Length  int    `json:"length"`
+}
+
+type jsonExpr struct {
+	Op           string          `json:"op"`
+	Token        *string         `json:"token,omitempty"`
+	Int          *int            `json:"int,omitempty"`
+	Offset       *int            `json:"offset,omitempty"`
+	Start        *int            `json:"start,omitempty"`
+	End          *int            `json:"end,omitempty"`
+	Quoted       bool            `json:"quoted,omitempty"`
+	DoubleQuoted bool            `json:"doubleQuoted,omitempty"`
+	Distinct     bool            `json:"distinct,omitempty"`
+	Infix        bool            `json:"infix,omitempty"`
+	OnError      string          `json:"onError,omitempty"`
+	Param        int             `json:"param,omitempty"`
+	Field        *int            `json:"field,omitempty"`
+	FieldCount   int             `json:"fieldCount,omitempty"`
+	Left         *jsonExpr       `json:"left,omitempty"`
+	Right        *jsonExpr       `json:"right,omitempty"`
+	List         []*jsonExprItem `json:"list,omitempty"`
+	Select       *jsonSelect     `json:"select,omitempty"`
+	Window       *jsonWindow     `json:"window,omitempty"`
+}
+
+type jsonExprItem struct {
+	Expr      *jsonExpr `json:"expr,omitempty"`
+	Name      *string   `json:"name,omitempty"`
+	NameKind  string    `json:"nameKind,omitempty"`
+	SortFlags int       `json:"sortFlags,omitempty"`
+	Nulls     bool      `json:"nulls,omitempty"`
+}
+
+type jsonSelect struct {
+	Op         string          `json:"op"`
+	Distinct   bool            `json:"distinct,omitempty"`
+	All        bool            `json:"all,omitempty"`
+	Values     bool            `json:"values,omitempty"`
+	MultiValue bool            `json:"multiValue,omitempty"`
+	NestedFrom bool            `json:"nestedFrom,omitempty"`
+	Result     []*jsonExprItem `json:"result,omitempty"`
+	From       []*jsonSrcItem  `json:"from,omitempty"`
+	Where      *jsonExpr       `json:"where,omitempty"`
+	GroupBy    []*jsonExprItem `json:"groupBy,omitempty"`
+	Having     *jsonExpr       `json:"having,omitempty"`
+	OrderBy    []*jsonExprItem `json:"orderBy,omitempty"`
+	Limit      *jsonExpr       `json:"limit,omitempty"`
+	With       *jsonWith       `json:"with,omitempty"`
+	Windows    []*jsonWindow   `json:"windows,omitempty"`
+	Prior      *jsonSelect     `json:"prior,omitempty"`
+}
+
+type jsonSrcItem struct {
+	Database   string          `json:"database,omitempty"`
+	Name       string          `json:"name,omitempty"`
+	Alias      string          `json:"alias,omitempty"`
+	JoinType   int             `json:"joinType,omitempty"`
+	Select     *jsonSelect     `json:"select,omitempty"`
+	On         *jsonExpr       `json:"on,omitempty"`
+	Using      []string        `json:"using,omitempty"`
+	IndexedBy  string          `json:"indexedBy,omitempty"`
+	NotIndexed bool            `json:"notIndexed,omitempty"`
+	FuncArgs   []*jsonExprItem `json:"funcArgs,omitempty"`
+}
+
+type jsonWindow struct {
+	Name          string          `json:"name,omitempty"`
+	Base          string          `json:"base,omitempty"`
+	Partition     []*jsonExprItem `json:"partition,omitempty"`
+	OrderBy       []*jsonExprItem `json:"orderBy,omitempty"`
+	FrameType     string          `json:"frameType,omitempty"`
+	Start         string          `json:"start,omitempty"`
+	End           string          `json:"end,omitempty"`
+	Exclude       string          `json:"exclude,omitempty"`
+	StartExpr     *jsonExpr       `json:"startExpr,omitempty"`
+	EndExpr       *jsonExpr       `json:"endExpr,omitempty"`
+	Filter        *jsonExpr       `json:"filter,omitempty"`
+	ImplicitFrame bool            `json:"implicitFrame,omitempty"`
+}
+
+type jsonWith struct {
+	Ctes []*jsonCte `json:"ctes"`
+}
+
+type jsonCte struct {
+	Name         string          `json:"name"`
+	Columns      []*jsonExprItem `json:"columns,omitempty"`
+	Select       *jsonSelect     `json:"select"`
+	Materialized string          `json:"materialized,omitempty"`
+}
+
+type jsonUpsert struct {
+	Target      []*jsonExprItem `json:"target,omitempty"`
+	TargetWhere *jsonExpr       `json:"targetWhere,omitempty"`
+	Set         []*jsonExprItem `json:"set,omitempty"`
+	Where       *jsonExpr       `json:"where,omitempty"`
+}
+
+type jsonDelete struct {
+	Table     []*jsonSrcItem  `json:"table"`
+	Where     *jsonExpr       `json:"where,omitempty"`
+	OrderBy   []*jsonExprItem `json:"orderBy,omitempty"`
+	Limit     *jsonExpr       `json:"limit,omitempty"`
+	Returning []*jsonExprItem `json:"returning,omitempty"`
+}
+
+type jsonUpdate struct {
+	Table     []*jsonSrcItem  `json:"table"`
+	Set       []*jsonExprItem `json:"set"`
+	Where     *jsonExpr       `json:"where,omitempty"`
+	OnError   string          `json:"onError,omitempty"`
+	OrderBy   []*jsonExprItem `json:"orderBy,omitempty"`
+	Limit     *jsonExpr       `json:"limit,omitempty"`
+	Upsert    []*jsonUpsert   `json:"upsert,omitempty"`
+	Returning []*jsonExprItem `json:"returning,omitempty"`
+}
+
+type jsonInsert struct {
+	Table     []*jsonSrcItem  `json:"table"`
+	Columns   []string        `json:"columns,omitempty"`
+	Select    *jsonSelect     `json:"select,omitempty"`
+	OnError   string          `json:"onError,omitempty"`
+	Upsert    []*jsonUpsert   `json:"upsert,omitempty"`
+	Returning []*jsonExprItem `json:"returning,omitempty"`
+}
+
+type jsonTable struct {
+	Name          string            `json:"name"`
+	Columns       []*jsonColumn     `json:"columns,omitempty"`
+	OnError       string            `json:"onError,omitempty"`
+	Autoincrement bool              `json:"autoincrement,omitempty"`
+	WithoutRowid  bool              `json:"withoutRowid,omitempty"`
+	Strict        bool              `json:"strict,omitempty"`
+	Indexes       []*jsonIndex      `json:"indexes,omitempty"`
+	ForeignKeys   []*jsonForeignKey `json:"foreignKeys,omitempty"`
+}
+
+type jsonView struct {
+	Name       string        `json:"name"`
+	ColumnList []string      `json:"columnList,omitempty"`
+	Columns    []*jsonColumn `json:"columns,omitempty"`
+	Select     *jsonSelect   `json:"select"`
+}
+
+type jsonIndex struct {
+	Name       string          `json:"name"`
+	Table      string          `json:"table"`
+	OnError    string          `json:"onError"`
+	PrimaryKey bool            `json:"primaryKey,omitempty"`
+	Columns    []*jsonExprItem `json:"columns"`
+	Where      *jsonExpr       `json:"where,omitempty"`
+}
+
+type jsonColumn struct {
+	Name       string `json:"name"`
+	Type       string `json:"type,omitempty"`
+	Affinity   string `json:"affinity,omitempty"`
+	Collation  string `json:"collation,omitempty"`
+	NotNull    string `json:"notNull,omitempty"`
+	PrimaryKey bool   `json:"primaryKey,omitempty"`
+}
+
+type jsonForeignKey struct {
+	Columns   []string `json:"columns"`
+	Table     string   `json:"table"`
+	ToColumns []string `json:"toColumns,omitempty"`
+	OnDelete  string   `json:"onDelete,omitempty"`
+	OnUpdate  string   `json:"onUpdate,omitempty"`
+	Deferred  bool     `json:"deferred,omitempty"`
+}
+
+type jsonTrigger struct {
+	Name    string             `json:"name"`
+	Table   string             `json:"table"`
+	Timing  string             `json:"timing"`
+	Event   string             `json:"event"`
+	Columns []string           `json:"columns,omitempty"`
+	When    *jsonExpr          `json:"when,omitempty"`
+	Steps   []*jsonTriggerStep `json:"steps"`
+}
+
+type jsonTriggerStep struct {
+	Op      string          `json:"op"`
+	SQL     string          `json:"sql"`
+	Target  string          `json:"target,omitempty"`
+	OnError string          `json:"onError,omitempty"`
+	Columns []string        `json:"columns,omitempty"`
+	Select  *jsonSelect     `json:"select,omitempty"`
+	From    []*jsonSrcItem  `json:"from,omitempty"`
+	Set     []*jsonExprItem `json:"set,omitempty"`
+	Where   *jsonExpr       `json:"where,omitempty"`
+	Upsert  []*jsonUpsert   `json:"upsert,omitempty"`
+}
+
+type jsonDrop struct {
+	Op       string       `json:"op"`
+	Object   *jsonSrcItem `json:"object"`
+	IfExists bool         `json:"ifExists,omitempty"`
+}
+
+type jsonAlter struct {
+	Op         string       `json:"op"`
+	Table      *jsonSrcItem `json:"table"`
+	Column     string       `json:"column,omitempty"`
+	NewName    string       `json:"newName,omitempty"`
+	Definition *jsonTable   `json:"definition,omitempty"`
+}
+
+type jsonPragma struct {
+	Database string  `json:"database,omitempty"`
+	Name     string  `json:"name"`
+	Value    *string `json:"value,omitempty"`
+}
+
+type jsonAttach struct {
+	Op       string    `json:"op"`
+	Filename *jsonExpr `json:"filename,omitempty"`
+	Database *jsonExpr `json:"database"`
+	Key      *jsonExpr `json:"key,omitempty"`
+}
+
+/*
+** Names used for the OE_* conflict resolution codes, indexed by code.
+ */
+var jsonOnError = []string{
+	OE_None:     "none",
+	OE_Rollback: "rollback",
+	OE_Abort:    "abort",
+	OE_Fail:     "fail",
+	OE_Ignore:   "ignore",
+	OE_Replace:  "replace",
+	OE_Update:   "update",
+	OE_Restrict: "restrict",
+	OE_SetNull:  "setnull",
+	OE_SetDflt:  "setdefault",
+	OE_Cascade:  "cascade",
+	OE_Default:  "default",
+}
+
+/*
+** Names used for the ENAME_* values of ExprList_item.eEName, and for the
+** M10d_* values of Cte.eM10d, indexed by value.
+ */
+var jsonNameKind = []string{
+	ENAME_NAME: "name",
+	ENAME_SPAN: "span",
+	ENAME_TAB:  "tab",
+}
+var jsonMaterialized = []string{
+	M10d_Yes: "yes",
+	M10d_Any: "any",
+	M10d_No:  "no",
+}
+
+/*
+** Names used for the time at which a trigger fires.  The index is the
+** TRIGGER_* value of Trigger.tr_tm, except that "instead" stands for an
+** INSTEAD OF trigger, which has TRIGGER_BEFORE and Trigger.bInstead set.
+ */
+const jsonTriggerInstead = 0
+
+var jsonTriggerTiming = []string{
+	jsonTriggerInstead: "instead",
+	TRIGGER_BEFORE:     "before",
+	TRIGGER_AFTER:      "after",
+}
+
+/*
+** Names used for the SQLITE_AFF_* column affinities, indexed by the
+** affinity less SQLITE_AFF_NONE.
+ */
+var jsonAffinity = []string{
+	SQLITE_AFF_NONE - SQLITE_AFF_NONE:    "",
+	SQLITE_AFF_BLOB - SQLITE_AFF_NONE:    "blob",
+	SQLITE_AFF_TEXT - SQLITE_AFF_NONE:    "text",
+	SQLITE_AFF_NUMERIC - SQLITE_AFF_NONE: "numeric",
+	SQLITE_AFF_INTEGER - SQLITE_AFF_NONE: "integer",
+	SQLITE_AFF_REAL - SQLITE_AFF_NONE:    "real",
+}
+
+/*
+** Map from the name of a token, as found in yyTokenName[], to its TK_*
+** code.
+ */
+var jsonTokenCode = func() map[string]int {
+	m := make(map[string]int, len(yyTokenName))
+	for i, zName := range yyTokenName {
+		m[zName] = i
+	}
+	return m
+}()
+
+/*
+** Return the index of zName in the array azName[], or -1 if it is not
+** found.
+ */
+func jsonLookup(azName []string, zName string) int {
+	for i, z := range azName {
+		if z == zName {
+			return i
+		}
+	}
+	return -1
+}
+
+/*
+** Return the name of the TK_* code op, or "" for zero.  This is used
+** for window frame fields, where zero means "not specified".
+ */
+func jsonOptTokenName(op uint8) string {
+	if op == 0 {
+		return ""
+	}
+	return TokenKind(op).String()
+}
+
+/*
+** Return azName[i], or "" if i is out of range.
+ */
+func jsonEnumName(azName []string, i int) string {
+	if i < 0 || i >= len(azName) {
+		return ""
+	}
+	return azName[i]
+}
+
+/*
+** Return the name of the OE_* code onError, or "" for OE_None.  This is
+** used where OE_None means that no clause was given.
+ */
+func jsonOptOnError(onError uint8) string {
+	if onError == OE_None {
+		return ""
+	}
+	return jsonEnumName(jsonOnError, int(onError))
+}
+
+/*
+** Return a copy of z as a *string, or nil if z is nil.
+ */
+func jsonOptString(z []byte) *string {
+	if z == nil {
+		return nil
+	}
+	s := string(z)
+	return &s
+}
+
+/*
+** The routines that follow convert parse tree objects into their JSON
+** mirrors.  iBase is the offset within the complete SQL text that the
+** offsets stored in Expr objects are relative to.
+ */
+func jsonFromExpr(iBase int, p *Expr) *jsonExpr {
+	if p == nil {
+		return nil
+	}
+	pOut := &jsonExpr{}
+	pOut.Op = TokenKind(p.op).String()
+	if ExprHasProperty(p, EP_IntValue) {
+		iValue := p.u.iValue
+		pOut.Int = &iValue
+	} else {
+		pOut.Token = jsonOptString(p.u.zToken)
+	}
+	if p.w.iOfst > 0 {
+		iOfst := iBase + p.w.iOfst
+		pOut.Offset = &iOfst
+	}
+	if p.nSpan > 0 {
+		iStart := iBase + p.iSpan
+		iEnd := iStart + p.nSpan
+		pOut.Start = &iStart
+		pOut.End = &iEnd
+	}
+	pOut.Quoted = ExprHasProperty(p, EP_Quoted)
+	pOut.DoubleQuoted = ExprHasProperty(p, EP_DblQuoted)
+	pOut.Distinct = ExprHasProperty(p, EP_Distinct)
+	pOut.Infix = ExprHasProperty(p, EP_InfixFunc)
+	switch p.op {
+	case TK_RAISE:
+		pOut.OnError = jsonEnumName(jsonOnError, int(p.affExpr))
+	case TK_VARIABLE:
+		pOut.Param = int(p.iColumn)
+	case TK_SELECT_COLUMN:
+		iField := int(p.iColumn)
+		pOut.Field = &iField
+		pOut.FieldCount = p.iTable
+	}
+	pOut.Left = jsonFromExpr(iBase, p.pLeft)
+	pOut.Right = jsonFromExpr(iBase, p.pRight)
+	if ExprUseXSelect(p) {
+		pOut.Select = jsonFromSelect(iBase, p.x.pSelect)
+	} else {
+		pOut.List = jsonFromExprList(iBase, p.x.pList)
+	}
+	if ExprHasProperty(p, EP_WinFunc) {
+		pOut.Window = jsonFromWindow(iBase, p.y.pWin)
+	}
+	return pOut
+}
+func jsonFromExprList(iBase int, p *ExprList) []*jsonExprItem {
+	if p == nil {
+		return nil
+	}
+	aOut := make([]*jsonExprItem, p.nExpr)
+	for i := 0; i < p.nExpr; i++ {
+		pItem := &p.a[i]
+		pOut := &jsonExprItem{}
+		pOut.Expr = jsonFromExpr(iBase, pItem.pExpr)
+		if pItem.zEName != nil {
+			pOut.Name = jsonOptString(pItem.zEName)
+			pOut.NameKind = jsonEnumName(jsonNameKind, int(pItem.eEName))
+		}
+		pOut.SortFlags = int(pItem.sortFlags)
+		pOut.Nulls = pItem.bNulls != 0
+		aOut[i] = pOut
+	}
+	return aOut
+}
+func jsonFromIdList(p *IdList) []string {
+	var aOut []string
+	if p != nil {
+		for i := 0; i < p.nId; i++ {
+			aOut = append(aOut, string(p.a[i].zName))
+		}
+	}
+	return aOut
+}
+func jsonFromSrcList(iBase int, p *SrcList) []*jsonSrcItem {
+	if p == nil {
+		return nil
+	}
+	aOut := make([]*jsonSrcItem, p.nSrc)
+	for i := 0; i < p.nSrc; i++ {
+		pItem := &p.a[i]
+		pOut := &jsonSrcItem{}
+		pOut.Database = string(pItem.zDatabase)
+		pOut.Name = string(pItem.zName)
+		pOut.Alias = string(pItem.zAlias)
+		pOut.JoinType = int(pItem.fg.jointype)
+		pOut.Select = jsonFromSelect(iBase, pItem.pSelect)
+		pOut.On = jsonFromExpr(iBase, pItem.u3.pOn)
+		pOut.Using = jsonFromIdList(pItem.u3.pUsing)
+		pOut.IndexedBy = string(pItem.u1.zIndexedBy)
+		pOut.NotIndexed = pItem.fg.notIndexed != 0
+		pOut.FuncArgs = jsonFromExprList(iBase, pItem.u1.pFuncArg)
+		aOut[i] = pOut
+	}
+	return aOut
+}
+func jsonFromSelect(iBase int, p *Select) *jsonSelect {
+	if p == nil {
+		return nil
+	}
+	pOut := &jsonSelect{}
+	pOut.Op = TokenKind(p.op).String()
+	pOut.Distinct = (p.selFlags & SF_Distinct) != 0
+	pOut.All = (p.selFlags & SF_All) != 0
+	pOut.Values = (p.selFlags & SF_Values) != 0
+	pOut.MultiValue = (p.selFlags & SF_MultiValue) != 0
+	pOut.NestedFrom = (p.selFlags & SF_NestedFrom) != 0
+	pOut.Result = jsonFromExprList(iBase, p.pEList)
+	pOut.From = jsonFromSrcList(iBase, p.pSrc)
+	pOut.Where = jsonFromExpr(iBase, p.pWhere)
+	pOut.GroupBy = jsonFromExprList(iBase, p.pGroupBy)
+	pOut.Having = jsonFromExpr(iBase, p.pHaving)
+	pOut.OrderBy = jsonFromExprList(iBase, p.pOrderBy)
+	pOut.Limit = jsonFromExpr(iBase, p.pLimit)
+	pOut.With = jsonFromWith(iBase, p.pWith)
+	for pWin := p.pWinDefn; pWin != nil; pWin = pWin.pNextWin {
+		pOut.Windows = append(pOut.Windows, jsonFromWindow(iBase, pWin))
+	}
+	pOut.Prior = jsonFromSelect(iBase, p.pPrior)
+	return pOut
+}
+func jsonFromWindow(iBase int, p *Window) *jsonWindow {
+	if p == nil {
+		return nil
+	}
+	pOut := &jsonWindow{}
+	pOut.Name = string(p.zName)
+	pOut.Base = string(p.zBase)
+	pOut.Partition = jsonFromExprList(iBase, p.pPartition)
+	pOut.OrderBy = jsonFromExprList(iBase, p.pOrderBy)
+	pOut.FrameType = jsonOptTokenName(p.eFrmType)
+	pOut.Start = jsonOptTokenName(p.eStart)
+	pOut.End = jsonOptTokenName(p.eEnd)
+	pOut.Exclude = jsonOptTokenName(p.eExclude)
+	pOut.StartExpr = jsonFromExpr(iBase, p.pStart)
+	pOut.EndExpr = jsonFromExpr(iBase, p.pEnd)
+	pOut.Filter = jsonFromExpr(iBase, p.pFilter)
+	pOut.ImplicitFrame = p.bImplicitFrame != 0
+	return pOut
+}
+func jsonFromWith(iBase int, p *With) *jsonWith {
+	if p == nil {
+		return nil
+	}
+	pOut := &jsonWith{Ctes: make([]*jsonCte, p.nCte)}
+	for i := 0; i < p.nCte; i++ {
+		pCte := &p.a[i]
+		pOut.Ctes[i] = &jsonCte{
+			Name:         string(pCte.zName),
+			Columns:      jsonFromExprList(iBase, pCte.pCols),
+			Select:       jsonFromSelect(iBase, pCte.pSelect),
+			Materialized: jsonEnumName(jsonMaterialized, int(pCte.eM10d)),
+		}
+	}
+	return pOut
+}
+func jsonFromUpsert(iBase int, p *Upsert) []*jsonUpsert {
+	var aOut []*jsonUpsert
+	for ; p != nil; p = p.pNextUpsert {
+		aOut = append(aOut, &jsonUpsert{
+			Target:      jsonFromExprList(iBase, p.pUpsertTarget),
+			TargetWhere: jsonFromExpr(iBase, p.pUpsertTargetWhere),
+			Set:         jsonFromExprList(iBase, p.pUpsertSet),
+			Where:       jsonFromExpr(iBase, p.pUpsertWhere),
+		})
+	}
+	return aOut
+}
+func jsonFromColumns(p *Table) []*jsonColumn {
+	var aOut []*jsonColumn
+	for i := 0; i < int(p.nCol); i++ {
+		pCol := &p.aCol[i]
+		aOut = append(aOut, &jsonColumn{
+			Name:       string(pCol.zCnName[:sqlite3Strlen30(pCol.zCnName)]),
+			Type:       string(sqlite3ColumnType(pCol, nil)),
+			Affinity:   jsonEnumName(jsonAffinity, int(pCol.affinity-SQLITE_AFF_NONE)),
+			Collation:  string(sqlite3ColumnColl(pCol)),
+			NotNull:    jsonOptOnError(pCol.notNull),
+			PrimaryKey: (pCol.colFlags & COLFLAG_PRIMKEY) != 0,
+		})
+	}
+	return aOut
+}
+func jsonFromTable(iBase int, p *Table) *jsonTable {
+	if p == nil || IsView(p) {
+		return nil
+	}
+	pOut := &jsonTable{}
+	pOut.Name = string(p.zName)
+	pOut.Columns = jsonFromColumns(p)
+	if p.iPKey >= 0 {
+		pOut.OnError = jsonOptOnError(p.keyConf)
+	}
+	pOut.Autoincrement = (p.tabFlags & TF_Autoincrement) != 0
+	pOut.WithoutRowid = (p.tabFlags & TF_WithoutRowid) != 0
+	pOut.Strict = (p.tabFlags & TF_Strict) != 0
+	for pIdx := p.pIndex; pIdx != nil; pIdx = pIdx.pNext {
+		pOut.Indexes = append(pOut.Indexes, jsonFromIndex(iBase, pIdx))
+	}
+
+	/* The list of foreign keys is in the reverse of the order in which
+	 ** they were declared.  They are written in declaration order.  */
+	for pFKey := p.u.tab.pFKey; pFKey != nil; pFKey = pFKey.pNextFrom {
+		pFk := &jsonForeignKey{}
+		pFk.Table = string(pFKey.zTo)
+		for i := 0; i < pFKey.nCol; i++ {
+			pCol := &p.aCol[pFKey.aCol[i].iFrom]
+			pFk.Columns = append(pFk.Columns, string(pCol.zCnName[:sqlite3Strlen30(pCol.zCnName)]))
+			if pFKey.aCol[i].zCol != nil {
+				pFk.ToColumns = append(pFk.ToColumns, string(pFKey.aCol[i].zCol))
+			}
+		}
+		pFk.OnDelete = jsonOptOnError(pFKey.aAction[0])
+		pFk.OnUpdate = jsonOptOnError(pFKey.aAction[1])
+		pFk.Deferred = pFKey.isDeferred != 0
+		pOut.ForeignKeys = append([]*jsonForeignKey{pFk}, pOut.ForeignKeys...)
+	}
+	return pOut
+}
+func jsonFromView(iBase int, p *Table) *jsonView {
+	if p == nil || !IsView(p) {
+		return nil
+	}
+	pOut := &jsonView{}
+	pOut.Name = string(p.zName)
+	if p.pCheck != nil {
+		for i := 0; i < p.pCheck.nExpr; i++ {
+			pOut.ColumnList = append(pOut.ColumnList, string(p.pCheck.a[i].zEName))
+		}
+	}
+	pOut.Columns = jsonFromColumns(p)
+	pOut.Select = jsonFromSelect(iBase, p.u.view.pSelect)
+	return pOut
+}
+func jsonFromIndex(iBase int, p *Index) *jsonIndex {
+	if p == nil {
+		return nil
+	}
+	pOut := &jsonIndex{}
+	pOut.Name = string(p.zName)
+	pOut.Table = string(p.pTable.zName)
+	pOut.OnError = jsonEnumName(jsonOnError, int(p.onError))
+	pOut.PrimaryKey = p.idxType == SQLITE_IDXTYPE_PRIMARYKEY
+	pOut.Columns = jsonFromExprList(iBase, p.aColExpr)
+	pOut.Where = jsonFromExpr(iBase, p.pPartIdxWhere)
+	return pOut
+}
+func jsonFromTrigger(iBase int, p *Trigger) *jsonTrigger {
+	if p == nil {
+		return nil
+	}
+	pOut := &jsonTrigger{}
+	pOut.Name = string(p.zName)
+	pOut.Table = string(p.table)
+	if p.bInstead != 0 {
+		pOut.Timing = jsonTriggerTiming[jsonTriggerInstead]
+	} else {
+		pOut.Timing = jsonEnumName(jsonTriggerTiming, int(p.tr_tm))
+	}
+	pOut.Event = TokenKind(p.op).String()
+	pOut.Columns = jsonFromIdList(p.pColumns)
+	pOut.When = jsonFromExpr(iBase, p.pWhen)
+	pOut.Steps = []*jsonTriggerStep{}
+	for pStep := p.step_list; pStep != nil; pStep = pStep.pNext {
+		pOut.Steps = append(pOut.Steps, &jsonTriggerStep{
+			Op:      TokenKind(pStep.op).String(),
+			SQL:     string(pStep.zSpan),
+			Target:  string(pStep.zTarget),
+			OnError: jsonEnumName(jsonOnError, int(pStep.orconf)),
+			Columns: jsonFromIdList(pStep.pIdList),
+			Select:  jsonFromSelect(iBase, pStep.pSelect),
+			From:    jsonFromSrcList(iBase, pStep.pFrom),
+			Set:     jsonFromExprList(iBase, pStep.pExprList),
+			Where:   jsonFromExpr(iBase, pStep.pWhere),
+			Upsert:  jsonFromUpsert(iBase, pStep.pUpsert),
+		})
+	}
+	return pOut
+}
+func jsonFromDrop(iBase int, p *Drop) *jsonDrop {
+	if p == nil {
+		return nil
+	}
+	return &jsonDrop{
+		Op:       TokenKind(p.op).String(),
+		Object:   jsonFromSrcList(iBase, p.pName)[0],
+		IfExists: p.noErr != 0,
+	}
+}
+func jsonFromAlter(iBase int, p *Alter) *jsonAlter {
+	if p == nil {
+		return nil
+	}
+	return &jsonAlter{
+		Op:         TokenKind(p.op).String(),
+		Table:      jsonFromSrcList(iBase, p.pSrc)[0],
+		Column:     string(p.zOld),
+		NewName:    string(p.zNew),
+		Definition: jsonFromTable(iBase, p.pNew),
+	}
+}
+func jsonFromPragma(p *Pragma) *jsonPragma {
+	if p == nil {
+		return nil
+	}
+	return &jsonPragma{
+		Database: string(p.zDb),
+		Name:     string(p.zLeft),
+		Value:    jsonOptString(p.zRight),
+	}
+}
+func jsonFromAttach(iBase int, p *Attach) *jsonAttach {
+	if p == nil {
+		return nil
+	}
+	return &jsonAttach{
+		Op:       TokenKind(p.op).String(),
+		Filename: jsonFromExpr(iBase, p.pFilename),
+		Database: jsonFromExpr(iBase, p.pDbname),
+		Key:      jsonFromExpr(iBase, p.pKey),
+	}
+}
+func jsonFromStmt(p *Stmt) *jsonStmt {
+	iBase := p.iBase
+	pOut := &jsonStmt{}
+	pOut.SQL = string(p.zSql)
+	pOut.Offset = p.iOfst
+	pOut.Length = len(p.zSql)
+	pOut.Explain = int(p.explain)
+	pOut.Select = jsonFromSelect(iBase, p.pSelect)
+	if p.pDelete != nil {
+		pOut.Delete = &jsonDelete{
+			Table:     jsonFromSrcList(iBase, p.pDelete.pTabList),
+			Where:     jsonFromExpr(iBase, p.pDelete.pWhere),
+			OrderBy:   jsonFromExprList(iBase, p.pDelete.pOrderBy),
+			Limit:     jsonFromExpr(iBase, p.pDelete.pLimit),
+			Returning: jsonFromExprList(iBase, p.pDelete.pReturning),
+		}
+	}
+	if p.pUpdate != nil {
+		pOut.Update = &jsonUpdate{
+			Table:     jsonFromSrcList(iBase, p.pUpdate.pTabList),
+			Set:       jsonFromExprList(iBase, p.pUpdate.pChanges),
+			Where:     jsonFromExpr(iBase, p.pUpdate.pWhere),
+			OnError:   jsonEnumName(jsonOnError, p.pUpdate.onError),
+			OrderBy:   jsonFromExprList(iBase, p.pUpdate.pOrderBy),
+			Limit:     jsonFromExpr(iBase, p.pUpdate.pLimit),
+			Upsert:    jsonFromUpsert(iBase, p.pUpdate.pUpsert),
+			Returning: jsonFromExprList(iBase, p.pUpdate.pReturning),
+		}
+	}
+	if p.pInsert != nil {
+		pOut.Insert = &jsonInsert{
+			Table:     jsonFromSrcList(iBase, p.pInsert.pTabList),
+			Columns:   jsonFromIdList(p.pInsert.pColumn),
+			Select:    jsonFromSelect(iBase, p.pInsert.pSelect),
+			OnError:   jsonEnumName(jsonOnError, p.pInsert.onError),
+			Upsert:    jsonFromUpsert(iBase, p.pInsert.pUpsert),
+			Returning: jsonFromExprList(iBase, p.pInsert.pReturning),
+		}
+	}
+	pOut.Trigger = jsonFromTrigger(iBase, p.pTrigger)
+	pOut.Table = jsonFromTable(iBase, p.pTable)
+	pOut.View = jsonFromView(iBase, p.pTable)
+	pOut.Index = jsonFromIndex(iBase, p.pIndex)
+	pOut.Drop = jsonFromDrop(iBase, p.pDrop)
+	pOut.Alter = jsonFromAlter(iBase, p.pAlter)
+	pOut.Pragma = jsonFromPragma(p.pPragma)
+	pOut.Attach = jsonFromAttach(iBase, p.pAttach)
+	for i := range p.aVersion {
+		pIssue := &p.aVersion[i]
+		pOut.VersionIssues = append(pOut.VersionIssues, &jsonVersionIssue{
+			Feature: pIssue.Feature,
+			Version: pIssue.Version,
+			Offset:  pIssue.Offset,
+			Length:  pIssue.Length,
+		})
+	}
+	return pOut
+}
+
+/*
+** An instance of this object holds the state of a conversion from the
+** JSON mirrors back into a parse tree.  Only the first error is kept.
+ */
+type jsonTree struct {
+	db    *sqlite3 /* Connection used by the routines that build tables */
+	iBase int      /* Offset that offsets in Expr objects are made from */
+	err   error    /* First error seen, or nil */
+}
+
+/*
+** Record an error against the conversion pTree.
+ */
+func jsonTreeError(pTree *jsonTree, zFormat string, ap ...interface{}) {
+	if pTree.err == nil {
+		pTree.err = &Error{Code: SQLITE_ERROR, Msg: fmt.Sprintf(zFormat, ap...), Offset: -1}
+	}
+}
+
+/*
+** Return the TK_* code named zName.  zWhat describes the member being
+** decoded, for the error message.  If bOptional is true, then "" stands
+** for zero.
+ */
+func jsonTreeToken(pTree *jsonTree, zName string, zWhat string, bOptional bool) int {
+	if zName == "" && bOptional {
+		return 0
+	}
+	op, ok := jsonTokenCode[zName]
+	if !ok {
+		jsonTreeError(pTree, "unknown %s: %q", zWhat, zName)
+	}
+	return op
+}
+
+/*
+** Return the index of zName in azName[].  If zName is "", then iDflt
+** is returned.
+ */
+func jsonTreeEnum(pTree *jsonTree, azName []string, zName string, zWhat string, iDflt int) int {
+	if zName == "" {
+		return iDflt
+	}
+	i := jsonLookup(azName, zName)
+	if i < 0 {
+		jsonTreeError(pTree, "unknown %s: %q", zWhat, zName)
+		return iDflt
+	}
+	return i
+}
+
+/*
+** Return a copy of z as a []byte, or nil if z is "".
+ */
+func jsonTreeName(z string) []byte {
+	if z == "" {
+		return nil
+	}
+	return []byte(z)
+}
+
+/*
+** Return a Token from which sqlite3NameFromToken() recovers zName
+** exactly.
+ */
+func jsonTreeIdToken(zName string) Token {
+	var t Token
+	z := []byte(QuoteIdent(zName))
+	t.z = z
+	t.n = uint(len(z))
+	return t
+}
+
+/*
+** Bits for the members of a jsonExpr that hold its token and operands.
+** jsonExprMember[] has the name of each, for error messages.
+ */
+const (
+	jsonHasToken  = 0x01 /* "token" or "int" */
+	jsonHasLeft   = 0x02 /* "left" */
+	jsonHasRight  = 0x04 /* "right" */
+	jsonHasList   = 0x08 /* "list" */
+	jsonHasSelect = 0x10 /* "select" */
+)
+
+var jsonExprMember = []struct {
+	mask  int
+	zName string
+}{
+	{jsonHasToken, "token"},
+	{jsonHasLeft, "left operand"},
+	{jsonHasRight, "right operand"},
+	{jsonHasList, "list"},
+	{jsonHasSelect, "select"},
+}
+
+/*
+** Check that the expression p, whose TK_* code is op, has the token and
+** operands that the parser gives an expression of that kind, and no
+** others.  An error is recorded against pTree if it does not.
+ */
+func jsonTreeCheckExpr(pTree *jsonTree, p *jsonExpr, op int) {
+	var mHas int     /* jsonHas* members present */
+	var mReq int     /* jsonHas* members required */
+	var mOpt int     /* jsonHas* members allowed but not required */
+	var nMinList int /* Fewest list items allowed */
+	var nMaxList int /* Most list items allowed, or 0 for no limit */
+
+	if p.Token != nil || p.Int != nil {
+		mHas |= jsonHasToken
+	}
+	if p.Left != nil {
+		mHas |= jsonHasLeft
+	}
+	if p.Right != nil {
+		mHas |= jsonHasRight
+	}
+	if p.List != nil {
+		mHas |= jsonHasList
+	}
+	if p.Select != nil {
+		mHas |= jsonHasSelect
+	}
+
+	switch op {
+	case TK_ID, TK_STRING, TK_INTEGER, TK_FLOAT, TK_BLOB, TK_VARIABLE, TK_TRUEFALSE:
+		mReq = jsonHasToken
+	case TK_NULL, TK_ASTERISK, TK_RAISE:
+		mOpt = jsonHasToken
+	case TK_NOT, TK_BITNOT, TK_UMINUS, TK_UPLUS, TK_ISNULL, TK_NOTNULL:
+		mReq = jsonHasLeft
+	case TK_CAST, TK_COLLATE:
+		mReq = jsonHasToken | jsonHasLeft
+	case TK_AND, TK_OR, TK_EQ, TK_NE, TK_LT, TK_LE, TK_GT, TK_GE, TK_IS,
+		TK_ISNOT, TK_BITAND, TK_BITOR, TK_LSHIFT, TK_RSHIFT, TK_PLUS,
+		TK_MINUS, TK_STAR, TK_SLASH, TK_REM, TK_CONCAT, TK_PTR, TK_DOT:
+		mReq = jsonHasLeft | jsonHasRight
+		if op == TK_PTR {
+			mReq |= jsonHasToken
+		}
+	case TK_LIMIT, TK_SELECT_COLUMN:
+		mReq = jsonHasLeft
+		mOpt = jsonHasRight
+	case TK_BETWEEN:
+		mReq = jsonHasLeft | jsonHasList
+		nMinList, nMaxList = 2, 2
+	case TK_IN:
+		/* Exactly one of the list and the select is present */
+		mReq = jsonHasLeft
+		if (mHas & jsonHasSelect) != 0 {
+			mReq |= jsonHasSelect
+		} else {
+			mReq |= jsonHasList
+		}
+		nMinList = 1
+	case TK_EXISTS, TK_SELECT:
+		mReq = jsonHasSelect
+	case TK_FUNCTION:
+		mReq = jsonHasToken
+		mOpt = jsonHasList
+	case TK_CASE:
+		mReq = jsonHasList
+		mOpt = jsonHasLeft
+		nMinList = 2
+	case TK_VECTOR:
+		mReq = jsonHasList
+		nMinList = 2
+	default:
+		jsonTreeError(pTree, "unknown expression op: %q", p.Op)
+		return
+	}
+
+	for _, m := range jsonExprMember {
+		if (mReq&m.mask) != 0 && (mHas&m.mask) == 0 {
+			jsonTreeError(pTree, "%s expression without a %s", p.Op, m.zName)
+		} else if ((mReq|mOpt)&m.mask) == 0 && (mHas&m.mask) != 0 {
+			jsonTreeError(pTree, "%s expression with an unexpected %s", p.Op, m.zName)
+		}
+	}
+	if p.List != nil && (len(p.List) < nMinList || (nMaxList > 0 && len(p.List) > nMaxList)) {
+		jsonTreeError(pTree, "%s expression has %d list items", p.Op, len(p.List))
+	}
+	if op == TK_TRUEFALSE && p.Token != nil && sqlite3IsTrueOrFalse([]byte(*p.Token)) == 0 {
+		jsonTreeError(pTree, "TRUEFALSE expression with token %q", *p.Token)
+	}
+	if op == TK_BLOB && p.Token != nil {
+		/* The tokenizer accepts only well-formed blob literals, and so
+		 ** sqlite3ExprLiteralValue() does not check them again. */
+		var tokenType int
+		if sqlite3GetToken([]byte(*p.Token), &tokenType) != len(*p.Token) || tokenType != TK_BLOB {
+			jsonTreeError(pTree, "malformed blob literal: %s", *p.Token)
+		}
+	}
+}
+
+/*
+** The routines that follow convert the JSON mirrors back into parse
+** tree objects.  The flags that the parser sets on an Expr or Select are
+** computed from the members that are present, in the same way as the
+** parser computes them.
+ */
+func exprFromJson(pTree *jsonTree, p *jsonExpr) *Expr {
+	if p == nil {
+		return nil
+	}
+	pNew := &Expr{}
+	pNew.op = uint8(jsonTreeToken(pTree, p.Op, "expression op", false))
+	pNew.iAgg = -1
+	jsonTreeCheckExpr(pTree, p, int(pNew.op))
+	if p.Int != nil {
+		/* As sqlite3ExprAlloc() does for an integer literal */
+		pNew.flags |= EP_IntValue | EP_Leaf
+		if *p.Int != 0 {
+			pNew.flags |= EP_IsTrue
+		} else {
+			pNew.flags |= EP_IsFalse
+		}
+		pNew.u.iValue = *p.Int
+	} else if p.Token != nil {
+		pNew.u.zToken = []byte(*p.Token)
+		if pNew.op == TK_TRUEFALSE {
+			pNew.flags |= sqlite3IsTrueOrFalse(pNew.u.zToken)
+		}
+	}
+	if p.Offset != nil {
+		pNew.w.iOfst = *p.Offset - pTree.iBase
+	}
+	if p.Start != nil || p.End != nil {
+		if p.Start == nil || p.End == nil || *p.End <= *p.Start || *p.Start < pTree.iBase {
+			jsonTreeError(pTree, "%s expression has a malformed span", p.Op)
+		} else {
+			pNew.iSpan = *p.Start - pTree.iBase
+			pNew.nSpan = *p.End - *p.Start
+		}
+	}
+	if p.DoubleQuoted {
+		pNew.flags |= EP_Quoted | EP_DblQuoted
+	} else if p.Quoted {
+		pNew.flags |= EP_Quoted
+	}
+	if p.Distinct {
+		pNew.flags |= EP_Distinct
+	}
+	if p.Infix {
+		pNew.flags |= EP_InfixFunc
+	}
+	switch pNew.op {
+	case TK_RAISE:
+		pNew.affExpr = rune(jsonTreeEnum(pTree, jsonOnError, p.OnError, "conflict resolution", OE_None))
+	case TK_VARIABLE:
+		pNew.iColumn = ynVar(p.Param)
+	case TK_SELECT_COLUMN:
+		if p.Field == nil {
+			jsonTreeError(pTree, "SELECT_COLUMN expression without a field")
+		} else {
+			pNew.iColumn = ynVar(*p.Field)
+		}
+		pNew.iTable = p.FieldCount
+	case TK_FUNCTION:
+		pNew.flags |= EP_HasFunc
+	}
+	pLeft := exprFromJson(pTree, p.Left)
+	pRight := exprFromJson(pTree, p.Right)
+	switch pNew.op {
+	case TK_COLLATE:
+		/* As sqlite3ExprAddCollateToken(), which does not propagate the
+		 ** flags of the operand or count it in the height */
+		pNew.flags |= EP_Collate | EP_Skip
+		pNew.pLeft = pLeft
+		pNew.pRight = pRight
+		pNew.nHeight = 1
+	case TK_SELECT_COLUMN:
+		/* As sqlite3ExprForVectorField() */
+		pNew.pLeft = pLeft
+		pNew.pRight = pRight
+		pNew.nHeight = 1
+	default:
+		sqlite3ExprAttachSubtrees(nil, pNew, pLeft, pRight)
+	}
+	if p.Select != nil {
+		if p.List != nil {
+			jsonTreeError(pTree, "%s expression has both a list and a select", p.Op)
+		}
+		pNew.x.pSelect = selectFromJson(pTree, p.Select)
+		pNew.flags |= EP_xIsSelect | EP_Subquery
+		exprSetHeight(pNew)
+	} else if p.List != nil {
+		/* The grammar counts the list in the height, and propagates its
+		 ** flags, only for a function, an IN list and a CASE.  A vector
+		 ** takes the flags of its first term.  */
+		pNew.x.pList = exprListFromJson(pTree, p.List)
+		switch pNew.op {
+		case TK_FUNCTION, TK_IN, TK_CASE:
+			exprSetHeight(pNew)
+		case TK_VECTOR:
+			if pNew.x.pList.a[0].pExpr != nil {
+				pNew.flags |= pNew.x.pList.a[0].pExpr.flags & EP_Propagate
+			}
+		}
+	} else if pNew.pLeft == nil && pNew.pRight == nil && pNew.nSpan > 0 {
+		/* As tokenExpr() does for a term of the grammar */
+		switch pNew.op {
+		case TK_ID, TK_STRING, TK_NULL, TK_FLOAT, TK_BLOB, TK_VARIABLE, TK_TRUEFALSE:
+			pNew.flags |= EP_Leaf
+		}
+	}
+	if p.Window != nil {
+		if pNew.op != TK_FUNCTION {
+			jsonTreeError(pTree, "%s expression has a window", p.Op)
+		}
+		pNew.flags |= EP_WinFunc
+		pNew.y.pWin = windowFromJson(pTree, p.Window)
+		pNew.y.pWin.pOwner = pNew
+	}
+	return pNew
+}
+func exprListFromJson(pTree *jsonTree, a []*jsonExprItem) *ExprList {
+	if len(a) == 0 {
+		return nil
+	}
+	pNew := &ExprList{}
+	pNew.nExpr = len(a)
+	pNew.nAlloc = len(a)
+	pNew.a = make([]ExprList_item, len(a))
+	for i, p := range a {
+		pItem := &pNew.a[i]
+		if p == nil || (p.Expr == nil && p.Name == nil) {
+			jsonTreeError(pTree, "list item without an expression or name")
+			continue
+		}
+		pItem.pExpr = exprFromJson(pTree, p.Expr)
+		if p.Name != nil {
+			pItem.zEName = []byte(*p.Name)
+			pItem.eEName = uint8(jsonTreeEnum(pTree, jsonNameKind, p.NameKind, "name kind", ENAME_NAME))
+		}
+		pItem.sortFlags = uint8(p.SortFlags)
+		if p.Nulls {
+			pItem.bNulls = 1
+		}
+	}
+	return pNew
+}
+func idListFromJson(a []string) *IdList {
+	if len(a) == 0 {
+		return nil
+	}
+	pNew := &IdList{}
+	for _, zName := range a {
+		pNew.a = append(pNew.a, struct {
+			zName []byte
+			idx   int
+			pExpr *Expr
+		}{zName: []byte(zName)})
+	}
+	pNew.nId = len(pNew.a)
+	return pNew
+}
+func srcListFromJson(pTree *jsonTree, a []*jsonSrcItem) *SrcList {
+	if len(a) == 0 {
+		return nil
+	}
+	pNew := &SrcList{}
+	pNew.nSrc = len(a)
+	pNew.nAlloc = uint32(len(a))
+	pNew.a = make([]SrcItem, len(a))
+	for i, p := range a {
+		pItem := &pNew.a[i]
+		if p == nil {
+			jsonTreeError(pTree, "null FROM clause term")
+			continue
+		}
+		pItem.zDatabase = jsonTreeName(p.Database)
+		pItem.zName = jsonTreeName(p.Name)
+		pItem.zAlias = jsonTreeName(p.Alias)
+		pItem.fg.jointype = uint8(p.JoinType)
+		pItem.iCursor = -1
+		pItem.pSelect = selectFromJson(pTree, p.Select)
+		pItem.u3.pOn = exprFromJson(pTree, p.On)
+		pItem.u3.pUsing = idListFromJson(p.Using)
+		pItem.u1.zIndexedBy = jsonTreeName(p.IndexedBy)
+		if p.NotIndexed {
+			pItem.fg.notIndexed = 1
+		}
+		pItem.u1.pFuncArg = exprListFromJson(pTree, p.FuncArgs)
+	}
+	return pNew
+}
+
+/*
+** Convert the name of a single object, such as the table of an ALTER
+** TABLE, into a SrcList with one entry.  zWhat describes the member, for
+** the error message.
+ */
+func srcItemFromJson(pTree *jsonTree, p *jsonSrcItem, zWhat string) *SrcList {
+	if p == nil || p.Name == "" {
+		jsonTreeError(pTree, "%s without a name", zWhat)
+		return &SrcList{nSrc: 1, nAlloc: 1, a: make([]SrcItem, 1)}
+	}
+	return srcListFromJson(pTree, []*jsonSrcItem{p})
+}
+func selectFromJson(pTree *jsonTree, p *jsonSelect) *Select {
+	var pRet *Select
+	var pNext *Select
+	var selFlags uint32
+	pp := &pRet
+	if p != nil && p.Prior != nil {
+		/* As parserDoubleLinkSelect() does */
+		selFlags = SF_Compound
+	}
+	for ; p != nil; p = p.Prior {
+		pNew := &Select{}
+		pNew.op = uint8(jsonTreeToken(pTree, p.Op, "select op", false))
+		switch pNew.op {
+		case TK_UNION, TK_ALL, TK_EXCEPT, TK_INTERSECT:
+			if p.Prior == nil {
+				jsonTreeError(pTree, "%s select without a prior select", p.Op)
+			}
+		case TK_SELECT:
+			if p.Prior != nil {
+				jsonTreeError(pTree, "SELECT select with a prior select")
+			}
+		default:
+			jsonTreeError(pTree, "unknown select op: %q", p.Op)
+		}
+		if len(p.Result) == 0 {
+			jsonTreeError(pTree, "select without a result list")
+		}
+		pNew.selFlags = selFlags
+		if p.Distinct {
+			pNew.selFlags |= SF_Distinct
+		}
+		if p.All {
+			pNew.selFlags |= SF_All
+		}
+		if p.Values {
+			pNew.selFlags |= SF_Values
+		}
+		if p.MultiValue {
+			pNew.selFlags |= SF_MultiValue
+		}
+		if p.NestedFrom {
+			pNew.selFlags |= SF_NestedFrom
+		}
+		pNew.pEList = exprListFromJson(pTree, p.Result)
+		pNew.pSrc = srcListFromJson(pTree, p.From)
+		if pNew.pSrc == nil {
+			pNew.pSrc = &SrcList{}
+		}
+		pNew.pWhere = exprFromJson(pTree, p.Where)
+		pNew.pGroupBy = exprListFromJson(pTree, p.GroupBy)
+		pNew.pHaving = exprFromJson(pTree, p.Having)
+		pNew.pOrderBy = exprListFromJson(pTree, p.OrderBy)
+		pNew.pLimit = exprFromJson(pTree, p.Limit)
+		pNew.pWith = withFromJson(pTree, p.With)
+		ppWin := &pNew.pWinDefn
+		for _, pWin := range p.Windows {
+			*ppWin = windowFromJson(pTree, pWin)
+			ppWin = &(*ppWin).pNextWin
+		}
+		pNew.addrOpenEphm[0] = -1
+		pNew.addrOpenEphm[1] = -1
+		pNew.pNext = pNext
+		gatherSelectWindows(pNew)
+		*pp = pNew
+		pp = &pNew.pPrior
+		pNext = pNew
+	}
+	return pRet
+}
+func windowFromJson(pTree *jsonTree, p *jsonWindow) *Window {
+	pNew := &Window{}
+	if p == nil {
+		jsonTreeError(pTree, "null window")
+		return pNew
+	}
+	pNew.zName = jsonTreeName(p.Name)
+	pNew.zBase = jsonTreeName(p.Base)
+	pNew.pPartition = exprListFromJson(pTree, p.Partition)
+	pNew.pOrderBy = exprListFromJson(pTree, p.OrderBy)
+	pNew.eFrmType = uint8(jsonTreeToken(pTree, p.FrameType, "window frame type", true))
+	pNew.eStart = uint8(jsonTreeToken(pTree, p.Start, "window frame start", true))
+	pNew.eEnd = uint8(jsonTreeToken(pTree, p.End, "window frame end", true))
+	pNew.eExclude = uint8(jsonTreeToken(pTree, p.Exclude, "window frame exclusion", true))
+	pNew.pStart = exprFromJson(pTree, p.StartExpr)
+	pNew.pEnd = exprFromJson(pTree, p.EndExpr)
+	pNew.pFilter = exprFromJson(pTree, p.Filter)
+	if p.ImplicitFrame {
+		pNew.bImplicitFrame = 1
+	}
+	return pNew
+}
+func withFromJson(pTree *jsonTree, p *jsonWith) *With {
+	if p == nil {
+		return nil
+	}
+	pNew := &With{}
+	pNew.nCte = len(p.Ctes)
+	pNew.a = make([]Cte, len(p.Ctes))
+	for i, pCte := range p.Ctes {
+		if pCte == nil || pCte.Name == "" || pCte.Select == nil {
+			jsonTreeError(pTree, "malformed common table expression")
+			continue
+		}
+		pNew.a[i].zName = []byte(pCte.Name)
+		pNew.a[i].pCols = exprListFromJson(pTree, pCte.Columns)
+		pNew.a[i].pSelect = selectFromJson(pTree, pCte.Select)
+		pNew.a[i].eM10d = uint8(jsonTreeEnum(pTree, jsonMaterialized, pCte.Materialized, "materialization", M10d_Any))
+	}
+	return pNew
+}
+func upsertFromJson(pTree *jsonTree, a []*jsonUpsert) *Upsert {
+	var pRet *Upsert
+	for i := len(a) - 1; i >= 0; i-- {
+		p := a[i]
+		if p == nil {
+			jsonTreeError(pTree, "null upsert clause")
+			continue
+		}
+		if p.Target == nil && i < len(a)-1 {
+			jsonTreeError(pTree, "only the last upsert clause may omit the target")
+		}
+		pRet = sqlite3UpsertNew(nil,
+			exprListFromJson(pTree, p.Target),
+			exprFromJson(pTree, p.TargetWhere),
+			exprListFromJson(pTree, p.Set),
+			exprFromJson(pTree, p.Where),
+			pRet,
+		)
+	}
+	return pRet
+}
+
+/*
+** Build the table described by p.  The columns are added by
+** sqlite3AddColumn(), so that their types and affinities are worked out
+** as the parser works them out.  The indexes are resolved against the
+** finished columns by sqlite3ResolveIndex().
+ */
+func tableFromJson(pTree *jsonTree, p *jsonTable) *Table {
+	var sParse Parse
+	var pPk *Column
+	var nPk int
+	if p == nil {
+		return nil
+	}
+	if p.Name == "" || len(p.Columns) == 0 {
+		jsonTreeError(pTree, "table without a name or columns")
+	}
+	sParse.db = pTree.db
+	pNew := &Table{}
+	pNew.zName = []byte(p.Name)
+	pNew.iPKey = -1
+	pNew.nTabRef = 1
+	sParse.pNewTable = pNew
+	for _, pCol := range p.Columns {
+		if pCol == nil || pCol.Name == "" {
+			jsonTreeError(pTree, "table column without a name")
+			continue
+		}
+		var sType Token
+		if pCol.Type != "" {
+			sType = jsonTreeIdToken(pCol.Type)
+		}
+		sqlite3AddColumn(&sParse, jsonTreeIdToken(pCol.Name), sType)
+		if sParse.nErr != 0 {
+			jsonTreeError(pTree, "%s", sParse.zErrMsg)
+			return pNew
+		}
+		pNewCol := &pNew.aCol[pNew.nCol-1]
+		if pCol.Affinity != "" {
+			pNewCol.affinity = rune(jsonTreeEnum(pTree, jsonAffinity, pCol.Affinity, "affinity", 0) + SQLITE_AFF_NONE)
+		}
+		if pCol.Collation != "" {
+			sqlite3ColumnSetColl(pTree.db, pNewCol, []byte(pCol.Collation))
+		}
+		pNewCol.notNull = uint8(jsonTreeEnum(pTree, jsonOnError, pCol.NotNull, "conflict resolution", OE_None))
+		if pNewCol.notNull != OE_None {
+			pNew.tabFlags |= TF_HasNotNull
+		}
+		if pCol.PrimaryKey {
+			pNewCol.colFlags |= COLFLAG_PRIMKEY
+			pNew.tabFlags |= TF_HasPrimaryKey
+			pPk = pNewCol
+			nPk++
+		}
+	}
+	sParse.pNewTable = nil
+
+	/* Each index is resolved against the columns of the table.  The list
+	 ** of indexes is kept in the order written.  */
+	ppIdx := &pNew.pIndex
+	bPkIndex := false
+	for _, pIdx := range p.Indexes {
+		if pIdx == nil {
+			jsonTreeError(pTree, "null table index")
+			continue
+		}
+		pIndex := indexFromJson(pTree, pIdx)
+		if pIndex == nil {
+			continue
+		}
+		if pIndex.onError == OE_None {
+			jsonTreeError(pTree, "index %s of a table is not unique", pIdx.Name)
+		}
+		pIndex.idxType = SQLITE_IDXTYPE_UNIQUE
+		if pIdx.PrimaryKey {
+			pIndex.idxType = SQLITE_IDXTYPE_PRIMARYKEY
+			bPkIndex = true
+		}
+		if sqlite3ResolveIndex(&sParse, pIndex, pNew) != 0 {
+			jsonTreeError(pTree, "%s", sParse.zErrMsg)
+			return pNew
+		}
+		*ppIdx = pIndex
+		ppIdx = &pIndex.pNext
+	}
+
+	/* A PRIMARY KEY without an index is an INTEGER PRIMARY KEY, which is
+	 ** the rowid.  See sqlite3AddPrimaryKey().  */
+	if nPk > 0 && !bPkIndex {
+		if nPk > 1 || pPk.eCType != COLTYPE_INTEGER {
+			jsonTreeError(pTree, "PRIMARY KEY of table %s has no index", p.Name)
+		}
+		pNew.iPKey = int16(sqlite3ColumnIndex(pNew, pPk.zCnName))
+		pNew.keyConf = uint8(jsonTreeEnum(pTree, jsonOnError, p.OnError, "conflict resolution", OE_None))
+		if p.Autoincrement {
+			pNew.tabFlags |= TF_Autoincrement
+		}
+	} else if p.Autoincrement {
+		jsonTreeError(pTree, "AUTOINCREMENT is only allowed on an INTEGER PRIMARY KEY")
+	}
+	if p.WithoutRowid {
+		pNew.tabFlags |= TF_WithoutRowid | TF_NoVisibleRowid
+	}
+	if p.Strict {
+		pNew.tabFlags |= TF_Strict
+	}
+
+	/* Foreign keys are linked in the order that sqlite3CreateForeignKey()
+	 ** links them, so the last declared is first.  */
+	for _, pFk := range p.ForeignKeys {
+		if pFk == nil {
+			jsonTreeError(pTree, "null foreign key")
+			continue
+		}
+		if len(pFk.Columns) == 0 ||
+			(pFk.ToColumns != nil && len(pFk.ToColumns) != len(pFk.Columns)) {
+			jsonTreeError(pTree, "malformed foreign key on table %s", p.Name)
+			continue
+		}
+		pFKey := &FKey{}
+		pFKey.pFrom = pNew
+		pFKey.pNextFrom = pNew.u.tab.pFKey
+		pFKey.zTo = []byte(pFk.Table)
+		pFKey.nCol = len(pFk.Columns)
+		pFKey.aCol = make([]struct {
+			iFrom int
+			zCol  []byte
+		}, pFKey.nCol)
+		for i, zCol := range pFk.Columns {
+			pFKey.aCol[i].iFrom = sqlite3ColumnIndex(pNew, []byte(zCol))
+			if pFKey.aCol[i].iFrom < 0 {
+				jsonTreeError(pTree, "unknown column %q in foreign key definition", zCol)
+				pFKey.aCol[i].iFrom = 0
+			}
+			if pFk.ToColumns != nil {
+				pFKey.aCol[i].zCol = []byte(pFk.ToColumns[i])
+			}
+		}
+		pFKey.aAction[0] = uint8(jsonTreeEnum(pTree, jsonOnError, pFk.OnDelete, "foreign key action", OE_None))
+		pFKey.aAction[1] = uint8(jsonTreeEnum(pTree, jsonOnError, pFk.OnUpdate, "foreign key action", OE_None))
+		if pFk.Deferred {
+			pFKey.isDeferred = 1
+		}
+		pNew.u.tab.pFKey = pFKey
+	}
+	return pNew
+}
+func viewFromJson(pTree *jsonTree, p *jsonView) *Table {
+	if p == nil {
+		return nil
+	}
+	if p.Name == "" {
+		jsonTreeError(pTree, "view without a name")
+	}
+	pNew := &Table{}
+	pNew.zName = []byte(p.Name)
+	pNew.iPKey = -1
+	pNew.nTabRef = 1
+	pNew.tabFlags = TF_NoVisibleRowid
+	pNew.eTabType = TABTYP_VIEW
+	for _, zCol := range p.ColumnList {
+		pNew.pCheck = sqlite3ExprListAppend(nil, pNew.pCheck, nil)
+		pNew.pCheck.a[pNew.pCheck.nExpr-1].zEName = []byte(zCol)
+	}
+	for _, pCol := range p.Columns {
+		if pCol == nil {
+			jsonTreeError(pTree, "null view column")
+			continue
+		}
+		pNewCol := Column{}
+		pNewCol.zCnName = []byte(pCol.Name)
+		if pCol.Type != "" {
+			pNewCol.zCnName = append(append(append(pNewCol.zCnName, 0), pCol.Type...), 0)
+			pNewCol.colFlags |= COLFLAG_HASTYPE
+		}
+		if pCol.Collation != "" {
+			sqlite3ColumnSetColl(pTree.db, &pNewCol, []byte(pCol.Collation))
+		}
+		pNewCol.affinity = rune(jsonTreeEnum(pTree, jsonAffinity, pCol.Affinity, "affinity", 0) + SQLITE_AFF_NONE)
+		pNewCol.notNull = uint8(jsonTreeEnum(pTree, jsonOnError, pCol.NotNull, "conflict resolution", OE_None))
+		pNew.aCol = append(pNew.aCol, pNewCol)
+	}
+	pNew.nCol = int16(len(pNew.aCol))
+	if p.Select == nil {
+		jsonTreeError(pTree, "view without a select")
+	}
+	pNew.u.view.pSelect = selectFromJson(pTree, p.Select)
+	if pNew.u.view.pSelect != nil {
+		pNew.u.view.pSelect.selFlags |= SF_View
+	}
+	return pNew
+}
+func indexFromJson(pTree *jsonTree, p *jsonIndex) *Index {
+	if p == nil {
+		return nil
+	}
+	if p.Name == "" || p.Table == "" {
+		jsonTreeError(pTree, "index without a name or table")
+	}
+	pList := exprListFromJson(pTree, p.Columns)
+	if pList == nil {
+		jsonTreeError(pTree, "index without columns")
+		return nil
+	}
+	pNew := sqlite3AllocateIndexObject(nil, pList.nExpr)
+	pNew.zName = []byte(p.Name)
+	pNew.pTable = &Table{}
+	pNew.pTable.zName = []byte(p.Table)
+	pNew.pTable.iPKey = -1
+	pNew.onError = uint8(jsonTreeEnum(pTree, jsonOnError, p.OnError, "conflict resolution", OE_None))
+	pNew.idxType = SQLITE_IDXTYPE_APPDEF
+	pNew.aColExpr = pList
+	for i := 0; i < pList.nExpr; i++ {
+		pItem := &pList.a[i]
+		if pItem.pExpr != nil && pItem.pExpr.op == TK_COLLATE {
+			pNew.azColl[i] = pItem.pExpr.u.zToken
+		}
+		pNew.aSortOrder[i] = pItem.sortFlags
+	}
+	pNew.pPartIdxWhere = exprFromJson(pTree, p.Where)
+	return pNew
+}
+func triggerFromJson(pTree *jsonTree, p *jsonTrigger) *Trigger {
+	if p == nil {
+		return nil
+	}
+	if p.Name == "" || p.Table == "" || len(p.Steps) == 0 {
+		jsonTreeError(pTree, "trigger without a name, table or steps")
+	}
+	pNew := &Trigger{}
+	pNew.zName = []byte(p.Name)
+	pNew.table = []byte(p.Table)
+	pNew.tr_tm = uint8(jsonTreeEnum(pTree, jsonTriggerTiming, p.Timing, "trigger timing", TRIGGER_BEFORE))
+	if pNew.tr_tm == jsonTriggerInstead {
+		pNew.bInstead = 1
+		pNew.tr_tm = TRIGGER_BEFORE
+	}
+	pNew.op = uint8(jsonTreeToken(pTree, p.Event, "trigger event", false))
+	switch pNew.op {
+	case TK_INSERT, TK_UPDATE, TK_DELETE:
+	default:
+		jsonTreeError(pTree, "unknown trigger event: %q", p.Event)
+	}
+	pNew.pColumns = idListFromJson(p.Columns)
+	pNew.pWhen = exprFromJson(pTree, p.When)
+	pp := &pNew.step_list
+	for _, pStep := range p.Steps {
+		if pStep == nil {
+			jsonTreeError(pTree, "null trigger step")
+			continue
+		}
+		*pp = &TriggerStep{
+			op:        uint8(jsonTreeToken(pTree, pStep.Op, "trigger step op", false)),
+			orconf:    uint8(jsonTreeEnum(pTree, jsonOnError, pStep.OnError, "conflict resolution", OE_Default)),
+			pTrig:     pNew,
+			pSelect:   selectFromJson(pTree, pStep.Select),
+			zTarget:   jsonTreeName(pStep.Target),
+			pFrom:     srcListFromJson(pTree, pStep.From),
+			pWhere:    exprFromJson(pTree, pStep.Where),
+			pExprList: exprListFromJson(pTree, pStep.Set),
+			pIdList:   idListFromJson(pStep.Columns),
+			pUpsert:   upsertFromJson(pTree, pStep.Upsert),
+			zSpan:     []byte(pStep.SQL),
+		}
+		switch (*pp).op {
+		case TK_SELECT:
+			if pStep.Select == nil {
+				jsonTreeError(pTree, "SELECT trigger step without a select")
+			}
+		case TK_INSERT, TK_UPDATE, TK_DELETE:
+			if pStep.Target == "" {
+				jsonTreeError(pTree, "%s trigger step without a target", pStep.Op)
+			} else if (*pp).op == TK_UPDATE && len(pStep.Set) == 0 {
+				jsonTreeError(pTree, "UPDATE trigger step without a SET clause")
+			}
+		default:
+			jsonTreeError(pTree, "unknown trigger step op: %q", pStep.Op)
+		}
+		pNew.step_list.pLast = *pp
+		pp = &(*pp).pNext
+	}
+	return pNew
+}
+func dropFromJson(pTree *jsonTree, p *jsonDrop) *Drop {
+	if p == nil {
+		return nil
+	}
+	pNew := &Drop{}
+	pNew.op = uint8(jsonTreeToken(pTree, p.Op, "drop op", false))
+	switch pNew.op {
+	case TK_TABLE, TK_VIEW, TK_INDEX, TK_TRIGGER:
+	default:
+		jsonTreeError(pTree, "unknown drop op: %q", p.Op)
+	}
+	pNew.pName = srcItemFromJson(pTree, p.Object, "DROP")
+	if p.IfExists {
+		pNew.noErr = 1
+	}
+	return pNew
+}
+func alterFromJson(pTree *jsonTree, p *jsonAlter) *Alter {
+	if p == nil {
+		return nil
+	}
+	pNew := &Alter{}
+	pNew.op = uint8(jsonTreeToken(pTree, p.Op, "alter op", false))
+	pNew.pSrc = srcItemFromJson(pTree, p.Table, "ALTER TABLE")
+	pNew.zOld = jsonTreeName(p.Column)
+	pNew.zNew = jsonTreeName(p.NewName)
+	pNew.pNew = tableFromJson(pTree, p.Definition)
+	switch pNew.op {
+	case TK_RENAME:
+		if pNew.zNew == nil {
+			jsonTreeError(pTree, "ALTER TABLE RENAME without a new name")
+		}
+	case TK_ADD:
+		if pNew.pNew == nil || pNew.pNew.nCol != 1 {
+			jsonTreeError(pTree, "ALTER TABLE ADD COLUMN without a column")
+		}
+	case TK_DROP:
+		if pNew.zOld == nil {
+			jsonTreeError(pTree, "ALTER TABLE DROP COLUMN without a column")
+		}
+	default:
+		jsonTreeError(pTree, "unknown alter op: %q", p.Op)
+	}
+	return pNew
+}
+func pragmaFromJson(pTree *jsonTree, p *jsonPragma) *Pragma {
+	if p == nil {
+		return nil
+	}
+	if p.Name == "" {
+		jsonTreeError(pTree, "PRAGMA without a name")
+	}
+	pNew := &Pragma{}
+	pNew.zDb = jsonTreeName(p.Database)
+	pNew.zLeft = []byte(p.Name)
+	if p.Value != nil {
+		pNew.zRight = []byte(*p.Value)
+	}
+	return pNew
+}
+func attachFromJson(pTree *jsonTree, p *jsonAttach) *Attach {
+	if p == nil {
+		return nil
+	}
+	pNew := &Attach{}
+	pNew.op = uint8(jsonTreeToken(pTree, p.Op, "attach op", false))
+	if pNew.op != TK_ATTACH && pNew.op != TK_DETACH {
+		jsonTreeError(pTree, "unknown attach op: %q", p.Op)
+	}
+	if p.Database == nil || (pNew.op == TK_ATTACH) != (p.Filename != nil) {
+		jsonTreeError(pTree, "malformed %s statement", p.Op)
+	}
+	pNew.pFilename = exprFromJson(pTree, p.Filename)
+	pNew.pDbname = exprFromJson(pTree, p.Database)
+	pNew.pKey = exprFromJson(pTree, p.Key)
+	return pNew
+}
+
+/*
+** Check that a, the "table" member of an INSERT, UPDATE or DELETE, names
+** the table written.  It may hold further terms, the FROM clause, only if
+** bFrom is true.
+ */
+func jsonTreeCheckTarget(pTree *jsonTree, a []*jsonSrcItem, zWhat string, bFrom bool) {
+	if len(a) == 0 || a[0] == nil || a[0].Name == "" {
+		jsonTreeError(pTree, "%s without a table", zWhat)
+	} else if len(a) > 1 && !bFrom {
+		jsonTreeError(pTree, "%s with more than one table", zWhat)
+	}
+}
+func stmtFromJson(pTree *jsonTree, p *jsonStmt) *Stmt {
+	nTree := 0
+	for _, bTree := range []bool{
+		p.Select != nil, p.Delete != nil, p.Update != nil, p.Insert != nil,
+		p.Trigger != nil, p.Table != nil, p.View != nil, p.Index != nil,
+		p.Drop != nil, p.Alter != nil, p.Pragma != nil, p.Attach != nil,
+	} {
+		if bTree {
+			nTree++
+		}
+	}
+	if nTree > 1 {
+		jsonTreeError(pTree, "statement has more than one parse tree")
+	}
+
+	pNew := &Stmt{}
+	pTree.iBase = p.Offset
+	pNew.zSql = []byte(p.SQL)
+	pNew.iOfst = p.Offset
+	pNew.iBase = p.Offset
+	pNew.explain = uint8(p.Explain)
+	pNew.pSelect = selectFromJson(pTree, p.Select)
+	if p.Delete != nil {
+		jsonTreeCheckTarget(pTree, p.Delete.Table, "DELETE", false)
+		pNew.pDelete = &Delete{
+			pTabList:   srcListFromJson(pTree, p.Delete.Table),
+			pWhere:     exprFromJson(pTree, p.Delete.Where),
+			pOrderBy:   exprListFromJson(pTree, p.Delete.OrderBy),
+			pLimit:     exprFromJson(pTree, p.Delete.Limit),
+			pReturning: exprListFromJson(pTree, p.Delete.Returning),
+		}
+	}
+	if p.Update != nil {
+		jsonTreeCheckTarget(pTree, p.Update.Table, "UPDATE", true)
+		if len(p.Update.Set) == 0 {
+			jsonTreeError(pTree, "UPDATE without a SET clause")
+		}
+		pNew.pUpdate = &Update{
+			pTabList:   srcListFromJson(pTree, p.Update.Table),
+			pChanges:   exprListFromJson(pTree, p.Update.Set),
+			pWhere:     exprFromJson(pTree, p.Update.Where),
+			onError:    jsonTreeEnum(pTree, jsonOnError, p.Update.OnError, "conflict resolution", OE_Default),
+			pOrderBy:   exprListFromJson(pTree, p.Update.OrderBy),
+			pLimit:     exprFromJson(pTree, p.Update.Limit),
+			pUpsert:    upsertFromJson(pTree, p.Update.Upsert),
+			pReturning: exprListFromJson(pTree, p.Update.Returning),
+		}
+	}
+	if p.Insert != nil {
+		jsonTreeCheckTarget(pTree, p.Insert.Table, "INSERT", false)
+		pNew.pInsert = &Insert{
+			pTabList:   srcListFromJson(pTree, p.Insert.Table),
+			pColumn:    idListFromJson(p.Insert.Columns),
+			pSelect:    selectFromJson(pTree, p.Insert.Select),
+			onError:    jsonTreeEnum(pTree, jsonOnError, p.Insert.OnError, "conflict resolution", OE_Default),
+			pUpsert:    upsertFromJson(pTree, p.Insert.Upsert),
+			pReturning: exprListFromJson(pTree, p.Insert.Returning),
+		}
+	}
+	pNew.pTrigger = triggerFromJson(pTree, p.Trigger)
+	pNew.pTable = tableFromJson(pTree, p.Table)
+	if p.View != nil {
+		pNew.pTable = viewFromJson(pTree, p.View)
+	}
+	pNew.pIndex = indexFromJson(pTree, p.Index)
+	pNew.pDrop = dropFromJson(pTree, p.Drop)
+	pNew.pAlter = alterFromJson(pTree, p.Alter)
+	pNew.pPragma = pragmaFromJson(pTree, p.Pragma)
+	pNew.pAttach = attachFromJson(pTree, p.Attach)
+	for _, pIssue := range p.VersionIssues {
+		if pIssue != nil {
+			pNew.aVersion = append(pNew.aVersion, VersionIssue{
+				Feature: pIssue.Feature,
+				Version: pIssue.Version,
+				Offset:  pIssue.Offset,
+				Length:  pIssue.Length,
+			})
+		}
+	}
+	return pNew
+}
+
+/*
+** EncodeJSON returns the JSON encoding of the statements in aStmt, in
+** the format described at the top of this file.
+ */
+func EncodeJSON(aStmt []*Stmt) ([]byte, error) {
+	doc := jsonDoc{Version: JSONFormatVersion, Statements: []*jsonStmt{}}
+	for _, p := range aStmt {
+		doc.Statements = append(doc.Statements, jsonFromStmt(p))
+	}
+	return json.Marshal(&doc)
+}
+
+/*
+** DecodeJSON converts a document written by EncodeJSON back into
+** statements.  An *Error is returned if the document is not valid JSON,
+** has a different version, or describes a malformed parse tree.
+**
+** Decoding restores the parse trees but not what the parser records
+** outside of them, such as the span of a FROM clause.
+ */
+func DecodeJSON(data []byte) ([]*Stmt, error) {
+	var doc jsonDoc
+	var tree jsonTree
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, &Error{Code: SQLITE_ERROR, Msg: err.Error(), Offset: -1}
+	}
+	if doc.Version != JSONFormatVersion {
+		return nil, &Error{Code: SQLITE_ERROR, Msg: fmt.Sprintf("unsupported JSON format version: %d", doc.Version), Offset: -1}
+	}
+	tree.db = openDatabase()
+	aStmt := make([]*Stmt, 0, len(doc.Statements))
+	for _, p := range doc.Statements {
+		if p == nil {
+			jsonTreeError(&tree, "null statement")
+			break
+		}
+		aStmt = append(aStmt, stmtFromJson(&tree, p))
+	}
+	if tree.err != nil {
+		return nil, tree.err
+	}
+	return aStmt, nil
+}
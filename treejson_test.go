@@ -0,0 +1,190 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Tests for the JSON encoding of parse trees.
+ */
+package internal
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+/*
+** Statements of every kind that EncodeJSON describes.
+ */
+var testJsonCorpus = []string{
+	"SELECT DISTINCT a, b AS c FROM t WHERE a > 1 GROUP BY b HAVING count(*) > 2 ORDER BY 1 DESC LIMIT 10 OFFSET 5",
+	"SELECT * FROM t1 LEFT JOIN t2 ON t1.a = t2.b JOIN t3 USING (c) WHERE t1.x IN (SELECT y FROM u)",
+	"WITH c(x) AS (SELECT 1 UNION ALL SELECT x+1 FROM c WHERE x<10) SELECT x FROM c",
+	"SELECT sum(a) OVER w, rank() OVER (PARTITION BY b ORDER BY c ROWS BETWEEN 1 PRECEDING AND CURRENT ROW) FROM t WINDOW w AS (ORDER BY a)",
+	"SELECT count(*) FILTER (WHERE a > 1), CASE a WHEN 1 THEN 'x' END, CAST(b AS TEXT), c COLLATE nocase, x'00', ?1, :name, \"q\" FROM t",
+	"SELECT (a, b) = (1, 2), a IS NOT DISTINCT FROM b, EXISTS (SELECT 1) FROM t",
+	"VALUES(1),(2),(3)",
+	"VALUES(1) UNION ALL VALUES(2)",
+	"SELECT 1 UNION ALL VALUES(1),(2)",
+	"INSERT INTO t(a, b) VALUES(1, 2) ON CONFLICT(a) DO UPDATE SET b = excluded.b WHERE b > 0 RETURNING *",
+	"UPDATE t SET a = a + 1, (b, c) = (1, 2) FROM u WHERE t.x = u.x",
+	"DELETE FROM t WHERE a IS NULL",
+	"CREATE TABLE p(a INTEGER PRIMARY KEY, b TEXT NOT NULL COLLATE nocase DEFAULT 'x', c AS (a+1) STORED, UNIQUE(b, c), CHECK(a > 0))",
+	"CREATE TABLE c(x PRIMARY KEY REFERENCES p ON DELETE CASCADE, y, FOREIGN KEY(y) REFERENCES p(b) DEFERRABLE INITIALLY DEFERRED) WITHOUT ROWID",
+	"CREATE VIEW v(x, y) AS SELECT a, b FROM p",
+	"CREATE UNIQUE INDEX i ON p(b DESC, c) WHERE a > 1",
+	"CREATE TRIGGER tr AFTER UPDATE OF a ON p WHEN new.a > 1 BEGIN SELECT RAISE(ABORT, 'no'); DELETE FROM c WHERE x = old.a; END",
+	"DROP TABLE IF EXISTS p",
+	"ALTER TABLE p RENAME COLUMN a TO z",
+	"PRAGMA main.cache_size = 10",
+	"ATTACH 'file.db' AS aux",
+	"EXPLAIN QUERY PLAN SELECT 1",
+}
+
+/*
+** Decoding an encoded document and encoding it again must reproduce the
+** document exactly.
+ */
+func TestJSONRoundTrip(t *testing.T) {
+	for _, zSql := range testJsonCorpus {
+		aStmt, err := ParseSQL(zSql, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", zSql, err)
+		}
+		aJson, err := EncodeJSON(aStmt)
+		if err != nil {
+			t.Fatalf("%s: %v", zSql, err)
+		}
+		aDecoded, err := DecodeJSON(aJson)
+		if err != nil {
+			t.Errorf("%s: DecodeJSON: %v", zSql, err)
+			continue
+		}
+		aAgain, err := EncodeJSON(aDecoded)
+		if err != nil {
+			t.Fatalf("%s: %v", zSql, err)
+		}
+		if !bytes.Equal(aJson, aAgain) {
+			t.Errorf("%s: round trip differs:\n%s\n%s", zSql, aJson, aAgain)
+		}
+		if pSel := aStmt[0].Select(); pSel != nil {
+			if zGot := aDecoded[0].Select().SQL(); zGot != pSel.SQL() {
+				t.Errorf("%s: decoded tree renders as %s", zSql, zGot)
+			}
+		}
+	}
+}
+
+/*
+** DecodeJSON must reject text that is not JSON and documents written in
+** a different version of the format.
+ */
+func TestJSONDecodeError(t *testing.T) {
+	zOther := strconv.Itoa(JSONFormatVersion + 1)
+	for _, zJson := range []string{
+		"",
+		"{",
+		"[]",
+		`{"version":` + zOther + `,"statements":[]}`,
+		`{"version":` + strconv.Itoa(JSONFormatVersion) + `,"statements":[null]}`,
+	} {
+		if _, err := DecodeJSON([]byte(zJson)); err == nil {
+			t.Errorf("DecodeJSON(%q) succeeded", zJson)
+		} else if _, ok := err.(*Error); !ok {
+			t.Errorf("DecodeJSON(%q) returned %T, want *Error", zJson, err)
+		}
+	}
+}
+
+/*
+** DecodeJSON must reject parse trees that are missing members the
+** parser always sets, or that have the wrong operands for their kind.
+ */
+func TestJSONDecodeMalformed(t *testing.T) {
+	zSel := func(zExpr string) string {
+		return `{"select":{"op":"SELECT","result":[{"expr":` + zExpr + `}]}}`
+	}
+	aTest := []struct {
+		zStmt string
+		zErr  string
+	}{
+		{zSel(`{"op":"PLUS","left":{"op":"INTEGER","int":1}}`),
+			"PLUS expression without a right operand"},
+		{zSel(`{"op":"NOT"}`),
+			"NOT expression without a left operand"},
+		{zSel(`{"op":"ID"}`),
+			"ID expression without a token"},
+		{zSel(`{"op":"NULL","left":{"op":"ID","token":"a"}}`),
+			"NULL expression with an unexpected left operand"},
+		{zSel(`{"op":"BETWEEN","left":{"op":"ID","token":"a"},"list":[{"expr":{"op":"INTEGER","int":1}}]}`),
+			"BETWEEN expression has 1 list items"},
+		{zSel(`{"op":"IN","left":{"op":"ID","token":"a"}}`),
+			"IN expression without a list"},
+		{zSel(`{"op":"IN","left":{"op":"ID","token":"a"},"list":[{"expr":{"op":"INTEGER","int":1}}],` +
+			`"select":{"op":"SELECT","result":[{"expr":{"op":"INTEGER","int":1}}]}}`),
+			"IN expression with an unexpected list"},
+		{zSel(`{"op":"EXISTS"}`),
+			"EXISTS expression without a select"},
+		{zSel(`{"op":"FUNCTION","list":[{"expr":{"op":"ID","token":"a"}}]}`),
+			"FUNCTION expression without a token"},
+		{zSel(`{"op":"VECTOR","list":[{"expr":{"op":"ID","token":"a"}}]}`),
+			"VECTOR expression has 1 list items"},
+		{zSel(`{"op":"TRUEFALSE","token":"maybe"}`),
+			`TRUEFALSE expression with token "maybe"`},
+		{zSel(`{"op":"BLOB","token":"x'0g'"}`),
+			"malformed blob literal: x'0g'"},
+		{zSel(`{"op":"BLOB","token":"x'abc'"}`),
+			"malformed blob literal: x'abc'"},
+		{zSel(`{"op":"BLOB","token":"'ab'"}`),
+			"malformed blob literal: 'ab'"},
+		{zSel(`{"op":"BLOB","token":"x'ab' "}`),
+			"malformed blob literal: x'ab' "},
+		{zSel(`{"op":"SEMI"}`),
+			`unknown expression op: "SEMI"`},
+		{`{"select":{"op":"SELECT"}}`,
+			"select without a result list"},
+		{`{"select":{"op":"UNION","result":[{"expr":{"op":"NULL"}}]}}`,
+			"UNION select without a prior select"},
+		{`{"select":{"op":"SELECT","result":[{"expr":{"op":"NULL"}}],` +
+			`"with":{"ctes":[{"name":"c"}]}}}`,
+			"malformed common table expression"},
+		{`{"select":{"op":"SELECT","result":[{"expr":{"op":"NULL"}}]},"pragma":{"name":"x"}}`,
+			"statement has more than one parse tree"},
+		{`{"delete":{"table":[]}}`,
+			"DELETE without a table"},
+		{`{"insert":{"table":[{"name":"t"},{"name":"u"}]}}`,
+			"INSERT with more than one table"},
+		{`{"update":{"table":[{"name":"t"}]}}`,
+			"UPDATE without a SET clause"},
+		{`{"table":{"name":"t"}}`,
+			"table without a name or columns"},
+		{`{"table":{"name":"t","columns":[{"type":"INT"}]}}`,
+			"table column without a name"},
+		{`{"index":{"name":"i","onError":"none","columns":[{"expr":{"op":"ID","token":"a"}}]}}`,
+			"index without a name or table"},
+		{`{"trigger":{"name":"tr","table":"t","timing":"after","event":"INSERT"}}`,
+			"trigger without a name, table or steps"},
+		{`{"trigger":{"name":"tr","table":"t","timing":"after","event":"SELECT",` +
+			`"steps":[{"op":"DELETE","sql":"DELETE FROM u","target":"u"}]}}`,
+			`unknown trigger event: "SELECT"`},
+		{`{"trigger":{"name":"tr","table":"t","timing":"after","event":"INSERT",` +
+			`"steps":[{"op":"DELETE","sql":"DELETE FROM u"}]}}`,
+			"DELETE trigger step without a target"},
+		{`{"trigger":{"name":"tr","table":"t","timing":"after","event":"INSERT",` +
+			`"steps":[{"op":"UPDATE","sql":"UPDATE u","target":"u"}]}}`,
+			"UPDATE trigger step without a SET clause"},
+	}
+	for _, tc := range aTest {
+		zJson := `{"version":` + strconv.Itoa(JSONFormatVersion) + `,"statements":[` + tc.zStmt + `]}`
+		_, err := DecodeJSON([]byte(zJson))
+		if pErr, ok := err.(*Error); !ok || pErr.Msg != tc.zErr {
+			t.Errorf("%s: got %v, want %q", tc.zStmt, err, tc.zErr)
+		}
+	}
+}
@@ -0,0 +1,542 @@
+/*
+** 2008 June 15
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains the implementation for TRIGGERs
+ */
+package internal
+
+/*
+** This is called by the parser when it sees a CREATE TRIGGER statement
+** up to the point of the BEGIN before the trigger actions.  A Trigger
+** structure is generated based on the information available and stored
+** in pParse->pNewTrigger.  After the trigger actions have been parsed, the
+** sqlite3FinishTrigger() function is called to complete the trigger
+** construction process.
+**
+** The Go port has no schema, so it cannot tell a view from a table.  An
+** INSTEAD OF trigger is recorded as such, and the checks that INSTEAD OF
+** triggers are created on views and only on views are made by
+** NewCatalog(), which knows the table.
+ */
+func sqlite3BeginTrigger(
+	pParse *Parse, /* The parse context of the CREATE TRIGGER statement */
+	pName1 *Token, /* The name of the trigger */
+	pName2 *Token, /* The name of the trigger */
+	tr_tm int, /* One of TK_BEFORE, TK_AFTER, TK_INSTEAD */
+	op int, /* One of TK_INSERT, TK_UPDATE, TK_DELETE */
+	pColumns *IdList, /* column list if this is an UPDATE OF trigger */
+	pTableName *SrcList, /* The name of the table/view the trigger applies to */
+	pWhen *Expr, /* WHEN clause */
+	isTemp int, /* True if the TEMPORARY keyword is present */
+	noErr int, /* Suppress errors if the trigger already exists */
+) {
+	var pTrigger *Trigger /* The new trigger */
+	var zName []byte      /* Name of the trigger */
+	var pName *Token      /* The unqualified db name */
+	db := pParse.db       /* The database connection */
+
+	if sqlite3IsOmitted(pParse, OmitTrigger, "CREATE TRIGGER") {
+		goto trigger_cleanup
+	}
+	assert(pName1 != nil, "pName1 != nil") /* pName1->z might be NULL, but not pName1 itself */
+	assert(pName2 != nil, "pName2 != nil")
+	assert(op == TK_INSERT || op == TK_UPDATE || op == TK_DELETE, "op == TK_INSERT || op == TK_UPDATE || op == TK_DELETE")
+	assert(op > 0 && op < 0xff, "op > 0 && op < 0xff")
+	if isTemp != 0 {
+		/* If TEMP was specified, then the trigger name may not be qualified. */
+		if pName2.n > 0 {
+			sqlite3ErrorMsg(pParse, "temporary trigger may not have qualified name")
+			goto trigger_cleanup
+		}
+		pName = pName1
+	} else if pName2.n > 0 {
+		/* The trigger name is "database.name" */
+		pName = pName2
+	} else {
+		pName = pName1
+	}
+	if pTableName == nil {
+		goto trigger_cleanup
+	}
+
+	/* Check that the trigger name is not reserved and that no trigger of the
+	 ** specified name exists.  The port has no schema, so only the name
+	 ** itself is examined.
+	 */
+	zName = sqlite3NameFromToken(db, pName)
+	if zName == nil {
+		goto trigger_cleanup
+	}
+
+	/* Do not create a trigger on a system table */
+	if sqlite3_strnicmp(pTableName.a[0].zName, []byte("sqlite_"), 7) == 0 {
+		sqlite3ErrorMsg(pParse, "cannot create trigger on system table")
+		goto trigger_cleanup
+	}
+
+	/* Build the Trigger object */
+	pTrigger = &Trigger{}
+	pTrigger.zName = zName
+	pTrigger.table = sqlite3DbStrDup(db, pTableName.a[0].zName)
+	pTrigger.op = uint8(op)
+	if tr_tm == TK_INSTEAD {
+		pTrigger.bInstead = 1
+		tr_tm = TK_BEFORE
+	}
+	if tr_tm == TK_BEFORE {
+		pTrigger.tr_tm = TRIGGER_BEFORE
+	} else {
+		pTrigger.tr_tm = TRIGGER_AFTER
+	}
+	pTrigger.pWhen = pWhen
+	pWhen = nil
+	pTrigger.pColumns = pColumns
+	pColumns = nil
+	assert(pParse.pNewTrigger == nil, "pParse.pNewTrigger == nil")
+	pParse.pNewTrigger = pTrigger
+
+trigger_cleanup:
+	sqlite3SrcListDelete(db, pTableName)
+	sqlite3IdListDelete(db, pColumns)
+	sqlite3ExprDelete(db, pWhen)
+}
+
+/*
+** This routine is called after all of the trigger actions have been parsed
+** in order to complete the process of building the trigger.
+**
+** The C version writes the trigger into the schema.  The port checks the
+** WHEN clause and the trigger program, then records the trigger in
+** pParse->pTrigger so that it is attached to the Stmt.
+ */
+func sqlite3FinishTrigger(
+	pParse *Parse, /* Parser context */
+	pStepList *TriggerStep, /* The triggered program */
+	pAll *Token, /* Token that describes the complete CREATE TRIGGER */
+) {
+	pTrig := pParse.pNewTrigger /* Trigger being finished */
+	db := pParse.db             /* The database */
+
+	UNUSED_PARAMETER(pAll)
+	if NEVER(pParse.nErr != 0) || pTrig == nil {
+		goto triggerfinish_cleanup
+	}
+	pTrig.step_list = pStepList
+	for pStepList != nil {
+		pStepList.pTrig = pTrig
+		pStepList = pStepList.pNext
+	}
+	if triggerResolve(pParse, pTrig) != 0 {
+		goto triggerfinish_cleanup
+	}
+	pParse.pTrigger = pTrig
+
+triggerfinish_cleanup:
+	pParse.pNewTrigger = nil
+	sqlite3DeleteTriggerStep(db, pStepList)
+}
+
+/*
+** Duplicate a range of text from an SQL statement, then convert all
+** whitespace characters into ordinary space characters.
+ */
+func triggerSpanDup(db *sqlite3, zStart []byte, zEnd []byte) []byte {
+	z := sqlite3DbSpanDup(db, zStart, zEnd)
+	for i := range z {
+		if sqlite3Isspace(z[i]) {
+			z[i] = ' '
+		}
+	}
+	return z
+}
+
+/*
+** Turn a SELECT statement (that the pSelect parameter points to) into
+** a trigger step.  Return a pointer to a TriggerStep structure.
+**
+** The parser calls this routine when it finds a SELECT statement in
+** body of a TRIGGER.
+ */
+func sqlite3TriggerSelectStep(
+	db *sqlite3, /* Database connection */
+	pSelect *Select, /* The SELECT statement */
+	zStart []byte, /* Start of SQL text */
+	zEnd []byte, /* End of SQL text */
+) *TriggerStep {
+	pTriggerStep := &TriggerStep{}
+	pTriggerStep.op = TK_SELECT
+	pTriggerStep.pSelect = pSelect
+	pTriggerStep.orconf = OE_Default
+	pTriggerStep.zSpan = triggerSpanDup(db, zStart, zEnd)
+	return pTriggerStep
+}
+
+/*
+** Allocate space to hold a new trigger step.  The allocated space
+** holds both the TriggerStep object and the TriggerStep.target.z string.
+ */
+func triggerStepAllocate(
+	pParse *Parse, /* Parser context */
+	op uint8, /* Trigger opcode */
+	pName *Token, /* The target name */
+	zStart []byte, /* Start of SQL text */
+	zEnd []byte, /* End of SQL text */
+) *TriggerStep {
+	db := pParse.db
+	pTriggerStep := &TriggerStep{}
+	pTriggerStep.zTarget = sqlite3NameFromToken(db, pName)
+	pTriggerStep.op = op
+	pTriggerStep.zSpan = triggerSpanDup(db, zStart, zEnd)
+	return pTriggerStep
+}
+
+/*
+** Build a trigger step out of an INSERT statement.  Return a pointer
+** to the new trigger step.
+**
+** The parser calls this routine when it sees an INSERT inside the
+** body of a trigger.
+ */
+func sqlite3TriggerInsertStep(
+	pParse *Parse, /* Parser */
+	pTableName *Token, /* Name of the table into which we insert */
+	pColumn *IdList, /* List of columns in pTableName to insert into */
+	pSelect *Select, /* A SELECT statement that supplies values */
+	orconf int, /* The conflict algorithm (OE_Abort, OE_Replace, etc.) */
+	pUpsert *Upsert, /* ON CONFLICT clauses for upsert */
+	zStart []byte, /* Start of SQL text */
+	zEnd []byte, /* End of SQL text */
+) *TriggerStep {
+	var pTriggerStep *TriggerStep
+
+	assert(pSelect != nil, "pSelect != nil")
+
+	pTriggerStep = triggerStepAllocate(pParse, TK_INSERT, pTableName, zStart, zEnd)
+	pTriggerStep.pSelect = pSelect
+	pTriggerStep.pIdList = pColumn
+	pTriggerStep.pUpsert = pUpsert
+	pTriggerStep.orconf = uint8(orconf)
+	return pTriggerStep
+}
+
+/*
+** Construct a trigger step that implements an UPDATE statement and return
+** a pointer to that trigger step.  The parser calls this routine when it
+** sees an UPDATE statement inside the body of a CREATE TRIGGER.
+ */
+func sqlite3TriggerUpdateStep(
+	pParse *Parse, /* Parser */
+	pTableName *Token, /* Name of the table to be updated */
+	pFrom *SrcList, /* FROM clause for an UPDATE-FROM, or NULL */
+	pEList *ExprList, /* The SET clause: list of column and new values */
+	pWhere *Expr, /* The WHERE clause */
+	orconf int, /* The conflict algorithm. (OE_Abort, OE_Ignore, etc) */
+	zStart []byte, /* Start of SQL text */
+	zEnd []byte, /* End of SQL text */
+) *TriggerStep {
+	var pTriggerStep *TriggerStep
+
+	pTriggerStep = triggerStepAllocate(pParse, TK_UPDATE, pTableName, zStart, zEnd)
+	pTriggerStep.pExprList = pEList
+	pTriggerStep.pWhere = pWhere
+	pTriggerStep.pFrom = pFrom
+	pTriggerStep.orconf = uint8(orconf)
+	return pTriggerStep
+}
+
+/*
+** Construct a trigger step that implements a DELETE statement and return
+** a pointer to that trigger step.  The parser calls this routine when it
+** sees a DELETE statement inside the body of a CREATE TRIGGER.
+ */
+func sqlite3TriggerDeleteStep(
+	pParse *Parse, /* Parser */
+	pTableName *Token, /* The table from which rows are deleted */
+	pWhere *Expr, /* The WHERE clause */
+	zStart []byte, /* Start of SQL text */
+	zEnd []byte, /* End of SQL text */
+) *TriggerStep {
+	var pTriggerStep *TriggerStep
+
+	pTriggerStep = triggerStepAllocate(pParse, TK_DELETE, pTableName, zStart, zEnd)
+	pTriggerStep.pWhere = pWhere
+	pTriggerStep.orconf = OE_Default
+	return pTriggerStep
+}
+
+/*
+** This function is called to drop a trigger from the database schema.
+**
+** This may be called directly from the parser and therefore identifies
+** the trigger by name.  The sqlite3DropTriggerPtr() routine does the
+** same job as this routine except it takes a pointer to the trigger
+** instead of the trigger name.
+**
+** The port has no schema, so the statement is only recorded.
+ */
+func sqlite3DropTrigger(pParse *Parse, pName *SrcList, noErr int) {
+	if sqlite3IsOmitted(pParse, OmitTrigger, "DROP TRIGGER") {
+		sqlite3SrcListDelete(pParse.db, pName)
+		return
+	}
+	sqlite3DropObject(pParse, TK_TRIGGER, pName, noErr)
+}
+
+/*
+** Convert the pStep->zTarget string into a SrcList and return a pointer
+** to that SrcList.
+**
+** This routine adds a specific database name, if needed, to the target when
+** forming the SrcList.  This prevents a trigger in one database from
+** referring to a target in another database.  An exception is when the
+** trigger is in TEMP in which case it can refer to any other database it
+** wants.
+ */
+func sqlite3TriggerStepSrc(
+	pParse *Parse, /* The parsing context */
+	pStep *TriggerStep, /* The trigger containing the target token */
+) *SrcList {
+	var pSrc *SrcList /* SrcList to be returned */
+
+	db := pParse.db
+	zName := sqlite3DbStrDup(db, pStep.zTarget)
+	pSrc = sqlite3SrcListAppend(pParse, nil, nil, nil)
+	assert(pSrc == nil || pSrc.nSrc == 1, "pSrc == nil || pSrc.nSrc == 1")
+	if pSrc != nil {
+		pSrc.a[0].zName = zName
+		if pStep.pFrom != nil {
+			pDup := sqlite3SrcListDup(db, pStep.pFrom, 0)
+			pSrc = sqlite3SrcListAppendList(pParse, pSrc, pDup)
+		}
+	}
+	return pSrc
+}
+
+/*
+** Check the WHEN clause and the program of trigger pNew, which is the
+** trigger being built by pParse.  The checks are those made by
+** resolve.go: the program may use the NEW and OLD pseudo-tables only as
+** the trigger event allows, and every SELECT is checked as it would be
+** outside of a trigger.  This follows renameResolveTrigger() of the C
+** version, which resolves a trigger in the same way for ALTER TABLE.
+**
+** Return the number of errors seen.
+ */
+func triggerResolve(pParse *Parse, pNew *Trigger) int {
+	var sNC NameContext
+	var rc int
+
+	assert(pNew == pParse.pNewTrigger, "pNew == pParse.pNewTrigger")
+	sNC.pParse = pParse
+	rc = sqlite3ResolveExprNames(&sNC, pNew.pWhen)
+	for pStep := pNew.step_list; rc == 0 && pStep != nil; pStep = pStep.pNext {
+		if pStep.pSelect != nil {
+			sqlite3SelectPrep(pParse, pStep.pSelect, &sNC)
+			if pParse.nErr != 0 {
+				rc = 1
+			}
+		}
+		if rc == 0 && pStep.zTarget != nil {
+			pSrc := sqlite3TriggerStepSrc(pParse, pStep)
+			if pSrc != nil {
+				if pStep.pFrom != nil {
+					for i := 0; i < pStep.pFrom.nSrc && rc == 0; i++ {
+						p := &pStep.pFrom.a[i]
+						if p.pSelect != nil {
+							sqlite3SelectPrep(pParse, p.pSelect, nil)
+							if pParse.nErr != 0 {
+								rc = 1
+							}
+						}
+					}
+				}
+
+				sNC.pSrcList = pSrc
+				if rc == 0 {
+					rc = sqlite3ResolveExprNames(&sNC, pStep.pWhere)
+				}
+				if rc == 0 {
+					rc = sqlite3ResolveExprListNames(&sNC, pStep.pExprList)
+				}
+				if pStep.pUpsert != nil && rc == 0 {
+					rc = sqlite3UpsertResolve(pParse, pSrc, pStep.pUpsert)
+				}
+				sNC.pSrcList = nil
+			}
+		}
+	}
+	return rc
+}
+
+/*
+** Return true if the pExpr term from the RETURNING clause argument
+** list is of the form "*".  Raise an error if the term is of the
+** form "table.*".
+ */
+func isAsteriskTerm(
+	pParse *Parse, /* Parsing context */
+	pTerm *Expr, /* A term in the RETURNING clause */
+) bool {
+	assert(pParse != nil, "pParse != nil")
+	if pTerm == nil {
+		return false
+	}
+	if pTerm.op == TK_ASTERISK {
+		return true
+	}
+	if pTerm.op != TK_DOT {
+		return false
+	}
+	assert(pTerm.pRight != nil, "pTerm.pRight != nil")
+	assert(pTerm.pLeft != nil, "pTerm.pLeft != nil")
+	if pTerm.pRight.op != TK_ASTERISK {
+		return false
+	}
+	sqlite3ErrorMsg(pParse, "RETURNING may not use \"TABLE.*\" wildcards")
+	return true
+}
+
+/*
+** Convert an ExprList of the RETURNING clause into the result set of
+** pTab: each "*" term becomes the list of the non-hidden columns of the
+** table.  The other terms are copied.
+ */
+func sqlite3ExpandReturning(pParse *Parse, pList *ExprList, pTab *Table) *ExprList {
+	var pNew *ExprList
+	db := pParse.db
+
+	for i := 0; i < pList.nExpr; i++ {
+		pOldExpr := pList.a[i].pExpr
+		if NEVER(pOldExpr == nil) {
+			continue
+		}
+		if isAsteriskTerm(pParse, pOldExpr) {
+			for jj := 0; jj < int(pTab.nCol); jj++ {
+				if IsHiddenColumn(&pTab.aCol[jj]) {
+					continue
+				}
+				pNewExpr := sqlite3Expr(db, TK_ID, pTab.aCol[jj].zCnName)
+				pNew = sqlite3ExprListAppend(pParse, pNew, pNewExpr)
+				pItem := &pNew.a[pNew.nExpr-1]
+				pItem.zEName = pNewExpr.u.zToken
+				pItem.eEName = ENAME_NAME
+			}
+		} else {
+			pNewExpr := sqlite3ExprDup(db, pOldExpr, 0)
+			pNew = sqlite3ExprListAppend(pParse, pNew, pNewExpr)
+			if ALWAYS(pList.a[i].zEName != nil) {
+				pItem := &pNew.a[pNew.nExpr-1]
+				pItem.zEName = sqlite3DbStrDup(db, pList.a[i].zEName)
+				pItem.eEName = pList.a[i].eEName
+			}
+		}
+	}
+	return pNew
+}
+
+/*
+** This is the xExprCallback of the walker used by sqlite3ReturningResolve().
+** A "TABLE.COLUMN" term of the RETURNING clause must name the table being
+** modified, either by its name or by its alias.  Subqueries are not
+** entered, since they may have a FROM clause of their own.
+ */
+func returningExprStep(pWalker *Walker, pExpr *Expr) int {
+	if pExpr.op != TK_DOT || pExpr.pLeft.op != TK_ID || pExpr.pRight.op != TK_ID {
+		return WRC_Continue
+	}
+	pNC := pWalker.u.pNC
+	pItem := &pNC.pSrcList.a[0]
+	zTab := pExpr.pLeft.u.zToken
+	if sqlite3StrICmp(zTab, pItem.zName) == 0 ||
+		(pItem.zAlias != nil && sqlite3StrICmp(zTab, pItem.zAlias) == 0) {
+		return WRC_Prune
+	}
+	sqlite3ErrorMsg(pNC.pParse, "no such column: %s.%s", zTab, pExpr.pRight.u.zToken)
+	sqlite3RecordErrorOffsetOfExpr(pNC.pParse.db, pExpr)
+	return WRC_Abort
+}
+
+/*
+** Resolve the names in the RETURNING clause of the statement being
+** parsed, if it has one, and return the clause.  pTabList is the table
+** modified by the INSERT, UPDATE or DELETE, followed by the FROM clause
+** of an UPDATE.  Only the table being modified is visible to RETURNING.
+**
+** The C version does this while coding the RETURNING trigger, after each
+** "*" has been replaced by the columns of the table.  Without a schema a
+** "*" is kept as it is.  NewCatalog() expands it with
+** sqlite3ExpandReturning() once the table is known.
+**
+** If an error is seen, it is left in pParse and NULL is returned.
+ */
+func sqlite3ReturningResolve(pParse *Parse, pTabList *SrcList) *ExprList {
+	var sNC NameContext
+	var sSrc SrcList
+	var w Walker
+
+	pRet := pParse.u1.pReturning
+	if pRet == nil || pParse.nErr != 0 {
+		return nil
+	}
+	pList := pRet.pReturnEL
+	for i := 0; i < pList.nExpr; i++ {
+		isAsteriskTerm(pParse, pList.a[i].pExpr)
+		if pParse.nErr != 0 {
+			return nil
+		}
+	}
+	assert(pTabList.nSrc >= 1, "pTabList.nSrc >= 1")
+	sSrc.nSrc = 1
+	sSrc.nAlloc = 1
+	sSrc.a = pTabList.a[:1]
+	sNC.pParse = pParse
+	sNC.pSrcList = &sSrc
+	if sqlite3ResolveExprListNames(&sNC, pList) != 0 {
+		return nil
+	}
+	w.pParse = pParse
+	w.xExprCallback = returningExprStep
+	w.u.pNC = &sNC
+	if sqlite3WalkExprList(&w, pList) != 0 {
+		return nil
+	}
+	return pList
+}
+
+/* Name returns the name of the trigger */
+func (p *Trigger) Name() string { return string(p.zName) }
+
+/* Table returns the name of the table or view the trigger is on */
+func (p *Trigger) Table() string { return string(p.table) }
+
+/* Time returns "BEFORE", "AFTER" or "INSTEAD OF" */
+func (p *Trigger) Time() string {
+	if p.bInstead != 0 {
+		return "INSTEAD OF"
+	} else if p.tr_tm == TRIGGER_BEFORE {
+		return "BEFORE"
+	}
+	return "AFTER"
+}
+
+/* Event returns "INSERT", "UPDATE" or "DELETE" */
+func (p *Trigger) Event() string {
+	switch p.op {
+	case TK_INSERT:
+		return "INSERT"
+	case TK_UPDATE:
+		return "UPDATE"
+	}
+	return "DELETE"
+}
+
+/* When returns the WHEN clause of the trigger, or nil if there is none */
+func (p *Trigger) When() *Expr { return p.pWhen }
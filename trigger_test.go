@@ -0,0 +1,166 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Tests for building CREATE TRIGGER objects, checking trigger programs
+** and resolving RETURNING clauses.
+ */
+package internal
+
+import "testing"
+
+/*
+** A CREATE TRIGGER statement must record the timing, the event, the
+** UPDATE OF columns, the WHEN clause and each step with its target and
+** text.
+ */
+func TestTrigger(t *testing.T) {
+	zSql := "CREATE TRIGGER tr BEFORE UPDATE OF a, b ON t WHEN new.a > 1 BEGIN " +
+		"UPDATE u SET x = new.a; INSERT INTO v VALUES(old.b); DELETE FROM w; SELECT 1; END"
+	aStmt, err := ParseSQL(zSql, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pTrig := aStmt[0].pTrigger
+	if pTrig == nil {
+		t.Fatal("no trigger recorded")
+	}
+	if string(pTrig.zName) != "tr" || string(pTrig.table) != "t" ||
+		pTrig.op != TK_UPDATE || pTrig.tr_tm != TRIGGER_BEFORE || pTrig.bInstead != 0 {
+		t.Errorf("got %+v", pTrig)
+	}
+	if pTrig.pColumns == nil || pTrig.pColumns.nId != 2 ||
+		string(pTrig.pColumns.a[1].zName) != "b" {
+		t.Error("UPDATE OF columns not recorded")
+	}
+	if pTrig.pWhen == nil || pTrig.pWhen.op != TK_GT {
+		t.Error("WHEN clause not recorded")
+	}
+
+	aWant := []struct {
+		op      uint8
+		zTarget string
+		zSpan   string
+	}{
+		{TK_UPDATE, "u", "UPDATE u SET x = new.a"},
+		{TK_INSERT, "v", "INSERT INTO v VALUES(old.b)"},
+		{TK_DELETE, "w", "DELETE FROM w"},
+		{TK_SELECT, "", "SELECT 1"},
+	}
+	pStep := pTrig.step_list
+	for _, w := range aWant {
+		if pStep == nil {
+			t.Fatalf("missing step %s", w.zSpan)
+		}
+		if pStep.op != w.op || string(pStep.zTarget) != w.zTarget || string(pStep.zSpan) != w.zSpan {
+			t.Errorf("got step %d %q %q, want %d %q %q",
+				pStep.op, pStep.zTarget, pStep.zSpan, w.op, w.zTarget, w.zSpan)
+		}
+		pStep = pStep.pNext
+	}
+	if pStep != nil {
+		t.Error("too many steps")
+	}
+
+	aStmt, err = ParseSQL("CREATE TRIGGER tr INSTEAD OF DELETE ON v BEGIN SELECT RAISE(IGNORE); END", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pTrig = aStmt[0].pTrigger; pTrig.bInstead == 0 || pTrig.op != TK_DELETE {
+		t.Errorf("got %+v for INSTEAD OF", pTrig)
+	}
+}
+
+/*
+** OLD in an INSERT trigger, NEW in a DELETE trigger and RAISE() outside
+** a trigger program must be reported the way SQLite reports them.
+ */
+func TestTriggerError(t *testing.T) {
+	aTest := []struct {
+		zSql  string
+		zErr  string
+		iOfst int
+	}{
+		{"CREATE TRIGGER tr AFTER INSERT ON t BEGIN SELECT old.a; END",
+			"no such column: old.a", 49},
+		{"CREATE TRIGGER tr AFTER DELETE ON t BEGIN SELECT new.a; END",
+			"no such column: new.a", 49},
+		{"CREATE TRIGGER tr AFTER INSERT ON t WHEN old.a BEGIN SELECT 1; END",
+			"no such column: old.a", 41},
+		{"SELECT RAISE(ABORT, 'x')",
+			"RAISE() may only be used within a trigger-program", -1},
+		{"UPDATE t SET a = RAISE(IGNORE)",
+			"RAISE() may only be used within a trigger-program", -1},
+		{"DELETE FROM t WHERE RAISE(FAIL, 'x')",
+			"RAISE() may only be used within a trigger-program", -1},
+	}
+	for _, tc := range aTest {
+		_, err := ParseSQL(tc.zSql, nil)
+		pErr, ok := err.(*Error)
+		if !ok || pErr.Msg != tc.zErr {
+			t.Errorf("%s: got %v, want %q", tc.zSql, err, tc.zErr)
+		} else if tc.iOfst >= 0 && pErr.Offset != tc.iOfst {
+			t.Errorf("%s: error at offset %d, want %d", tc.zSql, pErr.Offset, tc.iOfst)
+		}
+	}
+
+	/* A FROM clause may hide the pseudo-table. */
+	zSql := "CREATE TRIGGER tr AFTER INSERT ON t BEGIN SELECT old.a FROM old; END"
+	if _, err := ParseSQL(zSql, nil); err != nil {
+		t.Errorf("%s: %v", zSql, err)
+	}
+}
+
+/*
+** A RETURNING clause must be stored on the statement it belongs to and
+** see only the table being modified.
+ */
+func TestReturning(t *testing.T) {
+	aStmt, err := ParseSQL("INSERT INTO t(a) VALUES(1) RETURNING a, b AS c; "+
+		"UPDATE t SET a = 1 FROM u RETURNING t.a, *; DELETE FROM t RETURNING rowid", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := aStmt[0].pInsert.pReturning; p == nil || p.nExpr != 2 || string(p.a[1].zEName) != "c" {
+		t.Error("INSERT ... RETURNING not recorded")
+	}
+	if p := aStmt[1].pUpdate.pReturning; p == nil || p.nExpr != 2 || p.a[1].pExpr.op != TK_ASTERISK {
+		t.Error("UPDATE ... RETURNING not recorded")
+	}
+	if p := aStmt[2].pDelete.pReturning; p == nil || p.nExpr != 1 {
+		t.Error("DELETE ... RETURNING not recorded")
+	}
+
+	aTest := []struct {
+		zSql  string
+		zErr  string
+		iOfst int
+	}{
+		{"INSERT INTO t(a) VALUES(1) RETURNING t.*",
+			"RETURNING may not use \"TABLE.*\" wildcards", -1},
+		{"INSERT INTO t(a) VALUES(1) ON CONFLICT(a) DO UPDATE SET b=1 RETURNING excluded.a",
+			"no such column: excluded.a", 70},
+		{"UPDATE t SET a = 1 FROM u RETURNING u.x",
+			"no such column: u.x", 36},
+		{"DELETE FROM t RETURNING x.a",
+			"no such column: x.a", 24},
+		{"CREATE TRIGGER tr AFTER INSERT ON t BEGIN INSERT INTO u VALUES(1) RETURNING a; END",
+			"cannot use RETURNING in a trigger", -1},
+	}
+	for _, tc := range aTest {
+		_, err := ParseSQL(tc.zSql, nil)
+		pErr, ok := err.(*Error)
+		if !ok || pErr.Msg != tc.zErr {
+			t.Errorf("%s: got %v, want %q", tc.zSql, err, tc.zErr)
+		} else if tc.iOfst >= 0 && pErr.Offset != tc.iOfst {
+			t.Errorf("%s: error at offset %d, want %d", tc.zSql, pErr.Offset, tc.iOfst)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+/*
+** 2001 September 15
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains C code routines that are called by the parser
+** to handle UPDATE statements.
+ */
+package internal
+
+/*
+** The Go port does not generate code for an UPDATE statement.  Instead
+** the parse tree is recorded in one of these objects and attached to the
+** Stmt.
+ */
+type Update struct {
+	pTabList   *SrcList  /* The table in which we should change things */
+	pChanges   *ExprList /* Things to be changed */
+	pWhere     *Expr     /* The WHERE clause.  May be null */
+	onError    int       /* How to handle constraint errors */
+	pOrderBy   *ExprList /* ORDER BY clause. May be null */
+	pLimit     *Expr     /* LIMIT clause. May be null */
+	pUpsert    *Upsert   /* ON CONFLICT clause, or null */
+	pReturning *ExprList /* RETURNING clause. May be null */
+}
+
+/*
+** Process an UPDATE statement.
+**
+**   UPDATE OR IGNORE tbl SET a=b, c=d FROM tbl2... WHERE e<5 AND f NOT NULL;
+**          \_______/ \_/     \______/      \_____/       \________________/
+**           onError   |      pChanges         |                pWhere
+**                     \_______________________/
+**                               pTabList
+ */
+func sqlite3Update(
+	pParse *Parse, /* The parser context */
+	pTabList *SrcList, /* The table in which we should change things */
+	pChanges *ExprList, /* Things to be changed */
+	pWhere *Expr, /* The WHERE clause.  May be null */
+	onError int, /* How to handle constraint errors */
+	pOrderBy *ExprList, /* ORDER BY clause. May be null */
+	pLimit *Expr, /* LIMIT clause. May be null */
+	pUpsert *Upsert, /* ON CONFLICT clause, or null */
+) {
+	var p *Update
+	var pReturning *ExprList /* The RETURNING clause, if any */
+	var sNC NameContext      /* The name-context to resolve expressions in */
+
+	if pParse.nErr != 0 {
+		goto update_cleanup
+	}
+
+	/* Resolve the column names in all the expressions of the
+	 ** UPDATE statement.
+	 */
+	sNC.pParse = pParse
+	sNC.pSrcList = pTabList
+	if sqlite3ResolveExprListNames(&sNC, pChanges) != 0 ||
+		sqlite3ResolveExprNames(&sNC, pWhere) != 0 {
+		goto update_cleanup
+	}
+	pReturning = sqlite3ReturningResolve(pParse, pTabList)
+	if pParse.nErr != 0 {
+		goto update_cleanup
+	}
+	assert(pOrderBy == nil || pParse.db.bUpdateDeleteLimit != 0, "pOrderBy == nil || pParse.db.bUpdateDeleteLimit != 0")
+	assert(pLimit == nil || pParse.db.bUpdateDeleteLimit != 0, "pLimit == nil || pParse.db.bUpdateDeleteLimit != 0")
+	p = &Update{}
+	p.pTabList = pTabList
+	p.pChanges = pChanges
+	p.pWhere = pWhere
+	p.onError = onError
+	p.pOrderBy = pOrderBy
+	p.pLimit = pLimit
+	p.pUpsert = pUpsert
+	p.pReturning = pReturning
+	pParse.pUpdate = p
+	return
+
+update_cleanup:
+	sqlite3SrcListDelete(pParse.db, pTabList)
+	sqlite3ExprListDelete(pParse.db, pChanges)
+	sqlite3ExprDelete(pParse.db, pWhere)
+	sqlite3ExprListDelete(pParse.db, pOrderBy)
+	sqlite3ExprDelete(pParse.db, pLimit)
+}
+
+/* Table returns the name of the table that p updates */
+func (p *Update) Table() string { return string(p.pTabList.a[0].zName) }
+
+/* Where returns the WHERE clause, or nil if there is none */
+func (p *Update) Where() *Expr { return p.pWhere }
+
+/*
+** Returning returns the terms of the RETURNING clause, or nil if there is
+** none.  A Catalog replaces "*" by the columns of the table.
+ */
+func (p *Update) Returning() []*Expr { return exprListToSlice(p.pReturning) }
@@ -0,0 +1,266 @@
+/*
+** 2018-04-12
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains code to implement various aspects of UPSERT
+** processing and handling of the Upsert object.
+ */
+package internal
+
+/*
+** Duplicate an Upsert object.
+ */
+func sqlite3UpsertDup(db *sqlite3, p *Upsert) *Upsert {
+	if p == nil {
+		return nil
+	}
+	return sqlite3UpsertNew(db,
+		sqlite3ExprListDup(db, p.pUpsertTarget, 0),
+		sqlite3ExprDup(db, p.pUpsertTargetWhere, 0),
+		sqlite3ExprListDup(db, p.pUpsertSet, 0),
+		sqlite3ExprDup(db, p.pUpsertWhere, 0),
+		sqlite3UpsertDup(db, p.pNextUpsert),
+	)
+}
+
+/*
+** Clone returns a deep copy of p and of the ON CONFLICT clauses that
+** follow it.  The copy can be modified without changing p.
+ */
+func (p *Upsert) Clone() *Upsert {
+	return sqlite3UpsertDup(openDatabase(), p)
+}
+
+/*
+** Create a new Upsert object.
+ */
+func sqlite3UpsertNew(
+	db *sqlite3, /* Determines which memory allocator to use */
+	pTarget *ExprList, /* Target argument to ON CONFLICT, or NULL */
+	pTargetWhere *Expr, /* Optional WHERE clause on the target */
+	pSet *ExprList, /* UPDATE columns, or NULL for a DO NOTHING */
+	pWhere *Expr, /* WHERE clause for the ON CONFLICT UPDATE */
+	pNext *Upsert, /* Next ON CONFLICT clause in the list */
+) *Upsert {
+	pNew := &Upsert{}
+	pNew.pUpsertTarget = pTarget
+	pNew.pUpsertTargetWhere = pTargetWhere
+	pNew.pUpsertSet = pSet
+	pNew.pUpsertWhere = pWhere
+	if pSet != nil {
+		pNew.isDoUpdate = 1
+	}
+	pNew.pNextUpsert = pNext
+	return pNew
+}
+
+/*
+** Analyze the ON CONFLICT clause described by pUpsert.  Resolve all
+** symbolic names in the conflict-target clause, which includes both the
+** list of columns and the optional partial-index WHERE clause.
+**
+** The C version goes on to find the PRIMARY KEY or UNIQUE index that the
+** conflict target matches and stores it in pUpsert->pUpsertIdx.  That
+** needs the schema of the target table, so in the Go port it is done by
+** sqlite3UpsertLocateIndex() when the INSERT is added to a Catalog.
+**
+** Return SQLITE_OK on success or an error code if problems are found.
+ */
+func sqlite3UpsertAnalyzeTarget(
+	pParse *Parse, /* The parsing context */
+	pTabList *SrcList, /* Table into which we are inserting */
+	pUpsert *Upsert, /* The ON CONFLICT clause to analyze */
+	pAll *Upsert, /* Complete list of all ON CONFLICT clauses */
+) int {
+	var sNC NameContext
+	var rc int
+
+	assert(pTabList.nSrc == 1, "pTabList.nSrc == 1")
+	assert(pUpsert != nil, "pUpsert != nil")
+	assert(pUpsert.pUpsertTarget != nil, "pUpsert.pUpsertTarget != nil")
+
+	sNC.pParse = pParse
+	sNC.pSrcList = pTabList
+	rc = sqlite3ResolveExprListNames(&sNC, pUpsert.pUpsertTarget)
+	if rc != 0 {
+		return rc
+	}
+	rc = sqlite3ResolveExprNames(&sNC, pUpsert.pUpsertTargetWhere)
+	return rc
+}
+
+/*
+** Return the column of pTab named by the conflict-target term pTerm, with
+** any COLLATE removed.  Return XN_ROWID if pTerm names the rowid, or the
+** INTEGER PRIMARY KEY column that is an alias for it, as name resolution
+** does in C.  Return XN_EXPR if pTerm is not a column name.
+ */
+func upsertTermColumn(pTab *Table, pTerm *Expr) int {
+	zCol := indexColumnName(sqlite3ExprSkipCollate(pTerm))
+	if zCol == nil {
+		return XN_EXPR
+	}
+	for iCol := 0; iCol < int(pTab.nCol); iCol++ {
+		if sqlite3StrICmp(zCol, pTab.aCol[iCol].zCnName) == 0 {
+			if iCol == int(pTab.iPKey) {
+				return XN_ROWID
+			}
+			return iCol
+		}
+	}
+	if HasRowid(pTab) && sqlite3IsRowid(zCol) {
+		return XN_ROWID
+	}
+	return XN_EXPR
+}
+
+/*
+** Find the PRIMARY KEY or UNIQUE index of pTab that the conflict target
+** of each clause of the ON CONFLICT chain pUpsert matches, and store it in
+** pUpsertIdx.  pUpsertIdx is left NULL for a clause whose conflict target
+** is the rowid, and for the last clause if it has no conflict target.
+** The indexes made by CREATE INDEX statements on pTab are passed in
+** aIdx[], as the port keeps them apart from pTab->pIndex.
+**
+** A term of the conflict target matches a column of an index if it names
+** the same column, or is the same expression, and if it has a COLLATE
+** then the index uses that collating sequence for the column.
+**
+** Return SQLITE_OK on success or SQLITE_ERROR if some conflict target
+** matches no index.  The error is left in pParse.
+ */
+func sqlite3UpsertLocateIndex(
+	pParse *Parse, /* The parsing context */
+	pTab *Table, /* Table into which we are inserting */
+	aIdx []*Index, /* CREATE INDEX indexes on pTab */
+	pUpsert *Upsert, /* The ON CONFLICT clauses to analyze */
+) int {
+	var apIdx []*Index /* All indexes on pTab */
+
+	for p := pTab.pIndex; p != nil; p = p.pNext {
+		apIdx = append(apIdx, p)
+	}
+	apIdx = append(apIdx, aIdx...)
+	for nClause := 0; pUpsert != nil; pUpsert, nClause = pUpsert.pNextUpsert, nClause+1 {
+		pTarget := pUpsert.pUpsertTarget
+		if pTarget == nil {
+			continue
+		}
+		pUpsert.pUpsertIdx = nil
+
+		/* Check to see if the conflict target matches the rowid. */
+		if HasRowid(pTab) && pTarget.nExpr == 1 &&
+			pTarget.a[0].pExpr.op != TK_COLLATE &&
+			upsertTermColumn(pTab, pTarget.a[0].pExpr) == XN_ROWID {
+			continue
+		}
+
+		for _, pIdx := range apIdx {
+			var ii, jj int
+			if !IsUniqueIndex(pIdx) || pIdx.aiColumn == nil {
+				continue
+			}
+			if pTarget.nExpr != int(pIdx.nKeyCol) {
+				continue
+			}
+			if pIdx.pPartIdxWhere != nil {
+				if pUpsert.pUpsertTargetWhere == nil {
+					continue
+				}
+				if sqlite3ExprCompare(pParse, pUpsert.pUpsertTargetWhere,
+					pIdx.pPartIdxWhere, -1) != 0 {
+					continue
+				}
+			}
+			nn := int(pIdx.nKeyCol)
+			for ii = 0; ii < nn; ii++ {
+				iCol := int(pIdx.aiColumn[ii])
+				pIdxExpr := sqlite3ExprSkipCollate(pIdx.aColExpr.a[ii].pExpr)
+				for jj = 0; jj < nn; jj++ {
+					pTerm := pTarget.a[jj].pExpr
+					if iCol == XN_EXPR {
+						if sqlite3ExprCompare(pParse, sqlite3ExprSkipCollate(pTerm), pIdxExpr, -1) != 0 {
+							continue
+						}
+					} else if upsertTermColumn(pTab, pTerm) != iCol {
+						continue
+					}
+					if pTerm.op == TK_COLLATE &&
+						sqlite3StrICmp(pTerm.u.zToken, pIdx.azColl[ii]) != 0 {
+						continue
+					}
+					break /* Column ii of the index matches column jj of target */
+				}
+				if jj >= nn {
+					/* The target contains no match for column ii of the index */
+					break
+				}
+			}
+			if ii < nn {
+				/* Column ii of the index did not match any term of the conflict
+				 ** target.  Continue the search with the next index. */
+				continue
+			}
+			pUpsert.pUpsertIdx = pIdx
+			break
+		}
+		if pUpsert.pUpsertIdx == nil {
+			zWhich := ""
+			if nClause != 0 || pUpsert.pNextUpsert != nil {
+				zWhich = string(sqlite3MPrintf(pParse.db, "%r ", nClause+1))
+			}
+			sqlite3ErrorMsg(pParse, "%sON CONFLICT clause does not match any "+
+				"PRIMARY KEY or UNIQUE constraint", zWhich)
+			return SQLITE_ERROR
+		}
+	}
+	return SQLITE_OK
+}
+
+/*
+** Resolve names in every clause of the ON CONFLICT chain pUpsert, which
+** belongs to an INSERT into the single table of pTabList.  The SET and
+** WHERE terms of a DO UPDATE may refer to the EXCLUDED pseudo-table; the
+** conflict target may not.
+**
+** The grammar only allows the last clause of the chain to omit its
+** conflict target.  The check is repeated here for chains that were built
+** some other way.
+**
+** Return the number of errors seen.
+ */
+func sqlite3UpsertResolve(pParse *Parse, pTabList *SrcList, pUpsert *Upsert) int {
+	var sNC NameContext
+	var nClause int
+
+	for pNx := pUpsert; pNx != nil; pNx = pNx.pNextUpsert {
+		pNx.pUpsertSrc = pTabList
+		if pNx.pUpsertTarget == nil {
+			if pNx.pNextUpsert != nil {
+				sqlite3ErrorMsg(pParse, "%r ON CONFLICT clause has no conflict target "+
+					"and is not the last", nClause+1)
+				return 1
+			}
+		} else if sqlite3UpsertAnalyzeTarget(pParse, pTabList, pNx, pUpsert) != 0 {
+			return 1
+		}
+		sNC = NameContext{}
+		sNC.pParse = pParse
+		sNC.pSrcList = pTabList
+		sNC.uNC.pUpsert = pNx
+		sNC.ncFlags = NC_UUpsert
+		if sqlite3ResolveExprListNames(&sNC, pNx.pUpsertSet) != 0 ||
+			sqlite3ResolveExprNames(&sNC, pNx.pUpsertWhere) != 0 {
+			return 1
+		}
+		nClause++
+	}
+	return 0
+}
@@ -0,0 +1,730 @@
+/*
+** 2001 September 15
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Utility functions used throughout sqlite.
+**
+** This file contains functions for allocating memory, comparing
+** strings, and stuff like that.
+ */
+package internal
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+/*
+** The following macros mimic the standard library functions toupper(),
+** isspace(), isalnum(), isdigit() and isxdigit(), respectively. The
+** sqlite versions only work for ASCII characters, regardless of locale.
+ */
+func sqlite3Toupper(x byte) byte  { return x &^ (sqlite3CtypeMap[x] & 0x20) }
+func sqlite3Isspace(x byte) bool  { return (sqlite3CtypeMap[x] & 0x01) != 0 }
+func sqlite3Isalnum(x byte) bool  { return (sqlite3CtypeMap[x] & 0x06) != 0 }
+func sqlite3Isalpha(x byte) bool  { return (sqlite3CtypeMap[x] & 0x02) != 0 }
+func sqlite3Isdigit(x byte) bool  { return (sqlite3CtypeMap[x] & 0x04) != 0 }
+func sqlite3Isxdigit(x byte) bool { return (sqlite3CtypeMap[x] & 0x08) != 0 }
+func sqlite3Tolower(x byte) byte  { return sqlite3UpperToLower[x] }
+func sqlite3Isquote(x byte) bool  { return (sqlite3CtypeMap[x] & 0x80) != 0 }
+
+/*
+** Return the byte at offset i of z, or 0 if i is past the end of z.
+** The C sources rely on SQL text being zero-terminated; Go slices are
+** not, so every look-ahead goes through this helper instead.
+ */
+func charAt(z []byte, i int) byte {
+	if i < len(z) {
+		return z[i]
+	}
+	return 0
+}
+
+/*
+** Add an error message to pParse->zErrMsg and increment pParse->nErr.
+**
+** This function should be used to report any error that occurs while
+** compiling an SQL statement (i.e. within sqlite3_prepare()). The
+** last thing the sqlite3_prepare() function does is copy the error
+** stored by this function into the database handle using sqlite3Error().
+** Functions sqlite3Error() or sqlite3ErrorWithMsg() should be used
+** during statement execution (sqlite3_step() etc.).
+ */
+func sqlite3ErrorMsg(pParse *Parse, zFormat string, args ...interface{}) {
+	db := pParse.db
+	if db != nil {
+		db.errByteOffset = -2
+	}
+	zMsg := sqlite3MPrintf(db, zFormat, args...)
+	if db != nil && db.errByteOffset < -1 {
+		db.errByteOffset = -1
+	}
+	pParse.nErr++
+	pParse.zErrMsg = zMsg
+	pParse.rc = SQLITE_ERROR
+}
+
+/*
+** If database connection db is currently parsing SQL, then transfer
+** error code errCode to that parser if the parser has not already
+** encountered some other kind of error.
+ */
+func sqlite3ErrorToParser(db *sqlite3, errCode int) int {
+	var pParse *Parse
+	if db == nil {
+		return 0
+	}
+	pParse = db.pParse
+	if pParse == nil {
+		return 0
+	}
+	pParse.rc = errCode
+	pParse.nErr++
+	return 1
+}
+
+/*
+** Convert an SQL-style quoted string into a normal string by removing
+** the quote characters.  The conversion is done in-place.  If the
+** input does not begin with a quote character, then this routine
+** is a no-op.
+**
+** The input string must be zero-terminated.  A new zero-terminator
+** is added to the dequoted string.
+**
+** The return value is -1 if no dequoting occurs or the length of the
+** dequoted string, exclusive of the zero terminator, if dequoting does
+** occur.
+**
+** 2002-02-14: This routine is extended to remove MS-Access style
+** brackets from around identifiers.  For example:  "[a-b-c]" becomes
+** "a-b-c".
+**
+** The Go version returns the dequoted text, which shares storage with
+** z.
+ */
+func sqlite3Dequote(z []byte) []byte {
+	var quote byte
+	var i, j int
+	if len(z) == 0 {
+		return z
+	}
+	quote = z[0]
+	if !sqlite3Isquote(quote) {
+		return z
+	}
+	if quote == '[' {
+		quote = ']'
+	}
+	for i, j = 1, 0; ; i++ {
+		if i >= len(z) {
+			/* Unterminated quote.  The tokenizer never produces one of
+			 ** these, but be safe rather than index past the end. */
+			break
+		}
+		if z[i] == quote {
+			if charAt(z, i+1) == quote {
+				z[j] = quote
+				j++
+				i++
+			} else {
+				break
+			}
+		} else {
+			z[j] = z[i]
+			j++
+		}
+	}
+	return z[:j]
+}
+
+/*
+** Generate a Token object from a string
+ */
+func sqlite3TokenInit(p *Token, z []byte) {
+	p.z = z
+	p.n = uint(sqlite3Strlen30(z))
+}
+
+/*
+** Compute a string length that is limited to what can be stored in
+** lower 30 bits of a 32-bit signed integer.
+**
+** The text of z ends at its first 0x00 byte, if it has one, as a C
+** string would.
+ */
+func sqlite3Strlen30(z []byte) int {
+	if i := bytes.IndexByte(z, 0); i >= 0 {
+		return i & 0x3fffffff
+	}
+	return len(z) & 0x3fffffff
+}
+
+/* Convenient short-hand */
+func UpperToLower(x byte) byte { return sqlite3UpperToLower[x] }
+
+/*
+** Some systems have stricmp().  Others have strcasecmp().  Because
+** there is no consistency, we will define our own.
+**
+** IMPLEMENTATION-OF: R-30243-02494 The sqlite3_stricmp() and
+** sqlite3_strnicmp() APIs allow applications and extensions to compare
+** the contents of two buffers containing UTF-8 strings in a
+** case-independent fashion, using the same definition of "case
+** independence" that SQLite uses internally when comparing identifiers.
+ */
+func sqlite3_stricmp(zLeft, zRight []byte) int {
+	if zLeft == nil {
+		if zRight == nil {
+			return 0
+		}
+		return -1
+	} else if zRight == nil {
+		return 1
+	}
+	return sqlite3StrICmp(zLeft, zRight)
+}
+
+func sqlite3StrICmp(zLeft, zRight []byte) int {
+	var c, x int
+	for i := 0; ; i++ {
+		a, b := charAt(zLeft, i), charAt(zRight, i)
+		c = int(a)
+		x = int(b)
+		if c == x {
+			if c == 0 {
+				break
+			}
+		} else {
+			c = int(UpperToLower(a)) - int(UpperToLower(b))
+			if c != 0 {
+				break
+			}
+		}
+	}
+	return c
+}
+
+func sqlite3_strnicmp(zLeft, zRight []byte, N int) int {
+	var i int
+	if zLeft == nil {
+		if zRight == nil {
+			return 0
+		}
+		return -1
+	} else if zRight == nil {
+		return 1
+	}
+	for i = 0; N > 0 && charAt(zLeft, i) != 0 &&
+		UpperToLower(charAt(zLeft, i)) == UpperToLower(charAt(zRight, i)); i++ {
+		N--
+	}
+	if N <= 0 {
+		return 0
+	}
+	return int(UpperToLower(charAt(zLeft, i))) - int(UpperToLower(charAt(zRight, i)))
+}
+
+/*
+** Translate a single byte of Hex into an integer.
+** This routine only works if h really is a valid hexadecimal
+** character:  0..9a..fA..F
+ */
+func sqlite3HexToInt(h int) uint8 {
+	assert((h >= '0' && h <= '9') || (h >= 'a' && h <= 'f') || (h >= 'A' && h <= 'F'),
+		"(h >= '0' && h <= '9') || (h >= 'a' && h <= 'f') || (h >= 'A' && h <= 'F')")
+	h += 9 * (1 & (h >> 6))
+	return uint8(h & 0xf)
+}
+
+/*
+** If zNum represents an integer that will fit in 32-bits, then set
+** *pValue to that integer and return true.  Otherwise return false.
+**
+** This routine accepts both decimal and hexadecimal notation for integers.
+**
+** Any non-numeric characters that following zNum are ignored.
+** This is different from sqlite3Atoi64() which requires the
+** input number to be zero-terminated.
+ */
+func sqlite3GetInt32(zNum []byte, pValue *int) int {
+	var v int64
+	var i, c int
+	neg := false
+	if charAt(zNum, 0) == '-' {
+		neg = true
+		zNum = zNum[1:]
+	} else if charAt(zNum, 0) == '+' {
+		zNum = zNum[1:]
+	} else if charAt(zNum, 0) == '0' &&
+		(charAt(zNum, 1) == 'x' || charAt(zNum, 1) == 'X') &&
+		sqlite3Isxdigit(charAt(zNum, 2)) {
+		var u uint32
+		zNum = zNum[2:]
+		for charAt(zNum, 0) == '0' {
+			zNum = zNum[1:]
+		}
+		for i = 0; sqlite3Isxdigit(charAt(zNum, i)) && i < 8; i++ {
+			u = u*16 + uint32(sqlite3HexToInt(int(zNum[i])))
+		}
+		if (u&0x80000000) == 0 && !sqlite3Isxdigit(charAt(zNum, i)) {
+			*pValue = int(int32(u))
+			return 1
+		} else {
+			return 0
+		}
+	}
+	if !sqlite3Isdigit(charAt(zNum, 0)) {
+		return 0
+	}
+	for charAt(zNum, 0) == '0' {
+		zNum = zNum[1:]
+	}
+	for i = 0; i < 11; i++ {
+		c = int(charAt(zNum, i)) - '0'
+		if c < 0 || c > 9 {
+			break
+		}
+		v = v*10 + int64(c)
+	}
+
+	/* The longest decimal representation of a 32 bit integer is 10 digits:
+	 **
+	 **             1234567890
+	 **     2^31 -> 2147483648
+	 */
+	testcase(i == 10)
+	if i > 10 {
+		return 0
+	}
+	testcase(v-int64(neg2int(neg)) == 2147483647)
+	if v-int64(neg2int(neg)) > 2147483647 {
+		return 0
+	}
+	if neg {
+		v = -v
+	}
+	*pValue = int(v)
+	return 1
+}
+
+func neg2int(neg bool) int {
+	if neg {
+		return 1
+	}
+	return 0
+}
+
+/*
+** Each VList entry maps a variable name to the parameter number it was
+** assigned.  A VList is kept on the Parse object while a statement is
+** being parsed so that "?NNN", ":AAA", "@AAA" and "$AAA" parameters with
+** the same name receive the same number.
+**
+** The C implementation packs this into a single array of integers.  The
+** Go version keeps a slice of entries instead.
+ */
+type VList struct {
+	a []VListItem
+}
+
+type VListItem struct {
+	iVar  int    /* Variable number */
+	zName []byte /* Name of the variable, including the prefix character */
+}
+
+/*
+** Add a new name/number pair to a VList.  This might require that the
+** VList object be reallocated, so return the new VList.  If an OOM
+** error occurs, the original VList returned and the
+** db->mallocFailed flag is set.
+ */
+func sqlite3VListAdd(db *sqlite3, pIn *VList, zName []byte, iVal int) *VList {
+	if pIn == nil {
+		pIn = &VList{}
+	}
+	pIn.a = append(pIn.a, VListItem{iVar: iVal, zName: zName})
+	return pIn
+}
+
+/*
+** Return a pointer to the name of a variable in the given VList that
+** has the value iVal.  Or return a NULL if there is no such variable in
+** the list
+ */
+func sqlite3VListNumToName(pIn *VList, iVal int) []byte {
+	if pIn == nil {
+		return nil
+	}
+	for _, x := range pIn.a {
+		if x.iVar == iVal {
+			return x.zName
+		}
+	}
+	return nil
+}
+
+/*
+** Return the number of the variable named zName, if it is in VList.
+** or return 0 if there is no such variable.
+ */
+func sqlite3VListNameToNum(pIn *VList, zName []byte) int {
+	if pIn == nil {
+		return 0
+	}
+	for _, x := range pIn.a {
+		if string(x.zName) == string(zName) {
+			return x.iVar
+		}
+	}
+	return 0
+}
+
+/*
+** Largest and smallest possible 64-bit signed integers.
+ */
+const (
+	LARGEST_INT64  = int64(0x7fffffffffffffff)
+	SMALLEST_INT64 = -1 - LARGEST_INT64
+)
+
+/*
+** The string z[] is an text representation of a real number.
+** Convert this string to a double and write it into *pResult.
+**
+** The string z[] is UTF-8.  It is not necessarily zero-terminated.
+**
+** Return TRUE if the result is a valid real number (or integer) and FALSE
+** if the string is empty or contains extraneous text.  More specifically
+** return
+**      1          =>  The input string is a pure integer
+**      2 or more  =>  The input has a decimal point or eNNN clause
+**      0 or less  =>  The input string is not a valid number
+**     -1          =>  Not a valid number, but has a valid prefix which
+**                     includes a decimal point and/or an eNNN clause
+**
+** Valid numbers are in one of these formats:
+**
+**    [+-]digits[E[+-]digits]
+**    [+-]digits.[digits][E[+-]digits]
+**    [+-].digits[E[+-]digits]
+**
+** Leading and trailing whitespace is ignored for the purpose of determining
+** validity.
+**
+** If some prefix of the input string is a valid number, this routine
+** returns FALSE but it still converts the prefix and writes the result
+** into *pResult.
+**
+** The C version accumulates the digits itself.  The Go port finds the
+** extent of the number in the same way and leaves the conversion of
+** those bytes to strconv, which rounds correctly.
+ */
+func sqlite3AtoF(z []byte, pResult *float64) int {
+	var i, iStart, iEnd int
+	nDigit := 0    /* Number of digits processed */
+	eType := 1     /* 1: pure integer,  2+: fractional */
+	eValid := true /* True exponent is either not used or is well-formed */
+
+	*pResult = 0.0 /* Default return value, in case of an error */
+	n := len(z)
+
+	/* skip leading spaces */
+	for i < n && sqlite3Isspace(z[i]) {
+		i++
+	}
+	if i >= n {
+		return 0
+	}
+	iStart = i
+
+	/* get sign of significand */
+	if z[i] == '-' || z[i] == '+' {
+		i++
+	}
+
+	/* copy max significant digits to significand */
+	for i < n && sqlite3Isdigit(z[i]) {
+		i++
+		nDigit++
+	}
+	iEnd = i
+
+	/* if decimal point is present */
+	if i < n && z[i] == '.' {
+		i++
+		eType++
+		/* copy digits from after decimal to significand
+		 ** (decrease exponent by d to shift decimal left) */
+		for i < n && sqlite3Isdigit(z[i]) {
+			i++
+			nDigit++
+		}
+		iEnd = i
+	}
+
+	/* if exponent is present */
+	if i < n && (z[i] == 'e' || z[i] == 'E') {
+		i++
+		eValid = false
+		eType++
+
+		/* get sign of exponent */
+		if i < n && (z[i] == '-' || z[i] == '+') {
+			i++
+		}
+		/* copy digits to exponent */
+		for i < n && sqlite3Isdigit(z[i]) {
+			i++
+			eValid = true
+		}
+		if eValid {
+			iEnd = i
+		}
+	}
+
+	/* skip trailing spaces */
+	for i < n && sqlite3Isspace(z[i]) {
+		i++
+	}
+
+	if nDigit > 0 {
+		/* A value out of range becomes an infinity or zero, as in C */
+		*pResult, _ = strconv.ParseFloat(string(z[iStart:iEnd]), 64)
+	}
+
+	/* return true if number and no extra non-whitespace characters after */
+	if i == n && nDigit > 0 && eValid && eType > 0 {
+		return eType
+	} else if eType >= 2 && (eType == 3 || eValid) && nDigit > 0 {
+		return -1
+	} else {
+		return 0
+	}
+}
+
+/*
+** Compare the 19-character string zNum against the text representation
+** value 2^63:  9223372036854775808.  Return negative, zero, or positive
+** if zNum is less than, equal to, or greater than the string.
+** Note that zNum must contain exactly 19 characters.
+**
+** Unlike memcmp() this routine is guaranteed to return the difference
+** in the values of the last digit if the only difference is in the
+** last digit.  So, for example,
+**
+**      compare2pow63("9223372036854775800", 1)
+**
+** will return -8.
+ */
+func compare2pow63(zNum []byte) int {
+	c := 0
+	/* 012345678901234567 */
+	pow63 := "922337203685477580"
+	for i := 0; c == 0 && i < 18; i++ {
+		c = (int(zNum[i]) - int(pow63[i])) * 10
+	}
+	if c == 0 {
+		c = int(zNum[18]) - '8'
+		testcase(c == (-1))
+		testcase(c == 0)
+		testcase(c == (+1))
+	}
+	return c
+}
+
+/*
+** Convert zNum to a 64-bit signed integer.  zNum must be decimal. This
+** routine does *not* accept hexadecimal notation.
+**
+** Returns:
+**
+**    -1    Not even a prefix of the input text looks like an integer
+**     0    Successful transformation.  Fits in a 64-bit signed integer.
+**     1    Excess non-space text after the integer value
+**     2    Integer too large for a 64-bit signed integer or is malformed
+**     3    Special case of 9223372036854775808
+**
+** length is the number of bytes in the string (bytes, not characters).
+** The string is not necessarily zero-terminated.  The encoding is
+** always UTF-8 in the Go port.
+ */
+func sqlite3Atoi64(zNum []byte, pNum *int64, length int) int {
+	var u uint64
+	neg := false /* assume positive */
+	var i, c int
+	var rc int /* Baseline return code */
+	var zStart int
+
+	if length > len(zNum) {
+		length = len(zNum)
+	}
+	zNum = zNum[:length]
+	z := 0
+	for z < len(zNum) && sqlite3Isspace(zNum[z]) {
+		z++
+	}
+	if z < len(zNum) {
+		if zNum[z] == '-' {
+			neg = true
+			z++
+		} else if zNum[z] == '+' {
+			z++
+		}
+	}
+	zStart = z
+	for z < len(zNum) && zNum[z] == '0' {
+		z++
+	} /* Skip leading zeros. */
+	for i = 0; z+i < len(zNum); i++ {
+		c = int(zNum[z+i])
+		if c < '0' || c > '9' {
+			break
+		}
+		u = u*10 + uint64(c) - '0'
+	}
+	testcase(i == 18)
+	testcase(i == 19)
+	testcase(i == 20)
+	if u > uint64(LARGEST_INT64) {
+		if neg {
+			*pNum = SMALLEST_INT64
+		} else {
+			*pNum = LARGEST_INT64
+		}
+	} else if neg {
+		*pNum = -int64(u)
+	} else {
+		*pNum = int64(u)
+	}
+	rc = 0
+	if i == 0 && zStart == z { /* No digits */
+		rc = -1
+	} else if z+i < len(zNum) { /* Extra bytes at the end */
+		for jj := z + i; jj < len(zNum); jj++ {
+			if !sqlite3Isspace(zNum[jj]) {
+				rc = 1 /* Extra non-space text after the integer */
+				break
+			}
+		}
+	}
+	if i < 19 {
+		/* Less than 19 digits, so we know that it fits in 64 bits */
+		assert(u <= uint64(LARGEST_INT64), "u <= LARGEST_INT64")
+		return rc
+	}
+	/* zNum is a 19-digit numbers.  Compare it against 9223372036854775808. */
+	if i > 19 {
+		c = 1
+	} else {
+		c = compare2pow63(zNum[z:])
+	}
+	if c < 0 {
+		/* zNum is less than 9223372036854775808 so it fits */
+		assert(u <= uint64(LARGEST_INT64), "u <= LARGEST_INT64")
+		return rc
+	}
+	if neg {
+		*pNum = SMALLEST_INT64
+	} else {
+		*pNum = LARGEST_INT64
+	}
+	if c > 0 {
+		/* zNum is greater than 9223372036854775808 so it overflows */
+		return 2
+	}
+	/* zNum is exactly 9223372036854775808.  Fits if negative.  The
+	 ** special case 2 overflow if positive */
+	assert(u-1 == uint64(LARGEST_INT64), "u-1 == LARGEST_INT64")
+	if neg {
+		return rc
+	}
+	return 3
+}
+
+/*
+** Transform a UTF-8 integer literal, in either decimal or hexadecimal,
+** into a 64-bit signed integer.  This routine accepts hexadecimal literals,
+** whereas sqlite3Atoi64() does not.
+**
+** Returns:
+**
+**     0    Successful transformation.  Fits in a 64-bit signed integer.
+**     1    Excess text after the integer value
+**     2    Integer too large for a 64-bit signed integer or is malformed
+**     3    Special case of 9223372036854775808
+ */
+func sqlite3DecOrHexToI64(z []byte, pOut *int64) int {
+	if charAt(z, 0) == '0' && (charAt(z, 1) == 'x' || charAt(z, 1) == 'X') {
+		var u uint64
+		var i, k int
+		for i = 2; charAt(z, i) == '0'; i++ {
+		}
+		for k = i; sqlite3Isxdigit(charAt(z, k)); k++ {
+			u = u*16 + uint64(sqlite3HexToInt(int(z[k])))
+		}
+		*pOut = int64(u)
+		if k-i > 16 {
+			return 2
+		}
+		if k < len(z) {
+			return 1
+		}
+		return 0
+	}
+	n := 0
+	for n < len(z) && strings.IndexByte("+- \n\t0123456789", z[n]) >= 0 {
+		n++
+	}
+	if n < len(z) {
+		n++
+	}
+	return sqlite3Atoi64(z, pOut, n)
+}
+
+/*
+** Convert a BLOB literal of the form "x'hhhhhh'" into its binary
+** value.  Return NULL if malformed.  The "x'" prefix and the "'" suffix
+** must already have been removed, so z is just the hex digits.  The
+** Go version checks the digits instead of assuming the tokenizer has.
+ */
+func sqlite3HexToBlob(db *sqlite3, z []byte) []byte {
+	if len(z)%2 != 0 {
+		return nil
+	}
+	zBlob := make([]byte, len(z)/2)
+	for i := 0; i < len(z); i += 2 {
+		if !sqlite3Isxdigit(z[i]) || !sqlite3Isxdigit(z[i+1]) {
+			return nil
+		}
+		zBlob[i/2] = sqlite3HexToInt(int(z[i]))<<4 | sqlite3HexToInt(int(z[i+1]))
+	}
+	return zBlob
+}
+
+/*
+** Return the declared type of a column.  Or return zDflt if the column
+** has no declared type.
+**
+** The column type is an extra string stored after the zero-terminator on
+** the column name if and only if the COLFLAG_HASTYPE flag is set.
+ */
+func sqlite3ColumnType(pCol *Column, zDflt []byte) []byte {
+	if (pCol.colFlags & COLFLAG_HASTYPE) != 0 {
+		z := pCol.zCnName[sqlite3Strlen30(pCol.zCnName)+1:]
+		return z[:sqlite3Strlen30(z)]
+	} else if pCol.eCType != 0 {
+		assert(pCol.eCType <= SQLITE_N_STDTYPE, "pCol.eCType <= SQLITE_N_STDTYPE")
+		return []byte(sqlite3StdType[pCol.eCType-1])
+	} else {
+		return zDflt
+	}
+}
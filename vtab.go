@@ -0,0 +1,62 @@
+/*
+** 2006 June 10
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains code used to help implement virtual tables.
+ */
+package internal
+
+/*
+** The parser calls this routine when it first sees a CREATE VIRTUAL TABLE
+** statement.  The module name has been parsed, but the optional list
+** of parameters that follow the module name are still pending.
+ */
+func sqlite3VtabBeginParse(
+	pParse *Parse, /* Parsing context */
+	pName1 *Token, /* Name of new table, or database name */
+	pName2 *Token, /* Name of new table or NULL */
+	pModuleName *Token, /* Name of the module for the virtual table */
+	ifNotExists int, /* No error if the table already exists */
+) {
+	if sqlite3IsOmitted(pParse, OmitVirtualTable, "CREATE VIRTUAL TABLE") {
+		return
+	}
+}
+
+/*
+** The parser calls this routine after the CREATE VIRTUAL TABLE statement
+** has been completely parsed.
+ */
+func sqlite3VtabFinishParse(pParse *Parse, pEnd *Token) {
+}
+
+/*
+** The parser calls this routine when it sees the first token
+** of an argument to the module name in a CREATE VIRTUAL TABLE statement.
+ */
+func sqlite3VtabArgInit(pParse *Parse) {
+	pParse.sArg.z = nil
+	pParse.sArg.n = 0
+}
+
+/*
+** The parser calls this routine for each token after the first token
+** in an argument to the module name in a CREATE VIRTUAL TABLE statement.
+ */
+func sqlite3VtabArgExtend(pParse *Parse, p *Token) {
+	pArg := &pParse.sArg
+	if pArg.z == nil {
+		pArg.z = p.z
+		pArg.n = p.n
+	} else {
+		assert(len(pArg.z) > len(p.z), "len(pArg.z) > len(p.z)")
+		pArg.n = uint(len(pArg.z)-len(p.z)) + p.n
+	}
+}
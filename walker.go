@@ -0,0 +1,275 @@
+/*
+** 2008 August 16
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** This file contains routines used for walking the parser tree for
+** an SQL statement.
+ */
+package internal
+
+/*
+** Walk all expressions linked into the list of Window objects passed
+** as the second argument.
+ */
+func walkWindowList(pWalker *Walker, pList *Window, bOneOnly bool) int {
+	var pWin *Window
+	for pWin = pList; pWin != nil; pWin = pWin.pNextWin {
+		var rc int
+		rc = sqlite3WalkExprList(pWalker, pWin.pOrderBy)
+		if rc != 0 {
+			return WRC_Abort
+		}
+		rc = sqlite3WalkExprList(pWalker, pWin.pPartition)
+		if rc != 0 {
+			return WRC_Abort
+		}
+		rc = sqlite3WalkExpr(pWalker, pWin.pFilter)
+		if rc != 0 {
+			return WRC_Abort
+		}
+		rc = sqlite3WalkExpr(pWalker, pWin.pStart)
+		if rc != 0 {
+			return WRC_Abort
+		}
+		rc = sqlite3WalkExpr(pWalker, pWin.pEnd)
+		if rc != 0 {
+			return WRC_Abort
+		}
+		if bOneOnly {
+			break
+		}
+	}
+	return WRC_Continue
+}
+
+/*
+** Walk an expression tree.  Invoke the callback once for each node
+** of the expression, while descending.  (In other words, the callback
+** is invoked before visiting children.)
+**
+** The return value from the callback should be one of the WRC_*
+** constants to specify how to proceed with the walk.
+**
+**    WRC_Continue      Continue descending down the tree.
+**
+**    WRC_Prune         Do not descend into child nodes, but allow
+**                      the walk to continue with sibling nodes.
+**
+**    WRC_Abort         Do no more callbacks.  Unwind the stack and
+**                      return from the top-level walk call.
+**
+** The return value from this routine is WRC_Abort to abandon the tree walk
+** and WRC_Continue to continue.
+ */
+func walkExpr(pWalker *Walker, pExpr *Expr) int {
+	var rc int
+	testcase(ExprHasProperty(pExpr, EP_TokenOnly))
+	testcase(ExprHasProperty(pExpr, EP_Reduced))
+	for {
+		rc = pWalker.xExprCallback(pWalker, pExpr)
+		if rc != 0 {
+			return rc & WRC_Abort
+		}
+		if !ExprHasProperty(pExpr, EP_TokenOnly|EP_Leaf) {
+			assert(pExpr.x.pList == nil || pExpr.pRight == nil, "pExpr.x.pList == nil || pExpr.pRight == nil")
+			if pExpr.pLeft != nil && walkExpr(pWalker, pExpr.pLeft) != 0 {
+				return WRC_Abort
+			}
+			if pExpr.pRight != nil {
+				assert(!ExprHasProperty(pExpr, EP_WinFunc), "!ExprHasProperty(pExpr, EP_WinFunc)")
+				pExpr = pExpr.pRight
+				continue
+			} else if ExprUseXSelect(pExpr) {
+				assert(!ExprHasProperty(pExpr, EP_WinFunc), "!ExprHasProperty(pExpr, EP_WinFunc)")
+				if sqlite3WalkSelect(pWalker, pExpr.x.pSelect) != 0 {
+					return WRC_Abort
+				}
+			} else {
+				if pExpr.x.pList != nil {
+					if sqlite3WalkExprList(pWalker, pExpr.x.pList) != 0 {
+						return WRC_Abort
+					}
+				}
+				if ExprHasProperty(pExpr, EP_WinFunc) {
+					if walkWindowList(pWalker, pExpr.y.pWin, true) != 0 {
+						return WRC_Abort
+					}
+				}
+			}
+		}
+		break
+	}
+	return WRC_Continue
+}
+func sqlite3WalkExpr(pWalker *Walker, pExpr *Expr) int {
+	if pExpr != nil {
+		return walkExpr(pWalker, pExpr)
+	}
+	return WRC_Continue
+}
+
+/*
+** Call sqlite3WalkExpr() for every expression in list p or until
+** an abort request is seen.
+ */
+func sqlite3WalkExprList(pWalker *Walker, p *ExprList) int {
+	if p != nil {
+		for i := 0; i < p.nExpr; i++ {
+			if sqlite3WalkExpr(pWalker, p.a[i].pExpr) != 0 {
+				return WRC_Abort
+			}
+		}
+	}
+	return WRC_Continue
+}
+
+/*
+** Walk all expressions associated with SELECT statement p.  Do
+** not invoke the SELECT callback on p, but do (of course) invoke
+** any expr callbacks and SELECT callbacks that come from subqueries.
+** Return WRC_Abort or WRC_Continue.
+**
+** The C version walks p->pWinDefn only for a few particular values of
+** xSelectCallback2.  Go cannot compare functions, so the walker sets
+** bWinDefn instead.
+ */
+func sqlite3WalkSelectExpr(pWalker *Walker, p *Select) int {
+	if sqlite3WalkExprList(pWalker, p.pEList) != 0 {
+		return WRC_Abort
+	}
+	if sqlite3WalkExpr(pWalker, p.pWhere) != 0 {
+		return WRC_Abort
+	}
+	if sqlite3WalkExprList(pWalker, p.pGroupBy) != 0 {
+		return WRC_Abort
+	}
+	if sqlite3WalkExpr(pWalker, p.pHaving) != 0 {
+		return WRC_Abort
+	}
+	if sqlite3WalkExprList(pWalker, p.pOrderBy) != 0 {
+		return WRC_Abort
+	}
+	if sqlite3WalkExpr(pWalker, p.pLimit) != 0 {
+		return WRC_Abort
+	}
+	if p.pWinDefn != nil && pWalker.bWinDefn {
+		/* The following may return WRC_Abort if there are unresolvable
+		 ** symbols (e.g. a table that does not exist) in a window definition. */
+		return walkWindowList(pWalker, p.pWinDefn, false)
+	}
+	return WRC_Continue
+}
+
+/*
+** Walk the parse trees associated with all subqueries in the
+** FROM clause of SELECT statement p.  Do not invoke the select
+** callback on p, but do invoke it on each FROM clause subquery
+** and on any subqueries further down in the tree.  Return
+** WRC_Abort or WRC_Continue;
+ */
+func sqlite3WalkSelectFrom(pWalker *Walker, p *Select) int {
+	pSrc := p.pSrc
+	if pSrc != nil {
+		for i := 0; i < pSrc.nSrc; i++ {
+			pItem := &pSrc.a[i]
+			if pItem.pSelect != nil && sqlite3WalkSelect(pWalker, pItem.pSelect) != 0 {
+				return WRC_Abort
+			}
+			if pItem.u1.pFuncArg != nil &&
+				sqlite3WalkExprList(pWalker, pItem.u1.pFuncArg) != 0 {
+				return WRC_Abort
+			}
+		}
+	}
+	return WRC_Continue
+}
+
+/*
+** Call sqlite3WalkExpr() for every expression in Select statement p.
+** Invoke sqlite3WalkSelect() for subqueries in the FROM clause and
+** on the compound select chain, p->pPrior.
+**
+** If it is not NULL, the xSelectCallback() callback is invoked before
+** the walk of the expressions and FROM clause. The xSelectCallback2()
+** method is invoked following the walk of the expressions and FROM clause,
+** but only if both xSelectCallback and xSelectCallback2 are both non-NULL
+** and if the expressions and FROM clause both return WRC_Continue;
+**
+** Return WRC_Continue under normal conditions.  Return WRC_Abort if
+** there is an abort request.
+**
+** If the Walker does not have an xSelectCallback() then this routine
+** is a no-op returning WRC_Continue.
+ */
+func sqlite3WalkSelect(pWalker *Walker, p *Select) int {
+	var rc int
+	if p == nil {
+		return WRC_Continue
+	}
+	if pWalker.xSelectCallback == nil {
+		return WRC_Continue
+	}
+	for {
+		rc = pWalker.xSelectCallback(pWalker, p)
+		if rc != 0 {
+			return rc & WRC_Abort
+		}
+		if sqlite3WalkSelectExpr(pWalker, p) != 0 ||
+			sqlite3WalkSelectFrom(pWalker, p) != 0 {
+			return WRC_Abort
+		}
+		if pWalker.xSelectCallback2 != nil {
+			pWalker.xSelectCallback2(pWalker, p)
+		}
+		p = p.pPrior
+		if p == nil {
+			break
+		}
+	}
+	return WRC_Continue
+}
+
+/* Increase the walkerDepth when entering a subquery, and
+** decrease when leaving the subquery.
+ */
+func sqlite3WalkerDepthIncrease(pWalker *Walker, pSelect *Select) int {
+	UNUSED_PARAMETER(pSelect)
+	pWalker.walkerDepth++
+	return WRC_Continue
+}
+func sqlite3WalkerDepthDecrease(pWalker *Walker, pSelect *Select) {
+	UNUSED_PARAMETER(pSelect)
+	pWalker.walkerDepth--
+}
+
+/*
+** No-op routine for the parse-tree walker.
+**
+** When this routine is the Walker.xExprCallback then expression trees
+** are walked without any actions being taken at each node.  Presumably,
+** when this routine is used for Walker.xExprCallback then
+** Walker.xSelectCallback is set to do something useful for every
+** subquery in the parser tree.
+ */
+func sqlite3ExprWalkNoop(NotUsed *Walker, NotUsed2 *Expr) int {
+	UNUSED_PARAMETER(NotUsed)
+	UNUSED_PARAMETER(NotUsed2)
+	return WRC_Continue
+}
+
+/*
+** No-op routine for the parse-tree walker for SELECT statements.
+** subquery in the parser tree.
+ */
+func sqlite3SelectWalkNoop(NotUsed *Walker, NotUsed2 *Select) int {
+	UNUSED_PARAMETER(NotUsed)
+	UNUSED_PARAMETER(NotUsed2)
+	return WRC_Continue
+}
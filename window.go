@@ -0,0 +1,458 @@
+/*
+** 2018 May 08
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+ */
+package internal
+
+/*
+** Return a deep copy of the Window object passed as the third argument.
+** The copy is attached to the expression pOwner (which may be NULL).
+ */
+func sqlite3WindowDup(db *sqlite3, pOwner *Expr, p *Window) *Window {
+	var pNew *Window
+	if p != nil {
+		pNew = &Window{}
+		pNew.zName = sqlite3DbStrDup(db, p.zName)
+		pNew.zBase = sqlite3DbStrDup(db, p.zBase)
+		pNew.pFilter = sqlite3ExprDup(db, p.pFilter, 0)
+		pNew.pWFunc = p.pWFunc
+		pNew.pPartition = sqlite3ExprListDup(db, p.pPartition, 0)
+		pNew.pOrderBy = sqlite3ExprListDup(db, p.pOrderBy, 0)
+		pNew.eFrmType = p.eFrmType
+		pNew.eEnd = p.eEnd
+		pNew.eStart = p.eStart
+		pNew.eExclude = p.eExclude
+		pNew.regResult = p.regResult
+		pNew.regAccum = p.regAccum
+		pNew.iArgCol = p.iArgCol
+		pNew.iEphCsr = p.iEphCsr
+		pNew.bExprArgs = p.bExprArgs
+		pNew.pStart = sqlite3ExprDup(db, p.pStart, 0)
+		pNew.pEnd = sqlite3ExprDup(db, p.pEnd, 0)
+		pNew.pOwner = pOwner
+		pNew.bImplicitFrame = p.bImplicitFrame
+	}
+	return pNew
+}
+
+/*
+** Return a copy of the linked list of Window objects passed as the
+** second argument.
+ */
+func sqlite3WindowListDup(db *sqlite3, p *Window) *Window {
+	var pRet *Window
+	pp := &pRet
+	for pWin := p; pWin != nil; pWin = pWin.pNextWin {
+		*pp = sqlite3WindowDup(db, nil, pWin)
+		pp = &(*pp).pNextWin
+	}
+	return pRet
+}
+
+/*
+** Clone returns a deep copy of the window p, but not of the windows that
+** follow it in a list.  The copy belongs to no expression.
+ */
+func (p *Window) Clone() *Window {
+	return sqlite3WindowDup(openDatabase(), nil, p)
+}
+
+/*
+** Search the linked list of named windows pList for a window named
+** zName.  If no such window is found, leave an error in pParse and
+** return NULL.
+ */
+func windowFind(pParse *Parse, pList *Window, zName []byte) *Window {
+	var p *Window
+	for p = pList; p != nil; p = p.pNextWin {
+		if sqlite3StrICmp(p.zName, zName) == 0 {
+			break
+		}
+	}
+	if p == nil {
+		sqlite3ErrorMsg(pParse, "no such window: %s", zName)
+	}
+	return p
+}
+
+/*
+** This function is called immediately after resolving the function name
+** for a window function within a SELECT statement. Argument pList is a
+** linked list of WINDOW definitions for the current SELECT statement.
+** Argument pFunc is the function definition just resolved and pWin
+** is the Window object representing the associated OVER clause. This
+** function updates the contents of pWin as follows:
+**
+**   * If the OVER clause referred to a named window (as in "max(x) OVER win"),
+**     search list pList for a matching WINDOW definition, and update pWin
+**     accordingly. If no such WINDOW clause can be found, leave an error
+**     in pParse.
+**
+**   * If the OVER clause refers to a named window that is itself
+**     modified ("OVER (win ORDER BY x)"), chain it to the named window.
+**
+** The C version also adjusts the frame of built-in window functions
+** such as row_number().  This port does not resolve function names, so
+** pFunc is recorded but not examined.
+ */
+func sqlite3WindowUpdate(
+	pParse *Parse,
+	pList *Window, /* List of named windows for this SELECT */
+	pWin *Window, /* Window frame to update */
+	pFunc *FuncDef, /* Window function definition */
+) {
+	if pWin.zName != nil && pWin.eFrmType == 0 {
+		p := windowFind(pParse, pList, pWin.zName)
+		if p == nil {
+			return
+		}
+		db := pParse.db
+		pWin.pPartition = sqlite3ExprListDup(db, p.pPartition, 0)
+		pWin.pOrderBy = sqlite3ExprListDup(db, p.pOrderBy, 0)
+		pWin.pStart = sqlite3ExprDup(db, p.pStart, 0)
+		pWin.pEnd = sqlite3ExprDup(db, p.pEnd, 0)
+		pWin.eStart = p.eStart
+		pWin.eEnd = p.eEnd
+		pWin.eFrmType = p.eFrmType
+		pWin.eExclude = p.eExclude
+	} else {
+		sqlite3WindowChain(pParse, pWin, pList)
+	}
+	if pWin.eFrmType == TK_RANGE &&
+		(pWin.pStart != nil || pWin.pEnd != nil) &&
+		(pWin.pOrderBy == nil || pWin.pOrderBy.nExpr != 1) {
+		sqlite3ErrorMsg(pParse,
+			"RANGE with offset PRECEDING/FOLLOWING requires one ORDER BY expression")
+	}
+	pWin.pWFunc = pFunc
+}
+
+/*
+** The argument expression is an PRECEDING or FOLLOWING offset.  The
+** value should be a non-negative integer.  If the value is not a
+** constant, change it to NULL.  The fact that it is then a non-negative
+** integer will be caught later.  But it is important not to leave
+** variable values in the expression tree.
+ */
+func sqlite3WindowOffsetExpr(pParse *Parse, pExpr *Expr) *Expr {
+	if sqlite3ExprIsConstant(pExpr) == 0 {
+		sqlite3ExprDelete(pParse.db, pExpr)
+		pExpr = sqlite3ExprAlloc(pParse.db, TK_NULL, nil, 0)
+	}
+	return pExpr
+}
+
+/*
+** Check that the PRECEDING or FOLLOWING offset pExpr of a frame of type
+** eFrmType is a non-negative integer, or a non-negative number for a
+** RANGE frame.  If it is not, leave an error in pParse.
+**
+** SQLite makes this check when the statement runs, in windowCheckValue().
+** Offsets are constant, so most values are known here.  An offset whose
+** value cannot be computed, such as a bound parameter, is accepted.
+ */
+func windowCheckOffset(pParse *Parse, pExpr *Expr, eFrmType uint8, bEnd bool) {
+	v, ok := foldValue(pExpr)
+	if !ok {
+		return
+	}
+	bValid := false
+	switch x := foldApplyNumericAffinity(v).(type) {
+	case int64:
+		bValid = x >= 0
+	case float64:
+		bValid = x >= 0.0 &&
+			(eFrmType == TK_RANGE || foldRealSameAsInt(x, foldDoubleToInt64(x)))
+	}
+	if !bValid {
+		zBound := "starting"
+		if bEnd {
+			zBound = "ending"
+		}
+		zType := "integer"
+		if eFrmType == TK_RANGE {
+			zType = "number"
+		}
+		sqlite3ErrorMsg(pParse, "frame %s offset must be a non-negative %s", zBound, zType)
+	}
+}
+
+/*
+** Allocate and return a new Window object describing a Window Definition.
+ */
+func sqlite3WindowAlloc(
+	pParse *Parse, /* Parsing context */
+	eType int, /* Frame type. TK_RANGE, TK_ROWS, TK_GROUPS, or 0 */
+	eStart int, /* Start type: CURRENT, PRECEDING, FOLLOWING, UNBOUNDED */
+	pStart *Expr, /* Start window size if TK_PRECEDING or FOLLOWING */
+	eEnd int, /* End type: CURRENT, FOLLOWING, TK_UNBOUNDED, PRECEDING */
+	pEnd *Expr, /* End window size if TK_FOLLOWING or PRECEDING */
+	eExclude uint8, /* EXCLUDE clause */
+) *Window {
+	var pWin *Window
+	bImplicitFrame := uint8(0)
+
+	/* Parser assures the following: */
+	assert(eType == 0 || eType == TK_RANGE || eType == TK_ROWS || eType == TK_GROUPS,
+		"eType == 0 || eType == TK_RANGE || eType == TK_ROWS || eType == TK_GROUPS")
+	assert(eStart == TK_CURRENT || eStart == TK_PRECEDING ||
+		eStart == TK_UNBOUNDED || eStart == TK_FOLLOWING,
+		"eStart == TK_CURRENT || eStart == TK_PRECEDING || eStart == TK_UNBOUNDED || eStart == TK_FOLLOWING")
+	assert(eEnd == TK_CURRENT || eEnd == TK_FOLLOWING ||
+		eEnd == TK_UNBOUNDED || eEnd == TK_PRECEDING,
+		"eEnd == TK_CURRENT || eEnd == TK_FOLLOWING || eEnd == TK_UNBOUNDED || eEnd == TK_PRECEDING")
+	assert((eStart == TK_PRECEDING || eStart == TK_FOLLOWING) == (pStart != nil),
+		"(eStart == TK_PRECEDING || eStart == TK_FOLLOWING) == (pStart != nil)")
+	assert((eEnd == TK_FOLLOWING || eEnd == TK_PRECEDING) == (pEnd != nil),
+		"(eEnd == TK_FOLLOWING || eEnd == TK_PRECEDING) == (pEnd != nil)")
+
+	if eType == 0 {
+		bImplicitFrame = 1
+		eType = TK_RANGE
+	}
+
+	/* Additionally, the
+	 ** starting boundary type may not occur earlier in the following list than
+	 ** the ending boundary type:
+	 **
+	 **   UNBOUNDED PRECEDING
+	 **   <expr> PRECEDING
+	 **   CURRENT ROW
+	 **   <expr> FOLLOWING
+	 **   UNBOUNDED FOLLOWING
+	 **
+	 ** The parser ensures that "UNBOUNDED PRECEDING" cannot be used as an ending
+	 ** boundary, and than "UNBOUNDED FOLLOWING" cannot be used as a starting
+	 ** frame boundary.
+	 */
+	if (eStart == TK_CURRENT && eEnd == TK_PRECEDING) ||
+		(eStart == TK_FOLLOWING && (eEnd == TK_PRECEDING || eEnd == TK_CURRENT)) {
+		sqlite3ErrorMsg(pParse, "unsupported frame specification")
+		sqlite3ExprDelete(pParse.db, pEnd)
+		sqlite3ExprDelete(pParse.db, pStart)
+		return nil
+	}
+
+	pWin = &Window{}
+	pWin.eFrmType = uint8(eType)
+	pWin.eStart = uint8(eStart)
+	pWin.eEnd = uint8(eEnd)
+	pWin.eExclude = eExclude
+	pWin.bImplicitFrame = bImplicitFrame
+	pWin.pEnd = sqlite3WindowOffsetExpr(pParse, pEnd)
+	pWin.pStart = sqlite3WindowOffsetExpr(pParse, pStart)
+	if pWin.pStart != nil {
+		windowCheckOffset(pParse, pWin.pStart, pWin.eFrmType, false)
+	}
+	if pWin.pEnd != nil {
+		windowCheckOffset(pParse, pWin.pEnd, pWin.eFrmType, true)
+	}
+	return pWin
+}
+
+/*
+** Attach PARTITION and ORDER BY clauses pPartition and pOrderBy to window
+** pWin. Also, if parameter pBase is not NULL, set pWin->zBase to the
+** equivalent nul-terminated string.
+ */
+func sqlite3WindowAssemble(
+	pParse *Parse,
+	pWin *Window,
+	pPartition *ExprList,
+	pOrderBy *ExprList,
+	pBase *Token,
+) *Window {
+	if pWin != nil {
+		pWin.pPartition = pPartition
+		pWin.pOrderBy = pOrderBy
+		if pBase != nil {
+			pWin.zBase = sqlite3DbStrNDup(pParse.db, pBase.z, pBase.n)
+		}
+	} else {
+		sqlite3ExprListDelete(pParse.db, pPartition)
+		sqlite3ExprListDelete(pParse.db, pOrderBy)
+	}
+	return pWin
+}
+
+/*
+** Window *pWin has just been created from a WINDOW clause. Token pBase
+** is the base window. Earlier windows from the same WINDOW clause are
+** stored in the linked list starting at pWin->pNextWin. This function
+** either updates *pWin according to the base specification, or else
+** leaves an error in pParse.
+ */
+func sqlite3WindowChain(pParse *Parse, pWin *Window, pList *Window) {
+	if pWin.zBase != nil {
+		db := pParse.db
+		pExist := windowFind(pParse, pList, pWin.zBase)
+		if pExist != nil {
+			zErr := ""
+			/* Check for errors */
+			if pWin.pPartition != nil {
+				zErr = "PARTITION clause"
+			} else if pExist.pOrderBy != nil && pWin.pOrderBy != nil {
+				zErr = "ORDER BY clause"
+			} else if pExist.bImplicitFrame == 0 {
+				zErr = "frame specification"
+			}
+			if zErr != "" {
+				sqlite3ErrorMsg(pParse,
+					"cannot override %s of window: %s", zErr, pWin.zBase)
+			} else {
+				pWin.pPartition = sqlite3ExprListDup(db, pExist.pPartition, 0)
+				if pExist.pOrderBy != nil {
+					assert(pWin.pOrderBy == nil, "pWin.pOrderBy == nil")
+					pWin.pOrderBy = sqlite3ExprListDup(db, pExist.pOrderBy, 0)
+				}
+				pWin.zBase = nil
+			}
+		}
+	}
+}
+
+/*
+** Attach window object pWin to expression p.
+ */
+func sqlite3WindowAttach(pParse *Parse, p *Expr, pWin *Window) {
+	if p != nil {
+		assert(p.op == TK_FUNCTION, "p.op == TK_FUNCTION")
+		assert(pWin != nil, "pWin != nil")
+		p.y.pWin = pWin
+		ExprSetProperty(p, EP_WinFunc)
+		pWin.pOwner = p
+		if (p.flags&EP_Distinct) != 0 && pWin.eFrmType != TK_FILTER {
+			sqlite3ErrorMsg(pParse,
+				"DISTINCT is not supported for window functions")
+		}
+	} else {
+		sqlite3WindowDelete(pParse.db, pWin)
+	}
+}
+
+/*
+** Link window pWin into the list at pSel->pWin (window functions to be
+** processed as part of SELECT statement pSel).
+**
+** Windows that use a compatible window frame can be processed in a
+** single pass, and are kept together in the list.  A window that cannot
+** share a pass with the windows at the head of the list is placed before
+** the first window it can share a pass with, or at the end of the list.
+** As in C, SF_MultiPart is set if such a window also has a different
+** PARTITION BY clause.
+ */
+func sqlite3WindowLink(pSel *Select, pWin *Window) {
+	if pSel != nil {
+		ppWin := &pSel.pWin
+		for *ppWin != nil && sqlite3WindowCompare(nil, *ppWin, pWin, false) != 0 {
+			ppWin = &(*ppWin).pNextWin
+		}
+		if ppWin != &pSel.pWin &&
+			sqlite3ExprListCompare(pWin.pPartition, pSel.pWin.pPartition, -1) != 0 {
+			pSel.selFlags |= SF_MultiPart
+		}
+		pWin.pNextWin = *ppWin
+		if *ppWin != nil {
+			(*ppWin).ppThis = &pWin.pNextWin
+		}
+		*ppWin = pWin
+		pWin.ppThis = ppWin
+	}
+}
+
+/*
+** Return 0 if the two window objects are identical, 1 if they are
+** different, or 2 if it cannot be determined if the objects are identical
+** or not. Identical window objects can be processed in a single scan.
+ */
+func sqlite3WindowCompare(pParse *Parse, p1 *Window, p2 *Window, bFilter bool) int {
+	var res int
+	if p1 == nil || p2 == nil {
+		return 1
+	}
+	if p1.eFrmType != p2.eFrmType {
+		return 1
+	}
+	if p1.eStart != p2.eStart {
+		return 1
+	}
+	if p1.eEnd != p2.eEnd {
+		return 1
+	}
+	if p1.eExclude != p2.eExclude {
+		return 1
+	}
+	if sqlite3ExprCompare(pParse, p1.pStart, p2.pStart, -1) != 0 {
+		return 1
+	}
+	if sqlite3ExprCompare(pParse, p1.pEnd, p2.pEnd, -1) != 0 {
+		return 1
+	}
+	if res = sqlite3ExprListCompare(p1.pPartition, p2.pPartition, -1); res != 0 {
+		return res
+	}
+	if res = sqlite3ExprListCompare(p1.pOrderBy, p2.pOrderBy, -1); res != 0 {
+		return res
+	}
+	if bFilter {
+		if res = sqlite3ExprCompare(pParse, p1.pFilter, p2.pFilter, -1); res != 0 {
+			return res
+		}
+	}
+	return 0
+}
+
+/*
+** Expression callback used by windowResolveSelect().  Update the window
+** of each window function found against the WINDOW clause of the
+** SELECT in pWalker->u.pSelect and link it into that SELECT.
+ */
+func windowResolveExprStep(pWalker *Walker, pExpr *Expr) int {
+	if pExpr.op == TK_FUNCTION && ExprHasProperty(pExpr, EP_WinFunc) {
+		pWin := pExpr.y.pWin
+		if pWin.eFrmType != TK_FILTER {
+			pSel := pWalker.u.pSelect
+			sqlite3WindowUpdate(pWalker.pParse, pSel.pWinDefn, pWin, nil)
+			if pWalker.pParse.nErr != 0 {
+				return WRC_Abort
+			}
+			sqlite3WindowLink(pSel, pWin)
+		}
+	}
+	return WRC_Continue
+}
+
+/*
+** Select callback that prevents windowResolveSelect() from entering a
+** subquery.
+ */
+func windowResolvePrune(NotUsed *Walker, NotUsed2 *Select) int {
+	UNUSED_PARAMETER(NotUsed)
+	UNUSED_PARAMETER(NotUsed2)
+	return WRC_Prune
+}
+
+/*
+** Resolve the window functions that belong to SELECT p itself.  This is
+** called by resolveSelectStep() for each SELECT, so subqueries are
+** skipped here.  Only pWalker->pParse is used.
+ */
+func windowResolveSelect(pWalker *Walker, p *Select) int {
+	var w Walker
+	w.pParse = pWalker.pParse
+	w.xExprCallback = windowResolveExprStep
+	w.xSelectCallback = windowResolvePrune
+	w.u.pSelect = p
+	if sqlite3WalkSelectExpr(&w, p) != 0 {
+		return WRC_Abort
+	}
+	return WRC_Continue
+}
@@ -0,0 +1,101 @@
+/*
+** 2026 October 19
+**
+** The author disclaims copyright to this source code.  In place of
+** a legal notice, here is a blessing:
+**
+**    May you do good and not evil.
+**    May you find forgiveness for yourself and forgive others.
+**    May you share freely, never taking more than you give.
+**
+*************************************************************************
+** Tests for building, resolving and linking windows.
+ */
+package internal
+
+import "testing"
+
+/*
+** Every window function of a SELECT must be linked into its pWin list,
+** with windows that can share a pass next to each other.  SF_MultiPart
+** is set only if the windows have different PARTITION BY clauses.
+ */
+func TestWindowLink(t *testing.T) {
+	aTest := []struct {
+		zSql       string
+		nWin       int
+		bMultiPart bool
+	}{
+		{"SELECT sum(a) OVER (PARTITION BY b), sum(a) OVER (PARTITION BY c), count(*) OVER (PARTITION BY b) FROM t", 3, true},
+		{"SELECT sum(a) OVER (ORDER BY b), rank() OVER (ORDER BY c) FROM t", 2, false},
+		{"SELECT sum(a) OVER w, max(a) OVER w FROM t WINDOW w AS (PARTITION BY b)", 2, false},
+		{"SELECT count(*) FILTER (WHERE a > 1) FROM t", 0, false},
+	}
+	for _, tc := range aTest {
+		p := testSelect(t, tc.zSql)
+		var apWin []*Window
+		for pWin := p.pWin; pWin != nil; pWin = pWin.pNextWin {
+			if *pWin.ppThis != pWin {
+				t.Errorf("%s: window %d has a bad ppThis", tc.zSql, len(apWin))
+			}
+			apWin = append(apWin, pWin)
+		}
+		if len(apWin) != tc.nWin {
+			t.Errorf("%s: %d windows linked, want %d", tc.zSql, len(apWin), tc.nWin)
+		}
+		for i := 0; i < len(apWin); i++ {
+			for j := i + 2; j < len(apWin); j++ {
+				if sqlite3WindowCompare(nil, apWin[i], apWin[j], false) == 0 &&
+					sqlite3WindowCompare(nil, apWin[i], apWin[j-1], false) != 0 {
+					t.Errorf("%s: windows %d and %d are not adjacent", tc.zSql, i, j)
+				}
+			}
+		}
+		if bMultiPart := (p.selFlags & SF_MultiPart) != 0; bMultiPart != tc.bMultiPart {
+			t.Errorf("%s: SF_MultiPart is %v, want %v", tc.zSql, bMultiPart, tc.bMultiPart)
+		}
+	}
+}
+
+/*
+** Window definitions that SQLite rejects must be reported with SQLite's
+** error messages.
+ */
+func TestWindowError(t *testing.T) {
+	aTest := []struct {
+		zSql string
+		zErr string
+	}{
+		{"SELECT sum(a) OVER w FROM t", "no such window: w"},
+		{"SELECT sum(a) OVER (w PARTITION BY b) FROM t WINDOW w AS (ORDER BY a)",
+			"cannot override PARTITION clause of window: w"},
+		{"SELECT sum(a) OVER (w ORDER BY b) FROM t WINDOW w AS (ORDER BY a)",
+			"cannot override ORDER BY clause of window: w"},
+		{"SELECT sum(a) OVER (w) FROM t WINDOW w AS (ROWS 1 PRECEDING)",
+			"cannot override frame specification of window: w"},
+		{"SELECT sum(a) OVER (RANGE 1 PRECEDING) FROM t",
+			"RANGE with offset PRECEDING/FOLLOWING requires one ORDER BY expression"},
+		{"SELECT sum(a) OVER (ORDER BY a, b RANGE 1 PRECEDING) FROM t",
+			"RANGE with offset PRECEDING/FOLLOWING requires one ORDER BY expression"},
+		{"SELECT sum(a) OVER (ROWS -1 PRECEDING) FROM t",
+			"frame starting offset must be a non-negative integer"},
+		{"SELECT sum(a) OVER (ROWS 1.5 PRECEDING) FROM t",
+			"frame starting offset must be a non-negative integer"},
+		{"SELECT sum(a) OVER (ROWS a PRECEDING) FROM t",
+			"frame starting offset must be a non-negative integer"},
+		{"SELECT sum(a) OVER (ORDER BY a RANGE 1 PRECEDING) FROM t", ""},
+		{"SELECT sum(a) OVER (w ROWS 1 PRECEDING) FROM t WINDOW w AS (ORDER BY a)", ""},
+	}
+	for _, tc := range aTest {
+		_, err := ParseSQL(tc.zSql, nil)
+		if tc.zErr == "" {
+			if err != nil {
+				t.Errorf("%s: %v", tc.zSql, err)
+			}
+			continue
+		}
+		if pErr, ok := err.(*Error); !ok || pErr.Msg != tc.zErr {
+			t.Errorf("%s: got %v, want %q", tc.zSql, err, tc.zErr)
+		}
+	}
+}